@@ -0,0 +1,207 @@
+package main
+
+import (
+	"backend/internal/config"
+	"backend/internal/database"
+	"backend/internal/delivery/http"
+	"backend/internal/entity"
+	"backend/internal/health"
+	"backend/internal/metrics"
+	"backend/internal/repository"
+	"backend/internal/tracing"
+	"backend/internal/usecase"
+	"backend/internal/worker"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+)
+
+// app is the HTTP server with every dependency wired up, and the workers to
+// run beside it
+type app struct {
+	echo      *echo.Echo
+	readiness *health.Readiness
+	workers   []interface{ Run(context.Context) }
+	// collectors report metrics read from the app's database
+	collectors []prometheus.Collector
+}
+
+// newApp wires the repositories, usecases and handlers to queries and
+// registers the routes. checks are the readiness checks of whatever backs
+// queries.
+func newApp(cfg *config.Config, logger *slog.Logger, queries database.CoinStatementQuerier, txManager database.TxManager, checks map[string]health.Check) (*app, error) {
+	e := echo.New()
+	e.HideBanner = true
+	e.HidePort = true
+
+	validator, err := http.NewValidator()
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up validation: %w", err)
+	}
+	e.Validator = validator
+	e.HTTPErrorHandler = http.ProblemErrorHandler
+
+	// Middleware
+	e.Use(otelecho.Middleware(tracing.ServiceName))
+	e.Use(http.RequestMetrics())
+	e.Use(http.RequestLogger(logger))
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins:     cfg.CORS.AllowOrigins,
+		AllowMethods:     []string{echo.GET, echo.POST, echo.PUT, echo.DELETE},
+		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowCredentials: true,
+	}))
+
+	// DI
+	authMiddleware := http.NewAuthMiddleware(cfg.Auth.JWTSecret)
+
+	userRepo := repository.NewUserRepository(queries, cfg.Coins.SignupBonus)
+	referralRepo := repository.NewReferralRepository(queries)
+	userUC := usecase.NewUserUseCase(userRepo, referralRepo, txManager)
+
+	productRepo := repository.NewProductRepository(queries)
+	productUC := usecase.NewProductUseCase(productRepo)
+
+	cartRepo := repository.NewCartRepository(queries)
+	cartUC := usecase.NewCartUseCase(cartRepo)
+
+	categoryRepo := repository.NewCategoryRepository(queries)
+	categoryUC := usecase.NewCategoryUseCase(categoryRepo)
+
+	coinPackRepo := repository.NewCoinPackRepository(queries)
+	coinPackUC := usecase.NewCoinPackUseCase(coinPackRepo)
+
+	coinExpiryPolicy := newCoinExpiryPolicy(cfg)
+
+	spendLimitPolicy := entity.SpendLimitPolicy{
+		DailyLimit:          cfg.SpendLimits.DailyLimit,
+		PerTransactionLimit: cfg.SpendLimits.PerTransactionLimit,
+		VelocityMaxSpends:   cfg.SpendLimits.VelocityMaxSpends,
+		VelocityWindow:      cfg.SpendLimits.VelocityWindow,
+		BlockDuration:       cfg.SpendLimits.BlockDuration,
+	}
+
+	spendLimitRepo := repository.NewSpendLimitRepository(queries, txManager)
+	spendLimitUC := usecase.NewSpendLimitUseCase(spendLimitRepo, spendLimitPolicy)
+
+	coinTransactionRepo := repository.NewCoinTransactionRepository(queries, txManager, coinExpiryPolicy)
+	coinHoldRepo := repository.NewCoinHoldRepository(queries, txManager)
+	coinTransactionUC := usecase.NewCoinTransactionUseCase(coinTransactionRepo, coinPackRepo, coinHoldRepo, spendLimitRepo, txManager, spendLimitPolicy, cfg.Coins.HoldTTL)
+
+	cashbackPolicy := entity.CashbackPolicy{
+		DefaultRatePercent: cfg.Cashback.DefaultRatePercent,
+	}
+
+	orderRepo := repository.NewOrderRepository(queries)
+	cashbackRateRepo := repository.NewCashbackRateRepository(queries)
+	cashbackUC := usecase.NewCashbackUseCase(coinTransactionRepo, orderRepo, cashbackRateRepo, txManager, cashbackPolicy)
+
+	referralPolicy := entity.ReferralPolicy{
+		ReferrerBonus: cfg.Referral.ReferrerBonus,
+		RefereeBonus:  cfg.Referral.RefereeBonus,
+	}
+
+	referralUC := usecase.NewReferralUseCase(coinTransactionRepo, referralRepo, txManager, referralPolicy)
+
+	orderUC := usecase.NewOrderUseCase(orderRepo, txManager, coinTransactionUC, cashbackUC, referralUC)
+
+	giftCodePolicy := entity.GiftCodePolicy{
+		MaxFailedAttempts: cfg.GiftCodes.MaxFailedAttempts,
+		FailureWindow:     cfg.GiftCodes.FailureWindow,
+	}
+
+	giftCodeRepo := repository.NewGiftCodeRepository(queries, txManager)
+	giftCodeUC := usecase.NewGiftCodeUseCase(giftCodeRepo, coinTransactionRepo, txManager, giftCodePolicy)
+
+	userHandler := http.NewUserHandler(userUC, cfg.Auth.JWTSecret, cfg.Auth.JWTTTL)
+	productHandler := http.NewProductHandler(productUC)
+	cartHandler := http.NewCartHandler(cartUC)
+	categoryHandler := http.NewCategoryHandler(categoryUC)
+	coinTransactionHandler := http.NewCoinTransactionHandler(coinTransactionUC)
+	coinPackHandler := http.NewCoinPackHandler(coinPackUC)
+	spendLimitHandler := http.NewSpendLimitHandler(spendLimitUC)
+	orderHandler := http.NewOrderHandler(orderUC)
+	cashbackHandler := http.NewCashbackHandler(cashbackUC)
+	giftCodeHandler := http.NewGiftCodeHandler(giftCodeUC)
+	referralHandler := http.NewReferralHandler(referralUC)
+	coinExpiryWorker := worker.NewCoinExpiryWorker(coinTransactionUC, time.Hour)
+	coinHoldExpiryWorker := worker.NewCoinHoldExpiryWorker(coinTransactionUC, time.Minute)
+	adminMiddleware := http.NewAdminMiddleware(userUC)
+
+	readiness := health.NewReadiness(checks, 2*time.Second)
+	healthHandler := http.NewHealthHandler(readiness)
+
+	// Route groupin
+	healthHandler.RegisterRoutes(e)
+
+	api := e.Group("/api")
+
+	// Public endpoints
+	public := api.Group("")
+	public.POST("/signup", userHandler.SignUp)
+	public.POST("/login", userHandler.Login)
+	productHandler.RegisterRoutes(api)
+	categoryHandler.RegisterRoutes(api)
+	coinPackHandler.RegisterRoutes(api)
+
+	// Protected endpoints
+	protected := api.Group("")
+	protected.Use(authMiddleware.Middleware)
+	protected.GET("/users/:id", userHandler.GetUserById)
+	protected.PATCH("/users/:id/name", userHandler.UpdateUserName)
+	protected.PATCH("/users/:id/email", userHandler.UpdateUserEmail)
+	protected.PATCH("/users/:id/password", userHandler.ChangePassword)
+	protected.DELETE("/users/:id", userHandler.DeleteUser)
+
+	protected.POST("/coins/charge", coinTransactionHandler.ChargeUserCoins)
+	protected.POST("/coins/spend", coinTransactionHandler.SpendUserCoins)
+	protected.GET("/coins/balance", coinTransactionHandler.GetCoinBalance)
+	protected.GET("/coins/balance/series", coinTransactionHandler.GetBalanceSeries)
+	protected.GET("/coins/statement", coinTransactionHandler.GetStatement)
+	protected.GET("/coins/transactions", coinTransactionHandler.GetUserTransactions)
+	protected.GET("/coins/transactions/:id", coinTransactionHandler.GetTransactionByID)
+	protected.POST("/coins/holds", coinTransactionHandler.HoldUserCoins)
+	protected.GET("/coins/holds", coinTransactionHandler.GetUserHolds)
+	protected.POST("/coins/holds/:id/capture", coinTransactionHandler.CaptureHold)
+	protected.POST("/coins/holds/:id/release", coinTransactionHandler.ReleaseHold)
+
+	giftCodeHandler.RegisterRoutes(protected)
+	referralHandler.RegisterRoutes(protected)
+	cartHandler.RegisterRoutes(protected)
+
+	// Admin endpoints
+	admin := protected.Group("/admin")
+	admin.Use(adminMiddleware.Middleware)
+	coinPackHandler.RegisterAdminRoutes(admin)
+	spendLimitHandler.RegisterAdminRoutes(admin)
+	orderHandler.RegisterAdminRoutes(admin)
+	cashbackHandler.RegisterAdminRoutes(admin)
+	giftCodeHandler.RegisterAdminRoutes(admin)
+
+	e.Server.ReadHeaderTimeout = cfg.Server.ReadHeaderTimeout
+	e.Server.ReadTimeout = cfg.Server.ReadTimeout
+	e.Server.WriteTimeout = cfg.Server.WriteTimeout
+	e.Server.IdleTimeout = cfg.Server.IdleTimeout
+
+	return &app{
+		echo:       e,
+		readiness:  readiness,
+		workers:    []interface{ Run(context.Context) }{coinExpiryWorker, coinHoldExpiryWorker},
+		collectors: []prometheus.Collector{metrics.NewOrderCollector(orderRepo.CountOrdersByStatus)},
+	}, nil
+}
+
+// newCoinExpiryPolicy is the expiry the service applies to granted coins,
+// which seeded lots follow too
+func newCoinExpiryPolicy(cfg *config.Config) entity.CoinExpiryPolicy {
+	return entity.CoinExpiryPolicy{
+		ChargeMonths: cfg.Coins.ExpiryMonths,
+		BonusMonths:  cfg.Coins.BonusExpiryMonths,
+	}
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"backend/internal/config"
+	"backend/internal/database"
+	"backend/internal/database/memdb"
+	"backend/seed"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testApp serves the whole API from db, as the demo mode does, configured by
+// env on top of the defaults
+func testApp(t *testing.T, db *memdb.Queries, env ...string) *app {
+	t.Helper()
+
+	vars := map[string]string{"DATABASE_DEMO": "true", "JWT_SECRET": "jwt-secret"}
+	for i := 0; i+1 < len(env); i += 2 {
+		vars[env[i]] = env[i+1]
+	}
+	cfg, err := config.Load("", func(key string) (string, bool) {
+		value, ok := vars[key]
+		return value, ok
+	})
+	require.NoError(t, err)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	a, err := newApp(cfg, logger, db, database.NewTxManager(db), nil)
+	require.NoError(t, err)
+	return a
+}
+
+// call sends a JSON request and decodes the JSON response into a map
+func call(t *testing.T, a *app, method, path, token string, body any) (int, map[string]any) {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(payload)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	a.echo.ServeHTTP(rec, req)
+
+	var out map[string]any
+	if rec.Body.Len() > 0 && rec.Body.Bytes()[0] == '{' {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+	}
+	return rec.Code, out
+}
+
+func login(t *testing.T, a *app, email, password string) string {
+	t.Helper()
+
+	status, body := call(t, a, http.MethodPost, "/api/login", "", map[string]string{"email": email, "password": password})
+	require.Equal(t, http.StatusOK, status, body)
+	return body["token"].(string)
+}
+
+func TestApp_SignUpChargeAndSpend(t *testing.T) {
+	db := memdb.New()
+	_, err := db.InsertCoinPack(context.Background(), database.CoinPack{
+		Name:       "5000 + 500 Bonus",
+		BaseCoins:  5000,
+		BonusCoins: 500,
+		Price:      pgtype.Numeric{Int: big.NewInt(4999), Exp: -2, Valid: true},
+		IsActive:   true,
+	})
+	require.NoError(t, err)
+	a := testApp(t, db)
+
+	status, body := call(t, a, http.MethodPost, "/api/signup", "", map[string]string{
+		"name": "Alice", "email": "alice@example.com", "password": "password123",
+	})
+	require.Equal(t, http.StatusCreated, status, body)
+	assert.Equal(t, 1000.0, body["user"].(map[string]any)["coins"])
+
+	status, _ = call(t, a, http.MethodPost, "/api/signup", "", map[string]string{
+		"name": "Alice", "email": "alice@example.com", "password": "password123",
+	})
+	assert.Equal(t, http.StatusConflict, status)
+
+	status, _ = call(t, a, http.MethodPost, "/api/login", "", map[string]string{"email": "nobody@example.com", "password": "password123"})
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	token := login(t, a, "alice@example.com", "password123")
+
+	status, body = call(t, a, http.MethodPost, "/api/coins/charge", token, map[string]int{"pack_id": 1})
+	require.Equal(t, http.StatusOK, status, body)
+	assert.Equal(t, 6500.0, body["user"].(map[string]any)["coins"])
+	assert.Len(t, body["transactions"], 2)
+
+	status, body = call(t, a, http.MethodPost, "/api/coins/spend", token, map[string]any{"amount": 1500, "description": "Order"})
+	require.Equal(t, http.StatusOK, status, body)
+	assert.Equal(t, 5000.0, body["user"].(map[string]any)["coins"])
+
+	status, _ = call(t, a, http.MethodPost, "/api/coins/spend", token, map[string]any{"amount": 5001, "description": "Order"})
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, body = call(t, a, http.MethodGet, "/api/coins/transactions", token, nil)
+	require.Equal(t, http.StatusOK, status, body)
+	transactions := body["transactions"].([]any)
+	require.Len(t, transactions, 4)
+	// The sign-up bonus is the first entry in the ledger
+	assert.Equal(t, "bonus", transactions[3].(map[string]any)["transaction_type"])
+	assert.Equal(t, 1000.0, transactions[3].(map[string]any)["balance_after"])
+}
+
+func TestApp_SignUpWithReferralCode(t *testing.T) {
+	a := testApp(t, memdb.New())
+
+	status, body := call(t, a, http.MethodPost, "/api/signup", "", map[string]string{
+		"name": "Alice", "email": "alice@example.com", "password": "password123",
+	})
+	require.Equal(t, http.StatusCreated, status, body)
+	code := body["user"].(map[string]any)["referral_code"].(string)
+
+	status, _ = call(t, a, http.MethodPost, "/api/signup", "", map[string]string{
+		"name": "Bob", "email": "bob@example.com", "password": "password123", "referral_code": "NOSUCH23",
+	})
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	for _, email := range []string{"bob@example.com", "alice+2@example.com"} {
+		status, body = call(t, a, http.MethodPost, "/api/signup", "", map[string]string{
+			"name": "Friend", "email": email, "password": "password123", "referral_code": code,
+		})
+		require.Equal(t, http.StatusCreated, status, body)
+	}
+
+	token := login(t, a, "alice@example.com", "password123")
+	status, body = call(t, a, http.MethodGet, "/api/referrals", token, nil)
+	require.Equal(t, http.StatusOK, status, body)
+
+	statuses := make(map[string]int)
+	for _, referral := range body["referrals"].([]any) {
+		statuses[referral.(map[string]any)["status"].(string)]++
+	}
+	// Alice's second address is recognised as her own
+	assert.Equal(t, map[string]int{"pending": 1, "rejected": 1}, statuses)
+}
+
+func TestApp_ConcurrentSpendsStayWithinDailyLimit(t *testing.T) {
+	a := testApp(t, memdb.New(), "SPEND_DAILY_LIMIT", "500")
+
+	status, body := call(t, a, http.MethodPost, "/api/signup", "", map[string]string{
+		"name": "Alice", "email": "alice@example.com", "password": "password123",
+	})
+	require.Equal(t, http.StatusCreated, status, body)
+	token := login(t, a, "alice@example.com", "password123")
+
+	// Each spend fits the limit on its own; together only five do
+	var wg sync.WaitGroup
+	statuses := make([]int, 10)
+	for i := range statuses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i], _ = call(t, a, http.MethodPost, "/api/coins/spend", token, map[string]any{"amount": 100, "description": "Order"})
+		}()
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, status := range statuses {
+		if status == http.StatusOK {
+			succeeded++
+		}
+	}
+	assert.Equal(t, 5, succeeded)
+
+	status, body = call(t, a, http.MethodGet, "/api/coins/balance", token, nil)
+	require.Equal(t, http.StatusOK, status, body)
+	assert.Equal(t, 500.0, body["coins"], body)
+}
+
+func TestApp_ConcurrentGiftCodeFailuresStayWithinLimit(t *testing.T) {
+	a := testApp(t, memdb.New(), "GIFT_CODE_MAX_FAILED_ATTEMPTS", "3")
+
+	status, body := call(t, a, http.MethodPost, "/api/signup", "", map[string]string{
+		"name": "Alice", "email": "alice@example.com", "password": "password123",
+	})
+	require.Equal(t, http.StatusCreated, status, body)
+	token := login(t, a, "alice@example.com", "password123")
+
+	// Every attempt is counted before the next one is checked
+	var wg sync.WaitGroup
+	statuses := make([]int, 10)
+	for i := range statuses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i], _ = call(t, a, http.MethodPost, "/api/coins/redeem", token, map[string]string{"code": "AAAA-BBBB-CCCC-DDDD"})
+		}()
+	}
+	wg.Wait()
+
+	counts := make(map[int]int)
+	for _, status := range statuses {
+		counts[status]++
+	}
+	assert.Equal(t, map[int]int{http.StatusBadRequest: 3, http.StatusTooManyRequests: 7}, counts)
+}
+
+func TestApp_BalanceSeriesAcceptsOffsetTimestamps(t *testing.T) {
+	a := testApp(t, memdb.New())
+
+	status, body := call(t, a, http.MethodPost, "/api/signup", "", map[string]string{
+		"name": "Alice", "email": "alice@example.com", "password": "password123",
+	})
+	require.Equal(t, http.StatusCreated, status, body)
+	token := login(t, a, "alice@example.com", "password123")
+
+	query := url.Values{"from": {"2026-03-01T20:00:00-05:00"}, "to": {"2026-03-05T00:00:00Z"}, "tz": {"Asia/Tokyo"}}
+	status, body = call(t, a, http.MethodGet, "/api/coins/balance/series?"+query.Encode(), token, nil)
+	require.Equal(t, http.StatusOK, status, body)
+	assert.Len(t, body["points"], 4)
+}
+
+func TestApp_ListsProducts(t *testing.T) {
+	ctx := context.Background()
+	db := memdb.New()
+	category, err := db.InsertCategory(ctx, database.Category{Name: "Books"})
+	require.NoError(t, err)
+	_, err = db.InsertProduct(ctx, database.Product{
+		CategoryID:    category.ID,
+		Name:          "Go in Practice",
+		Price:         pgtype.Numeric{Int: big.NewInt(2999), Exp: -2, Valid: true},
+		StockQuantity: 3,
+	})
+	require.NoError(t, err)
+	a := testApp(t, db)
+
+	rec := httptest.NewRecorder()
+	a.echo.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/products", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var products []map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &products))
+	require.Len(t, products, 1)
+	assert.Equal(t, "Go in Practice", products[0]["name"])
+	assert.Equal(t, 29.99, products[0]["price"])
+}
+
+func TestOpenDemo(t *testing.T) {
+	if testing.Short() {
+		t.Skip("generates the full demo dataset")
+	}
+
+	cfg := config.Default()
+	db, err := openDemo(context.Background(), &cfg)
+	require.NoError(t, err)
+	a := testApp(t, db)
+
+	token := login(t, a, "admin@example.com", seed.Password)
+	status, body := call(t, a, http.MethodGet, "/api/admin/orders/1", token, nil)
+	assert.Equal(t, http.StatusOK, status, body)
+
+	status, body = call(t, a, http.MethodGet, "/api/coins/packs", "", nil)
+	require.Equal(t, http.StatusOK, status, body)
+	assert.Len(t, body["packs"], len(seed.DefaultCoinPacks))
+}
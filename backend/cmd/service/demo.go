@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"backend/internal/config"
+	"backend/internal/database/memdb"
+	"backend/seed"
+)
+
+// openDemo returns an in-memory database holding what the seed command
+// generates by default, so the service can run without Postgres
+func openDemo(ctx context.Context, cfg *config.Config) (*memdb.Queries, error) {
+	opts, err := parseSeedArgs(nil, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	opts.Expiry = newCoinExpiryPolicy(cfg)
+
+	data, err := seed.Generate(opts)
+	if err != nil {
+		return nil, err
+	}
+	db := memdb.New()
+	if err := seed.LoadMemory(ctx, db, data); err != nil {
+		return nil, fmt.Errorf("failed to load demo data: %w", err)
+	}
+
+	slog.Warn("serving demo data from memory; every change is lost on exit",
+		"admin", "admin@example.com",
+		"password", seed.Password,
+		"users", len(data.Users),
+		"products", len(data.Products),
+	)
+	return db, nil
+}
@@ -1,113 +1,207 @@
 package main
 
 import (
+	"backend/internal/config"
 	"backend/internal/database"
-	"backend/internal/delivery/http"
-	"backend/internal/repository"
-	"backend/internal/usecase"
+	"backend/internal/health"
+	"backend/internal/logging"
+	"backend/internal/metrics"
+	"backend/internal/tracing"
+	"backend/migrations"
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
+	nethttp "net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	_ "time/tzdata" // statement timezones must resolve even without a system zoneinfo
 
-	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
-	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
 )
 
-type CustomValidator struct {
-	validator *validator.Validate
-}
-
-func (cv *CustomValidator) Validate(i interface{}) error {
-	return cv.validator.Struct(i)
-}
-
 func main() {
 	_ = godotenv.Load()
 
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		log.Fatal("JWT_SECRET is not set")
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "YAML config file; environment variables override it")
+	printConfig := flag.Bool("print-config", false, "print the effective config with secrets redacted and exit")
+	demo := flag.Bool("demo", false, "serve generated demo data from memory; no database is needed")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: service [flags] [migrate <command> | seed [flags]]\n\nflags:\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	lookupEnv := os.LookupEnv
+	if *demo {
+		lookupEnv = func(key string) (string, bool) {
+			if key == "DATABASE_DEMO" {
+				return "true", true
+			}
+			return os.LookupEnv(key)
+		}
 	}
 
-	ctx := context.Background()
-
-	dbService, err := database.NewService(ctx, os.Getenv("DATABASE_URL"))
+	cfg, err := config.Load(*configPath, lookupEnv)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		log.Fatalf("invalid configuration:\n%v", err)
 	}
-	defer dbService.Close()
-
-	queries := dbService.Queries()
-	db := dbService.DB()
-
-	e := echo.New()
-
-	e.Validator = &CustomValidator{validator: validator.New()}
-
-	// Middleware
-	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins:     []string{"http://localhost:5173"},
-		AllowMethods:     []string{echo.GET, echo.POST, echo.DELETE},
-		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
-		AllowCredentials: true,
-	}))
-
-	// DI
-	authMiddleware := http.NewAuthMiddleware(jwtSecret)
-
-	userRepo := repository.NewUserRepository(queries, db)
-	userUC := usecase.NewUserUseCase(userRepo)
-
-	productRepo := repository.NewProductRepository(queries)
-	productUC := usecase.NewProductUseCase(productRepo)
-
-	cartRepo := repository.NewCartRepository(queries)
-	cartUC := usecase.NewCartUseCase(cartRepo)
-
-	categoryRepo := repository.NewCategoryRepository(queries)
-	categoryUC := usecase.NewCategoryUseCase(categoryRepo)
-
-	coinTransactionRepo := repository.NewCoinTransactionRepository(queries, db)
-	coinTransactionUC := usecase.NewCoinTransactionUseCase(coinTransactionRepo)
-
-	userHandler := http.NewUserHandler(userUC, jwtSecret)
-	productHandler := http.NewProductHandler(productUC)
-	cartHandler := http.NewCartHandler(cartUC)
-	categoryHandler := http.NewCategoryHandler(categoryUC)
-	coinTransactionHandler := http.NewCoinTransactionHandler(coinTransactionUC)
-
-	// Route groupin
 
-	api := e.Group("/api")
+	if *printConfig {
+		out, err := cfg.Redacted().YAML()
+		if err != nil {
+			log.Fatal(err)
+		}
+		os.Stdout.Write(out)
+		return
+	}
 
-	// Public endpoints
-	public := api.Group("")
-	public.POST("/signup", userHandler.SignUp)
-	public.POST("/login", userHandler.Login)
-	productHandler.RegisterRoutes(api)
-	categoryHandler.RegisterRoutes(api)
+	logger, err := logging.New(os.Stdout, cfg.Log.Level)
+	if err != nil {
+		log.Fatal(err)
+	}
+	slog.SetDefault(logger)
+
+	// Exit only once the command has returned, so its deferred cleanup has happened
+	switch command := flag.Arg(0); command {
+	case "":
+		err = run(cfg, logger)
+	case "migrate":
+		err = runMigrate(cfg, flag.Args()[1:])
+	case "seed":
+		err = runSeed(cfg, flag.Args()[1:])
+	default:
+		flag.Usage()
+		err = fmt.Errorf("unknown command %q", command)
+	}
+	if errors.Is(err, errMigrateUsage) {
+		fmt.Fprintf(os.Stderr, "%v\n\n%s\n", err, migrateUsage)
+		os.Exit(2)
+	}
+	if errors.Is(err, errSeedUsage) {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if err != nil {
+		slog.Error("service stopped", "error", err)
+		os.Exit(1)
+	}
+}
 
-	// Protected endpoints
-	protected := api.Group("")
-	protected.Use(authMiddleware.Middleware)
-	protected.GET("/users/:id", userHandler.GetUserById)
-	protected.PATCH("/users/:id/name", userHandler.UpdateUserName)
-	protected.PATCH("/users/:id/email", userHandler.UpdateUserEmail)
-	protected.PATCH("/users/:id/password", userHandler.ChangePassword)
-	protected.PATCH("/users/:id/coins", userHandler.UpdateUserCoins)
-	protected.DELETE("/users/:id", userHandler.DeleteUser)
+func run(cfg *config.Config, logger *slog.Logger) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	protected.POST("/coins/charge", coinTransactionHandler.ChargeUserCoins)
-	protected.POST("/coins/spend", coinTransactionHandler.SpendUserCoins)
-	protected.GET("/coins//transactions", coinTransactionHandler.GetUserTransactions)
-	protected.GET("/coins//transactions/:id", coinTransactionHandler.GetTransactionByID)
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing.Exporter, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %w", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Error("failed to flush traces", "error", err)
+		}
+	}()
+
+	var (
+		queries   database.CoinStatementQuerier
+		txManager database.TxManager
+		pool      *pgxpool.Pool
+		checks    map[string]health.Check
+		closeDB   = func() {}
+	)
+	if cfg.Database.Demo {
+		mem, err := openDemo(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		queries, txManager = mem, database.NewTxManager(mem)
+	} else {
+		if cfg.Database.AutoMigrate {
+			if err := autoMigrate(cfg.Database.URL); err != nil {
+				return err
+			}
+		}
+
+		dbService, err := database.NewService(ctx, cfg.Database.URL)
+		if err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		// Closes the pool on the returns before shutdown; closing it twice is harmless
+		defer dbService.Close()
+		closeDB = dbService.Close
+
+		schemaVersion, err := migrations.LatestVersion()
+		if err != nil {
+			return fmt.Errorf("failed to read migrations: %w", err)
+		}
+		queries, txManager, pool = dbService.Queries(), dbService.TxManager(), dbService.DB()
+		checks = map[string]health.Check{
+			"database":   health.PingCheck(dbService.Ping),
+			"migrations": health.MigrationCheck(dbService.MigrationVersion, schemaVersion),
+			"pool":       health.PoolCheck(pool),
+		}
+	}
 
-	cartHandler.RegisterRoutes(protected)
+	app, err := newApp(cfg, logger, queries, txManager, checks)
+	if err != nil {
+		return err
+	}
+	e, readiness := app.echo, app.readiness
+
+	// Metrics are served on their own address so they stay off the public API
+	metricsServer := metrics.NewServer(cfg.Metrics.Addr, metrics.NewRegistry(pool, app.collectors...))
+	go func() {
+		slog.Info("metrics server running", "addr", cfg.Metrics.Addr)
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+			slog.Error("metrics server stopped", "error", err)
+		}
+	}()
+
+	// Workers get their own context so shutdown can stop them only after the
+	// server has drained
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	for _, w := range app.workers {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			w.Run(workerCtx)
+		}()
+	}
 
-	log.Println("Server running on :8080")
-	if err := e.Start(":8080"); err != nil {
-		log.Fatal(err)
+	serverErr := make(chan error, 1)
+	go func() {
+		slog.Info("server running", "addr", cfg.Server.Addr)
+		serverErr <- e.Start(cfg.Server.Addr)
+	}()
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		slog.Info("shutting down")
+	case err := <-serverErr:
+		runErr = fmt.Errorf("server stopped: %w", err)
 	}
+
+	shutdown{
+		readiness:     readiness,
+		delay:         cfg.Server.ShutdownDelay,
+		timeout:       cfg.Server.ShutdownTimeout,
+		server:        e,
+		metricsServer: metricsServer,
+		stopWorkers: func() {
+			stopWorkers()
+			workers.Wait()
+		},
+		closeDB: closeDB,
+	}.run()
+
+	// Traces are flushed as deferred, once everything that records them has stopped
+	slog.Info("shutdown complete")
+	return runErr
 }
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"backend/internal/config"
+	"backend/migrations"
+)
+
+const migrateUsage = `usage: service migrate <command>
+
+commands:
+  up            apply every pending migration
+  down [N]      roll back the last N migrations (default 1)
+  status        show the current version and pending migrations
+  goto V        migrate up or down to version V
+  force V       mark version V as applied and clean without running it`
+
+var errMigrateUsage = errors.New("invalid migrate command")
+
+// runMigrate runs the migrate subcommand
+func runMigrate(cfg *config.Config, args []string) error {
+	if cfg.Database.Demo {
+		return errors.New("migrate needs a database and cannot run in demo mode")
+	}
+
+	action, err := parseMigrateArgs(args)
+	if err != nil {
+		return err
+	}
+
+	migrator, err := migrations.NewMigrator(cfg.Database.URL)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	return action(migrator)
+}
+
+// parseMigrateArgs checks the arguments before anything connects
+func parseMigrateArgs(args []string) (func(*migrations.Migrator) error, error) {
+	if len(args) == 0 {
+		return nil, errMigrateUsage
+	}
+
+	command, args := args[0], args[1:]
+	switch {
+	case command == "up" && len(args) == 0:
+		return (*migrations.Migrator).Up, nil
+	case command == "down" && len(args) <= 1:
+		steps := 1
+		if len(args) == 1 {
+			var err error
+			if steps, err = strconv.Atoi(args[0]); err != nil || steps < 1 {
+				return nil, fmt.Errorf("%w: invalid step count %q", errMigrateUsage, args[0])
+			}
+		}
+		return func(m *migrations.Migrator) error { return m.Down(steps) }, nil
+	case command == "status" && len(args) == 0:
+		return printMigrationStatus, nil
+	case (command == "goto" || command == "force") && len(args) == 1:
+		version, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid version %q", errMigrateUsage, args[0])
+		}
+		if command == "force" {
+			return func(m *migrations.Migrator) error { return m.Force(uint(version)) }, nil
+		}
+		return func(m *migrations.Migrator) error { return m.Goto(uint(version)) }, nil
+	}
+	return nil, errMigrateUsage
+}
+
+func printMigrationStatus(migrator *migrations.Migrator) error {
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	state := "clean"
+	if status.Dirty {
+		state = "dirty: the last migration failed part way; fix the schema and run force"
+	}
+	fmt.Fprintf(os.Stdout, "version: %d (%s)\nlatest:  %d\n", status.Version, state, status.Latest)
+	if len(status.Pending) == 0 {
+		fmt.Fprintln(os.Stdout, "pending: none")
+		return nil
+	}
+	fmt.Fprintf(os.Stdout, "pending: %v\n", status.Pending)
+	return nil
+}
+
+// autoMigrate applies pending migrations before the service starts. Replicas
+// starting together queue on the migrator's advisory lock.
+func autoMigrate(databaseURL string) error {
+	migrator, err := migrations.NewMigrator(databaseURL)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	if err := migrator.Up(); err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"backend/internal/config"
+	"backend/internal/database"
+	"backend/seed"
+)
+
+const seedUsage = `usage: service seed [flags]
+
+Fills an empty, migrated database with the default coin packs and generated
+users, products, carts, orders, reviews and coin histories. Every user's password is "` + seed.Password + `".
+The same -seed always produces the same data; without -until the history
+ends today, so only the timestamps move from one day to the next.
+
+flags:`
+
+var errSeedUsage = errors.New("invalid seed command")
+
+// runSeed runs the seed subcommand
+func runSeed(cfg *config.Config, args []string) error {
+	if cfg.Database.Demo {
+		return errors.New("seed needs a database and cannot run in demo mode")
+	}
+
+	opts, err := parseSeedArgs(args, time.Now())
+	if err != nil {
+		return err
+	}
+	opts.Expiry = newCoinExpiryPolicy(cfg)
+
+	ctx := context.Background()
+	dbService, err := database.NewService(ctx, cfg.Database.URL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer dbService.Close()
+
+	data, err := seed.Generate(opts)
+	if err != nil {
+		return err
+	}
+	if err := seed.Load(ctx, dbService.DB(), data); err != nil {
+		return err
+	}
+
+	slog.Info("database seeded",
+		"seed", opts.Seed,
+		"users", len(data.Users),
+		"products", len(data.Products),
+		"orders", len(data.Orders),
+		"comments", len(data.Comments),
+		"coin_transactions", len(data.Transactions),
+	)
+	return nil
+}
+
+// parseSeedArgs checks the arguments before anything connects
+func parseSeedArgs(args []string, now time.Time) (seed.Options, error) {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	seedValue := fs.Uint64("seed", 1, "random seed; the same seed generates the same data")
+	users := fs.Int("users", 100, "number of users, the first of which is admin@example.com")
+	products := fs.Int("products", 200, "number of products, spread across the categories")
+	days := fs.Int("days", 180, "days of order history to generate")
+	until := fs.String("until", "", "last day of the history as YYYY-MM-DD (default today)")
+
+	usage := func() string {
+		var b strings.Builder
+		fs.SetOutput(&b)
+		fs.PrintDefaults()
+		fs.SetOutput(io.Discard)
+		return seedUsage + "\n" + b.String()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return seed.Options{}, fmt.Errorf("%w\n\n%s", errSeedUsage, usage())
+		}
+		return seed.Options{}, fmt.Errorf("%w: %v\n\n%s", errSeedUsage, err, usage())
+	}
+	if fs.NArg() > 0 {
+		return seed.Options{}, fmt.Errorf("%w: unexpected argument %q\n\n%s", errSeedUsage, fs.Arg(0), usage())
+	}
+	if *users < 1 || *products < 1 || *days < 1 {
+		return seed.Options{}, fmt.Errorf("%w: -users, -products and -days must be at least 1", errSeedUsage)
+	}
+
+	end := now.UTC().Truncate(24 * time.Hour)
+	if *until != "" {
+		var err error
+		if end, err = time.Parse(time.DateOnly, *until); err != nil {
+			return seed.Options{}, fmt.Errorf("%w: invalid -until %q", errSeedUsage, *until)
+		}
+	}
+
+	return seed.Options{
+		Seed:     *seedValue,
+		Users:    *users,
+		Products: *products,
+		Days:     *days,
+		End:      end,
+	}, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"backend/internal/health"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// server is anything run serves on and drains on shutdown, such as the API's
+// *echo.Echo and the metrics *http.Server
+type server interface {
+	Shutdown(ctx context.Context) error
+}
+
+// shutdown holds what run stops once it is told to exit
+type shutdown struct {
+	readiness *health.Readiness
+	// delay is how long the servers keep serving after readiness fails
+	delay time.Duration
+	// timeout bounds how long the servers may take to drain
+	timeout       time.Duration
+	server        server
+	metricsServer server
+	// stopWorkers stops the workers and returns once they have
+	stopWorkers func()
+	closeDB     func()
+}
+
+// run stops the service in the order that loses no work. Readiness fails
+// first and requests are still served for delay, so the orchestrator stops
+// routing here before new connections are refused. The servers then drain,
+// the workers are stopped, and the pool is closed only after both have
+// finished with it.
+func (s shutdown) run() {
+	s.readiness.Drain()
+	time.Sleep(s.delay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		slog.Error("failed to drain requests", "error", err)
+	}
+	if err := s.metricsServer.Shutdown(ctx); err != nil {
+		slog.Error("failed to stop metrics server", "error", err)
+	}
+
+	s.stopWorkers()
+	s.closeDB()
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"backend/internal/database/memdb"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// serverFunc is a server whose Shutdown is the function itself
+type serverFunc func(ctx context.Context) error
+
+func (f serverFunc) Shutdown(ctx context.Context) error {
+	return f(ctx)
+}
+
+func TestShutdown_StopsInOrder(t *testing.T) {
+	a := testApp(t, memdb.New())
+	ready := func() int {
+		rec := httptest.NewRecorder()
+		a.echo.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		return rec.Code
+	}
+	assert.Equal(t, http.StatusOK, ready())
+
+	const delay = 50 * time.Millisecond
+	var steps []string
+	var readyAtShutdown int
+	start := time.Now()
+
+	shutdown{
+		readiness: a.readiness,
+		delay:     delay,
+		timeout:   time.Second,
+		server: serverFunc(func(ctx context.Context) error {
+			readyAtShutdown = ready()
+			assert.GreaterOrEqual(t, time.Since(start), delay)
+			steps = append(steps, "server")
+			return nil
+		}),
+		metricsServer: serverFunc(func(ctx context.Context) error {
+			steps = append(steps, "metrics")
+			return nil
+		}),
+		stopWorkers: func() { steps = append(steps, "workers") },
+		closeDB:     func() { steps = append(steps, "pool") },
+	}.run()
+
+	// Readiness already failed while the server was still up
+	assert.Equal(t, http.StatusServiceUnavailable, readyAtShutdown)
+	assert.Equal(t, []string{"server", "metrics", "workers", "pool"}, steps)
+}
+
+func TestShutdown_KeepsGoingWhenAServerFailsToDrain(t *testing.T) {
+	a := testApp(t, memdb.New())
+	var steps []string
+
+	shutdown{
+		readiness: a.readiness,
+		timeout:   time.Second,
+		server: serverFunc(func(ctx context.Context) error {
+			steps = append(steps, "server")
+			return context.DeadlineExceeded
+		}),
+		metricsServer: serverFunc(func(ctx context.Context) error {
+			steps = append(steps, "metrics")
+			return nil
+		}),
+		stopWorkers: func() { steps = append(steps, "workers") },
+		closeDB:     func() { steps = append(steps, "pool") },
+	}.run()
+
+	assert.Equal(t, []string{"server", "metrics", "workers", "pool"}, steps)
+}
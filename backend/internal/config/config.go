@@ -0,0 +1,347 @@
+// Package config loads the service's settings. Defaults are overridden by an
+// optional YAML file and then by environment variables, and the result is
+// validated before anything starts, so a bad deploy fails at boot with every
+// problem listed rather than at the first request that hits one.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Server      ServerConfig      `yaml:"server"`
+	Database    DatabaseConfig    `yaml:"database"`
+	Auth        AuthConfig        `yaml:"auth"`
+	CORS        CORSConfig        `yaml:"cors"`
+	Log         LogConfig         `yaml:"log"`
+	Metrics     MetricsConfig     `yaml:"metrics"`
+	Tracing     TracingConfig     `yaml:"tracing"`
+	Coins       CoinsConfig       `yaml:"coins"`
+	SpendLimits SpendLimitsConfig `yaml:"spend_limits"`
+	Cashback    CashbackConfig    `yaml:"cashback"`
+	Referral    ReferralConfig    `yaml:"referral"`
+	GiftCodes   GiftCodesConfig   `yaml:"gift_codes"`
+}
+
+type ServerConfig struct {
+	Addr              string        `yaml:"addr" env:"HTTP_ADDR"`
+	ReadHeaderTimeout time.Duration `yaml:"read_header_timeout" env:"HTTP_READ_HEADER_TIMEOUT"`
+	ReadTimeout       time.Duration `yaml:"read_timeout" env:"HTTP_READ_TIMEOUT"`
+	// Statements stream for as long as they take to build, so writes get more room
+	WriteTimeout time.Duration `yaml:"write_timeout" env:"HTTP_WRITE_TIMEOUT"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout" env:"HTTP_IDLE_TIMEOUT"`
+	// ShutdownDelay keeps serving after readiness fails, so the orchestrator
+	// stops routing here before new connections are refused
+	ShutdownDelay   time.Duration `yaml:"shutdown_delay" env:"SHUTDOWN_DELAY"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+}
+
+type DatabaseConfig struct {
+	URL string `yaml:"url" env:"DATABASE_URL" secret:"url"`
+	// AutoMigrate applies pending migrations at startup
+	AutoMigrate bool `yaml:"auto_migrate" env:"DATABASE_AUTO_MIGRATE"`
+	// Demo serves generated seed data from memory instead of Postgres, so URL
+	// is not needed. Everything written is lost on exit
+	Demo bool `yaml:"demo" env:"DATABASE_DEMO"`
+}
+
+type AuthConfig struct {
+	JWTSecret string        `yaml:"jwt_secret" env:"JWT_SECRET" secret:"true"`
+	JWTTTL    time.Duration `yaml:"jwt_ttl" env:"JWT_TTL"`
+}
+
+type CORSConfig struct {
+	AllowOrigins []string `yaml:"allow_origins" env:"CORS_ALLOW_ORIGINS"`
+}
+
+type LogConfig struct {
+	Level string `yaml:"level" env:"LOG_LEVEL"`
+}
+
+type MetricsConfig struct {
+	Addr string `yaml:"addr" env:"METRICS_ADDR"`
+}
+
+type TracingConfig struct {
+	Exporter string `yaml:"exporter" env:"OTEL_TRACES_EXPORTER"`
+}
+
+type CoinsConfig struct {
+	SignupBonus       int           `yaml:"signup_bonus" env:"SIGNUP_COINS"`
+	ExpiryMonths      int           `yaml:"expiry_months" env:"COIN_EXPIRY_MONTHS"`
+	BonusExpiryMonths int           `yaml:"bonus_expiry_months" env:"BONUS_COIN_EXPIRY_MONTHS"`
+	HoldTTL           time.Duration `yaml:"hold_ttl" env:"COIN_HOLD_TTL"`
+}
+
+// SpendLimitsConfig mirrors entity.SpendLimitPolicy; zero disables a limit
+type SpendLimitsConfig struct {
+	DailyLimit          int           `yaml:"daily_limit" env:"SPEND_DAILY_LIMIT"`
+	PerTransactionLimit int           `yaml:"per_transaction_limit" env:"SPEND_PER_TRANSACTION_LIMIT"`
+	VelocityMaxSpends   int           `yaml:"velocity_max_spends" env:"SPEND_VELOCITY_MAX_SPENDS"`
+	VelocityWindow      time.Duration `yaml:"velocity_window" env:"SPEND_VELOCITY_WINDOW"`
+	BlockDuration       time.Duration `yaml:"block_duration" env:"SPEND_BLOCK_DURATION"`
+}
+
+type CashbackConfig struct {
+	DefaultRatePercent float64 `yaml:"default_rate_percent" env:"CASHBACK_PERCENT"`
+}
+
+type ReferralConfig struct {
+	ReferrerBonus int `yaml:"referrer_bonus" env:"REFERRAL_REFERRER_BONUS"`
+	RefereeBonus  int `yaml:"referee_bonus" env:"REFERRAL_REFEREE_BONUS"`
+}
+
+type GiftCodesConfig struct {
+	MaxFailedAttempts int           `yaml:"max_failed_attempts" env:"GIFT_CODE_MAX_FAILED_ATTEMPTS"`
+	FailureWindow     time.Duration `yaml:"failure_window" env:"GIFT_CODE_FAILURE_WINDOW"`
+}
+
+// Default returns the settings used for anything not configured
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Addr:              ":8080",
+			ReadHeaderTimeout: 5 * time.Second,
+			ReadTimeout:       15 * time.Second,
+			WriteTimeout:      60 * time.Second,
+			IdleTimeout:       120 * time.Second,
+			ShutdownTimeout:   30 * time.Second,
+		},
+		Auth:    AuthConfig{JWTTTL: 24 * time.Hour},
+		CORS:    CORSConfig{AllowOrigins: []string{"http://localhost:5173"}},
+		Log:     LogConfig{Level: "info"},
+		Metrics: MetricsConfig{Addr: "127.0.0.1:9090"},
+		Tracing: TracingConfig{Exporter: "none"},
+		Coins: CoinsConfig{
+			SignupBonus:       1000,
+			BonusExpiryMonths: 6,
+			HoldTTL:           30 * time.Minute,
+		},
+		// By default only the velocity rule is on
+		SpendLimits: SpendLimitsConfig{
+			VelocityMaxSpends: 10,
+			VelocityWindow:    5 * time.Minute,
+			BlockDuration:     30 * time.Minute,
+		},
+		Referral: ReferralConfig{ReferrerBonus: 500, RefereeBonus: 500},
+		GiftCodes: GiftCodesConfig{
+			MaxFailedAttempts: 5,
+			FailureWindow:     15 * time.Minute,
+		},
+	}
+}
+
+// Load returns the defaults overridden by the YAML file at path, if path is
+// not empty, and then by the variables getenv finds, and validates the result
+func Load(path string, getenv func(string) (string, bool)) (*Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	// Unparseable variables leave their default, so validation still runs and
+	// every problem is reported together
+	if err := errors.Join(cfg.loadEnv(getenv), cfg.Validate()); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Config) loadEnv(getenv func(string) (string, bool)) error {
+	var errs []error
+	walkFields(reflect.ValueOf(c).Elem(), func(field reflect.Value, tag reflect.StructTag) {
+		name := tag.Get("env")
+		if name == "" {
+			return
+		}
+		// An empty variable counts as unset, as it does for godotenv's .env files
+		value, ok := getenv(name)
+		value = strings.TrimSpace(value)
+		if !ok || value == "" {
+			return
+		}
+		if err := setField(field, value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	})
+
+	return errors.Join(errs...)
+}
+
+// walkFields calls fn for every leaf field of the struct v
+func walkFields(v reflect.Value, fn func(field reflect.Value, tag reflect.StructTag)) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			walkFields(field, fn)
+			continue
+		}
+		fn(field, v.Type().Field(i).Tag)
+	}
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func setField(field reflect.Value, value string) error {
+	switch {
+	case field.Type() == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration such as 30s or 5m", value)
+		}
+		field.SetInt(int64(d))
+	case field.Kind() == reflect.String:
+		field.SetString(value)
+	case field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not true or false", value)
+		}
+		field.SetBool(b)
+	case field.Kind() == reflect.Int:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number", value)
+		}
+		field.SetInt(int64(n))
+	case field.Kind() == reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		field.SetFloat(f)
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// Validate reports every invalid setting at once
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Server.Addr != "", "server.addr (HTTP_ADDR) is required")
+	check(c.Server.ReadHeaderTimeout > 0, "server.read_header_timeout (HTTP_READ_HEADER_TIMEOUT) must be positive")
+	check(c.Server.ReadTimeout > 0, "server.read_timeout (HTTP_READ_TIMEOUT) must be positive")
+	check(c.Server.WriteTimeout > 0, "server.write_timeout (HTTP_WRITE_TIMEOUT) must be positive")
+	check(c.Server.IdleTimeout > 0, "server.idle_timeout (HTTP_IDLE_TIMEOUT) must be positive")
+	check(c.Server.ShutdownDelay >= 0, "server.shutdown_delay (SHUTDOWN_DELAY) must not be negative")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout (SHUTDOWN_TIMEOUT) must be positive")
+
+	check(c.Database.URL != "" || c.Database.Demo, "database.url (DATABASE_URL) is required")
+	check(c.Auth.JWTSecret != "", "auth.jwt_secret (JWT_SECRET) is required")
+	check(c.Auth.JWTTTL > 0, "auth.jwt_ttl (JWT_TTL) must be positive")
+	check(len(c.CORS.AllowOrigins) > 0, "cors.allow_origins (CORS_ALLOW_ORIGINS) needs at least one origin")
+	check(c.Metrics.Addr != "", "metrics.addr (METRICS_ADDR) is required")
+
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log.level (LOG_LEVEL) must be debug, info, warn or error, got %q", c.Log.Level))
+	}
+	switch c.Tracing.Exporter {
+	case "none", "console", "stdout", "otlp":
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter (OTEL_TRACES_EXPORTER) must be none, console, stdout or otlp, got %q", c.Tracing.Exporter))
+	}
+
+	// Bonuses are stored as 32-bit balances
+	check(c.Coins.SignupBonus >= 0 && c.Coins.SignupBonus <= math.MaxInt32,
+		"coins.signup_bonus (SIGNUP_COINS) must be between 0 and 2147483647")
+	check(c.Coins.ExpiryMonths >= 0, "coins.expiry_months (COIN_EXPIRY_MONTHS) must not be negative")
+	check(c.Coins.BonusExpiryMonths >= 0, "coins.bonus_expiry_months (BONUS_COIN_EXPIRY_MONTHS) must not be negative")
+	check(c.Coins.HoldTTL > 0, "coins.hold_ttl (COIN_HOLD_TTL) must be positive")
+
+	check(c.SpendLimits.DailyLimit >= 0, "spend_limits.daily_limit (SPEND_DAILY_LIMIT) must not be negative")
+	check(c.SpendLimits.PerTransactionLimit >= 0, "spend_limits.per_transaction_limit (SPEND_PER_TRANSACTION_LIMIT) must not be negative")
+	check(c.SpendLimits.VelocityMaxSpends >= 0, "spend_limits.velocity_max_spends (SPEND_VELOCITY_MAX_SPENDS) must not be negative")
+	check(c.SpendLimits.VelocityMaxSpends == 0 || c.SpendLimits.VelocityWindow > 0,
+		"spend_limits.velocity_window (SPEND_VELOCITY_WINDOW) must be positive when the velocity rule is on")
+	check(c.SpendLimits.BlockDuration >= 0, "spend_limits.block_duration (SPEND_BLOCK_DURATION) must not be negative")
+
+	check(c.Cashback.DefaultRatePercent >= 0 && c.Cashback.DefaultRatePercent <= 100,
+		"cashback.default_rate_percent (CASHBACK_PERCENT) must be between 0 and 100")
+	check(c.Referral.ReferrerBonus >= 0 && c.Referral.ReferrerBonus <= math.MaxInt32,
+		"referral.referrer_bonus (REFERRAL_REFERRER_BONUS) must be between 0 and 2147483647")
+	check(c.Referral.RefereeBonus >= 0 && c.Referral.RefereeBonus <= math.MaxInt32,
+		"referral.referee_bonus (REFERRAL_REFEREE_BONUS) must be between 0 and 2147483647")
+	check(c.GiftCodes.MaxFailedAttempts >= 0, "gift_codes.max_failed_attempts (GIFT_CODE_MAX_FAILED_ATTEMPTS) must not be negative")
+	check(c.GiftCodes.MaxFailedAttempts == 0 || c.GiftCodes.FailureWindow > 0,
+		"gift_codes.failure_window (GIFT_CODE_FAILURE_WINDOW) must be positive when attempts are limited")
+
+	return errors.Join(errs...)
+}
+
+const redacted = "[redacted]"
+
+// Redacted returns a copy that is safe to print: secrets are masked and
+// URLs keep everything but their password
+func (c Config) Redacted() Config {
+	walkFields(reflect.ValueOf(&c).Elem(), func(field reflect.Value, tag reflect.StructTag) {
+		if field.Kind() != reflect.String || field.String() == "" {
+			return
+		}
+		switch tag.Get("secret") {
+		case "true":
+			field.SetString(redacted)
+		case "url":
+			field.SetString(redactURL(field.String()))
+		}
+	})
+	return c
+}
+
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" {
+		// Not a URL, perhaps a key=value DSN, so the password cannot be picked out
+		return redacted
+	}
+	return u.Redacted()
+}
+
+// YAML renders the configuration in the format Load reads
+func (c Config) YAML() ([]byte, error) {
+	return yaml.Marshal(c)
+}
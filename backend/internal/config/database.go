@@ -1,27 +0,0 @@
-package config
-
-import (
-	"database/sql"
-	"fmt"
-	"os"
-
-	_ "github.com/jackc/pgx/v5/stdlib"
-)
-
-func NewDatabase() (*sql.DB, error) {
-	dbURL := os.Getenv("DATABASE_URL")
-	if dbURL == "" {
-		return nil, fmt.Errorf("DATABASE_URL environment variable is not set")
-	}
-
-	db, err := sql.Open("pgx", dbURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
-	}
-
-	if err := db.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
-	}
-
-	return db, nil
-}
@@ -0,0 +1,81 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: cashback_rates.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteCategoryCashbackRate = `-- name: DeleteCategoryCashbackRate :execrows
+DELETE FROM category_cashback_rates
+WHERE category_id = $1
+`
+
+func (q *Queries) DeleteCategoryCashbackRate(ctx context.Context, categoryID int32) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCategoryCashbackRate, categoryID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listCategoryCashbackRates = `-- name: ListCategoryCashbackRates :many
+SELECT category_id, rate_percent, created_at, updated_at
+FROM category_cashback_rates
+ORDER BY category_id
+`
+
+func (q *Queries) ListCategoryCashbackRates(ctx context.Context) ([]CategoryCashbackRate, error) {
+	rows, err := q.db.Query(ctx, listCategoryCashbackRates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CategoryCashbackRate
+	for rows.Next() {
+		var i CategoryCashbackRate
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.RatePercent,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertCategoryCashbackRate = `-- name: UpsertCategoryCashbackRate :one
+INSERT INTO category_cashback_rates (category_id, rate_percent)
+VALUES ($1, $2)
+ON CONFLICT (category_id) DO UPDATE
+SET rate_percent = EXCLUDED.rate_percent
+RETURNING category_id, rate_percent, created_at, updated_at
+`
+
+type UpsertCategoryCashbackRateParams struct {
+	CategoryID  int32          `db:"category_id" json:"category_id"`
+	RatePercent pgtype.Numeric `db:"rate_percent" json:"rate_percent"`
+}
+
+func (q *Queries) UpsertCategoryCashbackRate(ctx context.Context, arg UpsertCategoryCashbackRateParams) (CategoryCashbackRate, error) {
+	row := q.db.QueryRow(ctx, upsertCategoryCashbackRate, arg.CategoryID, arg.RatePercent)
+	var i CategoryCashbackRate
+	err := row.Scan(
+		&i.CategoryID,
+		&i.RatePercent,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,262 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: coin_holds.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createCoinHold = `-- name: CreateCoinHold :one
+INSERT INTO coin_holds (user_id, amount, description, order_id, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, user_id, amount, description, order_id, status, expires_at, coin_transaction_id, created_at, updated_at
+`
+
+type CreateCoinHoldParams struct {
+	UserID      pgtype.UUID        `db:"user_id" json:"user_id"`
+	Amount      int32              `db:"amount" json:"amount"`
+	Description pgtype.Text        `db:"description" json:"description"`
+	OrderID     pgtype.Int4        `db:"order_id" json:"order_id"`
+	ExpiresAt   pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) CreateCoinHold(ctx context.Context, arg CreateCoinHoldParams) (CoinHold, error) {
+	row := q.db.QueryRow(ctx, createCoinHold,
+		arg.UserID,
+		arg.Amount,
+		arg.Description,
+		arg.OrderID,
+		arg.ExpiresAt,
+	)
+	var i CoinHold
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Amount,
+		&i.Description,
+		&i.OrderID,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.CoinTransactionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getCoinHoldByID = `-- name: GetCoinHoldByID :one
+SELECT id, user_id, amount, description, order_id, status, expires_at, coin_transaction_id, created_at, updated_at
+FROM coin_holds
+WHERE id = $1
+`
+
+func (q *Queries) GetCoinHoldByID(ctx context.Context, id int32) (CoinHold, error) {
+	row := q.db.QueryRow(ctx, getCoinHoldByID, id)
+	var i CoinHold
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Amount,
+		&i.Description,
+		&i.OrderID,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.CoinTransactionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getCoinHoldForUpdate = `-- name: GetCoinHoldForUpdate :one
+SELECT id, user_id, amount, description, order_id, status, expires_at, coin_transaction_id, created_at, updated_at
+FROM coin_holds
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetCoinHoldForUpdate(ctx context.Context, id int32) (CoinHold, error) {
+	row := q.db.QueryRow(ctx, getCoinHoldForUpdate, id)
+	var i CoinHold
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Amount,
+		&i.Description,
+		&i.OrderID,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.CoinTransactionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listActiveCoinHoldsByOrderID = `-- name: ListActiveCoinHoldsByOrderID :many
+SELECT id, user_id, amount, description, order_id, status, expires_at, coin_transaction_id, created_at, updated_at
+FROM coin_holds
+WHERE order_id = $1
+  AND status = 'active'
+ORDER BY id ASC
+`
+
+func (q *Queries) ListActiveCoinHoldsByOrderID(ctx context.Context, orderID pgtype.Int4) ([]CoinHold, error) {
+	rows, err := q.db.Query(ctx, listActiveCoinHoldsByOrderID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CoinHold
+	for rows.Next() {
+		var i CoinHold
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Amount,
+			&i.Description,
+			&i.OrderID,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.CoinTransactionID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCoinHoldsByUserID = `-- name: ListCoinHoldsByUserID :many
+SELECT id, user_id, amount, description, order_id, status, expires_at, coin_transaction_id, created_at, updated_at
+FROM coin_holds
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListCoinHoldsByUserIDParams struct {
+	UserID pgtype.UUID `db:"user_id" json:"user_id"`
+	Limit  int32       `db:"limit" json:"limit"`
+	Offset int32       `db:"offset" json:"offset"`
+}
+
+func (q *Queries) ListCoinHoldsByUserID(ctx context.Context, arg ListCoinHoldsByUserIDParams) ([]CoinHold, error) {
+	rows, err := q.db.Query(ctx, listCoinHoldsByUserID, arg.UserID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CoinHold
+	for rows.Next() {
+		var i CoinHold
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Amount,
+			&i.Description,
+			&i.OrderID,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.CoinTransactionID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExpiredCoinHolds = `-- name: ListExpiredCoinHolds :many
+SELECT id, user_id, amount, description, order_id, status, expires_at, coin_transaction_id, created_at, updated_at
+FROM coin_holds
+WHERE status = 'active'
+  AND expires_at <= $1
+ORDER BY expires_at ASC, id ASC
+LIMIT $2
+`
+
+type ListExpiredCoinHoldsParams struct {
+	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	Limit     int32              `db:"limit" json:"limit"`
+}
+
+func (q *Queries) ListExpiredCoinHolds(ctx context.Context, arg ListExpiredCoinHoldsParams) ([]CoinHold, error) {
+	rows, err := q.db.Query(ctx, listExpiredCoinHolds, arg.ExpiresAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CoinHold
+	for rows.Next() {
+		var i CoinHold
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Amount,
+			&i.Description,
+			&i.OrderID,
+			&i.Status,
+			&i.ExpiresAt,
+			&i.CoinTransactionID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateCoinHoldStatus = `-- name: UpdateCoinHoldStatus :one
+UPDATE coin_holds
+SET 
+    status = $2,
+    coin_transaction_id = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, user_id, amount, description, order_id, status, expires_at, coin_transaction_id, created_at, updated_at
+`
+
+type UpdateCoinHoldStatusParams struct {
+	ID                int32          `db:"id" json:"id"`
+	Status            CoinHoldStatus `db:"status" json:"status"`
+	CoinTransactionID pgtype.Int4    `db:"coin_transaction_id" json:"coin_transaction_id"`
+}
+
+func (q *Queries) UpdateCoinHoldStatus(ctx context.Context, arg UpdateCoinHoldStatusParams) (CoinHold, error) {
+	row := q.db.QueryRow(ctx, updateCoinHoldStatus, arg.ID, arg.Status, arg.CoinTransactionID)
+	var i CoinHold
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Amount,
+		&i.Description,
+		&i.OrderID,
+		&i.Status,
+		&i.ExpiresAt,
+		&i.CoinTransactionID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
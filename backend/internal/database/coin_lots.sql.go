@@ -0,0 +1,238 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: coin_lots.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createCoinLot = `-- name: CreateCoinLot :one
+INSERT INTO coin_lots (user_id, coin_transaction_id, source, original_amount, remaining_amount, expires_at)
+VALUES ($1, $2, $3, $4, $4, $5)
+RETURNING id, user_id, coin_transaction_id, source, original_amount, remaining_amount, expires_at, created_at
+`
+
+type CreateCoinLotParams struct {
+	UserID            pgtype.UUID        `db:"user_id" json:"user_id"`
+	CoinTransactionID pgtype.Int4        `db:"coin_transaction_id" json:"coin_transaction_id"`
+	Source            TransactionType    `db:"source" json:"source"`
+	OriginalAmount    int32              `db:"original_amount" json:"original_amount"`
+	ExpiresAt         pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) CreateCoinLot(ctx context.Context, arg CreateCoinLotParams) (CoinLot, error) {
+	row := q.db.QueryRow(ctx, createCoinLot,
+		arg.UserID,
+		arg.CoinTransactionID,
+		arg.Source,
+		arg.OriginalAmount,
+		arg.ExpiresAt,
+	)
+	var i CoinLot
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.CoinTransactionID,
+		&i.Source,
+		&i.OriginalAmount,
+		&i.RemainingAmount,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getCoinLotForUpdate = `-- name: GetCoinLotForUpdate :one
+SELECT id, user_id, coin_transaction_id, source, original_amount, remaining_amount, expires_at, created_at
+FROM coin_lots
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetCoinLotForUpdate(ctx context.Context, id int32) (CoinLot, error) {
+	row := q.db.QueryRow(ctx, getCoinLotForUpdate, id)
+	var i CoinLot
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.CoinTransactionID,
+		&i.Source,
+		&i.OriginalAmount,
+		&i.RemainingAmount,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getExpiredCoinLotTotal = `-- name: GetExpiredCoinLotTotal :one
+SELECT COALESCE(SUM(remaining_amount), 0)::integer AS total
+FROM coin_lots
+WHERE user_id = $1
+  AND remaining_amount > 0
+  AND expires_at <= $2
+`
+
+type GetExpiredCoinLotTotalParams struct {
+	UserID    pgtype.UUID        `db:"user_id" json:"user_id"`
+	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) GetExpiredCoinLotTotal(ctx context.Context, arg GetExpiredCoinLotTotalParams) (int32, error) {
+	row := q.db.QueryRow(ctx, getExpiredCoinLotTotal, arg.UserID, arg.ExpiresAt)
+	var total int32
+	err := row.Scan(&total)
+	return total, err
+}
+
+const getUpcomingCoinExpiries = `-- name: GetUpcomingCoinExpiries :many
+SELECT expires_at::date AS expires_on, SUM(remaining_amount)::integer AS amount
+FROM coin_lots
+WHERE user_id = $1
+  AND remaining_amount > 0
+  AND expires_at > $2
+GROUP BY expires_at::date
+ORDER BY expires_on
+`
+
+type GetUpcomingCoinExpiriesParams struct {
+	UserID    pgtype.UUID        `db:"user_id" json:"user_id"`
+	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+}
+
+type GetUpcomingCoinExpiriesRow struct {
+	ExpiresOn pgtype.Date `db:"expires_on" json:"expires_on"`
+	Amount    int32       `db:"amount" json:"amount"`
+}
+
+func (q *Queries) GetUpcomingCoinExpiries(ctx context.Context, arg GetUpcomingCoinExpiriesParams) ([]GetUpcomingCoinExpiriesRow, error) {
+	rows, err := q.db.Query(ctx, getUpcomingCoinExpiries, arg.UserID, arg.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUpcomingCoinExpiriesRow
+	for rows.Next() {
+		var i GetUpcomingCoinExpiriesRow
+		if err := rows.Scan(&i.ExpiresOn, &i.Amount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExpiredCoinLots = `-- name: ListExpiredCoinLots :many
+SELECT cl.id, cl.user_id, cl.coin_transaction_id, cl.source, cl.original_amount, cl.remaining_amount, cl.expires_at, cl.created_at
+FROM coin_lots cl
+JOIN users u ON u.id = cl.user_id
+WHERE cl.remaining_amount > 0
+  AND cl.expires_at <= $1
+  AND COALESCE(u.coins, 0) > u.held_coins
+ORDER BY cl.expires_at ASC, cl.id ASC
+LIMIT $2
+`
+
+type ListExpiredCoinLotsParams struct {
+	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	Limit     int32              `db:"limit" json:"limit"`
+}
+
+// Lots of users whose coins are all held are left until the holds end, so
+// they never fill a batch the sweep cannot expire.
+func (q *Queries) ListExpiredCoinLots(ctx context.Context, arg ListExpiredCoinLotsParams) ([]CoinLot, error) {
+	rows, err := q.db.Query(ctx, listExpiredCoinLots, arg.ExpiresAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CoinLot
+	for rows.Next() {
+		var i CoinLot
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CoinTransactionID,
+			&i.Source,
+			&i.OriginalAmount,
+			&i.RemainingAmount,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSpendableCoinLotsForUpdate = `-- name: ListSpendableCoinLotsForUpdate :many
+SELECT id, user_id, coin_transaction_id, source, original_amount, remaining_amount, expires_at, created_at
+FROM coin_lots
+WHERE user_id = $1
+  AND remaining_amount > 0
+  AND (expires_at IS NULL OR expires_at > $2)
+ORDER BY created_at ASC, id ASC
+FOR UPDATE
+`
+
+type ListSpendableCoinLotsForUpdateParams struct {
+	UserID    pgtype.UUID        `db:"user_id" json:"user_id"`
+	ExpiresAt pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) ListSpendableCoinLotsForUpdate(ctx context.Context, arg ListSpendableCoinLotsForUpdateParams) ([]CoinLot, error) {
+	rows, err := q.db.Query(ctx, listSpendableCoinLotsForUpdate, arg.UserID, arg.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CoinLot
+	for rows.Next() {
+		var i CoinLot
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.CoinTransactionID,
+			&i.Source,
+			&i.OriginalAmount,
+			&i.RemainingAmount,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateCoinLotRemaining = `-- name: UpdateCoinLotRemaining :exec
+UPDATE coin_lots
+SET remaining_amount = $2
+WHERE id = $1
+`
+
+type UpdateCoinLotRemainingParams struct {
+	ID              int32 `db:"id" json:"id"`
+	RemainingAmount int32 `db:"remaining_amount" json:"remaining_amount"`
+}
+
+func (q *Queries) UpdateCoinLotRemaining(ctx context.Context, arg UpdateCoinLotRemainingParams) error {
+	_, err := q.db.Exec(ctx, updateCoinLotRemaining, arg.ID, arg.RemainingAmount)
+	return err
+}
@@ -0,0 +1,196 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: coin_packs.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createCoinPack = `-- name: CreateCoinPack :one
+INSERT INTO coin_packs (name, base_coins, bonus_coins, price, is_active, sort_order)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, name, base_coins, bonus_coins, price, is_active, sort_order, created_at, updated_at
+`
+
+type CreateCoinPackParams struct {
+	Name       string         `db:"name" json:"name"`
+	BaseCoins  int32          `db:"base_coins" json:"base_coins"`
+	BonusCoins int32          `db:"bonus_coins" json:"bonus_coins"`
+	Price      pgtype.Numeric `db:"price" json:"price"`
+	IsActive   bool           `db:"is_active" json:"is_active"`
+	SortOrder  int32          `db:"sort_order" json:"sort_order"`
+}
+
+func (q *Queries) CreateCoinPack(ctx context.Context, arg CreateCoinPackParams) (CoinPack, error) {
+	row := q.db.QueryRow(ctx, createCoinPack,
+		arg.Name,
+		arg.BaseCoins,
+		arg.BonusCoins,
+		arg.Price,
+		arg.IsActive,
+		arg.SortOrder,
+	)
+	var i CoinPack
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.BaseCoins,
+		&i.BonusCoins,
+		&i.Price,
+		&i.IsActive,
+		&i.SortOrder,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getCoinPackByID = `-- name: GetCoinPackByID :one
+SELECT id, name, base_coins, bonus_coins, price, is_active, sort_order, created_at, updated_at
+FROM coin_packs
+WHERE id = $1
+`
+
+func (q *Queries) GetCoinPackByID(ctx context.Context, id int32) (CoinPack, error) {
+	row := q.db.QueryRow(ctx, getCoinPackByID, id)
+	var i CoinPack
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.BaseCoins,
+		&i.BonusCoins,
+		&i.Price,
+		&i.IsActive,
+		&i.SortOrder,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listActiveCoinPacks = `-- name: ListActiveCoinPacks :many
+SELECT id, name, base_coins, bonus_coins, price, is_active, sort_order, created_at, updated_at
+FROM coin_packs
+WHERE is_active = TRUE
+ORDER BY sort_order, id
+`
+
+func (q *Queries) ListActiveCoinPacks(ctx context.Context) ([]CoinPack, error) {
+	rows, err := q.db.Query(ctx, listActiveCoinPacks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CoinPack
+	for rows.Next() {
+		var i CoinPack
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.BaseCoins,
+			&i.BonusCoins,
+			&i.Price,
+			&i.IsActive,
+			&i.SortOrder,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCoinPacks = `-- name: ListCoinPacks :many
+SELECT id, name, base_coins, bonus_coins, price, is_active, sort_order, created_at, updated_at
+FROM coin_packs
+ORDER BY sort_order, id
+`
+
+func (q *Queries) ListCoinPacks(ctx context.Context) ([]CoinPack, error) {
+	rows, err := q.db.Query(ctx, listCoinPacks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CoinPack
+	for rows.Next() {
+		var i CoinPack
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.BaseCoins,
+			&i.BonusCoins,
+			&i.Price,
+			&i.IsActive,
+			&i.SortOrder,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateCoinPack = `-- name: UpdateCoinPack :one
+UPDATE coin_packs
+SET 
+    name = $2,
+    base_coins = $3,
+    bonus_coins = $4,
+    price = $5,
+    is_active = $6,
+    sort_order = $7,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, base_coins, bonus_coins, price, is_active, sort_order, created_at, updated_at
+`
+
+type UpdateCoinPackParams struct {
+	ID         int32          `db:"id" json:"id"`
+	Name       string         `db:"name" json:"name"`
+	BaseCoins  int32          `db:"base_coins" json:"base_coins"`
+	BonusCoins int32          `db:"bonus_coins" json:"bonus_coins"`
+	Price      pgtype.Numeric `db:"price" json:"price"`
+	IsActive   bool           `db:"is_active" json:"is_active"`
+	SortOrder  int32          `db:"sort_order" json:"sort_order"`
+}
+
+func (q *Queries) UpdateCoinPack(ctx context.Context, arg UpdateCoinPackParams) (CoinPack, error) {
+	row := q.db.QueryRow(ctx, updateCoinPack,
+		arg.ID,
+		arg.Name,
+		arg.BaseCoins,
+		arg.BonusCoins,
+		arg.Price,
+		arg.IsActive,
+		arg.SortOrder,
+	)
+	var i CoinPack
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.BaseCoins,
+		&i.BonusCoins,
+		&i.Price,
+		&i.IsActive,
+		&i.SortOrder,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// sqlc only generates :many queries that collect every row into a slice, so the
+// statement query is kept here and read row by row from the pgx cursor instead.
+const streamCoinTransactionsForStatement = `
+SELECT id, user_id, transaction_type, amount, balance_after, order_id, description, created_at, coin_pack_id
+FROM coin_transactions
+WHERE user_id = $1
+  AND created_at >= $2
+  AND created_at < $3
+ORDER BY created_at, id
+`
+
+// CoinStatementQuerier is a Querier that can also stream statement rows
+type CoinStatementQuerier interface {
+	Querier
+	StreamCoinTransactionsForStatement(ctx context.Context, arg StreamCoinTransactionsForStatementParams, fn func(CoinTransaction) error) error
+}
+
+var _ CoinStatementQuerier = (*Queries)(nil)
+
+type StreamCoinTransactionsForStatementParams struct {
+	UserID      pgtype.UUID        `db:"user_id" json:"user_id"`
+	CreatedFrom pgtype.Timestamptz `db:"created_from" json:"created_from"`
+	CreatedTo   pgtype.Timestamptz `db:"created_to" json:"created_to"`
+}
+
+// StreamCoinTransactionsForStatement calls fn for each of the user's transactions
+// in [CreatedFrom, CreatedTo), oldest first. Rows are decoded as they arrive, so
+// memory use does not grow with the size of the history. Returning an error from
+// fn stops the iteration and closes the cursor.
+func (q *Queries) StreamCoinTransactionsForStatement(ctx context.Context, arg StreamCoinTransactionsForStatementParams, fn func(CoinTransaction) error) error {
+	rows, err := q.db.Query(ctx, streamCoinTransactionsForStatement, arg.UserID, arg.CreatedFrom, arg.CreatedTo)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var i CoinTransaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.TransactionType,
+			&i.Amount,
+			&i.BalanceAfter,
+			&i.OrderID,
+			&i.Description,
+			&i.CreatedAt,
+			&i.CoinPackID,
+		); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -12,9 +12,9 @@ import (
 )
 
 const createCoinTransaction = `-- name: CreateCoinTransaction :one
-INSERT INTO coin_transactions (user_id, transaction_type, amount, balance_after, order_id, description)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, user_id, transaction_type, amount, balance_after, order_id, description, created_at
+INSERT INTO coin_transactions (user_id, transaction_type, amount, balance_after, order_id, description, coin_pack_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, user_id, transaction_type, amount, balance_after, order_id, description, created_at, coin_pack_id
 `
 
 type CreateCoinTransactionParams struct {
@@ -24,6 +24,7 @@ type CreateCoinTransactionParams struct {
 	BalanceAfter    int32           `db:"balance_after" json:"balance_after"`
 	OrderID         pgtype.Int4     `db:"order_id" json:"order_id"`
 	Description     pgtype.Text     `db:"description" json:"description"`
+	CoinPackID      pgtype.Int4     `db:"coin_pack_id" json:"coin_pack_id"`
 }
 
 func (q *Queries) CreateCoinTransaction(ctx context.Context, arg CreateCoinTransactionParams) (CoinTransaction, error) {
@@ -34,6 +35,7 @@ func (q *Queries) CreateCoinTransaction(ctx context.Context, arg CreateCoinTrans
 		arg.BalanceAfter,
 		arg.OrderID,
 		arg.Description,
+		arg.CoinPackID,
 	)
 	var i CoinTransaction
 	err := row.Scan(
@@ -45,12 +47,41 @@ func (q *Queries) CreateCoinTransaction(ctx context.Context, arg CreateCoinTrans
 		&i.OrderID,
 		&i.Description,
 		&i.CreatedAt,
+		&i.CoinPackID,
 	)
 	return i, err
 }
 
+const getCoinBalanceAt = `-- name: GetCoinBalanceAt :one
+SELECT COALESCE((
+    SELECT ct.balance_after
+    FROM coin_transactions ct
+    WHERE ct.user_id = u.id
+      AND ct.created_at < $1
+    ORDER BY ct.created_at DESC, ct.id DESC
+    LIMIT 1
+), 0)::integer AS balance
+FROM users u
+WHERE u.id = $2
+`
+
+type GetCoinBalanceAtParams struct {
+	At     pgtype.Timestamptz `db:"at" json:"at"`
+	UserID pgtype.UUID        `db:"user_id" json:"user_id"`
+}
+
+// The balance at a point in time is the balance after the last ledger entry
+// before it, or 0 before the first. Entries at exactly at are left out, as
+// ListCoinBalanceChangesByInterval counts them from created_from.
+func (q *Queries) GetCoinBalanceAt(ctx context.Context, arg GetCoinBalanceAtParams) (int32, error) {
+	row := q.db.QueryRow(ctx, getCoinBalanceAt, arg.At, arg.UserID)
+	var balance int32
+	err := row.Scan(&balance)
+	return balance, err
+}
+
 const getCoinTransactionByID = `-- name: GetCoinTransactionByID :one
-SELECT id, user_id, transaction_type, amount, balance_after, order_id, description, created_at
+SELECT id, user_id, transaction_type, amount, balance_after, order_id, description, created_at, coin_pack_id
 FROM coin_transactions
 WHERE id = $1
 `
@@ -67,12 +98,44 @@ func (q *Queries) GetCoinTransactionByID(ctx context.Context, id int32) (CoinTra
 		&i.OrderID,
 		&i.Description,
 		&i.CreatedAt,
+		&i.CoinPackID,
+	)
+	return i, err
+}
+
+const getCoinTransactionByOrderAndType = `-- name: GetCoinTransactionByOrderAndType :one
+SELECT id, user_id, transaction_type, amount, balance_after, order_id, description, created_at, coin_pack_id
+FROM coin_transactions
+WHERE order_id = $1
+  AND transaction_type = $2
+ORDER BY id
+LIMIT 1
+`
+
+type GetCoinTransactionByOrderAndTypeParams struct {
+	OrderID         pgtype.Int4     `db:"order_id" json:"order_id"`
+	TransactionType TransactionType `db:"transaction_type" json:"transaction_type"`
+}
+
+func (q *Queries) GetCoinTransactionByOrderAndType(ctx context.Context, arg GetCoinTransactionByOrderAndTypeParams) (CoinTransaction, error) {
+	row := q.db.QueryRow(ctx, getCoinTransactionByOrderAndType, arg.OrderID, arg.TransactionType)
+	var i CoinTransaction
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TransactionType,
+		&i.Amount,
+		&i.BalanceAfter,
+		&i.OrderID,
+		&i.Description,
+		&i.CreatedAt,
+		&i.CoinPackID,
 	)
 	return i, err
 }
 
 const getCoinTransactionsByUserID = `-- name: GetCoinTransactionsByUserID :many
-SELECT id, user_id, transaction_type, amount, balance_after, order_id, description, created_at
+SELECT id, user_id, transaction_type, amount, balance_after, order_id, description, created_at, coin_pack_id
 FROM coin_transactions
 WHERE user_id = $1
 ORDER BY created_at DESC
@@ -103,6 +166,7 @@ func (q *Queries) GetCoinTransactionsByUserID(ctx context.Context, arg GetCoinTr
 			&i.OrderID,
 			&i.Description,
 			&i.CreatedAt,
+			&i.CoinPackID,
 		); err != nil {
 			return nil, err
 		}
@@ -113,3 +177,177 @@ func (q *Queries) GetCoinTransactionsByUserID(ctx context.Context, arg GetCoinTr
 	}
 	return items, nil
 }
+
+const listCoinBalanceChangesByInterval = `-- name: ListCoinBalanceChangesByInterval :many
+SELECT date_trunc($1::text, created_at, $2::text)::timestamptz AS bucket_start,
+       SUM(amount)::integer AS net_change,
+       (SUM(SUM(amount)) OVER (ORDER BY date_trunc($1::text, created_at, $2::text)))::integer AS running_change
+FROM coin_transactions
+WHERE user_id = $3
+  AND created_at >= $4
+  AND created_at < $5
+GROUP BY date_trunc($1::text, created_at, $2::text)
+ORDER BY bucket_start
+`
+
+type ListCoinBalanceChangesByIntervalParams struct {
+	Bucket      string             `db:"bucket" json:"bucket"`
+	TimeZone    string             `db:"time_zone" json:"time_zone"`
+	UserID      pgtype.UUID        `db:"user_id" json:"user_id"`
+	CreatedFrom pgtype.Timestamptz `db:"created_from" json:"created_from"`
+	CreatedTo   pgtype.Timestamptz `db:"created_to" json:"created_to"`
+}
+
+type ListCoinBalanceChangesByIntervalRow struct {
+	BucketStart   pgtype.Timestamptz `db:"bucket_start" json:"bucket_start"`
+	NetChange     int32              `db:"net_change" json:"net_change"`
+	RunningChange int32              `db:"running_change" json:"running_change"`
+}
+
+// Net change per bucket, truncated in the caller's timezone, with a running
+// total so each bucket's closing balance is the opening balance plus
+// running_change. Empty buckets are not returned.
+func (q *Queries) ListCoinBalanceChangesByInterval(ctx context.Context, arg ListCoinBalanceChangesByIntervalParams) ([]ListCoinBalanceChangesByIntervalRow, error) {
+	rows, err := q.db.Query(ctx, listCoinBalanceChangesByInterval,
+		arg.Bucket,
+		arg.TimeZone,
+		arg.UserID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCoinBalanceChangesByIntervalRow
+	for rows.Next() {
+		var i ListCoinBalanceChangesByIntervalRow
+		if err := rows.Scan(&i.BucketStart, &i.NetChange, &i.RunningChange); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCoinTransactionsFiltered = `-- name: ListCoinTransactionsFiltered :many
+SELECT id, user_id, transaction_type, amount, balance_after, order_id, description, created_at, coin_pack_id
+FROM coin_transactions
+WHERE user_id = $1
+  AND transaction_type = ANY($2::text[]::transaction_type[])
+  AND created_at >= $3
+  AND created_at < $4
+  AND ($5::integer IS NULL OR order_id = $5)
+  AND ($6::integer = 0 OR SIGN(amount) = $6::integer)
+ORDER BY created_at DESC, id DESC
+LIMIT $8 OFFSET $7
+`
+
+type ListCoinTransactionsFilteredParams struct {
+	UserID           pgtype.UUID        `db:"user_id" json:"user_id"`
+	TransactionTypes []string           `db:"transaction_types" json:"transaction_types"`
+	CreatedFrom      pgtype.Timestamptz `db:"created_from" json:"created_from"`
+	CreatedTo        pgtype.Timestamptz `db:"created_to" json:"created_to"`
+	OrderID          pgtype.Int4        `db:"order_id" json:"order_id"`
+	AmountSign       int32              `db:"amount_sign" json:"amount_sign"`
+	RowOffset        int32              `db:"row_offset" json:"row_offset"`
+	RowLimit         int32              `db:"row_limit" json:"row_limit"`
+}
+
+// The type list and date range are always bound so that idx_coin_transactions_type
+// and idx_coin_transactions_created_at stay usable; callers pass every type and
+// an infinite range when those filters are not set.
+func (q *Queries) ListCoinTransactionsFiltered(ctx context.Context, arg ListCoinTransactionsFilteredParams) ([]CoinTransaction, error) {
+	rows, err := q.db.Query(ctx, listCoinTransactionsFiltered,
+		arg.UserID,
+		arg.TransactionTypes,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.OrderID,
+		arg.AmountSign,
+		arg.RowOffset,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CoinTransaction
+	for rows.Next() {
+		var i CoinTransaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.TransactionType,
+			&i.Amount,
+			&i.BalanceAfter,
+			&i.OrderID,
+			&i.Description,
+			&i.CreatedAt,
+			&i.CoinPackID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const summarizeCoinTransactionsFiltered = `-- name: SummarizeCoinTransactionsFiltered :one
+SELECT
+    COUNT(*)::bigint AS total_count,
+    COALESCE(SUM(amount) FILTER (WHERE transaction_type IN ('charge', 'bonus', 'cashback', 'gift_code', 'referral')), 0)::bigint AS total_charged,
+    COALESCE(-SUM(amount) FILTER (WHERE transaction_type IN ('purchase', 'expiry', 'cashback_reversal')), 0)::bigint AS total_spent,
+    COALESCE(SUM(amount) FILTER (WHERE transaction_type = 'refund'), 0)::bigint AS total_refunded
+FROM coin_transactions
+WHERE user_id = $1
+  AND transaction_type = ANY($2::text[]::transaction_type[])
+  AND created_at >= $3
+  AND created_at < $4
+  AND ($5::integer IS NULL OR order_id = $5)
+  AND ($6::integer = 0 OR SIGN(amount) = $6::integer)
+`
+
+type SummarizeCoinTransactionsFilteredParams struct {
+	UserID           pgtype.UUID        `db:"user_id" json:"user_id"`
+	TransactionTypes []string           `db:"transaction_types" json:"transaction_types"`
+	CreatedFrom      pgtype.Timestamptz `db:"created_from" json:"created_from"`
+	CreatedTo        pgtype.Timestamptz `db:"created_to" json:"created_to"`
+	OrderID          pgtype.Int4        `db:"order_id" json:"order_id"`
+	AmountSign       int32              `db:"amount_sign" json:"amount_sign"`
+}
+
+type SummarizeCoinTransactionsFilteredRow struct {
+	TotalCount    int64 `db:"total_count" json:"total_count"`
+	TotalCharged  int64 `db:"total_charged" json:"total_charged"`
+	TotalSpent    int64 `db:"total_spent" json:"total_spent"`
+	TotalRefunded int64 `db:"total_refunded" json:"total_refunded"`
+}
+
+// Every type falls in exactly one total, so total_charged + total_refunded -
+// total_spent is the net change of the matched entries. Keep the lists in step
+// with entity.CoinTransactionSummary.
+func (q *Queries) SummarizeCoinTransactionsFiltered(ctx context.Context, arg SummarizeCoinTransactionsFilteredParams) (SummarizeCoinTransactionsFilteredRow, error) {
+	row := q.db.QueryRow(ctx, summarizeCoinTransactionsFiltered,
+		arg.UserID,
+		arg.TransactionTypes,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.OrderID,
+		arg.AmountSign,
+	)
+	var i SummarizeCoinTransactionsFilteredRow
+	err := row.Scan(
+		&i.TotalCount,
+		&i.TotalCharged,
+		&i.TotalSpent,
+		&i.TotalRefunded,
+	)
+	return i, err
+}
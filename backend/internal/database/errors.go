@@ -0,0 +1,87 @@
+package database
+
+import (
+	"errors"
+
+	"backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres error codes that TranslateError maps
+const (
+	pgForeignKeyViolation = "23503"
+	pgUniqueViolation     = "23505"
+	pgCheckViolation      = "23514"
+)
+
+// constraintErrors maps constraint names to the domain error a violation of
+// the constraint means. Constraints that are missing fall back to a generic
+// error for the violation's code.
+var constraintErrors = map[string]*domain.Error{
+	"users_email_key":                 domain.ErrEmailAlreadyExists,
+	"users_coins_check":               domain.ErrInsufficientCoins,
+	"users_held_coins_within_balance": domain.ErrInsufficientCoins,
+	"products_stock_quantity_check":   domain.ErrInsufficientStock,
+
+	"cart_items_user_id_fkey":                  domain.ErrUserNotFound,
+	"cart_items_product_id_fkey":               domain.ErrProductNotFound,
+	"coin_transactions_user_id_fkey":           domain.ErrUserNotFound,
+	"coin_transactions_coin_pack_id_fkey":      domain.ErrCoinPackNotFound,
+	"user_spend_limits_user_id_fkey":           domain.ErrUserNotFound,
+	"category_cashback_rates_category_id_fkey": domain.ErrCategoryNotFound,
+
+	"coin_packs_base_coins_check":                   domain.ErrBaseCoinsNotPositive,
+	"coin_packs_bonus_coins_check":                  domain.ErrBonusCoinsNegative,
+	"coin_packs_price_check":                        domain.ErrCoinPackPriceNegative,
+	"user_spend_limits_daily_limit_check":           domain.ErrDailyLimitNegative,
+	"user_spend_limits_per_transaction_limit_check": domain.ErrPerTransactionLimitNegative,
+	"category_cashback_rates_rate_percent_check":    domain.ErrCashbackRateOutOfRange,
+
+	"gift_codes_redemptions_within_max":              domain.ErrGiftCodeFullyRedeemed,
+	"gift_code_redemptions_gift_code_id_user_id_key": domain.ErrGiftCodeAlreadyRedeemed,
+}
+
+// TranslateError maps an error from a query to a domain error. pgx.ErrNoRows
+// becomes notFound when one is given, and unique, check and foreign key
+// violations become the error for the violated constraint. The original error
+// stays reachable with errors.As. Anything else, including errors that are
+// already domain errors, is returned unchanged.
+func TranslateError(err error, notFound *domain.Error) error {
+	if err == nil {
+		return nil
+	}
+
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		return err
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		if notFound == nil {
+			return err
+		}
+		return domain.Wrap(notFound, err)
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	if mapped, ok := constraintErrors[pgErr.ConstraintName]; ok {
+		return domain.Wrap(mapped, err)
+	}
+
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		return domain.Wrap(domain.ErrAlreadyExists, err)
+	case pgCheckViolation:
+		return domain.Wrap(domain.ErrConstraintViolation, err)
+	case pgForeignKeyViolation:
+		return domain.Wrap(domain.ErrReferenceViolation, err)
+	}
+
+	return err
+}
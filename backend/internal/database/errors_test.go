@@ -0,0 +1,94 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		notFound *domain.Error
+		want     error
+	}{
+		{"no rows", pgx.ErrNoRows, domain.ErrUserNotFound, domain.ErrUserNotFound},
+		{"wrapped no rows", fmt.Errorf("scan: %w", pgx.ErrNoRows), domain.ErrOrderNotFound, domain.ErrOrderNotFound},
+		{
+			"unique email",
+			&pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "users_email_key"},
+			nil,
+			domain.ErrEmailAlreadyExists,
+		},
+		{
+			"coins check",
+			&pgconn.PgError{Code: pgCheckViolation, ConstraintName: "users_coins_check"},
+			nil,
+			domain.ErrInsufficientCoins,
+		},
+		{
+			"stock check",
+			&pgconn.PgError{Code: pgCheckViolation, ConstraintName: "products_stock_quantity_check"},
+			nil,
+			domain.ErrInsufficientStock,
+		},
+		{
+			"missing product",
+			&pgconn.PgError{Code: pgForeignKeyViolation, ConstraintName: "cart_items_product_id_fkey"},
+			nil,
+			domain.ErrProductNotFound,
+		},
+		{
+			"other unique",
+			&pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "categories_name_key"},
+			nil,
+			domain.ErrAlreadyExists,
+		},
+		{
+			"other check",
+			&pgconn.PgError{Code: pgCheckViolation, ConstraintName: "cart_items_quantity_check"},
+			nil,
+			domain.ErrConstraintViolation,
+		},
+		{
+			"other foreign key",
+			&pgconn.PgError{Code: pgForeignKeyViolation, ConstraintName: "orders_user_id_fkey"},
+			nil,
+			domain.ErrReferenceViolation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := TranslateError(tt.err, tt.notFound)
+
+			assert.ErrorIs(t, err, tt.want)
+			assert.Equal(t, tt.want.Error(), err.Error())
+			// The original error stays reachable for logging
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestTranslateError_Unchanged(t *testing.T) {
+	plain := errors.New("connection refused")
+	assert.Same(t, plain, TranslateError(plain, domain.ErrUserNotFound))
+
+	// Without a not-found error, no rows is passed through for the caller
+	assert.Same(t, pgx.ErrNoRows, TranslateError(pgx.ErrNoRows, nil))
+
+	deadlock := &pgconn.PgError{Code: "40P01"}
+	assert.Same(t, deadlock, TranslateError(deadlock, nil))
+
+	translated := TranslateError(pgx.ErrNoRows, domain.ErrUserNotFound)
+	assert.Same(t, translated, TranslateError(translated, domain.ErrOrderNotFound))
+
+	assert.NoError(t, TranslateError(nil, domain.ErrUserNotFound))
+}
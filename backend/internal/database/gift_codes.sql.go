@@ -0,0 +1,221 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: gift_codes.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createGiftCode = `-- name: CreateGiftCode :one
+INSERT INTO gift_codes (batch_id, code_hash, code_hint, coin_amount, max_redemptions, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, batch_id, code_hash, code_hint, coin_amount, max_redemptions, redemption_count, expires_at, created_at
+`
+
+type CreateGiftCodeParams struct {
+	BatchID        int32              `db:"batch_id" json:"batch_id"`
+	CodeHash       string             `db:"code_hash" json:"code_hash"`
+	CodeHint       string             `db:"code_hint" json:"code_hint"`
+	CoinAmount     int32              `db:"coin_amount" json:"coin_amount"`
+	MaxRedemptions int32              `db:"max_redemptions" json:"max_redemptions"`
+	ExpiresAt      pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+}
+
+func (q *Queries) CreateGiftCode(ctx context.Context, arg CreateGiftCodeParams) (GiftCode, error) {
+	row := q.db.QueryRow(ctx, createGiftCode,
+		arg.BatchID,
+		arg.CodeHash,
+		arg.CodeHint,
+		arg.CoinAmount,
+		arg.MaxRedemptions,
+		arg.ExpiresAt,
+	)
+	var i GiftCode
+	err := row.Scan(
+		&i.ID,
+		&i.BatchID,
+		&i.CodeHash,
+		&i.CodeHint,
+		&i.CoinAmount,
+		&i.MaxRedemptions,
+		&i.RedemptionCount,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createGiftCodeBatch = `-- name: CreateGiftCodeBatch :one
+INSERT INTO gift_code_batches (description, created_by)
+VALUES ($1, $2)
+RETURNING id, description, created_by, created_at
+`
+
+type CreateGiftCodeBatchParams struct {
+	Description pgtype.Text `db:"description" json:"description"`
+	CreatedBy   pgtype.UUID `db:"created_by" json:"created_by"`
+}
+
+func (q *Queries) CreateGiftCodeBatch(ctx context.Context, arg CreateGiftCodeBatchParams) (GiftCodeBatch, error) {
+	row := q.db.QueryRow(ctx, createGiftCodeBatch, arg.Description, arg.CreatedBy)
+	var i GiftCodeBatch
+	err := row.Scan(
+		&i.ID,
+		&i.Description,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createGiftCodeRedemption = `-- name: CreateGiftCodeRedemption :one
+INSERT INTO gift_code_redemptions (gift_code_id, user_id, coin_transaction_id)
+VALUES ($1, $2, $3)
+RETURNING id, gift_code_id, user_id, coin_transaction_id, created_at
+`
+
+type CreateGiftCodeRedemptionParams struct {
+	GiftCodeID        int32       `db:"gift_code_id" json:"gift_code_id"`
+	UserID            pgtype.UUID `db:"user_id" json:"user_id"`
+	CoinTransactionID int32       `db:"coin_transaction_id" json:"coin_transaction_id"`
+}
+
+func (q *Queries) CreateGiftCodeRedemption(ctx context.Context, arg CreateGiftCodeRedemptionParams) (GiftCodeRedemption, error) {
+	row := q.db.QueryRow(ctx, createGiftCodeRedemption, arg.GiftCodeID, arg.UserID, arg.CoinTransactionID)
+	var i GiftCodeRedemption
+	err := row.Scan(
+		&i.ID,
+		&i.GiftCodeID,
+		&i.UserID,
+		&i.CoinTransactionID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getGiftCodeBatchByID = `-- name: GetGiftCodeBatchByID :one
+SELECT id, description, created_by, created_at
+FROM gift_code_batches
+WHERE id = $1
+`
+
+func (q *Queries) GetGiftCodeBatchByID(ctx context.Context, id int32) (GiftCodeBatch, error) {
+	row := q.db.QueryRow(ctx, getGiftCodeBatchByID, id)
+	var i GiftCodeBatch
+	err := row.Scan(
+		&i.ID,
+		&i.Description,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getGiftCodeByHashForUpdate = `-- name: GetGiftCodeByHashForUpdate :one
+SELECT id, batch_id, code_hash, code_hint, coin_amount, max_redemptions, redemption_count, expires_at, created_at
+FROM gift_codes
+WHERE code_hash = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetGiftCodeByHashForUpdate(ctx context.Context, codeHash string) (GiftCode, error) {
+	row := q.db.QueryRow(ctx, getGiftCodeByHashForUpdate, codeHash)
+	var i GiftCode
+	err := row.Scan(
+		&i.ID,
+		&i.BatchID,
+		&i.CodeHash,
+		&i.CodeHint,
+		&i.CoinAmount,
+		&i.MaxRedemptions,
+		&i.RedemptionCount,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const giftCodeRedeemedByUser = `-- name: GiftCodeRedeemedByUser :one
+SELECT EXISTS(
+    SELECT 1 FROM gift_code_redemptions
+    WHERE gift_code_id = $1 AND user_id = $2
+)
+`
+
+type GiftCodeRedeemedByUserParams struct {
+	GiftCodeID int32       `db:"gift_code_id" json:"gift_code_id"`
+	UserID     pgtype.UUID `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) GiftCodeRedeemedByUser(ctx context.Context, arg GiftCodeRedeemedByUserParams) (bool, error) {
+	row := q.db.QueryRow(ctx, giftCodeRedeemedByUser, arg.GiftCodeID, arg.UserID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const incrementGiftCodeRedemptions = `-- name: IncrementGiftCodeRedemptions :one
+UPDATE gift_codes
+SET redemption_count = redemption_count + 1
+WHERE id = $1
+RETURNING id, batch_id, code_hash, code_hint, coin_amount, max_redemptions, redemption_count, expires_at, created_at
+`
+
+func (q *Queries) IncrementGiftCodeRedemptions(ctx context.Context, id int32) (GiftCode, error) {
+	row := q.db.QueryRow(ctx, incrementGiftCodeRedemptions, id)
+	var i GiftCode
+	err := row.Scan(
+		&i.ID,
+		&i.BatchID,
+		&i.CodeHash,
+		&i.CodeHint,
+		&i.CoinAmount,
+		&i.MaxRedemptions,
+		&i.RedemptionCount,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listGiftCodesByBatchID = `-- name: ListGiftCodesByBatchID :many
+SELECT id, batch_id, code_hash, code_hint, coin_amount, max_redemptions, redemption_count, expires_at, created_at
+FROM gift_codes
+WHERE batch_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListGiftCodesByBatchID(ctx context.Context, batchID int32) ([]GiftCode, error) {
+	rows, err := q.db.Query(ctx, listGiftCodesByBatchID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GiftCode
+	for rows.Next() {
+		var i GiftCode
+		if err := rows.Scan(
+			&i.ID,
+			&i.BatchID,
+			&i.CodeHash,
+			&i.CodeHint,
+			&i.CoinAmount,
+			&i.MaxRedemptions,
+			&i.RedemptionCount,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
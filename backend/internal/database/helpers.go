@@ -2,6 +2,7 @@
 package database
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -69,3 +70,11 @@ func NumericToFloat64(num pgtype.Numeric) float64 {
 	f, _ := num.Float64Value()
 	return f.Float64
 }
+
+func Float64ToNumeric(f float64) pgtype.Numeric {
+	var num pgtype.Numeric
+	if err := num.Scan(strconv.FormatFloat(f, 'f', 2, 64)); err != nil {
+		return pgtype.Numeric{}
+	}
+	return num
+}
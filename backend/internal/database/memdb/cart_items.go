@@ -0,0 +1,122 @@
+package memdb
+
+import (
+	"backend/internal/database"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (q *Queries) CheckCartItemExists(ctx context.Context, arg database.CheckCartItemExistsParams) (bool, error) {
+	var exists bool
+	err := q.read(func(s *state) error {
+		exists = s.cartItems.any(func(c database.CartItem) bool {
+			return sameUUID(c.UserID, arg.UserID) && c.ProductID == arg.ProductID
+		})
+		return nil
+	})
+	return exists, err
+}
+
+func (q *Queries) CreateCartItem(ctx context.Context, arg database.CreateCartItemParams) (database.CartItem, error) {
+	var c database.CartItem
+	err := q.write(ctx, func(s *state) error {
+		c = database.CartItem{
+			ID:        q.store.nextval("cart_items"),
+			UserID:    arg.UserID,
+			ProductID: arg.ProductID,
+			Quantity:  arg.Quantity,
+			CreatedAt: storedTime(arg.CreatedAt),
+			UpdatedAt: storedTime(arg.UpdatedAt),
+		}
+		return insertCartItem(s, c)
+	})
+	if err != nil {
+		return database.CartItem{}, err
+	}
+	return c, nil
+}
+
+func (q *Queries) DeleteAllCartItemsByUser(ctx context.Context, userID pgtype.UUID) error {
+	return q.deleteCartItems(ctx, func(c database.CartItem) bool { return sameUUID(c.UserID, userID) })
+}
+
+func (q *Queries) DeleteCartItem(ctx context.Context, arg database.DeleteCartItemParams) error {
+	return q.deleteCartItems(ctx, func(c database.CartItem) bool {
+		return sameUUID(c.UserID, arg.UserID) && c.ProductID == arg.ProductID
+	})
+}
+
+func (q *Queries) deleteCartItems(ctx context.Context, match func(database.CartItem) bool) error {
+	return q.write(ctx, func(s *state) error {
+		for _, c := range s.cartItems.filter(match) {
+			del(s, &s.cartItems, c.ID)
+		}
+		return nil
+	})
+}
+
+// GetCartItemsByUser returns the user's items, most recently added first
+func (q *Queries) GetCartItemsByUser(ctx context.Context, userID pgtype.UUID) ([]database.CartItem, error) {
+	var items []database.CartItem
+	err := q.read(func(s *state) error {
+		items = s.cartItems.filter(func(c database.CartItem) bool { return sameUUID(c.UserID, userID) })
+		return nil
+	})
+	slices.SortFunc(items, func(a, b database.CartItem) int {
+		return cmp.Or(-compareTime(a.CreatedAt, b.CreatedAt), -cmp.Compare(a.ID, b.ID))
+	})
+	return items, err
+}
+
+// UpdateCartItemQuantity sets the quantity; updated_at is set by the trigger,
+// whatever the statement passes
+func (q *Queries) UpdateCartItemQuantity(ctx context.Context, arg database.UpdateCartItemQuantityParams) (database.CartItem, error) {
+	var item database.CartItem
+	err := q.write(ctx, func(s *state) error {
+		found := s.cartItems.filter(func(c database.CartItem) bool {
+			return sameUUID(c.UserID, arg.UserID) && c.ProductID == arg.ProductID
+		})
+		if len(found) == 0 {
+			return pgx.ErrNoRows
+		}
+		item = found[0]
+		item.Quantity = arg.Quantity
+		item.UpdatedAt = s.now
+		return saveCartItem(s, item)
+	})
+	if err != nil {
+		return database.CartItem{}, err
+	}
+	return item, nil
+}
+
+func insertCartItem(s *state, c database.CartItem) error {
+	if err := unique("cart_items", "cart_items_pkey", s.cartItems.has(c.ID), fmt.Sprintf("(id)=(%d)", c.ID)); err != nil {
+		return err
+	}
+	return saveCartItem(s, c)
+}
+
+func saveCartItem(s *state, c database.CartItem) error {
+	err := firstError(
+		notNull("cart_items", "user_id", c.UserID.Valid),
+		check("cart_items", "cart_items_quantity_check", c.Quantity > 0),
+		unique("cart_items", "cart_items_user_id_product_id_key",
+			s.cartItems.any(func(o database.CartItem) bool {
+				return o.ID != c.ID && sameUUID(o.UserID, c.UserID) && o.ProductID == c.ProductID
+			}),
+			fmt.Sprintf("(user_id, product_id)=(%s, %d)", uuidString(c.UserID), c.ProductID)),
+		references("cart_items", "user_id", "users", s.users.has(c.UserID.Bytes), uuidString(c.UserID)),
+		references("cart_items", "product_id", "products", s.products.has(c.ProductID), c.ProductID),
+	)
+	if err != nil {
+		return err
+	}
+	put(s, &s.cartItems, c.ID, c)
+	return nil
+}
@@ -0,0 +1,68 @@
+package memdb
+
+import (
+	"backend/internal/database"
+	"cmp"
+	"context"
+	"slices"
+)
+
+// DeleteCategoryCashbackRate returns the number of rates deleted
+func (q *Queries) DeleteCategoryCashbackRate(ctx context.Context, categoryID int32) (int64, error) {
+	var deleted int64
+	err := q.write(ctx, func(s *state) error {
+		if s.cashbackRates.has(categoryID) {
+			del(s, &s.cashbackRates, categoryID)
+			deleted = 1
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+func (q *Queries) ListCategoryCashbackRates(ctx context.Context) ([]database.CategoryCashbackRate, error) {
+	var rates []database.CategoryCashbackRate
+	err := q.read(func(s *state) error {
+		rates = s.cashbackRates.filter(func(database.CategoryCashbackRate) bool { return true })
+		return nil
+	})
+	slices.SortFunc(rates, func(a, b database.CategoryCashbackRate) int { return cmp.Compare(a.CategoryID, b.CategoryID) })
+	return rates, err
+}
+
+func (q *Queries) UpsertCategoryCashbackRate(ctx context.Context, arg database.UpsertCategoryCashbackRateParams) (database.CategoryCashbackRate, error) {
+	var rate database.CategoryCashbackRate
+	err := q.write(ctx, func(s *state) error {
+		percent, err := decimal(arg.RatePercent, 5, 2)
+		if err != nil {
+			return err
+		}
+
+		var ok bool
+		if rate, ok = s.cashbackRates.get(arg.CategoryID); ok {
+			rate.UpdatedAt = s.now
+		} else {
+			rate = database.CategoryCashbackRate{CategoryID: arg.CategoryID, CreatedAt: s.now, UpdatedAt: s.now}
+		}
+		rate.RatePercent = percent
+
+		err = firstError(
+			notNull("category_cashback_rates", "rate_percent", rate.RatePercent.Valid),
+			check("category_cashback_rates", "category_cashback_rates_rate_percent_check",
+				compareNumeric(rate.RatePercent, 2, 0) >= 0 && compareNumeric(rate.RatePercent, 2, 100) <= 0),
+			references("category_cashback_rates", "category_id", "categories", s.categories.has(rate.CategoryID), rate.CategoryID),
+		)
+		if err != nil {
+			return err
+		}
+		put(s, &s.cashbackRates, rate.CategoryID, rate)
+		return nil
+	})
+	if err != nil {
+		return database.CategoryCashbackRate{}, err
+	}
+	return rate, nil
+}
@@ -0,0 +1,128 @@
+package memdb
+
+import (
+	"backend/internal/database"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (q *Queries) CreateCoinHold(ctx context.Context, arg database.CreateCoinHoldParams) (database.CoinHold, error) {
+	var h database.CoinHold
+	err := q.write(ctx, func(s *state) error {
+		h = database.CoinHold{
+			ID:          q.store.nextval("coin_holds"),
+			UserID:      arg.UserID,
+			Amount:      arg.Amount,
+			Description: arg.Description,
+			OrderID:     arg.OrderID,
+			Status:      database.CoinHoldStatusActive,
+			ExpiresAt:   storedTime(arg.ExpiresAt),
+			CreatedAt:   s.now,
+			UpdatedAt:   s.now,
+		}
+		if err := unique("coin_holds", "coin_holds_pkey", s.coinHolds.has(h.ID), fmt.Sprintf("(id)=(%d)", h.ID)); err != nil {
+			return err
+		}
+		return saveCoinHold(s, h)
+	})
+	if err != nil {
+		return database.CoinHold{}, err
+	}
+	return h, nil
+}
+
+func (q *Queries) GetCoinHoldByID(ctx context.Context, id int32) (database.CoinHold, error) {
+	return get(q, func(s *state) *table[int32, database.CoinHold] { return s.coinHolds }, id)
+}
+
+func (q *Queries) GetCoinHoldForUpdate(ctx context.Context, id int32) (database.CoinHold, error) {
+	return get(q, func(s *state) *table[int32, database.CoinHold] { return s.coinHolds }, id)
+}
+
+func (q *Queries) ListCoinHoldsByUserID(ctx context.Context, arg database.ListCoinHoldsByUserIDParams) ([]database.CoinHold, error) {
+	var holds []database.CoinHold
+	err := q.read(func(s *state) error {
+		holds = s.coinHolds.filter(func(h database.CoinHold) bool { return sameUUID(h.UserID, arg.UserID) })
+		slices.SortFunc(holds, func(a, b database.CoinHold) int {
+			return cmp.Or(-compareTime(a.CreatedAt, b.CreatedAt), -cmp.Compare(a.ID, b.ID))
+		})
+		var err error
+		holds, err = page(holds, arg.Limit, arg.Offset)
+		return err
+	})
+	return holds, err
+}
+
+// ListActiveCoinHoldsByOrderID returns the order's active holds, oldest first
+func (q *Queries) ListActiveCoinHoldsByOrderID(ctx context.Context, orderID pgtype.Int4) ([]database.CoinHold, error) {
+	var holds []database.CoinHold
+	err := q.read(func(s *state) error {
+		holds = s.coinHolds.filter(func(h database.CoinHold) bool {
+			return h.Status == database.CoinHoldStatusActive && orderID.Valid && h.OrderID == orderID
+		})
+		return nil
+	})
+	slices.SortFunc(holds, func(a, b database.CoinHold) int { return cmp.Compare(a.ID, b.ID) })
+	return holds, err
+}
+
+// ListExpiredCoinHolds returns active holds that expired by expires_at,
+// soonest expiry first
+func (q *Queries) ListExpiredCoinHolds(ctx context.Context, arg database.ListExpiredCoinHoldsParams) ([]database.CoinHold, error) {
+	var holds []database.CoinHold
+	err := q.read(func(s *state) error {
+		holds = s.coinHolds.filter(func(h database.CoinHold) bool {
+			return h.Status == database.CoinHoldStatusActive && atOrBefore(h.ExpiresAt, arg.ExpiresAt)
+		})
+		slices.SortFunc(holds, func(a, b database.CoinHold) int {
+			return cmp.Or(compareTime(a.ExpiresAt, b.ExpiresAt), cmp.Compare(a.ID, b.ID))
+		})
+		var err error
+		holds, err = page(holds, arg.Limit, 0)
+		return err
+	})
+	return holds, err
+}
+
+func (q *Queries) UpdateCoinHoldStatus(ctx context.Context, arg database.UpdateCoinHoldStatusParams) (database.CoinHold, error) {
+	var h database.CoinHold
+	err := q.write(ctx, func(s *state) error {
+		if err := coinHoldStatus(arg.Status); err != nil {
+			return err
+		}
+		var ok bool
+		if h, ok = s.coinHolds.get(arg.ID); !ok {
+			return pgx.ErrNoRows
+		}
+		h.Status = arg.Status
+		h.CoinTransactionID = arg.CoinTransactionID
+		h.UpdatedAt = s.now
+		return saveCoinHold(s, h)
+	})
+	if err != nil {
+		return database.CoinHold{}, err
+	}
+	return h, nil
+}
+
+func saveCoinHold(s *state, h database.CoinHold) error {
+	err := firstError(
+		notNull("coin_holds", "user_id", h.UserID.Valid),
+		notNull("coin_holds", "expires_at", h.ExpiresAt.Valid),
+		check("coin_holds", "coin_holds_amount_check", h.Amount > 0),
+		references("coin_holds", "user_id", "users", s.users.has(h.UserID.Bytes), uuidString(h.UserID)),
+		references("coin_holds", "order_id", "orders", !h.OrderID.Valid || s.orders.has(h.OrderID.Int32), h.OrderID.Int32),
+		references("coin_holds", "coin_transaction_id", "coin_transactions",
+			!h.CoinTransactionID.Valid || s.coinTransactions.has(h.CoinTransactionID.Int32), h.CoinTransactionID.Int32),
+	)
+	if err != nil {
+		return err
+	}
+	put(s, &s.coinHolds, h.ID, h)
+	return nil
+}
@@ -0,0 +1,165 @@
+package memdb
+
+import (
+	"backend/internal/database"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (q *Queries) CreateCoinLot(ctx context.Context, arg database.CreateCoinLotParams) (database.CoinLot, error) {
+	var l database.CoinLot
+	err := q.write(ctx, func(s *state) error {
+		l = database.CoinLot{
+			ID:                q.store.nextval("coin_lots"),
+			UserID:            arg.UserID,
+			CoinTransactionID: arg.CoinTransactionID,
+			Source:            arg.Source,
+			OriginalAmount:    arg.OriginalAmount,
+			RemainingAmount:   arg.OriginalAmount,
+			ExpiresAt:         storedTime(arg.ExpiresAt),
+			CreatedAt:         s.now,
+		}
+		return insertCoinLot(s, l)
+	})
+	if err != nil {
+		return database.CoinLot{}, err
+	}
+	return l, nil
+}
+
+func (q *Queries) GetCoinLotForUpdate(ctx context.Context, id int32) (database.CoinLot, error) {
+	return get(q, func(s *state) *table[int32, database.CoinLot] { return s.coinLots }, id)
+}
+
+func (q *Queries) GetExpiredCoinLotTotal(ctx context.Context, arg database.GetExpiredCoinLotTotalParams) (int32, error) {
+	var total int32
+	err := q.read(func(s *state) error {
+		var sum int64
+		for _, l := range s.coinLots.rows {
+			if sameUUID(l.UserID, arg.UserID) && l.RemainingAmount > 0 && atOrBefore(l.ExpiresAt, arg.ExpiresAt) {
+				sum += int64(l.RemainingAmount)
+			}
+		}
+		var err error
+		total, err = integer(sum)
+		return err
+	})
+	return total, err
+}
+
+// GetUpcomingCoinExpiries sums the user's open lots by the UTC date they
+// expire on, which is the time zone the service's connections use
+func (q *Queries) GetUpcomingCoinExpiries(ctx context.Context, arg database.GetUpcomingCoinExpiriesParams) ([]database.GetUpcomingCoinExpiriesRow, error) {
+	var rows []database.GetUpcomingCoinExpiriesRow
+	err := q.read(func(s *state) error {
+		sums := make(map[pgtype.Date]int64)
+		for _, l := range s.coinLots.rows {
+			if sameUUID(l.UserID, arg.UserID) && l.RemainingAmount > 0 && after(l.ExpiresAt, arg.ExpiresAt) {
+				sums[dateOf(l.ExpiresAt)] += int64(l.RemainingAmount)
+			}
+		}
+		for day, sum := range sums {
+			amount, err := integer(sum)
+			if err != nil {
+				return err
+			}
+			rows = append(rows, database.GetUpcomingCoinExpiriesRow{ExpiresOn: day, Amount: amount})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(rows, func(a, b database.GetUpcomingCoinExpiriesRow) int {
+		return cmp.Or(cmp.Compare(a.ExpiresOn.InfinityModifier, b.ExpiresOn.InfinityModifier), a.ExpiresOn.Time.Compare(b.ExpiresOn.Time))
+	})
+	return rows, nil
+}
+
+// dateOf is t::date
+func dateOf(t pgtype.Timestamptz) pgtype.Date {
+	if t.InfinityModifier != pgtype.Finite {
+		return pgtype.Date{InfinityModifier: t.InfinityModifier, Valid: true}
+	}
+	utc := t.Time.UTC()
+	return pgtype.Date{Time: time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, time.UTC), Valid: true}
+}
+
+// ListExpiredCoinLots returns open lots that expired by expires_at, soonest
+// expiry first, of users with coins that are not held
+func (q *Queries) ListExpiredCoinLots(ctx context.Context, arg database.ListExpiredCoinLotsParams) ([]database.CoinLot, error) {
+	var lots []database.CoinLot
+	err := q.read(func(s *state) error {
+		lots = s.coinLots.filter(func(l database.CoinLot) bool {
+			u, _ := s.users.get(l.UserID.Bytes)
+			return l.RemainingAmount > 0 && atOrBefore(l.ExpiresAt, arg.ExpiresAt) &&
+				u.Coins.Int32 > u.HeldCoins
+		})
+		slices.SortFunc(lots, byExpiry)
+		var err error
+		lots, err = page(lots, arg.Limit, 0)
+		return err
+	})
+	return lots, err
+}
+
+// ListSpendableCoinLotsForUpdate returns the user's open lots that have not
+// expired, in the order they are spent: oldest grant first
+func (q *Queries) ListSpendableCoinLotsForUpdate(ctx context.Context, arg database.ListSpendableCoinLotsForUpdateParams) ([]database.CoinLot, error) {
+	var lots []database.CoinLot
+	err := q.read(func(s *state) error {
+		lots = s.coinLots.filter(func(l database.CoinLot) bool {
+			return sameUUID(l.UserID, arg.UserID) && l.RemainingAmount > 0 &&
+				(!l.ExpiresAt.Valid || after(l.ExpiresAt, arg.ExpiresAt))
+		})
+		return nil
+	})
+	slices.SortFunc(lots, func(a, b database.CoinLot) int {
+		return cmp.Or(compareTime(a.CreatedAt, b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+	return lots, err
+}
+
+func byExpiry(a, b database.CoinLot) int {
+	return cmp.Or(compareTime(a.ExpiresAt, b.ExpiresAt), cmp.Compare(a.ID, b.ID))
+}
+
+func (q *Queries) UpdateCoinLotRemaining(ctx context.Context, arg database.UpdateCoinLotRemainingParams) error {
+	return q.write(ctx, func(s *state) error {
+		l, ok := s.coinLots.get(arg.ID)
+		if !ok {
+			return nil
+		}
+		l.RemainingAmount = arg.RemainingAmount
+		return saveCoinLot(s, l)
+	})
+}
+
+func insertCoinLot(s *state, l database.CoinLot) error {
+	if err := unique("coin_lots", "coin_lots_pkey", s.coinLots.has(l.ID), fmt.Sprintf("(id)=(%d)", l.ID)); err != nil {
+		return err
+	}
+	return saveCoinLot(s, l)
+}
+
+func saveCoinLot(s *state, l database.CoinLot) error {
+	err := firstError(
+		transactionType(l.Source),
+		notNull("coin_lots", "user_id", l.UserID.Valid),
+		check("coin_lots", "coin_lots_original_amount_check", l.OriginalAmount > 0),
+		check("coin_lots", "coin_lots_remaining_amount_check", l.RemainingAmount >= 0 && l.RemainingAmount <= l.OriginalAmount),
+		references("coin_lots", "user_id", "users", s.users.has(l.UserID.Bytes), uuidString(l.UserID)),
+		references("coin_lots", "coin_transaction_id", "coin_transactions",
+			!l.CoinTransactionID.Valid || s.coinTransactions.has(l.CoinTransactionID.Int32), l.CoinTransactionID.Int32),
+	)
+	if err != nil {
+		return err
+	}
+	put(s, &s.coinLots, l.ID, l)
+	return nil
+}
@@ -0,0 +1,108 @@
+package memdb
+
+import (
+	"backend/internal/database"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (q *Queries) CreateCoinPack(ctx context.Context, arg database.CreateCoinPackParams) (database.CoinPack, error) {
+	var p database.CoinPack
+	err := q.write(ctx, func(s *state) error {
+		p = database.CoinPack{
+			ID:         q.store.nextval("coin_packs"),
+			Name:       arg.Name,
+			BaseCoins:  arg.BaseCoins,
+			BonusCoins: arg.BonusCoins,
+			Price:      arg.Price,
+			IsActive:   arg.IsActive,
+			SortOrder:  arg.SortOrder,
+			CreatedAt:  s.now,
+			UpdatedAt:  s.now,
+		}
+		if err := unique("coin_packs", "coin_packs_pkey", s.coinPacks.has(p.ID), fmt.Sprintf("(id)=(%d)", p.ID)); err != nil {
+			return err
+		}
+		var err error
+		p, err = saveCoinPack(s, p)
+		return err
+	})
+	if err != nil {
+		return database.CoinPack{}, err
+	}
+	return p, nil
+}
+
+func (q *Queries) GetCoinPackByID(ctx context.Context, id int32) (database.CoinPack, error) {
+	return get(q, func(s *state) *table[int32, database.CoinPack] { return s.coinPacks }, id)
+}
+
+func (q *Queries) ListActiveCoinPacks(ctx context.Context) ([]database.CoinPack, error) {
+	return q.listCoinPacks(func(p database.CoinPack) bool { return p.IsActive })
+}
+
+func (q *Queries) ListCoinPacks(ctx context.Context) ([]database.CoinPack, error) {
+	return q.listCoinPacks(func(database.CoinPack) bool { return true })
+}
+
+func (q *Queries) listCoinPacks(match func(database.CoinPack) bool) ([]database.CoinPack, error) {
+	var packs []database.CoinPack
+	err := q.read(func(s *state) error {
+		packs = s.coinPacks.filter(match)
+		return nil
+	})
+	slices.SortFunc(packs, func(a, b database.CoinPack) int {
+		return cmp.Or(cmp.Compare(a.SortOrder, b.SortOrder), cmp.Compare(a.ID, b.ID))
+	})
+	return packs, err
+}
+
+func (q *Queries) UpdateCoinPack(ctx context.Context, arg database.UpdateCoinPackParams) (database.CoinPack, error) {
+	var p database.CoinPack
+	err := q.write(ctx, func(s *state) error {
+		var ok bool
+		if p, ok = s.coinPacks.get(arg.ID); !ok {
+			return pgx.ErrNoRows
+		}
+		p.Name = arg.Name
+		p.BaseCoins = arg.BaseCoins
+		p.BonusCoins = arg.BonusCoins
+		p.Price = arg.Price
+		p.IsActive = arg.IsActive
+		p.SortOrder = arg.SortOrder
+		p.UpdatedAt = s.now
+		var err error
+		p, err = saveCoinPack(s, p)
+		return err
+	})
+	if err != nil {
+		return database.CoinPack{}, err
+	}
+	return p, nil
+}
+
+// saveCoinPack stores p and returns it with its price as stored
+func saveCoinPack(s *state, p database.CoinPack) (database.CoinPack, error) {
+	price, err := decimal(p.Price, 10, 2)
+	if err != nil {
+		return database.CoinPack{}, err
+	}
+	p.Price = price
+
+	err = firstError(
+		varchar(p.Name, 100),
+		notNull("coin_packs", "price", p.Price.Valid),
+		check("coin_packs", "coin_packs_base_coins_check", p.BaseCoins > 0),
+		check("coin_packs", "coin_packs_bonus_coins_check", p.BonusCoins >= 0),
+		check("coin_packs", "coin_packs_price_check", !p.Price.Valid || compareNumeric(p.Price, 2, 0) >= 0),
+	)
+	if err != nil {
+		return database.CoinPack{}, err
+	}
+	put(s, &s.coinPacks, p.ID, p)
+	return p, nil
+}
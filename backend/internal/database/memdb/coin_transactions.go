@@ -0,0 +1,331 @@
+package memdb
+
+import (
+	"backend/internal/database"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (q *Queries) CreateCoinTransaction(ctx context.Context, arg database.CreateCoinTransactionParams) (database.CoinTransaction, error) {
+	var t database.CoinTransaction
+	err := q.write(ctx, func(s *state) error {
+		t = database.CoinTransaction{
+			ID:              q.store.nextval("coin_transactions"),
+			UserID:          arg.UserID,
+			TransactionType: arg.TransactionType,
+			Amount:          arg.Amount,
+			BalanceAfter:    arg.BalanceAfter,
+			OrderID:         arg.OrderID,
+			Description:     arg.Description,
+			CreatedAt:       s.now,
+			CoinPackID:      arg.CoinPackID,
+		}
+		return insertCoinTransaction(s, t)
+	})
+	if err != nil {
+		return database.CoinTransaction{}, err
+	}
+	return t, nil
+}
+
+// GetCoinBalanceAt backs the user's entries from at onwards out of their
+// current balance
+func (q *Queries) GetCoinBalanceAt(ctx context.Context, arg database.GetCoinBalanceAtParams) (int32, error) {
+	var balance int32
+	err := q.read(func(s *state) error {
+		u, ok := s.users.get(arg.UserID.Bytes)
+		if !ok || !arg.UserID.Valid {
+			return pgx.ErrNoRows
+		}
+		var last *database.CoinTransaction
+		for _, t := range s.coinTransactions.rows {
+			if !sameUUID(t.UserID, u.ID) || atOrAfter(t.CreatedAt, arg.At) {
+				continue
+			}
+			if last == nil || cmp.Or(compareTime(t.CreatedAt, last.CreatedAt), cmp.Compare(t.ID, last.ID)) > 0 {
+				last = &t
+			}
+		}
+		if last != nil {
+			balance = last.BalanceAfter
+		}
+		return nil
+	})
+	return balance, err
+}
+
+func (q *Queries) GetCoinTransactionByID(ctx context.Context, id int32) (database.CoinTransaction, error) {
+	return get(q, func(s *state) *table[int32, database.CoinTransaction] { return s.coinTransactions }, id)
+}
+
+func (q *Queries) GetCoinTransactionByOrderAndType(ctx context.Context, arg database.GetCoinTransactionByOrderAndTypeParams) (database.CoinTransaction, error) {
+	var found []database.CoinTransaction
+	err := q.read(func(s *state) error {
+		if err := transactionType(arg.TransactionType); err != nil {
+			return err
+		}
+		found = s.coinTransactions.filter(func(t database.CoinTransaction) bool {
+			return sameInt4(t.OrderID, arg.OrderID) && t.TransactionType == arg.TransactionType
+		})
+		return nil
+	})
+	if err != nil {
+		return database.CoinTransaction{}, err
+	}
+	if len(found) == 0 {
+		return database.CoinTransaction{}, pgx.ErrNoRows
+	}
+	return slices.MinFunc(found, func(a, b database.CoinTransaction) int { return cmp.Compare(a.ID, b.ID) }), nil
+}
+
+func (q *Queries) GetCoinTransactionsByUserID(ctx context.Context, arg database.GetCoinTransactionsByUserIDParams) ([]database.CoinTransaction, error) {
+	var txs []database.CoinTransaction
+	err := q.read(func(s *state) error {
+		txs = s.coinTransactions.filter(func(t database.CoinTransaction) bool { return sameUUID(t.UserID, arg.UserID) })
+		slices.SortFunc(txs, newestTransactionFirst)
+		var err error
+		txs, err = page(txs, arg.Limit, arg.Offset)
+		return err
+	})
+	return txs, err
+}
+
+// ListCoinBalanceChangesByInterval groups the user's entries in the range by
+// date_trunc(bucket, created_at, time_zone), oldest bucket first
+func (q *Queries) ListCoinBalanceChangesByInterval(ctx context.Context, arg database.ListCoinBalanceChangesByIntervalParams) ([]database.ListCoinBalanceChangesByIntervalRow, error) {
+	var rows []database.ListCoinBalanceChangesByIntervalRow
+	err := q.read(func(s *state) error {
+		truncate, err := dateTrunc(arg.Bucket, arg.TimeZone)
+		if err != nil {
+			return err
+		}
+
+		var buckets []pgtype.Timestamptz
+		net := make(map[pgtype.Timestamptz]int64)
+		for _, t := range s.coinTransactions.rows {
+			if !sameUUID(t.UserID, arg.UserID) || !within(t.CreatedAt, arg.CreatedFrom, arg.CreatedTo) {
+				continue
+			}
+			bucket := truncate(t.CreatedAt)
+			if _, ok := net[bucket]; !ok {
+				buckets = append(buckets, bucket)
+			}
+			net[bucket] += int64(t.Amount)
+		}
+		slices.SortFunc(buckets, compareTime)
+
+		var running int64
+		for _, bucket := range buckets {
+			running += net[bucket]
+			change, err := integer(net[bucket])
+			if err != nil {
+				return err
+			}
+			total, err := integer(running)
+			if err != nil {
+				return err
+			}
+			rows = append(rows, database.ListCoinBalanceChangesByIntervalRow{BucketStart: bucket, NetChange: change, RunningChange: total})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (q *Queries) ListCoinTransactionsFiltered(ctx context.Context, arg database.ListCoinTransactionsFilteredParams) ([]database.CoinTransaction, error) {
+	var txs []database.CoinTransaction
+	err := q.read(func(s *state) error {
+		var err error
+		txs, err = filterCoinTransactions(s, coinTransactionFilter{
+			UserID:           arg.UserID,
+			TransactionTypes: arg.TransactionTypes,
+			CreatedFrom:      arg.CreatedFrom,
+			CreatedTo:        arg.CreatedTo,
+			OrderID:          arg.OrderID,
+			AmountSign:       arg.AmountSign,
+		})
+		if err != nil {
+			return err
+		}
+		slices.SortFunc(txs, newestTransactionFirst)
+		txs, err = page(txs, arg.RowLimit, arg.RowOffset)
+		return err
+	})
+	return txs, err
+}
+
+func (q *Queries) SummarizeCoinTransactionsFiltered(ctx context.Context, arg database.SummarizeCoinTransactionsFilteredParams) (database.SummarizeCoinTransactionsFilteredRow, error) {
+	var txs []database.CoinTransaction
+	err := q.read(func(s *state) error {
+		var err error
+		txs, err = filterCoinTransactions(s, coinTransactionFilter(arg))
+		return err
+	})
+	if err != nil {
+		return database.SummarizeCoinTransactionsFilteredRow{}, err
+	}
+
+	summary := database.SummarizeCoinTransactionsFilteredRow{TotalCount: int64(len(txs))}
+	for _, t := range txs {
+		switch t.TransactionType {
+		case database.TransactionTypeCharge, database.TransactionTypeBonus, database.TransactionTypeCashback,
+			database.TransactionTypeGiftCode, database.TransactionTypeReferral:
+			summary.TotalCharged += int64(t.Amount)
+		case database.TransactionTypePurchase, database.TransactionTypeExpiry, database.TransactionTypeCashbackReversal:
+			summary.TotalSpent -= int64(t.Amount)
+		case database.TransactionTypeRefund:
+			summary.TotalRefunded += int64(t.Amount)
+		}
+	}
+	return summary, nil
+}
+
+// coinTransactionFilter is the WHERE clause the filtered list and its summary
+// share
+type coinTransactionFilter struct {
+	UserID           pgtype.UUID
+	TransactionTypes []string
+	CreatedFrom      pgtype.Timestamptz
+	CreatedTo        pgtype.Timestamptz
+	OrderID          pgtype.Int4
+	AmountSign       int32
+}
+
+func filterCoinTransactions(s *state, f coinTransactionFilter) ([]database.CoinTransaction, error) {
+	// The types are cast to transaction_type[], which fails on any that is not
+	// a label even if no row would match it
+	types := make(map[database.TransactionType]bool, len(f.TransactionTypes))
+	for _, typ := range f.TransactionTypes {
+		if err := transactionType(database.TransactionType(typ)); err != nil {
+			return nil, err
+		}
+		types[database.TransactionType(typ)] = true
+	}
+
+	return s.coinTransactions.filter(func(t database.CoinTransaction) bool {
+		return sameUUID(t.UserID, f.UserID) &&
+			types[t.TransactionType] &&
+			within(t.CreatedAt, f.CreatedFrom, f.CreatedTo) &&
+			(!f.OrderID.Valid || sameInt4(t.OrderID, f.OrderID)) &&
+			(f.AmountSign == 0 || int32(cmp.Compare(t.Amount, 0)) == f.AmountSign)
+	}), nil
+}
+
+// StreamCoinTransactionsForStatement calls fn for each of the user's
+// transactions in [CreatedFrom, CreatedTo), oldest first
+func (q *Queries) StreamCoinTransactionsForStatement(ctx context.Context, arg database.StreamCoinTransactionsForStatementParams, fn func(database.CoinTransaction) error) error {
+	var txs []database.CoinTransaction
+	err := q.read(func(s *state) error {
+		txs = s.coinTransactions.filter(func(t database.CoinTransaction) bool {
+			return sameUUID(t.UserID, arg.UserID) && within(t.CreatedAt, arg.CreatedFrom, arg.CreatedTo)
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	slices.SortFunc(txs, func(a, b database.CoinTransaction) int {
+		return cmp.Or(compareTime(a.CreatedAt, b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+	for _, t := range txs {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newestTransactionFirst(a, b database.CoinTransaction) int {
+	return cmp.Or(-compareTime(a.CreatedAt, b.CreatedAt), -cmp.Compare(a.ID, b.ID))
+}
+
+// integer casts a sum to integer
+func integer(n int64) (int32, error) {
+	if n != int64(int32(n)) {
+		return 0, pgError(pgNumericValueOutOfRange, "integer out of range")
+	}
+	return int32(n), nil
+}
+
+// dateTrunc returns date_trunc(unit, _, zone) for the units and IANA time
+// zones the service uses
+func dateTrunc(unit, zone string) (func(pgtype.Timestamptz) pgtype.Timestamptz, error) {
+	if zone == "" || zone == "Local" {
+		return nil, pgError(pgInvalidParameterValue, fmt.Sprintf("time zone %q not recognized", zone))
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, pgError(pgInvalidParameterValue, fmt.Sprintf("time zone %q not recognized", zone))
+	}
+
+	var trunc func(t time.Time) time.Time
+	switch strings.TrimSuffix(strings.ToLower(unit), "s") {
+	case "second":
+		trunc = func(t time.Time) time.Time { return t.Truncate(time.Second) }
+	case "minute":
+		trunc = func(t time.Time) time.Time {
+			return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+		}
+	case "hour":
+		trunc = func(t time.Time) time.Time { return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc) }
+	case "day":
+		trunc = func(t time.Time) time.Time { return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc) }
+	case "week":
+		// ISO weeks start on Monday
+		trunc = func(t time.Time) time.Time {
+			back := (int(t.Weekday()) + 6) % 7
+			return time.Date(t.Year(), t.Month(), t.Day()-back, 0, 0, 0, 0, loc)
+		}
+	case "month":
+		trunc = func(t time.Time) time.Time { return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc) }
+	case "quarter":
+		trunc = func(t time.Time) time.Time {
+			return time.Date(t.Year(), (t.Month()-1)/3*3+1, 1, 0, 0, 0, 0, loc)
+		}
+	case "year":
+		trunc = func(t time.Time) time.Time { return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, loc) }
+	default:
+		return nil, pgError(pgInvalidParameterValue, fmt.Sprintf("unit %q not recognized for type timestamp with time zone", unit))
+	}
+
+	return func(t pgtype.Timestamptz) pgtype.Timestamptz {
+		if !t.Valid || t.InfinityModifier != pgtype.Finite {
+			return t
+		}
+		return timestamptz(trunc(t.Time.In(loc)))
+	}, nil
+}
+
+func insertCoinTransaction(s *state, t database.CoinTransaction) error {
+	cashback := t.TransactionType == database.TransactionTypeCashback ||
+		t.TransactionType == database.TransactionTypeCashbackReversal
+	err := firstError(
+		transactionType(t.TransactionType),
+		varchar(t.Description.String, 255),
+		notNull("coin_transactions", "user_id", t.UserID.Valid),
+		unique("coin_transactions", "coin_transactions_pkey", s.coinTransactions.has(t.ID), fmt.Sprintf("(id)=(%d)", t.ID)),
+		unique("coin_transactions", "idx_coin_transactions_order_cashback",
+			cashback && s.coinTransactions.any(func(o database.CoinTransaction) bool {
+				return sameInt4(o.OrderID, t.OrderID) && o.TransactionType == t.TransactionType
+			}),
+			fmt.Sprintf("(order_id, transaction_type)=(%d, %s)", t.OrderID.Int32, t.TransactionType)),
+		references("coin_transactions", "user_id", "users", s.users.has(t.UserID.Bytes), uuidString(t.UserID)),
+		references("coin_transactions", "order_id", "orders", !t.OrderID.Valid || s.orders.has(t.OrderID.Int32), t.OrderID.Int32),
+		references("coin_transactions", "coin_pack_id", "coin_packs", !t.CoinPackID.Valid || s.coinPacks.has(t.CoinPackID.Int32), t.CoinPackID.Int32),
+	)
+	if err != nil {
+		return err
+	}
+	put(s, &s.coinTransactions, t.ID, t)
+	return nil
+}
@@ -0,0 +1,125 @@
+package memdb
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres error codes the in-memory database raises
+const (
+	pgNotNullViolation        = "23502"
+	pgForeignKeyViolation     = "23503"
+	pgUniqueViolation         = "23505"
+	pgCheckViolation          = "23514"
+	pgStringDataRightTrunc    = "22001"
+	pgNumericValueOutOfRange  = "22003"
+	pgInvalidParameterValue   = "22023"
+	pgInvalidTextRepr         = "22P02"
+	pgInvalidRowCountInLimit  = "2201W"
+	pgInvalidRowCountInOffset = "2201X"
+	pgReadOnlySQLTransaction  = "25006"
+	pgInFailedSQLTransaction  = "25P02"
+)
+
+func pgError(code, message string) *pgconn.PgError {
+	return &pgconn.PgError{Severity: "ERROR", Code: code, Message: message}
+}
+
+func errAborted() error {
+	return pgError(pgInFailedSQLTransaction, "current transaction is aborted, commands ignored until end of transaction block")
+}
+
+func errReadOnly() error {
+	return pgError(pgReadOnlySQLTransaction, "cannot execute statement in a read-only transaction")
+}
+
+// firstError returns the first of errs that is not nil. Constraints are
+// listed in the order Postgres checks them: not null, then check constraints
+// by name, then unique indexes, then foreign keys.
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func notNull(table, column string, valid bool) error {
+	if valid {
+		return nil
+	}
+	err := pgError(pgNotNullViolation, fmt.Sprintf("null value in column %q of relation %q violates not-null constraint", column, table))
+	err.TableName = table
+	err.ColumnName = column
+	return err
+}
+
+// check fails with the named check constraint unless ok. A check on NULL
+// passes, so callers treat NULL columns as ok.
+func check(table, constraint string, ok bool) error {
+	if ok {
+		return nil
+	}
+	err := pgError(pgCheckViolation, fmt.Sprintf("new row for relation %q violates check constraint %q", table, constraint))
+	err.TableName = table
+	err.ConstraintName = constraint
+	return err
+}
+
+// unique fails with the named unique constraint if conflict
+func unique(table, constraint string, conflict bool, key string) error {
+	if !conflict {
+		return nil
+	}
+	err := pgError(pgUniqueViolation, fmt.Sprintf("duplicate key value violates unique constraint %q", constraint))
+	err.Detail = fmt.Sprintf("Key %s already exists.", key)
+	err.TableName = table
+	err.ConstraintName = constraint
+	return err
+}
+
+// references fails with table's foreign key on column unless the referenced
+// row exists. NULL references nothing and passes.
+func references(table, column, target string, exists bool, key any) error {
+	if exists {
+		return nil
+	}
+	constraint := table + "_" + column + "_fkey"
+	err := pgError(pgForeignKeyViolation, fmt.Sprintf("insert or update on table %q violates foreign key constraint %q", table, constraint))
+	err.Detail = fmt.Sprintf("Key (%s)=(%v) is not present in table %q.", column, key, target)
+	err.TableName = table
+	err.ConstraintName = constraint
+	return err
+}
+
+// stillReferenced is the error for deleting a row of target that table still
+// references through column
+func stillReferenced(table, column, target string, key any) error {
+	constraint := table + "_" + column + "_fkey"
+	err := pgError(pgForeignKeyViolation, fmt.Sprintf("update or delete on table %q violates foreign key constraint %q on table %q", target, constraint, table))
+	err.Detail = fmt.Sprintf("Key (id)=(%v) is still referenced from table %q.", key, table)
+	err.TableName = table
+	err.ConstraintName = constraint
+	return err
+}
+
+// varchar fails if value does not fit a VARCHAR(n) or CHAR(n) column
+func varchar(value string, n int) error {
+	if utf8.RuneCountInString(value) <= n {
+		return nil
+	}
+	return pgError(pgStringDataRightTrunc, fmt.Sprintf("value too long for type character varying(%d)", n))
+}
+
+// enum fails unless value is one of the labels of the enum type
+func enum[E ~string](typ string, value E, labels ...E) error {
+	for _, label := range labels {
+		if value == label {
+			return nil
+		}
+	}
+	return pgError(pgInvalidTextRepr, fmt.Sprintf("invalid input value for enum %s: %q", typ, string(value)))
+}
@@ -0,0 +1,177 @@
+package memdb
+
+import (
+	"backend/internal/database"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// The Insert methods write rows that the service's queries never create, such
+// as categories, products and orders, so tests and the demo can set up any
+// state. Each takes a whole row and checks it against the schema's constraints
+// like an INSERT would. A zero id is taken from the table's sequence, and an
+// explicit one moves the sequence past it. Timestamps, coin balances, ratings
+// and statuses that are left NULL take their column defaults. The stored row
+// is returned.
+
+// HasSeedableRows reports whether the database holds any users, products or
+// coin packs, which loading seeded rows with fixed ids would collide with
+func (q *Queries) HasSeedableRows(ctx context.Context) (bool, error) {
+	var exists bool
+	err := q.read(func(s *state) error {
+		exists = len(s.users.rows) > 0 || len(s.products.rows) > 0 || len(s.coinPacks.rows) > 0
+		return nil
+	})
+	return exists, err
+}
+
+func (q *Queries) InsertCategory(ctx context.Context, c database.Category) (database.Category, error) {
+	return insertFixture(ctx, q, "categories", &c.ID, func(s *state) error {
+		return insertCategory(s, c)
+	}, func(s *state) database.Category {
+		stored, _ := s.categories.get(c.ID)
+		return stored
+	})
+}
+
+func (q *Queries) InsertProduct(ctx context.Context, p database.Product) (database.Product, error) {
+	return insertFixture(ctx, q, "products", &p.ID, func(s *state) error {
+		if !p.AverageRating.Valid {
+			p.AverageRating = cents(0)
+		}
+		p.TotalComments = int4Or(p.TotalComments, 0)
+		p.CreatedAt = timeOr(storedTime(p.CreatedAt), s.now)
+		p.UpdatedAt = timeOr(storedTime(p.UpdatedAt), s.now)
+		return insertProduct(s, p)
+	}, func(s *state) database.Product {
+		stored, _ := s.products.get(p.ID)
+		return stored
+	})
+}
+
+// InsertUser inserts u, generating an id if it has none
+func (q *Queries) InsertUser(ctx context.Context, u database.User) (database.User, error) {
+	if !u.ID.Valid {
+		u.ID = pgtype.UUID{Bytes: uuid.New(), Valid: true}
+	}
+	var stored database.User
+	err := q.write(ctx, func(s *state) error {
+		u.Coins = int4Or(u.Coins, 0)
+		u.CreatedAt = timeOr(storedTime(u.CreatedAt), s.now)
+		u.UpdatedAt = timeOr(storedTime(u.UpdatedAt), s.now)
+		if err := insertUser(s, u); err != nil {
+			return err
+		}
+		stored, _ = s.users.get(u.ID.Bytes)
+		return nil
+	})
+	return stored, err
+}
+
+func (q *Queries) InsertCoinPack(ctx context.Context, p database.CoinPack) (database.CoinPack, error) {
+	return insertFixture(ctx, q, "coin_packs", &p.ID, func(s *state) error {
+		p.CreatedAt = timeOr(storedTime(p.CreatedAt), s.now)
+		p.UpdatedAt = timeOr(storedTime(p.UpdatedAt), s.now)
+		if err := unique("coin_packs", "coin_packs_pkey", s.coinPacks.has(p.ID), fmt.Sprintf("(id)=(%d)", p.ID)); err != nil {
+			return err
+		}
+		_, err := saveCoinPack(s, p)
+		return err
+	}, func(s *state) database.CoinPack {
+		stored, _ := s.coinPacks.get(p.ID)
+		return stored
+	})
+}
+
+func (q *Queries) InsertCartItem(ctx context.Context, c database.CartItem) (database.CartItem, error) {
+	return insertFixture(ctx, q, "cart_items", &c.ID, func(s *state) error {
+		c.CreatedAt = timeOr(storedTime(c.CreatedAt), s.now)
+		c.UpdatedAt = timeOr(storedTime(c.UpdatedAt), s.now)
+		return insertCartItem(s, c)
+	}, func(s *state) database.CartItem {
+		stored, _ := s.cartItems.get(c.ID)
+		return stored
+	})
+}
+
+// InsertComment inserts c and updates its product's rating, as the trigger
+// does
+func (q *Queries) InsertComment(ctx context.Context, c database.Comment) (database.Comment, error) {
+	return insertFixture(ctx, q, "comments", &c.ID, func(s *state) error {
+		c.CreatedAt = timeOr(storedTime(c.CreatedAt), s.now)
+		c.UpdatedAt = timeOr(storedTime(c.UpdatedAt), s.now)
+		return insertComment(s, c)
+	}, func(s *state) database.Comment {
+		stored, _ := s.comments.get(c.ID)
+		return stored
+	})
+}
+
+func (q *Queries) InsertOrder(ctx context.Context, o database.Order) (database.Order, error) {
+	return insertFixture(ctx, q, "orders", &o.ID, func(s *state) error {
+		if !o.Status.Valid {
+			o.Status = database.NullOrderStatus{OrderStatus: database.OrderStatusPending, Valid: true}
+		} else if err := orderStatus(o.Status.OrderStatus); err != nil {
+			return err
+		}
+		o.CreatedAt = timeOr(storedTime(o.CreatedAt), s.now)
+		o.UpdatedAt = timeOr(storedTime(o.UpdatedAt), s.now)
+		return insertOrder(s, o)
+	}, func(s *state) database.Order {
+		stored, _ := s.orders.get(o.ID)
+		return stored
+	})
+}
+
+func (q *Queries) InsertOrderItem(ctx context.Context, i database.OrderItem) (database.OrderItem, error) {
+	return insertFixture(ctx, q, "order_items", &i.ID, func(s *state) error {
+		return insertOrderItem(s, i)
+	}, func(s *state) database.OrderItem {
+		stored, _ := s.orderItems.get(i.ID)
+		return stored
+	})
+}
+
+func (q *Queries) InsertCoinTransaction(ctx context.Context, t database.CoinTransaction) (database.CoinTransaction, error) {
+	return insertFixture(ctx, q, "coin_transactions", &t.ID, func(s *state) error {
+		t.CreatedAt = timeOr(storedTime(t.CreatedAt), s.now)
+		return insertCoinTransaction(s, t)
+	}, func(s *state) database.CoinTransaction {
+		stored, _ := s.coinTransactions.get(t.ID)
+		return stored
+	})
+}
+
+func (q *Queries) InsertCoinLot(ctx context.Context, l database.CoinLot) (database.CoinLot, error) {
+	return insertFixture(ctx, q, "coin_lots", &l.ID, func(s *state) error {
+		l.ExpiresAt = storedTime(l.ExpiresAt)
+		l.CreatedAt = timeOr(storedTime(l.CreatedAt), s.now)
+		return insertCoinLot(s, l)
+	}, func(s *state) database.CoinLot {
+		stored, _ := s.coinLots.get(l.ID)
+		return stored
+	})
+}
+
+// insertFixture assigns the row's id from the table's sequence, or moves the
+// sequence past an explicit one, then runs insert and returns the row stored
+func insertFixture[V any](ctx context.Context, q *Queries, table string, id *int32, insert func(s *state) error, stored func(s *state) V) (V, error) {
+	if *id == 0 {
+		*id = q.store.nextval(table)
+	} else {
+		q.store.setval(table, *id)
+	}
+
+	var row V
+	err := q.write(ctx, func(s *state) error {
+		if err := insert(s); err != nil {
+			return err
+		}
+		row = stored(s)
+		return nil
+	})
+	return row, err
+}
@@ -0,0 +1,188 @@
+package memdb
+
+import (
+	"backend/internal/database"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (q *Queries) CreateGiftCode(ctx context.Context, arg database.CreateGiftCodeParams) (database.GiftCode, error) {
+	var c database.GiftCode
+	err := q.write(ctx, func(s *state) error {
+		c = database.GiftCode{
+			ID:             q.store.nextval("gift_codes"),
+			BatchID:        arg.BatchID,
+			CodeHash:       bpchar(arg.CodeHash, 64),
+			CodeHint:       arg.CodeHint,
+			CoinAmount:     arg.CoinAmount,
+			MaxRedemptions: arg.MaxRedemptions,
+			ExpiresAt:      storedTime(arg.ExpiresAt),
+			CreatedAt:      s.now,
+		}
+		err := firstError(
+			varchar(arg.CodeHash, 64),
+			varchar(c.CodeHint, 4),
+			checkGiftCode(c),
+			unique("gift_codes", "gift_codes_pkey", s.giftCodes.has(c.ID), fmt.Sprintf("(id)=(%d)", c.ID)),
+			unique("gift_codes", "gift_codes_code_hash_key",
+				s.giftCodes.any(func(o database.GiftCode) bool { return o.CodeHash == c.CodeHash }),
+				fmt.Sprintf("(code_hash)=(%s)", c.CodeHash)),
+			references("gift_codes", "batch_id", "gift_code_batches", s.giftCodeBatches.has(c.BatchID), c.BatchID),
+		)
+		if err != nil {
+			return err
+		}
+		put(s, &s.giftCodes, c.ID, c)
+		return nil
+	})
+	if err != nil {
+		return database.GiftCode{}, err
+	}
+	return c, nil
+}
+
+func (q *Queries) CreateGiftCodeBatch(ctx context.Context, arg database.CreateGiftCodeBatchParams) (database.GiftCodeBatch, error) {
+	var b database.GiftCodeBatch
+	err := q.write(ctx, func(s *state) error {
+		b = database.GiftCodeBatch{
+			ID:          q.store.nextval("gift_code_batches"),
+			Description: arg.Description,
+			CreatedBy:   arg.CreatedBy,
+			CreatedAt:   s.now,
+		}
+		err := references("gift_code_batches", "created_by", "users",
+			!b.CreatedBy.Valid || s.users.has(b.CreatedBy.Bytes), uuidString(b.CreatedBy))
+		if err != nil {
+			return err
+		}
+		put(s, &s.giftCodeBatches, b.ID, b)
+		return nil
+	})
+	if err != nil {
+		return database.GiftCodeBatch{}, err
+	}
+	return b, nil
+}
+
+func (q *Queries) CreateGiftCodeRedemption(ctx context.Context, arg database.CreateGiftCodeRedemptionParams) (database.GiftCodeRedemption, error) {
+	var r database.GiftCodeRedemption
+	err := q.write(ctx, func(s *state) error {
+		r = database.GiftCodeRedemption{
+			ID:                q.store.nextval("gift_code_redemptions"),
+			GiftCodeID:        arg.GiftCodeID,
+			UserID:            arg.UserID,
+			CoinTransactionID: arg.CoinTransactionID,
+			CreatedAt:         s.now,
+		}
+		err := firstError(
+			notNull("gift_code_redemptions", "user_id", r.UserID.Valid),
+			unique("gift_code_redemptions", "gift_code_redemptions_gift_code_id_user_id_key",
+				s.giftCodeRedemptions.any(func(o database.GiftCodeRedemption) bool {
+					return o.GiftCodeID == r.GiftCodeID && sameUUID(o.UserID, r.UserID)
+				}),
+				fmt.Sprintf("(gift_code_id, user_id)=(%d, %s)", r.GiftCodeID, uuidString(r.UserID))),
+			references("gift_code_redemptions", "gift_code_id", "gift_codes", s.giftCodes.has(r.GiftCodeID), r.GiftCodeID),
+			references("gift_code_redemptions", "user_id", "users", s.users.has(r.UserID.Bytes), uuidString(r.UserID)),
+			references("gift_code_redemptions", "coin_transaction_id", "coin_transactions",
+				s.coinTransactions.has(r.CoinTransactionID), r.CoinTransactionID),
+		)
+		if err != nil {
+			return err
+		}
+		put(s, &s.giftCodeRedemptions, r.ID, r)
+		return nil
+	})
+	if err != nil {
+		return database.GiftCodeRedemption{}, err
+	}
+	return r, nil
+}
+
+func (q *Queries) GetGiftCodeBatchByID(ctx context.Context, id int32) (database.GiftCodeBatch, error) {
+	return get(q, func(s *state) *table[int32, database.GiftCodeBatch] { return s.giftCodeBatches }, id)
+}
+
+func (q *Queries) GetGiftCodeByHashForUpdate(ctx context.Context, codeHash string) (database.GiftCode, error) {
+	var found []database.GiftCode
+	err := q.read(func(s *state) error {
+		found = s.giftCodes.filter(func(c database.GiftCode) bool { return c.CodeHash == bpchar(codeHash, 64) })
+		return nil
+	})
+	if err != nil {
+		return database.GiftCode{}, err
+	}
+	if len(found) == 0 {
+		return database.GiftCode{}, pgx.ErrNoRows
+	}
+	return found[0], nil
+}
+
+func (q *Queries) GiftCodeRedeemedByUser(ctx context.Context, arg database.GiftCodeRedeemedByUserParams) (bool, error) {
+	var redeemed bool
+	err := q.read(func(s *state) error {
+		redeemed = s.giftCodeRedemptions.any(func(r database.GiftCodeRedemption) bool {
+			return r.GiftCodeID == arg.GiftCodeID && sameUUID(r.UserID, arg.UserID)
+		})
+		return nil
+	})
+	return redeemed, err
+}
+
+// IncrementGiftCodeRedemptions counts a redemption, failing with
+// gift_codes_redemptions_within_max once the code is used up
+func (q *Queries) IncrementGiftCodeRedemptions(ctx context.Context, id int32) (database.GiftCode, error) {
+	var c database.GiftCode
+	err := q.write(ctx, func(s *state) error {
+		var ok bool
+		if c, ok = s.giftCodes.get(id); !ok {
+			return pgx.ErrNoRows
+		}
+		count, err := addInt32(c.RedemptionCount, 1)
+		if err != nil {
+			return err
+		}
+		c.RedemptionCount = count.Int32
+		if err := checkGiftCode(c); err != nil {
+			return err
+		}
+		put(s, &s.giftCodes, c.ID, c)
+		return nil
+	})
+	if err != nil {
+		return database.GiftCode{}, err
+	}
+	return c, nil
+}
+
+func (q *Queries) ListGiftCodesByBatchID(ctx context.Context, batchID int32) ([]database.GiftCode, error) {
+	var codes []database.GiftCode
+	err := q.read(func(s *state) error {
+		codes = s.giftCodes.filter(func(c database.GiftCode) bool { return c.BatchID == batchID })
+		return nil
+	})
+	slices.SortFunc(codes, func(a, b database.GiftCode) int { return cmp.Compare(a.ID, b.ID) })
+	return codes, err
+}
+
+func checkGiftCode(c database.GiftCode) error {
+	return firstError(
+		check("gift_codes", "gift_codes_coin_amount_check", c.CoinAmount > 0),
+		check("gift_codes", "gift_codes_max_redemptions_check", c.MaxRedemptions > 0),
+		check("gift_codes", "gift_codes_redemptions_within_max",
+			c.RedemptionCount >= 0 && c.RedemptionCount <= c.MaxRedemptions),
+	)
+}
+
+// bpchar returns value as a CHAR(n) column holds it: padded with spaces, which
+// comparisons ignore
+func bpchar(value string, n int) string {
+	if pad := n - len([]rune(value)); pad > 0 {
+		return value + strings.Repeat(" ", pad)
+	}
+	return value
+}
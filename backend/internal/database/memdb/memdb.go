@@ -0,0 +1,295 @@
+// Package memdb is an in-memory database.Querier for tests and the demo mode.
+// It keeps the schema's rules that the service relies on: unique, check, not
+// null and foreign key constraints fail with the same Postgres error codes and
+// constraint names, so database.TranslateError maps them as it does the real
+// ones; updated_at is set on every update; and comments keep their product's
+// rating and comment count current, as the triggers do.
+//
+// Read-write transactions run one at a time, so they behave as if every
+// transaction were serializable and never have to be retried. Read-only
+// transactions and queries outside a transaction read the last committed
+// state without waiting.
+package memdb
+
+import (
+	"backend/internal/database"
+	"context"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	_ database.CoinStatementQuerier = (*Queries)(nil)
+	_ database.ContextQuerier       = (*Queries)(nil)
+	_ database.TxBeginner           = (*Queries)(nil)
+)
+
+// Queries runs the service's queries against an in-memory database. The zero
+// value is not usable; create one with New.
+type Queries struct {
+	store *store
+	tx    *Tx
+}
+
+// New returns an empty database in the state the migrations leave one
+func New() *Queries {
+	s := &store{
+		writer: make(chan struct{}, 1),
+		seq:    make(map[string]int32),
+		clock:  time.Now,
+	}
+	s.current.Store(newState(s.nextGen()))
+	return &Queries{store: s}
+}
+
+// BeginTx starts a transaction, making Queries a database.TxBeginner so that
+// database.NewTxManager can run units of work on it. Only the access mode of
+// opts is used.
+func (q *Queries) BeginTx(ctx context.Context, opts pgx.TxOptions) (pgx.Tx, error) {
+	if q.tx != nil {
+		return q.tx.Begin(ctx)
+	}
+	return q.store.begin(ctx, opts.AccessMode == pgx.ReadOnly)
+}
+
+// WithTx returns queries that run in tx, which must have been started by
+// BeginTx on the same database
+func (q *Queries) WithTx(tx pgx.Tx) *Queries {
+	memTx, ok := tx.(*Tx)
+	if !ok || memTx.store != q.store {
+		panic("memdb: WithTx needs a transaction begun on the same database")
+	}
+	return &Queries{store: q.store, tx: memTx}
+}
+
+// FromContext returns queries that run in the transaction a TxManager put in
+// ctx, or q itself when ctx has none of this database's transactions
+func (q *Queries) FromContext(ctx context.Context) database.Querier {
+	if tx, ok := database.TxFromContext(ctx); ok {
+		if memTx, ok := tx.(*Tx); ok && memTx.store == q.store {
+			return q.WithTx(memTx)
+		}
+	}
+	return q
+}
+
+// read runs fn against the state the queries see
+func (q *Queries) read(fn func(s *state) error) error {
+	if q.tx != nil {
+		return q.tx.read(fn)
+	}
+	return fn(q.store.current.Load())
+}
+
+// write runs fn as one statement, in the queries' transaction or, outside
+// one, in a transaction of its own
+func (q *Queries) write(ctx context.Context, fn func(s *state) error) error {
+	if q.tx != nil {
+		return q.tx.write(fn)
+	}
+
+	tx, err := q.store.begin(ctx, false)
+	if err != nil {
+		return err
+	}
+	if err := tx.write(fn); err != nil {
+		_ = tx.Rollback(ctx)
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// store is the committed state shared by every Queries of one database
+type store struct {
+	current atomic.Pointer[state]
+	// writer is held by the read-write transaction in progress
+	writer chan struct{}
+	gen    atomic.Uint64
+
+	// Sequences are not transactional, as in Postgres: ids taken by a
+	// transaction that rolls back are not handed out again
+	seqMu sync.Mutex
+	seq   map[string]int32
+
+	clock func() time.Time
+}
+
+func (s *store) nextGen() uint64 {
+	return s.gen.Add(1)
+}
+
+// now returns the current time at the microsecond precision Postgres keeps
+func (s *store) now() pgtype.Timestamptz {
+	return timestamptz(s.clock())
+}
+
+func (s *store) nextval(table string) int32 {
+	s.seqMu.Lock()
+	defer s.seqMu.Unlock()
+	s.seq[table]++
+	return s.seq[table]
+}
+
+// setval moves the sequence of table past id, so rows inserted with an
+// explicit id do not collide with later generated ones
+func (s *store) setval(table string, id int32) {
+	s.seqMu.Lock()
+	defer s.seqMu.Unlock()
+	if id > s.seq[table] {
+		s.seq[table] = id
+	}
+}
+
+// table holds the rows of one table. A table belongs to the state whose
+// generation it carries and is copied before another state writes to it, so
+// committed states are never modified.
+type table[K comparable, V any] struct {
+	gen  uint64
+	rows map[K]V
+}
+
+func newTable[K comparable, V any](gen uint64) *table[K, V] {
+	return &table[K, V]{gen: gen, rows: make(map[K]V)}
+}
+
+func (t *table[K, V]) get(key K) (V, bool) {
+	row, ok := t.rows[key]
+	return row, ok
+}
+
+func (t *table[K, V]) has(key K) bool {
+	_, ok := t.rows[key]
+	return ok
+}
+
+// filter returns the rows match accepts, in no particular order
+func (t *table[K, V]) filter(match func(V) bool) []V {
+	var rows []V
+	for _, row := range t.rows {
+		if match(row) {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// any reports whether match accepts a row
+func (t *table[K, V]) any(match func(V) bool) bool {
+	for _, row := range t.rows {
+		if match(row) {
+			return true
+		}
+	}
+	return false
+}
+
+// state is a version of every table. Writes go through put and del, which
+// record how to undo them so that a failed statement leaves no trace.
+type state struct {
+	gen uint64
+	// now is CURRENT_TIMESTAMP: the start of the transaction
+	now  pgtype.Timestamptz
+	undo []func()
+
+	users               *table[[16]byte, database.User]
+	categories          *table[int32, database.Category]
+	products            *table[int32, database.Product]
+	cartItems           *table[int32, database.CartItem]
+	comments            *table[int32, database.Comment]
+	orders              *table[int32, database.Order]
+	orderItems          *table[int32, database.OrderItem]
+	coinTransactions    *table[int32, database.CoinTransaction]
+	coinPacks           *table[int32, database.CoinPack]
+	coinLots            *table[int32, database.CoinLot]
+	spendLimits         *table[[16]byte, database.UserSpendLimit]
+	securityEvents      *table[int32, database.SecurityEvent]
+	coinHolds           *table[int32, database.CoinHold]
+	cashbackRates       *table[int32, database.CategoryCashbackRate]
+	giftCodeBatches     *table[int32, database.GiftCodeBatch]
+	giftCodes           *table[int32, database.GiftCode]
+	giftCodeRedemptions *table[int32, database.GiftCodeRedemption]
+	referrals           *table[int32, database.Referral]
+}
+
+func newState(gen uint64) *state {
+	return &state{
+		gen:                 gen,
+		users:               newTable[[16]byte, database.User](gen),
+		categories:          newTable[int32, database.Category](gen),
+		products:            newTable[int32, database.Product](gen),
+		cartItems:           newTable[int32, database.CartItem](gen),
+		comments:            newTable[int32, database.Comment](gen),
+		orders:              newTable[int32, database.Order](gen),
+		orderItems:          newTable[int32, database.OrderItem](gen),
+		coinTransactions:    newTable[int32, database.CoinTransaction](gen),
+		coinPacks:           newTable[int32, database.CoinPack](gen),
+		coinLots:            newTable[int32, database.CoinLot](gen),
+		spendLimits:         newTable[[16]byte, database.UserSpendLimit](gen),
+		securityEvents:      newTable[int32, database.SecurityEvent](gen),
+		coinHolds:           newTable[int32, database.CoinHold](gen),
+		cashbackRates:       newTable[int32, database.CategoryCashbackRate](gen),
+		giftCodeBatches:     newTable[int32, database.GiftCodeBatch](gen),
+		giftCodes:           newTable[int32, database.GiftCode](gen),
+		giftCodeRedemptions: newTable[int32, database.GiftCodeRedemption](gen),
+		referrals:           newTable[int32, database.Referral](gen),
+	}
+}
+
+// fork returns a state with the same rows that can be written without
+// changing s. Tables are only copied once they are written to.
+func (s *state) fork(gen uint64, now pgtype.Timestamptz) *state {
+	forked := *s
+	forked.gen = gen
+	forked.now = now
+	forked.undo = nil
+	return &forked
+}
+
+// rollback undoes the writes of the current statement
+func (s *state) rollback() {
+	for i := len(s.undo) - 1; i >= 0; i-- {
+		s.undo[i]()
+	}
+	s.undo = nil
+}
+
+// own returns the table at t after copying it into s if it belongs to another
+// state
+func own[K comparable, V any](s *state, t **table[K, V]) *table[K, V] {
+	if (*t).gen != s.gen {
+		*t = &table[K, V]{gen: s.gen, rows: maps.Clone((*t).rows)}
+	}
+	return *t
+}
+
+// put inserts or replaces the row at key
+func put[K comparable, V any](s *state, t **table[K, V], key K, row V) {
+	tbl := own(s, t)
+	old, existed := tbl.rows[key]
+	tbl.rows[key] = row
+	s.undo = append(s.undo, func() {
+		if existed {
+			tbl.rows[key] = old
+		} else {
+			delete(tbl.rows, key)
+		}
+	})
+}
+
+// del removes the row at key, if there is one
+func del[K comparable, V any](s *state, t **table[K, V], key K) {
+	tbl := own(s, t)
+	old, existed := tbl.rows[key]
+	if !existed {
+		return
+	}
+	delete(tbl.rows, key)
+	s.undo = append(s.undo, func() {
+		tbl.rows[key] = old
+	})
+}
@@ -0,0 +1,401 @@
+package memdb
+
+import (
+	"backend/internal/database"
+	"backend/internal/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createUser(t *testing.T, q *Queries, email string, coins int32) database.CreateUserRow {
+	t.Helper()
+	u, err := q.CreateUser(context.Background(), database.CreateUserParams{
+		Name:            "Test User",
+		Email:           email,
+		NormalizedEmail: email,
+		PasswordHash:    "hash",
+		Coins:           pgtype.Int4{Int32: coins, Valid: true},
+		ReferralCode:    email[:4],
+	})
+	require.NoError(t, err)
+	return u
+}
+
+func createProduct(t *testing.T, q *Queries) database.Product {
+	t.Helper()
+	ctx := context.Background()
+	c, err := q.InsertCategory(ctx, database.Category{Name: "Books"})
+	require.NoError(t, err)
+	p, err := q.InsertProduct(ctx, database.Product{CategoryID: c.ID, Name: "Go", Price: cents(1999), StockQuantity: 5})
+	require.NoError(t, err)
+	return p
+}
+
+func pgCode(t *testing.T, err error) string {
+	t.Helper()
+	var pgErr *pgconn.PgError
+	require.ErrorAs(t, err, &pgErr)
+	return pgErr.Code
+}
+
+func TestNew_IsEmpty(t *testing.T) {
+	q := New()
+	ctx := context.Background()
+
+	exists, err := q.HasSeedableRows(ctx)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	packs, err := q.ListCoinPacks(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, packs)
+}
+
+func TestCreateUser_ConstraintsTranslate(t *testing.T) {
+	q := New()
+	ctx := context.Background()
+	u := createUser(t, q, "alice@example.com", 10)
+
+	_, err := q.CreateUser(ctx, database.CreateUserParams{Email: "alice@example.com", ReferralCode: "OTHER"})
+	assert.ErrorIs(t, database.TranslateError(err, nil), domain.ErrEmailAlreadyExists)
+
+	_, err = q.UpdateUserCoins(ctx, database.UpdateUserCoinsParams{ID: u.ID, Coins: pgtype.Int4{Int32: -11, Valid: true}})
+	assert.ErrorIs(t, database.TranslateError(err, nil), domain.ErrInsufficientCoins)
+
+	_, err = q.CreateCartItem(ctx, database.CreateCartItemParams{UserID: u.ID, ProductID: 42, Quantity: 1})
+	assert.ErrorIs(t, database.TranslateError(err, nil), domain.ErrProductNotFound)
+
+	// None of the failed statements changed anything
+	stored, err := q.GetUserByID(ctx, u.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int32(10), stored.Coins.Int32)
+}
+
+func TestGetUserByID_MissingIsNoRows(t *testing.T) {
+	_, err := New().GetUserByID(context.Background(), pgtype.UUID{Bytes: uuid.New(), Valid: true})
+
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestTx_CommitAndRollback(t *testing.T) {
+	q := New()
+	ctx := context.Background()
+
+	tx, err := q.BeginTx(ctx, pgx.TxOptions{})
+	require.NoError(t, err)
+	u := createUser(t, q.WithTx(tx), "alice@example.com", 0)
+
+	_, err = q.GetUserByID(ctx, u.ID)
+	assert.ErrorIs(t, err, pgx.ErrNoRows, "uncommitted rows are not visible outside the transaction")
+	require.NoError(t, tx.Commit(ctx))
+	_, err = q.GetUserByID(ctx, u.ID)
+	assert.NoError(t, err)
+
+	tx, err = q.BeginTx(ctx, pgx.TxOptions{})
+	require.NoError(t, err)
+	require.NoError(t, q.WithTx(tx).DeleteUser(ctx, u.ID))
+	require.NoError(t, tx.Rollback(ctx))
+	_, err = q.GetUserByID(ctx, u.ID)
+	assert.NoError(t, err)
+}
+
+func TestTx_SavepointRollbackKeepsOuterWork(t *testing.T) {
+	q := New()
+	ctx := context.Background()
+	tx, err := q.BeginTx(ctx, pgx.TxOptions{})
+	require.NoError(t, err)
+	alice := createUser(t, q.WithTx(tx), "alice@example.com", 0)
+
+	savepoint, err := tx.Begin(ctx)
+	require.NoError(t, err)
+	createUser(t, q.WithTx(savepoint), "bobby@example.com", 0)
+	_, err = q.WithTx(savepoint).CreateUser(ctx, database.CreateUserParams{Email: "alice@example.com", ReferralCode: "X"})
+	require.Error(t, err)
+	require.NoError(t, savepoint.Rollback(ctx))
+	require.NoError(t, tx.Commit(ctx))
+
+	_, err = q.GetUserByID(ctx, alice.ID)
+	assert.NoError(t, err)
+	exists, err := q.CheckEmailExists(ctx, "bobby@example.com")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestTx_FailedStatementAbortsTransaction(t *testing.T) {
+	q := New()
+	ctx := context.Background()
+	createUser(t, q, "alice@example.com", 0)
+
+	tx, err := q.BeginTx(ctx, pgx.TxOptions{})
+	require.NoError(t, err)
+	_, err = q.WithTx(tx).CreateUser(ctx, database.CreateUserParams{Email: "alice@example.com", ReferralCode: "X"})
+	assert.Equal(t, "23505", pgCode(t, err))
+
+	_, err = q.WithTx(tx).CheckEmailExists(ctx, "alice@example.com")
+	assert.Equal(t, "25P02", pgCode(t, err))
+	assert.ErrorIs(t, tx.Commit(ctx), pgx.ErrTxCommitRollback)
+}
+
+func TestTx_NoRowsDoesNotAbort(t *testing.T) {
+	q := New()
+	ctx := context.Background()
+	tx, err := q.BeginTx(ctx, pgx.TxOptions{})
+	require.NoError(t, err)
+
+	_, err = q.WithTx(tx).GetProductByID(ctx, 1)
+	require.ErrorIs(t, err, pgx.ErrNoRows)
+	createUser(t, q.WithTx(tx), "alice@example.com", 0)
+	assert.NoError(t, tx.Commit(ctx))
+}
+
+func TestTx_ReadOnlyRejectsWrites(t *testing.T) {
+	q := New()
+	ctx := context.Background()
+	tx, err := q.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	require.NoError(t, err)
+
+	_, err = q.WithTx(tx).CreateUser(ctx, database.CreateUserParams{Email: "alice@example.com"})
+
+	assert.Equal(t, "25006", pgCode(t, err))
+	require.NoError(t, tx.Rollback(ctx))
+}
+
+func TestTxManager_JoinsTransactionFromContext(t *testing.T) {
+	q := New()
+	m := database.NewTxManager(q)
+	ctx := context.Background()
+	errFail := errors.New("fail")
+
+	err := m.WithinTx(ctx, func(ctx context.Context) error {
+		createUser(t, database.QuerierFromContext[database.Querier](ctx, q).(*Queries), "alice@example.com", 0)
+		return errFail
+	})
+	require.ErrorIs(t, err, errFail)
+	exists, err := q.CheckEmailExists(ctx, "alice@example.com")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	err = m.WithinTx(ctx, func(ctx context.Context) error {
+		createUser(t, database.QuerierFromContext[database.Querier](ctx, q).(*Queries), "alice@example.com", 0)
+		return nil
+	})
+	require.NoError(t, err)
+	exists, err = q.CheckEmailExists(ctx, "alice@example.com")
+	require.NoError(t, err)
+	assert.True(t, exists)
+}
+
+func TestComments_UpdateProductRating(t *testing.T) {
+	q := New()
+	ctx := context.Background()
+	p := createProduct(t, q)
+	alice := createUser(t, q, "alice@example.com", 0)
+	bob := createUser(t, q, "bobby@example.com", 0)
+
+	for _, c := range []struct {
+		user   pgtype.UUID
+		rating pgtype.Int4
+	}{
+		{alice.ID, pgtype.Int4{Int32: 5, Valid: true}},
+		{alice.ID, pgtype.Int4{Int32: 4, Valid: true}},
+		{bob.ID, pgtype.Int4{Int32: 4, Valid: true}},
+		{bob.ID, pgtype.Int4{}},
+	} {
+		_, err := q.InsertComment(ctx, database.Comment{UserID: c.user, ProductID: p.ID, Rating: c.rating})
+		require.NoError(t, err)
+	}
+
+	rated, err := q.GetProductByID(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, cents(433), rated.AverageRating, "13/3 rounds to 4.33")
+	assert.Equal(t, int32(4), rated.TotalComments.Int32)
+
+	_, err = q.InsertComment(ctx, database.Comment{UserID: alice.ID, ProductID: p.ID, Rating: pgtype.Int4{Int32: 6, Valid: true}})
+	assert.Equal(t, "23514", pgCode(t, err))
+
+	// Deleting alice removes her comments, which the rating follows
+	require.NoError(t, q.DeleteUser(ctx, alice.ID))
+	rated, err = q.GetProductByID(ctx, p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, cents(400), rated.AverageRating)
+	assert.Equal(t, int32(2), rated.TotalComments.Int32)
+}
+
+func TestDeleteUser_RestrictedByLedger(t *testing.T) {
+	q := New()
+	ctx := context.Background()
+	u := createUser(t, q, "alice@example.com", 0)
+	_, err := q.CreateCoinTransaction(ctx, database.CreateCoinTransactionParams{
+		UserID:          u.ID,
+		TransactionType: database.TransactionTypeCharge,
+		Amount:          100,
+		BalanceAfter:    100,
+	})
+	require.NoError(t, err)
+
+	err = q.DeleteUser(ctx, u.ID)
+
+	assert.Equal(t, "23503", pgCode(t, err))
+	_, err = q.GetUserByID(ctx, u.ID)
+	assert.NoError(t, err)
+}
+
+func TestCreateCoinTransaction_CashbackOncePerOrder(t *testing.T) {
+	q := New()
+	ctx := context.Background()
+	u := createUser(t, q, "alice@example.com", 0)
+	o, err := q.InsertOrder(ctx, database.Order{UserID: u.ID, OrderNumber: "ORD-1", TotalAmount: cents(100)})
+	require.NoError(t, err)
+	assert.Equal(t, database.OrderStatusPending, o.Status.OrderStatus)
+
+	cashback := database.CreateCoinTransactionParams{
+		UserID:          u.ID,
+		TransactionType: database.TransactionTypeCashback,
+		Amount:          5,
+		OrderID:         pgtype.Int4{Int32: o.ID, Valid: true},
+	}
+	_, err = q.CreateCoinTransaction(ctx, cashback)
+	require.NoError(t, err)
+	_, err = q.CreateCoinTransaction(ctx, cashback)
+
+	assert.ErrorIs(t, database.TranslateError(err, nil), domain.ErrAlreadyExists)
+}
+
+func TestListCoinTransactionsFiltered(t *testing.T) {
+	q := New()
+	ctx := context.Background()
+	u := createUser(t, q, "alice@example.com", 0)
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, amount := range []int32{100, -30, 50, -20} {
+		typ := database.TransactionTypeCharge
+		if amount < 0 {
+			typ = database.TransactionTypePurchase
+		}
+		_, err := q.InsertCoinTransaction(ctx, database.CoinTransaction{
+			UserID:          u.ID,
+			TransactionType: typ,
+			Amount:          amount,
+			CreatedAt:       pgtype.Timestamptz{Time: start.AddDate(0, 0, i), Valid: true},
+		})
+		require.NoError(t, err)
+	}
+	all := []string{"charge", "purchase", "refund"}
+	everything := database.ListCoinTransactionsFilteredParams{
+		UserID:           u.ID,
+		TransactionTypes: all,
+		CreatedFrom:      pgtype.Timestamptz{InfinityModifier: pgtype.NegativeInfinity, Valid: true},
+		CreatedTo:        pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true},
+		RowLimit:         10,
+	}
+
+	txs, err := q.ListCoinTransactionsFiltered(ctx, everything)
+	require.NoError(t, err)
+	require.Len(t, txs, 4)
+	assert.Equal(t, int32(-20), txs[0].Amount, "newest first")
+
+	spends := everything
+	spends.AmountSign = -1
+	spends.RowLimit = 1
+	spends.RowOffset = 1
+	txs, err = q.ListCoinTransactionsFiltered(ctx, spends)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.Equal(t, int32(-30), txs[0].Amount)
+
+	summary, err := q.SummarizeCoinTransactionsFiltered(ctx, database.SummarizeCoinTransactionsFilteredParams{
+		UserID:           u.ID,
+		TransactionTypes: all,
+		CreatedFrom:      pgtype.Timestamptz{Time: start.AddDate(0, 0, 1), Valid: true},
+		CreatedTo:        everything.CreatedTo,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, database.SummarizeCoinTransactionsFilteredRow{TotalCount: 3, TotalCharged: 50, TotalSpent: 50}, summary)
+
+	invalid := everything
+	invalid.TransactionTypes = []string{"gift"}
+	_, err = q.ListCoinTransactionsFiltered(ctx, invalid)
+	assert.Equal(t, "22P02", pgCode(t, err))
+}
+
+func TestListCoinBalanceChangesByInterval(t *testing.T) {
+	q := New()
+	ctx := context.Background()
+	u := createUser(t, q, "alice@example.com", 0)
+	// 23:30 UTC on Sunday 5 January is already Monday in Tokyo
+	for _, at := range []time.Time{
+		time.Date(2025, 1, 5, 23, 30, 0, 0, time.UTC),
+		time.Date(2025, 1, 6, 1, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 13, 1, 0, 0, 0, time.UTC),
+	} {
+		_, err := q.InsertCoinTransaction(ctx, database.CoinTransaction{
+			UserID:          u.ID,
+			TransactionType: database.TransactionTypeCharge,
+			Amount:          10,
+			CreatedAt:       pgtype.Timestamptz{Time: at, Valid: true},
+		})
+		require.NoError(t, err)
+	}
+	params := database.ListCoinBalanceChangesByIntervalParams{
+		Bucket:      "week",
+		TimeZone:    "Asia/Tokyo",
+		UserID:      u.ID,
+		CreatedFrom: pgtype.Timestamptz{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		CreatedTo:   pgtype.Timestamptz{Time: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+	}
+
+	rows, err := q.ListCoinBalanceChangesByInterval(ctx, params)
+
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	assert.True(t, rows[0].BucketStart.Time.Equal(time.Date(2025, 1, 6, 0, 0, 0, 0, tokyo)))
+	assert.Equal(t, int32(20), rows[0].NetChange)
+	assert.Equal(t, int32(30), rows[1].RunningChange)
+
+	params.TimeZone = "Mars/Olympus"
+	_, err = q.ListCoinBalanceChangesByInterval(ctx, params)
+	assert.Equal(t, "22023", pgCode(t, err))
+}
+
+func TestListSpendableCoinLotsForUpdate_OldestFirst(t *testing.T) {
+	q := New()
+	ctx := context.Background()
+	u := createUser(t, q, "alice@example.com", 0)
+	now := time.Now()
+	for _, expiresAt := range []pgtype.Timestamptz{
+		{},
+		{Time: now.Add(48 * time.Hour), Valid: true},
+		{Time: now.Add(-time.Hour), Valid: true},
+		{Time: now.Add(24 * time.Hour), Valid: true},
+	} {
+		_, err := q.CreateCoinLot(ctx, database.CreateCoinLotParams{
+			UserID:         u.ID,
+			Source:         database.TransactionTypeCharge,
+			OriginalAmount: 10,
+			ExpiresAt:      expiresAt,
+		})
+		require.NoError(t, err)
+	}
+
+	lots, err := q.ListSpendableCoinLotsForUpdate(ctx, database.ListSpendableCoinLotsForUpdateParams{
+		UserID:    u.ID,
+		ExpiresAt: pgtype.Timestamptz{Time: now, Valid: true},
+	})
+
+	require.NoError(t, err)
+	var ids []int32
+	for _, l := range lots {
+		ids = append(ids, l.ID)
+	}
+	assert.Equal(t, []int32{1, 2, 4}, ids)
+}
@@ -0,0 +1,167 @@
+package memdb
+
+import (
+	"backend/internal/database"
+	"cmp"
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (q *Queries) GetOrderByID(ctx context.Context, id int32) (database.Order, error) {
+	return get(q, func(s *state) *table[int32, database.Order] { return s.orders }, id)
+}
+
+// CountOrdersByStatus counts orders by status; a NULL status counts as
+// pending
+func (q *Queries) CountOrdersByStatus(ctx context.Context) ([]database.CountOrdersByStatusRow, error) {
+	counts := make(map[database.OrderStatus]int32)
+	err := q.read(func(s *state) error {
+		for _, o := range s.orders.filter(func(database.Order) bool { return true }) {
+			status := database.OrderStatusPending
+			if o.Status.Valid {
+				status = o.Status.OrderStatus
+			}
+			counts[status]++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]database.CountOrdersByStatusRow, 0, len(counts))
+	for status, count := range counts {
+		rows = append(rows, database.CountOrdersByStatusRow{Status: status, Count: count})
+	}
+	slices.SortFunc(rows, func(a, b database.CountOrdersByStatusRow) int {
+		return cmp.Compare(a.Status, b.Status)
+	})
+	return rows, nil
+}
+
+// ListOrderCategorySubtotals sums the order's items by the category their
+// product is in now
+func (q *Queries) ListOrderCategorySubtotals(ctx context.Context, orderID int32) ([]database.ListOrderCategorySubtotalsRow, error) {
+	sums := make(map[int32]*big.Int)
+	err := q.read(func(s *state) error {
+		for _, item := range s.orderItems.filter(func(i database.OrderItem) bool { return i.OrderID == orderID }) {
+			p, ok := s.products.get(item.ProductID)
+			if !ok {
+				continue
+			}
+			if sums[p.CategoryID] == nil {
+				sums[p.CategoryID] = new(big.Int)
+			}
+			// Subtotals are stored as DECIMAL(10,2)
+			sums[p.CategoryID].Add(sums[p.CategoryID], item.Subtotal.Int)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []database.ListOrderCategorySubtotalsRow
+	for categoryID, sum := range sums {
+		subtotal, err := decimal(pgtype.Numeric{Int: sum, Exp: -2, Valid: true}, 12, 2)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, database.ListOrderCategorySubtotalsRow{CategoryID: categoryID, Subtotal: subtotal})
+	}
+	slices.SortFunc(rows, func(a, b database.ListOrderCategorySubtotalsRow) int {
+		return cmp.Compare(a.CategoryID, b.CategoryID)
+	})
+	return rows, nil
+}
+
+// UpdateOrderStatus moves the order to the new status if it still has the
+// current one; a NULL status counts as pending
+func (q *Queries) UpdateOrderStatus(ctx context.Context, arg database.UpdateOrderStatusParams) (database.Order, error) {
+	var o database.Order
+	err := q.write(ctx, func(s *state) error {
+		if err := firstError(orderStatus(arg.NewStatus), orderStatus(arg.CurrentStatus)); err != nil {
+			return err
+		}
+		var ok bool
+		if o, ok = s.orders.get(arg.ID); !ok {
+			return pgx.ErrNoRows
+		}
+		current := database.OrderStatusPending
+		if o.Status.Valid {
+			current = o.Status.OrderStatus
+		}
+		if current != arg.CurrentStatus {
+			return pgx.ErrNoRows
+		}
+		o.Status = database.NullOrderStatus{OrderStatus: arg.NewStatus, Valid: true}
+		o.UpdatedAt = s.now
+		return saveOrder(s, o)
+	})
+	if err != nil {
+		return database.Order{}, err
+	}
+	return o, nil
+}
+
+func insertOrder(s *state, o database.Order) error {
+	if err := unique("orders", "orders_pkey", s.orders.has(o.ID), fmt.Sprintf("(id)=(%d)", o.ID)); err != nil {
+		return err
+	}
+	return saveOrder(s, o)
+}
+
+func saveOrder(s *state, o database.Order) error {
+	total, err := decimal(o.TotalAmount, 10, 2)
+	if err != nil {
+		return err
+	}
+	o.TotalAmount = total
+
+	err = firstError(
+		varchar(o.OrderNumber, 50),
+		notNull("orders", "user_id", o.UserID.Valid),
+		notNull("orders", "total_amount", o.TotalAmount.Valid),
+		unique("orders", "orders_order_number_key",
+			s.orders.any(func(other database.Order) bool { return other.ID != o.ID && other.OrderNumber == o.OrderNumber }),
+			fmt.Sprintf("(order_number)=(%s)", o.OrderNumber)),
+		references("orders", "user_id", "users", s.users.has(o.UserID.Bytes), uuidString(o.UserID)),
+	)
+	if err != nil {
+		return err
+	}
+	put(s, &s.orders, o.ID, o)
+	return nil
+}
+
+func insertOrderItem(s *state, i database.OrderItem) error {
+	price, err := decimal(i.ProductPrice, 10, 2)
+	if err != nil {
+		return err
+	}
+	i.ProductPrice = price
+	subtotal, err := decimal(i.Subtotal, 10, 2)
+	if err != nil {
+		return err
+	}
+	i.Subtotal = subtotal
+
+	err = firstError(
+		varchar(i.ProductName, 255),
+		notNull("order_items", "product_price", i.ProductPrice.Valid),
+		notNull("order_items", "subtotal", i.Subtotal.Valid),
+		unique("order_items", "order_items_pkey", s.orderItems.has(i.ID), fmt.Sprintf("(id)=(%d)", i.ID)),
+		references("order_items", "order_id", "orders", s.orders.has(i.OrderID), i.OrderID),
+		references("order_items", "product_id", "products", s.products.has(i.ProductID), i.ProductID),
+	)
+	if err != nil {
+		return err
+	}
+	put(s, &s.orderItems, i.ID, i)
+	return nil
+}
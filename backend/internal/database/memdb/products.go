@@ -0,0 +1,187 @@
+package memdb
+
+import (
+	"backend/internal/database"
+	"cmp"
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (q *Queries) GetAllCategories(ctx context.Context) ([]database.Category, error) {
+	var categories []database.Category
+	err := q.read(func(s *state) error {
+		categories = s.categories.filter(func(database.Category) bool { return true })
+		return nil
+	})
+	slices.SortFunc(categories, func(a, b database.Category) int { return cmp.Compare(a.Name, b.Name) })
+	return categories, err
+}
+
+func (q *Queries) GetCategoryByID(ctx context.Context, id int32) (database.Category, error) {
+	return get(q, func(s *state) *table[int32, database.Category] { return s.categories }, id)
+}
+
+func (q *Queries) GetProductByID(ctx context.Context, id int32) (database.Product, error) {
+	return get(q, func(s *state) *table[int32, database.Product] { return s.products }, id)
+}
+
+func (q *Queries) ListProducts(ctx context.Context, arg database.ListProductsParams) ([]database.Product, error) {
+	return q.listProducts(func(database.Product) bool { return true }, arg.Limit, arg.Offset)
+}
+
+func (q *Queries) ListProductsByCategory(ctx context.Context, arg database.ListProductsByCategoryParams) ([]database.Product, error) {
+	return q.listProducts(func(p database.Product) bool { return p.CategoryID == arg.CategoryID }, arg.Limit, arg.Offset)
+}
+
+// listProducts returns the newest products first
+func (q *Queries) listProducts(match func(database.Product) bool, limit, offset int32) ([]database.Product, error) {
+	var products []database.Product
+	err := q.read(func(s *state) error {
+		products = s.products.filter(match)
+		slices.SortFunc(products, func(a, b database.Product) int {
+			return cmp.Or(-compareTime(a.CreatedAt, b.CreatedAt), -cmp.Compare(a.ID, b.ID))
+		})
+		var err error
+		products, err = page(products, limit, offset)
+		return err
+	})
+	return products, err
+}
+
+func (q *Queries) UpdateProductStock(ctx context.Context, arg database.UpdateProductStockParams) (database.Product, error) {
+	var p database.Product
+	err := q.write(ctx, func(s *state) error {
+		var ok bool
+		if p, ok = s.products.get(arg.ID); !ok {
+			return pgx.ErrNoRows
+		}
+		p.StockQuantity = arg.StockQuantity
+		p.UpdatedAt = s.now
+		return saveProduct(s, p)
+	})
+	if err != nil {
+		return database.Product{}, err
+	}
+	return p, nil
+}
+
+// get returns the row of the table at key, or pgx.ErrNoRows
+func get[K comparable, V any](q *Queries, tbl func(s *state) *table[K, V], key K) (V, error) {
+	var row V
+	var ok bool
+	err := q.read(func(s *state) error {
+		row, ok = tbl(s).get(key)
+		return nil
+	})
+	if err == nil && !ok {
+		err = pgx.ErrNoRows
+	}
+	return row, err
+}
+
+func insertCategory(s *state, c database.Category) error {
+	err := firstError(
+		varchar(c.Name, 100),
+		unique("categories", "categories_pkey", s.categories.has(c.ID), fmt.Sprintf("(id)=(%d)", c.ID)),
+		unique("categories", "categories_name_key",
+			s.categories.any(func(o database.Category) bool { return o.Name == c.Name }),
+			fmt.Sprintf("(name)=(%s)", c.Name)),
+	)
+	if err != nil {
+		return err
+	}
+	put(s, &s.categories, c.ID, c)
+	return nil
+}
+
+func insertProduct(s *state, p database.Product) error {
+	if err := unique("products", "products_pkey", s.products.has(p.ID), fmt.Sprintf("(id)=(%d)", p.ID)); err != nil {
+		return err
+	}
+	return saveProduct(s, p)
+}
+
+func saveProduct(s *state, p database.Product) error {
+	price, err := decimal(p.Price, 10, 2)
+	if err != nil {
+		return err
+	}
+	p.Price = price
+	rating, err := decimal(p.AverageRating, 3, 2)
+	if err != nil {
+		return err
+	}
+	p.AverageRating = rating
+
+	err = firstError(
+		varchar(p.Name, 255),
+		varchar(p.ImageUrl.String, 500),
+		notNull("products", "price", p.Price.Valid),
+		check("products", "products_average_rating_check", !p.AverageRating.Valid ||
+			(compareNumeric(p.AverageRating, 2, 0) >= 0 && compareNumeric(p.AverageRating, 2, 5) <= 0)),
+		check("products", "products_price_check", compareNumeric(p.Price, 2, 0) >= 0),
+		check("products", "products_stock_quantity_check", p.StockQuantity >= 0),
+		references("products", "category_id", "categories", s.categories.has(p.CategoryID), p.CategoryID),
+	)
+	if err != nil {
+		return err
+	}
+	put(s, &s.products, p.ID, p)
+	return nil
+}
+
+func insertComment(s *state, c database.Comment) error {
+	err := firstError(
+		notNull("comments", "user_id", c.UserID.Valid),
+		check("comments", "comments_rating_check", !c.Rating.Valid || (c.Rating.Int32 >= 1 && c.Rating.Int32 <= 5)),
+		unique("comments", "comments_pkey", s.comments.has(c.ID), fmt.Sprintf("(id)=(%d)", c.ID)),
+		references("comments", "user_id", "users", s.users.has(c.UserID.Bytes), uuidString(c.UserID)),
+		references("comments", "product_id", "products", s.products.has(c.ProductID), c.ProductID),
+	)
+	if err != nil {
+		return err
+	}
+	put(s, &s.comments, c.ID, c)
+	return updateProductRating(s, c.ProductID)
+}
+
+func deleteComment(s *state, c database.Comment) error {
+	del(s, &s.comments, c.ID)
+	return updateProductRating(s, c.ProductID)
+}
+
+// updateProductRating is the update_product_rating trigger: the product's
+// average rating is that of its rated comments, rounded to two decimals, and
+// its comment count includes comments without a rating
+func updateProductRating(s *state, productID int32) error {
+	p, ok := s.products.get(productID)
+	if !ok {
+		return nil
+	}
+
+	var total, rated, sum int64
+	for _, c := range s.comments.rows {
+		if c.ProductID != productID {
+			continue
+		}
+		total++
+		if c.Rating.Valid {
+			rated++
+			sum += int64(c.Rating.Int32)
+		}
+	}
+
+	p.AverageRating = cents(0)
+	if rated > 0 {
+		// ROUND(sum / rated, 2), rounding half away from zero; ratings are positive
+		p.AverageRating = pgtype.Numeric{Int: big.NewInt((200*sum + rated) / (2 * rated)), Exp: -2, Valid: true}
+	}
+	p.TotalComments = pgtype.Int4{Int32: int32(total), Valid: true}
+	p.UpdatedAt = s.now
+	return saveProduct(s, p)
+}
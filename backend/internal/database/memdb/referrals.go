@@ -0,0 +1,115 @@
+package memdb
+
+import (
+	"backend/internal/database"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (q *Queries) CreateReferral(ctx context.Context, arg database.CreateReferralParams) (database.Referral, error) {
+	var r database.Referral
+	err := q.write(ctx, func(s *state) error {
+		if err := referralStatus(arg.Status); err != nil {
+			return err
+		}
+		r = database.Referral{
+			ID:              q.store.nextval("referrals"),
+			ReferrerID:      arg.ReferrerID,
+			RefereeID:       arg.RefereeID,
+			Status:          arg.Status,
+			RejectionReason: arg.RejectionReason,
+			CreatedAt:       s.now,
+			UpdatedAt:       s.now,
+		}
+		if err := unique("referrals", "referrals_pkey", s.referrals.has(r.ID), fmt.Sprintf("(id)=(%d)", r.ID)); err != nil {
+			return err
+		}
+		return saveReferral(s, r)
+	})
+	if err != nil {
+		return database.Referral{}, err
+	}
+	return r, nil
+}
+
+func (q *Queries) GetReferralByRefereeID(ctx context.Context, refereeID pgtype.UUID) (database.Referral, error) {
+	var found []database.Referral
+	err := q.read(func(s *state) error {
+		found = s.referrals.filter(func(r database.Referral) bool { return sameUUID(r.RefereeID, refereeID) })
+		return nil
+	})
+	if err != nil {
+		return database.Referral{}, err
+	}
+	if len(found) == 0 {
+		return database.Referral{}, pgx.ErrNoRows
+	}
+	return found[0], nil
+}
+
+func (q *Queries) GetReferralForUpdate(ctx context.Context, id int32) (database.Referral, error) {
+	return get(q, func(s *state) *table[int32, database.Referral] { return s.referrals }, id)
+}
+
+func (q *Queries) ListReferralsByReferrerID(ctx context.Context, arg database.ListReferralsByReferrerIDParams) ([]database.Referral, error) {
+	var referrals []database.Referral
+	err := q.read(func(s *state) error {
+		referrals = s.referrals.filter(func(r database.Referral) bool { return sameUUID(r.ReferrerID, arg.ReferrerID) })
+		slices.SortFunc(referrals, func(a, b database.Referral) int {
+			return cmp.Or(-compareTime(a.CreatedAt, b.CreatedAt), -cmp.Compare(a.ID, b.ID))
+		})
+		var err error
+		referrals, err = page(referrals, arg.Limit, arg.Offset)
+		return err
+	})
+	return referrals, err
+}
+
+func (q *Queries) MarkReferralRewarded(ctx context.Context, arg database.MarkReferralRewardedParams) (database.Referral, error) {
+	var r database.Referral
+	err := q.write(ctx, func(s *state) error {
+		var ok bool
+		if r, ok = s.referrals.get(arg.ID); !ok {
+			return pgx.ErrNoRows
+		}
+		r.Status = database.ReferralStatusRewarded
+		r.ReferrerTransactionID = arg.ReferrerTransactionID
+		r.RefereeTransactionID = arg.RefereeTransactionID
+		r.RewardedAt = s.now
+		r.UpdatedAt = s.now
+		return saveReferral(s, r)
+	})
+	if err != nil {
+		return database.Referral{}, err
+	}
+	return r, nil
+}
+
+func saveReferral(s *state, r database.Referral) error {
+	transaction := func(column string, id pgtype.Int4) error {
+		return references("referrals", column, "coin_transactions", !id.Valid || s.coinTransactions.has(id.Int32), id.Int32)
+	}
+	err := firstError(
+		varchar(r.RejectionReason.String, 50),
+		notNull("referrals", "referrer_id", r.ReferrerID.Valid),
+		notNull("referrals", "referee_id", r.RefereeID.Valid),
+		check("referrals", "referrals_not_self", r.ReferrerID.Bytes != r.RefereeID.Bytes),
+		unique("referrals", "referrals_referee_id_key",
+			s.referrals.any(func(o database.Referral) bool { return o.ID != r.ID && sameUUID(o.RefereeID, r.RefereeID) }),
+			fmt.Sprintf("(referee_id)=(%s)", uuidString(r.RefereeID))),
+		references("referrals", "referrer_id", "users", s.users.has(r.ReferrerID.Bytes), uuidString(r.ReferrerID)),
+		references("referrals", "referee_id", "users", s.users.has(r.RefereeID.Bytes), uuidString(r.RefereeID)),
+		transaction("referrer_transaction_id", r.ReferrerTransactionID),
+		transaction("referee_transaction_id", r.RefereeTransactionID),
+	)
+	if err != nil {
+		return err
+	}
+	put(s, &s.referrals, r.ID, r)
+	return nil
+}
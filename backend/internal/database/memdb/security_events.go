@@ -0,0 +1,61 @@
+package memdb
+
+import (
+	"backend/internal/database"
+	"cmp"
+	"context"
+	"slices"
+)
+
+func (q *Queries) CountSecurityEventsSince(ctx context.Context, arg database.CountSecurityEventsSinceParams) (int32, error) {
+	var count int32
+	err := q.read(func(s *state) error {
+		count = int32(len(s.securityEvents.filter(func(e database.SecurityEvent) bool {
+			return sameUUID(e.UserID, arg.UserID) && e.EventType == arg.EventType && atOrAfter(e.CreatedAt, arg.CreatedAt)
+		})))
+		return nil
+	})
+	return count, err
+}
+
+func (q *Queries) CreateSecurityEvent(ctx context.Context, arg database.CreateSecurityEventParams) (database.SecurityEvent, error) {
+	var e database.SecurityEvent
+	err := q.write(ctx, func(s *state) error {
+		e = database.SecurityEvent{
+			ID:        q.store.nextval("security_events"),
+			UserID:    arg.UserID,
+			EventType: arg.EventType,
+			Details:   arg.Details,
+			CreatedAt: s.now,
+		}
+		err := firstError(
+			varchar(e.EventType, 50),
+			notNull("security_events", "user_id", e.UserID.Valid),
+			references("security_events", "user_id", "users", s.users.has(e.UserID.Bytes), uuidString(e.UserID)),
+		)
+		if err != nil {
+			return err
+		}
+		put(s, &s.securityEvents, e.ID, e)
+		return nil
+	})
+	if err != nil {
+		return database.SecurityEvent{}, err
+	}
+	return e, nil
+}
+
+// ListSecurityEventsByUserID returns the user's most recent events first
+func (q *Queries) ListSecurityEventsByUserID(ctx context.Context, arg database.ListSecurityEventsByUserIDParams) ([]database.SecurityEvent, error) {
+	var events []database.SecurityEvent
+	err := q.read(func(s *state) error {
+		events = s.securityEvents.filter(func(e database.SecurityEvent) bool { return sameUUID(e.UserID, arg.UserID) })
+		slices.SortFunc(events, func(a, b database.SecurityEvent) int {
+			return cmp.Or(-compareTime(a.CreatedAt, b.CreatedAt), -cmp.Compare(a.ID, b.ID))
+		})
+		var err error
+		events, err = page(events, arg.Limit, 0)
+		return err
+	})
+	return events, err
+}
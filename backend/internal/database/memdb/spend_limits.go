@@ -0,0 +1,134 @@
+package memdb
+
+import (
+	"backend/internal/database"
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// BlockUserSpending blocks the user's spending until the later of the
+// existing block and the new one
+func (q *Queries) BlockUserSpending(ctx context.Context, arg database.BlockUserSpendingParams) error {
+	return q.write(ctx, func(s *state) error {
+		blockedUntil := storedTime(arg.SpendingBlockedUntil)
+		limit, ok := s.spendLimits.get(arg.UserID.Bytes)
+		if !ok || !arg.UserID.Valid {
+			return insertSpendLimit(s, database.UserSpendLimit{
+				UserID:               arg.UserID,
+				SpendingBlockedUntil: blockedUntil,
+				CreatedAt:            s.now,
+				UpdatedAt:            s.now,
+			})
+		}
+		limit.SpendingBlockedUntil = greatest(limit.SpendingBlockedUntil, blockedUntil)
+		limit.UpdatedAt = s.now
+		return saveSpendLimit(s, limit)
+	})
+}
+
+// GetSpendActivity returns how much the user spent since spent_since and how
+// many purchases they made since counted_since, active holds included
+func (q *Queries) GetSpendActivity(ctx context.Context, arg database.GetSpendActivityParams) (database.GetSpendActivityRow, error) {
+	var row database.GetSpendActivityRow
+	err := q.read(func(s *state) error {
+		var spent int64
+		count := func(amount int32, createdAt pgtype.Timestamptz) {
+			if atOrAfter(createdAt, arg.SpentSince) {
+				spent += int64(amount)
+			}
+			if atOrAfter(createdAt, arg.CountedSince) {
+				row.SpendCount++
+			}
+		}
+		for _, t := range s.coinTransactions.rows {
+			if sameUUID(t.UserID, arg.UserID) && t.TransactionType == database.TransactionTypePurchase {
+				count(-t.Amount, t.CreatedAt)
+			}
+		}
+		for _, h := range s.coinHolds.rows {
+			if sameUUID(h.UserID, arg.UserID) && h.Status == database.CoinHoldStatusActive {
+				count(h.Amount, h.CreatedAt)
+			}
+		}
+		var err error
+		row.Spent, err = integer(spent)
+		return err
+	})
+	return row, err
+}
+
+func (q *Queries) GetUserSpendLimit(ctx context.Context, userID pgtype.UUID) (database.UserSpendLimit, error) {
+	limit, err := get(q, func(s *state) *table[[16]byte, database.UserSpendLimit] { return s.spendLimits }, userID.Bytes)
+	if err == nil && !userID.Valid {
+		return database.UserSpendLimit{}, pgx.ErrNoRows
+	}
+	return limit, err
+}
+
+// UnblockUserSpending clears the user's block and reports whether there was one
+func (q *Queries) UnblockUserSpending(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	var rows int64
+	err := q.write(ctx, func(s *state) error {
+		limit, ok := s.spendLimits.get(userID.Bytes)
+		if !ok || !userID.Valid || !limit.SpendingBlockedUntil.Valid {
+			return nil
+		}
+		limit.SpendingBlockedUntil = pgtype.Timestamptz{}
+		limit.UpdatedAt = s.now
+		rows = 1
+		return saveSpendLimit(s, limit)
+	})
+	return rows, err
+}
+
+// UpsertUserSpendLimit writes only the limits, leaving a block in place
+func (q *Queries) UpsertUserSpendLimit(ctx context.Context, arg database.UpsertUserSpendLimitParams) (database.UserSpendLimit, error) {
+	var limit database.UserSpendLimit
+	err := q.write(ctx, func(s *state) error {
+		existing, ok := s.spendLimits.get(arg.UserID.Bytes)
+		if !ok || !arg.UserID.Valid {
+			limit = database.UserSpendLimit{
+				UserID:              arg.UserID,
+				DailyLimit:          arg.DailyLimit,
+				PerTransactionLimit: arg.PerTransactionLimit,
+				CreatedAt:           s.now,
+				UpdatedAt:           s.now,
+			}
+			return insertSpendLimit(s, limit)
+		}
+		limit = existing
+		limit.DailyLimit = arg.DailyLimit
+		limit.PerTransactionLimit = arg.PerTransactionLimit
+		limit.UpdatedAt = s.now
+		return saveSpendLimit(s, limit)
+	})
+	if err != nil {
+		return database.UserSpendLimit{}, err
+	}
+	return limit, nil
+}
+
+// insertSpendLimit checks the columns the way an insert does: the primary key
+// is NOT NULL before any other constraint is checked
+func insertSpendLimit(s *state, l database.UserSpendLimit) error {
+	if err := notNull("user_spend_limits", "user_id", l.UserID.Valid); err != nil {
+		return err
+	}
+	return saveSpendLimit(s, l)
+}
+
+func saveSpendLimit(s *state, l database.UserSpendLimit) error {
+	err := firstError(
+		check("user_spend_limits", "user_spend_limits_daily_limit_check", !l.DailyLimit.Valid || l.DailyLimit.Int32 >= 0),
+		check("user_spend_limits", "user_spend_limits_per_transaction_limit_check",
+			!l.PerTransactionLimit.Valid || l.PerTransactionLimit.Int32 >= 0),
+		references("user_spend_limits", "user_id", "users", s.users.has(l.UserID.Bytes), uuidString(l.UserID)),
+	)
+	if err != nil {
+		return err
+	}
+	put(s, &s.spendLimits, l.UserID.Bytes, l)
+	return nil
+}
@@ -0,0 +1,194 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// errNoSQL is returned by the pgx.Tx methods that would run SQL, which the
+// in-memory database cannot parse
+var errNoSQL = errors.New("memdb: transactions cannot run SQL; use Queries.WithTx")
+
+// Tx is a transaction on an in-memory database, or a savepoint within one.
+// Like a pgx.Tx it must be used by one goroutine at a time.
+type Tx struct {
+	store    *store
+	parent   *Tx // nil for the outermost transaction
+	readOnly bool
+	state    *state
+	// aborted is set when a statement fails, after which the transaction can
+	// only be rolled back, as in Postgres
+	aborted bool
+	done    bool
+}
+
+var _ pgx.Tx = (*Tx)(nil)
+
+// begin starts a transaction. A read-write one waits for the one in progress
+// to finish, so its snapshot already holds everything committed before it.
+func (s *store) begin(ctx context.Context, readOnly bool) (*Tx, error) {
+	if readOnly {
+		return &Tx{store: s, readOnly: true, state: s.current.Load()}, nil
+	}
+
+	select {
+	case s.writer <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &Tx{store: s, state: s.current.Load().fork(s.nextGen(), s.now())}, nil
+}
+
+// Begin starts a savepoint: a transaction whose work is undone on rollback
+// and kept in tx on commit
+func (tx *Tx) Begin(ctx context.Context) (pgx.Tx, error) {
+	if err := tx.usable(); err != nil {
+		return nil, err
+	}
+	return &Tx{
+		store:    tx.store,
+		parent:   tx,
+		readOnly: tx.readOnly,
+		state:    tx.state.fork(tx.store.nextGen(), tx.state.now),
+	}, nil
+}
+
+// Commit makes the transaction's work visible, to the enclosing transaction
+// for a savepoint. Committing a transaction in which a statement failed rolls
+// it back and returns pgx.ErrTxCommitRollback.
+func (tx *Tx) Commit(ctx context.Context) error {
+	if tx.done {
+		return pgx.ErrTxClosed
+	}
+	tx.done = true
+
+	if tx.parent != nil {
+		if tx.aborted {
+			tx.parent.aborted = true
+			return errAborted()
+		}
+		tx.parent.state = tx.state
+		return nil
+	}
+
+	defer tx.release()
+	if tx.aborted {
+		return pgx.ErrTxCommitRollback
+	}
+	if !tx.readOnly {
+		tx.store.current.Store(tx.state)
+	}
+	return nil
+}
+
+// Rollback discards the transaction's work
+func (tx *Tx) Rollback(ctx context.Context) error {
+	if tx.done {
+		return pgx.ErrTxClosed
+	}
+	tx.done = true
+	if tx.parent == nil {
+		tx.release()
+	}
+	return nil
+}
+
+func (tx *Tx) release() {
+	if !tx.readOnly {
+		<-tx.store.writer
+	}
+}
+
+// usable returns the error Postgres gives for a statement the transaction
+// cannot run any more
+func (tx *Tx) usable() error {
+	if tx.done {
+		return pgx.ErrTxClosed
+	}
+	if tx.aborted {
+		return errAborted()
+	}
+	return nil
+}
+
+func (tx *Tx) read(fn func(s *state) error) error {
+	if err := tx.usable(); err != nil {
+		return err
+	}
+	return tx.fail(fn(tx.state))
+}
+
+// write runs fn as one statement: if it fails, none of its writes are kept
+func (tx *Tx) write(fn func(s *state) error) error {
+	if err := tx.usable(); err != nil {
+		return err
+	}
+	if tx.readOnly {
+		return tx.fail(errReadOnly())
+	}
+
+	err := fn(tx.state)
+	if err != nil {
+		tx.state.rollback()
+		return tx.fail(err)
+	}
+	tx.state.undo = nil
+	return nil
+}
+
+// fail aborts the transaction if err is one the server would have raised;
+// pgx.ErrNoRows is reported by the client and leaves it usable
+func (tx *Tx) fail(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		tx.aborted = true
+	}
+	return err
+}
+
+func (tx *Tx) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return 0, errNoSQL
+}
+
+func (tx *Tx) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	return errBatchResults{}
+}
+
+func (tx *Tx) LargeObjects() pgx.LargeObjects {
+	return pgx.LargeObjects{}
+}
+
+func (tx *Tx) Prepare(ctx context.Context, name, sql string) (*pgconn.StatementDescription, error) {
+	return nil, errNoSQL
+}
+
+func (tx *Tx) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, errNoSQL
+}
+
+func (tx *Tx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return nil, errNoSQL
+}
+
+func (tx *Tx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return errRow{}
+}
+
+// Conn returns nil: there is no connection behind the transaction
+func (tx *Tx) Conn() *pgx.Conn {
+	return nil
+}
+
+type errRow struct{}
+
+func (errRow) Scan(dest ...any) error { return errNoSQL }
+
+type errBatchResults struct{}
+
+func (errBatchResults) Exec() (pgconn.CommandTag, error) { return pgconn.CommandTag{}, errNoSQL }
+func (errBatchResults) Query() (pgx.Rows, error)         { return nil, errNoSQL }
+func (errBatchResults) QueryRow() pgx.Row                { return errRow{} }
+func (errBatchResults) Close() error                     { return errNoSQL }
@@ -0,0 +1,270 @@
+package memdb
+
+import (
+	"backend/internal/database"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (q *Queries) CheckEmailExists(ctx context.Context, email string) (bool, error) {
+	var exists bool
+	err := q.read(func(s *state) error {
+		exists = s.users.any(func(u database.User) bool { return u.Email == email })
+		return nil
+	})
+	return exists, err
+}
+
+func (q *Queries) CheckEmailExistsForOtherUser(ctx context.Context, arg database.CheckEmailExistsForOtherUserParams) (bool, error) {
+	var exists bool
+	err := q.read(func(s *state) error {
+		exists = s.users.any(func(u database.User) bool {
+			return u.Email == arg.Email && arg.ID.Valid && u.ID.Bytes != arg.ID.Bytes
+		})
+		return nil
+	})
+	return exists, err
+}
+
+func (q *Queries) CountUsersByNormalizedEmail(ctx context.Context, normalizedEmail string) (int32, error) {
+	var count int32
+	err := q.read(func(s *state) error {
+		count = int32(len(s.users.filter(func(u database.User) bool { return u.NormalizedEmail == normalizedEmail })))
+		return nil
+	})
+	return count, err
+}
+
+// LockNormalizedEmail has nothing to do: read-write transactions already run
+// one at a time
+func (q *Queries) LockNormalizedEmail(ctx context.Context, normalizedEmail string) error {
+	return nil
+}
+
+func (q *Queries) CreateUser(ctx context.Context, arg database.CreateUserParams) (database.CreateUserRow, error) {
+	var u database.User
+	err := q.write(ctx, func(s *state) error {
+		u = database.User{
+			ID:              pgtype.UUID{Bytes: uuid.New(), Valid: true},
+			Name:            arg.Name,
+			Email:           arg.Email,
+			PasswordHash:    arg.PasswordHash,
+			Coins:           arg.Coins,
+			CreatedAt:       s.now,
+			UpdatedAt:       s.now,
+			ReferralCode:    arg.ReferralCode,
+			NormalizedEmail: arg.NormalizedEmail,
+		}
+		return insertUser(s, u)
+	})
+	if err != nil {
+		return database.CreateUserRow{}, err
+	}
+	return userRow(u), nil
+}
+
+// DeleteUser deletes the user along with the rows that reference it with ON
+// DELETE CASCADE, and fails if orders or coin transactions still reference it
+func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) error {
+	return q.write(ctx, func(s *state) error {
+		if _, ok := s.users.get(id.Bytes); !ok || !id.Valid {
+			return nil
+		}
+		of := func(userID pgtype.UUID) bool { return sameUUID(userID, id) }
+
+		for _, c := range s.cartItems.filter(func(c database.CartItem) bool { return of(c.UserID) }) {
+			del(s, &s.cartItems, c.ID)
+		}
+		for _, c := range s.comments.filter(func(c database.Comment) bool { return of(c.UserID) }) {
+			if err := deleteComment(s, c); err != nil {
+				return err
+			}
+		}
+		for _, l := range s.coinLots.filter(func(l database.CoinLot) bool { return of(l.UserID) }) {
+			del(s, &s.coinLots, l.ID)
+		}
+		del(s, &s.spendLimits, id.Bytes)
+		for _, e := range s.securityEvents.filter(func(e database.SecurityEvent) bool { return of(e.UserID) }) {
+			del(s, &s.securityEvents, e.ID)
+		}
+		for _, h := range s.coinHolds.filter(func(h database.CoinHold) bool { return of(h.UserID) }) {
+			del(s, &s.coinHolds, h.ID)
+		}
+		for _, r := range s.giftCodeRedemptions.filter(func(r database.GiftCodeRedemption) bool { return of(r.UserID) }) {
+			del(s, &s.giftCodeRedemptions, r.ID)
+		}
+		for _, r := range s.referrals.filter(func(r database.Referral) bool { return of(r.ReferrerID) || of(r.RefereeID) }) {
+			del(s, &s.referrals, r.ID)
+		}
+		for _, b := range s.giftCodeBatches.filter(func(b database.GiftCodeBatch) bool { return of(b.CreatedBy) }) {
+			b.CreatedBy = pgtype.UUID{}
+			put(s, &s.giftCodeBatches, b.ID, b)
+		}
+
+		if s.orders.any(func(o database.Order) bool { return of(o.UserID) }) {
+			return stillReferenced("orders", "user_id", "users", uuidString(id))
+		}
+		if s.coinTransactions.any(func(t database.CoinTransaction) bool { return of(t.UserID) }) {
+			return stillReferenced("coin_transactions", "user_id", "users", uuidString(id))
+		}
+
+		del(s, &s.users, id.Bytes)
+		return nil
+	})
+}
+
+func (q *Queries) GetUserByEmail(ctx context.Context, email string) (database.User, error) {
+	return q.findUser(func(u database.User) bool { return u.Email == email })
+}
+
+func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (database.User, error) {
+	return q.findUser(func(u database.User) bool { return sameUUID(u.ID, id) })
+}
+
+func (q *Queries) GetUserByIDForUpdate(ctx context.Context, id pgtype.UUID) (database.User, error) {
+	return q.findUser(func(u database.User) bool { return sameUUID(u.ID, id) })
+}
+
+func (q *Queries) GetUserByReferralCode(ctx context.Context, referralCode string) (database.User, error) {
+	return q.findUser(func(u database.User) bool { return u.ReferralCode == referralCode })
+}
+
+// findUser returns the user match accepts; the callers look users up by a
+// unique column, so there is at most one
+func (q *Queries) findUser(match func(database.User) bool) (database.User, error) {
+	var found []database.User
+	err := q.read(func(s *state) error {
+		found = s.users.filter(match)
+		return nil
+	})
+	if err != nil {
+		return database.User{}, err
+	}
+	if len(found) == 0 {
+		return database.User{}, pgx.ErrNoRows
+	}
+	return found[0], nil
+}
+
+func (q *Queries) UpdateUserCoins(ctx context.Context, arg database.UpdateUserCoinsParams) (database.UpdateUserCoinsRow, error) {
+	u, err := q.updateUser(ctx, arg.ID, func(u *database.User) error {
+		coins, err := addInt4(u.Coins, arg.Coins)
+		u.Coins = coins
+		return err
+	})
+	return database.UpdateUserCoinsRow(userRow(u)), err
+}
+
+func (q *Queries) UpdateUserEmail(ctx context.Context, arg database.UpdateUserEmailParams) (database.UpdateUserEmailRow, error) {
+	u, err := q.updateUser(ctx, arg.ID, func(u *database.User) error {
+		u.Email = arg.Email
+		u.NormalizedEmail = arg.NormalizedEmail
+		return nil
+	})
+	return database.UpdateUserEmailRow(userRow(u)), err
+}
+
+func (q *Queries) UpdateUserHeldCoins(ctx context.Context, arg database.UpdateUserHeldCoinsParams) (database.UpdateUserHeldCoinsRow, error) {
+	u, err := q.updateUser(ctx, arg.ID, func(u *database.User) error {
+		held, err := addInt32(u.HeldCoins, arg.HeldCoins)
+		u.HeldCoins = held.Int32
+		return err
+	})
+	return database.UpdateUserHeldCoinsRow(userRow(u)), err
+}
+
+func (q *Queries) UpdateUserName(ctx context.Context, arg database.UpdateUserNameParams) (database.UpdateUserNameRow, error) {
+	u, err := q.updateUser(ctx, arg.ID, func(u *database.User) error {
+		u.Name = arg.Name
+		return nil
+	})
+	return database.UpdateUserNameRow(userRow(u)), err
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg database.UpdateUserPasswordParams) error {
+	_, err := q.updateUser(ctx, arg.ID, func(u *database.User) error {
+		u.PasswordHash = arg.PasswordHash
+		return nil
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		// An :exec statement that matches nothing succeeds
+		return nil
+	}
+	return err
+}
+
+// updateUser applies set to the user with id, returning pgx.ErrNoRows if
+// there is none
+func (q *Queries) updateUser(ctx context.Context, id pgtype.UUID, set func(u *database.User) error) (database.User, error) {
+	var u database.User
+	err := q.write(ctx, func(s *state) error {
+		var ok bool
+		if u, ok = s.users.get(id.Bytes); !ok || !id.Valid {
+			return pgx.ErrNoRows
+		}
+		if err := set(&u); err != nil {
+			return err
+		}
+		u.UpdatedAt = s.now
+		return saveUser(s, u)
+	})
+	if err != nil {
+		return database.User{}, err
+	}
+	return u, nil
+}
+
+// userRow returns the columns the user inserts and updates return, which are
+// the same for each of them
+func userRow(u database.User) database.CreateUserRow {
+	return database.CreateUserRow{
+		ID:           u.ID,
+		Name:         u.Name,
+		Email:        u.Email,
+		Coins:        u.Coins,
+		CreatedAt:    u.CreatedAt,
+		UpdatedAt:    u.UpdatedAt,
+		HeldCoins:    u.HeldCoins,
+		ReferralCode: u.ReferralCode,
+	}
+}
+
+func insertUser(s *state, u database.User) error {
+	if err := unique("users", "users_pkey", s.users.has(u.ID.Bytes), fmt.Sprintf("(id)=(%s)", uuidString(u.ID))); err != nil {
+		return err
+	}
+	return saveUser(s, u)
+}
+
+func saveUser(s *state, u database.User) error {
+	other := func(match func(database.User) bool) bool {
+		return s.users.any(func(o database.User) bool { return o.ID.Bytes != u.ID.Bytes && match(o) })
+	}
+	err := firstError(
+		varchar(u.Name, 100),
+		varchar(u.Email, 255),
+		varchar(u.PasswordHash, 255),
+		varchar(u.ReferralCode, 16),
+		varchar(u.NormalizedEmail, 255),
+		notNull("users", "id", u.ID.Valid),
+		check("users", "users_coins_check", !u.Coins.Valid || u.Coins.Int32 >= 0),
+		check("users", "users_held_coins_check", u.HeldCoins >= 0),
+		check("users", "users_held_coins_within_balance", !u.Coins.Valid || u.HeldCoins <= u.Coins.Int32),
+		unique("users", "users_email_key",
+			other(func(o database.User) bool { return o.Email == u.Email }),
+			fmt.Sprintf("(email)=(%s)", u.Email)),
+		unique("users", "users_referral_code_key",
+			other(func(o database.User) bool { return o.ReferralCode == u.ReferralCode }),
+			fmt.Sprintf("(referral_code)=(%s)", u.ReferralCode)),
+	)
+	if err != nil {
+		return err
+	}
+	put(s, &s.users, u.ID.Bytes, u)
+	return nil
+}
@@ -0,0 +1,232 @@
+package memdb
+
+import (
+	"backend/internal/database"
+	"cmp"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func transactionType(t database.TransactionType) error {
+	return enum("transaction_type", t,
+		database.TransactionTypeCharge,
+		database.TransactionTypePurchase,
+		database.TransactionTypeRefund,
+		database.TransactionTypeBonus,
+		database.TransactionTypeExpiry,
+		database.TransactionTypeCashback,
+		database.TransactionTypeCashbackReversal,
+		database.TransactionTypeGiftCode,
+		database.TransactionTypeReferral,
+	)
+}
+
+func orderStatus(s database.OrderStatus) error {
+	return enum("order_status", s,
+		database.OrderStatusPending,
+		database.OrderStatusCompleted,
+		database.OrderStatusCancelled,
+		database.OrderStatusRefunded,
+	)
+}
+
+func coinHoldStatus(s database.CoinHoldStatus) error {
+	return enum("coin_hold_status", s,
+		database.CoinHoldStatusActive,
+		database.CoinHoldStatusCaptured,
+		database.CoinHoldStatusReleased,
+		database.CoinHoldStatusExpired,
+	)
+}
+
+func referralStatus(s database.ReferralStatus) error {
+	return enum("referral_status", s,
+		database.ReferralStatusPending,
+		database.ReferralStatusRewarded,
+		database.ReferralStatusRejected,
+	)
+}
+
+// timestamptz returns t as Postgres stores and pgx returns it: rounded to the
+// microsecond, in the local time zone
+func timestamptz(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t.Round(time.Microsecond).Local(), Valid: true}
+}
+
+// storedTime returns a timestamp parameter as it would be stored
+func storedTime(t pgtype.Timestamptz) pgtype.Timestamptz {
+	if !t.Valid || t.InfinityModifier != pgtype.Finite {
+		return t
+	}
+	return timestamptz(t.Time)
+}
+
+// timeOr and int4Or return v, or def when v is NULL. Fixture inserts use them
+// for columns that take their default when left out.
+func timeOr(v, def pgtype.Timestamptz) pgtype.Timestamptz {
+	if v.Valid {
+		return v
+	}
+	return def
+}
+
+func int4Or(v pgtype.Int4, def int32) pgtype.Int4 {
+	if v.Valid {
+		return v
+	}
+	return pgtype.Int4{Int32: def, Valid: true}
+}
+
+// compareTime orders timestamps as ORDER BY does: -infinity first, then
+// finite times, then infinity and finally NULL
+func compareTime(a, b pgtype.Timestamptz) int {
+	rank := func(t pgtype.Timestamptz) int {
+		if !t.Valid {
+			return 2
+		}
+		return int(t.InfinityModifier)
+	}
+	if c := cmp.Compare(rank(a), rank(b)); c != 0 || rank(a) != 0 {
+		return c
+	}
+	return a.Time.Compare(b.Time)
+}
+
+// before, atOrAfter and the other comparisons are false when either side is
+// NULL, as they are in a WHERE clause
+func before(a, b pgtype.Timestamptz) bool {
+	return a.Valid && b.Valid && compareTime(a, b) < 0
+}
+
+func atOrBefore(a, b pgtype.Timestamptz) bool {
+	return a.Valid && b.Valid && compareTime(a, b) <= 0
+}
+
+func after(a, b pgtype.Timestamptz) bool {
+	return a.Valid && b.Valid && compareTime(a, b) > 0
+}
+
+func atOrAfter(a, b pgtype.Timestamptz) bool {
+	return a.Valid && b.Valid && compareTime(a, b) >= 0
+}
+
+// within reports whether t is in [from, to)
+func within(t, from, to pgtype.Timestamptz) bool {
+	return atOrAfter(t, from) && before(t, to)
+}
+
+// greatest ignores NULLs, as GREATEST does
+func greatest(a, b pgtype.Timestamptz) pgtype.Timestamptz {
+	if !a.Valid || (b.Valid && compareTime(b, a) > 0) {
+		return b
+	}
+	return a
+}
+
+func sameUUID(a, b pgtype.UUID) bool {
+	return a.Valid && b.Valid && a.Bytes == b.Bytes
+}
+
+func sameInt4(a, b pgtype.Int4) bool {
+	return a.Valid && b.Valid && a.Int32 == b.Int32
+}
+
+func uuidString(u pgtype.UUID) string {
+	if !u.Valid {
+		return "NULL"
+	}
+	return uuid.UUID(u.Bytes).String()
+}
+
+// addInt4 is a + b, NULL if either is
+func addInt4(a, b pgtype.Int4) (pgtype.Int4, error) {
+	if !a.Valid || !b.Valid {
+		return pgtype.Int4{}, nil
+	}
+	return addInt32(a.Int32, b.Int32)
+}
+
+func addInt32(a, b int32) (pgtype.Int4, error) {
+	sum := int64(a) + int64(b)
+	if sum != int64(int32(sum)) {
+		return pgtype.Int4{}, pgError(pgNumericValueOutOfRange, "integer out of range")
+	}
+	return pgtype.Int4{Int32: int32(sum), Valid: true}, nil
+}
+
+// page applies LIMIT and OFFSET to rows that are already sorted
+func page[V any](rows []V, limit, offset int32) ([]V, error) {
+	if limit < 0 {
+		return nil, pgError(pgInvalidRowCountInLimit, "LIMIT must not be negative")
+	}
+	if offset < 0 {
+		return nil, pgError(pgInvalidRowCountInOffset, "OFFSET must not be negative")
+	}
+	if int(offset) >= len(rows) {
+		return nil, nil
+	}
+	rows = rows[offset:]
+	if int(limit) < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows, nil
+}
+
+// cents returns an amount of cents as a DECIMAL(_,2)
+func cents(c int64) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(c), Exp: -2, Valid: true}
+}
+
+// decimal returns n as stored in a DECIMAL(precision, scale) column: rounded
+// half away from zero to scale digits, or an error if it does not fit
+func decimal(n pgtype.Numeric, precision, scale int32) (pgtype.Numeric, error) {
+	if !n.Valid || n.NaN {
+		return n, nil
+	}
+	overflow := pgError(pgNumericValueOutOfRange, "numeric field overflow")
+	overflow.Detail = fmt.Sprintf("A field with precision %d, scale %d must round to an absolute value less than 10^%d.", precision, scale, precision-scale)
+	if n.InfinityModifier != pgtype.Finite {
+		return pgtype.Numeric{}, overflow
+	}
+
+	v := new(big.Int)
+	if n.Int != nil {
+		v.Set(n.Int)
+	}
+	if shift := n.Exp + scale; shift >= 0 {
+		v.Mul(v, pow10(shift))
+	} else {
+		div := pow10(-shift)
+		var rem big.Int
+		v.QuoRem(v, div, &rem)
+		if rem.Abs(&rem).Lsh(&rem, 1).Cmp(div) >= 0 {
+			v.Add(v, big.NewInt(int64(n.Int.Sign())))
+		}
+	}
+
+	if new(big.Int).Abs(v).Cmp(pow10(precision)) >= 0 {
+		return pgtype.Numeric{}, overflow
+	}
+	return pgtype.Numeric{Int: v, Exp: -scale, Valid: true}, nil
+}
+
+// compareNumeric compares a stored DECIMAL(_, scale) with a whole number. It
+// is only meaningful for a valid n; callers check for NULL separately.
+func compareNumeric(n pgtype.Numeric, scale int32, whole int64) int {
+	if n.NaN {
+		// NaN sorts above every number
+		return 1
+	}
+	if n.Int == nil {
+		return big.NewInt(0).Cmp(big.NewInt(whole))
+	}
+	return n.Int.Cmp(new(big.Int).Mul(big.NewInt(whole), pow10(scale)))
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -11,6 +11,50 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type CoinHoldStatus string
+
+const (
+	CoinHoldStatusActive   CoinHoldStatus = "active"
+	CoinHoldStatusCaptured CoinHoldStatus = "captured"
+	CoinHoldStatusReleased CoinHoldStatus = "released"
+	CoinHoldStatusExpired  CoinHoldStatus = "expired"
+)
+
+func (e *CoinHoldStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CoinHoldStatus(s)
+	case string:
+		*e = CoinHoldStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CoinHoldStatus: %T", src)
+	}
+	return nil
+}
+
+type NullCoinHoldStatus struct {
+	CoinHoldStatus CoinHoldStatus `json:"coin_hold_status"`
+	Valid          bool           `json:"valid"` // Valid is true if CoinHoldStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCoinHoldStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.CoinHoldStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CoinHoldStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCoinHoldStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CoinHoldStatus), nil
+}
+
 type OrderStatus string
 
 const (
@@ -55,12 +99,61 @@ func (ns NullOrderStatus) Value() (driver.Value, error) {
 	return string(ns.OrderStatus), nil
 }
 
+type ReferralStatus string
+
+const (
+	ReferralStatusPending  ReferralStatus = "pending"
+	ReferralStatusRewarded ReferralStatus = "rewarded"
+	ReferralStatusRejected ReferralStatus = "rejected"
+)
+
+func (e *ReferralStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReferralStatus(s)
+	case string:
+		*e = ReferralStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReferralStatus: %T", src)
+	}
+	return nil
+}
+
+type NullReferralStatus struct {
+	ReferralStatus ReferralStatus `json:"referral_status"`
+	Valid          bool           `json:"valid"` // Valid is true if ReferralStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullReferralStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ReferralStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ReferralStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullReferralStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ReferralStatus), nil
+}
+
 type TransactionType string
 
 const (
-	TransactionTypeCharge   TransactionType = "charge"
-	TransactionTypePurchase TransactionType = "purchase"
-	TransactionTypeRefund   TransactionType = "refund"
+	TransactionTypeCharge           TransactionType = "charge"
+	TransactionTypePurchase         TransactionType = "purchase"
+	TransactionTypeRefund           TransactionType = "refund"
+	TransactionTypeBonus            TransactionType = "bonus"
+	TransactionTypeExpiry           TransactionType = "expiry"
+	TransactionTypeCashback         TransactionType = "cashback"
+	TransactionTypeCashbackReversal TransactionType = "cashback_reversal"
+	TransactionTypeGiftCode         TransactionType = "gift_code"
+	TransactionTypeReferral         TransactionType = "referral"
 )
 
 func (e *TransactionType) Scan(src interface{}) error {
@@ -112,6 +205,49 @@ type Category struct {
 	Name string `db:"name" json:"name"`
 }
 
+type CategoryCashbackRate struct {
+	CategoryID  int32              `db:"category_id" json:"category_id"`
+	RatePercent pgtype.Numeric     `db:"rate_percent" json:"rate_percent"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type CoinHold struct {
+	ID                int32              `db:"id" json:"id"`
+	UserID            pgtype.UUID        `db:"user_id" json:"user_id"`
+	Amount            int32              `db:"amount" json:"amount"`
+	Description       pgtype.Text        `db:"description" json:"description"`
+	OrderID           pgtype.Int4        `db:"order_id" json:"order_id"`
+	Status            CoinHoldStatus     `db:"status" json:"status"`
+	ExpiresAt         pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	CoinTransactionID pgtype.Int4        `db:"coin_transaction_id" json:"coin_transaction_id"`
+	CreatedAt         pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt         pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type CoinLot struct {
+	ID                int32              `db:"id" json:"id"`
+	UserID            pgtype.UUID        `db:"user_id" json:"user_id"`
+	CoinTransactionID pgtype.Int4        `db:"coin_transaction_id" json:"coin_transaction_id"`
+	Source            TransactionType    `db:"source" json:"source"`
+	OriginalAmount    int32              `db:"original_amount" json:"original_amount"`
+	RemainingAmount   int32              `db:"remaining_amount" json:"remaining_amount"`
+	ExpiresAt         pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	CreatedAt         pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type CoinPack struct {
+	ID         int32              `db:"id" json:"id"`
+	Name       string             `db:"name" json:"name"`
+	BaseCoins  int32              `db:"base_coins" json:"base_coins"`
+	BonusCoins int32              `db:"bonus_coins" json:"bonus_coins"`
+	Price      pgtype.Numeric     `db:"price" json:"price"`
+	IsActive   bool               `db:"is_active" json:"is_active"`
+	SortOrder  int32              `db:"sort_order" json:"sort_order"`
+	CreatedAt  pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
 type CoinTransaction struct {
 	ID              int32              `db:"id" json:"id"`
 	UserID          pgtype.UUID        `db:"user_id" json:"user_id"`
@@ -121,6 +257,7 @@ type CoinTransaction struct {
 	OrderID         pgtype.Int4        `db:"order_id" json:"order_id"`
 	Description     pgtype.Text        `db:"description" json:"description"`
 	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	CoinPackID      pgtype.Int4        `db:"coin_pack_id" json:"coin_pack_id"`
 }
 
 type Comment struct {
@@ -133,6 +270,33 @@ type Comment struct {
 	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
 
+type GiftCode struct {
+	ID              int32              `db:"id" json:"id"`
+	BatchID         int32              `db:"batch_id" json:"batch_id"`
+	CodeHash        string             `db:"code_hash" json:"code_hash"`
+	CodeHint        string             `db:"code_hint" json:"code_hint"`
+	CoinAmount      int32              `db:"coin_amount" json:"coin_amount"`
+	MaxRedemptions  int32              `db:"max_redemptions" json:"max_redemptions"`
+	RedemptionCount int32              `db:"redemption_count" json:"redemption_count"`
+	ExpiresAt       pgtype.Timestamptz `db:"expires_at" json:"expires_at"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type GiftCodeBatch struct {
+	ID          int32              `db:"id" json:"id"`
+	Description pgtype.Text        `db:"description" json:"description"`
+	CreatedBy   pgtype.UUID        `db:"created_by" json:"created_by"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+type GiftCodeRedemption struct {
+	ID                int32              `db:"id" json:"id"`
+	GiftCodeID        int32              `db:"gift_code_id" json:"gift_code_id"`
+	UserID            pgtype.UUID        `db:"user_id" json:"user_id"`
+	CoinTransactionID int32              `db:"coin_transaction_id" json:"coin_transaction_id"`
+	CreatedAt         pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
 type Order struct {
 	ID             int32              `db:"id" json:"id"`
 	UserID         pgtype.UUID        `db:"user_id" json:"user_id"`
@@ -168,12 +332,46 @@ type Product struct {
 	UpdatedAt     pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
 
+type Referral struct {
+	ID                    int32              `db:"id" json:"id"`
+	ReferrerID            pgtype.UUID        `db:"referrer_id" json:"referrer_id"`
+	RefereeID             pgtype.UUID        `db:"referee_id" json:"referee_id"`
+	Status                ReferralStatus     `db:"status" json:"status"`
+	RejectionReason       pgtype.Text        `db:"rejection_reason" json:"rejection_reason"`
+	ReferrerTransactionID pgtype.Int4        `db:"referrer_transaction_id" json:"referrer_transaction_id"`
+	RefereeTransactionID  pgtype.Int4        `db:"referee_transaction_id" json:"referee_transaction_id"`
+	RewardedAt            pgtype.Timestamptz `db:"rewarded_at" json:"rewarded_at"`
+	CreatedAt             pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type SecurityEvent struct {
+	ID        int32              `db:"id" json:"id"`
+	UserID    pgtype.UUID        `db:"user_id" json:"user_id"`
+	EventType string             `db:"event_type" json:"event_type"`
+	Details   pgtype.Text        `db:"details" json:"details"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
 type User struct {
-	ID           pgtype.UUID        `db:"id" json:"id"`
-	Name         string             `db:"name" json:"name"`
-	Email        string             `db:"email" json:"email"`
-	PasswordHash string             `db:"password_hash" json:"password_hash"`
-	Coins        pgtype.Int4        `db:"coins" json:"coins"`
-	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	ID              pgtype.UUID        `db:"id" json:"id"`
+	Name            string             `db:"name" json:"name"`
+	Email           string             `db:"email" json:"email"`
+	PasswordHash    string             `db:"password_hash" json:"password_hash"`
+	Coins           pgtype.Int4        `db:"coins" json:"coins"`
+	CreatedAt       pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	IsAdmin         bool               `db:"is_admin" json:"is_admin"`
+	HeldCoins       int32              `db:"held_coins" json:"held_coins"`
+	ReferralCode    string             `db:"referral_code" json:"referral_code"`
+	NormalizedEmail string             `db:"normalized_email" json:"normalized_email"`
+}
+
+type UserSpendLimit struct {
+	UserID               pgtype.UUID        `db:"user_id" json:"user_id"`
+	DailyLimit           pgtype.Int4        `db:"daily_limit" json:"daily_limit"`
+	PerTransactionLimit  pgtype.Int4        `db:"per_transaction_limit" json:"per_transaction_limit"`
+	SpendingBlockedUntil pgtype.Timestamptz `db:"spending_blocked_until" json:"spending_blocked_until"`
+	CreatedAt            pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
@@ -0,0 +1,135 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: orders.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countOrdersByStatus = `-- name: CountOrdersByStatus :many
+SELECT COALESCE(status, 'pending')::order_status AS status, COUNT(*)::int AS count
+FROM orders
+GROUP BY COALESCE(status, 'pending')
+ORDER BY 1
+`
+
+type CountOrdersByStatusRow struct {
+	Status OrderStatus `db:"status" json:"status"`
+	Count  int32       `db:"count" json:"count"`
+}
+
+// A NULL status is an unset 'pending'
+func (q *Queries) CountOrdersByStatus(ctx context.Context) ([]CountOrdersByStatusRow, error) {
+	rows, err := q.db.Query(ctx, countOrdersByStatus)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountOrdersByStatusRow
+	for rows.Next() {
+		var i CountOrdersByStatusRow
+		if err := rows.Scan(&i.Status, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrderByID = `-- name: GetOrderByID :one
+SELECT id, user_id, order_number, total_amount, total_coins_used, status, created_at, updated_at
+FROM orders
+WHERE id = $1
+`
+
+func (q *Queries) GetOrderByID(ctx context.Context, id int32) (Order, error) {
+	row := q.db.QueryRow(ctx, getOrderByID, id)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.OrderNumber,
+		&i.TotalAmount,
+		&i.TotalCoinsUsed,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listOrderCategorySubtotals = `-- name: ListOrderCategorySubtotals :many
+SELECT p.category_id, SUM(oi.subtotal)::decimal(12,2) AS subtotal
+FROM order_items oi
+JOIN products p ON p.id = oi.product_id
+WHERE oi.order_id = $1
+GROUP BY p.category_id
+ORDER BY p.category_id
+`
+
+type ListOrderCategorySubtotalsRow struct {
+	CategoryID int32          `db:"category_id" json:"category_id"`
+	Subtotal   pgtype.Numeric `db:"subtotal" json:"subtotal"`
+}
+
+func (q *Queries) ListOrderCategorySubtotals(ctx context.Context, orderID int32) ([]ListOrderCategorySubtotalsRow, error) {
+	rows, err := q.db.Query(ctx, listOrderCategorySubtotals, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOrderCategorySubtotalsRow
+	for rows.Next() {
+		var i ListOrderCategorySubtotalsRow
+		if err := rows.Scan(&i.CategoryID, &i.Subtotal); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateOrderStatus = `-- name: UpdateOrderStatus :one
+UPDATE orders
+SET 
+    status = $1::order_status,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $2
+  AND COALESCE(status, 'pending') = $3::order_status
+RETURNING id, user_id, order_number, total_amount, total_coins_used, status, created_at, updated_at
+`
+
+type UpdateOrderStatusParams struct {
+	NewStatus     OrderStatus `db:"new_status" json:"new_status"`
+	ID            int32       `db:"id" json:"id"`
+	CurrentStatus OrderStatus `db:"current_status" json:"current_status"`
+}
+
+// The current status is part of the condition so that concurrent changes to
+// the same order cannot both apply. A NULL status is an unset 'pending'.
+func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (Order, error) {
+	row := q.db.QueryRow(ctx, updateOrderStatus, arg.NewStatus, arg.ID, arg.CurrentStatus)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.OrderNumber,
+		&i.TotalAmount,
+		&i.TotalCoinsUsed,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
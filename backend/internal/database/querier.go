@@ -11,32 +11,112 @@ import (
 )
 
 type Querier interface {
+	// A shorter block never replaces a longer one that is already in place.
+	BlockUserSpending(ctx context.Context, arg BlockUserSpendingParams) error
 	CheckCartItemExists(ctx context.Context, arg CheckCartItemExistsParams) (bool, error)
 	CheckEmailExists(ctx context.Context, email string) (bool, error)
 	CheckEmailExistsForOtherUser(ctx context.Context, arg CheckEmailExistsForOtherUserParams) (bool, error)
+	// A NULL status is an unset 'pending'
+	CountOrdersByStatus(ctx context.Context) ([]CountOrdersByStatusRow, error)
+	CountSecurityEventsSince(ctx context.Context, arg CountSecurityEventsSinceParams) (int32, error)
+	CountUsersByNormalizedEmail(ctx context.Context, normalizedEmail string) (int32, error)
 	CreateCartItem(ctx context.Context, arg CreateCartItemParams) (CartItem, error)
+	CreateCoinHold(ctx context.Context, arg CreateCoinHoldParams) (CoinHold, error)
+	CreateCoinLot(ctx context.Context, arg CreateCoinLotParams) (CoinLot, error)
+	CreateCoinPack(ctx context.Context, arg CreateCoinPackParams) (CoinPack, error)
 	CreateCoinTransaction(ctx context.Context, arg CreateCoinTransactionParams) (CoinTransaction, error)
+	CreateGiftCode(ctx context.Context, arg CreateGiftCodeParams) (GiftCode, error)
+	CreateGiftCodeBatch(ctx context.Context, arg CreateGiftCodeBatchParams) (GiftCodeBatch, error)
+	CreateGiftCodeRedemption(ctx context.Context, arg CreateGiftCodeRedemptionParams) (GiftCodeRedemption, error)
+	CreateReferral(ctx context.Context, arg CreateReferralParams) (Referral, error)
+	CreateSecurityEvent(ctx context.Context, arg CreateSecurityEventParams) (SecurityEvent, error)
 	// queries/user.sql
 	CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error)
 	DeleteAllCartItemsByUser(ctx context.Context, userID pgtype.UUID) error
 	DeleteCartItem(ctx context.Context, arg DeleteCartItemParams) error
+	DeleteCategoryCashbackRate(ctx context.Context, categoryID int32) (int64, error)
 	DeleteUser(ctx context.Context, id pgtype.UUID) error
 	GetAllCategories(ctx context.Context) ([]Category, error)
 	GetCartItemsByUser(ctx context.Context, userID pgtype.UUID) ([]CartItem, error)
 	GetCategoryByID(ctx context.Context, id int32) (Category, error)
+	// The balance at a point in time is the balance after the last ledger entry
+	// before it, or 0 before the first. Entries at exactly at are left out, as
+	// ListCoinBalanceChangesByInterval counts them from created_from.
+	GetCoinBalanceAt(ctx context.Context, arg GetCoinBalanceAtParams) (int32, error)
+	GetCoinHoldByID(ctx context.Context, id int32) (CoinHold, error)
+	GetCoinHoldForUpdate(ctx context.Context, id int32) (CoinHold, error)
+	GetCoinLotForUpdate(ctx context.Context, id int32) (CoinLot, error)
+	GetCoinPackByID(ctx context.Context, id int32) (CoinPack, error)
 	GetCoinTransactionByID(ctx context.Context, id int32) (CoinTransaction, error)
+	GetCoinTransactionByOrderAndType(ctx context.Context, arg GetCoinTransactionByOrderAndTypeParams) (CoinTransaction, error)
 	GetCoinTransactionsByUserID(ctx context.Context, arg GetCoinTransactionsByUserIDParams) ([]CoinTransaction, error)
+	GetExpiredCoinLotTotal(ctx context.Context, arg GetExpiredCoinLotTotalParams) (int32, error)
+	GetGiftCodeBatchByID(ctx context.Context, id int32) (GiftCodeBatch, error)
+	GetGiftCodeByHashForUpdate(ctx context.Context, codeHash string) (GiftCode, error)
+	GetOrderByID(ctx context.Context, id int32) (Order, error)
 	GetProductByID(ctx context.Context, id int32) (Product, error)
+	GetReferralByRefereeID(ctx context.Context, refereeID pgtype.UUID) (Referral, error)
+	GetReferralForUpdate(ctx context.Context, id int32) (Referral, error)
+	// Active holds count as spends: their coins are set aside for a purchase they
+	// become when captured. A captured hold is counted by its purchase entry.
+	GetSpendActivity(ctx context.Context, arg GetSpendActivityParams) (GetSpendActivityRow, error)
+	GetUpcomingCoinExpiries(ctx context.Context, arg GetUpcomingCoinExpiriesParams) ([]GetUpcomingCoinExpiriesRow, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
+	GetUserByIDForUpdate(ctx context.Context, id pgtype.UUID) (User, error)
+	GetUserByReferralCode(ctx context.Context, referralCode string) (User, error)
+	GetUserSpendLimit(ctx context.Context, userID pgtype.UUID) (UserSpendLimit, error)
+	GiftCodeRedeemedByUser(ctx context.Context, arg GiftCodeRedeemedByUserParams) (bool, error)
+	IncrementGiftCodeRedemptions(ctx context.Context, id int32) (GiftCode, error)
+	ListActiveCoinHoldsByOrderID(ctx context.Context, orderID pgtype.Int4) ([]CoinHold, error)
+	ListActiveCoinPacks(ctx context.Context) ([]CoinPack, error)
+	ListCategoryCashbackRates(ctx context.Context) ([]CategoryCashbackRate, error)
+	// Net change per bucket, truncated in the caller's timezone, with a running
+	// total so each bucket's closing balance is the opening balance plus
+	// running_change. Empty buckets are not returned.
+	ListCoinBalanceChangesByInterval(ctx context.Context, arg ListCoinBalanceChangesByIntervalParams) ([]ListCoinBalanceChangesByIntervalRow, error)
+	ListCoinHoldsByUserID(ctx context.Context, arg ListCoinHoldsByUserIDParams) ([]CoinHold, error)
+	ListCoinPacks(ctx context.Context) ([]CoinPack, error)
+	// The type list and date range are always bound so that idx_coin_transactions_type
+	// and idx_coin_transactions_created_at stay usable; callers pass every type and
+	// an infinite range when those filters are not set.
+	ListCoinTransactionsFiltered(ctx context.Context, arg ListCoinTransactionsFilteredParams) ([]CoinTransaction, error)
+	ListExpiredCoinHolds(ctx context.Context, arg ListExpiredCoinHoldsParams) ([]CoinHold, error)
+	// Lots of users whose coins are all held are left until the holds end, so
+	// they never fill a batch the sweep cannot expire.
+	ListExpiredCoinLots(ctx context.Context, arg ListExpiredCoinLotsParams) ([]CoinLot, error)
+	ListGiftCodesByBatchID(ctx context.Context, batchID int32) ([]GiftCode, error)
+	ListOrderCategorySubtotals(ctx context.Context, orderID int32) ([]ListOrderCategorySubtotalsRow, error)
 	ListProducts(ctx context.Context, arg ListProductsParams) ([]Product, error)
 	ListProductsByCategory(ctx context.Context, arg ListProductsByCategoryParams) ([]Product, error)
+	ListReferralsByReferrerID(ctx context.Context, arg ListReferralsByReferrerIDParams) ([]Referral, error)
+	ListSecurityEventsByUserID(ctx context.Context, arg ListSecurityEventsByUserIDParams) ([]SecurityEvent, error)
+	ListSpendableCoinLotsForUpdate(ctx context.Context, arg ListSpendableCoinLotsForUpdateParams) ([]CoinLot, error)
+	// Held until the transaction ends, so sign-ups of one inbox are serialized
+	// and each sees the accounts created before it
+	LockNormalizedEmail(ctx context.Context, normalizedEmail string) error
+	MarkReferralRewarded(ctx context.Context, arg MarkReferralRewardedParams) (Referral, error)
+	// Every type falls in exactly one total, so total_charged + total_refunded -
+	// total_spent is the net change of the matched entries. Keep the lists in step
+	// with entity.CoinTransactionSummary.
+	SummarizeCoinTransactionsFiltered(ctx context.Context, arg SummarizeCoinTransactionsFilteredParams) (SummarizeCoinTransactionsFilteredRow, error)
+	UnblockUserSpending(ctx context.Context, userID pgtype.UUID) (int64, error)
 	UpdateCartItemQuantity(ctx context.Context, arg UpdateCartItemQuantityParams) (CartItem, error)
+	UpdateCoinHoldStatus(ctx context.Context, arg UpdateCoinHoldStatusParams) (CoinHold, error)
+	UpdateCoinLotRemaining(ctx context.Context, arg UpdateCoinLotRemainingParams) error
+	UpdateCoinPack(ctx context.Context, arg UpdateCoinPackParams) (CoinPack, error)
+	// The current status is part of the condition so that concurrent changes to
+	// the same order cannot both apply. A NULL status is an unset 'pending'.
+	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (Order, error)
 	UpdateProductStock(ctx context.Context, arg UpdateProductStockParams) (Product, error)
 	UpdateUserCoins(ctx context.Context, arg UpdateUserCoinsParams) (UpdateUserCoinsRow, error)
 	UpdateUserEmail(ctx context.Context, arg UpdateUserEmailParams) (UpdateUserEmailRow, error)
+	UpdateUserHeldCoins(ctx context.Context, arg UpdateUserHeldCoinsParams) (UpdateUserHeldCoinsRow, error)
 	UpdateUserName(ctx context.Context, arg UpdateUserNameParams) (UpdateUserNameRow, error)
 	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	UpsertCategoryCashbackRate(ctx context.Context, arg UpsertCategoryCashbackRateParams) (CategoryCashbackRate, error)
+	// Only the limits are written; an active velocity block is left in place.
+	UpsertUserSpendLimit(ctx context.Context, arg UpsertUserSpendLimitParams) (UserSpendLimit, error)
 }
 
 var _ Querier = (*Queries)(nil)
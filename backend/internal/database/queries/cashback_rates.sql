@@ -0,0 +1,15 @@
+-- name: ListCategoryCashbackRates :many
+SELECT category_id, rate_percent, created_at, updated_at
+FROM category_cashback_rates
+ORDER BY category_id;
+
+-- name: UpsertCategoryCashbackRate :one
+INSERT INTO category_cashback_rates (category_id, rate_percent)
+VALUES ($1, $2)
+ON CONFLICT (category_id) DO UPDATE
+SET rate_percent = EXCLUDED.rate_percent
+RETURNING category_id, rate_percent, created_at, updated_at;
+
+-- name: DeleteCategoryCashbackRate :execrows
+DELETE FROM category_cashback_rates
+WHERE category_id = $1;
\ No newline at end of file
@@ -0,0 +1,46 @@
+-- name: CreateCoinHold :one
+INSERT INTO coin_holds (user_id, amount, description, order_id, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, user_id, amount, description, order_id, status, expires_at, coin_transaction_id, created_at, updated_at;
+
+-- name: GetCoinHoldByID :one
+SELECT id, user_id, amount, description, order_id, status, expires_at, coin_transaction_id, created_at, updated_at
+FROM coin_holds
+WHERE id = $1;
+
+-- name: GetCoinHoldForUpdate :one
+SELECT id, user_id, amount, description, order_id, status, expires_at, coin_transaction_id, created_at, updated_at
+FROM coin_holds
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListCoinHoldsByUserID :many
+SELECT id, user_id, amount, description, order_id, status, expires_at, coin_transaction_id, created_at, updated_at
+FROM coin_holds
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: ListExpiredCoinHolds :many
+SELECT id, user_id, amount, description, order_id, status, expires_at, coin_transaction_id, created_at, updated_at
+FROM coin_holds
+WHERE status = 'active'
+  AND expires_at <= $1
+ORDER BY expires_at ASC, id ASC
+LIMIT $2;
+
+-- name: UpdateCoinHoldStatus :one
+UPDATE coin_holds
+SET 
+    status = $2,
+    coin_transaction_id = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, user_id, amount, description, order_id, status, expires_at, coin_transaction_id, created_at, updated_at;
+
+-- name: ListActiveCoinHoldsByOrderID :many
+SELECT id, user_id, amount, description, order_id, status, expires_at, coin_transaction_id, created_at, updated_at
+FROM coin_holds
+WHERE order_id = $1
+  AND status = 'active'
+ORDER BY id ASC;
@@ -0,0 +1,52 @@
+-- name: CreateCoinLot :one
+INSERT INTO coin_lots (user_id, coin_transaction_id, source, original_amount, remaining_amount, expires_at)
+VALUES ($1, $2, $3, $4, $4, $5)
+RETURNING id, user_id, coin_transaction_id, source, original_amount, remaining_amount, expires_at, created_at;
+
+-- name: ListSpendableCoinLotsForUpdate :many
+SELECT id, user_id, coin_transaction_id, source, original_amount, remaining_amount, expires_at, created_at
+FROM coin_lots
+WHERE user_id = $1
+  AND remaining_amount > 0
+  AND (expires_at IS NULL OR expires_at > $2)
+ORDER BY created_at ASC, id ASC
+FOR UPDATE;
+
+-- name: GetExpiredCoinLotTotal :one
+SELECT COALESCE(SUM(remaining_amount), 0)::integer AS total
+FROM coin_lots
+WHERE user_id = $1
+  AND remaining_amount > 0
+  AND expires_at <= $2;
+
+-- name: UpdateCoinLotRemaining :exec
+UPDATE coin_lots
+SET remaining_amount = $2
+WHERE id = $1;
+
+-- name: ListExpiredCoinLots :many
+-- Lots of users whose coins are all held are left until the holds end, so
+-- they never fill a batch the sweep cannot expire.
+SELECT cl.id, cl.user_id, cl.coin_transaction_id, cl.source, cl.original_amount, cl.remaining_amount, cl.expires_at, cl.created_at
+FROM coin_lots cl
+JOIN users u ON u.id = cl.user_id
+WHERE cl.remaining_amount > 0
+  AND cl.expires_at <= $1
+  AND COALESCE(u.coins, 0) > u.held_coins
+ORDER BY cl.expires_at ASC, cl.id ASC
+LIMIT $2;
+
+-- name: GetCoinLotForUpdate :one
+SELECT id, user_id, coin_transaction_id, source, original_amount, remaining_amount, expires_at, created_at
+FROM coin_lots
+WHERE id = $1
+FOR UPDATE;
+
+-- name: GetUpcomingCoinExpiries :many
+SELECT expires_at::date AS expires_on, SUM(remaining_amount)::integer AS amount
+FROM coin_lots
+WHERE user_id = $1
+  AND remaining_amount > 0
+  AND expires_at > $2
+GROUP BY expires_at::date
+ORDER BY expires_on;
\ No newline at end of file
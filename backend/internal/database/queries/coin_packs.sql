@@ -0,0 +1,33 @@
+-- name: ListActiveCoinPacks :many
+SELECT id, name, base_coins, bonus_coins, price, is_active, sort_order, created_at, updated_at
+FROM coin_packs
+WHERE is_active = TRUE
+ORDER BY sort_order, id;
+
+-- name: ListCoinPacks :many
+SELECT id, name, base_coins, bonus_coins, price, is_active, sort_order, created_at, updated_at
+FROM coin_packs
+ORDER BY sort_order, id;
+
+-- name: GetCoinPackByID :one
+SELECT id, name, base_coins, bonus_coins, price, is_active, sort_order, created_at, updated_at
+FROM coin_packs
+WHERE id = $1;
+
+-- name: CreateCoinPack :one
+INSERT INTO coin_packs (name, base_coins, bonus_coins, price, is_active, sort_order)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, name, base_coins, bonus_coins, price, is_active, sort_order, created_at, updated_at;
+
+-- name: UpdateCoinPack :one
+UPDATE coin_packs
+SET 
+    name = $2,
+    base_coins = $3,
+    bonus_coins = $4,
+    price = $5,
+    is_active = $6,
+    sort_order = $7,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, base_coins, bonus_coins, price, is_active, sort_order, created_at, updated_at;
\ No newline at end of file
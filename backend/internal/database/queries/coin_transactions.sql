@@ -1,16 +1,85 @@
 -- name: CreateCoinTransaction :one
-INSERT INTO coin_transactions (user_id, transaction_type, amount, balance_after, order_id, description)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, user_id, transaction_type, amount, balance_after, order_id, description, created_at;
+INSERT INTO coin_transactions (user_id, transaction_type, amount, balance_after, order_id, description, coin_pack_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, user_id, transaction_type, amount, balance_after, order_id, description, created_at, coin_pack_id;
 
 -- name: GetCoinTransactionsByUserID :many
-SELECT id, user_id, transaction_type, amount, balance_after, order_id, description, created_at
+SELECT id, user_id, transaction_type, amount, balance_after, order_id, description, created_at, coin_pack_id
 FROM coin_transactions
 WHERE user_id = $1
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: GetCoinTransactionByID :one
-SELECT id, user_id, transaction_type, amount, balance_after, order_id, description, created_at
+SELECT id, user_id, transaction_type, amount, balance_after, order_id, description, created_at, coin_pack_id
 FROM coin_transactions
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: ListCoinTransactionsFiltered :many
+-- The type list and date range are always bound so that idx_coin_transactions_type
+-- and idx_coin_transactions_created_at stay usable; callers pass every type and
+-- an infinite range when those filters are not set.
+SELECT id, user_id, transaction_type, amount, balance_after, order_id, description, created_at, coin_pack_id
+FROM coin_transactions
+WHERE user_id = sqlc.arg(user_id)
+  AND transaction_type = ANY(sqlc.arg(transaction_types)::text[]::transaction_type[])
+  AND created_at >= sqlc.arg(created_from)
+  AND created_at < sqlc.arg(created_to)
+  AND (sqlc.narg(order_id)::integer IS NULL OR order_id = sqlc.narg(order_id))
+  AND (sqlc.arg(amount_sign)::integer = 0 OR SIGN(amount) = sqlc.arg(amount_sign)::integer)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: SummarizeCoinTransactionsFiltered :one
+-- Every type falls in exactly one total, so total_charged + total_refunded -
+-- total_spent is the net change of the matched entries. Keep the lists in step
+-- with entity.CoinTransactionSummary.
+SELECT
+    COUNT(*)::bigint AS total_count,
+    COALESCE(SUM(amount) FILTER (WHERE transaction_type IN ('charge', 'bonus', 'cashback', 'gift_code', 'referral')), 0)::bigint AS total_charged,
+    COALESCE(-SUM(amount) FILTER (WHERE transaction_type IN ('purchase', 'expiry', 'cashback_reversal')), 0)::bigint AS total_spent,
+    COALESCE(SUM(amount) FILTER (WHERE transaction_type = 'refund'), 0)::bigint AS total_refunded
+FROM coin_transactions
+WHERE user_id = sqlc.arg(user_id)
+  AND transaction_type = ANY(sqlc.arg(transaction_types)::text[]::transaction_type[])
+  AND created_at >= sqlc.arg(created_from)
+  AND created_at < sqlc.arg(created_to)
+  AND (sqlc.narg(order_id)::integer IS NULL OR order_id = sqlc.narg(order_id))
+  AND (sqlc.arg(amount_sign)::integer = 0 OR SIGN(amount) = sqlc.arg(amount_sign)::integer);
+
+-- name: GetCoinBalanceAt :one
+-- The balance at a point in time is the balance after the last ledger entry
+-- before it, or 0 before the first. Entries at exactly at are left out, as
+-- ListCoinBalanceChangesByInterval counts them from created_from.
+SELECT COALESCE((
+    SELECT ct.balance_after
+    FROM coin_transactions ct
+    WHERE ct.user_id = u.id
+      AND ct.created_at < sqlc.arg(at)
+    ORDER BY ct.created_at DESC, ct.id DESC
+    LIMIT 1
+), 0)::integer AS balance
+FROM users u
+WHERE u.id = sqlc.arg(user_id);
+
+-- name: ListCoinBalanceChangesByInterval :many
+-- Net change per bucket, truncated in the caller's timezone, with a running
+-- total so each bucket's closing balance is the opening balance plus
+-- running_change. Empty buckets are not returned.
+SELECT date_trunc(sqlc.arg(bucket)::text, created_at, sqlc.arg(time_zone)::text)::timestamptz AS bucket_start,
+       SUM(amount)::integer AS net_change,
+       (SUM(SUM(amount)) OVER (ORDER BY date_trunc(sqlc.arg(bucket)::text, created_at, sqlc.arg(time_zone)::text)))::integer AS running_change
+FROM coin_transactions
+WHERE user_id = sqlc.arg(user_id)
+  AND created_at >= sqlc.arg(created_from)
+  AND created_at < sqlc.arg(created_to)
+GROUP BY date_trunc(sqlc.arg(bucket)::text, created_at, sqlc.arg(time_zone)::text)
+ORDER BY bucket_start;
+
+-- name: GetCoinTransactionByOrderAndType :one
+SELECT id, user_id, transaction_type, amount, balance_after, order_id, description, created_at, coin_pack_id
+FROM coin_transactions
+WHERE order_id = $1
+  AND transaction_type = $2
+ORDER BY id
+LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,43 @@
+-- name: CreateGiftCodeBatch :one
+INSERT INTO gift_code_batches (description, created_by)
+VALUES ($1, $2)
+RETURNING id, description, created_by, created_at;
+
+-- name: GetGiftCodeBatchByID :one
+SELECT id, description, created_by, created_at
+FROM gift_code_batches
+WHERE id = $1;
+
+-- name: CreateGiftCode :one
+INSERT INTO gift_codes (batch_id, code_hash, code_hint, coin_amount, max_redemptions, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, batch_id, code_hash, code_hint, coin_amount, max_redemptions, redemption_count, expires_at, created_at;
+
+-- name: ListGiftCodesByBatchID :many
+SELECT id, batch_id, code_hash, code_hint, coin_amount, max_redemptions, redemption_count, expires_at, created_at
+FROM gift_codes
+WHERE batch_id = $1
+ORDER BY id;
+
+-- name: GetGiftCodeByHashForUpdate :one
+SELECT id, batch_id, code_hash, code_hint, coin_amount, max_redemptions, redemption_count, expires_at, created_at
+FROM gift_codes
+WHERE code_hash = $1
+FOR UPDATE;
+
+-- name: IncrementGiftCodeRedemptions :one
+UPDATE gift_codes
+SET redemption_count = redemption_count + 1
+WHERE id = $1
+RETURNING id, batch_id, code_hash, code_hint, coin_amount, max_redemptions, redemption_count, expires_at, created_at;
+
+-- name: GiftCodeRedeemedByUser :one
+SELECT EXISTS(
+    SELECT 1 FROM gift_code_redemptions
+    WHERE gift_code_id = $1 AND user_id = $2
+);
+
+-- name: CreateGiftCodeRedemption :one
+INSERT INTO gift_code_redemptions (gift_code_id, user_id, coin_transaction_id)
+VALUES ($1, $2, $3)
+RETURNING id, gift_code_id, user_id, coin_transaction_id, created_at;
\ No newline at end of file
@@ -0,0 +1,29 @@
+-- name: GetOrderByID :one
+SELECT id, user_id, order_number, total_amount, total_coins_used, status, created_at, updated_at
+FROM orders
+WHERE id = $1;
+
+-- name: UpdateOrderStatus :one
+-- The current status is part of the condition so that concurrent changes to
+-- the same order cannot both apply. A NULL status is an unset 'pending'.
+UPDATE orders
+SET 
+    status = sqlc.arg(new_status)::order_status,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg(id)
+  AND COALESCE(status, 'pending') = sqlc.arg(current_status)::order_status
+RETURNING id, user_id, order_number, total_amount, total_coins_used, status, created_at, updated_at;
+
+-- name: ListOrderCategorySubtotals :many
+SELECT p.category_id, SUM(oi.subtotal)::decimal(12,2) AS subtotal
+FROM order_items oi
+JOIN products p ON p.id = oi.product_id
+WHERE oi.order_id = $1
+GROUP BY p.category_id
+ORDER BY p.category_id;
+-- name: CountOrdersByStatus :many
+-- A NULL status is an unset 'pending'
+SELECT COALESCE(status, 'pending')::order_status AS status, COUNT(*)::int AS count
+FROM orders
+GROUP BY COALESCE(status, 'pending')
+ORDER BY 1;
@@ -0,0 +1,33 @@
+-- name: CreateReferral :one
+INSERT INTO referrals (referrer_id, referee_id, status, rejection_reason)
+VALUES ($1, $2, $3, $4)
+RETURNING id, referrer_id, referee_id, status, rejection_reason, referrer_transaction_id, referee_transaction_id, rewarded_at, created_at, updated_at;
+
+-- name: GetReferralByRefereeID :one
+SELECT id, referrer_id, referee_id, status, rejection_reason, referrer_transaction_id, referee_transaction_id, rewarded_at, created_at, updated_at
+FROM referrals
+WHERE referee_id = $1;
+
+-- name: GetReferralForUpdate :one
+SELECT id, referrer_id, referee_id, status, rejection_reason, referrer_transaction_id, referee_transaction_id, rewarded_at, created_at, updated_at
+FROM referrals
+WHERE id = $1
+FOR UPDATE;
+
+-- name: MarkReferralRewarded :one
+UPDATE referrals
+SET 
+    status = 'rewarded',
+    referrer_transaction_id = $2,
+    referee_transaction_id = $3,
+    rewarded_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, referrer_id, referee_id, status, rejection_reason, referrer_transaction_id, referee_transaction_id, rewarded_at, created_at, updated_at;
+
+-- name: ListReferralsByReferrerID :many
+SELECT id, referrer_id, referee_id, status, rejection_reason, referrer_transaction_id, referee_transaction_id, rewarded_at, created_at, updated_at
+FROM referrals
+WHERE referrer_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
\ No newline at end of file
@@ -0,0 +1,18 @@
+-- name: CreateSecurityEvent :one
+INSERT INTO security_events (user_id, event_type, details)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, event_type, details, created_at;
+
+-- name: ListSecurityEventsByUserID :many
+SELECT id, user_id, event_type, details, created_at
+FROM security_events
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: CountSecurityEventsSince :one
+SELECT COUNT(*)::integer AS event_count
+FROM security_events
+WHERE user_id = $1
+  AND event_type = $2
+  AND created_at >= $3;
\ No newline at end of file
@@ -0,0 +1,43 @@
+-- name: GetUserSpendLimit :one
+SELECT user_id, daily_limit, per_transaction_limit, spending_blocked_until, created_at, updated_at
+FROM user_spend_limits
+WHERE user_id = $1;
+
+-- name: UpsertUserSpendLimit :one
+-- Only the limits are written; an active velocity block is left in place.
+INSERT INTO user_spend_limits (user_id, daily_limit, per_transaction_limit)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET daily_limit = EXCLUDED.daily_limit,
+    per_transaction_limit = EXCLUDED.per_transaction_limit
+RETURNING user_id, daily_limit, per_transaction_limit, spending_blocked_until, created_at, updated_at;
+
+-- name: BlockUserSpending :exec
+-- A shorter block never replaces a longer one that is already in place.
+INSERT INTO user_spend_limits (user_id, spending_blocked_until)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET spending_blocked_until = GREATEST(user_spend_limits.spending_blocked_until, EXCLUDED.spending_blocked_until);
+
+-- name: UnblockUserSpending :execrows
+UPDATE user_spend_limits
+SET spending_blocked_until = NULL
+WHERE user_id = $1
+  AND spending_blocked_until IS NOT NULL;
+
+-- name: GetSpendActivity :one
+-- Active holds count as spends: their coins are set aside for a purchase they
+-- become when captured. A captured hold is counted by its purchase entry.
+SELECT
+    (
+        COALESCE((SELECT -SUM(ct.amount) FROM coin_transactions ct
+                  WHERE ct.user_id = sqlc.arg(user_id) AND ct.transaction_type = 'purchase' AND ct.created_at >= sqlc.arg(spent_since)), 0)
+        + COALESCE((SELECT SUM(ch.amount) FROM coin_holds ch
+                    WHERE ch.user_id = sqlc.arg(user_id) AND ch.status = 'active' AND ch.created_at >= sqlc.arg(spent_since)), 0)
+    )::integer AS spent,
+    (
+        (SELECT COUNT(*) FROM coin_transactions ct
+         WHERE ct.user_id = sqlc.arg(user_id) AND ct.transaction_type = 'purchase' AND ct.created_at >= sqlc.arg(counted_since))
+        + (SELECT COUNT(*) FROM coin_holds ch
+           WHERE ch.user_id = sqlc.arg(user_id) AND ch.status = 'active' AND ch.created_at >= sqlc.arg(counted_since))
+    )::integer AS spend_count;
@@ -1,35 +1,51 @@
 -- queries/user.sql
 
 -- name: CreateUser :one
-INSERT INTO users (name, email, password_hash, coins)
-VALUES ($1, $2, $3, $4)
-RETURNING id, name, email, coins, created_at, updated_at;
+INSERT INTO users (name, email, password_hash, coins, referral_code, normalized_email)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, name, email, coins, created_at, updated_at, held_coins, referral_code;
 
 -- name: GetUserByID :one
-SELECT id, name, email, password_hash, coins, created_at, updated_at
+SELECT id, name, email, password_hash, coins, created_at, updated_at, is_admin, held_coins, referral_code, normalized_email
 FROM users
 WHERE id = $1;
 
 -- name: GetUserByEmail :one
-SELECT id, name, email, password_hash, coins, created_at, updated_at
+SELECT id, name, email, password_hash, coins, created_at, updated_at, is_admin, held_coins, referral_code, normalized_email
 FROM users
 WHERE email = $1;
 
+-- name: GetUserByReferralCode :one
+SELECT id, name, email, password_hash, coins, created_at, updated_at, is_admin, held_coins, referral_code, normalized_email
+FROM users
+WHERE referral_code = $1;
+
+-- name: CountUsersByNormalizedEmail :one
+SELECT COUNT(*)::integer AS user_count
+FROM users
+WHERE normalized_email = $1;
+
+-- name: LockNormalizedEmail :exec
+-- Held until the transaction ends, so sign-ups of one inbox are serialized
+-- and each sees the accounts created before it
+SELECT pg_advisory_xact_lock(hashtext(@normalized_email::text));
+
 -- name: UpdateUserName :one
 UPDATE users
 SET 
     name = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, email, coins, created_at, updated_at;
+RETURNING id, name, email, coins, created_at, updated_at, held_coins, referral_code;
 
 -- name: UpdateUserEmail :one  
 UPDATE users
 SET 
     email = $2,
+    normalized_email = $3,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, email, coins, created_at, updated_at;
+RETURNING id, name, email, coins, created_at, updated_at, held_coins, referral_code;
 
 -- name: UpdateUserCoins :one
 UPDATE users
@@ -37,7 +53,21 @@ SET
     coins = coins + $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, email, coins, created_at, updated_at;
+RETURNING id, name, email, coins, created_at, updated_at, held_coins, referral_code;
+
+-- name: GetUserByIDForUpdate :one
+SELECT id, name, email, password_hash, coins, created_at, updated_at, is_admin, held_coins, referral_code, normalized_email
+FROM users
+WHERE id = $1
+FOR UPDATE;
+
+-- name: UpdateUserHeldCoins :one
+UPDATE users
+SET 
+    held_coins = held_coins + $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, email, coins, created_at, updated_at, held_coins, referral_code;
 
 -- name: UpdateUserPassword :exec
 UPDATE users
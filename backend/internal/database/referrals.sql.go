@@ -0,0 +1,178 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: referrals.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createReferral = `-- name: CreateReferral :one
+INSERT INTO referrals (referrer_id, referee_id, status, rejection_reason)
+VALUES ($1, $2, $3, $4)
+RETURNING id, referrer_id, referee_id, status, rejection_reason, referrer_transaction_id, referee_transaction_id, rewarded_at, created_at, updated_at
+`
+
+type CreateReferralParams struct {
+	ReferrerID      pgtype.UUID    `db:"referrer_id" json:"referrer_id"`
+	RefereeID       pgtype.UUID    `db:"referee_id" json:"referee_id"`
+	Status          ReferralStatus `db:"status" json:"status"`
+	RejectionReason pgtype.Text    `db:"rejection_reason" json:"rejection_reason"`
+}
+
+func (q *Queries) CreateReferral(ctx context.Context, arg CreateReferralParams) (Referral, error) {
+	row := q.db.QueryRow(ctx, createReferral,
+		arg.ReferrerID,
+		arg.RefereeID,
+		arg.Status,
+		arg.RejectionReason,
+	)
+	var i Referral
+	err := row.Scan(
+		&i.ID,
+		&i.ReferrerID,
+		&i.RefereeID,
+		&i.Status,
+		&i.RejectionReason,
+		&i.ReferrerTransactionID,
+		&i.RefereeTransactionID,
+		&i.RewardedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getReferralByRefereeID = `-- name: GetReferralByRefereeID :one
+SELECT id, referrer_id, referee_id, status, rejection_reason, referrer_transaction_id, referee_transaction_id, rewarded_at, created_at, updated_at
+FROM referrals
+WHERE referee_id = $1
+`
+
+func (q *Queries) GetReferralByRefereeID(ctx context.Context, refereeID pgtype.UUID) (Referral, error) {
+	row := q.db.QueryRow(ctx, getReferralByRefereeID, refereeID)
+	var i Referral
+	err := row.Scan(
+		&i.ID,
+		&i.ReferrerID,
+		&i.RefereeID,
+		&i.Status,
+		&i.RejectionReason,
+		&i.ReferrerTransactionID,
+		&i.RefereeTransactionID,
+		&i.RewardedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getReferralForUpdate = `-- name: GetReferralForUpdate :one
+SELECT id, referrer_id, referee_id, status, rejection_reason, referrer_transaction_id, referee_transaction_id, rewarded_at, created_at, updated_at
+FROM referrals
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetReferralForUpdate(ctx context.Context, id int32) (Referral, error) {
+	row := q.db.QueryRow(ctx, getReferralForUpdate, id)
+	var i Referral
+	err := row.Scan(
+		&i.ID,
+		&i.ReferrerID,
+		&i.RefereeID,
+		&i.Status,
+		&i.RejectionReason,
+		&i.ReferrerTransactionID,
+		&i.RefereeTransactionID,
+		&i.RewardedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listReferralsByReferrerID = `-- name: ListReferralsByReferrerID :many
+SELECT id, referrer_id, referee_id, status, rejection_reason, referrer_transaction_id, referee_transaction_id, rewarded_at, created_at, updated_at
+FROM referrals
+WHERE referrer_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListReferralsByReferrerIDParams struct {
+	ReferrerID pgtype.UUID `db:"referrer_id" json:"referrer_id"`
+	Limit      int32       `db:"limit" json:"limit"`
+	Offset     int32       `db:"offset" json:"offset"`
+}
+
+func (q *Queries) ListReferralsByReferrerID(ctx context.Context, arg ListReferralsByReferrerIDParams) ([]Referral, error) {
+	rows, err := q.db.Query(ctx, listReferralsByReferrerID, arg.ReferrerID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Referral
+	for rows.Next() {
+		var i Referral
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReferrerID,
+			&i.RefereeID,
+			&i.Status,
+			&i.RejectionReason,
+			&i.ReferrerTransactionID,
+			&i.RefereeTransactionID,
+			&i.RewardedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markReferralRewarded = `-- name: MarkReferralRewarded :one
+UPDATE referrals
+SET 
+    status = 'rewarded',
+    referrer_transaction_id = $2,
+    referee_transaction_id = $3,
+    rewarded_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, referrer_id, referee_id, status, rejection_reason, referrer_transaction_id, referee_transaction_id, rewarded_at, created_at, updated_at
+`
+
+type MarkReferralRewardedParams struct {
+	ID                    int32       `db:"id" json:"id"`
+	ReferrerTransactionID pgtype.Int4 `db:"referrer_transaction_id" json:"referrer_transaction_id"`
+	RefereeTransactionID  pgtype.Int4 `db:"referee_transaction_id" json:"referee_transaction_id"`
+}
+
+func (q *Queries) MarkReferralRewarded(ctx context.Context, arg MarkReferralRewardedParams) (Referral, error) {
+	row := q.db.QueryRow(ctx, markReferralRewarded, arg.ID, arg.ReferrerTransactionID, arg.RefereeTransactionID)
+	var i Referral
+	err := row.Scan(
+		&i.ID,
+		&i.ReferrerID,
+		&i.RefereeID,
+		&i.Status,
+		&i.RejectionReason,
+		&i.ReferrerTransactionID,
+		&i.RefereeTransactionID,
+		&i.RewardedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
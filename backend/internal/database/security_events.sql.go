@@ -0,0 +1,97 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: security_events.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countSecurityEventsSince = `-- name: CountSecurityEventsSince :one
+SELECT COUNT(*)::integer AS event_count
+FROM security_events
+WHERE user_id = $1
+  AND event_type = $2
+  AND created_at >= $3
+`
+
+type CountSecurityEventsSinceParams struct {
+	UserID    pgtype.UUID        `db:"user_id" json:"user_id"`
+	EventType string             `db:"event_type" json:"event_type"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+}
+
+func (q *Queries) CountSecurityEventsSince(ctx context.Context, arg CountSecurityEventsSinceParams) (int32, error) {
+	row := q.db.QueryRow(ctx, countSecurityEventsSince, arg.UserID, arg.EventType, arg.CreatedAt)
+	var event_count int32
+	err := row.Scan(&event_count)
+	return event_count, err
+}
+
+const createSecurityEvent = `-- name: CreateSecurityEvent :one
+INSERT INTO security_events (user_id, event_type, details)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, event_type, details, created_at
+`
+
+type CreateSecurityEventParams struct {
+	UserID    pgtype.UUID `db:"user_id" json:"user_id"`
+	EventType string      `db:"event_type" json:"event_type"`
+	Details   pgtype.Text `db:"details" json:"details"`
+}
+
+func (q *Queries) CreateSecurityEvent(ctx context.Context, arg CreateSecurityEventParams) (SecurityEvent, error) {
+	row := q.db.QueryRow(ctx, createSecurityEvent, arg.UserID, arg.EventType, arg.Details)
+	var i SecurityEvent
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.EventType,
+		&i.Details,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listSecurityEventsByUserID = `-- name: ListSecurityEventsByUserID :many
+SELECT id, user_id, event_type, details, created_at
+FROM security_events
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2
+`
+
+type ListSecurityEventsByUserIDParams struct {
+	UserID pgtype.UUID `db:"user_id" json:"user_id"`
+	Limit  int32       `db:"limit" json:"limit"`
+}
+
+func (q *Queries) ListSecurityEventsByUserID(ctx context.Context, arg ListSecurityEventsByUserIDParams) ([]SecurityEvent, error) {
+	rows, err := q.db.Query(ctx, listSecurityEventsByUserID, arg.UserID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SecurityEvent
+	for rows.Next() {
+		var i SecurityEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.EventType,
+			&i.Details,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
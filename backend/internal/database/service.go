@@ -2,19 +2,28 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
 type Service struct {
-	db      *pgxpool.Pool
-	queries *Queries
+	db        *pgxpool.Pool
+	queries   *Queries
+	txManager TxManager
 }
 
 func NewService(ctx context.Context, databaseURL string) (*Service, error) {
-	db, err := pgxpool.New(ctx, databaseURL)
+	config, err := pgxpool.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database url: %w", err)
+	}
+	config.ConnConfig.Tracer = NewQueryTracer()
+
+	db, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
 	}
@@ -26,8 +35,9 @@ func NewService(ctx context.Context, databaseURL string) (*Service, error) {
 	queries := New(db)
 
 	return &Service{
-		db:      db,
-		queries: queries,
+		db:        db,
+		queries:   queries,
+		txManager: NewTxManager(db),
 	}, nil
 }
 
@@ -42,3 +52,28 @@ func (s *Service) Queries() *Queries {
 func (s *Service) DB() *pgxpool.Pool {
 	return s.db
 }
+
+func (s *Service) TxManager() TxManager {
+	return s.txManager
+}
+
+// Ping checks that a connection can be acquired and the server answers
+func (s *Service) Ping(ctx context.Context) error {
+	return s.db.Ping(ctx)
+}
+
+// MigrationVersion returns the schema version recorded by the migration tool
+// and whether the last migration failed part way. A database that has never
+// been migrated is at version 0.
+func (s *Service) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := s.db.QueryRow(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	return uint(version), dirty, nil
+}
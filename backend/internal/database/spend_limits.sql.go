@@ -0,0 +1,131 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: spend_limits.sql
+
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const blockUserSpending = `-- name: BlockUserSpending :exec
+INSERT INTO user_spend_limits (user_id, spending_blocked_until)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET spending_blocked_until = GREATEST(user_spend_limits.spending_blocked_until, EXCLUDED.spending_blocked_until)
+`
+
+type BlockUserSpendingParams struct {
+	UserID               pgtype.UUID        `db:"user_id" json:"user_id"`
+	SpendingBlockedUntil pgtype.Timestamptz `db:"spending_blocked_until" json:"spending_blocked_until"`
+}
+
+// A shorter block never replaces a longer one that is already in place.
+func (q *Queries) BlockUserSpending(ctx context.Context, arg BlockUserSpendingParams) error {
+	_, err := q.db.Exec(ctx, blockUserSpending, arg.UserID, arg.SpendingBlockedUntil)
+	return err
+}
+
+const getSpendActivity = `-- name: GetSpendActivity :one
+SELECT
+    (
+        COALESCE((SELECT -SUM(ct.amount) FROM coin_transactions ct
+                  WHERE ct.user_id = $1 AND ct.transaction_type = 'purchase' AND ct.created_at >= $2), 0)
+        + COALESCE((SELECT SUM(ch.amount) FROM coin_holds ch
+                    WHERE ch.user_id = $1 AND ch.status = 'active' AND ch.created_at >= $2), 0)
+    )::integer AS spent,
+    (
+        (SELECT COUNT(*) FROM coin_transactions ct
+         WHERE ct.user_id = $1 AND ct.transaction_type = 'purchase' AND ct.created_at >= $3)
+        + (SELECT COUNT(*) FROM coin_holds ch
+           WHERE ch.user_id = $1 AND ch.status = 'active' AND ch.created_at >= $3)
+    )::integer AS spend_count
+`
+
+type GetSpendActivityParams struct {
+	UserID       pgtype.UUID        `db:"user_id" json:"user_id"`
+	SpentSince   pgtype.Timestamptz `db:"spent_since" json:"spent_since"`
+	CountedSince pgtype.Timestamptz `db:"counted_since" json:"counted_since"`
+}
+
+type GetSpendActivityRow struct {
+	Spent      int32 `db:"spent" json:"spent"`
+	SpendCount int32 `db:"spend_count" json:"spend_count"`
+}
+
+// Active holds count as spends: their coins are set aside for a purchase they
+// become when captured. A captured hold is counted by its purchase entry.
+func (q *Queries) GetSpendActivity(ctx context.Context, arg GetSpendActivityParams) (GetSpendActivityRow, error) {
+	row := q.db.QueryRow(ctx, getSpendActivity, arg.UserID, arg.SpentSince, arg.CountedSince)
+	var i GetSpendActivityRow
+	err := row.Scan(&i.Spent, &i.SpendCount)
+	return i, err
+}
+
+const getUserSpendLimit = `-- name: GetUserSpendLimit :one
+SELECT user_id, daily_limit, per_transaction_limit, spending_blocked_until, created_at, updated_at
+FROM user_spend_limits
+WHERE user_id = $1
+`
+
+func (q *Queries) GetUserSpendLimit(ctx context.Context, userID pgtype.UUID) (UserSpendLimit, error) {
+	row := q.db.QueryRow(ctx, getUserSpendLimit, userID)
+	var i UserSpendLimit
+	err := row.Scan(
+		&i.UserID,
+		&i.DailyLimit,
+		&i.PerTransactionLimit,
+		&i.SpendingBlockedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const unblockUserSpending = `-- name: UnblockUserSpending :execrows
+UPDATE user_spend_limits
+SET spending_blocked_until = NULL
+WHERE user_id = $1
+  AND spending_blocked_until IS NOT NULL
+`
+
+func (q *Queries) UnblockUserSpending(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, unblockUserSpending, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const upsertUserSpendLimit = `-- name: UpsertUserSpendLimit :one
+INSERT INTO user_spend_limits (user_id, daily_limit, per_transaction_limit)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET daily_limit = EXCLUDED.daily_limit,
+    per_transaction_limit = EXCLUDED.per_transaction_limit
+RETURNING user_id, daily_limit, per_transaction_limit, spending_blocked_until, created_at, updated_at
+`
+
+type UpsertUserSpendLimitParams struct {
+	UserID              pgtype.UUID `db:"user_id" json:"user_id"`
+	DailyLimit          pgtype.Int4 `db:"daily_limit" json:"daily_limit"`
+	PerTransactionLimit pgtype.Int4 `db:"per_transaction_limit" json:"per_transaction_limit"`
+}
+
+// Only the limits are written; an active velocity block is left in place.
+func (q *Queries) UpsertUserSpendLimit(ctx context.Context, arg UpsertUserSpendLimitParams) (UserSpendLimit, error) {
+	row := q.db.QueryRow(ctx, upsertUserSpendLimit, arg.UserID, arg.DailyLimit, arg.PerTransactionLimit)
+	var i UserSpendLimit
+	err := row.Scan(
+		&i.UserID,
+		&i.DailyLimit,
+		&i.PerTransactionLimit,
+		&i.SpendingBlockedUntil,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,159 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres aborts a transaction with one of these codes when it conflicts
+// with a concurrent one; running it again usually succeeds. WithinTx runs at
+// read committed, where row locks taken in different orders end in a
+// deadlock; serialization failures come from stricter isolation levels.
+const (
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+// maxTxAttempts bounds how often WithinTx runs a transaction that keeps
+// conflicting with others
+const maxTxAttempts = 3
+
+// TxManager runs functions as a unit of work. Queries made through
+// QuerierFromContext with the context passed to fn take part in the
+// transaction, so repositories need not know whether they are in one.
+type TxManager interface {
+	// WithinTx runs fn in a transaction that commits if fn returns nil and
+	// rolls back otherwise. Inside another WithinTx, fn runs in a savepoint
+	// of the outer transaction instead: its failure undoes only its own
+	// work, and nothing commits until the outermost call returns. The
+	// outermost call runs fn again when the transaction fails to serialize
+	// or is picked to break a deadlock, so fn must not have effects outside
+	// the database.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// WithinReadOnlyTx runs fn in a read-only transaction that sees a single
+	// snapshot of the database. Inside another WithinTx it behaves as
+	// WithinTx does and sees the outer transaction's snapshot.
+	WithinReadOnlyTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// TxBeginner starts transactions; *pgxpool.Pool is one
+type TxBeginner interface {
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+}
+
+type txManager struct {
+	db TxBeginner
+}
+
+func NewTxManager(db TxBeginner) TxManager {
+	return &txManager{db: db}
+}
+
+type txContextKey struct{}
+
+// TxFromContext returns the transaction WithinTx put in ctx, if any
+func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+// ContextQuerier is a Querier that can join the transaction a TxManager put
+// in a context
+type ContextQuerier interface {
+	Querier
+	FromContext(ctx context.Context) Querier
+}
+
+// FromContext returns queries that run in the transaction in ctx, or q itself
+// when ctx has none
+func (q *Queries) FromContext(ctx context.Context) Querier {
+	if tx, ok := TxFromContext(ctx); ok {
+		return q.WithTx(tx)
+	}
+	return q
+}
+
+// QuerierFromContext returns the querier to use for ctx: the one joining its
+// transaction when q is a ContextQuerier, or q itself otherwise, as for mocks.
+// FromContext must return a querier of q's own type for the transaction to be
+// joined.
+func QuerierFromContext[Q Querier](ctx context.Context, q Q) Q {
+	if cq, ok := any(q).(ContextQuerier); ok {
+		if joined, ok := cq.FromContext(ctx).(Q); ok {
+			return joined
+		}
+	}
+	return q
+}
+
+func (m *txManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.within(ctx, pgx.TxOptions{}, fn)
+}
+
+func (m *txManager) WithinReadOnlyTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.within(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}, fn)
+}
+
+func (m *txManager) within(ctx context.Context, opts pgx.TxOptions, fn func(ctx context.Context) error) error {
+	if outer, ok := TxFromContext(ctx); ok {
+		// Begin on a transaction creates a savepoint
+		savepoint, err := outer.Begin(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create savepoint: %w", err)
+		}
+		return run(ctx, savepoint, fn)
+	}
+
+	for attempt := 1; ; attempt++ {
+		tx, err := m.db.BeginTx(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to start transaction: %w", err)
+		}
+
+		err = run(ctx, tx, fn)
+		if attempt == maxTxAttempts || !(IsSerializationFailure(err) || IsDeadlock(err)) {
+			return err
+		}
+
+		// Back off a little so the conflicting transaction can finish
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(time.Duration(attempt) * 10 * time.Millisecond):
+		}
+	}
+}
+
+// run calls fn with tx in its context and commits or rolls back by its result
+func run(ctx context.Context, tx pgx.Tx, fn func(ctx context.Context) error) error {
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		// A failed rollback leaves the transaction to be discarded with its
+		// connection, so fn's error is the one worth reporting
+		_ = tx.Rollback(ctx)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", TranslateError(err, nil))
+	}
+	return nil
+}
+
+// IsSerializationFailure reports whether err aborted a transaction because of
+// a concurrent one
+func IsSerializationFailure(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgSerializationFailure
+}
+
+// IsDeadlock reports whether err aborted a transaction to break a deadlock
+// with a concurrent one
+func IsDeadlock(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgDeadlockDetected
+}
@@ -0,0 +1,229 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTx records how a transaction ended. Methods that are not overridden
+// panic through the nil embedded interface.
+type fakeTx struct {
+	pgx.Tx
+	commitErr  error
+	committed  bool
+	rolledBack bool
+	savepoints []*fakeTx
+}
+
+func (tx *fakeTx) Begin(ctx context.Context) (pgx.Tx, error) {
+	savepoint := &fakeTx{}
+	tx.savepoints = append(tx.savepoints, savepoint)
+	return savepoint, nil
+}
+
+func (tx *fakeTx) Commit(ctx context.Context) error {
+	if tx.commitErr != nil {
+		return tx.commitErr
+	}
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(ctx context.Context) error {
+	tx.rolledBack = true
+	return nil
+}
+
+type fakeBeginner struct {
+	txs     []*fakeTx
+	options []pgx.TxOptions
+	// commitErrs are returned by the commits of the first transactions
+	commitErrs []error
+}
+
+func (b *fakeBeginner) BeginTx(ctx context.Context, opts pgx.TxOptions) (pgx.Tx, error) {
+	tx := &fakeTx{}
+	if len(b.txs) < len(b.commitErrs) {
+		tx.commitErr = b.commitErrs[len(b.txs)]
+	}
+	b.txs = append(b.txs, tx)
+	b.options = append(b.options, opts)
+	return tx, nil
+}
+
+var errSerialization = &pgconn.PgError{Code: pgSerializationFailure}
+
+func TestWithinTx_CommitsOnSuccess(t *testing.T) {
+	db := &fakeBeginner{}
+	m := NewTxManager(db)
+
+	err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+		tx, ok := TxFromContext(ctx)
+		assert.True(t, ok)
+		assert.Same(t, db.txs[0], tx)
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, db.txs, 1)
+	assert.True(t, db.txs[0].committed)
+	assert.False(t, db.txs[0].rolledBack)
+}
+
+func TestWithinTx_RollsBackOnError(t *testing.T) {
+	db := &fakeBeginner{}
+	m := NewTxManager(db)
+	fnErr := errors.New("boom")
+
+	err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+		return fnErr
+	})
+
+	assert.ErrorIs(t, err, fnErr)
+	require.Len(t, db.txs, 1)
+	assert.False(t, db.txs[0].committed)
+	assert.True(t, db.txs[0].rolledBack)
+}
+
+func TestWithinTx_RetriesSerializationFailures(t *testing.T) {
+	db := &fakeBeginner{}
+	m := NewTxManager(db)
+
+	calls := 0
+	err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+		calls++
+		if calls < maxTxAttempts {
+			return fmt.Errorf("failed to update coins: %w", errSerialization)
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, maxTxAttempts, calls)
+	require.Len(t, db.txs, maxTxAttempts)
+	assert.True(t, db.txs[0].rolledBack)
+	assert.True(t, db.txs[maxTxAttempts-1].committed)
+}
+
+func TestWithinTx_RetriesDeadlocks(t *testing.T) {
+	db := &fakeBeginner{}
+	m := NewTxManager(db)
+
+	calls := 0
+	err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+		calls++
+		if calls == 1 {
+			return fmt.Errorf("failed to lock user: %w", &pgconn.PgError{Code: pgDeadlockDetected})
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.True(t, db.txs[0].rolledBack)
+	assert.True(t, db.txs[1].committed)
+}
+
+func TestWithinTx_RetriesFailedCommit(t *testing.T) {
+	db := &fakeBeginner{commitErrs: []error{errSerialization}}
+	m := NewTxManager(db)
+
+	calls := 0
+	err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+		calls++
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.True(t, db.txs[1].committed)
+}
+
+func TestWithinTx_GivesUpAfterMaxAttempts(t *testing.T) {
+	db := &fakeBeginner{}
+	m := NewTxManager(db)
+
+	calls := 0
+	err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+		calls++
+		return errSerialization
+	})
+
+	assert.True(t, IsSerializationFailure(err))
+	assert.Equal(t, maxTxAttempts, calls)
+}
+
+func TestWithinTx_DoesNotRetryOtherErrors(t *testing.T) {
+	db := &fakeBeginner{}
+	m := NewTxManager(db)
+
+	calls := 0
+	err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+		calls++
+		return &pgconn.PgError{Code: pgUniqueViolation}
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestWithinTx_NestedCallUsesSavepoint(t *testing.T) {
+	db := &fakeBeginner{}
+	m := NewTxManager(db)
+	innerErr := errors.New("inner failed")
+
+	err := m.WithinTx(context.Background(), func(ctx context.Context) error {
+		outer := db.txs[0]
+
+		// A failing inner call rolls back only its savepoint
+		err := m.WithinTx(ctx, func(ctx context.Context) error {
+			tx, _ := TxFromContext(ctx)
+			assert.Same(t, outer.savepoints[0], tx)
+			return innerErr
+		})
+		assert.ErrorIs(t, err, innerErr)
+		assert.True(t, outer.savepoints[0].rolledBack)
+
+		require.NoError(t, m.WithinReadOnlyTx(ctx, func(ctx context.Context) error {
+			return nil
+		}))
+		assert.True(t, outer.savepoints[1].committed)
+		assert.False(t, outer.committed)
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, db.txs, 1, "nested calls must not start transactions")
+	assert.True(t, db.txs[0].committed)
+}
+
+func TestWithinReadOnlyTx_Options(t *testing.T) {
+	db := &fakeBeginner{}
+	m := NewTxManager(db)
+
+	require.NoError(t, m.WithinReadOnlyTx(context.Background(), func(ctx context.Context) error {
+		return nil
+	}))
+
+	assert.Equal(t, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}, db.options[0])
+}
+
+func TestQueriesFromContext(t *testing.T) {
+	q := New(nil)
+	assert.Same(t, q, q.FromContext(context.Background()))
+
+	tx := &fakeTx{}
+	ctx := context.WithValue(context.Background(), txContextKey{}, pgx.Tx(tx))
+	assert.Equal(t, tx, q.FromContext(ctx).(*Queries).db)
+	assert.Equal(t, tx, QuerierFromContext(ctx, Querier(q)).(*Queries).db)
+
+	var other Querier = struct{ Querier }{}
+	assert.Equal(t, other, QuerierFromContext(ctx, other))
+}
@@ -38,27 +38,44 @@ func (q *Queries) CheckEmailExistsForOtherUser(ctx context.Context, arg CheckEma
 	return exists, err
 }
 
+const countUsersByNormalizedEmail = `-- name: CountUsersByNormalizedEmail :one
+SELECT COUNT(*)::integer AS user_count
+FROM users
+WHERE normalized_email = $1
+`
+
+func (q *Queries) CountUsersByNormalizedEmail(ctx context.Context, normalizedEmail string) (int32, error) {
+	row := q.db.QueryRow(ctx, countUsersByNormalizedEmail, normalizedEmail)
+	var user_count int32
+	err := row.Scan(&user_count)
+	return user_count, err
+}
+
 const createUser = `-- name: CreateUser :one
 
-INSERT INTO users (name, email, password_hash, coins)
-VALUES ($1, $2, $3, $4)
-RETURNING id, name, email, coins, created_at, updated_at
+INSERT INTO users (name, email, password_hash, coins, referral_code, normalized_email)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, name, email, coins, created_at, updated_at, held_coins, referral_code
 `
 
 type CreateUserParams struct {
-	Name         string      `db:"name" json:"name"`
-	Email        string      `db:"email" json:"email"`
-	PasswordHash string      `db:"password_hash" json:"password_hash"`
-	Coins        pgtype.Int4 `db:"coins" json:"coins"`
+	Name            string      `db:"name" json:"name"`
+	Email           string      `db:"email" json:"email"`
+	PasswordHash    string      `db:"password_hash" json:"password_hash"`
+	Coins           pgtype.Int4 `db:"coins" json:"coins"`
+	ReferralCode    string      `db:"referral_code" json:"referral_code"`
+	NormalizedEmail string      `db:"normalized_email" json:"normalized_email"`
 }
 
 type CreateUserRow struct {
-	ID        pgtype.UUID        `db:"id" json:"id"`
-	Name      string             `db:"name" json:"name"`
-	Email     string             `db:"email" json:"email"`
-	Coins     pgtype.Int4        `db:"coins" json:"coins"`
-	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	ID           pgtype.UUID        `db:"id" json:"id"`
+	Name         string             `db:"name" json:"name"`
+	Email        string             `db:"email" json:"email"`
+	Coins        pgtype.Int4        `db:"coins" json:"coins"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	HeldCoins    int32              `db:"held_coins" json:"held_coins"`
+	ReferralCode string             `db:"referral_code" json:"referral_code"`
 }
 
 // queries/user.sql
@@ -68,6 +85,8 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateU
 		arg.Email,
 		arg.PasswordHash,
 		arg.Coins,
+		arg.ReferralCode,
+		arg.NormalizedEmail,
 	)
 	var i CreateUserRow
 	err := row.Scan(
@@ -77,6 +96,8 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateU
 		&i.Coins,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HeldCoins,
+		&i.ReferralCode,
 	)
 	return i, err
 }
@@ -92,7 +113,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, name, email, password_hash, coins, created_at, updated_at
+SELECT id, name, email, password_hash, coins, created_at, updated_at, is_admin, held_coins, referral_code, normalized_email
 FROM users
 WHERE email = $1
 `
@@ -108,12 +129,16 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Coins,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsAdmin,
+		&i.HeldCoins,
+		&i.ReferralCode,
+		&i.NormalizedEmail,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, name, email, password_hash, coins, created_at, updated_at
+SELECT id, name, email, password_hash, coins, created_at, updated_at, is_admin, held_coins, referral_code, normalized_email
 FROM users
 WHERE id = $1
 `
@@ -129,17 +154,83 @@ func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
 		&i.Coins,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsAdmin,
+		&i.HeldCoins,
+		&i.ReferralCode,
+		&i.NormalizedEmail,
+	)
+	return i, err
+}
+
+const getUserByIDForUpdate = `-- name: GetUserByIDForUpdate :one
+SELECT id, name, email, password_hash, coins, created_at, updated_at, is_admin, held_coins, referral_code, normalized_email
+FROM users
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetUserByIDForUpdate(ctx context.Context, id pgtype.UUID) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByIDForUpdate, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.PasswordHash,
+		&i.Coins,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsAdmin,
+		&i.HeldCoins,
+		&i.ReferralCode,
+		&i.NormalizedEmail,
 	)
 	return i, err
 }
 
+const getUserByReferralCode = `-- name: GetUserByReferralCode :one
+SELECT id, name, email, password_hash, coins, created_at, updated_at, is_admin, held_coins, referral_code, normalized_email
+FROM users
+WHERE referral_code = $1
+`
+
+func (q *Queries) GetUserByReferralCode(ctx context.Context, referralCode string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByReferralCode, referralCode)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.PasswordHash,
+		&i.Coins,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsAdmin,
+		&i.HeldCoins,
+		&i.ReferralCode,
+		&i.NormalizedEmail,
+	)
+	return i, err
+}
+
+const lockNormalizedEmail = `-- name: LockNormalizedEmail :exec
+SELECT pg_advisory_xact_lock(hashtext($1::text))
+`
+
+// Held until the transaction ends, so sign-ups of one inbox are serialized
+// and each sees the accounts created before it
+func (q *Queries) LockNormalizedEmail(ctx context.Context, normalizedEmail string) error {
+	_, err := q.db.Exec(ctx, lockNormalizedEmail, normalizedEmail)
+	return err
+}
+
 const updateUserCoins = `-- name: UpdateUserCoins :one
 UPDATE users
 SET 
     coins = coins + $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, email, coins, created_at, updated_at
+RETURNING id, name, email, coins, created_at, updated_at, held_coins, referral_code
 `
 
 type UpdateUserCoinsParams struct {
@@ -148,12 +239,14 @@ type UpdateUserCoinsParams struct {
 }
 
 type UpdateUserCoinsRow struct {
-	ID        pgtype.UUID        `db:"id" json:"id"`
-	Name      string             `db:"name" json:"name"`
-	Email     string             `db:"email" json:"email"`
-	Coins     pgtype.Int4        `db:"coins" json:"coins"`
-	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	ID           pgtype.UUID        `db:"id" json:"id"`
+	Name         string             `db:"name" json:"name"`
+	Email        string             `db:"email" json:"email"`
+	Coins        pgtype.Int4        `db:"coins" json:"coins"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	HeldCoins    int32              `db:"held_coins" json:"held_coins"`
+	ReferralCode string             `db:"referral_code" json:"referral_code"`
 }
 
 func (q *Queries) UpdateUserCoins(ctx context.Context, arg UpdateUserCoinsParams) (UpdateUserCoinsRow, error) {
@@ -166,6 +259,8 @@ func (q *Queries) UpdateUserCoins(ctx context.Context, arg UpdateUserCoinsParams
 		&i.Coins,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HeldCoins,
+		&i.ReferralCode,
 	)
 	return i, err
 }
@@ -174,27 +269,31 @@ const updateUserEmail = `-- name: UpdateUserEmail :one
 UPDATE users
 SET 
     email = $2,
+    normalized_email = $3,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, email, coins, created_at, updated_at
+RETURNING id, name, email, coins, created_at, updated_at, held_coins, referral_code
 `
 
 type UpdateUserEmailParams struct {
-	ID    pgtype.UUID `db:"id" json:"id"`
-	Email string      `db:"email" json:"email"`
+	ID              pgtype.UUID `db:"id" json:"id"`
+	Email           string      `db:"email" json:"email"`
+	NormalizedEmail string      `db:"normalized_email" json:"normalized_email"`
 }
 
 type UpdateUserEmailRow struct {
-	ID        pgtype.UUID        `db:"id" json:"id"`
-	Name      string             `db:"name" json:"name"`
-	Email     string             `db:"email" json:"email"`
-	Coins     pgtype.Int4        `db:"coins" json:"coins"`
-	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	ID           pgtype.UUID        `db:"id" json:"id"`
+	Name         string             `db:"name" json:"name"`
+	Email        string             `db:"email" json:"email"`
+	Coins        pgtype.Int4        `db:"coins" json:"coins"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	HeldCoins    int32              `db:"held_coins" json:"held_coins"`
+	ReferralCode string             `db:"referral_code" json:"referral_code"`
 }
 
 func (q *Queries) UpdateUserEmail(ctx context.Context, arg UpdateUserEmailParams) (UpdateUserEmailRow, error) {
-	row := q.db.QueryRow(ctx, updateUserEmail, arg.ID, arg.Email)
+	row := q.db.QueryRow(ctx, updateUserEmail, arg.ID, arg.Email, arg.NormalizedEmail)
 	var i UpdateUserEmailRow
 	err := row.Scan(
 		&i.ID,
@@ -203,6 +302,49 @@ func (q *Queries) UpdateUserEmail(ctx context.Context, arg UpdateUserEmailParams
 		&i.Coins,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HeldCoins,
+		&i.ReferralCode,
+	)
+	return i, err
+}
+
+const updateUserHeldCoins = `-- name: UpdateUserHeldCoins :one
+UPDATE users
+SET 
+    held_coins = held_coins + $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, name, email, coins, created_at, updated_at, held_coins, referral_code
+`
+
+type UpdateUserHeldCoinsParams struct {
+	ID        pgtype.UUID `db:"id" json:"id"`
+	HeldCoins int32       `db:"held_coins" json:"held_coins"`
+}
+
+type UpdateUserHeldCoinsRow struct {
+	ID           pgtype.UUID        `db:"id" json:"id"`
+	Name         string             `db:"name" json:"name"`
+	Email        string             `db:"email" json:"email"`
+	Coins        pgtype.Int4        `db:"coins" json:"coins"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	HeldCoins    int32              `db:"held_coins" json:"held_coins"`
+	ReferralCode string             `db:"referral_code" json:"referral_code"`
+}
+
+func (q *Queries) UpdateUserHeldCoins(ctx context.Context, arg UpdateUserHeldCoinsParams) (UpdateUserHeldCoinsRow, error) {
+	row := q.db.QueryRow(ctx, updateUserHeldCoins, arg.ID, arg.HeldCoins)
+	var i UpdateUserHeldCoinsRow
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Email,
+		&i.Coins,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.HeldCoins,
+		&i.ReferralCode,
 	)
 	return i, err
 }
@@ -213,7 +355,7 @@ SET
     name = $2,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1
-RETURNING id, name, email, coins, created_at, updated_at
+RETURNING id, name, email, coins, created_at, updated_at, held_coins, referral_code
 `
 
 type UpdateUserNameParams struct {
@@ -222,12 +364,14 @@ type UpdateUserNameParams struct {
 }
 
 type UpdateUserNameRow struct {
-	ID        pgtype.UUID        `db:"id" json:"id"`
-	Name      string             `db:"name" json:"name"`
-	Email     string             `db:"email" json:"email"`
-	Coins     pgtype.Int4        `db:"coins" json:"coins"`
-	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	ID           pgtype.UUID        `db:"id" json:"id"`
+	Name         string             `db:"name" json:"name"`
+	Email        string             `db:"email" json:"email"`
+	Coins        pgtype.Int4        `db:"coins" json:"coins"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	HeldCoins    int32              `db:"held_coins" json:"held_coins"`
+	ReferralCode string             `db:"referral_code" json:"referral_code"`
 }
 
 func (q *Queries) UpdateUserName(ctx context.Context, arg UpdateUserNameParams) (UpdateUserNameRow, error) {
@@ -240,6 +384,8 @@ func (q *Queries) UpdateUserName(ctx context.Context, arg UpdateUserNameParams)
 		&i.Coins,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HeldCoins,
+		&i.ReferralCode,
 	)
 	return i, err
 }
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"backend/internal/domain"
+	"backend/internal/usecase"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// AdminMiddleware must run after AuthMiddleware. The admin flag is read from
+// the database on every request so that revoking it takes effect immediately.
+type AdminMiddleware struct {
+	userUseCase *usecase.UserUseCase
+}
+
+func NewAdminMiddleware(uc *usecase.UserUseCase) *AdminMiddleware {
+	return &AdminMiddleware{userUseCase: uc}
+}
+
+func (a *AdminMiddleware) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userIDStr, ok := c.Get("user_id").(string)
+		if !ok {
+			return echo.ErrUnauthorized
+		}
+
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return echo.ErrUnauthorized
+		}
+
+		// A failed lookup says nothing about the user, so it is not a 403
+		user, err := a.userUseCase.GetUserById(c.Request().Context(), userID)
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return echo.ErrForbidden
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check admin rights: %w", err)
+		}
+		if !user.IsAdmin {
+			return echo.ErrForbidden
+		}
+
+		return next(c)
+	}
+}
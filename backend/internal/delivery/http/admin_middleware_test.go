@@ -0,0 +1,81 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/internal/database"
+	"backend/internal/repository"
+	"backend/internal/usecase"
+	"backend/mocks"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// serveAdmin runs a request from userID through AdminMiddleware, with users
+// looked up in q, and returns the response
+func serveAdmin(t *testing.T, q *mocks.MockQuerier, userID uuid.UUID) *httptest.ResponseRecorder {
+	t.Helper()
+
+	e := echo.New()
+	e.HTTPErrorHandler = ProblemErrorHandler
+	uc := usecase.NewUserUseCase(repository.NewUserRepository(q, 0), nil, nil)
+	admin := NewAdminMiddleware(uc)
+
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/api/admin/orders", nil), rec)
+	c.Set("user_id", userID.String())
+	err := admin.Middleware(func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})(c)
+	if err != nil {
+		e.HTTPErrorHandler(err, c)
+	}
+	return rec
+}
+
+func TestAdminMiddleware_LetsAdminsThrough(t *testing.T) {
+	q := mocks.NewMockQuerier(t)
+	userID := uuid.New()
+	q.EXPECT().GetUserByID(mock.Anything, database.UUIDToPgtype(userID)).
+		Return(database.User{ID: database.UUIDToPgtype(userID), IsAdmin: true}, nil)
+
+	rec := serveAdmin(t, q, userID)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestAdminMiddleware_ForbidsOtherUsers(t *testing.T) {
+	for name, lookup := range map[string]func(*mocks.MockQuerier_GetUserByID_Call){
+		"not an admin": func(call *mocks.MockQuerier_GetUserByID_Call) { call.Return(database.User{}, nil) },
+		"unknown user": func(call *mocks.MockQuerier_GetUserByID_Call) { call.Return(database.User{}, pgx.ErrNoRows) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			q := mocks.NewMockQuerier(t)
+			userID := uuid.New()
+			lookup(q.EXPECT().GetUserByID(mock.Anything, database.UUIDToPgtype(userID)))
+
+			rec := serveAdmin(t, q, userID)
+
+			assert.Equal(t, http.StatusForbidden, rec.Code)
+		})
+	}
+}
+
+func TestAdminMiddleware_FailedLookupIsAServerError(t *testing.T) {
+	q := mocks.NewMockQuerier(t)
+	userID := uuid.New()
+	q.EXPECT().GetUserByID(mock.Anything, database.UUIDToPgtype(userID)).
+		Return(database.User{}, errors.New("connection refused"))
+
+	rec := serveAdmin(t, q, userID)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get(echo.HeaderContentType))
+}
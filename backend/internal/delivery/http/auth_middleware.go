@@ -3,8 +3,12 @@ package http
 import (
 	"strings"
 
+	"backend/internal/logging"
+
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type AuthMiddleware struct {
@@ -41,6 +45,8 @@ func (a *AuthMiddleware) Middleware(next echo.HandlerFunc) echo.HandlerFunc {
 		}
 
 		c.Set("user_id", userID)
+		trace.SpanFromContext(c.Request().Context()).SetAttributes(semconv.EnduserID(userID))
+		c.SetRequest(c.Request().WithContext(logging.WithUserID(c.Request().Context(), userID)))
 		return next(c)
 	}
 }
@@ -29,10 +29,7 @@ func (h *CartHandler) RegisterRoutes(g *echo.Group) {
 func (h *CartHandler) AddToCart(c echo.Context) error {
 	userID, err := getUserIDFromContext(c)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, entity.AddToCartResponse{
-			Success: false,
-			Message: "Invalid user ID",
-		})
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid user ID")
 	}
 
 	var request struct {
@@ -41,25 +38,16 @@ func (h *CartHandler) AddToCart(c echo.Context) error {
 	}
 
 	if err := c.Bind(&request); err != nil {
-		return c.JSON(http.StatusBadRequest, entity.AddToCartResponse{
-			Success: false,
-			Message: "Invalid request body",
-		})
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
 	}
 
 	if request.Quantity <= 0 {
-		return c.JSON(http.StatusBadRequest, entity.AddToCartResponse{
-			Success: false,
-			Message: "Quantity must be greater than 0",
-		})
+		return echo.NewHTTPError(http.StatusBadRequest, "Quantity must be greater than 0")
 	}
 
 	err = h.usecase.AddToCart(c.Request().Context(), userID, request.ProductID, request.Quantity)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, entity.AddToCartResponse{
-			Success: false,
-			Message: err.Error(),
-		})
+		return toHTTPError(err)
 	}
 
 	return c.JSON(http.StatusOK, entity.AddToCartResponse{
@@ -71,18 +59,12 @@ func (h *CartHandler) AddToCart(c echo.Context) error {
 func (h *CartHandler) GetCartItems(c echo.Context) error {
 	userID, err := getUserIDFromContext(c)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, entity.GetCartResponse{
-			Success: false,
-			Message: "Invalid user ID",
-		})
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid user ID")
 	}
 
 	cartItems, err := h.usecase.GetCartItems(c.Request().Context(), userID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, entity.GetCartResponse{
-			Success: false,
-			Message: err.Error(),
-		})
+		return toHTTPError(err)
 	}
 
 	return c.JSON(http.StatusOK, entity.GetCartResponse{
@@ -95,34 +77,22 @@ func (h *CartHandler) GetCartItems(c echo.Context) error {
 func (h *CartHandler) RemoveFromCart(c echo.Context) error {
 	userID, err := getUserIDFromContext(c)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, entity.RemoveFromCartResponse{
-			Success: false,
-			Message: "Invalid user ID",
-		})
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid user ID")
 	}
 
 	productIDStr := c.QueryParam("product_id")
 	if productIDStr == "" {
-		return c.JSON(http.StatusBadRequest, entity.RemoveFromCartResponse{
-			Success: false,
-			Message: "product_id parameter is required",
-		})
+		return echo.NewHTTPError(http.StatusBadRequest, "product_id parameter is required")
 	}
 
 	productID, err := strconv.Atoi(productIDStr)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, entity.RemoveFromCartResponse{
-			Success: false,
-			Message: "Invalid product_id format",
-		})
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid product_id format")
 	}
 
 	err = h.usecase.RemoveFromCart(c.Request().Context(), userID, productID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, entity.RemoveFromCartResponse{
-			Success: false,
-			Message: err.Error(),
-		})
+		return toHTTPError(err)
 	}
 
 	return c.JSON(http.StatusOK, entity.RemoveFromCartResponse{
@@ -134,27 +104,18 @@ func (h *CartHandler) RemoveFromCart(c echo.Context) error {
 func (h *CartHandler) ClearCart(c echo.Context) error {
 	userID, err := getUserIDFromContext(c)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, entity.ClearCartResponse{
-			Success: false,
-			Message: "Invalid user ID",
-		})
+		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid user ID")
 	}
 
 	cartItems, err := h.usecase.GetCartItems(c.Request().Context(), userID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, entity.ClearCartResponse{
-			Success: false,
-			Message: err.Error(),
-		})
+		return toHTTPError(err)
 	}
 	itemsCount := len(cartItems)
 
 	err = h.usecase.ClearCart(c.Request().Context(), userID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, entity.ClearCartResponse{
-			Success: false,
-			Message: err.Error(),
-		})
+		return toHTTPError(err)
 	}
 
 	return c.JSON(http.StatusOK, entity.ClearCartResponse{
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"backend/internal/entity"
+	"backend/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CashbackHandler struct {
+	cashbackUC usecase.CashbackUseCase
+}
+
+func NewCashbackHandler(cashbackUC usecase.CashbackUseCase) *CashbackHandler {
+	return &CashbackHandler{
+		cashbackUC: cashbackUC,
+	}
+}
+
+// RegisterAdminRoutes expects a group that is already guarded by AdminMiddleware
+func (h *CashbackHandler) RegisterAdminRoutes(g *echo.Group) {
+	g.GET("/cashback-rates", h.GetCashbackRates)
+	g.PUT("/cashback-rates/:category_id", h.SetCashbackRate)
+	g.DELETE("/cashback-rates/:category_id", h.DeleteCashbackRate)
+}
+
+func (h *CashbackHandler) GetCashbackRates(c echo.Context) error {
+	rates, err := h.cashbackUC.GetCashbackRates(c.Request().Context())
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"rates": rates,
+	})
+}
+
+func (h *CashbackHandler) SetCashbackRate(c echo.Context) error {
+	categoryID, err := strconv.ParseInt(c.Param("category_id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid category ID")
+	}
+
+	req := new(entity.SetCashbackRateRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	rate, err := h.cashbackUC.SetCashbackRate(c.Request().Context(), int32(categoryID), req.RatePercent)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Cashback rate updated successfully",
+		"rate":    rate,
+	})
+}
+
+func (h *CashbackHandler) DeleteCashbackRate(c echo.Context) error {
+	categoryID, err := strconv.ParseInt(c.Param("category_id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid category ID")
+	}
+
+	if err := h.cashbackUC.DeleteCashbackRate(c.Request().Context(), int32(categoryID)); err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Cashback rate deleted successfully",
+	})
+}
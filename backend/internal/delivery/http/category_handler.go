@@ -27,7 +27,7 @@ func (h *CategoryHandler) GetAllCategories(c echo.Context) error {
 
 	categories, err := h.categoryUseCase.GetAllCategories(ctx)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return toHTTPError(err)
 	}
 
 	return c.JSON(http.StatusOK, categories)
@@ -39,16 +39,16 @@ func (h *CategoryHandler) GetCategoryByID(c echo.Context) error {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid category id"})
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid category id")
 	}
 
 	category, err := h.categoryUseCase.GetCategoryByID(ctx, id)
 
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return toHTTPError(err)
 	}
 	if category == nil {
-		return c.JSON(http.StatusNotFound, map[string]string{"error": "category not found"})
+		return echo.NewHTTPError(http.StatusNotFound, "category not found")
 	}
 
 	return c.JSON(http.StatusOK, category)
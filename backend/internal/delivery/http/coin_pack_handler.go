@@ -0,0 +1,98 @@
+package http
+
+import (
+	"backend/internal/entity"
+	"backend/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type CoinPackHandler struct {
+	coinPackUC usecase.CoinPackUseCase
+}
+
+func NewCoinPackHandler(coinPackUC usecase.CoinPackUseCase) *CoinPackHandler {
+	return &CoinPackHandler{
+		coinPackUC: coinPackUC,
+	}
+}
+
+func (h *CoinPackHandler) RegisterRoutes(g *echo.Group) {
+	g.GET("/coins/packs", h.GetActiveCoinPacks)
+}
+
+// RegisterAdminRoutes expects a group that is already guarded by AdminMiddleware
+func (h *CoinPackHandler) RegisterAdminRoutes(g *echo.Group) {
+	g.GET("/coins/packs", h.GetAllCoinPacks)
+	g.POST("/coins/packs", h.CreateCoinPack)
+	g.PUT("/coins/packs/:id", h.UpdateCoinPack)
+}
+
+func (h *CoinPackHandler) GetActiveCoinPacks(c echo.Context) error {
+	packs, err := h.coinPackUC.GetActiveCoinPacks(c.Request().Context())
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"packs": packs,
+	})
+}
+
+func (h *CoinPackHandler) GetAllCoinPacks(c echo.Context) error {
+	packs, err := h.coinPackUC.GetAllCoinPacks(c.Request().Context())
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"packs": packs,
+	})
+}
+
+func (h *CoinPackHandler) CreateCoinPack(c echo.Context) error {
+	req := new(entity.CreateCoinPackRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	pack, err := h.coinPackUC.CreateCoinPack(c.Request().Context(), *req)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message": "Coin pack created successfully",
+		"pack":    pack,
+	})
+}
+
+func (h *CoinPackHandler) UpdateCoinPack(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid coin pack ID")
+	}
+
+	req := new(entity.UpdateCoinPackRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	pack, err := h.coinPackUC.UpdateCoinPack(c.Request().Context(), int32(id), *req)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Coin pack updated successfully",
+		"pack":    pack,
+	})
+}
@@ -0,0 +1,192 @@
+package http
+
+import (
+	"backend/internal/entity"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// statementFlushEvery is how many rows are buffered before they are pushed to the client
+const statementFlushEvery = 100
+
+var statementCSVHeader = []string{
+	"record_type", "id", "created_at", "transaction_type", "amount",
+	"balance_after", "order_id", "coin_pack_id", "description",
+}
+
+// csvStatementWriter writes a statement as CSV. The opening and closing
+// balances are rows of their own so the file stays a single table.
+type csvStatementWriter struct {
+	w        *csv.Writer
+	flusher  http.Flusher
+	location *time.Location
+	rows     int
+}
+
+func newCSVStatementWriter(w io.Writer, flusher http.Flusher, location *time.Location) *csvStatementWriter {
+	return &csvStatementWriter{
+		w:        csv.NewWriter(w),
+		flusher:  flusher,
+		location: location,
+	}
+}
+
+func (sw *csvStatementWriter) WriteOpening(statement *entity.CoinStatement) error {
+	if err := sw.w.Write(statementCSVHeader); err != nil {
+		return err
+	}
+	return sw.w.Write([]string{
+		"opening_balance", "", sw.formatTime(statement.From), "", "",
+		strconv.Itoa(statement.OpeningBalance), "", "", "",
+	})
+}
+
+func (sw *csvStatementWriter) WriteTransaction(tx *entity.CoinTransaction) error {
+	err := sw.w.Write([]string{
+		"transaction",
+		strconv.Itoa(int(tx.ID)),
+		sw.formatTime(tx.CreatedAt),
+		tx.TransactionType,
+		strconv.Itoa(tx.Amount),
+		strconv.Itoa(tx.BalanceAfter),
+		formatOptionalID(tx.OrderID),
+		formatOptionalID(tx.CoinPackID),
+		tx.Description,
+	})
+	if err != nil {
+		return err
+	}
+
+	sw.rows++
+	if sw.rows%statementFlushEvery == 0 {
+		return sw.flush()
+	}
+	return nil
+}
+
+func (sw *csvStatementWriter) WriteClosing(statement *entity.CoinStatement) error {
+	err := sw.w.Write([]string{
+		"closing_balance", "", sw.formatTime(statement.To), "", "",
+		strconv.Itoa(statement.ClosingBalance), "", "", "",
+	})
+	if err != nil {
+		return err
+	}
+	return sw.flush()
+}
+
+func (sw *csvStatementWriter) flush() error {
+	sw.w.Flush()
+	if err := sw.w.Error(); err != nil {
+		return err
+	}
+	sw.flusher.Flush()
+	return nil
+}
+
+func (sw *csvStatementWriter) formatTime(t time.Time) string {
+	return t.In(sw.location).Format(time.RFC3339)
+}
+
+type statementLine struct {
+	Type             string  `json:"type"`
+	ID               int32   `json:"id,omitempty"`
+	At               string  `json:"at,omitempty"`
+	CreatedAt        string  `json:"created_at,omitempty"`
+	TransactionType  string  `json:"transaction_type,omitempty"`
+	Amount           *int    `json:"amount,omitempty"`
+	BalanceAfter     *int    `json:"balance_after,omitempty"`
+	OrderID          *int32  `json:"order_id,omitempty"`
+	CoinPackID       *int32  `json:"coin_pack_id,omitempty"`
+	Description      *string `json:"description,omitempty"`
+	Balance          *int    `json:"balance,omitempty"`
+	TransactionCount *int    `json:"transaction_count,omitempty"`
+}
+
+// jsonlStatementWriter writes a statement as JSON lines, one object per row,
+// with the opening balance first and the closing balance last.
+type jsonlStatementWriter struct {
+	buf      *bufio.Writer
+	enc      *json.Encoder
+	flusher  http.Flusher
+	location *time.Location
+	rows     int
+}
+
+func newJSONLStatementWriter(w io.Writer, flusher http.Flusher, location *time.Location) *jsonlStatementWriter {
+	buf := bufio.NewWriter(w)
+	return &jsonlStatementWriter{
+		buf:      buf,
+		enc:      json.NewEncoder(buf),
+		flusher:  flusher,
+		location: location,
+	}
+}
+
+func (sw *jsonlStatementWriter) WriteOpening(statement *entity.CoinStatement) error {
+	return sw.enc.Encode(statementLine{
+		Type:    "opening_balance",
+		At:      sw.formatTime(statement.From),
+		Balance: &statement.OpeningBalance,
+	})
+}
+
+func (sw *jsonlStatementWriter) WriteTransaction(tx *entity.CoinTransaction) error {
+	err := sw.enc.Encode(statementLine{
+		Type:            "transaction",
+		ID:              tx.ID,
+		CreatedAt:       sw.formatTime(tx.CreatedAt),
+		TransactionType: tx.TransactionType,
+		Amount:          &tx.Amount,
+		BalanceAfter:    &tx.BalanceAfter,
+		OrderID:         tx.OrderID,
+		CoinPackID:      tx.CoinPackID,
+		Description:     &tx.Description,
+	})
+	if err != nil {
+		return err
+	}
+
+	sw.rows++
+	if sw.rows%statementFlushEvery == 0 {
+		return sw.flush()
+	}
+	return nil
+}
+
+func (sw *jsonlStatementWriter) WriteClosing(statement *entity.CoinStatement) error {
+	err := sw.enc.Encode(statementLine{
+		Type:             "closing_balance",
+		At:               sw.formatTime(statement.To),
+		Balance:          &statement.ClosingBalance,
+		TransactionCount: &statement.TransactionCount,
+	})
+	if err != nil {
+		return err
+	}
+	return sw.flush()
+}
+
+func (sw *jsonlStatementWriter) flush() error {
+	if err := sw.buf.Flush(); err != nil {
+		return err
+	}
+	sw.flusher.Flush()
+	return nil
+}
+
+func (sw *jsonlStatementWriter) formatTime(t time.Time) string {
+	return t.In(sw.location).Format(time.RFC3339)
+}
+
+func formatOptionalID(id *int32) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*id))
+}
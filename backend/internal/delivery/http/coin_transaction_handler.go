@@ -1,9 +1,14 @@
 package http
 
 import (
+	"backend/internal/entity"
 	"backend/internal/usecase"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -20,12 +25,53 @@ func NewCoinTransactionHandler(coinTransactionUC usecase.CoinTransactionUseCase)
 }
 
 type chargeCoinsRequest struct {
+	PackID int32 `json:"pack_id" validate:"required,gt=0"`
+}
+
+type getTransactionsRequest struct {
+	Page            int32  `query:"page" validate:"omitempty,gte=1"`
+	Limit           int32  `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	TransactionType string `query:"transaction_type"` // comma-separated
+	From            string `query:"from"`
+	To              string `query:"to"`
+	OrderID         string `query:"order_id"`
+	AmountSign      string `query:"amount_sign" validate:"omitempty,oneof=positive negative"`
+}
+
+type getStatementRequest struct {
+	From     string `query:"from" validate:"required"`
+	To       string `query:"to" validate:"required"`
+	Format   string `query:"format" validate:"omitempty,oneof=csv jsonl"`
+	Timezone string `query:"tz"`
+}
+
+type getBalanceRequest struct {
+	At       string `query:"at"`
+	Timezone string `query:"tz"`
+}
+
+type getBalanceSeriesRequest struct {
+	Interval string `query:"interval" validate:"omitempty,oneof=day week month"`
+	From     string `query:"from"`
+	To       string `query:"to"`
+	Timezone string `query:"tz"`
+}
+
+// defaultBalanceSeriesPoints is how many intervals a series covers when no
+// start date is given
+const defaultBalanceSeriesPoints = 30
+
+// defaultStatementTimezone is used when a statement or balance request does
+// not name one; most of our users are in Japan
+const defaultStatementTimezone = "Asia/Tokyo"
+
+type holdCoinsRequest struct {
 	Amount      int    `json:"amount" validate:"required,gt=0"`
 	Description string `json:"description" validate:"required"`
 	OrderID     *int32 `json:"order_id,omitempty"`
 }
 
-type getTransactionsRequest struct {
+type getHoldsRequest struct {
 	Page  int32 `query:"page" validate:"omitempty,gte=1"`
 	Limit int32 `query:"limit" validate:"omitempty,gte=1,lte=100"`
 }
@@ -47,7 +93,7 @@ func (h *CoinTransactionHandler) SpendUserCoins(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
 	}
 	if err := c.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	user, transaction, err := h.coinTransactionUC.SpendUserCoins(
@@ -58,7 +104,7 @@ func (h *CoinTransactionHandler) SpendUserCoins(c echo.Context) error {
 		req.OrderID,
 	)
 	if err != nil {
-		return h.handleUseCaseError(err)
+		return toHTTPError(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -79,24 +125,27 @@ func (h *CoinTransactionHandler) ChargeUserCoins(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
 	}
 	if err := c.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
-	user, transaction, err := h.coinTransactionUC.ChargeUserCoins(
+	user, transactions, err := h.coinTransactionUC.PurchaseCoinPack(
 		c.Request().Context(),
 		userID,
-		req.Amount,
-		req.Description,
-		req.OrderID,
+		req.PackID,
 	)
 	if err != nil {
-		return h.handleUseCaseError(err)
+		return toHTTPError(err)
+	}
+
+	response := make([]map[string]interface{}, len(transactions))
+	for i, tx := range transactions {
+		response[i] = tx.ToResponse()
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
-		"message":     "Coins charged successfully",
-		"user":        user.ToResponse(),
-		"transaction": transaction.ToResponse(),
+		"message":      "Coins charged successfully",
+		"user":         user.ToResponse(),
+		"transactions": response,
 	})
 }
 
@@ -111,39 +160,236 @@ func (h *CoinTransactionHandler) GetUserTransactions(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
 	}
 	if err := c.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
-	// Set defaults
-	if req.Page == 0 {
-		req.Page = 1
+	filter := entity.CoinTransactionFilter{
+		Page:       req.Page,
+		Limit:      req.Limit,
+		AmountSign: req.AmountSign,
 	}
-	if req.Limit == 0 {
-		req.Limit = 20
+
+	if req.TransactionType != "" {
+		filter.TransactionTypes = strings.Split(req.TransactionType, ",")
 	}
 
-	transactions, err := h.coinTransactionUC.GetUserTransactions(
-		c.Request().Context(),
-		userID,
-		req.Page,
-		req.Limit,
-	)
+	if req.From != "" {
+		from, _, err := parseDateParam(req.From, time.UTC)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid from date")
+		}
+		filter.From = &from
+	}
+
+	if req.To != "" {
+		to, dateOnly, err := parseDateParam(req.To, time.UTC)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid to date")
+		}
+		// A bare date includes the whole day
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.To = &to
+	}
+
+	if req.OrderID != "" {
+		orderID, err := strconv.ParseInt(req.OrderID, 10, 32)
+		if err != nil || orderID <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
+		}
+		id := int32(orderID)
+		filter.OrderID = &id
+	}
+
+	history, err := h.coinTransactionUC.GetUserTransactions(c.Request().Context(), userID, filter)
 	if err != nil {
-		return h.handleUseCaseError(err)
+		return toHTTPError(err)
 	}
 
-	response := make([]map[string]interface{}, len(transactions))
-	for i, tx := range transactions {
+	response := make([]map[string]interface{}, len(history.Transactions))
+	for i, tx := range history.Transactions {
 		response[i] = tx.ToResponse()
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"transactions": response,
-		"page":         req.Page,
-		"limit":        req.Limit,
+		"summary":      history.Summary,
+		"total_count":  history.Summary.TotalCount,
+		"page":         history.Page,
+		"limit":        history.Limit,
 	})
 }
 
+func (h *CoinTransactionHandler) GetCoinBalance(c echo.Context) error {
+	userID, err := h.parseUserID(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(getBalanceRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+
+	if req.At == "" {
+		balance, err := h.coinTransactionUC.GetCoinBalance(c.Request().Context(), userID)
+		if err != nil {
+			return toHTTPError(err)
+		}
+
+		return c.JSON(http.StatusOK, balance)
+	}
+
+	location, err := loadTimezone(req.Timezone)
+	if err != nil {
+		return err
+	}
+
+	at, dateOnly, err := parseDateParam(req.At, location)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid at date")
+	}
+	// The balance on a date is the balance at the end of that day
+	if dateOnly {
+		at = at.AddDate(0, 0, 1)
+	}
+
+	balance, err := h.coinTransactionUC.GetCoinBalanceAt(c.Request().Context(), userID, at)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, balance)
+}
+
+// GetBalanceSeries returns the closing balance of every day, week or month in
+// a range, for charts. Intervals are cut in the requested timezone.
+func (h *CoinTransactionHandler) GetBalanceSeries(c echo.Context) error {
+	userID, err := h.parseUserID(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(getBalanceSeriesRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if req.Interval == "" {
+		req.Interval = entity.BalanceIntervalDay
+	}
+
+	location, err := loadTimezone(req.Timezone)
+	if err != nil {
+		return err
+	}
+
+	to := time.Now().In(location)
+	if req.To != "" {
+		var dateOnly bool
+		to, dateOnly, err = parseDateParam(req.To, location)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid to date")
+		}
+		// A bare date includes the whole day
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+	}
+
+	from := entity.AddInterval(to, req.Interval, -defaultBalanceSeriesPoints)
+	if req.From != "" {
+		from, _, err = parseDateParam(req.From, location)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid from date")
+		}
+	}
+
+	series, err := h.coinTransactionUC.GetCoinBalanceSeries(c.Request().Context(), userID, from, to, req.Interval, location)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, series)
+}
+
+// GetStatement streams the user's statement for a period as CSV or JSON lines.
+// Dates without a time are read in the requested timezone, which is also used
+// for every timestamp in the output.
+func (h *CoinTransactionHandler) GetStatement(c echo.Context) error {
+	userID, err := h.parseUserID(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(getStatementRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	if req.Format == "" {
+		req.Format = "csv"
+	}
+	location, err := loadTimezone(req.Timezone)
+	if err != nil {
+		return err
+	}
+
+	from, _, err := parseDateParam(req.From, location)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid from date")
+	}
+
+	to, dateOnly, err := parseDateParam(req.To, location)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid to date")
+	}
+	// A bare date includes the whole day
+	if dateOnly {
+		to = to.AddDate(0, 0, 1)
+	}
+
+	res := c.Response()
+	var writer entity.CoinStatementWriter
+	switch req.Format {
+	case "jsonl":
+		res.Header().Set(echo.HeaderContentType, "application/x-ndjson")
+		writer = newJSONLStatementWriter(res, res, location)
+	default:
+		res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+		writer = newCSVStatementWriter(res, res, location)
+	}
+
+	filename := fmt.Sprintf("coin-statement-%s-%s.%s",
+		from.In(location).Format("20060102"),
+		to.In(location).Format("20060102"),
+		req.Format,
+	)
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+
+	if err := h.coinTransactionUC.ExportCoinStatement(c.Request().Context(), userID, from, to, writer); err != nil {
+		// Once rows have been sent the status can no longer change, so the
+		// client just sees a truncated statement
+		if res.Committed {
+			slog.ErrorContext(c.Request().Context(), "failed to write coin statement", "error", err)
+			return nil
+		}
+		// Nothing was sent, so the error goes out as a problem instead
+		res.Header().Del(echo.HeaderContentType)
+		res.Header().Del(echo.HeaderContentDisposition)
+		return toHTTPError(err)
+	}
+
+	return nil
+}
+
 func (h *CoinTransactionHandler) GetTransactionByID(c echo.Context) error {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 32)
@@ -153,7 +399,7 @@ func (h *CoinTransactionHandler) GetTransactionByID(c echo.Context) error {
 
 	transaction, err := h.coinTransactionUC.GetTransactionByID(c.Request().Context(), int32(id))
 	if err != nil {
-		return h.handleUseCaseError(err)
+		return toHTTPError(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -161,6 +407,106 @@ func (h *CoinTransactionHandler) GetTransactionByID(c echo.Context) error {
 	})
 }
 
+func (h *CoinTransactionHandler) HoldUserCoins(c echo.Context) error {
+	userID, err := h.parseUserID(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(holdCoinsRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	hold, err := h.coinTransactionUC.HoldUserCoins(
+		c.Request().Context(),
+		userID,
+		req.Amount,
+		req.Description,
+		req.OrderID,
+	)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message": "Coins held successfully",
+		"hold":    hold,
+	})
+}
+
+func (h *CoinTransactionHandler) GetUserHolds(c echo.Context) error {
+	userID, err := h.parseUserID(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(getHoldsRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	holds, err := h.coinTransactionUC.GetUserHolds(c.Request().Context(), userID, req.Page, req.Limit)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"holds": holds,
+	})
+}
+
+func (h *CoinTransactionHandler) CaptureHold(c echo.Context) error {
+	userID, err := h.parseUserID(c)
+	if err != nil {
+		return err
+	}
+
+	holdID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid coin hold ID")
+	}
+
+	hold, transaction, err := h.coinTransactionUC.CaptureHold(c.Request().Context(), userID, int32(holdID))
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":     "Coin hold captured successfully",
+		"hold":        hold,
+		"transaction": transaction.ToResponse(),
+	})
+}
+
+func (h *CoinTransactionHandler) ReleaseHold(c echo.Context) error {
+	userID, err := h.parseUserID(c)
+	if err != nil {
+		return err
+	}
+
+	holdID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid coin hold ID")
+	}
+
+	hold, err := h.coinTransactionUC.ReleaseHold(c.Request().Context(), userID, int32(holdID))
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Coin hold released successfully",
+		"hold":    hold,
+	})
+}
+
 func (h *CoinTransactionHandler) parseUserID(c echo.Context) (uuid.UUID, error) {
 	userIDValue := c.Get("user_id")
 	if userIDValue == nil {
@@ -180,19 +526,28 @@ func (h *CoinTransactionHandler) parseUserID(c echo.Context) (uuid.UUID, error)
 	return userID, nil
 }
 
-func (h *CoinTransactionHandler) handleUseCaseError(err error) error {
-	switch err.Error() {
-	case "user not found":
-		return echo.NewHTTPError(http.StatusNotFound, err.Error())
-	case "insufficient coins":
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
-	case "transaction not found":
-		return echo.NewHTTPError(http.StatusNotFound, err.Error())
-	case "amount must be positive":
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
-	case "description is required":
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
-	default:
-		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
+// loadTimezone resolves a tz query parameter, defaulting to defaultStatementTimezone
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		name = defaultStatementTimezone
+	}
+
+	// "Local" is the server's zone, which the database knows nothing about
+	location, err := time.LoadLocation(name)
+	if err != nil || name == "Local" {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid timezone")
+	}
+
+	return location, nil
+}
+
+// parseDateParam accepts either RFC 3339 timestamps or YYYY-MM-DD dates,
+// which are read as midnight in loc. Either way the result is in loc.
+func parseDateParam(value string, loc *time.Location) (time.Time, bool, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		return t, true, nil
 	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	return t.In(loc), false, err
 }
@@ -0,0 +1,102 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"backend/internal/domain"
+	"backend/internal/entity"
+	"backend/internal/usecase"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seriesUseCase records what GetCoinBalanceSeries is asked for; the rest of
+// the use case is not needed by these tests
+type seriesUseCase struct {
+	usecase.CoinTransactionUseCase
+	from, to time.Time
+	location *time.Location
+}
+
+func (u *seriesUseCase) GetCoinBalanceSeries(ctx context.Context, userID uuid.UUID, from, to time.Time, interval string, location *time.Location) (*entity.CoinBalanceSeries, error) {
+	u.from, u.to, u.location = from, to, location
+	return &entity.CoinBalanceSeries{Interval: interval, From: from, To: to}, nil
+}
+
+func getBalanceSeries(t *testing.T, uc usecase.CoinTransactionUseCase, query url.Values) error {
+	t.Helper()
+
+	e := echo.New()
+	validator, err := NewValidator()
+	require.NoError(t, err)
+	e.Validator = validator
+
+	req := httptest.NewRequest(http.MethodGet, "/coins/balance/series?"+query.Encode(), nil)
+	c := e.NewContext(req, httptest.NewRecorder())
+	c.Set("user_id", uuid.NewString())
+	return NewCoinTransactionHandler(uc).GetBalanceSeries(c)
+}
+
+func TestGetBalanceSeries_OffsetTimestampsUseRequestedTimezone(t *testing.T) {
+	uc := &seriesUseCase{}
+
+	err := getBalanceSeries(t, uc, url.Values{
+		"from": {"2026-03-01T20:00:00-05:00"},
+		"to":   {"2026-03-05T00:00:00Z"},
+		"tz":   {"Asia/Tokyo"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", uc.location.String())
+	assert.Equal(t, "Asia/Tokyo", uc.from.Location().String())
+	assert.Equal(t, "Asia/Tokyo", uc.to.Location().String())
+	assert.True(t, uc.from.Equal(time.Date(2026, 3, 2, 1, 0, 0, 0, time.UTC)), uc.from)
+	assert.True(t, uc.to.Equal(time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)), uc.to)
+}
+
+func TestGetBalanceSeries_RejectsLocalTimezone(t *testing.T) {
+	err := getBalanceSeries(t, &seriesUseCase{}, url.Values{"tz": {"Local"}})
+
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+// statementUseCase fails every export with err before writing anything
+type statementUseCase struct {
+	usecase.CoinTransactionUseCase
+	err error
+}
+
+func (u *statementUseCase) ExportCoinStatement(ctx context.Context, userID uuid.UUID, from, to time.Time, w entity.CoinStatementWriter) error {
+	return u.err
+}
+
+func TestGetStatement_ErrorIsNotSentAsAttachment(t *testing.T) {
+	e := echo.New()
+	validator, err := NewValidator()
+	require.NoError(t, err)
+	e.Validator = validator
+	e.HTTPErrorHandler = ProblemErrorHandler
+
+	req := httptest.NewRequest(http.MethodGet, "/coins/statement?from=2026-03-05&to=2026-03-01", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_id", uuid.NewString())
+
+	err = NewCoinTransactionHandler(&statementUseCase{err: domain.ErrInvalidDateRange}).GetStatement(c)
+	require.Error(t, err)
+	e.HTTPErrorHandler(err, c)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get(echo.HeaderContentType))
+	assert.Empty(t, rec.Header().Get(echo.HeaderContentDisposition))
+}
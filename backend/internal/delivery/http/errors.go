@@ -0,0 +1,44 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"backend/internal/domain"
+
+	"github.com/labstack/echo/v4"
+)
+
+var kindStatus = map[domain.Kind]int{
+	domain.KindInvalid:      http.StatusBadRequest,
+	domain.KindUnauthorized: http.StatusUnauthorized,
+	domain.KindForbidden:    http.StatusForbidden,
+	domain.KindNotFound:     http.StatusNotFound,
+	domain.KindConflict:     http.StatusConflict,
+	domain.KindRateLimited:  http.StatusTooManyRequests,
+}
+
+// errorStatus returns the HTTP status and client message for an error from a
+// use case. Domain errors keep their own message; anything else is reported
+// as an internal server error so database details are not exposed.
+func errorStatus(err error) (int, string) {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		if status, ok := kindStatus[domainErr.Kind()]; ok {
+			return status, domainErr.Error()
+		}
+	}
+
+	return http.StatusInternalServerError, "Internal server error"
+}
+
+// toHTTPError converts an error from a use case into the HTTP error returned
+// by a handler. Internal errors keep err so RequestLogger can log it.
+func toHTTPError(err error) *echo.HTTPError {
+	status, message := errorStatus(err)
+	httpErr := echo.NewHTTPError(status, message)
+	if status == http.StatusInternalServerError {
+		httpErr.SetInternal(err)
+	}
+	return httpErr
+}
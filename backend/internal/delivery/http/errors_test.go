@@ -0,0 +1,106 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"backend/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantMessage string
+	}{
+		{"invalid", domain.ErrAmountNotPositive, http.StatusBadRequest, "amount must be positive"},
+		{"unauthorized", domain.ErrInvalidCredentials, http.StatusUnauthorized, "invalid credentials"},
+		{"forbidden", domain.ErrSpendingBlocked, http.StatusForbidden, "spending is temporarily blocked"},
+		{"not found", domain.ErrUserNotFound, http.StatusNotFound, "user not found"},
+		{"conflict", domain.ErrEmailAlreadyExists, http.StatusConflict, "email already exists"},
+		{"rate limited", domain.ErrTooManyRedemptionAttempts, http.StatusTooManyRequests, "too many failed redemption attempts"},
+		{
+			"detail keeps the kind",
+			domain.Errorf(domain.ErrInsufficientCoins, "have %d, need %d", 100, 200),
+			http.StatusBadRequest,
+			"insufficient coins: have 100, need 200",
+		},
+		{
+			"wrapped domain error",
+			fmt.Errorf("failed to redeem gift code: %w", domain.ErrGiftCodeAlreadyRedeemed),
+			http.StatusConflict,
+			"gift code already redeemed",
+		},
+		{"plain error", errors.New("connection refused"), http.StatusInternalServerError, "Internal server error"},
+		{"database error", fmt.Errorf("failed to get user: %w", pgx.ErrNoRows), http.StatusInternalServerError, "Internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, message := errorStatus(tt.err)
+
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantMessage, message)
+		})
+	}
+}
+
+func TestErrorStatus_Sentinels(t *testing.T) {
+	tests := []struct {
+		err        *domain.Error
+		wantStatus int
+	}{
+		{domain.ErrInsufficientCoins, http.StatusBadRequest},
+		{domain.ErrTransactionNotFound, http.StatusNotFound},
+		{domain.ErrCoinPackNotFound, http.StatusNotFound},
+		{domain.ErrCoinPackNotAvailable, http.StatusBadRequest},
+		{domain.ErrDailySpendLimitExceeded, http.StatusBadRequest},
+		{domain.ErrCoinHoldNotFound, http.StatusNotFound},
+		{domain.ErrCoinHoldNotActive, http.StatusConflict},
+		{domain.ErrCoinHoldExpired, http.StatusConflict},
+		{domain.ErrOrderNotFound, http.StatusNotFound},
+		{domain.ErrInvalidOrderStatus, http.StatusBadRequest},
+		{domain.ErrInvalidOrderStatusTransition, http.StatusConflict},
+		{domain.ErrOrderStatusChanged, http.StatusConflict},
+		{domain.ErrCashbackRateNotFound, http.StatusNotFound},
+		{domain.ErrGiftCodeBatchNotFound, http.StatusNotFound},
+		{domain.ErrInvalidGiftCode, http.StatusBadRequest},
+		{domain.ErrGiftCodeFullyRedeemed, http.StatusConflict},
+		{domain.ErrUserAlreadyExists, http.StatusConflict},
+		{domain.ErrCurrentPasswordIncorrect, http.StatusBadRequest},
+		{domain.ErrInvalidReferralCode, http.StatusBadRequest},
+		{domain.ErrProductNotFound, http.StatusNotFound},
+		{domain.ErrCategoryNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			status, message := errorStatus(tt.err)
+
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.err.Error(), message)
+		})
+	}
+}
+
+func TestToHTTPError(t *testing.T) {
+	httpErr := toHTTPError(fmt.Errorf("failed to get order: %w", domain.ErrOrderNotFound))
+
+	assert.Equal(t, http.StatusNotFound, httpErr.Code)
+	assert.Equal(t, "order not found", httpErr.Message)
+}
+
+func TestErrorf_MatchesBase(t *testing.T) {
+	err := domain.Errorf(domain.ErrInsufficientCoins, "have %d, need %d", 1, 2)
+
+	assert.ErrorIs(t, err, domain.ErrInsufficientCoins)
+	assert.NotErrorIs(t, err, domain.ErrAmountNotPositive)
+	assert.Equal(t, domain.KindInvalid, domain.KindOf(err))
+	assert.Equal(t, domain.KindInternal, domain.KindOf(errors.New("boom")))
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"backend/internal/entity"
+	"backend/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type GiftCodeHandler struct {
+	giftCodeUC usecase.GiftCodeUseCase
+}
+
+func NewGiftCodeHandler(giftCodeUC usecase.GiftCodeUseCase) *GiftCodeHandler {
+	return &GiftCodeHandler{
+		giftCodeUC: giftCodeUC,
+	}
+}
+
+// RegisterRoutes expects a group that is already guarded by AuthMiddleware
+func (h *GiftCodeHandler) RegisterRoutes(g *echo.Group) {
+	g.POST("/coins/redeem", h.RedeemGiftCode)
+}
+
+// RegisterAdminRoutes expects a group that is already guarded by AdminMiddleware
+func (h *GiftCodeHandler) RegisterAdminRoutes(g *echo.Group) {
+	g.POST("/gift-codes/batches", h.CreateGiftCodeBatch)
+	g.GET("/gift-codes/batches/:id", h.GetGiftCodeBatch)
+}
+
+func (h *GiftCodeHandler) RedeemGiftCode(c echo.Context) error {
+	userID, err := h.parseUserID(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(entity.RedeemGiftCodeRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	user, transaction, err := h.giftCodeUC.RedeemGiftCode(c.Request().Context(), userID, req.Code)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":     "Gift code redeemed successfully",
+		"user":        user.ToResponse(),
+		"transaction": transaction.ToResponse(),
+	})
+}
+
+func (h *GiftCodeHandler) CreateGiftCodeBatch(c echo.Context) error {
+	adminID, err := h.parseUserID(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(entity.CreateGiftCodeBatchRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	batch, codes, err := h.giftCodeUC.CreateGiftCodeBatch(c.Request().Context(), adminID, *req)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	// The plain codes cannot be recovered later, so this is the only chance to save them
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message": "Gift codes created successfully",
+		"batch":   batch,
+		"codes":   codes,
+	})
+}
+
+func (h *GiftCodeHandler) GetGiftCodeBatch(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid gift code batch ID")
+	}
+
+	batch, err := h.giftCodeUC.GetGiftCodeBatch(c.Request().Context(), int32(id))
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"batch": batch,
+	})
+}
+
+func (h *GiftCodeHandler) parseUserID(c echo.Context) (uuid.UUID, error) {
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return uuid.Nil, echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID format")
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,42 @@
+package http
+
+import (
+	"net/http"
+
+	"backend/internal/health"
+
+	"github.com/labstack/echo/v4"
+)
+
+type HealthHandler struct {
+	readiness *health.Readiness
+}
+
+func NewHealthHandler(readiness *health.Readiness) *HealthHandler {
+	return &HealthHandler{
+		readiness: readiness,
+	}
+}
+
+// RegisterRoutes registers the probes at the root, outside /api, so they
+// bypass the API's middleware groups
+func (h *HealthHandler) RegisterRoutes(e *echo.Echo) {
+	e.GET("/healthz", h.Liveness)
+	e.GET("/readyz", h.Readiness)
+}
+
+// Liveness only shows the process is serving; it checks no dependencies so a
+// database outage does not get every instance restarted
+func (h *HealthHandler) Liveness(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]string{"status": health.StatusOK})
+}
+
+func (h *HealthHandler) Readiness(c echo.Context) error {
+	report := h.readiness.Check(c.Request().Context())
+
+	status := http.StatusOK
+	if report.Status != health.StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	return c.JSON(status, report)
+}
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"backend/internal/health"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveHealth(t *testing.T, readiness *health.Readiness, path string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	e := echo.New()
+	NewHealthHandler(readiness).RegisterRoutes(e)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return rec, body
+}
+
+func TestHealthHandler_Liveness(t *testing.T) {
+	readiness := health.NewReadiness(nil, time.Second)
+	readiness.Drain()
+
+	rec, body := serveHealth(t, readiness, "/healthz")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ok", body["status"])
+}
+
+func TestHealthHandler_Readiness(t *testing.T) {
+	readiness := health.NewReadiness(map[string]health.Check{
+		"database": health.PingCheck(func(context.Context) error { return nil }),
+	}, time.Second)
+
+	rec, body := serveHealth(t, readiness, "/readyz")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "ok", body["status"])
+	assert.Contains(t, body["checks"], "database")
+
+	readiness.Drain()
+	rec, body = serveHealth(t, readiness, "/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "fail", body["status"])
+	assert.Contains(t, body["checks"], "shutdown")
+}
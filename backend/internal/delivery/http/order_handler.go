@@ -0,0 +1,67 @@
+package http
+
+import (
+	"backend/internal/entity"
+	"backend/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type OrderHandler struct {
+	orderUC usecase.OrderUseCase
+}
+
+func NewOrderHandler(orderUC usecase.OrderUseCase) *OrderHandler {
+	return &OrderHandler{
+		orderUC: orderUC,
+	}
+}
+
+// RegisterAdminRoutes expects a group that is already guarded by AdminMiddleware
+func (h *OrderHandler) RegisterAdminRoutes(g *echo.Group) {
+	g.GET("/orders/:id", h.GetOrderByID)
+	g.PATCH("/orders/:id/status", h.UpdateOrderStatus)
+}
+
+func (h *OrderHandler) GetOrderByID(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
+	}
+
+	order, err := h.orderUC.GetOrderByID(c.Request().Context(), int32(id))
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"order": order,
+	})
+}
+
+func (h *OrderHandler) UpdateOrderStatus(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid order ID")
+	}
+
+	req := new(entity.UpdateOrderStatusRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	order, err := h.orderUC.UpdateOrderStatus(c.Request().Context(), int32(id), req.Status)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Order status updated successfully",
+		"order":   order,
+	})
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+)
+
+const problemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details body. Every error response from the
+// API has this shape.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes one field that failed validation. Rule is the
+// validation tag that failed, such as "required" or "min", and Param its
+// argument, so clients can build their own messages.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// ProblemErrorHandler is the echo.HTTPErrorHandler for the API. It renders
+// errors returned by handlers and middleware as application/problem+json:
+// validation errors list the failing fields, echo.HTTPErrors keep their code
+// and message, and any other error goes through the use case error mapping.
+func ProblemErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	problem := newProblem(c, err)
+	problem.Instance = c.Request().URL.Path
+	problem.RequestID = requestID(c)
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(problem.Status)
+	} else {
+		err = writeProblem(c, problem)
+	}
+	if err != nil {
+		slog.ErrorContext(c.Request().Context(), "failed to write error response", "error", err)
+	}
+}
+
+func newProblem(c echo.Context, err error) *Problem {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		problem := problemFor(http.StatusBadRequest, "request validation failed")
+		problem.Errors = fieldErrors(c, validationErrs)
+		return problem
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return problemFor(httpErr.Code, fmt.Sprint(httpErr.Message))
+	}
+
+	return problemFor(errorStatus(err))
+}
+
+// problemFor returns a problem with no type beyond its status, so the title is
+// the status text as RFC 7807 asks for "about:blank"
+func problemFor(status int, detail string) *Problem {
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+func writeProblem(c echo.Context, problem *Problem) error {
+	body, err := json.Marshal(problem)
+	if err != nil {
+		return err
+	}
+	return c.Blob(problem.Status, problemContentType, body)
+}
+
+// requestID returns the id the RequestID middleware gave the request, or the
+// one the client sent
+func requestID(c echo.Context) string {
+	if id := c.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+		return id
+	}
+	return c.Request().Header.Get(echo.HeaderXRequestID)
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/internal/domain"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveProblem(t *testing.T, err error) (*httptest.ResponseRecorder, Problem) {
+	t.Helper()
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/coins/spend", nil)
+	req.Header.Set(echo.HeaderXRequestID, "req-123")
+	rec := httptest.NewRecorder()
+
+	ProblemErrorHandler(err, e.NewContext(req, rec))
+
+	var problem Problem
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	return rec, problem
+}
+
+func TestProblemErrorHandler_DomainError(t *testing.T) {
+	rec, problem := serveProblem(t, domain.ErrUserNotFound)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, problemContentType, rec.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "about:blank", problem.Type)
+	assert.Equal(t, "Not Found", problem.Title)
+	assert.Equal(t, http.StatusNotFound, problem.Status)
+	assert.Equal(t, "user not found", problem.Detail)
+	assert.Equal(t, "/api/coins/spend", problem.Instance)
+	assert.Equal(t, "req-123", problem.RequestID)
+}
+
+func TestProblemErrorHandler_HTTPError(t *testing.T) {
+	rec, problem := serveProblem(t, echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID"))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "Bad Request", problem.Title)
+	assert.Equal(t, "Invalid user ID", problem.Detail)
+}
+
+func TestProblemErrorHandler_InternalError(t *testing.T) {
+	rec, problem := serveProblem(t, errors.New("pq: connection refused"))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "Internal server error", problem.Detail)
+}
+
+func TestProblemErrorHandler_ValidationErrors(t *testing.T) {
+	type request struct {
+		Email  string `validate:"required,email"`
+		Amount int    `validate:"gt=0"`
+	}
+	err := validator.New().Struct(request{Email: "not-an-email"})
+
+	rec, problem := serveProblem(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, "request validation failed", problem.Detail)
+	assert.Equal(t, []FieldError{
+		{Field: "Email", Rule: "email", Message: "Email failed the email rule"},
+		{Field: "Amount", Rule: "gt", Param: "0", Message: "Amount failed the gt rule"},
+	}, problem.Errors)
+}
+
+func TestProblemErrorHandler_PrefersGeneratedRequestID(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/products", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Response().Header().Set(echo.HeaderXRequestID, "generated")
+
+	ProblemErrorHandler(echo.ErrNotFound, c)
+
+	var problem Problem
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, "generated", problem.RequestID)
+}
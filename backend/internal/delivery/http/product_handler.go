@@ -37,7 +37,7 @@ func (h *ProductHandler) GetProducts(c echo.Context) error {
 
 	products, err := h.productUseCase.GetAllProducts(ctx, page, limit)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return toHTTPError(err)
 	}
 
 	return c.JSON(http.StatusOK, products)
@@ -49,16 +49,16 @@ func (h *ProductHandler) GetProductByID(c echo.Context) error {
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid product id"})
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid product id")
 	}
 
 	product, err := h.productUseCase.GetProductByID(ctx, id)
 
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return toHTTPError(err)
 	}
 	if product == nil {
-		return c.JSON(http.StatusNotFound, map[string]string{"error": "product not found"})
+		return echo.NewHTTPError(http.StatusNotFound, "product not found")
 	}
 
 	return c.JSON(http.StatusOK, product)
@@ -0,0 +1,59 @@
+package http
+
+import (
+	"backend/internal/usecase"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type ReferralHandler struct {
+	referralUC usecase.ReferralUseCase
+}
+
+func NewReferralHandler(referralUC usecase.ReferralUseCase) *ReferralHandler {
+	return &ReferralHandler{
+		referralUC: referralUC,
+	}
+}
+
+type getReferralsRequest struct {
+	Page  int32 `query:"page" validate:"omitempty,gte=1"`
+	Limit int32 `query:"limit" validate:"omitempty,gte=1,lte=100"`
+}
+
+// RegisterRoutes expects a group that is already guarded by AuthMiddleware
+func (h *ReferralHandler) RegisterRoutes(g *echo.Group) {
+	g.GET("/referrals", h.GetUserReferrals)
+}
+
+// GetUserReferrals lists the signups made with the current user's referral code
+func (h *ReferralHandler) GetUserReferrals(c echo.Context) error {
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnauthorized, "User ID not found in context")
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID format")
+	}
+
+	req := new(getReferralsRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	referrals, err := h.referralUC.GetUserReferrals(c.Request().Context(), userID, req.Page, req.Limit)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"referrals": referrals,
+	})
+}
@@ -0,0 +1,75 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"backend/internal/logging"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+const maxRequestIDLength = 128
+
+// RequestLogger gives each request an id and logs the request once it has
+// been answered. An X-Request-ID sent by the client is kept when it looks
+// sane, otherwise a new one is generated, and the id is echoed back in the
+// response. It must wrap every middleware that can fail so that it sees the
+// final status.
+func RequestLogger(logger *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			requestID := c.Request().Header.Get(echo.HeaderXRequestID)
+			if !validRequestID(requestID) {
+				requestID = uuid.NewString()
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, requestID)
+			c.SetRequest(c.Request().WithContext(logging.WithRequestID(c.Request().Context(), requestID)))
+
+			err := next(c)
+			if err != nil {
+				// Render the error now so the status below is the one the client got
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			attrs := []slog.Attr{
+				slog.String("method", c.Request().Method),
+				slog.String("route", c.Path()),
+				slog.String("path", c.Request().URL.Path),
+				slog.Int("status", status),
+				slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			}
+
+			level := slog.LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = slog.LevelError
+				if err != nil {
+					attrs = append(attrs, slog.String("error", err.Error()))
+				}
+			}
+
+			// The request context by now also carries the user set by AuthMiddleware
+			logger.LogAttrs(c.Request().Context(), level, "request", attrs...)
+			return nil
+		}
+	}
+}
+
+// validRequestID accepts ids of printable ASCII without spaces, so a client
+// cannot inject anything odd into the logs
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
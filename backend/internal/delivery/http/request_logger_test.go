@@ -0,0 +1,82 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend/internal/logging"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveLogged(t *testing.T, requestID string, handler echo.HandlerFunc) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "info")
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.HTTPErrorHandler = ProblemErrorHandler
+	e.Use(RequestLogger(logger))
+	e.GET("/api/coins/transactions/:id", handler)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/coins/transactions/7", nil)
+	if requestID != "" {
+		req.Header.Set(echo.HeaderXRequestID, requestID)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	return rec, record
+}
+
+func TestRequestLogger_LogsRequest(t *testing.T) {
+	rec, record := serveLogged(t, "client-id-1", func(c echo.Context) error {
+		c.SetRequest(c.Request().WithContext(logging.WithUserID(c.Request().Context(), "user-1")))
+		assert.Equal(t, "client-id-1", logging.RequestID(c.Request().Context()))
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	assert.Equal(t, "client-id-1", rec.Header().Get(echo.HeaderXRequestID))
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, "GET", record["method"])
+	assert.Equal(t, "/api/coins/transactions/:id", record["route"])
+	assert.Equal(t, float64(http.StatusNoContent), record["status"])
+	assert.Equal(t, "client-id-1", record["request_id"])
+	assert.Equal(t, "user-1", record["user_id"])
+	assert.Contains(t, record, "latency_ms")
+}
+
+func TestRequestLogger_ReplacesInvalidID(t *testing.T) {
+	rec, record := serveLogged(t, "bad id\n", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	id := rec.Header().Get(echo.HeaderXRequestID)
+	assert.NotEqual(t, "bad id\n", id)
+	assert.Len(t, id, 36)
+	assert.Equal(t, id, record["request_id"])
+}
+
+func TestRequestLogger_LogsErrorStatus(t *testing.T) {
+	rec, record := serveLogged(t, "", func(c echo.Context) error {
+		return toHTTPError(errors.New("connection reset"))
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "ERROR", record["level"])
+	assert.Equal(t, float64(http.StatusInternalServerError), record["status"])
+	assert.True(t, strings.Contains(record["error"].(string), "connection reset"))
+	// The client only sees the generic message
+	assert.NotContains(t, rec.Body.String(), "connection reset")
+}
@@ -0,0 +1,35 @@
+package http
+
+import (
+	"strconv"
+	"time"
+
+	"backend/internal/metrics"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequestMetrics records how long each request took, by method, route and
+// status. It must come before RequestLogger so that the error has been
+// rendered and the status is final.
+func RequestMetrics() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			err := next(c)
+			if err != nil {
+				c.Error(err)
+			}
+
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+			metrics.HTTPRequestDuration.
+				WithLabelValues(c.Request().Method, route, strconv.Itoa(c.Response().Status)).
+				Observe(time.Since(start).Seconds())
+			return nil
+		}
+	}
+}
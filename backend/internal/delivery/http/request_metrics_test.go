@@ -0,0 +1,54 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend/internal/domain"
+	"backend/internal/metrics"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requestCount returns how many requests were observed with the given labels
+func requestCount(t *testing.T, method, route, status string) uint64 {
+	t.Helper()
+
+	reg := prometheus.NewRegistry()
+	require.NoError(t, reg.Register(metrics.HTTPRequestDuration))
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range m.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["method"] == method && labels["route"] == route && labels["status"] == status {
+				return m.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return 0
+}
+
+func TestRequestMetrics_RecordsRouteAndFinalStatus(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = ProblemErrorHandler
+	e.Use(RequestMetrics())
+	e.GET("/api/users/:id", func(c echo.Context) error {
+		return toHTTPError(domain.ErrUserNotFound)
+	})
+
+	before := requestCount(t, http.MethodGet, "/api/users/:id", "404")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/users/42", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, before+1, requestCount(t, http.MethodGet, "/api/users/:id", "404"))
+}
@@ -0,0 +1,102 @@
+package http
+
+import (
+	"backend/internal/entity"
+	"backend/internal/usecase"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type SpendLimitHandler struct {
+	spendLimitUC usecase.SpendLimitUseCase
+}
+
+func NewSpendLimitHandler(spendLimitUC usecase.SpendLimitUseCase) *SpendLimitHandler {
+	return &SpendLimitHandler{
+		spendLimitUC: spendLimitUC,
+	}
+}
+
+// RegisterAdminRoutes expects a group that is already guarded by AdminMiddleware
+func (h *SpendLimitHandler) RegisterAdminRoutes(g *echo.Group) {
+	g.GET("/users/:id/spend-limits", h.GetUserSpendLimits)
+	g.PUT("/users/:id/spend-limits", h.SetUserSpendLimits)
+	g.DELETE("/users/:id/spending-block", h.ClearSpendingBlock)
+	g.GET("/users/:id/security-events", h.GetSecurityEvents)
+}
+
+func (h *SpendLimitHandler) GetUserSpendLimits(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID format")
+	}
+
+	limits, err := h.spendLimitUC.GetUserSpendLimits(c.Request().Context(), userID)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"limits": limits,
+	})
+}
+
+func (h *SpendLimitHandler) SetUserSpendLimits(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID format")
+	}
+
+	req := new(entity.UpdateSpendLimitRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
+	}
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	limits, err := h.spendLimitUC.SetUserSpendLimits(c.Request().Context(), userID, *req)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Spend limits updated successfully",
+		"limits":  limits,
+	})
+}
+
+func (h *SpendLimitHandler) ClearSpendingBlock(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID format")
+	}
+
+	limits, err := h.spendLimitUC.ClearSpendingBlock(c.Request().Context(), userID)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Spending block cleared successfully",
+		"limits":  limits,
+	})
+}
+
+func (h *SpendLimitHandler) GetSecurityEvents(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID format")
+	}
+
+	events, err := h.spendLimitUC.GetSecurityEvents(c.Request().Context(), userID)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"events": events,
+	})
+}
@@ -15,10 +15,11 @@ import (
 type UserHandler struct {
 	userUseCase *usecase.UserUseCase
 	jwtSecret   string
+	jwtTTL      time.Duration
 }
 
-func NewUserHandler(uc *usecase.UserUseCase, jwtSecret string) *UserHandler {
-	return &UserHandler{userUseCase: uc, jwtSecret: jwtSecret}
+func NewUserHandler(uc *usecase.UserUseCase, jwtSecret string, jwtTTL time.Duration) *UserHandler {
+	return &UserHandler{userUseCase: uc, jwtSecret: jwtSecret, jwtTTL: jwtTTL}
 }
 
 func (h *UserHandler) GetUserById(c echo.Context) error {
@@ -31,10 +32,7 @@ func (h *UserHandler) GetUserById(c echo.Context) error {
 
 	user, err := h.userUseCase.GetUserById(c.Request().Context(), userID)
 	if err != nil {
-		if err.Error() == "user not found" {
-			return echo.NewHTTPError(http.StatusNotFound, "User not found")
-		}
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get user")
+		return toHTTPError(err)
 	}
 
 	return c.JSON(http.StatusOK, user.ToResponse())
@@ -51,7 +49,6 @@ func (h *UserHandler) RegisterRoutes(g *echo.Group) {
 	protected.PATCH("/users/:id/name", h.UpdateUserName)
 	protected.PATCH("/users/:id/email", h.UpdateUserEmail)
 	protected.PATCH("/users/:id/password", h.ChangePassword)
-	protected.PATCH("/users/:id/coins", h.UpdateUserCoins)
 	protected.DELETE("/users/:id", h.DeleteUser)
 
 	// Utility routes
@@ -63,6 +60,8 @@ type signupRequest struct {
 	Email    string `json:"email" validate:"required,email,max=255"`
 	Password string `json:"password" validate:"required,min=8"`
 	Coins    int    `json:"coins,omitempty"`
+	// ReferralCode is the code of the user who invited this one, if any
+	ReferralCode string `json:"referral_code,omitempty" validate:"omitempty,max=16"`
 }
 
 func (h *UserHandler) SignUp(c echo.Context) error {
@@ -71,17 +70,18 @@ func (h *UserHandler) SignUp(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
 	}
 	if err := c.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	user, err := h.userUseCase.SignUp(c.Request().Context(), entity.CreateUserRequest{
-		Name:     req.Name,
-		Email:    req.Email,
-		Password: req.Password,
-		Coins:    req.Coins,
+		Name:         req.Name,
+		Email:        req.Email,
+		Password:     req.Password,
+		Coins:        req.Coins,
+		ReferralCode: req.ReferralCode,
 	})
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return toHTTPError(err)
 	}
 
 	return c.JSON(http.StatusCreated, map[string]interface{}{
@@ -101,17 +101,17 @@ func (h *UserHandler) Login(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
 	}
 	if err := c.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	user, err := h.userUseCase.Login(c.Request().Context(), req.Email, req.Password)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid credentials")
+		return toHTTPError(err)
 	}
 
 	token, err := h.generateJWT(user.ID)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate token")
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate token").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -135,12 +135,12 @@ func (h *UserHandler) UpdateUserName(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
 	}
 	if err := c.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	user, err := h.userUseCase.UpdateUserName(c.Request().Context(), userID, req.Name)
 	if err != nil {
-		return h.handleUseCaseError(err)
+		return toHTTPError(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -164,12 +164,12 @@ func (h *UserHandler) UpdateUserEmail(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
 	}
 	if err := c.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	user, err := h.userUseCase.UpdateUserEmail(c.Request().Context(), userID, req.Email)
 	if err != nil {
-		return h.handleUseCaseError(err)
+		return toHTTPError(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -194,12 +194,12 @@ func (h *UserHandler) ChangePassword(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
 	}
 	if err := c.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	err = h.userUseCase.ChangePassword(c.Request().Context(), userID, req.CurrentPassword, req.NewPassword)
 	if err != nil {
-		return h.handleUseCaseError(err)
+		return toHTTPError(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{
@@ -207,35 +207,6 @@ func (h *UserHandler) ChangePassword(c echo.Context) error {
 	})
 }
 
-type updateCoinsRequest struct {
-	Amount int `json:"amount" validate:"required"`
-}
-
-func (h *UserHandler) UpdateUserCoins(c echo.Context) error {
-	userID, err := h.parseUserID(c)
-	if err != nil {
-		return err
-	}
-
-	req := new(updateCoinsRequest)
-	if err := c.Bind(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
-	}
-	if err := c.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
-	}
-
-	user, err := h.userUseCase.UpdateUserCoins(c.Request().Context(), userID, req.Amount)
-	if err != nil {
-		return h.handleUseCaseError(err)
-	}
-
-	return c.JSON(http.StatusOK, map[string]interface{}{
-		"message": "Coins updated successfully",
-		"user":    user.ToResponse(),
-	})
-}
-
 func (h *UserHandler) DeleteUser(c echo.Context) error {
 	userID, err := h.parseUserID(c)
 	if err != nil {
@@ -244,7 +215,7 @@ func (h *UserHandler) DeleteUser(c echo.Context) error {
 
 	err = h.userUseCase.DeleteUser(c.Request().Context(), userID)
 	if err != nil {
-		return h.handleUseCaseError(err)
+		return toHTTPError(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{
@@ -262,12 +233,12 @@ func (h *UserHandler) CheckEmailExists(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request")
 	}
 	if err := c.Validate(req); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return err
 	}
 
 	exists, err := h.userUseCase.CheckEmailExists(c.Request().Context(), req.Email)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check email")
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check email").SetInternal(err)
 	}
 
 	return c.JSON(http.StatusOK, map[string]bool{
@@ -284,25 +255,10 @@ func (h *UserHandler) parseUserID(c echo.Context) (uuid.UUID, error) {
 	return userID, nil
 }
 
-func (h *UserHandler) handleUseCaseError(err error) error {
-	switch err.Error() {
-	case "user not found":
-		return echo.NewHTTPError(http.StatusNotFound, "User not found")
-	case "email already exists":
-		return echo.NewHTTPError(http.StatusConflict, "Email already exists")
-	case "insufficient coins":
-		return echo.NewHTTPError(http.StatusBadRequest, "Insufficient coins")
-	case "current password is incorrect":
-		return echo.NewHTTPError(http.StatusBadRequest, "Current password is incorrect")
-	default:
-		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error")
-	}
-}
-
 func (h *UserHandler) generateJWT(userID uuid.UUID) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID.String(),
-		"exp":     time.Now().Add(24 * time.Hour).Unix(), // Extended to 24 hours
+		"exp":     time.Now().Add(h.jwtTTL).Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ja"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	ja_translations "github.com/go-playground/validator/v10/translations/ja"
+	"github.com/labstack/echo/v4"
+)
+
+// Validator is the echo.Validator for the API. Fields are reported by their
+// JSON or query parameter name, and ProblemErrorHandler turns its errors into
+// per-field messages in the language the client asked for.
+type Validator struct {
+	validate *validator.Validate
+	uni      *ut.UniversalTranslator
+}
+
+// NewValidator returns a validator with English and Japanese messages.
+// English is the fallback for any other language.
+func NewValidator() (*Validator, error) {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(fieldName)
+
+	enLocale := en.New()
+	uni := ut.New(enLocale, enLocale, ja.New())
+
+	enTrans, _ := uni.GetTranslator("en")
+	if err := en_translations.RegisterDefaultTranslations(validate, enTrans); err != nil {
+		return nil, fmt.Errorf("failed to register English translations: %w", err)
+	}
+
+	jaTrans, _ := uni.GetTranslator("ja")
+	if err := ja_translations.RegisterDefaultTranslations(validate, jaTrans); err != nil {
+		return nil, fmt.Errorf("failed to register Japanese translations: %w", err)
+	}
+
+	return &Validator{validate: validate, uni: uni}, nil
+}
+
+func (v *Validator) Validate(i interface{}) error {
+	return v.validate.Struct(i)
+}
+
+// Translator returns the translator for the first supported language in an
+// Accept-Language header, or English
+func (v *Validator) Translator(acceptLanguage string) ut.Translator {
+	trans, _ := v.uni.FindTranslator(parseAcceptLanguage(acceptLanguage)...)
+	return trans
+}
+
+// fieldName names a field after its json or query tag, so errors refer to
+// what the client sent rather than to the Go struct
+func fieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "query", "param"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// parseAcceptLanguage returns the primary language subtags of an
+// Accept-Language header, most preferred first. Languages with equal quality
+// keep the order they are listed in, and those with q=0 are left out.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		lang    string
+		quality float64
+	}
+
+	var prefs []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+		if lang = strings.ToLower(lang); lang == "" || lang == "*" {
+			continue
+		}
+		if quality := parseQuality(params); quality > 0 {
+			prefs = append(prefs, weighted{lang: lang, quality: quality})
+		}
+	}
+	sort.SliceStable(prefs, func(i, j int) bool { return prefs[i].quality > prefs[j].quality })
+
+	langs := make([]string, 0, len(prefs))
+	for _, pref := range prefs {
+		langs = append(langs, pref.lang)
+	}
+	return langs
+}
+
+// parseQuality reads the q parameter of a language range. A missing q means
+// 1, and a malformed one means the range is not acceptable.
+func parseQuality(params string) float64 {
+	for _, param := range strings.Split(params, ";") {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		if !strings.EqualFold(strings.TrimSpace(key), "q") {
+			continue
+		}
+		quality, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || quality < 0 || quality > 1 {
+			return 0
+		}
+		return quality
+	}
+	return 1
+}
+
+// fieldErrors describes each failed validation. Messages are translated when
+// the echo instance uses Validator.
+func fieldErrors(c echo.Context, errs validator.ValidationErrors) []FieldError {
+	var trans ut.Translator
+	if v, ok := c.Echo().Validator.(*Validator); ok {
+		trans = v.Translator(c.Request().Header.Get("Accept-Language"))
+	}
+
+	fields := make([]FieldError, 0, len(errs))
+	for _, fe := range errs {
+		message := fmt.Sprintf("%s failed the %s rule", fe.Field(), fe.Tag())
+		if trans != nil {
+			message = fe.Translate(trans)
+		}
+
+		fields = append(fields, FieldError{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: message,
+		})
+	}
+	return fields
+}
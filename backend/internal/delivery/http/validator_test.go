@@ -0,0 +1,82 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type validatorTestRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=8"`
+	Page     int    `query:"page" validate:"omitempty,min=1"`
+}
+
+func validationProblem(t *testing.T, acceptLanguage string, req validatorTestRequest) Problem {
+	t.Helper()
+
+	v, err := NewValidator()
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Validator = v
+
+	httpReq := httptest.NewRequest(http.MethodPost, "/api/signup", nil)
+	if acceptLanguage != "" {
+		httpReq.Header.Set("Accept-Language", acceptLanguage)
+	}
+	rec := httptest.NewRecorder()
+
+	ProblemErrorHandler(v.Validate(req), e.NewContext(httpReq, rec))
+
+	var problem Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	return problem
+}
+
+func TestValidator_EnglishByDefault(t *testing.T) {
+	problem := validationProblem(t, "", validatorTestRequest{Password: "short", Page: -1})
+
+	assert.Equal(t, []FieldError{
+		{Field: "email", Rule: "required", Message: "email is a required field"},
+		{Field: "password", Rule: "min", Param: "8", Message: "password must be at least 8 characters in length"},
+		{Field: "page", Rule: "min", Param: "1", Message: "page must be 1 or greater"},
+	}, problem.Errors)
+}
+
+func TestValidator_Japanese(t *testing.T) {
+	problem := validationProblem(t, "ja-JP,ja;q=0.9,en;q=0.8", validatorTestRequest{Email: "not-an-email", Password: "password123"})
+
+	require.Len(t, problem.Errors, 1)
+	assert.Equal(t, "email", problem.Errors[0].Field)
+	assert.Equal(t, "email", problem.Errors[0].Rule)
+	assert.Equal(t, "emailは正しいメールアドレスでなければなりません", problem.Errors[0].Message)
+}
+
+func TestValidator_UnsupportedLanguageFallsBackToEnglish(t *testing.T) {
+	problem := validationProblem(t, "fr-FR", validatorTestRequest{Email: "a@example.com"})
+
+	require.Len(t, problem.Errors, 1)
+	assert.Equal(t, "password is a required field", problem.Errors[0].Message)
+}
+
+func TestValidator_LanguageWithZeroQualityIsNotUsed(t *testing.T) {
+	problem := validationProblem(t, "ja;q=0, en;q=0.8", validatorTestRequest{Email: "a@example.com"})
+
+	require.Len(t, problem.Errors, 1)
+	assert.Equal(t, "password is a required field", problem.Errors[0].Message)
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	assert.Equal(t, []string{"en", "ja"}, parseAcceptLanguage("ja-JP;q=0.9, en"))
+	assert.Equal(t, []string{"ja", "en", "fr"}, parseAcceptLanguage("en;q=0.5, ja, fr;q=0.5"))
+	assert.Equal(t, []string{"ja"}, parseAcceptLanguage("en;q=0, ja;q=0.1, de;q=abc"))
+	assert.Equal(t, []string{"en"}, parseAcceptLanguage("*, EN-us"))
+	assert.Empty(t, parseAcceptLanguage(""))
+}
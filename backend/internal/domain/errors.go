@@ -0,0 +1,184 @@
+// Package domain defines the errors use cases and repositories return for
+// expected failures. Callers check them with errors.Is, or with errors.As and
+// Kind when only the category matters, instead of matching message text.
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind is the category of a domain error
+type Kind int
+
+const (
+	// KindInternal is any error that is not a domain error
+	KindInternal Kind = iota
+	KindInvalid
+	KindUnauthorized
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindRateLimited
+)
+
+// Error is an expected failure with a message that is safe to show to clients
+type Error struct {
+	kind    Kind
+	message string
+	err     error
+	cause   error
+}
+
+// New returns a domain error of kind. Use it for package-level sentinels.
+func New(kind Kind, message string) *Error {
+	return &Error{kind: kind, message: message}
+}
+
+// Errorf returns an error of the same kind as base with detail appended to its
+// message. The result still matches base with errors.Is.
+func Errorf(base *Error, format string, args ...any) *Error {
+	return &Error{
+		kind:    base.kind,
+		message: base.message + ": " + fmt.Sprintf(format, args...),
+		err:     base,
+	}
+}
+
+// Wrap returns an error that matches base and keeps cause reachable through
+// errors.Is and errors.As. The message is base's alone, so details of the
+// cause are not shown to clients.
+func Wrap(base *Error, cause error) *Error {
+	return &Error{
+		kind:    base.kind,
+		message: base.message,
+		err:     base,
+		cause:   cause,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Unwrap() []error {
+	var errs []error
+	if e.err != nil {
+		errs = append(errs, e.err)
+	}
+	if e.cause != nil {
+		errs = append(errs, e.cause)
+	}
+	return errs
+}
+
+// Kind returns the category of the error
+func (e *Error) Kind() Kind {
+	return e.kind
+}
+
+// KindOf returns the kind of the outermost domain error in err's chain, or
+// KindInternal if there is none
+func KindOf(err error) Kind {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.kind
+	}
+	return KindInternal
+}
+
+// Users
+var (
+	ErrUserNotFound             = New(KindNotFound, "user not found")
+	ErrUserAlreadyExists        = New(KindConflict, "user already exists")
+	ErrEmailAlreadyExists       = New(KindConflict, "email already exists")
+	ErrInvalidCredentials       = New(KindUnauthorized, "invalid credentials")
+	ErrCurrentPasswordIncorrect = New(KindInvalid, "current password is incorrect")
+	ErrNameRequired             = New(KindInvalid, "name is required")
+	ErrNameEmpty                = New(KindInvalid, "name cannot be empty")
+	ErrEmailRequired            = New(KindInvalid, "email is required")
+	ErrEmailEmpty               = New(KindInvalid, "email cannot be empty")
+	ErrPasswordRequired         = New(KindInvalid, "password is required")
+	ErrPasswordTooShort         = New(KindInvalid, "password must be at least 8 characters")
+	ErrNewPasswordTooShort      = New(KindInvalid, "new password must be at least 8 characters")
+	ErrInvalidReferralCode      = New(KindInvalid, "invalid referral code")
+)
+
+// Generic constraint violations, for constraints without a more specific error
+var (
+	ErrAlreadyExists       = New(KindConflict, "resource already exists")
+	ErrConstraintViolation = New(KindInvalid, "value violates a constraint")
+	ErrReferenceViolation  = New(KindConflict, "operation conflicts with related records")
+)
+
+// Coins and the ledger
+var (
+	ErrInsufficientCoins    = New(KindInvalid, "insufficient coins")
+	ErrTransactionNotFound  = New(KindNotFound, "transaction not found")
+	ErrInvalidTransactionID = New(KindInvalid, "invalid transaction ID")
+	ErrAmountNotPositive    = New(KindInvalid, "amount must be positive")
+	ErrDescriptionRequired  = New(KindInvalid, "description is required")
+	ErrInvalidTxType        = New(KindInvalid, "invalid transaction type")
+	ErrInvalidDateRange     = New(KindInvalid, "invalid date range")
+	ErrInvalidAmountSign    = New(KindInvalid, "invalid amount sign")
+	ErrInvalidInterval      = New(KindInvalid, "invalid interval")
+	ErrTooManySeriesPoints  = New(KindInvalid, "too many points in series")
+)
+
+// Coin packs
+var (
+	ErrCoinPackNotFound      = New(KindNotFound, "coin pack not found")
+	ErrCoinPackNotAvailable  = New(KindInvalid, "coin pack is not available")
+	ErrInvalidCoinPackID     = New(KindInvalid, "invalid coin pack ID")
+	ErrBaseCoinsNotPositive  = New(KindInvalid, "base coins must be positive")
+	ErrBonusCoinsNegative    = New(KindInvalid, "bonus coins cannot be negative")
+	ErrCoinPackPriceNegative = New(KindInvalid, "price cannot be negative")
+)
+
+// Spend limits
+var (
+	ErrPerTransactionLimitExceeded = New(KindInvalid, "amount exceeds per-transaction limit")
+	ErrDailySpendLimitExceeded     = New(KindInvalid, "daily spend limit exceeded")
+	ErrSpendingBlocked             = New(KindForbidden, "spending is temporarily blocked")
+	ErrDailyLimitNegative          = New(KindInvalid, "daily limit cannot be negative")
+	ErrPerTransactionLimitNegative = New(KindInvalid, "per-transaction limit cannot be negative")
+)
+
+// Coin holds
+var (
+	ErrCoinHoldNotFound  = New(KindNotFound, "coin hold not found")
+	ErrInvalidCoinHoldID = New(KindInvalid, "invalid coin hold ID")
+	ErrCoinHoldNotActive = New(KindConflict, "coin hold is not active")
+	ErrCoinHoldExpired   = New(KindConflict, "coin hold has expired")
+)
+
+// Orders and cashback
+var (
+	ErrOrderNotFound                = New(KindNotFound, "order not found")
+	ErrInvalidOrderStatus           = New(KindInvalid, "invalid order status")
+	ErrInvalidOrderStatusTransition = New(KindConflict, "invalid order status transition")
+	ErrOrderStatusChanged           = New(KindConflict, "order status has changed")
+	ErrCashbackRateNotFound         = New(KindNotFound, "cashback rate not found")
+	ErrCashbackRateOutOfRange       = New(KindInvalid, "cashback rate must be between 0 and 100")
+)
+
+// Gift codes
+var (
+	ErrGiftCodeBatchNotFound     = New(KindNotFound, "gift code batch not found")
+	ErrInvalidGiftCode           = New(KindInvalid, "invalid gift code")
+	ErrGiftCodeExpired           = New(KindInvalid, "gift code has expired")
+	ErrGiftCodeFullyRedeemed     = New(KindConflict, "gift code has been fully redeemed")
+	ErrGiftCodeAlreadyRedeemed   = New(KindConflict, "gift code already redeemed")
+	ErrTooManyRedemptionAttempts = New(KindRateLimited, "too many failed redemption attempts")
+	ErrGiftCodeCountOutOfRange   = New(KindInvalid, "count must be between 1 and 1000")
+	ErrGiftCodeAmountNotPositive = New(KindInvalid, "coin amount must be positive")
+	ErrMaxRedemptionsNotPositive = New(KindInvalid, "max redemptions must be positive")
+	ErrGiftCodeExpiryNotInFuture = New(KindInvalid, "expiry must be in the future")
+)
+
+// Catalog
+var (
+	ErrProductNotFound   = New(KindNotFound, "product not found")
+	ErrCategoryNotFound  = New(KindNotFound, "category not found")
+	ErrInsufficientStock = New(KindConflict, "insufficient stock")
+)
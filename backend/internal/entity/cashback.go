@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+// CashbackPolicy holds the cashback rate used for categories without a rate of their own
+type CashbackPolicy struct {
+	DefaultRatePercent float64
+}
+
+type CategoryCashbackRate struct {
+	CategoryID  int32     `json:"category_id"`
+	RatePercent float64   `json:"rate_percent"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type SetCashbackRateRequest struct {
+	RatePercent float64 `json:"rate_percent" validate:"gte=0,lte=100"`
+}
+
+// CalculateCashback returns the coins to give back for an order. The coins
+// used are split across categories in proportion to each category's share of
+// the item subtotal, and each share earns its category's rate. Orders without
+// items earn the default rate on everything. Fractions of a coin are dropped.
+func (p CashbackPolicy) CalculateCashback(coinsUsed int, subtotals []OrderCategorySubtotal, rates map[int32]float64) int {
+	if coinsUsed <= 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, s := range subtotals {
+		total += s.Subtotal
+	}
+
+	if total <= 0 {
+		return int(math.Floor(float64(coinsUsed) * p.DefaultRatePercent / 100))
+	}
+
+	cashback := 0.0
+	for _, s := range subtotals {
+		rate, ok := rates[s.CategoryID]
+		if !ok {
+			rate = p.DefaultRatePercent
+		}
+		cashback += float64(coinsUsed) * (s.Subtotal / total) * rate / 100
+	}
+
+	// Guard against float error pushing an exact result just below a whole coin
+	return int(math.Floor(cashback + 1e-9))
+}
@@ -0,0 +1,73 @@
+package entity
+
+import "time"
+
+// Bucket sizes for a balance series. They match the PostgreSQL date_trunc
+// fields of the same name, so weeks start on Monday.
+const (
+	BalanceIntervalDay   = "day"
+	BalanceIntervalWeek  = "week"
+	BalanceIntervalMonth = "month"
+)
+
+// IsValidBalanceInterval reports whether interval is one of the BalanceInterval values
+func IsValidBalanceInterval(interval string) bool {
+	switch interval {
+	case BalanceIntervalDay, BalanceIntervalWeek, BalanceIntervalMonth:
+		return true
+	}
+	return false
+}
+
+// TruncateToInterval returns the start of the day, week or month containing
+// t, in t's location.
+func TruncateToInterval(t time.Time, interval string) time.Time {
+	year, month, day := t.Date()
+	switch interval {
+	case BalanceIntervalWeek:
+		day -= (int(t.Weekday()) + 6) % 7
+	case BalanceIntervalMonth:
+		day = 1
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// AddInterval moves t by n days, weeks or months
+func AddInterval(t time.Time, interval string, n int) time.Time {
+	switch interval {
+	case BalanceIntervalWeek:
+		return t.AddDate(0, 0, 7*n)
+	case BalanceIntervalMonth:
+		return t.AddDate(0, n, 0)
+	default:
+		return t.AddDate(0, 0, n)
+	}
+}
+
+type CoinBalanceAt struct {
+	At      time.Time `json:"at"`
+	Balance int       `json:"balance"`
+}
+
+// CoinBalanceChange is the ledger activity in one bucket of a series.
+// RunningChange is the net change from the start of the series to the end of
+// the bucket.
+type CoinBalanceChange struct {
+	BucketStart   time.Time
+	NetChange     int
+	RunningChange int
+}
+
+type CoinBalanceSeriesPoint struct {
+	Start     time.Time `json:"start"`
+	NetChange int       `json:"net_change"`
+	Balance   int       `json:"balance"` // at the end of the bucket
+}
+
+type CoinBalanceSeries struct {
+	Interval       string                   `json:"interval"`
+	From           time.Time                `json:"from"`
+	To             time.Time                `json:"to"`
+	OpeningBalance int                      `json:"opening_balance"`
+	Points         []CoinBalanceSeriesPoint `json:"points"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CoinHoldStatusActive   = "active"
+	CoinHoldStatusCaptured = "captured"
+	CoinHoldStatusReleased = "released"
+	CoinHoldStatusExpired  = "expired"
+)
+
+// CoinHold reserves coins for a pending purchase. While active it lowers the
+// available balance but not the settled one; capturing turns it into a
+// purchase transaction, and releasing or expiry frees the coins again.
+type CoinHold struct {
+	ID                int32     `json:"id"`
+	UserID            uuid.UUID `json:"user_id"`
+	Amount            int       `json:"amount"`
+	Description       string    `json:"description"`
+	OrderID           *int32    `json:"order_id,omitempty"`
+	Status            string    `json:"status"`
+	ExpiresAt         time.Time `json:"expires_at"`
+	CoinTransactionID *int32    `json:"coin_transaction_id,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
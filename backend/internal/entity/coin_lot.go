@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CoinLot struct {
+	ID                int32      `json:"id"`
+	UserID            uuid.UUID  `json:"user_id"`
+	CoinTransactionID *int32     `json:"coin_transaction_id,omitempty"`
+	Source            string     `json:"source"`
+	OriginalAmount    int        `json:"original_amount"`
+	RemainingAmount   int        `json:"remaining_amount"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// CoinExpiryPolicy controls how many months granted coins stay valid.
+// Zero means coins of that kind never expire. Cashback, gift codes and
+// referral bonuses are promotional and follow the bonus expiry.
+type CoinExpiryPolicy struct {
+	ChargeMonths int
+	BonusMonths  int
+}
+
+// ExpiresAt returns when coins granted by the given transaction type expire,
+// or nil if they never do.
+func (p CoinExpiryPolicy) ExpiresAt(transactionType string, grantedAt time.Time) *time.Time {
+	var months int
+	switch transactionType {
+	case "charge":
+		months = p.ChargeMonths
+	case "bonus", "cashback", "gift_code", "referral":
+		months = p.BonusMonths
+	}
+
+	if months <= 0 {
+		return nil
+	}
+
+	expiresAt := grantedAt.AddDate(0, months, 0)
+	return &expiresAt
+}
+
+type CoinExpiryBucket struct {
+	ExpiresOn string `json:"expires_on"` // YYYY-MM-DD
+	Amount    int    `json:"amount"`
+}
+
+type CoinBalance struct {
+	Coins       int                `json:"coins"`     // settled
+	Held        int                `json:"held"`      // reserved by active holds
+	Available   int                `json:"available"` // settled minus held
+	NonExpiring int                `json:"non_expiring"`
+	Expiring    []CoinExpiryBucket `json:"expiring"`
+}
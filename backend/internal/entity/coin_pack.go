@@ -0,0 +1,38 @@
+package entity
+
+import "time"
+
+type CoinPack struct {
+	ID         int32     `json:"id"`
+	Name       string    `json:"name"`
+	BaseCoins  int       `json:"base_coins"`
+	BonusCoins int       `json:"bonus_coins"`
+	Price      float64   `json:"price"`
+	IsActive   bool      `json:"is_active"`
+	SortOrder  int       `json:"sort_order"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TotalCoins is the number of coins granted when the pack is purchased
+func (p *CoinPack) TotalCoins() int {
+	return p.BaseCoins + p.BonusCoins
+}
+
+type CreateCoinPackRequest struct {
+	Name       string  `json:"name" validate:"required,min=1,max=100"`
+	BaseCoins  int     `json:"base_coins" validate:"required,gt=0"`
+	BonusCoins int     `json:"bonus_coins" validate:"gte=0"`
+	Price      float64 `json:"price" validate:"gte=0"`
+	IsActive   bool    `json:"is_active"`
+	SortOrder  int     `json:"sort_order"`
+}
+
+type UpdateCoinPackRequest struct {
+	Name       string  `json:"name" validate:"required,min=1,max=100"`
+	BaseCoins  int     `json:"base_coins" validate:"required,gt=0"`
+	BonusCoins int     `json:"bonus_coins" validate:"gte=0"`
+	Price      float64 `json:"price" validate:"gte=0"`
+	IsActive   bool    `json:"is_active"`
+	SortOrder  int     `json:"sort_order"`
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CoinStatement describes a user's ledger over [From, To). ClosingBalance and
+// TransactionCount are only filled in once every transaction has been written.
+type CoinStatement struct {
+	UserID           uuid.UUID
+	From             time.Time
+	To               time.Time
+	OpeningBalance   int
+	ClosingBalance   int
+	TransactionCount int
+}
+
+// CoinStatementWriter receives a statement as it is read from the database:
+// the opening balance first, then each transaction oldest first, then the
+// closing balance.
+type CoinStatementWriter interface {
+	WriteOpening(statement *CoinStatement) error
+	WriteTransaction(transaction *CoinTransaction) error
+	WriteClosing(statement *CoinStatement) error
+}
@@ -13,6 +13,7 @@ type CoinTransaction struct {
 	Amount          int       `json:"amount"`
 	BalanceAfter    int       `json:"balance_after"`
 	OrderID         *int32    `json:"order_id,omitempty"`
+	CoinPackID      *int32    `json:"coin_pack_id,omitempty"`
 	Description     string    `json:"description"`
 	CreatedAt       time.Time `json:"created_at"`
 }
@@ -32,5 +33,74 @@ func (ct *CoinTransaction) ToResponse() map[string]interface{} {
 		response["order_id"] = *ct.OrderID
 	}
 
+	if ct.CoinPackID != nil {
+		response["coin_pack_id"] = *ct.CoinPackID
+	}
+
 	return response
 }
+
+const (
+	TransactionTypeCharge   = "charge"
+	TransactionTypePurchase = "purchase"
+	TransactionTypeRefund   = "refund"
+	TransactionTypeBonus    = "bonus"
+	TransactionTypeExpiry   = "expiry"
+
+	TransactionTypeCashback         = "cashback"
+	TransactionTypeCashbackReversal = "cashback_reversal"
+
+	TransactionTypeGiftCode = "gift_code"
+	TransactionTypeReferral = "referral"
+)
+
+// TransactionTypes lists every value of the transaction_type enum
+var TransactionTypes = []string{
+	TransactionTypeCharge,
+	TransactionTypePurchase,
+	TransactionTypeRefund,
+	TransactionTypeBonus,
+	TransactionTypeExpiry,
+	TransactionTypeCashback,
+	TransactionTypeCashbackReversal,
+	TransactionTypeGiftCode,
+	TransactionTypeReferral,
+}
+
+const (
+	AmountSignPositive = "positive"
+	AmountSignNegative = "negative"
+)
+
+// CoinTransactionFilter narrows a user's transaction history. Zero values mean "no filter".
+type CoinTransactionFilter struct {
+	TransactionTypes []string
+	From             *time.Time // inclusive
+	To               *time.Time // exclusive
+	OrderID          *int32
+	AmountSign       string // "", "positive" or "negative"
+	Page             int32
+	Limit            int32
+}
+
+// CoinTransactionSummary totals the transactions a filter matches. Every type
+// counts towards exactly one total, so TotalCharged + TotalRefunded -
+// TotalSpent is their net change.
+type CoinTransactionSummary struct {
+	TotalCount int `json:"total_count"`
+	// TotalCharged is the coins credited: charge, bonus, cashback, gift_code
+	// and referral entries
+	TotalCharged int `json:"total_charged"`
+	// TotalSpent is the coins debited, as a positive number: purchase, expiry
+	// and cashback_reversal entries
+	TotalSpent int `json:"total_spent"`
+	// TotalRefunded is the coins returned by refund entries
+	TotalRefunded int `json:"total_refunded"`
+}
+
+type CoinTransactionHistory struct {
+	Transactions []*CoinTransaction
+	Summary      CoinTransactionSummary
+	Page         int32
+	Limit        int32
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SecurityEventGiftCodeFailure is recorded for every failed redemption and
+// counted by the brute-force check
+const SecurityEventGiftCodeFailure = "gift_code_redeem_failed"
+
+// GiftCodePolicy limits how many failed redemptions a user may make within
+// FailureWindow before further attempts are refused.
+type GiftCodePolicy struct {
+	MaxFailedAttempts int
+	FailureWindow     time.Duration
+}
+
+type GiftCodeBatch struct {
+	ID          int32       `json:"id"`
+	Description string      `json:"description"`
+	CreatedBy   *uuid.UUID  `json:"created_by,omitempty"`
+	CreatedAt   time.Time   `json:"created_at"`
+	Codes       []*GiftCode `json:"codes"`
+}
+
+// GiftCode never carries the code itself; only its hash is stored
+type GiftCode struct {
+	ID              int32      `json:"id"`
+	BatchID         int32      `json:"batch_id"`
+	CodeHint        string     `json:"code_hint"`
+	CoinAmount      int        `json:"coin_amount"`
+	MaxRedemptions  int        `json:"max_redemptions"`
+	RedemptionCount int        `json:"redemption_count"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type CreateGiftCodeBatchRequest struct {
+	Count          int        `json:"count" validate:"required,gte=1,lte=1000"`
+	CoinAmount     int        `json:"coin_amount" validate:"required,gt=0"`
+	MaxRedemptions int        `json:"max_redemptions" validate:"required,gt=0"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	Description    string     `json:"description"`
+}
+
+type RedeemGiftCodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OrderStatusPending   = "pending"
+	OrderStatusCompleted = "completed"
+	OrderStatusCancelled = "cancelled"
+	OrderStatusRefunded  = "refunded"
+)
+
+// orderStatusTransitions lists the statuses each status may move to
+var orderStatusTransitions = map[string][]string{
+	OrderStatusPending:   {OrderStatusCompleted, OrderStatusCancelled},
+	OrderStatusCompleted: {OrderStatusRefunded},
+}
+
+// CanTransitionOrderStatus reports whether an order may move from one status to another
+func CanTransitionOrderStatus(from, to string) bool {
+	for _, next := range orderStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+type Order struct {
+	ID             int32     `json:"id"`
+	UserID         uuid.UUID `json:"user_id"`
+	OrderNumber    string    `json:"order_number"`
+	TotalAmount    float64   `json:"total_amount"`
+	TotalCoinsUsed int       `json:"total_coins_used"`
+	Status         string    `json:"status"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type UpdateOrderStatusRequest struct {
+	Status string `json:"status" validate:"required,oneof=pending completed cancelled refunded"`
+}
+
+// OrderCategorySubtotal is the part of an order's item subtotal that falls in one category
+type OrderCategorySubtotal struct {
+	CategoryID int32
+	Subtotal   float64
+}
@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReferralStatusPending  = "pending"
+	ReferralStatusRewarded = "rewarded"
+	ReferralStatusRejected = "rejected"
+)
+
+// Reasons a referral is rejected when the referee signs up
+const (
+	ReferralRejectedSelfReferral     = "self_referral"
+	ReferralRejectedDuplicateAccount = "duplicate_account"
+)
+
+// SecurityEventReferralRejected is recorded on the referee when a referral is rejected
+const SecurityEventReferralRejected = "referral_rejected"
+
+// ReferralPolicy sets the coins each side of a referral receives once the
+// referee completes their first order
+type ReferralPolicy struct {
+	ReferrerBonus int
+	RefereeBonus  int
+}
+
+type Referral struct {
+	ID              int32      `json:"id"`
+	ReferrerID      uuid.UUID  `json:"referrer_id"`
+	RefereeID       uuid.UUID  `json:"referee_id"`
+	Status          string     `json:"status"`
+	RejectionReason string     `json:"rejection_reason,omitempty"`
+	RewardedAt      *time.Time `json:"rewarded_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// NormalizeEmail reduces an email to the inbox it delivers to: lower case,
+// without a +tag, and for Gmail without dots. Two emails that normalize the
+// same belong to one person as far as referrals are concerned.
+func NormalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+	local, domain := email[:at], email[at+1:]
+
+	if plus := strings.Index(local, "+"); plus >= 0 {
+		local = local[:plus]
+	}
+
+	if domain == "gmail.com" || domain == "googlemail.com" {
+		local = strings.ReplaceAll(local, ".", "")
+		domain = "gmail.com"
+	}
+
+	return local + "@" + domain
+}
+
+// ReferralRejectionReason decides whether a new signup may count as a
+// referral. existingAccounts is how many users already share the referee's
+// normalized email. It returns "" when the referral is allowed.
+func ReferralRejectionReason(referrerNormalizedEmail, refereeNormalizedEmail string, existingAccounts int) string {
+	if referrerNormalizedEmail == refereeNormalizedEmail {
+		return ReferralRejectedSelfReferral
+	}
+	if existingAccounts > 0 {
+		return ReferralRejectedDuplicateAccount
+	}
+	return ""
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	assert.Equal(t, "john@example.com", NormalizeEmail(" John@Example.com "))
+	assert.Equal(t, "john@example.com", NormalizeEmail("john+promo@example.com"))
+	assert.Equal(t, "j.doe@example.com", NormalizeEmail("j.doe@example.com"))
+	assert.Equal(t, "johndoe@gmail.com", NormalizeEmail("John.Doe+2@googlemail.com"))
+	assert.Equal(t, "not-an-email", NormalizeEmail("Not-An-Email"))
+}
+
+func TestReferralRejectionReason(t *testing.T) {
+	assert.Equal(t, ReferralRejectedSelfReferral, ReferralRejectionReason("johndoe@gmail.com", "johndoe@gmail.com", 1))
+	assert.Equal(t, ReferralRejectedDuplicateAccount, ReferralRejectionReason("alice@example.com", "johndoe@gmail.com", 1))
+	assert.Empty(t, ReferralRejectionReason("alice@example.com", "johndoe@gmail.com", 0))
+}
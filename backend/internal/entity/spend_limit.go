@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SpendLimitPolicy holds the default spend limits. Zero disables a limit.
+// The daily limit covers a rolling 24 hours rather than a calendar day, so it
+// does not depend on the user's timezone.
+type SpendLimitPolicy struct {
+	DailyLimit          int
+	PerTransactionLimit int
+
+	// More than VelocityMaxSpends spends within VelocityWindow blocks
+	// spending for BlockDuration
+	VelocityMaxSpends int
+	VelocityWindow    time.Duration
+	BlockDuration     time.Duration
+}
+
+// UserSpendLimit is an admin override of the default limits for one user.
+// A nil limit means the default applies.
+type UserSpendLimit struct {
+	UserID               uuid.UUID  `json:"user_id"`
+	DailyLimit           *int       `json:"daily_limit"`
+	PerTransactionLimit  *int       `json:"per_transaction_limit"`
+	SpendingBlockedUntil *time.Time `json:"spending_blocked_until,omitempty"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
+
+// SpendLimits are the limits that actually apply to a user
+type SpendLimits struct {
+	DailyLimit           int        `json:"daily_limit"`
+	PerTransactionLimit  int        `json:"per_transaction_limit"`
+	SpendingBlockedUntil *time.Time `json:"spending_blocked_until,omitempty"`
+}
+
+// For merges a user's override, which may be nil, into the defaults
+func (p SpendLimitPolicy) For(override *UserSpendLimit) SpendLimits {
+	limits := SpendLimits{
+		DailyLimit:          p.DailyLimit,
+		PerTransactionLimit: p.PerTransactionLimit,
+	}
+
+	if override == nil {
+		return limits
+	}
+
+	if override.DailyLimit != nil {
+		limits.DailyLimit = *override.DailyLimit
+	}
+	if override.PerTransactionLimit != nil {
+		limits.PerTransactionLimit = *override.PerTransactionLimit
+	}
+	limits.SpendingBlockedUntil = override.SpendingBlockedUntil
+
+	return limits
+}
+
+type UpdateSpendLimitRequest struct {
+	DailyLimit          *int `json:"daily_limit" validate:"omitempty,gte=0"`
+	PerTransactionLimit *int `json:"per_transaction_limit" validate:"omitempty,gte=0"`
+}
+
+// SpendActivity summarizes a user's recent purchases, counting active holds
+// as purchases
+type SpendActivity struct {
+	Spent      int // coins spent since the start of the daily window
+	SpendCount int // purchases since the start of the velocity window
+}
+
+const (
+	SecurityEventSpendVelocity  = "spend_velocity_exceeded"
+	SecurityEventSpendUnblocked = "spending_unblocked"
+)
+
+type SecurityEvent struct {
+	ID        int32     `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	EventType string    `json:"event_type"`
+	Details   string    `json:"details,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -11,9 +11,13 @@ type User struct {
 	Name         string    `json:"name" db:"name"`
 	Email        string    `json:"email" db:"email"`
 	PasswordHash string    `json:"-" db:"password_hash"`
-	Coins        int       `json:"coins" db:"coins"`
-	CreatedAt    time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+	Coins        int       `json:"coins" db:"coins"` // settled balance, including held coins
+	// AvailableCoins is the settled balance minus coins reserved by active holds
+	AvailableCoins int       `json:"available_coins" db:"-"`
+	ReferralCode   string    `json:"referral_code" db:"referral_code"`
+	IsAdmin        bool      `json:"is_admin" db:"is_admin"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
 }
 
 type CreateUserRequest struct {
@@ -21,6 +25,8 @@ type CreateUserRequest struct {
 	Email    string `json:"email" validate:"required,email,max=255"`
 	Password string `json:"password" validate:"required,min=8"`
 	Coins    int    `json:"coins,omitempty"`
+	// ReferralCode is the code of the user who invited this one, if any
+	ReferralCode string `json:"referral_code,omitempty" validate:"omitempty,max=16"`
 }
 
 type UpdateUserRequest struct {
@@ -30,21 +36,27 @@ type UpdateUserRequest struct {
 }
 
 type UserResponse struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	Email     string    `json:"email"`
-	Coins     int       `json:"coins"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	Email          string    `json:"email"`
+	Coins          int       `json:"coins"`
+	AvailableCoins int       `json:"available_coins"`
+	ReferralCode   string    `json:"referral_code"`
+	IsAdmin        bool      `json:"is_admin"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
 
 func (u *User) ToResponse() UserResponse {
 	return UserResponse{
-		ID:        u.ID,
-		Name:      u.Name,
-		Email:     u.Email,
-		Coins:     u.Coins,
-		CreatedAt: u.CreatedAt,
-		UpdatedAt: u.UpdatedAt,
+		ID:             u.ID,
+		Name:           u.Name,
+		Email:          u.Email,
+		Coins:          u.Coins,
+		AvailableCoins: u.AvailableCoins,
+		ReferralCode:   u.ReferralCode,
+		IsAdmin:        u.IsAdmin,
+		CreatedAt:      u.CreatedAt,
+		UpdatedAt:      u.UpdatedAt,
 	}
 }
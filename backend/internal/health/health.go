@@ -0,0 +1,130 @@
+// Package health runs the readiness checks behind /readyz. Each check reports
+// its own status and details so an operator can see which dependency is
+// holding the instance out of rotation.
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// Result is the outcome of one check
+type Result struct {
+	Status  string         `json:"status"`
+	Error   string         `json:"error,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// Report is the outcome of every check; Status is ok only if all of them are
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+// Check inspects one dependency
+type Check func(ctx context.Context) Result
+
+// Readiness runs the named checks and fails once the service starts draining
+type Readiness struct {
+	checks   map[string]Check
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+// NewReadiness returns a Readiness running checks, each bounded by timeout
+func NewReadiness(checks map[string]Check, timeout time.Duration) *Readiness {
+	return &Readiness{checks: checks, timeout: timeout}
+}
+
+// Drain makes every following report fail, so the orchestrator stops sending
+// traffic while in-flight requests finish
+func (r *Readiness) Drain() {
+	r.draining.Store(true)
+}
+
+// Check runs every check and returns the combined report
+func (r *Readiness) Check(ctx context.Context) Report {
+	report := Report{Status: StatusOK, Checks: make(map[string]Result, len(r.checks)+1)}
+
+	if r.draining.Load() {
+		report.Checks["shutdown"] = Result{Status: StatusFail, Error: "shutting down"}
+	}
+
+	for name, check := range r.checks {
+		checkCtx, cancel := context.WithTimeout(ctx, r.timeout)
+		report.Checks[name] = check(checkCtx)
+		cancel()
+	}
+
+	for _, result := range report.Checks {
+		if result.Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+// PingCheck fails when ping does
+func PingCheck(ping func(ctx context.Context) error) Check {
+	return func(ctx context.Context) Result {
+		start := time.Now()
+		err := ping(ctx)
+		details := map[string]any{"latency_ms": float64(time.Since(start).Microseconds()) / 1000}
+		if err != nil {
+			return Result{Status: StatusFail, Error: err.Error(), Details: details}
+		}
+		return Result{Status: StatusOK, Details: details}
+	}
+}
+
+// MigrationCheck fails unless the database is cleanly at the expected version
+func MigrationCheck(version func(ctx context.Context) (uint, bool, error), expected uint) Check {
+	return func(ctx context.Context) Result {
+		current, dirty, err := version(ctx)
+		if err != nil {
+			return Result{Status: StatusFail, Error: err.Error()}
+		}
+
+		details := map[string]any{"version": current, "expected": expected, "dirty": dirty}
+		switch {
+		case dirty:
+			return Result{Status: StatusFail, Error: "last migration failed", Details: details}
+		case current != expected:
+			return Result{Status: StatusFail, Error: fmt.Sprintf("schema is at version %d, want %d", current, expected), Details: details}
+		}
+		return Result{Status: StatusOK, Details: details}
+	}
+}
+
+// PoolCheck reports how much of the pool is in use. It never fails: a busy
+// pool is a reason to scale out, and taking the instance out of rotation
+// would only push its load onto the others.
+func PoolCheck(pool *pgxpool.Pool) Check {
+	return func(context.Context) Result {
+		stat := pool.Stat()
+		return poolResult(stat.AcquiredConns(), stat.IdleConns(), stat.MaxConns())
+	}
+}
+
+func poolResult(acquired, idle, maxConns int32) Result {
+	saturation := 0.0
+	if maxConns > 0 {
+		saturation = float64(acquired) / float64(maxConns)
+	}
+
+	return Result{Status: StatusOK, Details: map[string]any{
+		"acquired":   acquired,
+		"idle":       idle,
+		"max":        maxConns,
+		"saturation": saturation,
+	}}
+}
@@ -0,0 +1,93 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ok(context.Context) Result { return Result{Status: StatusOK} }
+
+func TestReadiness_AllChecksPass(t *testing.T) {
+	r := NewReadiness(map[string]Check{"database": ok, "pool": ok}, time.Second)
+
+	report := r.Check(context.Background())
+
+	assert.Equal(t, StatusOK, report.Status)
+	assert.Len(t, report.Checks, 2)
+}
+
+func TestReadiness_OneCheckFails(t *testing.T) {
+	r := NewReadiness(map[string]Check{
+		"database": PingCheck(func(context.Context) error { return errors.New("connection refused") }),
+		"pool":     ok,
+	}, time.Second)
+
+	report := r.Check(context.Background())
+
+	assert.Equal(t, StatusFail, report.Status)
+	assert.Equal(t, StatusFail, report.Checks["database"].Status)
+	assert.Equal(t, "connection refused", report.Checks["database"].Error)
+	assert.Equal(t, StatusOK, report.Checks["pool"].Status)
+}
+
+func TestReadiness_FailsWhileDraining(t *testing.T) {
+	r := NewReadiness(map[string]Check{"database": ok}, time.Second)
+	r.Drain()
+
+	report := r.Check(context.Background())
+
+	assert.Equal(t, StatusFail, report.Status)
+	assert.Equal(t, StatusFail, report.Checks["shutdown"].Status)
+}
+
+func TestReadiness_BoundsEachCheck(t *testing.T) {
+	r := NewReadiness(map[string]Check{
+		"database": PingCheck(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+	}, 10*time.Millisecond)
+
+	report := r.Check(context.Background())
+
+	assert.Equal(t, StatusFail, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["database"].Error)
+}
+
+func TestMigrationCheck(t *testing.T) {
+	version := func(current uint, dirty bool, err error) func(context.Context) (uint, bool, error) {
+		return func(context.Context) (uint, bool, error) { return current, dirty, err }
+	}
+
+	tests := []struct {
+		name    string
+		version func(context.Context) (uint, bool, error)
+		status  string
+		error   string
+	}{
+		{"current", version(25, false, nil), StatusOK, ""},
+		{"behind", version(24, false, nil), StatusFail, "schema is at version 24, want 25"},
+		{"dirty", version(25, true, nil), StatusFail, "last migration failed"},
+		{"unreadable", version(0, false, errors.New("no table")), StatusFail, "no table"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := MigrationCheck(tt.version, 25)(context.Background())
+			assert.Equal(t, tt.status, result.Status)
+			assert.Equal(t, tt.error, result.Error)
+		})
+	}
+}
+
+func TestPoolResult(t *testing.T) {
+	result := poolResult(9, 1, 10)
+
+	assert.Equal(t, StatusOK, result.Status)
+	assert.Equal(t, 0.9, result.Details["saturation"])
+	assert.Equal(t, poolResult(0, 0, 0).Details["saturation"], 0.0)
+}
@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNew_AddsRequestFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "info")
+	require.NoError(t, err)
+
+	ctx := WithUserID(WithRequestID(context.Background(), "req-1"), "user-1")
+	logger.InfoContext(ctx, "hello", "count", 2)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "hello", record["msg"])
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, "req-1", record["request_id"])
+	assert.Equal(t, "user-1", record["user_id"])
+	assert.Equal(t, float64(2), record["count"])
+}
+
+func TestNew_AddsTraceIDs(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "info")
+	require.NoError(t, err)
+
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x02},
+	})
+	logger.InfoContext(trace.ContextWithSpanContext(context.Background(), spanCtx), "traced")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, spanCtx.TraceID().String(), record["trace_id"])
+	assert.Equal(t, spanCtx.SpanID().String(), record["span_id"])
+}
+
+func TestNew_Level(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "WARN")
+	require.NoError(t, err)
+
+	logger.Info("dropped")
+	assert.Zero(t, buf.Len())
+
+	logger.With("worker", "expiry").Warn("kept")
+	assert.Contains(t, buf.String(), `"worker":"expiry"`)
+}
+
+func TestNew_InvalidLevel(t *testing.T) {
+	_, err := New(&bytes.Buffer{}, "verbose")
+	assert.Error(t, err)
+}
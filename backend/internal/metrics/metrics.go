@@ -0,0 +1,87 @@
+// Package metrics defines the service's Prometheus metrics. The collectors are
+// package-level so any layer can record to them; NewRegistry gathers them for
+// the /metrics endpoint.
+package metrics
+
+import (
+	"backend/internal/entity"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+var (
+	// HTTPRequestDuration is labelled with the route pattern rather than the
+	// path, so ids in URLs do not create a series each
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time taken to answer HTTP requests.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	CoinsCharged = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "coins_charged_total",
+		Help: "Coins credited to users, by transaction type.",
+	}, []string{"type"})
+
+	CoinsSpent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "coins_spent_total",
+		Help: "Coins debited from users, by transaction type.",
+	}, []string{"type"})
+
+	// OrderStatusChanges counts changes made through the API; the orders
+	// gauge from NewOrderCollector covers orders however they were created
+	OrderStatusChanges = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "order_status_changes_total",
+		Help: "Orders moved into each status.",
+	}, []string{"status"})
+
+	CartAdds = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "cart_adds_total",
+		Help: "Products added to carts.",
+	})
+
+	LoginFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "login_failures_total",
+		Help: "Logins rejected for a wrong email or password.",
+	})
+)
+
+// NewRegistry returns a registry with the service's metrics, the Go runtime
+// and process collectors, extra and, when pool is not nil, the pool's
+// statistics
+func NewRegistry(pool *pgxpool.Pool, extra ...prometheus.Collector) *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		CoinsCharged,
+		CoinsSpent,
+		OrderStatusChanges,
+		CartAdds,
+		LoginFailures,
+	)
+	reg.MustRegister(extra...)
+	if pool != nil {
+		reg.MustRegister(NewPoolCollector(pool))
+	}
+	return reg
+}
+
+// ObserveCoinTransactions counts the coins moved by transactions, as charged
+// or spent depending on the sign of the amount
+func ObserveCoinTransactions(transactions ...*entity.CoinTransaction) {
+	for _, tx := range transactions {
+		if tx == nil {
+			continue
+		}
+		switch {
+		case tx.Amount > 0:
+			CoinsCharged.WithLabelValues(tx.TransactionType).Add(float64(tx.Amount))
+		case tx.Amount < 0:
+			CoinsSpent.WithLabelValues(tx.TransactionType).Add(float64(-tx.Amount))
+		}
+	}
+}
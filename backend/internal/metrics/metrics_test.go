@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend/internal/entity"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveCoinTransactions(t *testing.T) {
+	charged := testutil.ToFloat64(CoinsCharged.WithLabelValues("charge"))
+	bonus := testutil.ToFloat64(CoinsCharged.WithLabelValues("bonus"))
+	spent := testutil.ToFloat64(CoinsSpent.WithLabelValues("spend"))
+
+	ObserveCoinTransactions(
+		&entity.CoinTransaction{TransactionType: "charge", Amount: 1000},
+		&entity.CoinTransaction{TransactionType: "bonus", Amount: 100},
+		&entity.CoinTransaction{TransactionType: "spend", Amount: -300},
+		nil,
+	)
+
+	assert.Equal(t, charged+1000, testutil.ToFloat64(CoinsCharged.WithLabelValues("charge")))
+	assert.Equal(t, bonus+100, testutil.ToFloat64(CoinsCharged.WithLabelValues("bonus")))
+	assert.Equal(t, spent+300, testutil.ToFloat64(CoinsSpent.WithLabelValues("spend")))
+}
+
+func TestNewServer_ServesMetrics(t *testing.T) {
+	CartAdds.Inc()
+	server := NewServer(":0", NewRegistry(nil))
+
+	rec := httptest.NewRecorder()
+	server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.Contains(rec.Body.String(), "cart_adds_total"))
+	assert.True(t, strings.Contains(rec.Body.String(), "go_goroutines"))
+}
+
+func TestOrderCollector(t *testing.T) {
+	collector := NewOrderCollector(func(ctx context.Context) (map[string]int, error) {
+		return map[string]int{"pending": 2, "completed": 1}, nil
+	})
+
+	expected := `
+# HELP orders Orders currently in each status, however they were created.
+# TYPE orders gauge
+orders{status="completed"} 1
+orders{status="pending"} 2
+`
+	assert.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected)))
+}
+
+func TestOrderCollector_CountErrorFailsTheScrape(t *testing.T) {
+	reg := NewRegistry(nil, NewOrderCollector(func(ctx context.Context) (map[string]int, error) {
+		return nil, errors.New("database error")
+	}))
+
+	_, err := reg.Gather()
+
+	assert.ErrorContains(t, err, "database error")
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// orderCountTimeout bounds the query a scrape waits for
+const orderCountTimeout = 5 * time.Second
+
+var ordersByStatus = prometheus.NewDesc("orders",
+	"Orders currently in each status, however they were created.", []string{"status"}, nil)
+
+type orderCollector struct {
+	count func(ctx context.Context) (map[string]int, error)
+}
+
+// NewOrderCollector returns a collector reporting the orders in each status
+// at each scrape. Orders are created outside the API, so
+// order_status_changes_total cannot count them; count reads them from the
+// database instead.
+func NewOrderCollector(count func(ctx context.Context) (map[string]int, error)) prometheus.Collector {
+	return &orderCollector{count: count}
+}
+
+func (c *orderCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- ordersByStatus
+}
+
+func (c *orderCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), orderCountTimeout)
+	defer cancel()
+
+	counts, err := c.count(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(ordersByStatus, err)
+		return
+	}
+
+	for status, n := range counts {
+		ch <- prometheus.MustNewConstMetric(ordersByStatus, prometheus.GaugeValue, float64(n), status)
+	}
+}
@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	poolAcquiredConns = prometheus.NewDesc("pgxpool_acquired_conns",
+		"Connections currently checked out of the pool.", nil, nil)
+	poolIdleConns = prometheus.NewDesc("pgxpool_idle_conns",
+		"Idle connections in the pool.", nil, nil)
+	poolConstructingConns = prometheus.NewDesc("pgxpool_constructing_conns",
+		"Connections being opened.", nil, nil)
+	poolTotalConns = prometheus.NewDesc("pgxpool_total_conns",
+		"Connections in the pool, whether acquired, idle or being opened.", nil, nil)
+	poolMaxConns = prometheus.NewDesc("pgxpool_max_conns",
+		"Maximum size of the pool.", nil, nil)
+	poolAcquires = prometheus.NewDesc("pgxpool_acquires_total",
+		"Successful acquires from the pool.", nil, nil)
+	poolAcquireDuration = prometheus.NewDesc("pgxpool_acquire_duration_seconds_total",
+		"Time spent in successful acquires.", nil, nil)
+	poolCanceledAcquires = prometheus.NewDesc("pgxpool_canceled_acquires_total",
+		"Acquires canceled by their context.", nil, nil)
+	poolEmptyAcquires = prometheus.NewDesc("pgxpool_empty_acquires_total",
+		"Acquires that had to wait because the pool had no idle connection.", nil, nil)
+	poolNewConns = prometheus.NewDesc("pgxpool_new_conns_total",
+		"Connections opened by the pool.", nil, nil)
+	poolLifetimeDestroys = prometheus.NewDesc("pgxpool_max_lifetime_destroys_total",
+		"Connections closed for exceeding their maximum lifetime.", nil, nil)
+	poolIdleDestroys = prometheus.NewDesc("pgxpool_max_idle_destroys_total",
+		"Connections closed for exceeding their maximum idle time.", nil, nil)
+)
+
+type poolCollector struct {
+	pool *pgxpool.Pool
+}
+
+// NewPoolCollector returns a collector reporting pool.Stat() at each scrape
+func NewPoolCollector(pool *pgxpool.Pool) prometheus.Collector {
+	return &poolCollector{pool: pool}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	gauge := func(desc *prometheus.Desc, v int32) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(v))
+	}
+	counter := func(desc *prometheus.Desc, v float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, v)
+	}
+
+	gauge(poolAcquiredConns, stat.AcquiredConns())
+	gauge(poolIdleConns, stat.IdleConns())
+	gauge(poolConstructingConns, stat.ConstructingConns())
+	gauge(poolTotalConns, stat.TotalConns())
+	gauge(poolMaxConns, stat.MaxConns())
+	counter(poolAcquires, float64(stat.AcquireCount()))
+	counter(poolAcquireDuration, stat.AcquireDuration().Seconds())
+	counter(poolCanceledAcquires, float64(stat.CanceledAcquireCount()))
+	counter(poolEmptyAcquires, float64(stat.EmptyAcquireCount()))
+	counter(poolNewConns, float64(stat.NewConnsCount()))
+	counter(poolLifetimeDestroys, float64(stat.MaxLifetimeDestroyCount()))
+	counter(poolIdleDestroys, float64(stat.MaxIdleDestroyCount()))
+}
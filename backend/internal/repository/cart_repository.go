@@ -18,10 +18,10 @@ type CartRepository interface {
 }
 
 type cartRepository struct {
-	queries *database.Queries
+	queries database.Querier
 }
 
-func NewCartRepository(queries *database.Queries) CartRepository {
+func NewCartRepository(queries database.Querier) CartRepository {
 	return &cartRepository{
 		queries: queries,
 	}
@@ -29,7 +29,7 @@ func NewCartRepository(queries *database.Queries) CartRepository {
 
 func (r *cartRepository) AddToCart(ctx context.Context, userID uuid.UUID, productID int, quantity int) error {
 	// Check if item already exists in cart
-	exists, err := r.queries.CheckCartItemExists(ctx, database.CheckCartItemExistsParams{
+	exists, err := database.QuerierFromContext(ctx, r.queries).CheckCartItemExists(ctx, database.CheckCartItemExistsParams{
 		UserID:    database.UUIDToPgtype(userID),
 		ProductID: int32(productID),
 	})
@@ -38,19 +38,19 @@ func (r *cartRepository) AddToCart(ctx context.Context, userID uuid.UUID, produc
 	}
 
 	if exists {
-		_, err = r.queries.UpdateCartItemQuantity(ctx, database.UpdateCartItemQuantityParams{
+		_, err = database.QuerierFromContext(ctx, r.queries).UpdateCartItemQuantity(ctx, database.UpdateCartItemQuantityParams{
 			UserID:    database.UUIDToPgtype(userID),
 			ProductID: int32(productID),
 			Quantity:  int32(quantity), // This should be the new total quantity, not addition
 			UpdatedAt: database.TimeToPgtype(time.Now()),
 		})
 		if err != nil {
-			return fmt.Errorf("failed to update cart item quantity: %w", err)
+			return fmt.Errorf("failed to update cart item quantity: %w", database.TranslateError(err, nil))
 		}
 		return nil
 	}
 
-	_, err = r.queries.CreateCartItem(ctx, database.CreateCartItemParams{
+	_, err = database.QuerierFromContext(ctx, r.queries).CreateCartItem(ctx, database.CreateCartItemParams{
 		UserID:    database.UUIDToPgtype(userID),
 		ProductID: int32(productID),
 		Quantity:  int32(quantity),
@@ -58,14 +58,14 @@ func (r *cartRepository) AddToCart(ctx context.Context, userID uuid.UUID, produc
 		UpdatedAt: database.TimeToPgtype(time.Now()),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to add item to cart: %w", err)
+		return fmt.Errorf("failed to add item to cart: %w", database.TranslateError(err, nil))
 	}
 
 	return nil
 }
 
 func (r *cartRepository) GetCartItems(ctx context.Context, userID uuid.UUID) ([]entity.CartItem, error) {
-	dbCartItems, err := r.queries.GetCartItemsByUser(ctx, database.UUIDToPgtype(userID))
+	dbCartItems, err := database.QuerierFromContext(ctx, r.queries).GetCartItemsByUser(ctx, database.UUIDToPgtype(userID))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cart items: %w", err)
@@ -80,23 +80,23 @@ func (r *cartRepository) GetCartItems(ctx context.Context, userID uuid.UUID) ([]
 }
 
 func (r *cartRepository) RemoveFromCart(ctx context.Context, userID uuid.UUID, productID int) error {
-	err := r.queries.DeleteCartItem(ctx, database.DeleteCartItemParams{
+	err := database.QuerierFromContext(ctx, r.queries).DeleteCartItem(ctx, database.DeleteCartItemParams{
 		UserID:    database.UUIDToPgtype(userID),
 		ProductID: int32(productID),
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to remove item from cart: %w", err)
+		return fmt.Errorf("failed to remove item from cart: %w", database.TranslateError(err, nil))
 	}
 
 	return err
 }
 
 func (r *cartRepository) ClearCart(ctx context.Context, userID uuid.UUID) error {
-	err := r.queries.DeleteAllCartItemsByUser(ctx, database.UUIDToPgtype(userID))
+	err := database.QuerierFromContext(ctx, r.queries).DeleteAllCartItemsByUser(ctx, database.UUIDToPgtype(userID))
 
 	if err != nil {
-		return fmt.Errorf("failed to clear cart: %w", err)
+		return fmt.Errorf("failed to clear cart: %w", database.TranslateError(err, nil))
 	}
 
 	return err
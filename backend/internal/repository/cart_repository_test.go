@@ -2,83 +2,24 @@ package repository
 
 import (
 	"backend/internal/database"
-	"backend/internal/entity"
+	"backend/internal/domain"
 	"backend/mocks"
 	"context"
-	"database/sql"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
-// Test repository wrapper that uses the interface
-type testCartRepository struct {
-	queries mocks.CartQueriesInterface
-}
-
-func NewTestCartRepository(queries mocks.CartQueriesInterface) CartRepository {
-	return &testCartRepository{
-		queries: queries,
-	}
-}
-
-// Implement the cart repository methods matching your exact interface
-func (r *testCartRepository) AddToCart(ctx context.Context, userID uuid.UUID, productID int, quantity int) error {
-	// Check if item already exists
-	existingItem, err := r.queries.GetCartItem(ctx, pgtype.UUID{Bytes: userID, Valid: true}, int32(productID))
-
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		return err
-	}
-
-	if err == nil {
-		// Item exists, update quantity
-		newQuantity := existingItem.Quantity + int32(quantity)
-		return r.queries.UpdateCartItemQuantity(ctx, pgtype.UUID{Bytes: userID, Valid: true}, int32(productID), newQuantity)
-	}
-
-	// Item doesn't exist, create new
-	return r.queries.AddToCart(ctx, pgtype.UUID{Bytes: userID, Valid: true}, int32(productID), int32(quantity))
-}
-
-func (r *testCartRepository) GetCartItems(ctx context.Context, userID uuid.UUID) ([]entity.CartItem, error) {
-	dbItems, err := r.queries.GetCartItems(ctx, pgtype.UUID{Bytes: userID, Valid: true})
-	if err != nil {
-		return nil, err
-	}
-
-	var items []entity.CartItem
-	for _, dbItem := range dbItems {
-		item := entity.CartItem{
-			ID:        int(dbItem.ID),
-			UserID:    dbItem.UserID.Bytes,
-			ProductID: int(dbItem.ProductID),
-			Quantity:  int(dbItem.Quantity),
-			CreatedAt: dbItem.CreatedAt.Time,
-			UpdatedAt: dbItem.UpdatedAt.Time,
-		}
-		items = append(items, item)
-	}
-
-	return items, nil
-}
-
-func (r *testCartRepository) RemoveFromCart(ctx context.Context, userID uuid.UUID, productID int) error {
-	return r.queries.RemoveFromCart(ctx, pgtype.UUID{Bytes: userID, Valid: true}, int32(productID))
-}
-
-func (r *testCartRepository) ClearCart(ctx context.Context, userID uuid.UUID) error {
-	return r.queries.ClearCart(ctx, pgtype.UUID{Bytes: userID, Valid: true})
-}
-
 // Helper functions for tests
-func createMockDBCartItem(id, userID uuid.UUID, productID int32, quantity int32) database.CartItem {
+func createMockDBCartItem(id int32, userID uuid.UUID, productID int32, quantity int32) database.CartItem {
 	return database.CartItem{
-		ID:        int32(time.Now().UnixNano() % 1000000), // Use a simple int32 ID
+		ID:        id,
 		UserID:    pgtype.UUID{Bytes: userID, Valid: true},
 		ProductID: productID,
 		Quantity:  quantity,
@@ -87,69 +28,79 @@ func createMockDBCartItem(id, userID uuid.UUID, productID int32, quantity int32)
 	}
 }
 
-func setupCartTestRepository() (CartRepository, *mocks.MockCartQueries) {
-	mockQueries := new(mocks.MockCartQueries)
-	repo := NewTestCartRepository(mockQueries)
+func setupCartTestRepository(t *testing.T) (CartRepository, *mocks.MockQuerier) {
+	mockQueries := mocks.NewMockQuerier(t)
+	repo := NewCartRepository(mockQueries)
 	return repo, mockQueries
 }
 
+func expectCartItemExists(mockQueries *mocks.MockQuerier, userID uuid.UUID, productID int, exists bool) *mocks.MockQuerier_CheckCartItemExists_Call {
+	return mockQueries.EXPECT().CheckCartItemExists(mock.Anything, database.CheckCartItemExistsParams{
+		UserID:    pgtype.UUID{Bytes: userID, Valid: true},
+		ProductID: int32(productID),
+	}).Return(exists, nil)
+}
+
+// createCartItemParams matches the item to create, whatever its timestamps
+func createCartItemParams(userID uuid.UUID, productID, quantity int) interface{} {
+	return mock.MatchedBy(func(arg database.CreateCartItemParams) bool {
+		return arg.UserID == pgtype.UUID{Bytes: userID, Valid: true} &&
+			arg.ProductID == int32(productID) &&
+			arg.Quantity == int32(quantity) &&
+			arg.CreatedAt.Valid && arg.UpdatedAt.Valid
+	})
+}
+
+func updateCartItemQuantityParams(userID uuid.UUID, productID, quantity int) interface{} {
+	return mock.MatchedBy(func(arg database.UpdateCartItemQuantityParams) bool {
+		return arg.UserID == pgtype.UUID{Bytes: userID, Valid: true} &&
+			arg.ProductID == int32(productID) &&
+			arg.Quantity == int32(quantity) &&
+			arg.UpdatedAt.Valid
+	})
+}
+
 func TestAddToCart_NewItem(t *testing.T) {
-	repo, mockQueries := setupCartTestRepository()
+	repo, mockQueries := setupCartTestRepository(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
 	productID := 100
 	quantity := 2
 
-	pgUUID := pgtype.UUID{Bytes: userID, Valid: true}
-
-	// Mock GetCartItem to return sql.ErrNoRows (item doesn't exist)
-	mockQueries.On("GetCartItem", ctx, pgUUID, int32(productID)).
-		Return(database.CartItem{}, sql.ErrNoRows)
-
-	// Mock AddToCart
-	mockQueries.On("AddToCart", ctx, pgUUID, int32(productID), int32(quantity)).
-		Return(nil)
+	expectCartItemExists(mockQueries, userID, productID, false)
+	mockQueries.EXPECT().CreateCartItem(mock.Anything, createCartItemParams(userID, productID, quantity)).
+		Return(createMockDBCartItem(1, userID, int32(productID), int32(quantity)), nil)
 
 	// Execute
 	err := repo.AddToCart(ctx, userID, productID, quantity)
 
 	// Assert
 	assert.NoError(t, err)
-	mockQueries.AssertExpectations(t)
 }
 
 func TestAddToCart_ExistingItem(t *testing.T) {
-	repo, mockQueries := setupCartTestRepository()
+	repo, mockQueries := setupCartTestRepository(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
 	productID := 100
-	existingQuantity := int32(3)
-	additionalQuantity := 2
-	expectedTotalQuantity := existingQuantity + int32(additionalQuantity)
+	newQuantity := 5
 
-	pgUUID := pgtype.UUID{Bytes: userID, Valid: true}
-
-	// Mock GetCartItem to return existing item
-	existingItem := createMockDBCartItem(uuid.New(), userID, int32(productID), existingQuantity)
-	mockQueries.On("GetCartItem", ctx, pgUUID, int32(productID)).
-		Return(existingItem, nil)
-
-	// Mock UpdateCartItemQuantity
-	mockQueries.On("UpdateCartItemQuantity", ctx, pgUUID, int32(productID), expectedTotalQuantity).
-		Return(nil)
+	// An existing item takes the given quantity as its new total
+	expectCartItemExists(mockQueries, userID, productID, true)
+	mockQueries.EXPECT().UpdateCartItemQuantity(mock.Anything, updateCartItemQuantityParams(userID, productID, newQuantity)).
+		Return(createMockDBCartItem(1, userID, int32(productID), int32(newQuantity)), nil)
 
 	// Execute
-	err := repo.AddToCart(ctx, userID, productID, additionalQuantity)
+	err := repo.AddToCart(ctx, userID, productID, newQuantity)
 
 	// Assert
 	assert.NoError(t, err)
-	mockQueries.AssertExpectations(t)
 }
 
 func TestGetCartItems_Success(t *testing.T) {
-	repo, mockQueries := setupCartTestRepository()
+	repo, mockQueries := setupCartTestRepository(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -157,11 +108,11 @@ func TestGetCartItems_Success(t *testing.T) {
 
 	// Create mock cart items
 	dbItems := []database.CartItem{
-		createMockDBCartItem(uuid.New(), userID, 100, 2),
-		createMockDBCartItem(uuid.New(), userID, 101, 3),
+		createMockDBCartItem(1, userID, 100, 2),
+		createMockDBCartItem(2, userID, 101, 3),
 	}
 
-	mockQueries.On("GetCartItems", ctx, pgUUID).
+	mockQueries.EXPECT().GetCartItemsByUser(ctx, pgUUID).
 		Return(dbItems, nil)
 
 	// Execute
@@ -170,23 +121,23 @@ func TestGetCartItems_Success(t *testing.T) {
 	// Assert
 	assert.NoError(t, err)
 	assert.Len(t, items, 2)
+	assert.Equal(t, 1, items[0].ID)
+	assert.Equal(t, userID, items[0].UserID)
 	assert.Equal(t, 100, items[0].ProductID)
 	assert.Equal(t, 2, items[0].Quantity)
 	assert.Equal(t, 101, items[1].ProductID)
 	assert.Equal(t, 3, items[1].Quantity)
-
-	mockQueries.AssertExpectations(t)
 }
 
 func TestGetCartItems_EmptyCart(t *testing.T) {
-	repo, mockQueries := setupCartTestRepository()
+	repo, mockQueries := setupCartTestRepository(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
 	pgUUID := pgtype.UUID{Bytes: userID, Valid: true}
 
 	// Mock empty result
-	mockQueries.On("GetCartItems", ctx, pgUUID).
+	mockQueries.EXPECT().GetCartItemsByUser(ctx, pgUUID).
 		Return([]database.CartItem{}, nil)
 
 	// Execute
@@ -195,59 +146,54 @@ func TestGetCartItems_EmptyCart(t *testing.T) {
 	// Assert
 	assert.NoError(t, err)
 	assert.Len(t, items, 0)
-
-	mockQueries.AssertExpectations(t)
 }
 
 func TestRemoveFromCart_Success(t *testing.T) {
-	repo, mockQueries := setupCartTestRepository()
+	repo, mockQueries := setupCartTestRepository(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
 	productID := 100
 
-	pgUUID := pgtype.UUID{Bytes: userID, Valid: true}
-
-	mockQueries.On("RemoveFromCart", ctx, pgUUID, int32(productID)).
-		Return(nil)
+	mockQueries.EXPECT().DeleteCartItem(ctx, database.DeleteCartItemParams{
+		UserID:    pgtype.UUID{Bytes: userID, Valid: true},
+		ProductID: int32(productID),
+	}).Return(nil)
 
 	// Execute
 	err := repo.RemoveFromCart(ctx, userID, productID)
 
 	// Assert
 	assert.NoError(t, err)
-	mockQueries.AssertExpectations(t)
 }
 
-func TestRemoveFromCart_ItemNotFound(t *testing.T) {
-	repo, mockQueries := setupCartTestRepository()
+func TestRemoveFromCart_DatabaseError(t *testing.T) {
+	repo, mockQueries := setupCartTestRepository(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
 	productID := 100
 
-	pgUUID := pgtype.UUID{Bytes: userID, Valid: true}
-
-	mockQueries.On("RemoveFromCart", ctx, pgUUID, int32(productID)).
-		Return(sql.ErrNoRows)
+	mockQueries.EXPECT().DeleteCartItem(ctx, database.DeleteCartItemParams{
+		UserID:    pgtype.UUID{Bytes: userID, Valid: true},
+		ProductID: int32(productID),
+	}).Return(errors.New("database timeout"))
 
 	// Execute
 	err := repo.RemoveFromCart(ctx, userID, productID)
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, sql.ErrNoRows, err)
-	mockQueries.AssertExpectations(t)
+	assert.EqualError(t, err, "failed to remove item from cart: database timeout")
 }
 
 func TestClearCart_Success(t *testing.T) {
-	repo, mockQueries := setupCartTestRepository()
+	repo, mockQueries := setupCartTestRepository(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
 	pgUUID := pgtype.UUID{Bytes: userID, Valid: true}
 
-	mockQueries.On("ClearCart", ctx, pgUUID).
+	mockQueries.EXPECT().DeleteAllCartItemsByUser(ctx, pgUUID).
 		Return(nil)
 
 	// Execute
@@ -255,75 +201,83 @@ func TestClearCart_Success(t *testing.T) {
 
 	// Assert
 	assert.NoError(t, err)
-	mockQueries.AssertExpectations(t)
 }
 
 func TestAddToCart_DatabaseError(t *testing.T) {
-	repo, mockQueries := setupCartTestRepository()
+	repo, mockQueries := setupCartTestRepository(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
 	productID := 100
 	quantity := 2
 
-	pgUUID := pgtype.UUID{Bytes: userID, Valid: true}
-
-	// Mock GetCartItem to return database error
-	mockQueries.On("GetCartItem", ctx, pgUUID, int32(productID)).
-		Return(database.CartItem{}, errors.New("database connection failed"))
+	// Mock CheckCartItemExists to return database error
+	mockQueries.EXPECT().CheckCartItemExists(mock.Anything, mock.Anything).
+		Return(false, errors.New("database connection failed"))
 
 	// Execute
 	err := repo.AddToCart(ctx, userID, productID, quantity)
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, "database connection failed", err.Error())
-	mockQueries.AssertExpectations(t)
+	assert.EqualError(t, err, "failed to check if cart item exists: database connection failed")
+}
+
+func TestAddToCart_UnknownProduct(t *testing.T) {
+	repo, mockQueries := setupCartTestRepository(t)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	productID := 999
+
+	expectCartItemExists(mockQueries, userID, productID, false)
+	mockQueries.EXPECT().CreateCartItem(mock.Anything, createCartItemParams(userID, productID, 1)).
+		Return(database.CartItem{}, &pgconn.PgError{Code: "23503", ConstraintName: "cart_items_product_id_fkey"})
+
+	// Execute
+	err := repo.AddToCart(ctx, userID, productID, 1)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrProductNotFound)
 }
 
 func TestGetCartItems_DatabaseError(t *testing.T) {
-	repo, mockQueries := setupCartTestRepository()
+	repo, mockQueries := setupCartTestRepository(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
 	pgUUID := pgtype.UUID{Bytes: userID, Valid: true}
 
-	mockQueries.On("GetCartItems", ctx, pgUUID).
-		Return([]database.CartItem{}, errors.New("database timeout"))
+	mockQueries.EXPECT().GetCartItemsByUser(ctx, pgUUID).
+		Return(nil, errors.New("database timeout"))
 
 	// Execute
 	items, err := repo.GetCartItems(ctx, userID)
 
 	// Assert
-	assert.Error(t, err)
 	assert.Nil(t, items)
-	assert.Equal(t, "database timeout", err.Error())
-
-	mockQueries.AssertExpectations(t)
+	assert.EqualError(t, err, "failed to get cart items: database timeout")
 }
 
 func TestClearCart_DatabaseError(t *testing.T) {
-	repo, mockQueries := setupCartTestRepository()
+	repo, mockQueries := setupCartTestRepository(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
 	pgUUID := pgtype.UUID{Bytes: userID, Valid: true}
 
-	mockQueries.On("ClearCart", ctx, pgUUID).
-		Return(errors.New("foreign key constraint violation"))
+	mockQueries.EXPECT().DeleteAllCartItemsByUser(ctx, pgUUID).
+		Return(errors.New("connection reset"))
 
 	// Execute
 	err := repo.ClearCart(ctx, userID)
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, "foreign key constraint violation", err.Error())
-	mockQueries.AssertExpectations(t)
+	assert.EqualError(t, err, "failed to clear cart: connection reset")
 }
 
 // Integration-style test with multiple operations
 func TestCartWorkflow_AddAndRemove(t *testing.T) {
-	repo, mockQueries := setupCartTestRepository()
+	repo, mockQueries := setupCartTestRepository(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -333,28 +287,27 @@ func TestCartWorkflow_AddAndRemove(t *testing.T) {
 	pgUUID := pgtype.UUID{Bytes: userID, Valid: true}
 
 	// Step 1: Add first item
-	mockQueries.On("GetCartItem", ctx, pgUUID, int32(productID1)).
-		Return(database.CartItem{}, sql.ErrNoRows)
-	mockQueries.On("AddToCart", ctx, pgUUID, int32(productID1), int32(2)).
-		Return(nil)
+	expectCartItemExists(mockQueries, userID, productID1, false)
+	mockQueries.EXPECT().CreateCartItem(mock.Anything, createCartItemParams(userID, productID1, 2)).
+		Return(createMockDBCartItem(1, userID, int32(productID1), 2), nil)
 
 	err := repo.AddToCart(ctx, userID, productID1, 2)
 	assert.NoError(t, err)
 
 	// Step 2: Add second item
-	mockQueries.On("GetCartItem", ctx, pgUUID, int32(productID2)).
-		Return(database.CartItem{}, sql.ErrNoRows)
-	mockQueries.On("AddToCart", ctx, pgUUID, int32(productID2), int32(3)).
-		Return(nil)
+	expectCartItemExists(mockQueries, userID, productID2, false)
+	mockQueries.EXPECT().CreateCartItem(mock.Anything, createCartItemParams(userID, productID2, 3)).
+		Return(createMockDBCartItem(2, userID, int32(productID2), 3), nil)
 
 	err = repo.AddToCart(ctx, userID, productID2, 3)
 	assert.NoError(t, err)
 
 	// Step 3: Get cart items
-	cartItem1 := createMockDBCartItem(uuid.New(), userID, int32(productID1), 2)
-	cartItem2 := createMockDBCartItem(uuid.New(), userID, int32(productID2), 3)
-	dbItems := []database.CartItem{cartItem1, cartItem2}
-	mockQueries.On("GetCartItems", ctx, pgUUID).
+	dbItems := []database.CartItem{
+		createMockDBCartItem(1, userID, int32(productID1), 2),
+		createMockDBCartItem(2, userID, int32(productID2), 3),
+	}
+	mockQueries.EXPECT().GetCartItemsByUser(ctx, pgUUID).
 		Return(dbItems, nil)
 
 	items, err := repo.GetCartItems(ctx, userID)
@@ -362,123 +315,62 @@ func TestCartWorkflow_AddAndRemove(t *testing.T) {
 	assert.Len(t, items, 2)
 
 	// Step 4: Remove first item
-	mockQueries.On("RemoveFromCart", ctx, pgUUID, int32(productID1)).
+	mockQueries.EXPECT().DeleteCartItem(ctx, database.DeleteCartItemParams{UserID: pgUUID, ProductID: int32(productID1)}).
 		Return(nil)
 
 	err = repo.RemoveFromCart(ctx, userID, productID1)
 	assert.NoError(t, err)
 
 	// Step 5: Clear cart
-	mockQueries.On("ClearCart", ctx, pgUUID).
+	mockQueries.EXPECT().DeleteAllCartItemsByUser(ctx, pgUUID).
 		Return(nil)
 
 	err = repo.ClearCart(ctx, userID)
 	assert.NoError(t, err)
-
-	mockQueries.AssertExpectations(t)
-}
-
-func TestAddToCart_ZeroQuantity(t *testing.T) {
-	repo, mockQueries := setupCartTestRepository()
-	ctx := context.Background()
-
-	userID := uuid.New()
-	productID := 100
-	quantity := 0
-
-	pgUUID := pgtype.UUID{Bytes: userID, Valid: true}
-
-	// Mock GetCartItem to return sql.ErrNoRows (item doesn't exist)
-	mockQueries.On("GetCartItem", ctx, pgUUID, int32(productID)).
-		Return(database.CartItem{}, sql.ErrNoRows)
-
-	// Mock AddToCart with zero quantity
-	mockQueries.On("AddToCart", ctx, pgUUID, int32(productID), int32(quantity)).
-		Return(nil)
-
-	// Execute
-	err := repo.AddToCart(ctx, userID, productID, quantity)
-
-	// Assert
-	assert.NoError(t, err)
-	mockQueries.AssertExpectations(t)
 }
 
 func TestAddToCart_ExistingItemTwice(t *testing.T) {
-	repo, mockQueries := setupCartTestRepository()
+	repo, mockQueries := setupCartTestRepository(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
 	productID := 100
 
-	pgUUID := pgtype.UUID{Bytes: userID, Valid: true}
-
 	// First add - item doesn't exist
-	mockQueries.On("GetCartItem", ctx, pgUUID, int32(productID)).
-		Return(database.CartItem{}, sql.ErrNoRows).Once()
-	mockQueries.On("AddToCart", ctx, pgUUID, int32(productID), int32(2)).
-		Return(nil)
+	expectCartItemExists(mockQueries, userID, productID, false).Once()
+	mockQueries.EXPECT().CreateCartItem(mock.Anything, createCartItemParams(userID, productID, 2)).
+		Return(createMockDBCartItem(1, userID, int32(productID), 2), nil)
 
 	err := repo.AddToCart(ctx, userID, productID, 2)
 	assert.NoError(t, err)
 
 	// Second add - item exists
-	cartItem := createMockDBCartItem(uuid.New(), userID, int32(productID), 2)
-	mockQueries.On("GetCartItem", ctx, pgUUID, int32(productID)).
-		Return(cartItem, nil).Once()
-	mockQueries.On("UpdateCartItemQuantity", ctx, pgUUID, int32(productID), int32(5)).
-		Return(nil)
+	expectCartItemExists(mockQueries, userID, productID, true).Once()
+	mockQueries.EXPECT().UpdateCartItemQuantity(mock.Anything, updateCartItemQuantityParams(userID, productID, 3)).
+		Return(createMockDBCartItem(1, userID, int32(productID), 3), nil)
 
 	err = repo.AddToCart(ctx, userID, productID, 3)
 	assert.NoError(t, err)
-
-	mockQueries.AssertExpectations(t)
 }
 
 // Test edge cases
-func TestAddToCart_NegativeQuantity(t *testing.T) {
-	repo, mockQueries := setupCartTestRepository()
-	ctx := context.Background()
-
-	userID := uuid.New()
-	productID := 100
-	quantity := -1
-
-	pgUUID := pgtype.UUID{Bytes: userID, Valid: true}
-
-	// Mock GetCartItem to return sql.ErrNoRows (item doesn't exist)
-	mockQueries.On("GetCartItem", ctx, pgUUID, int32(productID)).
-		Return(database.CartItem{}, sql.ErrNoRows)
-
-	// Mock AddToCart with negative quantity
-	mockQueries.On("AddToCart", ctx, pgUUID, int32(productID), int32(quantity)).
-		Return(nil)
-
-	// Execute
-	err := repo.AddToCart(ctx, userID, productID, quantity)
-
-	// Assert
-	assert.NoError(t, err)
-	mockQueries.AssertExpectations(t)
-}
-
-func TestRemoveFromCart_EmptyCart(t *testing.T) {
-	repo, mockQueries := setupCartTestRepository()
+func TestAddToCart_NonPositiveQuantity(t *testing.T) {
+	repo, mockQueries := setupCartTestRepository(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
 	productID := 100
 
-	pgUUID := pgtype.UUID{Bytes: userID, Valid: true}
-
-	mockQueries.On("RemoveFromCart", ctx, pgUUID, int32(productID)).
-		Return(errors.New("cart is empty"))
+	// The cart_items CHECK constraint rejects the quantity
+	expectCartItemExists(mockQueries, userID, productID, false)
+	mockQueries.EXPECT().CreateCartItem(mock.Anything, createCartItemParams(userID, productID, -1)).
+		Return(database.CartItem{}, &pgconn.PgError{Code: "23514", ConstraintName: "cart_items_quantity_check"})
 
 	// Execute
-	err := repo.RemoveFromCart(ctx, userID, productID)
+	err := repo.AddToCart(ctx, userID, productID, -1)
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, "cart is empty", err.Error())
-	mockQueries.AssertExpectations(t)
+	var pgErr *pgconn.PgError
+	assert.ErrorAs(t, err, &pgErr)
+	assert.ErrorIs(t, err, domain.ErrConstraintViolation)
 }
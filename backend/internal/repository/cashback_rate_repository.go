@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"backend/internal/database"
+	"backend/internal/domain"
+	"backend/internal/entity"
+	"context"
+	"fmt"
+)
+
+type CashbackRateRepository interface {
+	GetCashbackRates(ctx context.Context) ([]*entity.CategoryCashbackRate, error)
+	SetCashbackRate(ctx context.Context, categoryID int32, ratePercent float64) (*entity.CategoryCashbackRate, error)
+	DeleteCashbackRate(ctx context.Context, categoryID int32) error
+}
+
+type cashbackRateRepository struct {
+	queries database.Querier
+}
+
+func NewCashbackRateRepository(queries database.Querier) CashbackRateRepository {
+	return &cashbackRateRepository{
+		queries: queries,
+	}
+}
+
+func (r *cashbackRateRepository) GetCashbackRates(ctx context.Context) ([]*entity.CategoryCashbackRate, error) {
+	dbRates, err := database.QuerierFromContext(ctx, r.queries).ListCategoryCashbackRates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cashback rates: %w", err)
+	}
+
+	rates := make([]*entity.CategoryCashbackRate, len(dbRates))
+	for i, dbRate := range dbRates {
+		rates[i] = dbCashbackRateToEntity(dbRate)
+	}
+
+	return rates, nil
+}
+
+func (r *cashbackRateRepository) SetCashbackRate(ctx context.Context, categoryID int32, ratePercent float64) (*entity.CategoryCashbackRate, error) {
+	dbRate, err := database.QuerierFromContext(ctx, r.queries).UpsertCategoryCashbackRate(ctx, database.UpsertCategoryCashbackRateParams{
+		CategoryID:  categoryID,
+		RatePercent: database.Float64ToNumeric(ratePercent),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set cashback rate: %w", database.TranslateError(err, nil))
+	}
+
+	return dbCashbackRateToEntity(dbRate), nil
+}
+
+func (r *cashbackRateRepository) DeleteCashbackRate(ctx context.Context, categoryID int32) error {
+	rows, err := database.QuerierFromContext(ctx, r.queries).DeleteCategoryCashbackRate(ctx, categoryID)
+	if err != nil {
+		return fmt.Errorf("failed to delete cashback rate: %w", database.TranslateError(err, nil))
+	}
+	if rows == 0 {
+		return domain.ErrCashbackRateNotFound
+	}
+
+	return nil
+}
+
+func dbCashbackRateToEntity(dbRate database.CategoryCashbackRate) *entity.CategoryCashbackRate {
+	return &entity.CategoryCashbackRate{
+		CategoryID:  dbRate.CategoryID,
+		RatePercent: database.NumericToFloat64(dbRate.RatePercent),
+		UpdatedAt:   dbRate.UpdatedAt.Time,
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"backend/internal/database"
+	"backend/internal/domain"
 	"backend/internal/entity"
 )
 
@@ -14,17 +15,17 @@ type CategoryRepository interface {
 }
 
 type categoryRepository struct {
-	queries *database.Queries
+	queries database.Querier
 }
 
-func NewCategoryRepository(queries *database.Queries) CategoryRepository {
+func NewCategoryRepository(queries database.Querier) CategoryRepository {
 	return &categoryRepository{
 		queries: queries,
 	}
 }
 
 func (r *categoryRepository) GetAllCategories(ctx context.Context) ([]entity.Category, error) {
-	dbCategories, err := r.queries.GetAllCategories(ctx)
+	dbCategories, err := database.QuerierFromContext(ctx, r.queries).GetAllCategories(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get categories: %w", err)
 	}
@@ -41,9 +42,9 @@ func (r *categoryRepository) GetAllCategories(ctx context.Context) ([]entity.Cat
 }
 
 func (r *categoryRepository) GetCategoryByID(ctx context.Context, id int) (*entity.Category, error) {
-	dbCategory, err := r.queries.GetCategoryByID(ctx, int32(id))
+	dbCategory, err := database.QuerierFromContext(ctx, r.queries).GetCategoryByID(ctx, int32(id))
 	if err != nil {
-		return nil, fmt.Errorf("category not found: %w", err)
+		return nil, fmt.Errorf("failed to get category: %w", database.TranslateError(err, domain.ErrCategoryNotFound))
 	}
 
 	category := &entity.Category{
@@ -0,0 +1,301 @@
+package repository
+
+import (
+	"backend/internal/database"
+	"backend/internal/domain"
+	"backend/internal/entity"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CoinHoldRepository interface {
+	CreateCoinHold(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32, expiresAt time.Time) (*entity.CoinHold, error)
+	GetCoinHoldsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*entity.CoinHold, error)
+	GetActiveCoinHoldsByOrderID(ctx context.Context, orderID int32) ([]*entity.CoinHold, error)
+	CaptureCoinHold(ctx context.Context, userID uuid.UUID, holdID int32) (*entity.CoinHold, *entity.CoinTransaction, error)
+	ReleaseCoinHold(ctx context.Context, userID uuid.UUID, holdID int32) (*entity.CoinHold, error)
+	ExpireCoinHolds(ctx context.Context, now time.Time, limit int32) (int, error)
+}
+
+type coinHoldRepository struct {
+	queries   database.Querier
+	txManager database.TxManager
+}
+
+func NewCoinHoldRepository(queries database.Querier, txManager database.TxManager) CoinHoldRepository {
+	return &coinHoldRepository{
+		queries:   queries,
+		txManager: txManager,
+	}
+}
+
+// CreateCoinHold reserves amount of the user's spendable coins until expiresAt
+func (r *coinHoldRepository) CreateCoinHold(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32, expiresAt time.Time) (*entity.CoinHold, error) {
+	var dbHold database.CoinHold
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		txQueries := database.QuerierFromContext(ctx, r.queries)
+
+		user, err := txQueries.GetUserByIDForUpdate(ctx, database.UUIDToPgtype(userID))
+		if err != nil {
+			return fmt.Errorf("failed to lock user: %w", database.TranslateError(err, domain.ErrUserNotFound))
+		}
+
+		spendable, err := spendableCoins(ctx, txQueries, user, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if spendable < amount {
+			return domain.Errorf(domain.ErrInsufficientCoins, "have %d, need %d", spendable, amount)
+		}
+
+		if _, err := txQueries.UpdateUserHeldCoins(ctx, database.UpdateUserHeldCoinsParams{
+			ID:        user.ID,
+			HeldCoins: int32(amount),
+		}); err != nil {
+			return fmt.Errorf("failed to update held coins: %w", database.TranslateError(err, nil))
+		}
+
+		var orderIDPgtype pgtype.Int4
+		if orderID != nil {
+			orderIDPgtype = database.Int32ToPgtype(*orderID)
+		}
+
+		dbHold, err = txQueries.CreateCoinHold(ctx, database.CreateCoinHoldParams{
+			UserID:      user.ID,
+			Amount:      int32(amount),
+			Description: database.StringToPgtype(description),
+			OrderID:     orderIDPgtype,
+			ExpiresAt:   database.TimeToPgtype(expiresAt),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create coin hold: %w", database.TranslateError(err, nil))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbCoinHoldToEntity(dbHold), nil
+}
+
+func (r *coinHoldRepository) GetCoinHoldsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*entity.CoinHold, error) {
+	dbHolds, err := database.QuerierFromContext(ctx, r.queries).ListCoinHoldsByUserID(ctx, database.ListCoinHoldsByUserIDParams{
+		UserID: database.UUIDToPgtype(userID),
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get coin holds: %w", err)
+	}
+
+	holds := make([]*entity.CoinHold, len(dbHolds))
+	for i, dbHold := range dbHolds {
+		holds[i] = dbCoinHoldToEntity(dbHold)
+	}
+
+	return holds, nil
+}
+
+// GetActiveCoinHoldsByOrderID returns the holds still reserving coins for the order
+func (r *coinHoldRepository) GetActiveCoinHoldsByOrderID(ctx context.Context, orderID int32) ([]*entity.CoinHold, error) {
+	dbHolds, err := database.QuerierFromContext(ctx, r.queries).ListActiveCoinHoldsByOrderID(ctx, database.Int32ToPgtype(orderID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order coin holds: %w", err)
+	}
+
+	holds := make([]*entity.CoinHold, len(dbHolds))
+	for i, dbHold := range dbHolds {
+		holds[i] = dbCoinHoldToEntity(dbHold)
+	}
+
+	return holds, nil
+}
+
+// CaptureCoinHold turns an active hold into a purchase transaction for the held amount
+func (r *coinHoldRepository) CaptureCoinHold(ctx context.Context, userID uuid.UUID, holdID int32) (*entity.CoinHold, *entity.CoinTransaction, error) {
+	var dbHold database.CoinHold
+	var coinTx database.CoinTransaction
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		txQueries := database.QuerierFromContext(ctx, r.queries)
+
+		user, hold, err := lockActiveCoinHold(ctx, txQueries, userID, holdID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if !hold.ExpiresAt.Time.After(now) {
+			return domain.ErrCoinHoldExpired
+		}
+
+		if err := consumeCoinLots(ctx, txQueries, user, int(hold.Amount), now); err != nil {
+			return err
+		}
+
+		if _, err := txQueries.UpdateUserHeldCoins(ctx, database.UpdateUserHeldCoinsParams{
+			ID:        hold.UserID,
+			HeldCoins: -hold.Amount,
+		}); err != nil {
+			return fmt.Errorf("failed to update held coins: %w", database.TranslateError(err, nil))
+		}
+
+		updatedUser, err := txQueries.UpdateUserCoins(ctx, database.UpdateUserCoinsParams{
+			ID:    hold.UserID,
+			Coins: database.Int32ToPgtype(-hold.Amount),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update coins: %w", database.TranslateError(err, nil))
+		}
+
+		coinTx, err = txQueries.CreateCoinTransaction(ctx, database.CreateCoinTransactionParams{
+			UserID:          hold.UserID,
+			TransactionType: database.TransactionType("purchase"),
+			Amount:          -hold.Amount,
+			BalanceAfter:    database.PgtypeToInt32(updatedUser.Coins),
+			OrderID:         hold.OrderID,
+			Description:     hold.Description,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create transaction: %w", database.TranslateError(err, nil))
+		}
+
+		dbHold, err = txQueries.UpdateCoinHoldStatus(ctx, database.UpdateCoinHoldStatusParams{
+			ID:                hold.ID,
+			Status:            database.CoinHoldStatus(entity.CoinHoldStatusCaptured),
+			CoinTransactionID: database.Int32ToPgtype(coinTx.ID),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update coin hold: %w", database.TranslateError(err, nil))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return dbCoinHoldToEntity(dbHold), dbTransactionToEntity(coinTx), nil
+}
+
+// ReleaseCoinHold frees the coins reserved by an active hold
+func (r *coinHoldRepository) ReleaseCoinHold(ctx context.Context, userID uuid.UUID, holdID int32) (*entity.CoinHold, error) {
+	return r.endCoinHold(ctx, userID, holdID, entity.CoinHoldStatusReleased)
+}
+
+// ExpireCoinHolds releases up to limit active holds that expired before now,
+// each in its own transaction.
+func (r *coinHoldRepository) ExpireCoinHolds(ctx context.Context, now time.Time, limit int32) (int, error) {
+	holds, err := database.QuerierFromContext(ctx, r.queries).ListExpiredCoinHolds(ctx, database.ListExpiredCoinHoldsParams{
+		ExpiresAt: database.TimeToPgtype(now),
+		Limit:     limit,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired coin holds: %w", err)
+	}
+
+	expired := 0
+	for _, hold := range holds {
+		_, err := r.endCoinHold(ctx, database.PgtypeToUUID(hold.UserID), hold.ID, entity.CoinHoldStatusExpired)
+		if err != nil {
+			// Captured or released since it was listed
+			if errors.Is(err, domain.ErrCoinHoldNotActive) {
+				continue
+			}
+			return expired, err
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// endCoinHold moves an active hold to status and frees its coins without
+// writing to the ledger.
+func (r *coinHoldRepository) endCoinHold(ctx context.Context, userID uuid.UUID, holdID int32, status string) (*entity.CoinHold, error) {
+	var dbHold database.CoinHold
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		txQueries := database.QuerierFromContext(ctx, r.queries)
+
+		_, hold, err := lockActiveCoinHold(ctx, txQueries, userID, holdID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := txQueries.UpdateUserHeldCoins(ctx, database.UpdateUserHeldCoinsParams{
+			ID:        hold.UserID,
+			HeldCoins: -hold.Amount,
+		}); err != nil {
+			return fmt.Errorf("failed to update held coins: %w", database.TranslateError(err, nil))
+		}
+
+		dbHold, err = txQueries.UpdateCoinHoldStatus(ctx, database.UpdateCoinHoldStatusParams{
+			ID:     hold.ID,
+			Status: database.CoinHoldStatus(status),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update coin hold: %w", database.TranslateError(err, nil))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbCoinHoldToEntity(dbHold), nil
+}
+
+// lockActiveCoinHold locks the user and then the hold, the same order spends
+// and lot expiry use, and checks the hold belongs to the user and is active.
+// It returns the locked user with the hold.
+func lockActiveCoinHold(ctx context.Context, q database.Querier, userID uuid.UUID, holdID int32) (database.User, database.CoinHold, error) {
+	user, err := q.GetUserByIDForUpdate(ctx, database.UUIDToPgtype(userID))
+	if err != nil {
+		return database.User{}, database.CoinHold{}, fmt.Errorf("failed to lock user: %w", database.TranslateError(err, domain.ErrUserNotFound))
+	}
+
+	hold, err := q.GetCoinHoldForUpdate(ctx, holdID)
+	if err != nil {
+		return database.User{}, database.CoinHold{}, fmt.Errorf("failed to lock coin hold: %w", database.TranslateError(err, domain.ErrCoinHoldNotFound))
+	}
+
+	if database.PgtypeToUUID(hold.UserID) != userID {
+		return database.User{}, database.CoinHold{}, domain.ErrCoinHoldNotFound
+	}
+
+	if hold.Status != database.CoinHoldStatus(entity.CoinHoldStatusActive) {
+		return database.User{}, database.CoinHold{}, domain.ErrCoinHoldNotActive
+	}
+
+	return user, hold, nil
+}
+
+func dbCoinHoldToEntity(dbHold database.CoinHold) *entity.CoinHold {
+	hold := &entity.CoinHold{
+		ID:          dbHold.ID,
+		UserID:      database.PgtypeToUUID(dbHold.UserID),
+		Amount:      int(dbHold.Amount),
+		Description: database.PgtypeToString(dbHold.Description),
+		Status:      string(dbHold.Status),
+		ExpiresAt:   dbHold.ExpiresAt.Time,
+		CreatedAt:   dbHold.CreatedAt.Time,
+		UpdatedAt:   dbHold.UpdatedAt.Time,
+	}
+
+	if dbHold.OrderID.Valid {
+		orderID := dbHold.OrderID.Int32
+		hold.OrderID = &orderID
+	}
+
+	if dbHold.CoinTransactionID.Valid {
+		coinTransactionID := dbHold.CoinTransactionID.Int32
+		hold.CoinTransactionID = &coinTransactionID
+	}
+
+	return hold
+}
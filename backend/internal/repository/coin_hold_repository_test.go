@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"backend/internal/database"
+	"backend/internal/domain"
+	"backend/internal/entity"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDbCoinHoldToEntity_Active(t *testing.T) {
+	userID := uuid.New()
+	expiresAt := time.Now().Add(30 * time.Minute)
+
+	hold := dbCoinHoldToEntity(database.CoinHold{
+		ID:          1,
+		UserID:      database.UUIDToPgtype(userID),
+		Amount:      300,
+		Description: pgtype.Text{String: "Order checkout", Valid: true},
+		Status:      database.CoinHoldStatusActive,
+		ExpiresAt:   database.TimeToPgtype(expiresAt),
+	})
+
+	assert.Equal(t, int32(1), hold.ID)
+	assert.Equal(t, userID, hold.UserID)
+	assert.Equal(t, 300, hold.Amount)
+	assert.Equal(t, "Order checkout", hold.Description)
+	assert.Equal(t, entity.CoinHoldStatusActive, hold.Status)
+	assert.True(t, expiresAt.Equal(hold.ExpiresAt))
+	assert.Nil(t, hold.OrderID)
+	assert.Nil(t, hold.CoinTransactionID)
+}
+
+func TestDbCoinHoldToEntity_Captured(t *testing.T) {
+	hold := dbCoinHoldToEntity(database.CoinHold{
+		ID:                2,
+		UserID:            database.UUIDToPgtype(uuid.New()),
+		Amount:            150,
+		OrderID:           database.Int32ToPgtype(7),
+		Status:            database.CoinHoldStatusCaptured,
+		CoinTransactionID: database.Int32ToPgtype(42),
+	})
+
+	assert.Equal(t, entity.CoinHoldStatusCaptured, hold.Status)
+	assert.NotNil(t, hold.OrderID)
+	assert.Equal(t, int32(7), *hold.OrderID)
+	assert.NotNil(t, hold.CoinTransactionID)
+	assert.Equal(t, int32(42), *hold.CoinTransactionID)
+}
+
+func TestAvailableCoins(t *testing.T) {
+	assert.Equal(t, 700, availableCoins(database.Int32ToPgtype(1000), 300))
+	assert.Equal(t, 0, availableCoins(pgtype.Int4{}, 0))
+}
+
+func TestGetActiveCoinHoldsByOrderID(t *testing.T) {
+	ctx := context.Background()
+	db, txManager := newTestDB(t)
+	repo := NewCoinHoldRepository(db, txManager)
+	userID := insertTestUser(t, db, 1000)
+	order := insertTestOrder(t, db, userID, 300)
+	other := insertTestOrder(t, db, userID, 100)
+	expiresAt := time.Now().Add(time.Hour)
+
+	first, err := repo.CreateCoinHold(ctx, userID, 200, "Order", &order.ID, expiresAt)
+	require.NoError(t, err)
+	released, err := repo.CreateCoinHold(ctx, userID, 50, "Order", &order.ID, expiresAt)
+	require.NoError(t, err)
+	_, err = repo.ReleaseCoinHold(ctx, userID, released.ID)
+	require.NoError(t, err)
+	second, err := repo.CreateCoinHold(ctx, userID, 100, "Order", &order.ID, expiresAt)
+	require.NoError(t, err)
+	_, err = repo.CreateCoinHold(ctx, userID, 100, "Other order", &other.ID, expiresAt)
+	require.NoError(t, err)
+
+	holds, err := repo.GetActiveCoinHoldsByOrderID(ctx, order.ID)
+
+	require.NoError(t, err)
+	require.Len(t, holds, 2)
+	assert.Equal(t, first.ID, holds[0].ID)
+	assert.Equal(t, second.ID, holds[1].ID)
+}
+
+func TestCreateCoinHold_ReservesSpendableCoins(t *testing.T) {
+	ctx := context.Background()
+	db, txManager := newTestDB(t)
+	repo := NewCoinHoldRepository(db, txManager)
+	userID := insertTestUser(t, db, 300)
+	expiresAt := time.Now().Add(time.Hour)
+
+	hold, err := repo.CreateCoinHold(ctx, userID, 200, "Order checkout", nil, expiresAt)
+
+	require.NoError(t, err)
+	assert.Equal(t, 200, hold.Amount)
+	assert.Equal(t, entity.CoinHoldStatusActive, hold.Status)
+	user := getTestUser(t, db, userID)
+	assert.Equal(t, int32(300), user.Coins.Int32)
+	assert.Equal(t, int32(200), user.HeldCoins)
+
+	// Held coins are no longer spendable
+	_, err = repo.CreateCoinHold(ctx, userID, 200, "Order checkout", nil, expiresAt)
+	assert.ErrorIs(t, err, domain.ErrInsufficientCoins)
+	assert.Equal(t, int32(200), getTestUser(t, db, userID).HeldCoins)
+}
+
+func TestCaptureCoinHold_SpendsHeldCoins(t *testing.T) {
+	ctx := context.Background()
+	db, txManager := newTestDB(t)
+	repo := NewCoinHoldRepository(db, txManager)
+	userID := insertTestUser(t, db, 300)
+	lot := insertTestLot(t, db, userID, 300, time.Now().Add(-time.Hour), nil)
+	order := insertTestOrder(t, db, userID, 200)
+	created, err := repo.CreateCoinHold(ctx, userID, 200, "Order checkout", &order.ID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	hold, transaction, err := repo.CaptureCoinHold(ctx, userID, created.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.CoinHoldStatusCaptured, hold.Status)
+	require.NotNil(t, hold.CoinTransactionID)
+	assert.Equal(t, transaction.ID, *hold.CoinTransactionID)
+	assert.Equal(t, entity.TransactionTypePurchase, transaction.TransactionType)
+	assert.Equal(t, -200, transaction.Amount)
+	assert.Equal(t, 100, transaction.BalanceAfter)
+	require.NotNil(t, transaction.OrderID)
+	assert.Equal(t, order.ID, *transaction.OrderID)
+	user := getTestUser(t, db, userID)
+	assert.Equal(t, int32(100), user.Coins.Int32)
+	assert.Zero(t, user.HeldCoins)
+	lots := listTestLots(t, db, userID)
+	require.Len(t, lots, 1)
+	assert.Equal(t, lot.ID, lots[0].ID)
+	assert.Equal(t, int32(100), lots[0].RemainingAmount)
+
+	// A captured hold cannot be captured again
+	_, _, err = repo.CaptureCoinHold(ctx, userID, created.ID)
+	assert.ErrorIs(t, err, domain.ErrCoinHoldNotActive)
+	assert.Len(t, listTestTransactions(t, db, userID), 1)
+}
+
+func TestCaptureCoinHold_ExpiredHoldSpendsNothing(t *testing.T) {
+	ctx := context.Background()
+	db, txManager := newTestDB(t)
+	repo := NewCoinHoldRepository(db, txManager)
+	userID := insertTestUser(t, db, 300)
+	created, err := repo.CreateCoinHold(ctx, userID, 200, "Order checkout", nil, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+
+	hold, transaction, err := repo.CaptureCoinHold(ctx, userID, created.ID)
+
+	assert.ErrorIs(t, err, domain.ErrCoinHoldExpired)
+	assert.Nil(t, hold)
+	assert.Nil(t, transaction)
+	user := getTestUser(t, db, userID)
+	assert.Equal(t, int32(300), user.Coins.Int32)
+	assert.Equal(t, int32(200), user.HeldCoins)
+	assert.Empty(t, listTestTransactions(t, db, userID))
+}
+
+func TestReleaseCoinHold_FreesCoinsWithoutLedgerEntry(t *testing.T) {
+	ctx := context.Background()
+	db, txManager := newTestDB(t)
+	repo := NewCoinHoldRepository(db, txManager)
+	userID := insertTestUser(t, db, 300)
+	created, err := repo.CreateCoinHold(ctx, userID, 200, "Order checkout", nil, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	hold, err := repo.ReleaseCoinHold(ctx, userID, created.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.CoinHoldStatusReleased, hold.Status)
+	assert.Nil(t, hold.CoinTransactionID)
+	user := getTestUser(t, db, userID)
+	assert.Equal(t, int32(300), user.Coins.Int32)
+	assert.Zero(t, user.HeldCoins)
+	assert.Empty(t, listTestTransactions(t, db, userID))
+
+	_, err = repo.ReleaseCoinHold(ctx, userID, created.ID)
+	assert.ErrorIs(t, err, domain.ErrCoinHoldNotActive)
+}
+
+func TestReleaseCoinHold_OtherUsersHold(t *testing.T) {
+	ctx := context.Background()
+	db, txManager := newTestDB(t)
+	repo := NewCoinHoldRepository(db, txManager)
+	ownerID := insertTestUser(t, db, 300)
+	otherID := insertTestUser(t, db, 300)
+	created, err := repo.CreateCoinHold(ctx, ownerID, 200, "Order checkout", nil, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	_, err = repo.ReleaseCoinHold(ctx, otherID, created.ID)
+
+	assert.ErrorIs(t, err, domain.ErrCoinHoldNotFound)
+	assert.Equal(t, int32(200), getTestUser(t, db, ownerID).HeldCoins)
+}
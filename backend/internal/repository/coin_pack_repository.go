@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"backend/internal/database"
+	"backend/internal/domain"
+	"backend/internal/entity"
+	"context"
+	"fmt"
+)
+
+type CoinPackRepository interface {
+	GetActiveCoinPacks(ctx context.Context) ([]*entity.CoinPack, error)
+	GetAllCoinPacks(ctx context.Context) ([]*entity.CoinPack, error)
+	GetCoinPackByID(ctx context.Context, id int32) (*entity.CoinPack, error)
+	CreateCoinPack(ctx context.Context, req entity.CreateCoinPackRequest) (*entity.CoinPack, error)
+	UpdateCoinPack(ctx context.Context, id int32, req entity.UpdateCoinPackRequest) (*entity.CoinPack, error)
+}
+
+type coinPackRepository struct {
+	queries database.Querier
+}
+
+func NewCoinPackRepository(queries database.Querier) CoinPackRepository {
+	return &coinPackRepository{
+		queries: queries,
+	}
+}
+
+func (r *coinPackRepository) GetActiveCoinPacks(ctx context.Context) ([]*entity.CoinPack, error) {
+	dbPacks, err := database.QuerierFromContext(ctx, r.queries).ListActiveCoinPacks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get coin packs: %w", err)
+	}
+
+	packs := make([]*entity.CoinPack, len(dbPacks))
+	for i, dbPack := range dbPacks {
+		packs[i] = dbCoinPackToEntity(dbPack)
+	}
+
+	return packs, nil
+}
+
+func (r *coinPackRepository) GetAllCoinPacks(ctx context.Context) ([]*entity.CoinPack, error) {
+	dbPacks, err := database.QuerierFromContext(ctx, r.queries).ListCoinPacks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get coin packs: %w", err)
+	}
+
+	packs := make([]*entity.CoinPack, len(dbPacks))
+	for i, dbPack := range dbPacks {
+		packs[i] = dbCoinPackToEntity(dbPack)
+	}
+
+	return packs, nil
+}
+
+func (r *coinPackRepository) GetCoinPackByID(ctx context.Context, id int32) (*entity.CoinPack, error) {
+	dbPack, err := database.QuerierFromContext(ctx, r.queries).GetCoinPackByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get coin pack: %w", database.TranslateError(err, domain.ErrCoinPackNotFound))
+	}
+
+	return dbCoinPackToEntity(dbPack), nil
+}
+
+func (r *coinPackRepository) CreateCoinPack(ctx context.Context, req entity.CreateCoinPackRequest) (*entity.CoinPack, error) {
+	dbPack, err := database.QuerierFromContext(ctx, r.queries).CreateCoinPack(ctx, database.CreateCoinPackParams{
+		Name:       req.Name,
+		BaseCoins:  int32(req.BaseCoins),
+		BonusCoins: int32(req.BonusCoins),
+		Price:      database.Float64ToNumeric(req.Price),
+		IsActive:   req.IsActive,
+		SortOrder:  int32(req.SortOrder),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create coin pack: %w", database.TranslateError(err, nil))
+	}
+
+	return dbCoinPackToEntity(dbPack), nil
+}
+
+func (r *coinPackRepository) UpdateCoinPack(ctx context.Context, id int32, req entity.UpdateCoinPackRequest) (*entity.CoinPack, error) {
+	dbPack, err := database.QuerierFromContext(ctx, r.queries).UpdateCoinPack(ctx, database.UpdateCoinPackParams{
+		ID:         id,
+		Name:       req.Name,
+		BaseCoins:  int32(req.BaseCoins),
+		BonusCoins: int32(req.BonusCoins),
+		Price:      database.Float64ToNumeric(req.Price),
+		IsActive:   req.IsActive,
+		SortOrder:  int32(req.SortOrder),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update coin pack: %w", database.TranslateError(err, domain.ErrCoinPackNotFound))
+	}
+
+	return dbCoinPackToEntity(dbPack), nil
+}
+
+func dbCoinPackToEntity(dbPack database.CoinPack) *entity.CoinPack {
+	return &entity.CoinPack{
+		ID:         dbPack.ID,
+		Name:       dbPack.Name,
+		BaseCoins:  int(dbPack.BaseCoins),
+		BonusCoins: int(dbPack.BonusCoins),
+		Price:      database.NumericToFloat64(dbPack.Price),
+		IsActive:   dbPack.IsActive,
+		SortOrder:  int(dbPack.SortOrder),
+		CreatedAt:  dbPack.CreatedAt.Time,
+		UpdatedAt:  dbPack.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"backend/internal/database"
+	"backend/internal/domain"
+	"backend/internal/entity"
+	"backend/mocks"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// ---- Helper Functions ----
+
+func sampleDBCoinPack(id int32, name string, base, bonus int32, active bool) database.CoinPack {
+	return database.CoinPack{
+		ID:         id,
+		Name:       name,
+		BaseCoins:  base,
+		BonusCoins: bonus,
+		Price:      database.Float64ToNumeric(49.99),
+		IsActive:   active,
+		SortOrder:  id,
+		CreatedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		UpdatedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	}
+}
+
+// ---- Tests ----
+
+func TestGetActiveCoinPacks(t *testing.T) {
+	mockQ := mocks.NewMockQuerier(t)
+	repo := NewCoinPackRepository(mockQ)
+
+	mockQ.EXPECT().ListActiveCoinPacks(mock.Anything).Return([]database.CoinPack{
+		sampleDBCoinPack(1, "1000 Coins", 1000, 0, true),
+		sampleDBCoinPack(2, "5000 + 500 Bonus", 5000, 500, true),
+	}, nil)
+
+	packs, err := repo.GetActiveCoinPacks(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, packs, 2)
+	assert.Equal(t, "5000 + 500 Bonus", packs[1].Name)
+	assert.Equal(t, 5000, packs[1].BaseCoins)
+	assert.Equal(t, 500, packs[1].BonusCoins)
+	assert.Equal(t, 49.99, packs[1].Price)
+}
+
+func TestGetAllCoinPacks_Error(t *testing.T) {
+	mockQ := mocks.NewMockQuerier(t)
+	repo := NewCoinPackRepository(mockQ)
+
+	mockQ.EXPECT().ListCoinPacks(mock.Anything).Return(nil, errors.New("db error"))
+
+	packs, err := repo.GetAllCoinPacks(context.Background())
+
+	assert.EqualError(t, err, "failed to get coin packs: db error")
+	assert.Nil(t, packs)
+}
+
+func TestGetCoinPackByID_NotFound(t *testing.T) {
+	mockQ := mocks.NewMockQuerier(t)
+	repo := NewCoinPackRepository(mockQ)
+
+	mockQ.EXPECT().GetCoinPackByID(mock.Anything, int32(9)).Return(database.CoinPack{}, pgx.ErrNoRows)
+
+	pack, err := repo.GetCoinPackByID(context.Background(), 9)
+
+	assert.ErrorIs(t, err, domain.ErrCoinPackNotFound)
+	assert.Nil(t, pack)
+}
+
+func TestCreateCoinPack(t *testing.T) {
+	mockQ := mocks.NewMockQuerier(t)
+	repo := NewCoinPackRepository(mockQ)
+
+	req := entity.CreateCoinPackRequest{Name: "Starter", BaseCoins: 500, BonusCoins: 50, Price: 49.99, IsActive: true, SortOrder: 4}
+	mockQ.EXPECT().CreateCoinPack(mock.Anything, database.CreateCoinPackParams{
+		Name:       "Starter",
+		BaseCoins:  500,
+		BonusCoins: 50,
+		Price:      database.Float64ToNumeric(49.99),
+		IsActive:   true,
+		SortOrder:  4,
+	}).Return(sampleDBCoinPack(4, "Starter", 500, 50, true), nil)
+
+	pack, err := repo.CreateCoinPack(context.Background(), req)
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), pack.ID)
+	assert.Equal(t, 50, pack.BonusCoins)
+}
+
+func TestCreateCoinPack_CheckViolation(t *testing.T) {
+	db, _ := newTestDB(t)
+	repo := NewCoinPackRepository(db)
+
+	_, err := repo.CreateCoinPack(context.Background(), entity.CreateCoinPackRequest{Name: "Broken", BaseCoins: 0, Price: 1})
+
+	assert.ErrorIs(t, err, domain.ErrBaseCoinsNotPositive)
+}
+
+func TestUpdateCoinPack_NotFound(t *testing.T) {
+	mockQ := mocks.NewMockQuerier(t)
+	repo := NewCoinPackRepository(mockQ)
+
+	mockQ.EXPECT().UpdateCoinPack(mock.Anything, mock.MatchedBy(func(p database.UpdateCoinPackParams) bool {
+		return p.ID == 9 && p.Name == "Gone"
+	})).Return(database.CoinPack{}, pgx.ErrNoRows)
+
+	pack, err := repo.UpdateCoinPack(context.Background(), 9, entity.UpdateCoinPackRequest{Name: "Gone", BaseCoins: 100, Price: 1})
+
+	assert.ErrorIs(t, err, domain.ErrCoinPackNotFound)
+	assert.Nil(t, pack)
+}
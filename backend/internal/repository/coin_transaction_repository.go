@@ -2,21 +2,37 @@ package repository
 
 import (
 	"backend/internal/database"
+	"backend/internal/domain"
 	"backend/internal/entity"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type CoinTransactionRepository interface {
 	CreateCoinTransaction(ctx context.Context, params CreateCoinTransactionParams) (*entity.CoinTransaction, error)
 	GetTransactionsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*entity.CoinTransaction, error)
 	GetTransactionByID(ctx context.Context, id int32) (*entity.CoinTransaction, error)
+	GetFilteredTransactions(ctx context.Context, userID uuid.UUID, filter entity.CoinTransactionFilter) ([]*entity.CoinTransaction, *entity.CoinTransactionSummary, error)
 	ChargeUserCoins(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32) (*entity.User, *entity.CoinTransaction, error)
 	SpendUserCoins(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32) (*entity.User, *entity.CoinTransaction, error)
+	ChargeCoinPack(ctx context.Context, userID uuid.UUID, pack *entity.CoinPack) (*entity.User, []*entity.CoinTransaction, error)
+	GetCoinBalance(ctx context.Context, userID uuid.UUID) (*entity.CoinBalance, error)
+	GetCoinBalanceAt(ctx context.Context, userID uuid.UUID, at time.Time) (int, error)
+	GetCoinBalanceChanges(ctx context.Context, userID uuid.UUID, from, to time.Time, interval, timeZone string) (int, []entity.CoinBalanceChange, error)
+	GrantCashback(ctx context.Context, order *entity.Order, amount int) (*entity.CoinTransaction, error)
+	ReverseCashback(ctx context.Context, order *entity.Order) (*entity.CoinTransaction, error)
+	RedeemGiftCode(ctx context.Context, userID uuid.UUID, codeHash string, now time.Time) (*entity.User, *entity.CoinTransaction, error)
+	GrantReferralBonuses(ctx context.Context, refereeID uuid.UUID, policy entity.ReferralPolicy) (*entity.Referral, []*entity.CoinTransaction, error)
+	WriteCoinStatement(ctx context.Context, userID uuid.UUID, from, to time.Time, w entity.CoinStatementWriter) error
+	ExpireCoinLots(ctx context.Context, now time.Time, limit int32) (int, []*entity.CoinTransaction, error)
 }
 
 type CreateCoinTransactionParams struct {
@@ -25,18 +41,28 @@ type CreateCoinTransactionParams struct {
 	Amount          int
 	BalanceAfter    int
 	OrderID         *int32
+	CoinPackID      *int32
 	Description     string
 }
 
+// ledgerEntry is a single coin movement written as part of a larger operation
+type ledgerEntry struct {
+	transactionType string
+	amount          int
+	description     string
+}
+
 type coinTransactionRepository struct {
-	queries *database.Queries
-	db      *pgxpool.Pool
+	queries      database.CoinStatementQuerier
+	txManager    database.TxManager
+	expiryPolicy entity.CoinExpiryPolicy
 }
 
-func NewCoinTransactionRepository(queries *database.Queries, db *pgxpool.Pool) CoinTransactionRepository {
+func NewCoinTransactionRepository(queries database.CoinStatementQuerier, txManager database.TxManager, expiryPolicy entity.CoinExpiryPolicy) CoinTransactionRepository {
 	return &coinTransactionRepository{
-		queries: queries,
-		db:      db,
+		queries:      queries,
+		txManager:    txManager,
+		expiryPolicy: expiryPolicy,
 	}
 }
 
@@ -46,23 +72,29 @@ func (r *coinTransactionRepository) CreateCoinTransaction(ctx context.Context, p
 		orderID = database.Int32ToPgtype(*params.OrderID)
 	}
 
-	dbTransaction, err := r.queries.CreateCoinTransaction(ctx, database.CreateCoinTransactionParams{
+	var coinPackID pgtype.Int4
+	if params.CoinPackID != nil {
+		coinPackID = database.Int32ToPgtype(*params.CoinPackID)
+	}
+
+	dbTransaction, err := database.QuerierFromContext(ctx, r.queries).CreateCoinTransaction(ctx, database.CreateCoinTransactionParams{
 		UserID:          database.UUIDToPgtype(params.UserID),
 		TransactionType: database.TransactionType(params.TransactionType),
 		Amount:          int32(params.Amount),
 		BalanceAfter:    int32(params.BalanceAfter),
 		OrderID:         orderID,
 		Description:     pgtype.Text{String: params.Description, Valid: params.Description != ""},
+		CoinPackID:      coinPackID,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create transaction: %w", err)
+		return nil, fmt.Errorf("failed to create transaction: %w", database.TranslateError(err, nil))
 	}
 
 	return dbTransactionToEntity(dbTransaction), nil
 }
 
 func (r *coinTransactionRepository) GetTransactionsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*entity.CoinTransaction, error) {
-	dbTransactions, err := r.queries.GetCoinTransactionsByUserID(ctx, database.GetCoinTransactionsByUserIDParams{
+	dbTransactions, err := database.QuerierFromContext(ctx, r.queries).GetCoinTransactionsByUserID(ctx, database.GetCoinTransactionsByUserIDParams{
 		UserID: database.UUIDToPgtype(userID),
 		Limit:  limit,
 		Offset: offset,
@@ -79,68 +111,142 @@ func (r *coinTransactionRepository) GetTransactionsByUserID(ctx context.Context,
 	return transactions, nil
 }
 
-func (r *coinTransactionRepository) GetTransactionByID(ctx context.Context, id int32) (*entity.CoinTransaction, error) {
-	dbTransaction, err := r.queries.GetCoinTransactionByID(ctx, id)
-	if err != nil {
-		return nil, fmt.Errorf("transaction not found: %w", err)
+func (r *coinTransactionRepository) GetFilteredTransactions(ctx context.Context, userID uuid.UUID, filter entity.CoinTransactionFilter) ([]*entity.CoinTransaction, *entity.CoinTransactionSummary, error) {
+	transactionTypes := filter.TransactionTypes
+	if len(transactionTypes) == 0 {
+		transactionTypes = entity.TransactionTypes
 	}
 
-	return dbTransactionToEntity(dbTransaction), nil
-}
+	// Unbounded ends are sent as +/-infinity so the created_at range is always applied
+	createdFrom := pgtype.Timestamptz{InfinityModifier: pgtype.NegativeInfinity, Valid: true}
+	if filter.From != nil {
+		createdFrom = database.TimeToPgtype(*filter.From)
+	}
+	createdTo := pgtype.Timestamptz{InfinityModifier: pgtype.Infinity, Valid: true}
+	if filter.To != nil {
+		createdTo = database.TimeToPgtype(*filter.To)
+	}
 
-func (r *coinTransactionRepository) ChargeUserCoins(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32) (*entity.User, *entity.CoinTransaction, error) {
-	tx, err := r.db.Begin(ctx)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to start transaction: %w", err)
+	var orderID pgtype.Int4
+	if filter.OrderID != nil {
+		orderID = database.Int32ToPgtype(*filter.OrderID)
 	}
-	defer tx.Rollback(ctx)
 
-	txQueries := r.queries.WithTx(tx)
+	var amountSign int32
+	switch filter.AmountSign {
+	case entity.AmountSignPositive:
+		amountSign = 1
+	case entity.AmountSignNegative:
+		amountSign = -1
+	}
 
-	user, err := txQueries.GetUserByID(ctx, database.UUIDToPgtype(userID))
+	dbTransactions, err := database.QuerierFromContext(ctx, r.queries).ListCoinTransactionsFiltered(ctx, database.ListCoinTransactionsFilteredParams{
+		UserID:           database.UUIDToPgtype(userID),
+		TransactionTypes: transactionTypes,
+		CreatedFrom:      createdFrom,
+		CreatedTo:        createdTo,
+		OrderID:          orderID,
+		AmountSign:       amountSign,
+		RowLimit:         filter.Limit,
+		RowOffset:        (filter.Page - 1) * filter.Limit,
+	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("user not found: %w", err)
+		return nil, nil, fmt.Errorf("failed to get transactions: %w", err)
 	}
 
-	currentCoins := int(database.PgtypeToInt32(user.Coins))
-	newBalance := currentCoins + amount
-
-	updatedUser, err := txQueries.UpdateUserCoins(ctx, database.UpdateUserCoinsParams{
-		ID:    database.UUIDToPgtype(userID),
-		Coins: database.Int32ToPgtype(int32(amount)),
+	dbSummary, err := database.QuerierFromContext(ctx, r.queries).SummarizeCoinTransactionsFiltered(ctx, database.SummarizeCoinTransactionsFilteredParams{
+		UserID:           database.UUIDToPgtype(userID),
+		TransactionTypes: transactionTypes,
+		CreatedFrom:      createdFrom,
+		CreatedTo:        createdTo,
+		OrderID:          orderID,
+		AmountSign:       amountSign,
 	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to update coins: %w", err)
+		return nil, nil, fmt.Errorf("failed to summarize transactions: %w", err)
 	}
 
-	var orderIDPgtype pgtype.Int4
-	if orderID != nil {
-		orderIDPgtype = database.Int32ToPgtype(*orderID)
+	transactions := make([]*entity.CoinTransaction, len(dbTransactions))
+	for i, dbTx := range dbTransactions {
+		transactions[i] = dbTransactionToEntity(dbTx)
 	}
 
-	coinTx, err := txQueries.CreateCoinTransaction(ctx, database.CreateCoinTransactionParams{
-		UserID:          database.UUIDToPgtype(userID),
-		TransactionType: database.TransactionType("charge"),
-		Amount:          int32(amount),
-		BalanceAfter:    int32(newBalance),
-		OrderID:         orderIDPgtype,
-		Description:     pgtype.Text{String: description, Valid: description != ""},
-	})
+	summary := &entity.CoinTransactionSummary{
+		TotalCount:    int(dbSummary.TotalCount),
+		TotalCharged:  int(dbSummary.TotalCharged),
+		TotalSpent:    int(dbSummary.TotalSpent),
+		TotalRefunded: int(dbSummary.TotalRefunded),
+	}
+
+	return transactions, summary, nil
+}
+
+func (r *coinTransactionRepository) GetTransactionByID(ctx context.Context, id int32) (*entity.CoinTransaction, error) {
+	dbTransaction, err := database.QuerierFromContext(ctx, r.queries).GetCoinTransactionByID(ctx, id)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create transaction: %w", err)
+		return nil, fmt.Errorf("failed to get transaction: %w", database.TranslateError(err, domain.ErrTransactionNotFound))
 	}
 
-	if err := tx.Commit(ctx); err != nil {
-		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	return dbTransactionToEntity(dbTransaction), nil
+}
+
+func (r *coinTransactionRepository) ChargeUserCoins(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32) (*entity.User, *entity.CoinTransaction, error) {
+	var updatedUser database.UpdateUserCoinsRow
+	var coinTx database.CoinTransaction
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		txQueries := database.QuerierFromContext(ctx, r.queries)
+
+		user, err := txQueries.GetUserByID(ctx, database.UUIDToPgtype(userID))
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", database.TranslateError(err, domain.ErrUserNotFound))
+		}
+
+		currentCoins := int(database.PgtypeToInt32(user.Coins))
+		newBalance := currentCoins + amount
+
+		updatedUser, err = txQueries.UpdateUserCoins(ctx, database.UpdateUserCoinsParams{
+			ID:    database.UUIDToPgtype(userID),
+			Coins: database.Int32ToPgtype(int32(amount)),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update coins: %w", database.TranslateError(err, nil))
+		}
+
+		var orderIDPgtype pgtype.Int4
+		if orderID != nil {
+			orderIDPgtype = database.Int32ToPgtype(*orderID)
+		}
+
+		coinTx, err = txQueries.CreateCoinTransaction(ctx, database.CreateCoinTransactionParams{
+			UserID:          database.UUIDToPgtype(userID),
+			TransactionType: database.TransactionType("charge"),
+			Amount:          int32(amount),
+			BalanceAfter:    int32(newBalance),
+			OrderID:         orderIDPgtype,
+			Description:     pgtype.Text{String: description, Valid: description != ""},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create transaction: %w", database.TranslateError(err, nil))
+		}
+
+		if err := r.createCoinLot(ctx, txQueries, coinTx); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
 	userEntity := &entity.User{
-		ID:        database.PgtypeToUUID(updatedUser.ID),
-		Name:      updatedUser.Name,
-		Email:     updatedUser.Email,
-		Coins:     int(database.PgtypeToInt32(updatedUser.Coins)),
-		CreatedAt: updatedUser.CreatedAt.Time,
-		UpdatedAt: updatedUser.UpdatedAt.Time,
+		ID:             database.PgtypeToUUID(updatedUser.ID),
+		Name:           updatedUser.Name,
+		Email:          updatedUser.Email,
+		Coins:          int(database.PgtypeToInt32(updatedUser.Coins)),
+		AvailableCoins: availableCoins(updatedUser.Coins, updatedUser.HeldCoins),
+		ReferralCode:   updatedUser.ReferralCode,
+		CreatedAt:      updatedUser.CreatedAt.Time,
+		UpdatedAt:      updatedUser.UpdatedAt.Time,
 	}
 
 	transactionEntity := &entity.CoinTransaction{
@@ -162,62 +268,73 @@ func (r *coinTransactionRepository) ChargeUserCoins(ctx context.Context, userID
 }
 
 func (r *coinTransactionRepository) SpendUserCoins(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32) (*entity.User, *entity.CoinTransaction, error) {
-	tx, err := r.db.Begin(ctx)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to start transaction: %w", err)
-	}
-	defer tx.Rollback(ctx)
+	var updatedUser database.UpdateUserCoinsRow
+	var coinTx database.CoinTransaction
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		txQueries := database.QuerierFromContext(ctx, r.queries)
 
-	txQueries := r.queries.WithTx(tx)
+		// Locking the user serializes spends with hold changes for the same user
+		user, err := txQueries.GetUserByIDForUpdate(ctx, database.UUIDToPgtype(userID))
+		if err != nil {
+			return fmt.Errorf("failed to lock user: %w", database.TranslateError(err, domain.ErrUserNotFound))
+		}
 
-	user, err := txQueries.GetUserByID(ctx, database.UUIDToPgtype(userID))
-	if err != nil {
-		return nil, nil, fmt.Errorf("user not found: %w", err)
-	}
+		now := time.Now()
 
-	currentCoins := int(database.PgtypeToInt32(user.Coins))
-	newBalance := currentCoins - amount
+		spendable, err := spendableCoins(ctx, txQueries, user, now)
+		if err != nil {
+			return err
+		}
 
-	if newBalance < 0 {
-		return nil, nil, fmt.Errorf("insufficient coins: have %d, need %d", currentCoins, amount)
-	}
+		newBalance := int(database.PgtypeToInt32(user.Coins)) - amount
 
-	updatedUser, err := txQueries.UpdateUserCoins(ctx, database.UpdateUserCoinsParams{
-		ID:    database.UUIDToPgtype(userID),
-		Coins: database.Int32ToPgtype(int32(-amount)),
-	})
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to update coins: %w", err)
-	}
+		if spendable < amount {
+			return domain.Errorf(domain.ErrInsufficientCoins, "have %d, need %d", spendable, amount)
+		}
 
-	var orderIDPgtype pgtype.Int4
-	if orderID != nil {
-		orderIDPgtype = database.Int32ToPgtype(*orderID)
-	}
+		if err := consumeCoinLots(ctx, txQueries, user, amount, now); err != nil {
+			return err
+		}
+
+		updatedUser, err = txQueries.UpdateUserCoins(ctx, database.UpdateUserCoinsParams{
+			ID:    database.UUIDToPgtype(userID),
+			Coins: database.Int32ToPgtype(int32(-amount)),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update coins: %w", database.TranslateError(err, nil))
+		}
+
+		var orderIDPgtype pgtype.Int4
+		if orderID != nil {
+			orderIDPgtype = database.Int32ToPgtype(*orderID)
+		}
 
-	coinTx, err := txQueries.CreateCoinTransaction(ctx, database.CreateCoinTransactionParams{
-		UserID:          database.UUIDToPgtype(userID),
-		TransactionType: database.TransactionType("purchase"),
-		Amount:          int32(-amount),
-		BalanceAfter:    int32(newBalance),
-		OrderID:         orderIDPgtype,
-		Description:     pgtype.Text{String: description, Valid: description != ""},
+		coinTx, err = txQueries.CreateCoinTransaction(ctx, database.CreateCoinTransactionParams{
+			UserID:          database.UUIDToPgtype(userID),
+			TransactionType: database.TransactionType("purchase"),
+			Amount:          int32(-amount),
+			BalanceAfter:    int32(newBalance),
+			OrderID:         orderIDPgtype,
+			Description:     pgtype.Text{String: description, Valid: description != ""},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create transaction: %w", database.TranslateError(err, nil))
+		}
+		return nil
 	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create transaction: %w", err)
-	}
-
-	if err := tx.Commit(ctx); err != nil {
-		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+		return nil, nil, err
 	}
 
 	userEntity := &entity.User{
-		ID:        database.PgtypeToUUID(updatedUser.ID),
-		Name:      updatedUser.Name,
-		Email:     updatedUser.Email,
-		Coins:     int(database.PgtypeToInt32(updatedUser.Coins)),
-		CreatedAt: updatedUser.CreatedAt.Time,
-		UpdatedAt: updatedUser.UpdatedAt.Time,
+		ID:             database.PgtypeToUUID(updatedUser.ID),
+		Name:           updatedUser.Name,
+		Email:          updatedUser.Email,
+		Coins:          int(database.PgtypeToInt32(updatedUser.Coins)),
+		AvailableCoins: availableCoins(updatedUser.Coins, updatedUser.HeldCoins),
+		ReferralCode:   updatedUser.ReferralCode,
+		CreatedAt:      updatedUser.CreatedAt.Time,
+		UpdatedAt:      updatedUser.UpdatedAt.Time,
 	}
 
 	transactionEntity := &entity.CoinTransaction{
@@ -238,6 +355,787 @@ func (r *coinTransactionRepository) SpendUserCoins(ctx context.Context, userID u
 	return userEntity, transactionEntity, nil
 }
 
+// ChargeCoinPack credits the pack's base coins as a charge and its bonus coins
+// as a separate bonus entry, so promotional coins can be tracked on their own.
+func (r *coinTransactionRepository) ChargeCoinPack(ctx context.Context, userID uuid.UUID, pack *entity.CoinPack) (*entity.User, []*entity.CoinTransaction, error) {
+	var updatedUser database.UpdateUserCoinsRow
+	var transactions []*entity.CoinTransaction
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		txQueries := database.QuerierFromContext(ctx, r.queries)
+
+		entries := []ledgerEntry{
+			{"charge", pack.BaseCoins, fmt.Sprintf("Purchased coin pack: %s", pack.Name)},
+		}
+		if pack.BonusCoins > 0 {
+			entries = append(entries, ledgerEntry{"bonus", pack.BonusCoins, fmt.Sprintf("Bonus coins: %s", pack.Name)})
+		}
+
+		transactions = make([]*entity.CoinTransaction, 0, len(entries))
+		for _, entry := range entries {
+			var err error
+			updatedUser, err = txQueries.UpdateUserCoins(ctx, database.UpdateUserCoinsParams{
+				ID:    database.UUIDToPgtype(userID),
+				Coins: database.Int32ToPgtype(int32(entry.amount)),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update coins: %w", database.TranslateError(err, domain.ErrUserNotFound))
+			}
+
+			coinTx, err := txQueries.CreateCoinTransaction(ctx, database.CreateCoinTransactionParams{
+				UserID:          database.UUIDToPgtype(userID),
+				TransactionType: database.TransactionType(entry.transactionType),
+				Amount:          int32(entry.amount),
+				BalanceAfter:    database.PgtypeToInt32(updatedUser.Coins),
+				Description:     pgtype.Text{String: entry.description, Valid: true},
+				CoinPackID:      database.Int32ToPgtype(pack.ID),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create transaction: %w", database.TranslateError(err, nil))
+			}
+
+			if err := r.createCoinLot(ctx, txQueries, coinTx); err != nil {
+				return err
+			}
+
+			transactions = append(transactions, dbTransactionToEntity(coinTx))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userEntity := &entity.User{
+		ID:             database.PgtypeToUUID(updatedUser.ID),
+		Name:           updatedUser.Name,
+		Email:          updatedUser.Email,
+		Coins:          int(database.PgtypeToInt32(updatedUser.Coins)),
+		AvailableCoins: availableCoins(updatedUser.Coins, updatedUser.HeldCoins),
+		ReferralCode:   updatedUser.ReferralCode,
+		CreatedAt:      updatedUser.CreatedAt.Time,
+		UpdatedAt:      updatedUser.UpdatedAt.Time,
+	}
+
+	return userEntity, transactions, nil
+}
+
+func (r *coinTransactionRepository) GetCoinBalance(ctx context.Context, userID uuid.UUID) (*entity.CoinBalance, error) {
+	user, err := database.QuerierFromContext(ctx, r.queries).GetUserByID(ctx, database.UUIDToPgtype(userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", database.TranslateError(err, domain.ErrUserNotFound))
+	}
+
+	now := time.Now()
+	rows, err := database.QuerierFromContext(ctx, r.queries).GetUpcomingCoinExpiries(ctx, database.GetUpcomingCoinExpiriesParams{
+		UserID:    database.UUIDToPgtype(userID),
+		ExpiresAt: database.TimeToPgtype(now),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get coin expiries: %w", err)
+	}
+
+	// Lots past their expiry are gone even before the worker sweeps them
+	expiredCoins, err := database.QuerierFromContext(ctx, r.queries).GetExpiredCoinLotTotal(ctx, database.GetExpiredCoinLotTotalParams{
+		UserID:    database.UUIDToPgtype(userID),
+		ExpiresAt: database.TimeToPgtype(now),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expired coins: %w", err)
+	}
+
+	expiredCoins = unheldCoins(user, expiredCoins)
+	balance := &entity.CoinBalance{
+		Coins:     int(database.PgtypeToInt32(user.Coins) - expiredCoins),
+		Held:      int(user.HeldCoins),
+		Available: availableCoins(user.Coins, user.HeldCoins) - int(expiredCoins),
+		Expiring:  make([]entity.CoinExpiryBucket, len(rows)),
+	}
+
+	expiring := 0
+	for i, row := range rows {
+		balance.Expiring[i] = entity.CoinExpiryBucket{
+			ExpiresOn: row.ExpiresOn.Time.Format("2006-01-02"),
+			Amount:    int(row.Amount),
+		}
+		expiring += int(row.Amount)
+	}
+	balance.NonExpiring = max(balance.Coins-expiring, 0)
+
+	return balance, nil
+}
+
+// GetCoinBalanceAt returns the user's settled balance just before at
+func (r *coinTransactionRepository) GetCoinBalanceAt(ctx context.Context, userID uuid.UUID, at time.Time) (int, error) {
+	balance, err := database.QuerierFromContext(ctx, r.queries).GetCoinBalanceAt(ctx, database.GetCoinBalanceAtParams{
+		At:     database.TimeToPgtype(at),
+		UserID: database.UUIDToPgtype(userID),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get balance: %w", database.TranslateError(err, domain.ErrUserNotFound))
+	}
+
+	return int(balance), nil
+}
+
+// GetCoinBalanceChanges returns the balance at from and the net change in
+// every non-empty bucket of [from, to). Buckets are cut in timeZone, an IANA
+// zone name. Both are read in one repeatable-read transaction so they agree.
+func (r *coinTransactionRepository) GetCoinBalanceChanges(ctx context.Context, userID uuid.UUID, from, to time.Time, interval, timeZone string) (int, []entity.CoinBalanceChange, error) {
+	var openingBalance int32
+	var changes []entity.CoinBalanceChange
+	err := r.txManager.WithinReadOnlyTx(ctx, func(ctx context.Context) error {
+		txQueries := database.QuerierFromContext(ctx, r.queries)
+
+		var err error
+		openingBalance, err = txQueries.GetCoinBalanceAt(ctx, database.GetCoinBalanceAtParams{
+			At:     database.TimeToPgtype(from),
+			UserID: database.UUIDToPgtype(userID),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get opening balance: %w", database.TranslateError(err, domain.ErrUserNotFound))
+		}
+
+		rows, err := txQueries.ListCoinBalanceChangesByInterval(ctx, database.ListCoinBalanceChangesByIntervalParams{
+			Bucket:      interval,
+			TimeZone:    timeZone,
+			UserID:      database.UUIDToPgtype(userID),
+			CreatedFrom: database.TimeToPgtype(from),
+			CreatedTo:   database.TimeToPgtype(to),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get balance changes: %w", err)
+		}
+
+		changes = make([]entity.CoinBalanceChange, len(rows))
+		for i, row := range rows {
+			changes[i] = entity.CoinBalanceChange{
+				BucketStart:   row.BucketStart.Time,
+				NetChange:     int(row.NetChange),
+				RunningChange: int(row.RunningChange),
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return int(openingBalance), changes, nil
+}
+
+// GrantCashback credits amount to the order's buyer as a cashback entry. An
+// order only ever earns cashback once; later calls return the existing entry.
+func (r *coinTransactionRepository) GrantCashback(ctx context.Context, order *entity.Order, amount int) (*entity.CoinTransaction, error) {
+	var transaction *entity.CoinTransaction
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		txQueries := database.QuerierFromContext(ctx, r.queries)
+
+		// Locking the buyer serializes this with other grants and reversals for the order
+		if _, err := txQueries.GetUserByIDForUpdate(ctx, database.UUIDToPgtype(order.UserID)); err != nil {
+			return fmt.Errorf("failed to lock user: %w", database.TranslateError(err, domain.ErrUserNotFound))
+		}
+
+		existing, err := r.getOrderTransaction(ctx, txQueries, order.ID, entity.TransactionTypeCashback)
+		if err != nil || existing != nil {
+			transaction = existing
+			return err
+		}
+
+		updatedUser, err := txQueries.UpdateUserCoins(ctx, database.UpdateUserCoinsParams{
+			ID:    database.UUIDToPgtype(order.UserID),
+			Coins: database.Int32ToPgtype(int32(amount)),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update coins: %w", database.TranslateError(err, nil))
+		}
+
+		coinTx, err := txQueries.CreateCoinTransaction(ctx, database.CreateCoinTransactionParams{
+			UserID:          database.UUIDToPgtype(order.UserID),
+			TransactionType: database.TransactionType(entity.TransactionTypeCashback),
+			Amount:          int32(amount),
+			BalanceAfter:    database.PgtypeToInt32(updatedUser.Coins),
+			OrderID:         database.Int32ToPgtype(order.ID),
+			Description:     database.StringToPgtype(fmt.Sprintf("Cashback for order %s", order.OrderNumber)),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create transaction: %w", database.TranslateError(err, nil))
+		}
+
+		if err := r.createCoinLot(ctx, txQueries, coinTx); err != nil {
+			return err
+		}
+
+		transaction = dbTransactionToEntity(coinTx)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// ReverseCashback takes back the cashback an order earned. Only coins that are
+// still available are taken, so a buyer who already spent the cashback is not
+// pushed below zero; the entry records what was actually reversed. Returns nil
+// if the order never earned cashback.
+func (r *coinTransactionRepository) ReverseCashback(ctx context.Context, order *entity.Order) (*entity.CoinTransaction, error) {
+	var transaction *entity.CoinTransaction
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		txQueries := database.QuerierFromContext(ctx, r.queries)
+
+		user, err := txQueries.GetUserByIDForUpdate(ctx, database.UUIDToPgtype(order.UserID))
+		if err != nil {
+			return fmt.Errorf("failed to lock user: %w", database.TranslateError(err, domain.ErrUserNotFound))
+		}
+
+		cashback, err := r.getOrderTransaction(ctx, txQueries, order.ID, entity.TransactionTypeCashback)
+		if err != nil || cashback == nil {
+			return err
+		}
+
+		existing, err := r.getOrderTransaction(ctx, txQueries, order.ID, entity.TransactionTypeCashbackReversal)
+		if err != nil || existing != nil {
+			transaction = existing
+			return err
+		}
+
+		now := time.Now()
+		spendable, err := spendableCoins(ctx, txQueries, user, now)
+		if err != nil {
+			return err
+		}
+
+		amount := min(cashback.Amount, max(spendable, 0))
+		balanceAfter := database.PgtypeToInt32(user.Coins)
+
+		if amount > 0 {
+			if err := consumeCoinLots(ctx, txQueries, user, amount, now); err != nil {
+				return err
+			}
+
+			updatedUser, err := txQueries.UpdateUserCoins(ctx, database.UpdateUserCoinsParams{
+				ID:    database.UUIDToPgtype(order.UserID),
+				Coins: database.Int32ToPgtype(int32(-amount)),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update coins: %w", database.TranslateError(err, nil))
+			}
+			balanceAfter = database.PgtypeToInt32(updatedUser.Coins)
+		}
+
+		description := fmt.Sprintf("Reversed cashback for order %s", order.OrderNumber)
+		if amount < cashback.Amount {
+			description = fmt.Sprintf("Reversed cashback for order %s (%d of %d coins)", order.OrderNumber, amount, cashback.Amount)
+		}
+
+		coinTx, err := txQueries.CreateCoinTransaction(ctx, database.CreateCoinTransactionParams{
+			UserID:          database.UUIDToPgtype(order.UserID),
+			TransactionType: database.TransactionType(entity.TransactionTypeCashbackReversal),
+			Amount:          int32(-amount),
+			BalanceAfter:    balanceAfter,
+			OrderID:         database.Int32ToPgtype(order.ID),
+			Description:     database.StringToPgtype(description),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create transaction: %w", database.TranslateError(err, nil))
+		}
+
+		transaction = dbTransactionToEntity(coinTx)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
+
+// getOrderTransaction returns the order's entry of the given type, or nil if it has none
+func (r *coinTransactionRepository) getOrderTransaction(ctx context.Context, q database.Querier, orderID int32, transactionType string) (*entity.CoinTransaction, error) {
+	dbTx, err := q.GetCoinTransactionByOrderAndType(ctx, database.GetCoinTransactionByOrderAndTypeParams{
+		OrderID:         database.Int32ToPgtype(orderID),
+		TransactionType: database.TransactionType(transactionType),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get order transaction: %w", err)
+	}
+
+	return dbTransactionToEntity(dbTx), nil
+}
+
+// RedeemGiftCode credits the value of the code with the given hash to the
+// user. The user and then the code are locked, so concurrent redemptions of
+// one code are serialized and can never exceed its maximum.
+func (r *coinTransactionRepository) RedeemGiftCode(ctx context.Context, userID uuid.UUID, codeHash string, now time.Time) (*entity.User, *entity.CoinTransaction, error) {
+	var updatedUser database.UpdateUserCoinsRow
+	var coinTx database.CoinTransaction
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		txQueries := database.QuerierFromContext(ctx, r.queries)
+
+		if _, err := txQueries.GetUserByIDForUpdate(ctx, database.UUIDToPgtype(userID)); err != nil {
+			return fmt.Errorf("failed to lock user: %w", database.TranslateError(err, domain.ErrUserNotFound))
+		}
+
+		code, err := txQueries.GetGiftCodeByHashForUpdate(ctx, codeHash)
+		if err != nil {
+			return fmt.Errorf("failed to lock gift code: %w", database.TranslateError(err, domain.ErrInvalidGiftCode))
+		}
+
+		if code.ExpiresAt.Valid && !code.ExpiresAt.Time.After(now) {
+			return domain.ErrGiftCodeExpired
+		}
+
+		if code.RedemptionCount >= code.MaxRedemptions {
+			return domain.ErrGiftCodeFullyRedeemed
+		}
+
+		redeemed, err := txQueries.GiftCodeRedeemedByUser(ctx, database.GiftCodeRedeemedByUserParams{
+			GiftCodeID: code.ID,
+			UserID:     database.UUIDToPgtype(userID),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to check gift code redemption: %w", err)
+		}
+		if redeemed {
+			return domain.ErrGiftCodeAlreadyRedeemed
+		}
+
+		if _, err := txQueries.IncrementGiftCodeRedemptions(ctx, code.ID); err != nil {
+			return fmt.Errorf("failed to update gift code: %w", database.TranslateError(err, nil))
+		}
+
+		updatedUser, err = txQueries.UpdateUserCoins(ctx, database.UpdateUserCoinsParams{
+			ID:    database.UUIDToPgtype(userID),
+			Coins: database.Int32ToPgtype(code.CoinAmount),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update coins: %w", database.TranslateError(err, nil))
+		}
+
+		coinTx, err = txQueries.CreateCoinTransaction(ctx, database.CreateCoinTransactionParams{
+			UserID:          database.UUIDToPgtype(userID),
+			TransactionType: database.TransactionType(entity.TransactionTypeGiftCode),
+			Amount:          code.CoinAmount,
+			BalanceAfter:    database.PgtypeToInt32(updatedUser.Coins),
+			Description:     database.StringToPgtype(fmt.Sprintf("Gift code ending in %s", code.CodeHint)),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create transaction: %w", database.TranslateError(err, nil))
+		}
+
+		if err := r.createCoinLot(ctx, txQueries, coinTx); err != nil {
+			return err
+		}
+
+		if _, err := txQueries.CreateGiftCodeRedemption(ctx, database.CreateGiftCodeRedemptionParams{
+			GiftCodeID:        code.ID,
+			UserID:            database.UUIDToPgtype(userID),
+			CoinTransactionID: coinTx.ID,
+		}); err != nil {
+			return fmt.Errorf("failed to record gift code redemption: %w", database.TranslateError(err, nil))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userEntity := &entity.User{
+		ID:             database.PgtypeToUUID(updatedUser.ID),
+		Name:           updatedUser.Name,
+		Email:          updatedUser.Email,
+		Coins:          int(database.PgtypeToInt32(updatedUser.Coins)),
+		AvailableCoins: availableCoins(updatedUser.Coins, updatedUser.HeldCoins),
+		ReferralCode:   updatedUser.ReferralCode,
+		CreatedAt:      updatedUser.CreatedAt.Time,
+		UpdatedAt:      updatedUser.UpdatedAt.Time,
+	}
+
+	return userEntity, dbTransactionToEntity(coinTx), nil
+}
+
+// GrantReferralBonuses pays both sides of the referee's pending referral and
+// marks it rewarded, so it pays out only once. Returns the referral and the
+// bonus entries, or nil if the referee has no pending referral.
+func (r *coinTransactionRepository) GrantReferralBonuses(ctx context.Context, refereeID uuid.UUID, policy entity.ReferralPolicy) (*entity.Referral, []*entity.CoinTransaction, error) {
+	pending, err := database.QuerierFromContext(ctx, r.queries).GetReferralByRefereeID(ctx, database.UUIDToPgtype(refereeID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to get referral: %w", err)
+	}
+	if pending.Status != database.ReferralStatusPending {
+		return nil, nil, nil
+	}
+
+	var rewardedReferral *entity.Referral
+	var bonuses []*entity.CoinTransaction
+	err = r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		txQueries := database.QuerierFromContext(ctx, r.queries)
+
+		// Both users are locked in a fixed order so that concurrent payouts
+		// between the same two users cannot deadlock
+		userIDs := []pgtype.UUID{pending.ReferrerID, pending.RefereeID}
+		if bytes.Compare(userIDs[0].Bytes[:], userIDs[1].Bytes[:]) > 0 {
+			userIDs[0], userIDs[1] = userIDs[1], userIDs[0]
+		}
+		for _, userID := range userIDs {
+			if _, err := txQueries.GetUserByIDForUpdate(ctx, userID); err != nil {
+				return fmt.Errorf("failed to lock user: %w", err)
+			}
+		}
+
+		referral, err := txQueries.GetReferralForUpdate(ctx, pending.ID)
+		if err != nil {
+			return fmt.Errorf("failed to lock referral: %w", err)
+		}
+		if referral.Status != database.ReferralStatusPending {
+			rewardedReferral, bonuses = nil, nil
+			return nil
+		}
+
+		referrerTx, err := r.grantReferralBonus(ctx, txQueries, referral.ReferrerID, policy.ReferrerBonus, "Referral bonus for inviting a friend")
+		if err != nil {
+			return err
+		}
+
+		refereeTx, err := r.grantReferralBonus(ctx, txQueries, referral.RefereeID, policy.RefereeBonus, "Referral bonus for joining")
+		if err != nil {
+			return err
+		}
+
+		rewarded, err := txQueries.MarkReferralRewarded(ctx, database.MarkReferralRewardedParams{
+			ID:                    referral.ID,
+			ReferrerTransactionID: transactionID(referrerTx),
+			RefereeTransactionID:  transactionID(refereeTx),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update referral: %w", database.TranslateError(err, nil))
+		}
+
+		rewardedReferral = dbReferralToEntity(rewarded)
+		bonuses = nil
+		for _, bonus := range []*entity.CoinTransaction{referrerTx, refereeTx} {
+			if bonus != nil {
+				bonuses = append(bonuses, bonus)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rewardedReferral, bonuses, nil
+}
+
+// grantReferralBonus credits amount to the user as a referral entry and
+// returns it, or nil when amount is zero.
+func (r *coinTransactionRepository) grantReferralBonus(ctx context.Context, q database.Querier, userID pgtype.UUID, amount int, description string) (*entity.CoinTransaction, error) {
+	if amount <= 0 {
+		return nil, nil
+	}
+
+	updatedUser, err := q.UpdateUserCoins(ctx, database.UpdateUserCoinsParams{
+		ID:    userID,
+		Coins: database.Int32ToPgtype(int32(amount)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update coins: %w", database.TranslateError(err, nil))
+	}
+
+	coinTx, err := q.CreateCoinTransaction(ctx, database.CreateCoinTransactionParams{
+		UserID:          userID,
+		TransactionType: database.TransactionType(entity.TransactionTypeReferral),
+		Amount:          int32(amount),
+		BalanceAfter:    database.PgtypeToInt32(updatedUser.Coins),
+		Description:     database.StringToPgtype(description),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", database.TranslateError(err, nil))
+	}
+
+	if err := r.createCoinLot(ctx, q, coinTx); err != nil {
+		return nil, err
+	}
+
+	return dbTransactionToEntity(coinTx), nil
+}
+
+// transactionID is the ID of tx, or a null ID when there is none
+func transactionID(tx *entity.CoinTransaction) pgtype.Int4 {
+	if tx == nil {
+		return pgtype.Int4{}
+	}
+	return database.Int32ToPgtype(tx.ID)
+}
+
+// WriteCoinStatement streams the user's transactions in [from, to) to w. The
+// opening balance and the rows are read in one repeatable-read transaction so
+// they describe the same snapshot; the closing balance is the opening balance
+// plus every streamed amount.
+func (r *coinTransactionRepository) WriteCoinStatement(ctx context.Context, userID uuid.UUID, from, to time.Time, w entity.CoinStatementWriter) error {
+	err := r.txManager.WithinReadOnlyTx(ctx, func(ctx context.Context) error {
+		txQueries := database.QuerierFromContext(ctx, r.queries)
+
+		openingBalance, err := txQueries.GetCoinBalanceAt(ctx, database.GetCoinBalanceAtParams{
+			At:     database.TimeToPgtype(from),
+			UserID: database.UUIDToPgtype(userID),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get opening balance: %w", database.TranslateError(err, domain.ErrUserNotFound))
+		}
+
+		statement := &entity.CoinStatement{
+			UserID:         userID,
+			From:           from,
+			To:             to,
+			OpeningBalance: int(openingBalance),
+			ClosingBalance: int(openingBalance),
+		}
+
+		if err := w.WriteOpening(statement); err != nil {
+			return err
+		}
+
+		err = txQueries.StreamCoinTransactionsForStatement(ctx, database.StreamCoinTransactionsForStatementParams{
+			UserID:      database.UUIDToPgtype(userID),
+			CreatedFrom: database.TimeToPgtype(from),
+			CreatedTo:   database.TimeToPgtype(to),
+		}, func(dbTx database.CoinTransaction) error {
+			statement.ClosingBalance += int(dbTx.Amount)
+			statement.TransactionCount++
+			return w.WriteTransaction(dbTransactionToEntity(dbTx))
+		})
+		if err != nil {
+			return fmt.Errorf("failed to stream transactions: %w", err)
+		}
+
+		if err := w.WriteClosing(statement); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ExpireCoinLots sweeps up to limit lots that expired before now. Each lot is
+// expired in its own transaction and written to the ledger as an expiry entry.
+// Returns how many lots were expired and the entries written for them.
+func (r *coinTransactionRepository) ExpireCoinLots(ctx context.Context, now time.Time, limit int32) (int, []*entity.CoinTransaction, error) {
+	lots, err := database.QuerierFromContext(ctx, r.queries).ListExpiredCoinLots(ctx, database.ListExpiredCoinLotsParams{
+		ExpiresAt: database.TimeToPgtype(now),
+		Limit:     limit,
+	})
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to list expired coin lots: %w", err)
+	}
+
+	expired := 0
+	var entries []*entity.CoinTransaction
+	for _, lot := range lots {
+		ok, entry, err := r.expireCoinLot(ctx, lot.UserID, lot.ID)
+		if err != nil {
+			return expired, entries, err
+		}
+		if ok {
+			expired++
+		}
+		if entry != nil {
+			entries = append(entries, entry)
+		}
+	}
+
+	return expired, entries, nil
+}
+
+// expireCoinLot takes the lot's coins from the balance and reports whether it
+// took any. Coins that active holds reserve stay on the lot, so they expire
+// once the hold is released or are spent if it is captured.
+func (r *coinTransactionRepository) expireCoinLot(ctx context.Context, userID pgtype.UUID, lotID int32) (bool, *entity.CoinTransaction, error) {
+	expired := false
+	var entry *entity.CoinTransaction
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		txQueries := database.QuerierFromContext(ctx, r.queries)
+
+		// The user is locked before the lot, in the same order as spends
+		user, err := txQueries.GetUserByIDForUpdate(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to lock user: %w", database.TranslateError(err, domain.ErrUserNotFound))
+		}
+
+		lot, err := txQueries.GetCoinLotForUpdate(ctx, lotID)
+		if err != nil {
+			return fmt.Errorf("failed to lock coin lot: %w", err)
+		}
+
+		// Never take the balance below zero or below what active holds reserve,
+		// even if it drifted from the lots
+		amount := min(lot.RemainingAmount, max(int32(availableCoins(user.Coins, user.HeldCoins)), 0))
+
+		// Another spend or sweep got here first, or holds reserve every coin
+		if amount == 0 {
+			expired, entry = false, nil
+			return nil
+		}
+
+		if err := txQueries.UpdateCoinLotRemaining(ctx, database.UpdateCoinLotRemainingParams{
+			ID:              lot.ID,
+			RemainingAmount: lot.RemainingAmount - amount,
+		}); err != nil {
+			return fmt.Errorf("failed to update coin lot: %w", database.TranslateError(err, nil))
+		}
+
+		updatedUser, err := txQueries.UpdateUserCoins(ctx, database.UpdateUserCoinsParams{
+			ID:    lot.UserID,
+			Coins: database.Int32ToPgtype(-amount),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update coins: %w", database.TranslateError(err, nil))
+		}
+
+		description := fmt.Sprintf("Expired %s coins (lot #%d)", lot.Source, lot.ID)
+		coinTx, err := txQueries.CreateCoinTransaction(ctx, database.CreateCoinTransactionParams{
+			UserID:          lot.UserID,
+			TransactionType: database.TransactionType("expiry"),
+			Amount:          -amount,
+			BalanceAfter:    database.PgtypeToInt32(updatedUser.Coins),
+			Description:     pgtype.Text{String: description, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create transaction: %w", database.TranslateError(err, nil))
+		}
+
+		expired, entry = true, dbTransactionToEntity(coinTx)
+		return nil
+	})
+	if err != nil {
+		return false, nil, err
+	}
+
+	return expired, entry, nil
+}
+
+// spendableCoins is the user's settled balance less coins reserved by holds
+// and coins in lots that have expired but not been swept yet.
+func spendableCoins(ctx context.Context, q database.Querier, user database.User, now time.Time) (int, error) {
+	expiredCoins, err := q.GetExpiredCoinLotTotal(ctx, database.GetExpiredCoinLotTotalParams{
+		UserID:    user.ID,
+		ExpiresAt: database.TimeToPgtype(now),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired coins: %w", err)
+	}
+
+	return availableCoins(user.Coins, user.HeldCoins) - int(unheldCoins(user, expiredCoins)), nil
+}
+
+// unheldCoins is the part of expired that the sweep takes from user. Coins
+// that holds reserve stay on their lots until the holds end, as in
+// expireCoinLot.
+func unheldCoins(user database.User, expired int32) int32 {
+	return min(expired, max(int32(availableCoins(user.Coins, user.HeldCoins)), 0))
+}
+
+// createCoinLot records the coins granted by coinTx as a lot that expires
+// according to the repository's expiry policy.
+func (r *coinTransactionRepository) createCoinLot(ctx context.Context, q database.Querier, coinTx database.CoinTransaction) error {
+	var expiresAt pgtype.Timestamptz
+	if t := r.expiryPolicy.ExpiresAt(string(coinTx.TransactionType), coinTx.CreatedAt.Time); t != nil {
+		expiresAt = database.TimeToPgtype(*t)
+	}
+
+	_, err := q.CreateCoinLot(ctx, database.CreateCoinLotParams{
+		UserID:            coinTx.UserID,
+		CoinTransactionID: database.Int32ToPgtype(coinTx.ID),
+		Source:            coinTx.TransactionType,
+		OriginalAmount:    coinTx.Amount,
+		ExpiresAt:         expiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create coin lot: %w", database.TranslateError(err, nil))
+	}
+
+	return nil
+}
+
+// consumeCoinLots takes amount from user's coins oldest first. user is the
+// locked row as it was before the debit. Coins outside any lot predate lot
+// tracking, so they go first; the rest comes from the open lots in the order
+// they were granted. Expired lots go last: only a captured hold reaches them,
+// for coins it reserved before they expired.
+func consumeCoinLots(ctx context.Context, q database.Querier, user database.User, amount int, now time.Time) error {
+	lots, err := q.ListSpendableCoinLotsForUpdate(ctx, database.ListSpendableCoinLotsForUpdateParams{
+		UserID:    user.ID,
+		ExpiresAt: database.TimeToPgtype(now),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get coin lots: %w", err)
+	}
+
+	expiredCoins, err := q.GetExpiredCoinLotTotal(ctx, database.GetExpiredCoinLotTotalParams{
+		UserID:    user.ID,
+		ExpiresAt: database.TimeToPgtype(now),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get expired coins: %w", err)
+	}
+
+	untracked := database.PgtypeToInt32(user.Coins) - expiredCoins
+	for _, lot := range lots {
+		untracked -= lot.RemainingAmount
+	}
+
+	remaining, err := takeFromCoinLots(ctx, q, lots, int32(amount)-min(max(untracked, 0), int32(amount)))
+	if err != nil || remaining == 0 {
+		return err
+	}
+
+	openLots, err := q.ListSpendableCoinLotsForUpdate(ctx, database.ListSpendableCoinLotsForUpdateParams{
+		UserID:    user.ID,
+		ExpiresAt: pgtype.Timestamptz{InfinityModifier: pgtype.NegativeInfinity, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get expired coin lots: %w", err)
+	}
+
+	expiredLots := slices.DeleteFunc(openLots, func(lot database.CoinLot) bool {
+		return !lot.ExpiresAt.Valid || lot.ExpiresAt.Time.After(now)
+	})
+	_, err = takeFromCoinLots(ctx, q, expiredLots, remaining)
+	return err
+}
+
+// takeFromCoinLots takes up to amount from lots in order and returns what
+// they could not cover
+func takeFromCoinLots(ctx context.Context, q database.Querier, lots []database.CoinLot, amount int32) (int32, error) {
+	for _, lot := range lots {
+		if amount == 0 {
+			break
+		}
+
+		take := min(lot.RemainingAmount, amount)
+		if err := q.UpdateCoinLotRemaining(ctx, database.UpdateCoinLotRemainingParams{
+			ID:              lot.ID,
+			RemainingAmount: lot.RemainingAmount - take,
+		}); err != nil {
+			return amount, fmt.Errorf("failed to update coin lot: %w", database.TranslateError(err, nil))
+		}
+		amount -= take
+	}
+
+	return amount, nil
+}
+
 func dbTransactionToEntity(dbTx database.CoinTransaction) *entity.CoinTransaction {
 	transaction := &entity.CoinTransaction{
 		ID:              dbTx.ID,
@@ -254,5 +1152,10 @@ func dbTransactionToEntity(dbTx database.CoinTransaction) *entity.CoinTransactio
 		transaction.OrderID = &orderID
 	}
 
+	if dbTx.CoinPackID.Valid {
+		coinPackID := dbTx.CoinPackID.Int32
+		transaction.CoinPackID = &coinPackID
+	}
+
 	return transaction
 }
@@ -3,98 +3,47 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"backend/internal/database"
+	"backend/internal/database/memdb"
+	"backend/internal/domain"
 	"backend/internal/entity"
 	"backend/mocks"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
-// ---- Test Repository ----
-
-type testCoinTransactionRepository struct {
-	queries *mocks.MockCoinTransactionQueries
-	db      *mocks.MockDB
-}
-
-func NewTestCoinTransactionRepository(queries *mocks.MockCoinTransactionQueries, db *mocks.MockDB) CoinTransactionRepository {
-	return &testCoinTransactionRepository{
-		queries: queries,
-		db:      db,
-	}
-}
-
-func (r *testCoinTransactionRepository) CreateCoinTransaction(ctx context.Context, params CreateCoinTransactionParams) (*entity.CoinTransaction, error) {
-	var orderID pgtype.Int4
-	if params.OrderID != nil {
-		orderID = database.Int32ToPgtype(*params.OrderID)
-	}
-
-	dbTransaction, err := r.queries.CreateCoinTransaction(ctx, database.CreateCoinTransactionParams{
-		UserID:          database.UUIDToPgtype(params.UserID),
-		TransactionType: database.TransactionType(params.TransactionType),
-		Amount:          int32(params.Amount),
-		BalanceAfter:    int32(params.BalanceAfter),
-		OrderID:         orderID,
-		Description:     pgtype.Text{String: params.Description, Valid: params.Description != ""},
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return dbTransactionToEntity(dbTransaction), nil
-}
-
-func (r *testCoinTransactionRepository) GetTransactionsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*entity.CoinTransaction, error) {
-	dbTransactions, err := r.queries.GetCoinTransactionsByUserID(ctx, database.GetCoinTransactionsByUserIDParams{
-		UserID: database.UUIDToPgtype(userID),
-		Limit:  limit,
-		Offset: offset,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	transactions := make([]*entity.CoinTransaction, len(dbTransactions))
-	for i, dbTx := range dbTransactions {
-		transactions[i] = dbTransactionToEntity(dbTx)
-	}
-
-	return transactions, nil
-}
-
-func (r *testCoinTransactionRepository) GetTransactionByID(ctx context.Context, id int32) (*entity.CoinTransaction, error) {
-	dbTransaction, err := r.queries.GetCoinTransactionByID(ctx, id)
-	if err != nil {
-		return nil, err
-	}
-
-	return dbTransactionToEntity(dbTransaction), nil
-}
+// ---- Helper Functions ----
 
-func (r *testCoinTransactionRepository) ChargeUserCoins(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32) (*entity.User, *entity.CoinTransaction, error) {
-	// This would involve complex transaction mocking - simplified for testing
-	return nil, nil, errors.New("not implemented in test")
+func setupCoinTransactionTestRepository(t *testing.T) (CoinTransactionRepository, *mocks.MockCoinStatementQuerier, *mocks.MockTxManager) {
+	mockQueries := mocks.NewMockCoinStatementQuerier(t)
+	mockTxManager := mocks.NewMockTxManager(t)
+	repo := NewCoinTransactionRepository(mockQueries, mockTxManager, entity.CoinExpiryPolicy{ChargeMonths: 12})
+	return repo, mockQueries, mockTxManager
 }
 
-func (r *testCoinTransactionRepository) SpendUserCoins(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32) (*entity.User, *entity.CoinTransaction, error) {
-	// This would involve complex transaction mocking - simplified for testing
-	return nil, nil, errors.New("not implemented in test")
+// setupMemoryCoinTransactionRepository runs the repository on an in-memory
+// database, for the flows that move coins
+func setupMemoryCoinTransactionRepository(t *testing.T, policy entity.CoinExpiryPolicy) (CoinTransactionRepository, *memdb.Queries) {
+	db, txManager := newTestDB(t)
+	return NewCoinTransactionRepository(db, txManager, policy), db
 }
 
-// ---- Helper Functions ----
-
-func setupCoinTransactionTestRepository() (CoinTransactionRepository, *mocks.MockCoinTransactionQueries, *mocks.MockDB) {
-	mockQueries := new(mocks.MockCoinTransactionQueries)
-	mockDB := new(mocks.MockDB)
-	repo := NewTestCoinTransactionRepository(mockQueries, mockDB)
-	return repo, mockQueries, mockDB
+// expectWithinTx makes the transaction manager run the unit of work directly
+func expectWithinTx(mockTxManager *mocks.MockTxManager) {
+	mockTxManager.EXPECT().WithinTx(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
 }
 
 func sampleDBCoinTransaction(id int32, userID uuid.UUID, txType string, amount int32) database.CoinTransaction {
@@ -124,7 +73,7 @@ func sampleUser(userID uuid.UUID, coins int32) database.User {
 // ---- Tests ----
 
 func TestCreateCoinTransaction_Success(t *testing.T) {
-	repo, mockQ, _ := setupCoinTransactionTestRepository()
+	repo, mockQ, _ := setupCoinTransactionTestRepository(t)
 
 	userID := uuid.New()
 	params := CreateCoinTransactionParams{
@@ -136,7 +85,7 @@ func TestCreateCoinTransaction_Success(t *testing.T) {
 	}
 
 	expectedDB := sampleDBCoinTransaction(1, userID, "charge", 100)
-	mockQ.On("CreateCoinTransaction", mock.Anything, mock.MatchedBy(func(p database.CreateCoinTransactionParams) bool {
+	mockQ.EXPECT().CreateCoinTransaction(mock.Anything, mock.MatchedBy(func(p database.CreateCoinTransactionParams) bool {
 		return database.PgtypeToUUID(p.UserID) == userID &&
 			string(p.TransactionType) == "charge" &&
 			p.Amount == 100 &&
@@ -147,17 +96,15 @@ func TestCreateCoinTransaction_Success(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.NotNil(t, transaction)
-	assert.Equal(t, 1, transaction.ID)
+	assert.Equal(t, int32(1), transaction.ID)
 	assert.Equal(t, userID, transaction.UserID)
 	assert.Equal(t, "charge", transaction.TransactionType)
 	assert.Equal(t, 100, transaction.Amount)
 	assert.Equal(t, 1100, transaction.BalanceAfter)
-
-	mockQ.AssertExpectations(t)
 }
 
 func TestCreateCoinTransaction_WithOrderID(t *testing.T) {
-	repo, mockQ, _ := setupCoinTransactionTestRepository()
+	repo, mockQ, _ := setupCoinTransactionTestRepository(t)
 
 	userID := uuid.New()
 	orderID := int32(123)
@@ -173,7 +120,7 @@ func TestCreateCoinTransaction_WithOrderID(t *testing.T) {
 	expectedDB := sampleDBCoinTransaction(2, userID, "purchase", -50)
 	expectedDB.OrderID = database.Int32ToPgtype(orderID)
 
-	mockQ.On("CreateCoinTransaction", mock.Anything, mock.MatchedBy(func(p database.CreateCoinTransactionParams) bool {
+	mockQ.EXPECT().CreateCoinTransaction(mock.Anything, mock.MatchedBy(func(p database.CreateCoinTransactionParams) bool {
 		return p.OrderID.Valid && p.OrderID.Int32 == orderID
 	})).Return(expectedDB, nil)
 
@@ -183,12 +130,10 @@ func TestCreateCoinTransaction_WithOrderID(t *testing.T) {
 	assert.NotNil(t, transaction)
 	assert.NotNil(t, transaction.OrderID)
 	assert.Equal(t, orderID, *transaction.OrderID)
-
-	mockQ.AssertExpectations(t)
 }
 
 func TestCreateCoinTransaction_Error(t *testing.T) {
-	repo, mockQ, _ := setupCoinTransactionTestRepository()
+	repo, mockQ, _ := setupCoinTransactionTestRepository(t)
 
 	userID := uuid.New()
 	params := CreateCoinTransactionParams{
@@ -199,20 +144,17 @@ func TestCreateCoinTransaction_Error(t *testing.T) {
 		Description:     "Test transaction",
 	}
 
-	mockQ.On("CreateCoinTransaction", mock.Anything, mock.Anything).
+	mockQ.EXPECT().CreateCoinTransaction(mock.Anything, mock.Anything).
 		Return(database.CoinTransaction{}, errors.New("invalid transaction type"))
 
 	transaction, err := repo.CreateCoinTransaction(context.Background(), params)
 
-	assert.Error(t, err)
 	assert.Nil(t, transaction)
-	assert.Contains(t, err.Error(), "invalid transaction type")
-
-	mockQ.AssertExpectations(t)
+	assert.EqualError(t, err, "failed to create transaction: invalid transaction type")
 }
 
 func TestGetTransactionsByUserID_Success(t *testing.T) {
-	repo, mockQ, _ := setupCoinTransactionTestRepository()
+	repo, mockQ, _ := setupCoinTransactionTestRepository(t)
 
 	userID := uuid.New()
 	dbTransactions := []database.CoinTransaction{
@@ -220,7 +162,7 @@ func TestGetTransactionsByUserID_Success(t *testing.T) {
 		sampleDBCoinTransaction(2, userID, "purchase", -50),
 	}
 
-	mockQ.On("GetCoinTransactionsByUserID", mock.Anything, database.GetCoinTransactionsByUserIDParams{
+	mockQ.EXPECT().GetCoinTransactionsByUserID(mock.Anything, database.GetCoinTransactionsByUserIDParams{
 		UserID: database.UUIDToPgtype(userID),
 		Limit:  10,
 		Offset: 0,
@@ -232,12 +174,10 @@ func TestGetTransactionsByUserID_Success(t *testing.T) {
 	assert.Len(t, transactions, 2)
 	assert.Equal(t, "charge", transactions[0].TransactionType)
 	assert.Equal(t, "purchase", transactions[1].TransactionType)
-
-	mockQ.AssertExpectations(t)
 }
 
 func TestGetTransactionsByUserID_WithPagination(t *testing.T) {
-	repo, mockQ, _ := setupCoinTransactionTestRepository()
+	repo, mockQ, _ := setupCoinTransactionTestRepository(t)
 
 	userID := uuid.New()
 	dbTransactions := []database.CoinTransaction{
@@ -245,7 +185,7 @@ func TestGetTransactionsByUserID_WithPagination(t *testing.T) {
 	}
 
 	// Test page 2 with limit 5 (offset = 5)
-	mockQ.On("GetCoinTransactionsByUserID", mock.Anything, database.GetCoinTransactionsByUserIDParams{
+	mockQ.EXPECT().GetCoinTransactionsByUserID(mock.Anything, database.GetCoinTransactionsByUserIDParams{
 		UserID: database.UUIDToPgtype(userID),
 		Limit:  5,
 		Offset: 5,
@@ -256,16 +196,14 @@ func TestGetTransactionsByUserID_WithPagination(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, transactions, 1)
 	assert.Equal(t, "refund", transactions[0].TransactionType)
-
-	mockQ.AssertExpectations(t)
 }
 
 func TestGetTransactionsByUserID_Empty(t *testing.T) {
-	repo, mockQ, _ := setupCoinTransactionTestRepository()
+	repo, mockQ, _ := setupCoinTransactionTestRepository(t)
 
 	userID := uuid.New()
 
-	mockQ.On("GetCoinTransactionsByUserID", mock.Anything, database.GetCoinTransactionsByUserIDParams{
+	mockQ.EXPECT().GetCoinTransactionsByUserID(mock.Anything, database.GetCoinTransactionsByUserIDParams{
 		UserID: database.UUIDToPgtype(userID),
 		Limit:  10,
 		Offset: 0,
@@ -275,59 +213,679 @@ func TestGetTransactionsByUserID_Empty(t *testing.T) {
 
 	assert.NoError(t, err)
 	assert.Len(t, transactions, 0)
-
-	mockQ.AssertExpectations(t)
 }
 
 func TestGetTransactionByID_Found(t *testing.T) {
-	repo, mockQ, _ := setupCoinTransactionTestRepository()
+	repo, mockQ, _ := setupCoinTransactionTestRepository(t)
 
 	userID := uuid.New()
 	expectedDB := sampleDBCoinTransaction(1, userID, "charge", 100)
 
-	mockQ.On("GetCoinTransactionByID", mock.Anything, int32(1)).Return(expectedDB, nil)
+	mockQ.EXPECT().GetCoinTransactionByID(mock.Anything, int32(1)).Return(expectedDB, nil)
 
 	transaction, err := repo.GetTransactionByID(context.Background(), 1)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, transaction)
-	assert.Equal(t, 1, transaction.ID)
+	assert.Equal(t, int32(1), transaction.ID)
 	assert.Equal(t, userID, transaction.UserID)
 	assert.Equal(t, "charge", transaction.TransactionType)
-
-	mockQ.AssertExpectations(t)
 }
 
 func TestGetTransactionByID_NotFound(t *testing.T) {
-	repo, mockQ, _ := setupCoinTransactionTestRepository()
+	repo, mockQ, _ := setupCoinTransactionTestRepository(t)
 
-	mockQ.On("GetCoinTransactionByID", mock.Anything, int32(999)).
-		Return(database.CoinTransaction{}, errors.New("transaction not found"))
+	mockQ.EXPECT().GetCoinTransactionByID(mock.Anything, int32(999)).
+		Return(database.CoinTransaction{}, pgx.ErrNoRows)
 
 	transaction, err := repo.GetTransactionByID(context.Background(), 999)
 
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrTransactionNotFound)
 	assert.Nil(t, transaction)
-	assert.Contains(t, err.Error(), "transaction not found")
-
-	mockQ.AssertExpectations(t)
 }
 
 func TestGetTransactionsByUserID_DatabaseError(t *testing.T) {
-	repo, mockQ, _ := setupCoinTransactionTestRepository()
+	repo, mockQ, _ := setupCoinTransactionTestRepository(t)
 
 	userID := uuid.New()
 
-	mockQ.On("GetCoinTransactionsByUserID", mock.Anything, mock.Anything).
-		Return(nil, errors.New("database connection error"))
+	mockQ.EXPECT().GetCoinTransactionsByUserID(mock.Anything, mock.Anything).
+		Return([]database.CoinTransaction(nil), errors.New("database connection error"))
 
 	transactions, err := repo.GetTransactionsByUserID(context.Background(), userID, 10, 0)
 
 	assert.Error(t, err)
 	assert.Nil(t, transactions)
 	assert.Contains(t, err.Error(), "database connection error")
+}
+
+func TestChargeUserCoins_Success(t *testing.T) {
+	repo, mockQ, mockTxManager := setupCoinTransactionTestRepository(t)
+	expectWithinTx(mockTxManager)
+
+	userID := uuid.New()
+	orderID := int32(7)
+	user := sampleUser(userID, 1000)
+
+	mockQ.EXPECT().GetUserByID(mock.Anything, user.ID).Return(user, nil)
+	mockQ.EXPECT().UpdateUserCoins(mock.Anything, database.UpdateUserCoinsParams{
+		ID:    user.ID,
+		Coins: database.Int32ToPgtype(100),
+	}).Return(database.UpdateUserCoinsRow{ID: user.ID, Name: user.Name, Email: user.Email, Coins: database.Int32ToPgtype(1100), HeldCoins: 50}, nil)
+
+	dbTx := sampleDBCoinTransaction(1, userID, "charge", 100)
+	dbTx.OrderID = database.Int32ToPgtype(orderID)
+	mockQ.EXPECT().CreateCoinTransaction(mock.Anything, mock.MatchedBy(func(p database.CreateCoinTransactionParams) bool {
+		return p.TransactionType == "charge" && p.Amount == 100 && p.BalanceAfter == 1100 && p.OrderID.Int32 == orderID
+	})).Return(dbTx, nil)
+
+	// Charged coins follow the charge expiry of the policy
+	mockQ.EXPECT().CreateCoinLot(mock.Anything, mock.MatchedBy(func(p database.CreateCoinLotParams) bool {
+		return p.CoinTransactionID.Int32 == dbTx.ID && p.OriginalAmount == 100 && p.ExpiresAt.Valid
+	})).Return(database.CoinLot{}, nil)
+
+	updatedUser, transaction, err := repo.ChargeUserCoins(context.Background(), userID, 100, "Test charge", &orderID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1100, updatedUser.Coins)
+	assert.Equal(t, 1050, updatedUser.AvailableCoins)
+	assert.Equal(t, int32(1), transaction.ID)
+	assert.Equal(t, orderID, *transaction.OrderID)
+}
+
+func TestChargeUserCoins_UserNotFound(t *testing.T) {
+	repo, mockQ, mockTxManager := setupCoinTransactionTestRepository(t)
+	expectWithinTx(mockTxManager)
+
+	userID := uuid.New()
+	mockQ.EXPECT().GetUserByID(mock.Anything, database.UUIDToPgtype(userID)).Return(database.User{}, pgx.ErrNoRows)
+
+	updatedUser, transaction, err := repo.ChargeUserCoins(context.Background(), userID, 100, "Test charge", nil)
+
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	assert.Nil(t, updatedUser)
+	assert.Nil(t, transaction)
+}
+
+func TestChargeUserCoins_TransactionFails(t *testing.T) {
+	repo, _, mockTxManager := setupCoinTransactionTestRepository(t)
+
+	// Nothing is returned when the unit of work does not commit
+	mockTxManager.EXPECT().WithinTx(mock.Anything, mock.Anything).Return(errors.New("commit failed"))
+
+	updatedUser, transaction, err := repo.ChargeUserCoins(context.Background(), uuid.New(), 100, "Test charge", nil)
+
+	assert.EqualError(t, err, "commit failed")
+	assert.Nil(t, updatedUser)
+	assert.Nil(t, transaction)
+}
+
+func TestChargeCoinPack_WritesBaseAndBonusEntries(t *testing.T) {
+	repo, db := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{ChargeMonths: 12, BonusMonths: 6})
+	userID := insertTestUser(t, db, 100)
+	pack := insertTestCoinPack(t, db, "5000 + 500 Bonus", 5000, 500)
+
+	user, transactions, err := repo.ChargeCoinPack(context.Background(), userID, pack)
+
+	require.NoError(t, err)
+	assert.Equal(t, 5600, user.Coins)
+	require.Len(t, transactions, 2)
+	assert.Equal(t, "charge", transactions[0].TransactionType)
+	assert.Equal(t, 5000, transactions[0].Amount)
+	assert.Equal(t, 5100, transactions[0].BalanceAfter)
+	assert.Equal(t, "bonus", transactions[1].TransactionType)
+	assert.Equal(t, 500, transactions[1].Amount)
+	assert.Equal(t, 5600, transactions[1].BalanceAfter)
+	for _, tx := range transactions {
+		require.NotNil(t, tx.CoinPackID)
+		assert.Equal(t, pack.ID, *tx.CoinPackID)
+	}
+
+	// Each entry gets a lot with its own expiry
+	lots := listTestLots(t, db, userID)
+	require.Len(t, lots, 2)
+	byTransaction := map[int32]database.CoinLot{}
+	for _, lot := range lots {
+		byTransaction[lot.CoinTransactionID.Int32] = lot
+	}
+	charge, bonus := byTransaction[transactions[0].ID], byTransaction[transactions[1].ID]
+	assert.Equal(t, int32(5000), charge.RemainingAmount)
+	assert.Equal(t, int32(500), bonus.RemainingAmount)
+	assert.Equal(t, transactions[0].CreatedAt.AddDate(0, 12, 0), charge.ExpiresAt.Time)
+	assert.Equal(t, transactions[1].CreatedAt.AddDate(0, 6, 0), bonus.ExpiresAt.Time)
+}
+
+func TestChargeCoinPack_NoBonusEntryWithoutBonus(t *testing.T) {
+	repo, db := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{})
+	userID := insertTestUser(t, db, 0)
+	pack := insertTestCoinPack(t, db, "1000 Coins", 1000, 0)
+
+	user, transactions, err := repo.ChargeCoinPack(context.Background(), userID, pack)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1000, user.Coins)
+	require.Len(t, transactions, 1)
+	assert.Equal(t, "charge", transactions[0].TransactionType)
+
+	// Without an expiry policy the lot never expires
+	lots := listTestLots(t, db, userID)
+	require.Len(t, lots, 1)
+	assert.False(t, lots[0].ExpiresAt.Valid)
+}
+
+func TestChargeCoinPack_UnknownUserWritesNothing(t *testing.T) {
+	repo, db := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{})
+	userID := uuid.New()
+
+	user, transactions, err := repo.ChargeCoinPack(context.Background(), userID, &entity.CoinPack{ID: 2, Name: "5000 + 500 Bonus", BaseCoins: 5000, BonusCoins: 500})
+
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	assert.Nil(t, user)
+	assert.Nil(t, transactions)
+	assert.Empty(t, listTestTransactions(t, db, userID))
+}
+
+func TestSpendUserCoins_ConsumesOldestCoinsFirst(t *testing.T) {
+	repo, db := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{})
+	now := time.Now()
+	userID := insertTestUser(t, db, 300)
+	nextMonth, tomorrow := now.AddDate(0, 0, 30), now.AddDate(0, 0, 1)
+	older := insertTestLot(t, db, userID, 100, now.AddDate(0, 0, -2), &nextMonth)
+	newer := insertTestLot(t, db, userID, 100, now.AddDate(0, 0, -1), &tomorrow)
+
+	// 100 coins sit outside any lot and go before either lot
+	user, transaction, err := repo.SpendUserCoins(context.Background(), userID, 150, "Order", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, 150, user.Coins)
+	assert.Equal(t, -150, transaction.Amount)
+	remaining := map[int32]int32{}
+	for _, lot := range listTestLots(t, db, userID) {
+		remaining[lot.ID] = lot.RemainingAmount
+	}
+	assert.Equal(t, map[int32]int32{older.ID: 50, newer.ID: 100}, remaining)
+}
+
+func TestSpendUserCoins_SkipsExpiredLots(t *testing.T) {
+	repo, db := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{})
+	now := time.Now()
+	hourAgo := now.Add(-time.Hour)
+	userID := insertTestUser(t, db, 100)
+	insertTestLot(t, db, userID, 100, now.AddDate(0, -6, 0), &hourAgo)
+
+	user, transaction, err := repo.SpendUserCoins(context.Background(), userID, 50, "Order", nil)
+
+	assert.ErrorIs(t, err, domain.ErrInsufficientCoins)
+	assert.Nil(t, user)
+	assert.Nil(t, transaction)
+	assert.Equal(t, int32(100), getTestUser(t, db, userID).Coins.Int32)
+}
+
+func TestGetCoinBalance_ExcludesUnsweptExpiredLots(t *testing.T) {
+	repo, db := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{})
+	now := time.Now()
+	hourAgo := now.Add(-time.Hour)
+	userID := insertTestUser(t, db, 150)
+	insertTestLot(t, db, userID, 100, now.AddDate(0, -6, 0), &hourAgo)
+	inTenDays := now.AddDate(0, 0, 10)
+	insertTestLot(t, db, userID, 50, now, &inTenDays)
+
+	balance, err := repo.GetCoinBalance(context.Background(), userID)
+
+	require.NoError(t, err)
+	assert.Equal(t, 50, balance.Coins)
+	assert.Equal(t, 50, balance.Available)
+	assert.Equal(t, 0, balance.NonExpiring)
+	require.Len(t, balance.Expiring, 1)
+	assert.Equal(t, 50, balance.Expiring[0].Amount)
+}
+
+func TestExpireCoinLots_WritesExpiryEntries(t *testing.T) {
+	repo, db := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{})
+	now := time.Now()
+	hourAgo := now.Add(-time.Hour)
+	userID := insertTestUser(t, db, 150)
+	insertTestLot(t, db, userID, 100, now.AddDate(0, -6, 0), &hourAgo)
+	kept := insertTestLot(t, db, userID, 50, now, nil)
+
+	expired, entries, err := repo.ExpireCoinLots(context.Background(), now, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	require.Len(t, entries, 1)
+	assert.Equal(t, entity.TransactionTypeExpiry, entries[0].TransactionType)
+	assert.Equal(t, -100, entries[0].Amount)
+	assert.Equal(t, int32(50), getTestUser(t, db, userID).Coins.Int32)
+	transactions := listTestTransactions(t, db, userID)
+	require.Len(t, transactions, 1)
+	assert.Equal(t, database.TransactionType("expiry"), transactions[0].TransactionType)
+	assert.Equal(t, int32(-100), transactions[0].Amount)
+	assert.Equal(t, int32(50), transactions[0].BalanceAfter)
+	lots := listTestLots(t, db, userID)
+	require.Len(t, lots, 1)
+	assert.Equal(t, kept.ID, lots[0].ID)
+
+	// A second sweep finds nothing left to expire
+	expired, entries, err = repo.ExpireCoinLots(context.Background(), now, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, expired)
+	assert.Empty(t, entries)
+}
+
+func TestExpireCoinLots_HeldCoinsExpireOnceReleased(t *testing.T) {
+	ctx := context.Background()
+	db, txManager := newTestDB(t)
+	repo := NewCoinTransactionRepository(db, txManager, entity.CoinExpiryPolicy{})
+	holdRepo := NewCoinHoldRepository(db, txManager)
+	now := time.Now()
+	hourAgo := now.Add(-time.Hour)
+	userID := insertTestUser(t, db, 100)
+	lot := insertTestLot(t, db, userID, 100, now.AddDate(0, -6, 0), &hourAgo)
+	hold := insertTestHold(t, db, userID, 100)
+
+	expired, entries, err := repo.ExpireCoinLots(ctx, now, 10)
+
+	require.NoError(t, err)
+	assert.Zero(t, expired)
+	assert.Empty(t, entries)
+	balance, err := repo.GetCoinBalance(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 100, balance.Coins)
+	assert.Equal(t, 0, balance.Available)
+
+	_, err = holdRepo.ReleaseCoinHold(ctx, userID, hold.ID)
+	require.NoError(t, err)
+
+	// Released, the coins are gone even before the sweep
+	balance, err = repo.GetCoinBalance(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, balance.Coins)
+	assert.Equal(t, 0, balance.Available)
+	_, _, err = repo.SpendUserCoins(ctx, userID, 50, "Order", nil)
+	assert.ErrorIs(t, err, domain.ErrInsufficientCoins)
+
+	expired, entries, err = repo.ExpireCoinLots(ctx, now, 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	require.Len(t, entries, 1)
+	assert.Equal(t, -100, entries[0].Amount)
+	assert.Equal(t, 0, entries[0].BalanceAfter)
+	assert.Zero(t, getTestUser(t, db, userID).Coins.Int32)
+	remaining, err := db.GetCoinLotForUpdate(ctx, lot.ID)
+	require.NoError(t, err)
+	assert.Zero(t, remaining.RemainingAmount)
+}
+
+func TestExpireCoinLots_CapturedHoldSpendsExpiredCoins(t *testing.T) {
+	ctx := context.Background()
+	db, txManager := newTestDB(t)
+	repo := NewCoinTransactionRepository(db, txManager, entity.CoinExpiryPolicy{})
+	holdRepo := NewCoinHoldRepository(db, txManager)
+	now := time.Now()
+	hourAgo := now.Add(-time.Hour)
+	userID := insertTestUser(t, db, 150)
+	insertTestLot(t, db, userID, 100, now.AddDate(0, -6, 0), &hourAgo)
+	insertTestLot(t, db, userID, 50, now.AddDate(0, -1, 0), nil)
+	hold := insertTestHold(t, db, userID, 100)
+
+	// Only the 50 coins that are not held expire
+	expired, entries, err := repo.ExpireCoinLots(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	require.Len(t, entries, 1)
+	assert.Equal(t, -50, entries[0].Amount)
+
+	_, transaction, err := holdRepo.CaptureCoinHold(ctx, userID, hold.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, -100, transaction.Amount)
+	assert.Equal(t, 0, transaction.BalanceAfter)
+	assert.Empty(t, listTestLots(t, db, userID))
+	expired, entries, err = repo.ExpireCoinLots(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Zero(t, expired)
+	assert.Empty(t, entries)
+}
+
+func TestGetCoinBalanceAt_ReadsBalanceAfterFromTheLedger(t *testing.T) {
+	ctx := context.Background()
+	repo, db := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{})
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	// The current balance disagrees with the ledger, so it cannot be what is read
+	userID := insertTestUser(t, db, 9999)
+	for i, balanceAfter := range []int32{100, 250, 175} {
+		_, err := db.InsertCoinTransaction(ctx, database.CoinTransaction{
+			UserID:          database.UUIDToPgtype(userID),
+			TransactionType: database.TransactionTypeCharge,
+			Amount:          balanceAfter,
+			BalanceAfter:    balanceAfter,
+			CreatedAt:       database.TimeToPgtype(start.AddDate(0, 0, i)),
+		})
+		require.NoError(t, err)
+	}
+
+	for _, tc := range []struct {
+		at   time.Time
+		want int
+	}{
+		{start, 0},
+		{start.Add(time.Hour), 100},
+		{start.AddDate(0, 0, 1), 100},
+		{start.AddDate(0, 0, 2).Add(time.Hour), 175},
+	} {
+		balance, err := repo.GetCoinBalanceAt(ctx, userID, tc.at)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, balance, "at %s", tc.at)
+	}
+
+	_, err := repo.GetCoinBalanceAt(ctx, uuid.New(), start)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestGetFilteredTransactions_SummaryCountsEveryType(t *testing.T) {
+	repo, db := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{})
+	userID := insertTestUser(t, db, 0)
+	amounts := map[string]int32{
+		"charge": 1000, "bonus": 100, "cashback": 30, "gift_code": 50, "referral": 200,
+		"purchase": -400, "expiry": -60, "cashback_reversal": -30,
+		"refund": 120,
+	}
+	net := 0
+	for typ, amount := range amounts {
+		_, err := db.InsertCoinTransaction(context.Background(), database.CoinTransaction{
+			UserID:          database.UUIDToPgtype(userID),
+			TransactionType: database.TransactionType(typ),
+			Amount:          amount,
+		})
+		require.NoError(t, err)
+		net += int(amount)
+	}
+
+	transactions, summary, err := repo.GetFilteredTransactions(context.Background(), userID, entity.CoinTransactionFilter{Page: 1, Limit: 20})
+
+	require.NoError(t, err)
+	assert.Len(t, transactions, len(amounts))
+	assert.Equal(t, &entity.CoinTransactionSummary{
+		TotalCount:    len(amounts),
+		TotalCharged:  1380,
+		TotalSpent:    490,
+		TotalRefunded: 120,
+	}, summary)
+	assert.Equal(t, net, summary.TotalCharged+summary.TotalRefunded-summary.TotalSpent)
+}
+
+func TestGrantReferralBonuses_LocksUsersInIDOrder(t *testing.T) {
+	repo, mockQueries, mockTxManager := setupCoinTransactionTestRepository(t)
+	ctx := context.Background()
+	expectWithinTx(mockTxManager)
+
+	// The referrer sorts after the referee, so the referee is locked first
+	referrerID := uuid.MustParse("ffffffff-0000-4000-8000-000000000000")
+	refereeID := uuid.MustParse("00000000-0000-4000-8000-000000000000")
+	pending := database.Referral{
+		ID:         3,
+		ReferrerID: database.UUIDToPgtype(referrerID),
+		RefereeID:  database.UUIDToPgtype(refereeID),
+		Status:     database.ReferralStatusPending,
+	}
+
+	mockQueries.EXPECT().GetReferralByRefereeID(mock.Anything, database.UUIDToPgtype(refereeID)).Return(pending, nil)
+	mock.InOrder(
+		mockQueries.EXPECT().GetUserByIDForUpdate(mock.Anything, database.UUIDToPgtype(refereeID)).Return(sampleUser(refereeID, 0), nil).Call,
+		mockQueries.EXPECT().GetUserByIDForUpdate(mock.Anything, database.UUIDToPgtype(referrerID)).Return(sampleUser(referrerID, 0), nil).Call,
+		// Paid by a concurrent payout while the locks were awaited
+		mockQueries.EXPECT().GetReferralForUpdate(mock.Anything, int32(3)).Return(database.Referral{ID: 3, Status: database.ReferralStatusRewarded}, nil).Call,
+	)
+
+	referral, bonuses, err := repo.GrantReferralBonuses(ctx, refereeID, entity.ReferralPolicy{ReferrerBonus: 500, RefereeBonus: 300})
+
+	assert.NoError(t, err)
+	assert.Nil(t, referral)
+	assert.Empty(t, bonuses)
+}
+
+func TestGrantReferralBonuses_PaysOnce(t *testing.T) {
+	ctx := context.Background()
+	repo, db := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{})
+	referrerID := insertTestUser(t, db, 0)
+	refereeID := insertTestUser(t, db, 0)
+	_, err := db.CreateReferral(ctx, database.CreateReferralParams{
+		ReferrerID: database.UUIDToPgtype(referrerID),
+		RefereeID:  database.UUIDToPgtype(refereeID),
+		Status:     database.ReferralStatusPending,
+	})
+	require.NoError(t, err)
+	policy := entity.ReferralPolicy{ReferrerBonus: 500, RefereeBonus: 300}
+
+	first, bonuses, err := repo.GrantReferralBonuses(ctx, refereeID, policy)
+	require.NoError(t, err)
+	second, _, err := repo.GrantReferralBonuses(ctx, refereeID, policy)
+	require.NoError(t, err)
+
+	require.NotNil(t, first)
+	assert.Equal(t, entity.ReferralStatusRewarded, first.Status)
+	require.Len(t, bonuses, 2)
+	assert.Equal(t, 500, bonuses[0].Amount)
+	assert.Equal(t, 300, bonuses[1].Amount)
+	assert.Nil(t, second)
+
+	assert.Equal(t, int32(500), database.PgtypeToInt32(getTestUser(t, db, referrerID).Coins))
+	assert.Equal(t, int32(300), database.PgtypeToInt32(getTestUser(t, db, refereeID).Coins))
+	assert.Len(t, listTestTransactions(t, db, referrerID), 1)
+	assert.Len(t, listTestTransactions(t, db, refereeID), 1)
+}
+
+func TestGrantCashback_PaysOnce(t *testing.T) {
+	ctx := context.Background()
+	repo, db := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{BonusMonths: 6})
+	userID := insertTestUser(t, db, 0)
+	dbOrder := insertTestOrder(t, db, userID, 1000)
+	order := &entity.Order{ID: dbOrder.ID, UserID: userID, OrderNumber: dbOrder.OrderNumber}
+
+	first, err := repo.GrantCashback(ctx, order, 50)
+	require.NoError(t, err)
+	second, err := repo.GrantCashback(ctx, order, 50)
+	require.NoError(t, err)
+
+	assert.Equal(t, entity.TransactionTypeCashback, first.TransactionType)
+	assert.Equal(t, 50, first.Amount)
+	assert.Equal(t, 50, first.BalanceAfter)
+	require.NotNil(t, first.OrderID)
+	assert.Equal(t, order.ID, *first.OrderID)
+	assert.Equal(t, first.ID, second.ID)
+	assert.Equal(t, int32(50), getTestUser(t, db, userID).Coins.Int32)
+	assert.Len(t, listTestTransactions(t, db, userID), 1)
+	lots := listTestLots(t, db, userID)
+	require.Len(t, lots, 1)
+	assert.Equal(t, int32(50), lots[0].RemainingAmount)
+	assert.True(t, lots[0].ExpiresAt.Valid)
+}
+
+func TestReverseCashback_TakesOnlyAvailableCoins(t *testing.T) {
+	ctx := context.Background()
+	repo, db := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{})
+	userID := insertTestUser(t, db, 0)
+	dbOrder := insertTestOrder(t, db, userID, 1000)
+	order := &entity.Order{ID: dbOrder.ID, UserID: userID, OrderNumber: dbOrder.OrderNumber}
+	_, err := repo.GrantCashback(ctx, order, 100)
+	require.NoError(t, err)
+	_, _, err = repo.SpendUserCoins(ctx, userID, 70, "Order", nil)
+	require.NoError(t, err)
+
+	first, err := repo.ReverseCashback(ctx, order)
+	require.NoError(t, err)
+	second, err := repo.ReverseCashback(ctx, order)
+	require.NoError(t, err)
+
+	assert.Equal(t, entity.TransactionTypeCashbackReversal, first.TransactionType)
+	assert.Equal(t, -30, first.Amount)
+	assert.Equal(t, 0, first.BalanceAfter)
+	assert.Equal(t, fmt.Sprintf("Reversed cashback for order %s (30 of 100 coins)", order.OrderNumber), first.Description)
+	assert.Equal(t, first.ID, second.ID)
+	assert.Zero(t, getTestUser(t, db, userID).Coins.Int32)
+	assert.Empty(t, listTestLots(t, db, userID))
+	assert.Len(t, listTestTransactions(t, db, userID), 3)
+}
+
+func TestReverseCashback_OrderWithoutCashback(t *testing.T) {
+	repo, db := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{})
+	userID := insertTestUser(t, db, 100)
+	dbOrder := insertTestOrder(t, db, userID, 1000)
+
+	transaction, err := repo.ReverseCashback(context.Background(), &entity.Order{ID: dbOrder.ID, UserID: userID, OrderNumber: dbOrder.OrderNumber})
+
+	require.NoError(t, err)
+	assert.Nil(t, transaction)
+	assert.Equal(t, int32(100), getTestUser(t, db, userID).Coins.Int32)
+	assert.Empty(t, listTestTransactions(t, db, userID))
+}
+
+func TestRedeemGiftCode_CreditsEachUserOnceUpToTheMaximum(t *testing.T) {
+	ctx := context.Background()
+	repo, db := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{BonusMonths: 6})
+	now := time.Now()
+	codeHash := strings.Repeat("a", 60) + "beef"
+	insertTestGiftCode(t, db, codeHash, 250, 2, nil)
+	firstID := insertTestUser(t, db, 100)
+	secondID := insertTestUser(t, db, 0)
+	thirdID := insertTestUser(t, db, 0)
+
+	user, transaction, err := repo.RedeemGiftCode(ctx, firstID, codeHash, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 350, user.Coins)
+	assert.Equal(t, entity.TransactionTypeGiftCode, transaction.TransactionType)
+	assert.Equal(t, 250, transaction.Amount)
+	assert.Equal(t, 350, transaction.BalanceAfter)
+	assert.Equal(t, "Gift code ending in beef", transaction.Description)
+	lots := listTestLots(t, db, firstID)
+	require.Len(t, lots, 1)
+	assert.Equal(t, int32(250), lots[0].RemainingAmount)
+
+	_, _, err = repo.RedeemGiftCode(ctx, firstID, codeHash, now)
+	assert.ErrorIs(t, err, domain.ErrGiftCodeAlreadyRedeemed)
+	_, _, err = repo.RedeemGiftCode(ctx, secondID, codeHash, now)
+	require.NoError(t, err)
+	_, _, err = repo.RedeemGiftCode(ctx, thirdID, codeHash, now)
+	assert.ErrorIs(t, err, domain.ErrGiftCodeFullyRedeemed)
+
+	assert.Equal(t, int32(350), getTestUser(t, db, firstID).Coins.Int32)
+	assert.Len(t, listTestTransactions(t, db, firstID), 1)
+	assert.Zero(t, getTestUser(t, db, thirdID).Coins.Int32)
+	assert.Empty(t, listTestTransactions(t, db, thirdID))
+}
+
+func TestRedeemGiftCode_RejectsExpiredAndUnknownCodes(t *testing.T) {
+	ctx := context.Background()
+	repo, db := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{})
+	now := time.Now()
+	hourAgo := now.Add(-time.Hour)
+	codeHash := strings.Repeat("b", 64)
+	insertTestGiftCode(t, db, codeHash, 250, 10, &hourAgo)
+	userID := insertTestUser(t, db, 0)
+
+	_, _, err := repo.RedeemGiftCode(ctx, userID, codeHash, now)
+	assert.ErrorIs(t, err, domain.ErrGiftCodeExpired)
+	_, _, err = repo.RedeemGiftCode(ctx, userID, strings.Repeat("c", 64), now)
+	assert.ErrorIs(t, err, domain.ErrInvalidGiftCode)
+
+	assert.Zero(t, getTestUser(t, db, userID).Coins.Int32)
+	assert.Empty(t, listTestTransactions(t, db, userID))
+}
+
+// statementRecorder keeps what WriteCoinStatement writes, and fails the
+// transaction writes with err when set
+type statementRecorder struct {
+	opening, closing entity.CoinStatement
+	transactions     []*entity.CoinTransaction
+	err              error
+}
+
+func (w *statementRecorder) WriteOpening(statement *entity.CoinStatement) error {
+	w.opening = *statement
+	return nil
+}
+
+func (w *statementRecorder) WriteTransaction(transaction *entity.CoinTransaction) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.transactions = append(w.transactions, transaction)
+	return nil
+}
 
-	mockQ.AssertExpectations(t)
+func (w *statementRecorder) WriteClosing(statement *entity.CoinStatement) error {
+	w.closing = *statement
+	return nil
+}
+
+func TestWriteCoinStatement_StreamsRangeWithBalances(t *testing.T) {
+	repo, db := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{})
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	userID := insertTestUser(t, db, 250)
+	for _, entry := range []struct {
+		amount, balanceAfter int32
+		at                   time.Time
+	}{
+		{100, 100, from.Add(-time.Hour)},
+		{200, 300, from.Add(time.Hour)},
+		{-50, 250, from.Add(2 * time.Hour)},
+		{0, 250, to}, // excluded: the range is half-open
+	} {
+		_, err := db.InsertCoinTransaction(context.Background(), database.CoinTransaction{
+			UserID:          database.UUIDToPgtype(userID),
+			TransactionType: database.TransactionTypeCharge,
+			Amount:          entry.amount,
+			BalanceAfter:    entry.balanceAfter,
+			CreatedAt:       database.TimeToPgtype(entry.at),
+		})
+		require.NoError(t, err)
+	}
+	w := &statementRecorder{}
+
+	err := repo.WriteCoinStatement(context.Background(), userID, from, to, w)
+
+	require.NoError(t, err)
+	assert.Equal(t, 100, w.opening.OpeningBalance)
+	require.Len(t, w.transactions, 2)
+	assert.Equal(t, 200, w.transactions[0].Amount)
+	assert.Equal(t, -50, w.transactions[1].Amount)
+	assert.Equal(t, entity.CoinStatement{
+		UserID:           userID,
+		From:             from,
+		To:               to,
+		OpeningBalance:   100,
+		ClosingBalance:   250,
+		TransactionCount: 2,
+	}, w.closing)
+}
+
+func TestWriteCoinStatement_StopsOnWriterError(t *testing.T) {
+	repo, db := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{})
+	userID := insertTestUser(t, db, 100)
+	_, err := db.InsertCoinTransaction(context.Background(), database.CoinTransaction{
+		UserID:          database.UUIDToPgtype(userID),
+		TransactionType: database.TransactionTypeCharge,
+		Amount:          100,
+	})
+	require.NoError(t, err)
+	writeErr := errors.New("client went away")
+	w := &statementRecorder{err: writeErr}
+
+	err = repo.WriteCoinStatement(context.Background(), userID, time.Now().Add(-time.Hour), time.Now().Add(time.Hour), w)
+
+	assert.ErrorIs(t, err, writeErr)
+	assert.Zero(t, w.closing)
+}
+
+func TestWriteCoinStatement_UnknownUser(t *testing.T) {
+	repo, _ := setupMemoryCoinTransactionRepository(t, entity.CoinExpiryPolicy{})
+
+	err := repo.WriteCoinStatement(context.Background(), uuid.New(), time.Now().Add(-time.Hour), time.Now(), &statementRecorder{})
+
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
 }
 
 // Test the conversion function
@@ -349,7 +907,7 @@ func TestDbTransactionToEntity(t *testing.T) {
 
 	entity := dbTransactionToEntity(dbTx)
 
-	assert.Equal(t, 1, entity.ID)
+	assert.Equal(t, int32(1), entity.ID)
 	assert.Equal(t, userID, entity.UserID)
 	assert.Equal(t, "charge", entity.TransactionType)
 	assert.Equal(t, 100, entity.Amount)
@@ -360,6 +918,19 @@ func TestDbTransactionToEntity(t *testing.T) {
 	assert.Equal(t, now, entity.CreatedAt)
 }
 
+func TestDbTransactionToEntity_WithCoinPackID(t *testing.T) {
+	userID := uuid.New()
+
+	dbTx := sampleDBCoinTransaction(3, userID, "bonus", 500)
+	dbTx.CoinPackID = database.Int32ToPgtype(2)
+
+	entity := dbTransactionToEntity(dbTx)
+
+	assert.Equal(t, "bonus", entity.TransactionType)
+	assert.NotNil(t, entity.CoinPackID)
+	assert.Equal(t, int32(2), *entity.CoinPackID)
+}
+
 func TestDbTransactionToEntity_NoOrderID(t *testing.T) {
 	userID := uuid.New()
 	now := time.Now()
@@ -377,11 +948,12 @@ func TestDbTransactionToEntity_NoOrderID(t *testing.T) {
 
 	entity := dbTransactionToEntity(dbTx)
 
-	assert.Equal(t, 2, entity.ID)
+	assert.Equal(t, int32(2), entity.ID)
 	assert.Equal(t, userID, entity.UserID)
 	assert.Equal(t, "purchase", entity.TransactionType)
 	assert.Equal(t, -50, entity.Amount)
 	assert.Equal(t, 950, entity.BalanceAfter)
 	assert.Nil(t, entity.OrderID) // Should be nil
+	assert.Nil(t, entity.CoinPackID)
 	assert.Equal(t, "Purchase", entity.Description)
 }
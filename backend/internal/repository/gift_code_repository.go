@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"backend/internal/database"
+	"backend/internal/domain"
+	"backend/internal/entity"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type GiftCodeRepository interface {
+	CreateGiftCodeBatch(ctx context.Context, createdBy uuid.UUID, req entity.CreateGiftCodeBatchRequest, codes []CreateGiftCodeParams) (*entity.GiftCodeBatch, error)
+	GetGiftCodeBatch(ctx context.Context, id int32) (*entity.GiftCodeBatch, error)
+	// LockUser locks the user's row until the transaction in ctx ends, so
+	// failed attempts counted in it stay counted until it records its own
+	LockUser(ctx context.Context, userID uuid.UUID) error
+	CountFailedRedemptions(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+	RecordFailedRedemption(ctx context.Context, userID uuid.UUID, details string) error
+}
+
+// CreateGiftCodeParams identifies one generated code by its hash
+type CreateGiftCodeParams struct {
+	CodeHash string
+	CodeHint string
+}
+
+type giftCodeRepository struct {
+	queries   database.Querier
+	txManager database.TxManager
+}
+
+func NewGiftCodeRepository(queries database.Querier, txManager database.TxManager) GiftCodeRepository {
+	return &giftCodeRepository{
+		queries:   queries,
+		txManager: txManager,
+	}
+}
+
+// CreateGiftCodeBatch stores a batch and all of its codes in one transaction
+func (r *giftCodeRepository) CreateGiftCodeBatch(ctx context.Context, createdBy uuid.UUID, req entity.CreateGiftCodeBatchRequest, codes []CreateGiftCodeParams) (*entity.GiftCodeBatch, error) {
+	var batch *entity.GiftCodeBatch
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		txQueries := database.QuerierFromContext(ctx, r.queries)
+
+		dbBatch, err := txQueries.CreateGiftCodeBatch(ctx, database.CreateGiftCodeBatchParams{
+			Description: database.StringToPgtype(req.Description),
+			CreatedBy:   database.UUIDToPgtype(createdBy),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create gift code batch: %w", database.TranslateError(err, nil))
+		}
+
+		var expiresAt pgtype.Timestamptz
+		if req.ExpiresAt != nil {
+			expiresAt = database.TimeToPgtype(*req.ExpiresAt)
+		}
+
+		dbCodes := make([]database.GiftCode, len(codes))
+		for i, code := range codes {
+			dbCodes[i], err = txQueries.CreateGiftCode(ctx, database.CreateGiftCodeParams{
+				BatchID:        dbBatch.ID,
+				CodeHash:       code.CodeHash,
+				CodeHint:       code.CodeHint,
+				CoinAmount:     int32(req.CoinAmount),
+				MaxRedemptions: int32(req.MaxRedemptions),
+				ExpiresAt:      expiresAt,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create gift code: %w", database.TranslateError(err, nil))
+			}
+		}
+
+		batch = dbGiftCodeBatchToEntity(dbBatch, dbCodes)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+func (r *giftCodeRepository) GetGiftCodeBatch(ctx context.Context, id int32) (*entity.GiftCodeBatch, error) {
+	dbBatch, err := database.QuerierFromContext(ctx, r.queries).GetGiftCodeBatchByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gift code batch: %w", database.TranslateError(err, domain.ErrGiftCodeBatchNotFound))
+	}
+
+	dbCodes, err := database.QuerierFromContext(ctx, r.queries).ListGiftCodesByBatchID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gift codes: %w", err)
+	}
+
+	return dbGiftCodeBatchToEntity(dbBatch, dbCodes), nil
+}
+
+func (r *giftCodeRepository) LockUser(ctx context.Context, userID uuid.UUID) error {
+	if _, err := database.QuerierFromContext(ctx, r.queries).GetUserByIDForUpdate(ctx, database.UUIDToPgtype(userID)); err != nil {
+		return fmt.Errorf("failed to lock user: %w", database.TranslateError(err, domain.ErrUserNotFound))
+	}
+	return nil
+}
+
+func (r *giftCodeRepository) CountFailedRedemptions(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	count, err := database.QuerierFromContext(ctx, r.queries).CountSecurityEventsSince(ctx, database.CountSecurityEventsSinceParams{
+		UserID:    database.UUIDToPgtype(userID),
+		EventType: entity.SecurityEventGiftCodeFailure,
+		CreatedAt: database.TimeToPgtype(since),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count failed redemptions: %w", err)
+	}
+
+	return int(count), nil
+}
+
+func (r *giftCodeRepository) RecordFailedRedemption(ctx context.Context, userID uuid.UUID, details string) error {
+	_, err := database.QuerierFromContext(ctx, r.queries).CreateSecurityEvent(ctx, database.CreateSecurityEventParams{
+		UserID:    database.UUIDToPgtype(userID),
+		EventType: entity.SecurityEventGiftCodeFailure,
+		Details:   database.StringToPgtype(details),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record failed redemption: %w", database.TranslateError(err, nil))
+	}
+
+	return nil
+}
+
+func dbGiftCodeBatchToEntity(dbBatch database.GiftCodeBatch, dbCodes []database.GiftCode) *entity.GiftCodeBatch {
+	batch := &entity.GiftCodeBatch{
+		ID:          dbBatch.ID,
+		Description: database.PgtypeToString(dbBatch.Description),
+		CreatedAt:   dbBatch.CreatedAt.Time,
+		Codes:       make([]*entity.GiftCode, len(dbCodes)),
+	}
+
+	if dbBatch.CreatedBy.Valid {
+		createdBy := database.PgtypeToUUID(dbBatch.CreatedBy)
+		batch.CreatedBy = &createdBy
+	}
+
+	for i, dbCode := range dbCodes {
+		batch.Codes[i] = dbGiftCodeToEntity(dbCode)
+	}
+
+	return batch
+}
+
+func dbGiftCodeToEntity(dbCode database.GiftCode) *entity.GiftCode {
+	code := &entity.GiftCode{
+		ID:              dbCode.ID,
+		BatchID:         dbCode.BatchID,
+		CodeHint:        dbCode.CodeHint,
+		CoinAmount:      int(dbCode.CoinAmount),
+		MaxRedemptions:  int(dbCode.MaxRedemptions),
+		RedemptionCount: int(dbCode.RedemptionCount),
+		CreatedAt:       dbCode.CreatedAt.Time,
+	}
+
+	if dbCode.ExpiresAt.Valid {
+		expiresAt := dbCode.ExpiresAt.Time
+		code.ExpiresAt = &expiresAt
+	}
+
+	return code
+}
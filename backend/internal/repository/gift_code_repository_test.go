@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"backend/internal/database"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDbGiftCodeBatchToEntity(t *testing.T) {
+	adminID := uuid.New()
+	expiresAt := time.Now().Add(24 * time.Hour)
+
+	batch := dbGiftCodeBatchToEntity(
+		database.GiftCodeBatch{
+			ID:          1,
+			Description: pgtype.Text{String: "Spring campaign", Valid: true},
+			CreatedBy:   database.UUIDToPgtype(adminID),
+		},
+		[]database.GiftCode{
+			{ID: 10, BatchID: 1, CodeHash: "hash", CodeHint: "NPQR", CoinAmount: 500, MaxRedemptions: 3, RedemptionCount: 1, ExpiresAt: database.TimeToPgtype(expiresAt)},
+			{ID: 11, BatchID: 1, CodeHash: "hash2", CodeHint: "WXYZ", CoinAmount: 500, MaxRedemptions: 3},
+		},
+	)
+
+	assert.Equal(t, int32(1), batch.ID)
+	assert.Equal(t, "Spring campaign", batch.Description)
+	assert.NotNil(t, batch.CreatedBy)
+	assert.Equal(t, adminID, *batch.CreatedBy)
+	assert.Len(t, batch.Codes, 2)
+
+	assert.Equal(t, "NPQR", batch.Codes[0].CodeHint)
+	assert.Equal(t, 500, batch.Codes[0].CoinAmount)
+	assert.Equal(t, 3, batch.Codes[0].MaxRedemptions)
+	assert.Equal(t, 1, batch.Codes[0].RedemptionCount)
+	assert.NotNil(t, batch.Codes[0].ExpiresAt)
+	assert.True(t, expiresAt.Equal(*batch.Codes[0].ExpiresAt))
+	assert.Nil(t, batch.Codes[1].ExpiresAt)
+}
+
+func TestDbGiftCodeBatchToEntity_NoCreator(t *testing.T) {
+	batch := dbGiftCodeBatchToEntity(database.GiftCodeBatch{ID: 2}, nil)
+
+	assert.Nil(t, batch.CreatedBy)
+	assert.Empty(t, batch.Codes)
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"backend/internal/database"
+	"backend/internal/domain"
+	"backend/internal/entity"
+	"context"
+	"fmt"
+)
+
+type OrderRepository interface {
+	GetOrderByID(ctx context.Context, id int32) (*entity.Order, error)
+	UpdateOrderStatus(ctx context.Context, id int32, from, to string) (*entity.Order, error)
+	GetOrderCategorySubtotals(ctx context.Context, id int32) ([]entity.OrderCategorySubtotal, error)
+	CountOrdersByStatus(ctx context.Context) (map[string]int, error)
+}
+
+type orderRepository struct {
+	queries database.Querier
+}
+
+func NewOrderRepository(queries database.Querier) OrderRepository {
+	return &orderRepository{
+		queries: queries,
+	}
+}
+
+func (r *orderRepository) GetOrderByID(ctx context.Context, id int32) (*entity.Order, error) {
+	dbOrder, err := database.QuerierFromContext(ctx, r.queries).GetOrderByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", database.TranslateError(err, domain.ErrOrderNotFound))
+	}
+
+	return dbOrderToEntity(dbOrder), nil
+}
+
+// UpdateOrderStatus moves the order from one status to another. It fails if
+// the order is no longer in the from status.
+func (r *orderRepository) UpdateOrderStatus(ctx context.Context, id int32, from, to string) (*entity.Order, error) {
+	dbOrder, err := database.QuerierFromContext(ctx, r.queries).UpdateOrderStatus(ctx, database.UpdateOrderStatusParams{
+		NewStatus:     database.OrderStatus(to),
+		ID:            id,
+		CurrentStatus: database.OrderStatus(from),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update order status: %w", database.TranslateError(err, domain.ErrOrderStatusChanged))
+	}
+
+	return dbOrderToEntity(dbOrder), nil
+}
+
+func (r *orderRepository) GetOrderCategorySubtotals(ctx context.Context, id int32) ([]entity.OrderCategorySubtotal, error) {
+	rows, err := database.QuerierFromContext(ctx, r.queries).ListOrderCategorySubtotals(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order subtotals: %w", err)
+	}
+
+	subtotals := make([]entity.OrderCategorySubtotal, len(rows))
+	for i, row := range rows {
+		subtotals[i] = entity.OrderCategorySubtotal{
+			CategoryID: row.CategoryID,
+			Subtotal:   database.NumericToFloat64(row.Subtotal),
+		}
+	}
+
+	return subtotals, nil
+}
+
+// CountOrdersByStatus returns how many orders are in each status that has any
+func (r *orderRepository) CountOrdersByStatus(ctx context.Context) (map[string]int, error) {
+	rows, err := database.QuerierFromContext(ctx, r.queries).CountOrdersByStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count orders: %w", err)
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[string(row.Status)] = int(row.Count)
+	}
+
+	return counts, nil
+}
+
+func dbOrderToEntity(dbOrder database.Order) *entity.Order {
+	// The column has a default but no NOT NULL; an unset status is pending
+	status := entity.OrderStatusPending
+	if dbOrder.Status.Valid {
+		status = string(dbOrder.Status.OrderStatus)
+	}
+
+	return &entity.Order{
+		ID:             dbOrder.ID,
+		UserID:         database.PgtypeToUUID(dbOrder.UserID),
+		OrderNumber:    dbOrder.OrderNumber,
+		TotalAmount:    database.NumericToFloat64(dbOrder.TotalAmount),
+		TotalCoinsUsed: int(dbOrder.TotalCoinsUsed),
+		Status:         status,
+		CreatedAt:      dbOrder.CreatedAt.Time,
+		UpdatedAt:      dbOrder.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"backend/internal/database"
+	"backend/internal/entity"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDbOrderToEntity(t *testing.T) {
+	userID := uuid.New()
+
+	order := dbOrderToEntity(database.Order{
+		ID:             3,
+		UserID:         database.UUIDToPgtype(userID),
+		OrderNumber:    "ORD-0003",
+		TotalAmount:    database.Float64ToNumeric(1200.5),
+		TotalCoinsUsed: 500,
+		Status:         database.NullOrderStatus{OrderStatus: database.OrderStatusCompleted, Valid: true},
+	})
+
+	assert.Equal(t, int32(3), order.ID)
+	assert.Equal(t, userID, order.UserID)
+	assert.Equal(t, "ORD-0003", order.OrderNumber)
+	assert.Equal(t, 1200.5, order.TotalAmount)
+	assert.Equal(t, 500, order.TotalCoinsUsed)
+	assert.Equal(t, entity.OrderStatusCompleted, order.Status)
+}
+
+func TestDbOrderToEntity_NullStatusIsPending(t *testing.T) {
+	order := dbOrderToEntity(database.Order{ID: 4})
+
+	assert.Equal(t, entity.OrderStatusPending, order.Status)
+}
+
+func TestCountOrdersByStatus(t *testing.T) {
+	ctx := context.Background()
+	db, _ := newTestDB(t)
+	repo := NewOrderRepository(db)
+	userID := insertTestUser(t, db, 0)
+	for range 2 {
+		insertTestOrder(t, db, userID, 100)
+	}
+	completed := insertTestOrder(t, db, userID, 100)
+	_, err := repo.UpdateOrderStatus(ctx, completed.ID, entity.OrderStatusPending, entity.OrderStatusCompleted)
+	require.NoError(t, err)
+
+	counts, err := repo.CountOrdersByStatus(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{entity.OrderStatusPending: 2, entity.OrderStatusCompleted: 1}, counts)
+}
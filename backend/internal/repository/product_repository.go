@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"backend/internal/database"
+	"backend/internal/domain"
 	"backend/internal/entity"
 )
 
@@ -16,10 +17,10 @@ type ProductRepository interface {
 }
 
 type productRepository struct {
-	queries *database.Queries
+	queries database.Querier
 }
 
-func NewProductRepository(queries *database.Queries) ProductRepository {
+func NewProductRepository(queries database.Querier) ProductRepository {
 	return &productRepository{
 		queries: queries,
 	}
@@ -28,7 +29,7 @@ func NewProductRepository(queries *database.Queries) ProductRepository {
 func (r *productRepository) GetAllProducts(ctx context.Context, page, limit int) ([]entity.Product, error) {
 	offset := (page - 1) * limit
 
-	dbProducts, err := r.queries.ListProducts(ctx, database.ListProductsParams{
+	dbProducts, err := database.QuerierFromContext(ctx, r.queries).ListProducts(ctx, database.ListProductsParams{
 		Limit:  int32(limit),
 		Offset: int32(offset),
 	})
@@ -45,9 +46,9 @@ func (r *productRepository) GetAllProducts(ctx context.Context, page, limit int)
 }
 
 func (r *productRepository) GetProductByID(ctx context.Context, id int) (*entity.Product, error) {
-	dbProduct, err := r.queries.GetProductByID(ctx, int32(id))
+	dbProduct, err := database.QuerierFromContext(ctx, r.queries).GetProductByID(ctx, int32(id))
 	if err != nil {
-		return nil, fmt.Errorf("product not found: %w", err)
+		return nil, fmt.Errorf("failed to get product: %w", database.TranslateError(err, domain.ErrProductNotFound))
 	}
 
 	return dbProductToEntity(dbProduct), nil
@@ -56,7 +57,7 @@ func (r *productRepository) GetProductByID(ctx context.Context, id int) (*entity
 func (r *productRepository) GetProductsByCategory(ctx context.Context, categoryID, page, limit int) ([]entity.Product, error) {
 	offset := (page - 1) * limit
 
-	dbProducts, err := r.queries.ListProductsByCategory(ctx, database.ListProductsByCategoryParams{
+	dbProducts, err := database.QuerierFromContext(ctx, r.queries).ListProductsByCategory(ctx, database.ListProductsByCategoryParams{
 		CategoryID: int32(categoryID),
 		Limit:      int32(limit),
 		Offset:     int32(offset),
@@ -74,12 +75,12 @@ func (r *productRepository) GetProductsByCategory(ctx context.Context, categoryI
 }
 
 func (r *productRepository) UpdateStock(ctx context.Context, productID, newStock int) error {
-	_, err := r.queries.UpdateProductStock(ctx, database.UpdateProductStockParams{
+	_, err := database.QuerierFromContext(ctx, r.queries).UpdateProductStock(ctx, database.UpdateProductStockParams{
 		ID:            int32(productID),
 		StockQuantity: int32(newStock),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to update stock: %w", err)
+		return fmt.Errorf("failed to update stock: %w", database.TranslateError(err, nil))
 	}
 
 	return nil
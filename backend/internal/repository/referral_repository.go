@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"backend/internal/database"
+	"backend/internal/entity"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type ReferralRepository interface {
+	GetReferralsByReferrerID(ctx context.Context, referrerID uuid.UUID, limit, offset int32) ([]*entity.Referral, error)
+	// CreateReferral saves a pending referral, or a rejected one when
+	// rejectionReason is set
+	CreateReferral(ctx context.Context, referrerID, refereeID uuid.UUID, rejectionReason string) (*entity.Referral, error)
+	RecordSecurityEvent(ctx context.Context, userID uuid.UUID, eventType, details string) error
+}
+
+type referralRepository struct {
+	queries database.Querier
+}
+
+func NewReferralRepository(queries database.Querier) ReferralRepository {
+	return &referralRepository{
+		queries: queries,
+	}
+}
+
+func (r *referralRepository) GetReferralsByReferrerID(ctx context.Context, referrerID uuid.UUID, limit, offset int32) ([]*entity.Referral, error) {
+	dbReferrals, err := database.QuerierFromContext(ctx, r.queries).ListReferralsByReferrerID(ctx, database.ListReferralsByReferrerIDParams{
+		ReferrerID: database.UUIDToPgtype(referrerID),
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get referrals: %w", err)
+	}
+
+	referrals := make([]*entity.Referral, len(dbReferrals))
+	for i, dbReferral := range dbReferrals {
+		referrals[i] = dbReferralToEntity(dbReferral)
+	}
+
+	return referrals, nil
+}
+
+func (r *referralRepository) CreateReferral(ctx context.Context, referrerID, refereeID uuid.UUID, rejectionReason string) (*entity.Referral, error) {
+	status := entity.ReferralStatusPending
+	if rejectionReason != "" {
+		status = entity.ReferralStatusRejected
+	}
+
+	dbReferral, err := database.QuerierFromContext(ctx, r.queries).CreateReferral(ctx, database.CreateReferralParams{
+		ReferrerID:      database.UUIDToPgtype(referrerID),
+		RefereeID:       database.UUIDToPgtype(refereeID),
+		Status:          database.ReferralStatus(status),
+		RejectionReason: database.StringToPgtype(rejectionReason),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create referral: %w", database.TranslateError(err, nil))
+	}
+
+	return dbReferralToEntity(dbReferral), nil
+}
+
+func (r *referralRepository) RecordSecurityEvent(ctx context.Context, userID uuid.UUID, eventType, details string) error {
+	_, err := database.QuerierFromContext(ctx, r.queries).CreateSecurityEvent(ctx, database.CreateSecurityEventParams{
+		UserID:    database.UUIDToPgtype(userID),
+		EventType: eventType,
+		Details:   database.StringToPgtype(details),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record security event: %w", database.TranslateError(err, nil))
+	}
+
+	return nil
+}
+
+func dbReferralToEntity(dbReferral database.Referral) *entity.Referral {
+	referral := &entity.Referral{
+		ID:              dbReferral.ID,
+		ReferrerID:      database.PgtypeToUUID(dbReferral.ReferrerID),
+		RefereeID:       database.PgtypeToUUID(dbReferral.RefereeID),
+		Status:          string(dbReferral.Status),
+		RejectionReason: database.PgtypeToString(dbReferral.RejectionReason),
+		CreatedAt:       dbReferral.CreatedAt.Time,
+	}
+
+	if dbReferral.RewardedAt.Valid {
+		rewardedAt := dbReferral.RewardedAt.Time
+		referral.RewardedAt = &rewardedAt
+	}
+
+	return referral
+}
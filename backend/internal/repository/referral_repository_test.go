@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"backend/internal/database"
+	"backend/internal/entity"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDbReferralToEntity_Rewarded(t *testing.T) {
+	referrerID := uuid.New()
+	refereeID := uuid.New()
+	rewardedAt := time.Now()
+
+	referral := dbReferralToEntity(database.Referral{
+		ID:                    1,
+		ReferrerID:            database.UUIDToPgtype(referrerID),
+		RefereeID:             database.UUIDToPgtype(refereeID),
+		Status:                database.ReferralStatusRewarded,
+		ReferrerTransactionID: database.Int32ToPgtype(10),
+		RefereeTransactionID:  database.Int32ToPgtype(11),
+		RewardedAt:            database.TimeToPgtype(rewardedAt),
+	})
+
+	assert.Equal(t, int32(1), referral.ID)
+	assert.Equal(t, referrerID, referral.ReferrerID)
+	assert.Equal(t, refereeID, referral.RefereeID)
+	assert.Equal(t, entity.ReferralStatusRewarded, referral.Status)
+	assert.Empty(t, referral.RejectionReason)
+	assert.NotNil(t, referral.RewardedAt)
+	assert.True(t, rewardedAt.Equal(*referral.RewardedAt))
+}
+
+func TestDbReferralToEntity_Rejected(t *testing.T) {
+	referral := dbReferralToEntity(database.Referral{
+		ID:              2,
+		ReferrerID:      database.UUIDToPgtype(uuid.New()),
+		RefereeID:       database.UUIDToPgtype(uuid.New()),
+		Status:          database.ReferralStatusRejected,
+		RejectionReason: pgtype.Text{String: entity.ReferralRejectedSelfReferral, Valid: true},
+	})
+
+	assert.Equal(t, entity.ReferralStatusRejected, referral.Status)
+	assert.Equal(t, entity.ReferralRejectedSelfReferral, referral.RejectionReason)
+	assert.Nil(t, referral.RewardedAt)
+}
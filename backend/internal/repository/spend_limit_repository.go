@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"backend/internal/database"
+	"backend/internal/domain"
+	"backend/internal/entity"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type SpendLimitRepository interface {
+	// GetUserSpendLimit returns nil without an error when the user has no override
+	GetUserSpendLimit(ctx context.Context, userID uuid.UUID) (*entity.UserSpendLimit, error)
+	SetUserSpendLimit(ctx context.Context, userID uuid.UUID, req entity.UpdateSpendLimitRequest) (*entity.UserSpendLimit, error)
+	GetSpendActivity(ctx context.Context, userID uuid.UUID, spentSince, countedSince time.Time) (*entity.SpendActivity, error)
+	BlockSpending(ctx context.Context, userID uuid.UUID, until time.Time, eventType, details string) error
+	// UnblockSpending clears a block and records the given event; it reports
+	// whether the user was blocked
+	UnblockSpending(ctx context.Context, userID uuid.UUID, eventType, details string) (bool, error)
+	// LockUser locks the user's row until the transaction in ctx ends, so the
+	// limits checked in it hold for the spend that follows
+	LockUser(ctx context.Context, userID uuid.UUID) error
+	GetSecurityEvents(ctx context.Context, userID uuid.UUID, limit int32) ([]*entity.SecurityEvent, error)
+}
+
+type spendLimitRepository struct {
+	queries   database.Querier
+	txManager database.TxManager
+}
+
+func NewSpendLimitRepository(queries database.Querier, txManager database.TxManager) SpendLimitRepository {
+	return &spendLimitRepository{
+		queries:   queries,
+		txManager: txManager,
+	}
+}
+
+func (r *spendLimitRepository) GetUserSpendLimit(ctx context.Context, userID uuid.UUID) (*entity.UserSpendLimit, error) {
+	dbLimit, err := database.QuerierFromContext(ctx, r.queries).GetUserSpendLimit(ctx, database.UUIDToPgtype(userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get spend limit: %w", err)
+	}
+
+	return dbSpendLimitToEntity(dbLimit), nil
+}
+
+func (r *spendLimitRepository) SetUserSpendLimit(ctx context.Context, userID uuid.UUID, req entity.UpdateSpendLimitRequest) (*entity.UserSpendLimit, error) {
+	var dailyLimit, perTransactionLimit pgtype.Int4
+	if req.DailyLimit != nil {
+		dailyLimit = database.Int32ToPgtype(int32(*req.DailyLimit))
+	}
+	if req.PerTransactionLimit != nil {
+		perTransactionLimit = database.Int32ToPgtype(int32(*req.PerTransactionLimit))
+	}
+
+	dbLimit, err := database.QuerierFromContext(ctx, r.queries).UpsertUserSpendLimit(ctx, database.UpsertUserSpendLimitParams{
+		UserID:              database.UUIDToPgtype(userID),
+		DailyLimit:          dailyLimit,
+		PerTransactionLimit: perTransactionLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set spend limit: %w", database.TranslateError(err, nil))
+	}
+
+	return dbSpendLimitToEntity(dbLimit), nil
+}
+
+func (r *spendLimitRepository) GetSpendActivity(ctx context.Context, userID uuid.UUID, spentSince, countedSince time.Time) (*entity.SpendActivity, error) {
+	row, err := database.QuerierFromContext(ctx, r.queries).GetSpendActivity(ctx, database.GetSpendActivityParams{
+		SpentSince:   database.TimeToPgtype(spentSince),
+		CountedSince: database.TimeToPgtype(countedSince),
+		UserID:       database.UUIDToPgtype(userID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spend activity: %w", err)
+	}
+
+	return &entity.SpendActivity{
+		Spent:      int(row.Spent),
+		SpendCount: int(row.SpendCount),
+	}, nil
+}
+
+// BlockSpending blocks the user's spending until the given time and records
+// why as a security event, both in one transaction.
+func (r *spendLimitRepository) BlockSpending(ctx context.Context, userID uuid.UUID, until time.Time, eventType, details string) error {
+	return r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		txQueries := database.QuerierFromContext(ctx, r.queries)
+
+		err := txQueries.BlockUserSpending(ctx, database.BlockUserSpendingParams{
+			UserID:               database.UUIDToPgtype(userID),
+			SpendingBlockedUntil: database.TimeToPgtype(until),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to block spending: %w", database.TranslateError(err, nil))
+		}
+
+		_, err = txQueries.CreateSecurityEvent(ctx, database.CreateSecurityEventParams{
+			UserID:    database.UUIDToPgtype(userID),
+			EventType: eventType,
+			Details:   database.StringToPgtype(details),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record security event: %w", database.TranslateError(err, nil))
+		}
+		return nil
+	})
+}
+
+func (r *spendLimitRepository) UnblockSpending(ctx context.Context, userID uuid.UUID, eventType, details string) (bool, error) {
+	unblocked := false
+	err := r.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		txQueries := database.QuerierFromContext(ctx, r.queries)
+
+		rows, err := txQueries.UnblockUserSpending(ctx, database.UUIDToPgtype(userID))
+		if err != nil {
+			return fmt.Errorf("failed to unblock spending: %w", database.TranslateError(err, nil))
+		}
+		if rows == 0 {
+			return nil
+		}
+
+		_, err = txQueries.CreateSecurityEvent(ctx, database.CreateSecurityEventParams{
+			UserID:    database.UUIDToPgtype(userID),
+			EventType: eventType,
+			Details:   database.StringToPgtype(details),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record security event: %w", database.TranslateError(err, nil))
+		}
+
+		unblocked = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return unblocked, nil
+}
+
+func (r *spendLimitRepository) LockUser(ctx context.Context, userID uuid.UUID) error {
+	if _, err := database.QuerierFromContext(ctx, r.queries).GetUserByIDForUpdate(ctx, database.UUIDToPgtype(userID)); err != nil {
+		return fmt.Errorf("failed to lock user: %w", database.TranslateError(err, domain.ErrUserNotFound))
+	}
+	return nil
+}
+
+func (r *spendLimitRepository) GetSecurityEvents(ctx context.Context, userID uuid.UUID, limit int32) ([]*entity.SecurityEvent, error) {
+	dbEvents, err := database.QuerierFromContext(ctx, r.queries).ListSecurityEventsByUserID(ctx, database.ListSecurityEventsByUserIDParams{
+		UserID: database.UUIDToPgtype(userID),
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get security events: %w", err)
+	}
+
+	events := make([]*entity.SecurityEvent, len(dbEvents))
+	for i, dbEvent := range dbEvents {
+		events[i] = &entity.SecurityEvent{
+			ID:        dbEvent.ID,
+			UserID:    database.PgtypeToUUID(dbEvent.UserID),
+			EventType: dbEvent.EventType,
+			Details:   database.PgtypeToString(dbEvent.Details),
+			CreatedAt: dbEvent.CreatedAt.Time,
+		}
+	}
+
+	return events, nil
+}
+
+func dbSpendLimitToEntity(dbLimit database.UserSpendLimit) *entity.UserSpendLimit {
+	limit := &entity.UserSpendLimit{
+		UserID:    database.PgtypeToUUID(dbLimit.UserID),
+		UpdatedAt: dbLimit.UpdatedAt.Time,
+	}
+
+	if dbLimit.DailyLimit.Valid {
+		dailyLimit := int(dbLimit.DailyLimit.Int32)
+		limit.DailyLimit = &dailyLimit
+	}
+	if dbLimit.PerTransactionLimit.Valid {
+		perTransactionLimit := int(dbLimit.PerTransactionLimit.Int32)
+		limit.PerTransactionLimit = &perTransactionLimit
+	}
+	if dbLimit.SpendingBlockedUntil.Valid {
+		blockedUntil := dbLimit.SpendingBlockedUntil.Time
+		limit.SpendingBlockedUntil = &blockedUntil
+	}
+
+	return limit
+}
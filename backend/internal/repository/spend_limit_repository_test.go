@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/internal/database"
+	"backend/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDbSpendLimitToEntity_DefaultsAreNil(t *testing.T) {
+	userID := uuid.New()
+
+	limit := dbSpendLimitToEntity(database.UserSpendLimit{
+		UserID:    database.UUIDToPgtype(userID),
+		UpdatedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+
+	assert.Equal(t, userID, limit.UserID)
+	assert.Nil(t, limit.DailyLimit)
+	assert.Nil(t, limit.PerTransactionLimit)
+	assert.Nil(t, limit.SpendingBlockedUntil)
+}
+
+func TestDbSpendLimitToEntity_WithOverrides(t *testing.T) {
+	userID := uuid.New()
+	blockedUntil := time.Now().Add(30 * time.Minute)
+
+	limit := dbSpendLimitToEntity(database.UserSpendLimit{
+		UserID:               database.UUIDToPgtype(userID),
+		DailyLimit:           database.Int32ToPgtype(0),
+		PerTransactionLimit:  database.Int32ToPgtype(500),
+		SpendingBlockedUntil: database.TimeToPgtype(blockedUntil),
+	})
+
+	assert.NotNil(t, limit.DailyLimit)
+	assert.Equal(t, 0, *limit.DailyLimit)
+	assert.NotNil(t, limit.PerTransactionLimit)
+	assert.Equal(t, 500, *limit.PerTransactionLimit)
+	assert.NotNil(t, limit.SpendingBlockedUntil)
+	assert.True(t, blockedUntil.Equal(*limit.SpendingBlockedUntil))
+}
+
+func TestGetSpendActivity_CountsActiveHolds(t *testing.T) {
+	db, txManager := newTestDB(t)
+	repo := NewSpendLimitRepository(db, txManager)
+	holdRepo := NewCoinHoldRepository(db, txManager)
+	ctx := context.Background()
+	userID := insertTestUser(t, db, 1000)
+	now := time.Now()
+
+	_, err := db.InsertCoinTransaction(ctx, database.CoinTransaction{
+		UserID:          database.UUIDToPgtype(userID),
+		TransactionType: database.TransactionTypePurchase,
+		Amount:          -100,
+	})
+	require.NoError(t, err)
+	_, err = holdRepo.CreateCoinHold(ctx, userID, 200, "Pending order", nil, now.Add(time.Hour))
+	require.NoError(t, err)
+	released, err := holdRepo.CreateCoinHold(ctx, userID, 400, "Abandoned order", nil, now.Add(time.Hour))
+	require.NoError(t, err)
+	_, err = holdRepo.ReleaseCoinHold(ctx, userID, released.ID)
+	require.NoError(t, err)
+
+	activity, err := repo.GetSpendActivity(ctx, userID, now.Add(-time.Hour), now.Add(-time.Hour))
+
+	require.NoError(t, err)
+	assert.Equal(t, &entity.SpendActivity{Spent: 300, SpendCount: 2}, activity)
+}
+
+func TestUnblockSpending_ClearsBlockAndRecordsEvent(t *testing.T) {
+	db, txManager := newTestDB(t)
+	repo := NewSpendLimitRepository(db, txManager)
+	ctx := context.Background()
+	userID := insertTestUser(t, db, 0)
+
+	require.NoError(t, repo.BlockSpending(ctx, userID, time.Now().Add(time.Hour), entity.SecurityEventSpendVelocity, "6 spends within 10m0s"))
+
+	unblocked, err := repo.UnblockSpending(ctx, userID, entity.SecurityEventSpendUnblocked, "cleared by an admin")
+
+	require.NoError(t, err)
+	assert.True(t, unblocked)
+	limit, err := repo.GetUserSpendLimit(ctx, userID)
+	require.NoError(t, err)
+	assert.Nil(t, limit.SpendingBlockedUntil)
+	events, err := repo.GetSecurityEvents(ctx, userID, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, entity.SecurityEventSpendUnblocked, events[0].EventType)
+
+	// Nothing is left to clear, so no second event is recorded
+	unblocked, err = repo.UnblockSpending(ctx, userID, entity.SecurityEventSpendUnblocked, "cleared by an admin")
+	require.NoError(t, err)
+	assert.False(t, unblocked)
+	events, err = repo.GetSecurityEvents(ctx, userID, 10)
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+}
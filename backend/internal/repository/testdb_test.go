@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/internal/database"
+	"backend/internal/database/memdb"
+	"backend/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestDB returns an in-memory database and a transaction manager on it, for
+// tests of flows that run many queries in one transaction. Mocks would only
+// repeat the implementation there; the in-memory database checks the result.
+func newTestDB(t *testing.T) (*memdb.Queries, database.TxManager) {
+	t.Helper()
+
+	db := memdb.New()
+	return db, database.NewTxManager(db)
+}
+
+// insertTestUser adds a user holding coins with no lots behind them, as
+// balances from before lots were tracked
+func insertTestUser(t *testing.T, db *memdb.Queries, coins int32) uuid.UUID {
+	t.Helper()
+
+	id := uuid.New()
+	_, err := db.InsertUser(context.Background(), database.User{
+		ID:              database.UUIDToPgtype(id),
+		Name:            "Test User",
+		Email:           id.String() + "@example.com",
+		NormalizedEmail: id.String() + "@example.com",
+		PasswordHash:    "hash",
+		Coins:           database.Int32ToPgtype(coins),
+		ReferralCode:    id.String()[:8],
+	})
+	require.NoError(t, err)
+	return id
+}
+
+// insertTestLot adds a lot of amount granted at createdAt. A nil expiresAt
+// makes a lot that never expires. The user's coins must already include it.
+func insertTestLot(t *testing.T, db *memdb.Queries, userID uuid.UUID, amount int32, createdAt time.Time, expiresAt *time.Time) database.CoinLot {
+	t.Helper()
+
+	lot := database.CoinLot{
+		UserID:          database.UUIDToPgtype(userID),
+		Source:          database.TransactionTypeCharge,
+		OriginalAmount:  amount,
+		RemainingAmount: amount,
+		CreatedAt:       database.TimeToPgtype(createdAt),
+	}
+	if expiresAt != nil {
+		lot.ExpiresAt = database.TimeToPgtype(*expiresAt)
+	}
+
+	lot, err := db.InsertCoinLot(context.Background(), lot)
+	require.NoError(t, err)
+	return lot
+}
+
+// getTestUser reads the user back from db
+func getTestUser(t *testing.T, db *memdb.Queries, id uuid.UUID) database.User {
+	t.Helper()
+
+	user, err := db.GetUserByID(context.Background(), database.UUIDToPgtype(id))
+	require.NoError(t, err)
+	return user
+}
+
+// listTestLots returns the user's lots that still hold coins, in the order
+// spends consume them
+func listTestLots(t *testing.T, db *memdb.Queries, id uuid.UUID) []database.CoinLot {
+	t.Helper()
+
+	lots, err := db.ListSpendableCoinLotsForUpdate(context.Background(), database.ListSpendableCoinLotsForUpdateParams{
+		UserID:    database.UUIDToPgtype(id),
+		ExpiresAt: pgtype.Timestamptz{InfinityModifier: pgtype.NegativeInfinity, Valid: true},
+	})
+	require.NoError(t, err)
+	return lots
+}
+
+// listTestTransactions returns the user's ledger, newest first
+func listTestTransactions(t *testing.T, db *memdb.Queries, id uuid.UUID) []database.CoinTransaction {
+	t.Helper()
+
+	transactions, err := db.GetCoinTransactionsByUserID(context.Background(), database.GetCoinTransactionsByUserIDParams{
+		UserID: database.UUIDToPgtype(id),
+		Limit:  100,
+	})
+	require.NoError(t, err)
+	return transactions
+}
+
+// insertTestOrder adds a pending order for the user that spent coinsUsed
+func insertTestOrder(t *testing.T, db *memdb.Queries, userID uuid.UUID, coinsUsed int32) database.Order {
+	t.Helper()
+
+	order, err := db.InsertOrder(context.Background(), database.Order{
+		UserID:         database.UUIDToPgtype(userID),
+		OrderNumber:    "ORD-" + uuid.NewString()[:8],
+		TotalAmount:    database.Float64ToNumeric(float64(coinsUsed)),
+		TotalCoinsUsed: coinsUsed,
+	})
+	require.NoError(t, err)
+	return order
+}
+
+// insertTestCoinPack adds an active pack selling base coins plus bonus ones
+func insertTestCoinPack(t *testing.T, db *memdb.Queries, name string, base, bonus int32) *entity.CoinPack {
+	t.Helper()
+
+	pack, err := db.InsertCoinPack(context.Background(), database.CoinPack{
+		Name:       name,
+		BaseCoins:  base,
+		BonusCoins: bonus,
+		Price:      database.Float64ToNumeric(float64(base) / 100),
+		IsActive:   true,
+	})
+	require.NoError(t, err)
+	return &entity.CoinPack{ID: pack.ID, Name: pack.Name, BaseCoins: int(base), BonusCoins: int(bonus)}
+}
+
+// insertTestGiftCode adds a code worth coins that maxRedemptions users can
+// redeem. A nil expiresAt makes a code that never expires.
+func insertTestGiftCode(t *testing.T, db *memdb.Queries, codeHash string, coins, maxRedemptions int32, expiresAt *time.Time) database.GiftCode {
+	t.Helper()
+
+	batch, err := db.CreateGiftCodeBatch(context.Background(), database.CreateGiftCodeBatchParams{})
+	require.NoError(t, err)
+
+	params := database.CreateGiftCodeParams{
+		BatchID:        batch.ID,
+		CodeHash:       codeHash,
+		CodeHint:       codeHash[len(codeHash)-4:],
+		CoinAmount:     coins,
+		MaxRedemptions: maxRedemptions,
+	}
+	if expiresAt != nil {
+		params.ExpiresAt = database.TimeToPgtype(*expiresAt)
+	}
+
+	code, err := db.CreateGiftCode(context.Background(), params)
+	require.NoError(t, err)
+	return code
+}
+
+// insertTestHold reserves amount of the user's coins for an hour without
+// checking what is spendable, as a hold taken before its coins expired
+func insertTestHold(t *testing.T, db *memdb.Queries, userID uuid.UUID, amount int32) database.CoinHold {
+	t.Helper()
+
+	_, err := db.UpdateUserHeldCoins(context.Background(), database.UpdateUserHeldCoinsParams{
+		ID:        database.UUIDToPgtype(userID),
+		HeldCoins: amount,
+	})
+	require.NoError(t, err)
+
+	hold, err := db.CreateCoinHold(context.Background(), database.CreateCoinHoldParams{
+		UserID:    database.UUIDToPgtype(userID),
+		Amount:    amount,
+		ExpiresAt: database.TimeToPgtype(time.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	return hold
+}
@@ -2,84 +2,127 @@ package repository
 
 import (
 	"backend/internal/database"
+	"backend/internal/domain"
 	"backend/internal/entity"
 	"context"
-	"database/sql"
-	"errors"
+	"crypto/rand"
+	"fmt"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/pgtype"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type UserRepository interface {
 	GetUserById(ctx context.Context, id uuid.UUID) (*entity.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
+	GetUserByReferralCode(ctx context.Context, code string) (*entity.User, error)
+	// LockNormalizedEmail holds a lock on the address until the transaction in
+	// ctx ends, so concurrent sign-ups of one inbox count each other
+	LockNormalizedEmail(ctx context.Context, normalizedEmail string) error
+	CountUsersByNormalizedEmail(ctx context.Context, normalizedEmail string) (int, error)
 	CreateUser(ctx context.Context, req entity.CreateUserRequest) (*entity.User, error)
 	UpdateUserName(ctx context.Context, id uuid.UUID, name string) (*entity.User, error)
 	UpdateUserEmail(ctx context.Context, id uuid.UUID, email string) (*entity.User, error)
 	UpdateUserPassword(ctx context.Context, id uuid.UUID, newPassword string) error
-	UpdateUserCoins(ctx context.Context, id uuid.UUID, coinsDelta int) (*entity.User, error)
 	CheckEmailExists(ctx context.Context, email string) (bool, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) error
 }
 
 type userRepository struct {
-	queries *database.Queries
-	db      *pgxpool.Pool
+	queries     database.Querier
+	signupCoins int
 }
 
-func NewUserRepository(queries *database.Queries, db *pgxpool.Pool) UserRepository {
+// NewUserRepository returns a UserRepository that starts new users with
+// signupCoins coins
+func NewUserRepository(queries database.Querier, signupCoins int) UserRepository {
 	return &userRepository{
-		queries: queries,
-		db:      db,
+		queries:     queries,
+		signupCoins: signupCoins,
 	}
 }
 
 func (r *userRepository) GetUserById(ctx context.Context, id uuid.UUID) (*entity.User, error) {
-	dbUser, err := r.queries.GetUserByID(ctx, database.UUIDToPgtype(id))
+	dbUser, err := database.QuerierFromContext(ctx, r.queries).GetUserByID(ctx, database.UUIDToPgtype(id))
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("user not found")
-		}
-		return nil, err
+		return nil, database.TranslateError(err, domain.ErrUserNotFound)
 	}
 
 	user := &entity.User{
-		ID:           database.PgtypeToUUID(dbUser.ID),
-		Name:         dbUser.Name,
-		Email:        dbUser.Email,
-		PasswordHash: dbUser.PasswordHash,
-		Coins:        int(database.PgtypeToInt32(dbUser.Coins)),
-		CreatedAt:    dbUser.CreatedAt.Time,
-		UpdatedAt:    dbUser.UpdatedAt.Time,
+		ID:             database.PgtypeToUUID(dbUser.ID),
+		Name:           dbUser.Name,
+		Email:          dbUser.Email,
+		PasswordHash:   dbUser.PasswordHash,
+		Coins:          int(database.PgtypeToInt32(dbUser.Coins)),
+		AvailableCoins: availableCoins(dbUser.Coins, dbUser.HeldCoins),
+		ReferralCode:   dbUser.ReferralCode,
+		IsAdmin:        dbUser.IsAdmin,
+		CreatedAt:      dbUser.CreatedAt.Time,
+		UpdatedAt:      dbUser.UpdatedAt.Time,
 	}
 
 	return user, nil
 }
 
 func (r *userRepository) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
-	dbUser, err := r.queries.GetUserByEmail(ctx, email)
+	dbUser, err := database.QuerierFromContext(ctx, r.queries).GetUserByEmail(ctx, email)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("user not found")
-		}
-		return nil, err
+		return nil, database.TranslateError(err, domain.ErrUserNotFound)
+	}
+
+	user := &entity.User{
+		ID:             database.PgtypeToUUID(dbUser.ID),
+		Name:           dbUser.Name,
+		Email:          dbUser.Email,
+		PasswordHash:   dbUser.PasswordHash,
+		Coins:          int(database.PgtypeToInt32(dbUser.Coins)),
+		AvailableCoins: availableCoins(dbUser.Coins, dbUser.HeldCoins),
+		ReferralCode:   dbUser.ReferralCode,
+		IsAdmin:        dbUser.IsAdmin,
+		CreatedAt:      dbUser.CreatedAt.Time,
+		UpdatedAt:      dbUser.UpdatedAt.Time,
+	}
+
+	return user, nil
+}
+
+func (r *userRepository) GetUserByReferralCode(ctx context.Context, code string) (*entity.User, error) {
+	dbUser, err := database.QuerierFromContext(ctx, r.queries).GetUserByReferralCode(ctx, code)
+	if err != nil {
+		return nil, database.TranslateError(err, domain.ErrUserNotFound)
 	}
 
 	user := &entity.User{
-		ID:           database.PgtypeToUUID(dbUser.ID),
-		Name:         dbUser.Name,
-		Email:        dbUser.Email,
-		PasswordHash: dbUser.PasswordHash,
-		Coins:        int(database.PgtypeToInt32(dbUser.Coins)),
-		CreatedAt:    dbUser.CreatedAt.Time,
-		UpdatedAt:    dbUser.UpdatedAt.Time,
+		ID:             database.PgtypeToUUID(dbUser.ID),
+		Name:           dbUser.Name,
+		Email:          dbUser.Email,
+		Coins:          int(database.PgtypeToInt32(dbUser.Coins)),
+		AvailableCoins: availableCoins(dbUser.Coins, dbUser.HeldCoins),
+		ReferralCode:   dbUser.ReferralCode,
+		IsAdmin:        dbUser.IsAdmin,
+		CreatedAt:      dbUser.CreatedAt.Time,
+		UpdatedAt:      dbUser.UpdatedAt.Time,
 	}
 
 	return user, nil
 }
 
+func (r *userRepository) LockNormalizedEmail(ctx context.Context, normalizedEmail string) error {
+	if err := database.QuerierFromContext(ctx, r.queries).LockNormalizedEmail(ctx, normalizedEmail); err != nil {
+		return fmt.Errorf("failed to lock email: %w", database.TranslateError(err, nil))
+	}
+	return nil
+}
+
+func (r *userRepository) CountUsersByNormalizedEmail(ctx context.Context, normalizedEmail string) (int, error) {
+	count, err := database.QuerierFromContext(ctx, r.queries).CountUsersByNormalizedEmail(ctx, normalizedEmail)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check for existing accounts: %w", err)
+	}
+	return int(count), nil
+}
+
 func (r *userRepository) CreateUser(ctx context.Context, req entity.CreateUserRequest) (*entity.User, error) {
 	// Check if email already exists
 	exists, err := r.CheckEmailExists(ctx, req.Email)
@@ -87,7 +130,7 @@ func (r *userRepository) CreateUser(ctx context.Context, req entity.CreateUserRe
 		return nil, err
 	}
 	if exists {
-		return nil, errors.New("user already exists")
+		return nil, domain.ErrUserAlreadyExists
 	}
 
 	// Hash password
@@ -96,48 +139,71 @@ func (r *userRepository) CreateUser(ctx context.Context, req entity.CreateUserRe
 		return nil, err
 	}
 
+	referralCode, err := newReferralCode()
+	if err != nil {
+		return nil, err
+	}
+
 	// Create user in database
-	dbUser, err := r.queries.CreateUser(ctx, database.CreateUserParams{
-		Name:         req.Name,
-		Email:        req.Email,
-		PasswordHash: string(hashedPassword),
-		Coins:        database.Int32ToPgtype(1000),
+	q := database.QuerierFromContext(ctx, r.queries)
+	dbUser, err := q.CreateUser(ctx, database.CreateUserParams{
+		Name:            req.Name,
+		Email:           req.Email,
+		PasswordHash:    string(hashedPassword),
+		Coins:           database.Int32ToPgtype(int32(r.signupCoins)),
+		ReferralCode:    referralCode,
+		NormalizedEmail: entity.NormalizeEmail(req.Email),
 	})
 	if err != nil {
-		return nil, err
+		return nil, database.TranslateError(err, nil)
+	}
+
+	// The sign-up bonus is ledgered like any other grant, so balances can be
+	// read back from the ledger
+	if coins := database.PgtypeToInt32(dbUser.Coins); coins > 0 {
+		if _, err := q.CreateCoinTransaction(ctx, database.CreateCoinTransactionParams{
+			UserID:          dbUser.ID,
+			TransactionType: database.TransactionType(entity.TransactionTypeBonus),
+			Amount:          coins,
+			BalanceAfter:    coins,
+			Description:     database.StringToPgtype("Sign-up bonus"),
+		}); err != nil {
+			return nil, fmt.Errorf("failed to create transaction: %w", database.TranslateError(err, nil))
+		}
 	}
 
 	user := &entity.User{
-		ID:        database.PgtypeToUUID(dbUser.ID),
-		Name:      dbUser.Name,
-		Email:     dbUser.Email,
-		Coins:     int(database.PgtypeToInt32(dbUser.Coins)),
-		CreatedAt: dbUser.CreatedAt.Time,
-		UpdatedAt: dbUser.UpdatedAt.Time,
+		ID:             database.PgtypeToUUID(dbUser.ID),
+		Name:           dbUser.Name,
+		Email:          dbUser.Email,
+		Coins:          int(database.PgtypeToInt32(dbUser.Coins)),
+		AvailableCoins: availableCoins(dbUser.Coins, dbUser.HeldCoins),
+		ReferralCode:   dbUser.ReferralCode,
+		CreatedAt:      dbUser.CreatedAt.Time,
+		UpdatedAt:      dbUser.UpdatedAt.Time,
 	}
 
 	return user, nil
 }
 
 func (r *userRepository) UpdateUserName(ctx context.Context, id uuid.UUID, name string) (*entity.User, error) {
-	dbUser, err := r.queries.UpdateUserName(ctx, database.UpdateUserNameParams{
+	dbUser, err := database.QuerierFromContext(ctx, r.queries).UpdateUserName(ctx, database.UpdateUserNameParams{
 		ID:   database.UUIDToPgtype(id),
 		Name: name,
 	})
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("user not found")
-		}
-		return nil, err
+		return nil, database.TranslateError(err, domain.ErrUserNotFound)
 	}
 
 	user := &entity.User{
-		ID:        database.PgtypeToUUID(dbUser.ID),
-		Name:      dbUser.Name,
-		Email:     dbUser.Email,
-		Coins:     int(database.PgtypeToInt32(dbUser.Coins)),
-		CreatedAt: dbUser.CreatedAt.Time,
-		UpdatedAt: dbUser.UpdatedAt.Time,
+		ID:             database.PgtypeToUUID(dbUser.ID),
+		Name:           dbUser.Name,
+		Email:          dbUser.Email,
+		Coins:          int(database.PgtypeToInt32(dbUser.Coins)),
+		AvailableCoins: availableCoins(dbUser.Coins, dbUser.HeldCoins),
+		ReferralCode:   dbUser.ReferralCode,
+		CreatedAt:      dbUser.CreatedAt.Time,
+		UpdatedAt:      dbUser.UpdatedAt.Time,
 	}
 
 	return user, nil
@@ -145,7 +211,7 @@ func (r *userRepository) UpdateUserName(ctx context.Context, id uuid.UUID, name
 
 func (r *userRepository) UpdateUserEmail(ctx context.Context, id uuid.UUID, email string) (*entity.User, error) {
 	// Check if new email already exists for another user
-	exists, err := r.queries.CheckEmailExistsForOtherUser(ctx, database.CheckEmailExistsForOtherUserParams{
+	exists, err := database.QuerierFromContext(ctx, r.queries).CheckEmailExistsForOtherUser(ctx, database.CheckEmailExistsForOtherUserParams{
 		Email: email,
 		ID:    database.UUIDToPgtype(id),
 	})
@@ -153,51 +219,27 @@ func (r *userRepository) UpdateUserEmail(ctx context.Context, id uuid.UUID, emai
 		return nil, err
 	}
 	if exists {
-		return nil, errors.New("email already exists")
+		return nil, domain.ErrEmailAlreadyExists
 	}
 
-	dbUser, err := r.queries.UpdateUserEmail(ctx, database.UpdateUserEmailParams{
-		ID:    database.UUIDToPgtype(id),
-		Email: email,
-	})
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("user not found")
-		}
-		return nil, err
-	}
-
-	user := &entity.User{
-		ID:        database.PgtypeToUUID(dbUser.ID),
-		Name:      dbUser.Name,
-		Email:     dbUser.Email,
-		Coins:     int(database.PgtypeToInt32(dbUser.Coins)),
-		CreatedAt: dbUser.CreatedAt.Time,
-		UpdatedAt: dbUser.UpdatedAt.Time,
-	}
-
-	return user, nil
-}
-
-func (r *userRepository) UpdateUserCoins(ctx context.Context, id uuid.UUID, coinsDelta int) (*entity.User, error) {
-	dbUser, err := r.queries.UpdateUserCoins(ctx, database.UpdateUserCoinsParams{
-		ID:    database.UUIDToPgtype(id),
-		Coins: database.Int32ToPgtype(int32(coinsDelta)),
+	dbUser, err := database.QuerierFromContext(ctx, r.queries).UpdateUserEmail(ctx, database.UpdateUserEmailParams{
+		ID:              database.UUIDToPgtype(id),
+		Email:           email,
+		NormalizedEmail: entity.NormalizeEmail(email),
 	})
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("user not found")
-		}
-		return nil, err
+		return nil, database.TranslateError(err, domain.ErrUserNotFound)
 	}
 
 	user := &entity.User{
-		ID:        database.PgtypeToUUID(dbUser.ID),
-		Name:      dbUser.Name,
-		Email:     dbUser.Email,
-		Coins:     int(database.PgtypeToInt32(dbUser.Coins)),
-		CreatedAt: dbUser.CreatedAt.Time,
-		UpdatedAt: dbUser.UpdatedAt.Time,
+		ID:             database.PgtypeToUUID(dbUser.ID),
+		Name:           dbUser.Name,
+		Email:          dbUser.Email,
+		Coins:          int(database.PgtypeToInt32(dbUser.Coins)),
+		AvailableCoins: availableCoins(dbUser.Coins, dbUser.HeldCoins),
+		ReferralCode:   dbUser.ReferralCode,
+		CreatedAt:      dbUser.CreatedAt.Time,
+		UpdatedAt:      dbUser.UpdatedAt.Time,
 	}
 
 	return user, nil
@@ -210,29 +252,54 @@ func (r *userRepository) UpdateUserPassword(ctx context.Context, id uuid.UUID, n
 		return err
 	}
 
-	err = r.queries.UpdateUserPassword(ctx, database.UpdateUserPasswordParams{
+	err = database.QuerierFromContext(ctx, r.queries).UpdateUserPassword(ctx, database.UpdateUserPasswordParams{
 		ID:           database.UUIDToPgtype(id),
 		PasswordHash: string(hashedPassword),
 	})
 	if err != nil {
-		return err
+		return database.TranslateError(err, nil)
 	}
 
 	return nil
 }
 
 func (r *userRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	err := r.queries.DeleteUser(ctx, database.UUIDToPgtype(id))
+	err := database.QuerierFromContext(ctx, r.queries).DeleteUser(ctx, database.UUIDToPgtype(id))
 	if err != nil {
-		return err
+		return database.TranslateError(err, nil)
 	}
 	return nil
 }
 
 func (r *userRepository) CheckEmailExists(ctx context.Context, email string) (bool, error) {
-	exists, err := r.queries.CheckEmailExists(ctx, email)
+	exists, err := database.QuerierFromContext(ctx, r.queries).CheckEmailExists(ctx, email)
 	if err != nil {
 		return false, err
 	}
 	return exists, nil
 }
+
+// referralCodeAlphabet leaves out 0/O and 1/I so shared codes are easy to type
+const referralCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+const referralCodeLength = 8
+
+func newReferralCode() (string, error) {
+	buf := make([]byte, referralCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate referral code: %w", err)
+	}
+
+	// 256 is a multiple of 32, so masking keeps every letter equally likely
+	code := make([]byte, referralCodeLength)
+	for i, b := range buf {
+		code[i] = referralCodeAlphabet[b&31]
+	}
+
+	return string(code), nil
+}
+
+// availableCoins is the part of the settled balance not reserved by holds
+func availableCoins(coins pgtype.Int4, heldCoins int32) int {
+	return int(database.PgtypeToInt32(coins) - heldCoins)
+}
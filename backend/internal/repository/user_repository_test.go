@@ -2,234 +2,22 @@ package repository
 
 import (
 	"backend/internal/database"
+	"backend/internal/domain"
 	"backend/internal/entity"
 	"backend/mocks"
 	"context"
-	"database/sql"
-	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
 )
 
-// Test repository wrapper that implements UserRepository interface
-type testUserRepository struct {
-	queries mocks.UserQueriesInterface
-}
-
-func NewTestUserRepository(queries mocks.UserQueriesInterface) UserRepository {
-	return &testUserRepository{
-		queries: queries,
-	}
-}
-
-func (r *testUserRepository) GetUserById(ctx context.Context, id uuid.UUID) (*entity.User, error) {
-	dbUser, err := r.queries.GetUserByID(ctx, database.UUIDToPgtype(id))
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("user not found")
-		}
-		return nil, err
-	}
-
-	user := &entity.User{
-		ID:           database.PgtypeToUUID(dbUser.ID),
-		Name:         dbUser.Name,
-		Email:        dbUser.Email,
-		PasswordHash: dbUser.PasswordHash,
-		Coins:        int(database.PgtypeToInt32(dbUser.Coins)),
-		CreatedAt:    dbUser.CreatedAt.Time,
-		UpdatedAt:    dbUser.UpdatedAt.Time,
-	}
-
-	return user, nil
-}
-
-func (r *testUserRepository) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
-	dbUser, err := r.queries.GetUserByEmail(ctx, email)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("user not found")
-		}
-		return nil, err
-	}
-
-	user := &entity.User{
-		ID:           database.PgtypeToUUID(dbUser.ID),
-		Name:         dbUser.Name,
-		Email:        dbUser.Email,
-		PasswordHash: dbUser.PasswordHash,
-		Coins:        int(database.PgtypeToInt32(dbUser.Coins)),
-		CreatedAt:    dbUser.CreatedAt.Time,
-		UpdatedAt:    dbUser.UpdatedAt.Time,
-	}
-
-	return user, nil
-}
-
-func (r *testUserRepository) CreateUser(ctx context.Context, req entity.CreateUserRequest) (*entity.User, error) {
-	// Check if email already exists
-	exists, err := r.CheckEmailExists(ctx, req.Email)
-	if err != nil {
-		return nil, err
-	}
-	if exists {
-		return nil, errors.New("email already exists")
-	}
-
-	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
-	if err != nil {
-		return nil, err
-	}
-
-	// Create user in database
-	dbUser, err := r.queries.CreateUser(ctx, database.CreateUserParams{
-		Name:         req.Name,
-		Email:        req.Email,
-		PasswordHash: string(hashedPassword),
-		Coins:        database.Int32ToPgtype(int32(req.Coins)),
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	user := &entity.User{
-		ID:        database.PgtypeToUUID(dbUser.ID),
-		Name:      dbUser.Name,
-		Email:     dbUser.Email,
-		Coins:     int(database.PgtypeToInt32(dbUser.Coins)),
-		CreatedAt: dbUser.CreatedAt.Time,
-		UpdatedAt: dbUser.UpdatedAt.Time,
-	}
-
-	return user, nil
-}
-
-func (r *testUserRepository) UpdateUserName(ctx context.Context, id uuid.UUID, name string) (*entity.User, error) {
-	dbUser, err := r.queries.UpdateUserName(ctx, database.UpdateUserNameParams{
-		ID:   database.UUIDToPgtype(id),
-		Name: name,
-	})
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("user not found")
-		}
-		return nil, err
-	}
-
-	user := &entity.User{
-		ID:        database.PgtypeToUUID(dbUser.ID),
-		Name:      dbUser.Name,
-		Email:     dbUser.Email,
-		Coins:     int(database.PgtypeToInt32(dbUser.Coins)),
-		CreatedAt: dbUser.CreatedAt.Time,
-		UpdatedAt: dbUser.UpdatedAt.Time,
-	}
-
-	return user, nil
-}
-
-func (r *testUserRepository) UpdateUserEmail(ctx context.Context, id uuid.UUID, email string) (*entity.User, error) {
-	// Check if new email already exists for another user
-	exists, err := r.queries.CheckEmailExistsForOtherUser(ctx, database.CheckEmailExistsForOtherUserParams{
-		Email: email,
-		ID:    database.UUIDToPgtype(id),
-	})
-	if err != nil {
-		return nil, err
-	}
-	if exists {
-		return nil, errors.New("email already exists")
-	}
-
-	dbUser, err := r.queries.UpdateUserEmail(ctx, database.UpdateUserEmailParams{
-		ID:    database.UUIDToPgtype(id),
-		Email: email,
-	})
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("user not found")
-		}
-		return nil, err
-	}
-
-	user := &entity.User{
-		ID:        database.PgtypeToUUID(dbUser.ID),
-		Name:      dbUser.Name,
-		Email:     dbUser.Email,
-		Coins:     int(database.PgtypeToInt32(dbUser.Coins)),
-		CreatedAt: dbUser.CreatedAt.Time,
-		UpdatedAt: dbUser.UpdatedAt.Time,
-	}
-
-	return user, nil
-}
-
-func (r *testUserRepository) UpdateUserPassword(ctx context.Context, id uuid.UUID, newPassword string) error {
-	// Hash new password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
-	if err != nil {
-		return err
-	}
-
-	// Update password
-	err = r.queries.UpdateUserPassword(ctx, database.UpdateUserPasswordParams{
-		ID:           database.UUIDToPgtype(id),
-		PasswordHash: string(hashedPassword),
-	})
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (r *testUserRepository) UpdateUserCoins(ctx context.Context, id uuid.UUID, coinsDelta int) (*entity.User, error) {
-	dbUser, err := r.queries.UpdateUserCoins(ctx, database.UpdateUserCoinsParams{
-		ID:    database.UUIDToPgtype(id),
-		Coins: database.Int32ToPgtype(int32(coinsDelta)),
-	})
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("user not found")
-		}
-		return nil, err
-	}
-
-	user := &entity.User{
-		ID:        database.PgtypeToUUID(dbUser.ID),
-		Name:      dbUser.Name,
-		Email:     dbUser.Email,
-		Coins:     int(database.PgtypeToInt32(dbUser.Coins)),
-		CreatedAt: dbUser.CreatedAt.Time,
-		UpdatedAt: dbUser.UpdatedAt.Time,
-	}
-
-	return user, nil
-}
-
-func (r *testUserRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	err := r.queries.DeleteUser(ctx, database.UUIDToPgtype(id))
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
-func (r *testUserRepository) CheckEmailExists(ctx context.Context, email string) (bool, error) {
-	exists, err := r.queries.CheckEmailExists(ctx, email)
-	if err != nil {
-		return false, err
-	}
-	return exists, nil
-}
-
 // Helper functions for tests
 func createMockDBUser(id uuid.UUID, name, email, passwordHash string, coins int) database.User {
 	return database.User{
@@ -243,21 +31,21 @@ func createMockDBUser(id uuid.UUID, name, email, passwordHash string, coins int)
 	}
 }
 
-func setupUserTestRepository() (UserRepository, *mocks.MockUserQueries) {
-	mockQueries := new(mocks.MockUserQueries)
-	repo := NewTestUserRepository(mockQueries)
+func setupUserTestRepository(t *testing.T) (UserRepository, *mocks.MockQuerier) {
+	mockQueries := mocks.NewMockQuerier(t)
+	repo := NewUserRepository(mockQueries, 0)
 	return repo, mockQueries
 }
 
 // Tests
 func TestGetUserById_Found(t *testing.T) {
-	repo, mockQueries := setupUserTestRepository()
+	repo, mockQueries := setupUserTestRepository(t)
 	ctx := context.Background()
 
 	testUserID := uuid.New()
 	expectedDBUser := createMockDBUser(testUserID, "Alice", "alice@example.com", "hashedpassword", 100)
 
-	mockQueries.On("GetUserByID", ctx, database.UUIDToPgtype(testUserID)).
+	mockQueries.EXPECT().GetUserByID(ctx, database.UUIDToPgtype(testUserID)).
 		Return(expectedDBUser, nil)
 
 	user, err := repo.GetUserById(ctx, testUserID)
@@ -268,37 +56,32 @@ func TestGetUserById_Found(t *testing.T) {
 	assert.Equal(t, "Alice", user.Name)
 	assert.Equal(t, "alice@example.com", user.Email)
 	assert.Equal(t, 100, user.Coins)
-
-	mockQueries.AssertExpectations(t)
 }
 
 func TestGetUserById_NotFound(t *testing.T) {
-	repo, mockQueries := setupUserTestRepository()
+	repo, mockQueries := setupUserTestRepository(t)
 	ctx := context.Background()
 
 	testUserID := uuid.New()
 
-	mockQueries.On("GetUserByID", ctx, database.UUIDToPgtype(testUserID)).
-		Return(database.User{}, sql.ErrNoRows)
+	mockQueries.EXPECT().GetUserByID(ctx, database.UUIDToPgtype(testUserID)).
+		Return(database.User{}, pgx.ErrNoRows)
 
 	user, err := repo.GetUserById(ctx, testUserID)
 
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
 	assert.Nil(t, user)
-	assert.Equal(t, "user not found", err.Error())
-
-	mockQueries.AssertExpectations(t)
 }
 
 func TestGetUserByEmail_Found(t *testing.T) {
-	repo, mockQueries := setupUserTestRepository()
+	repo, mockQueries := setupUserTestRepository(t)
 	ctx := context.Background()
 
 	testEmail := "alice@example.com"
 	testUserID := uuid.New()
 	expectedDBUser := createMockDBUser(testUserID, "Alice", testEmail, "hashedpassword", 50)
 
-	mockQueries.On("GetUserByEmail", ctx, testEmail).
+	mockQueries.EXPECT().GetUserByEmail(ctx, testEmail).
 		Return(expectedDBUser, nil)
 
 	user, err := repo.GetUserByEmail(ctx, testEmail)
@@ -307,65 +90,79 @@ func TestGetUserByEmail_Found(t *testing.T) {
 	assert.NotNil(t, user)
 	assert.Equal(t, testEmail, user.Email)
 	assert.Equal(t, "Alice", user.Name)
-
-	mockQueries.AssertExpectations(t)
 }
 
 func TestGetUserByEmail_NotFound(t *testing.T) {
-	repo, mockQueries := setupUserTestRepository()
+	repo, mockQueries := setupUserTestRepository(t)
 	ctx := context.Background()
 
 	testEmail := "nonexistent@example.com"
 
-	mockQueries.On("GetUserByEmail", ctx, testEmail).
-		Return(database.User{}, sql.ErrNoRows)
+	mockQueries.EXPECT().GetUserByEmail(ctx, testEmail).
+		Return(database.User{}, pgx.ErrNoRows)
 
 	user, err := repo.GetUserByEmail(ctx, testEmail)
 
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
 	assert.Nil(t, user)
-	assert.Equal(t, "user not found", err.Error())
-
-	mockQueries.AssertExpectations(t)
 }
 
 func TestCreateUser_Success(t *testing.T) {
-	repo, mockQueries := setupUserTestRepository()
+	repo, mockQueries := setupUserTestRepository(t)
 	ctx := context.Background()
 
 	req := entity.CreateUserRequest{
 		Name:     "Bob",
 		Email:    "bob@example.com",
 		Password: "secret123",
-		Coins:    0,
 	}
 
 	// Mock CheckEmailExists to return false
-	mockQueries.On("CheckEmailExists", ctx, req.Email).
+	mockQueries.EXPECT().CheckEmailExists(ctx, req.Email).
 		Return(false, nil)
 
 	// Mock CreateUser
 	createdUserID := uuid.New()
-	mockQueries.On("CreateUser", ctx, mock.MatchedBy(func(params database.CreateUserParams) bool {
+	mockQueries.EXPECT().CreateUser(mock.Anything, mock.MatchedBy(func(params database.CreateUserParams) bool {
 		return params.Name == req.Name &&
 			params.Email == req.Email &&
-			database.PgtypeToInt32(params.Coins) == int32(req.Coins) &&
-			len(params.PasswordHash) > 0
-	})).Return(createMockDBUser(createdUserID, req.Name, req.Email, "hashedpassword", req.Coins), nil)
+			params.NormalizedEmail == "bob@example.com" &&
+			database.PgtypeToInt32(params.Coins) == 0 &&
+			len(params.ReferralCode) == referralCodeLength &&
+			bcrypt.CompareHashAndPassword([]byte(params.PasswordHash), []byte(req.Password)) == nil
+	})).Return(database.CreateUserRow{
+		ID:           database.UUIDToPgtype(createdUserID),
+		Name:         req.Name,
+		Email:        req.Email,
+		Coins:        database.Int32ToPgtype(0),
+		ReferralCode: "ABCD2345",
+	}, nil)
 
 	user, err := repo.CreateUser(ctx, req)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
+	assert.Equal(t, createdUserID, user.ID)
 	assert.Equal(t, req.Name, user.Name)
 	assert.Equal(t, req.Email, user.Email)
-	assert.Equal(t, req.Coins, user.Coins)
+	assert.Equal(t, 0, user.Coins)
+	assert.Equal(t, "ABCD2345", user.ReferralCode)
+}
 
-	mockQueries.AssertExpectations(t)
+func TestGetUserByReferralCode_NotFound(t *testing.T) {
+	repo, mockQueries := setupUserTestRepository(t)
+	ctx := context.Background()
+
+	mockQueries.EXPECT().GetUserByReferralCode(ctx, "NOSUCH23").Return(database.User{}, pgx.ErrNoRows)
+
+	user, err := repo.GetUserByReferralCode(ctx, "NOSUCH23")
+
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	assert.Nil(t, user)
 }
 
 func TestCreateUser_EmailExists(t *testing.T) {
-	repo, mockQueries := setupUserTestRepository()
+	repo, mockQueries := setupUserTestRepository(t)
 	ctx := context.Background()
 
 	req := entity.CreateUserRequest{
@@ -375,28 +172,49 @@ func TestCreateUser_EmailExists(t *testing.T) {
 		Coins:    0,
 	}
 
-	mockQueries.On("CheckEmailExists", ctx, req.Email).
+	mockQueries.EXPECT().CheckEmailExists(ctx, req.Email).
 		Return(true, nil)
 
 	user, err := repo.CreateUser(ctx, req)
 
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrUserAlreadyExists)
 	assert.Nil(t, user)
-	assert.Contains(t, err.Error(), "email already exists")
+}
+
+func TestCreateUser_LedgersSignupBonus(t *testing.T) {
+	db, _ := newTestDB(t)
+	repo := NewUserRepository(db, 500)
 
-	mockQueries.AssertExpectations(t)
+	user, err := repo.CreateUser(context.Background(), entity.CreateUserRequest{
+		Name:     "Bob",
+		Email:    "bob@example.com",
+		Password: "secret123",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 500, user.Coins)
+	transactions := listTestTransactions(t, db, user.ID)
+	require.Len(t, transactions, 1)
+	assert.Equal(t, database.TransactionType(entity.TransactionTypeBonus), transactions[0].TransactionType)
+	assert.Equal(t, int32(500), transactions[0].Amount)
+	assert.Equal(t, int32(500), transactions[0].BalanceAfter)
 }
 
 func TestUpdateUserName_Success(t *testing.T) {
-	repo, mockQueries := setupUserTestRepository()
+	repo, mockQueries := setupUserTestRepository(t)
 	ctx := context.Background()
 
 	testUserID := uuid.New()
 	newName := "Updated Name"
 
-	updatedUser := createMockDBUser(testUserID, newName, "test@example.com", "hashedpassword", 100)
+	updatedUser := database.UpdateUserNameRow{
+		ID:    database.UUIDToPgtype(testUserID),
+		Name:  newName,
+		Email: "test@example.com",
+		Coins: database.Int32ToPgtype(100),
+	}
 
-	mockQueries.On("UpdateUserName", ctx, database.UpdateUserNameParams{
+	mockQueries.EXPECT().UpdateUserName(ctx, database.UpdateUserNameParams{
 		ID:   database.UUIDToPgtype(testUserID),
 		Name: newName,
 	}).Return(updatedUser, nil)
@@ -406,146 +224,116 @@ func TestUpdateUserName_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
 	assert.Equal(t, newName, user.Name)
-
-	mockQueries.AssertExpectations(t)
 }
 
 func TestUpdateUserEmail_Success(t *testing.T) {
-	repo, mockQueries := setupUserTestRepository()
+	repo, mockQueries := setupUserTestRepository(t)
 	ctx := context.Background()
 
 	testUserID := uuid.New()
 	newEmail := "newemail@example.com"
 
 	// Mock email check
-	mockQueries.On("CheckEmailExistsForOtherUser", ctx, database.CheckEmailExistsForOtherUserParams{
+	mockQueries.EXPECT().CheckEmailExistsForOtherUser(ctx, database.CheckEmailExistsForOtherUserParams{
 		Email: newEmail,
 		ID:    database.UUIDToPgtype(testUserID),
 	}).Return(false, nil)
 
 	// Mock update
-	updatedUser := createMockDBUser(testUserID, "Test User", newEmail, "hashedpassword", 100)
-	mockQueries.On("UpdateUserEmail", ctx, database.UpdateUserEmailParams{
+	updatedUser := database.UpdateUserEmailRow{
 		ID:    database.UUIDToPgtype(testUserID),
+		Name:  "Test User",
 		Email: newEmail,
+		Coins: database.Int32ToPgtype(100),
+	}
+	mockQueries.EXPECT().UpdateUserEmail(ctx, database.UpdateUserEmailParams{
+		ID:              database.UUIDToPgtype(testUserID),
+		Email:           newEmail,
+		NormalizedEmail: newEmail,
 	}).Return(updatedUser, nil)
 
 	user, err := repo.UpdateUserEmail(ctx, testUserID, newEmail)
 
 	assert.NoError(t, err)
 	assert.Equal(t, newEmail, user.Email)
-
-	mockQueries.AssertExpectations(t)
 }
 
 func TestUpdateUserEmail_EmailTaken(t *testing.T) {
-	repo, mockQueries := setupUserTestRepository()
+	repo, mockQueries := setupUserTestRepository(t)
 	ctx := context.Background()
 
 	testUserID := uuid.New()
 	newEmail := "taken@example.com"
 
-	mockQueries.On("CheckEmailExistsForOtherUser", ctx, database.CheckEmailExistsForOtherUserParams{
+	mockQueries.EXPECT().CheckEmailExistsForOtherUser(ctx, database.CheckEmailExistsForOtherUserParams{
 		Email: newEmail,
 		ID:    database.UUIDToPgtype(testUserID),
 	}).Return(true, nil)
 
 	user, err := repo.UpdateUserEmail(ctx, testUserID, newEmail)
 
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrEmailAlreadyExists)
 	assert.Nil(t, user)
-	assert.Contains(t, err.Error(), "email already exists")
-
-	mockQueries.AssertExpectations(t)
 }
 
 func TestUpdateUserPassword_Success(t *testing.T) {
-	repo, mockQueries := setupUserTestRepository()
+	repo, mockQueries := setupUserTestRepository(t)
 	ctx := context.Background()
 
 	testUserID := uuid.New()
 	newPassword := "newpassword123"
 
 	// Mock password update
-	mockQueries.On("UpdateUserPassword", ctx, mock.MatchedBy(func(params database.UpdateUserPasswordParams) bool {
-		return database.PgtypeToUUID(params.ID) == testUserID && len(params.PasswordHash) > 0
+	mockQueries.EXPECT().UpdateUserPassword(ctx, mock.MatchedBy(func(params database.UpdateUserPasswordParams) bool {
+		return database.PgtypeToUUID(params.ID) == testUserID &&
+			bcrypt.CompareHashAndPassword([]byte(params.PasswordHash), []byte(newPassword)) == nil
 	})).Return(nil)
 
 	err := repo.UpdateUserPassword(ctx, testUserID, newPassword)
 
 	assert.NoError(t, err)
-	mockQueries.AssertExpectations(t)
-}
-
-func TestUpdateUserCoins_Success(t *testing.T) {
-	repo, mockQueries := setupUserTestRepository()
-	ctx := context.Background()
-
-	testUserID := uuid.New()
-	coinsDelta := 50
-
-	updatedUser := createMockDBUser(testUserID, "Test User", "test@example.com", "hashedpassword", 150)
-
-	mockQueries.On("UpdateUserCoins", ctx, database.UpdateUserCoinsParams{
-		ID:    database.UUIDToPgtype(testUserID),
-		Coins: database.Int32ToPgtype(int32(coinsDelta)),
-	}).Return(updatedUser, nil)
-
-	user, err := repo.UpdateUserCoins(ctx, testUserID, coinsDelta)
-
-	assert.NoError(t, err)
-	assert.NotNil(t, user)
-	assert.Equal(t, 150, user.Coins)
-	assert.Equal(t, testUserID, user.ID)
-
-	mockQueries.AssertExpectations(t)
 }
 
 func TestDeleteUser_Success(t *testing.T) {
-	repo, mockQueries := setupUserTestRepository()
+	repo, mockQueries := setupUserTestRepository(t)
 	ctx := context.Background()
 
 	testUserID := uuid.New()
 
-	mockQueries.On("DeleteUser", ctx, database.UUIDToPgtype(testUserID)).
+	mockQueries.EXPECT().DeleteUser(ctx, database.UUIDToPgtype(testUserID)).
 		Return(nil)
 
 	err := repo.DeleteUser(ctx, testUserID)
 
 	assert.NoError(t, err)
-	mockQueries.AssertExpectations(t)
 }
 
 func TestCheckEmailExists_True(t *testing.T) {
-	repo, mockQueries := setupUserTestRepository()
+	repo, mockQueries := setupUserTestRepository(t)
 	ctx := context.Background()
 
 	testEmail := "existing@example.com"
 
-	mockQueries.On("CheckEmailExists", ctx, testEmail).
+	mockQueries.EXPECT().CheckEmailExists(ctx, testEmail).
 		Return(true, nil)
 
 	exists, err := repo.CheckEmailExists(ctx, testEmail)
 
 	assert.NoError(t, err)
 	assert.True(t, exists)
-
-	mockQueries.AssertExpectations(t)
 }
 
 func TestCheckEmailExists_False(t *testing.T) {
-	repo, mockQueries := setupUserTestRepository()
+	repo, mockQueries := setupUserTestRepository(t)
 	ctx := context.Background()
 
 	testEmail := "nonexistent@example.com"
 
-	mockQueries.On("CheckEmailExists", ctx, testEmail).
+	mockQueries.EXPECT().CheckEmailExists(ctx, testEmail).
 		Return(false, nil)
 
 	exists, err := repo.CheckEmailExists(ctx, testEmail)
 
 	assert.NoError(t, err)
 	assert.False(t, exists)
-
-	mockQueries.AssertExpectations(t)
 }
@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	_, err := Setup(context.Background(), "zipkin", nil)
+	assert.EqualError(t, err, `unknown trace exporter "zipkin"`)
+}
+
+func TestSetup_ConsoleExporter(t *testing.T) {
+	var buf bytes.Buffer
+	shutdown, err := Setup(context.Background(), ExporterConsole, &buf)
+	require.NoError(t, err)
+
+	_, span := Tracer("test").Start(context.Background(), "checkout")
+	span.End()
+	require.NoError(t, shutdown(context.Background()))
+
+	assert.Contains(t, buf.String(), `"Name":"checkout"`)
+	assert.Contains(t, buf.String(), ServiceName)
+}
+
+func TestSetup_PropagatesTraceContext(t *testing.T) {
+	shutdown, err := Setup(context.Background(), ExporterNone, nil)
+	require.NoError(t, err)
+	defer shutdown(context.Background())
+
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	carrier := propagation.MapCarrier{"traceparent": traceparent}
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), carrier)
+
+	out := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, out)
+	assert.Equal(t, traceparent, out["traceparent"])
+}
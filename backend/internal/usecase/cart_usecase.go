@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"backend/internal/entity"
+	"backend/internal/metrics"
 	"backend/internal/repository"
 
 	"github.com/google/uuid"
@@ -17,18 +18,35 @@ func NewCartUseCase(r repository.CartRepository) *CartUseCase {
 	return &CartUseCase{repo: r}
 }
 
-func (u *CartUseCase) AddToCart(ctx context.Context, userID uuid.UUID, productID int, quantity int) error {
-	return u.repo.AddToCart(ctx, userID, productID, quantity)
+func (u *CartUseCase) AddToCart(ctx context.Context, userID uuid.UUID, productID int, quantity int) (err error) {
+	ctx, end := startSpan(ctx, "CartUseCase.AddToCart")
+	defer end(&err)
+
+	if err := u.repo.AddToCart(ctx, userID, productID, quantity); err != nil {
+		return err
+	}
+
+	metrics.CartAdds.Inc()
+	return nil
 }
 
-func (u *CartUseCase) GetCartItems(ctx context.Context, userID uuid.UUID) ([]entity.CartItem, error) {
+func (u *CartUseCase) GetCartItems(ctx context.Context, userID uuid.UUID) (_ []entity.CartItem, err error) {
+	ctx, end := startSpan(ctx, "CartUseCase.GetCartItems")
+	defer end(&err)
+
 	return u.repo.GetCartItems(ctx, userID)
 }
 
-func (u *CartUseCase) RemoveFromCart(ctx context.Context, userID uuid.UUID, productID int) error {
+func (u *CartUseCase) RemoveFromCart(ctx context.Context, userID uuid.UUID, productID int) (err error) {
+	ctx, end := startSpan(ctx, "CartUseCase.RemoveFromCart")
+	defer end(&err)
+
 	return u.repo.RemoveFromCart(ctx, userID, productID)
 }
 
-func (u *CartUseCase) ClearCart(ctx context.Context, userID uuid.UUID) error {
+func (u *CartUseCase) ClearCart(ctx context.Context, userID uuid.UUID) (err error) {
+	ctx, end := startSpan(ctx, "CartUseCase.ClearCart")
+	defer end(&err)
+
 	return u.repo.ClearCart(ctx, userID)
 }
@@ -62,12 +62,12 @@ func TestCartUseCase_AddToCart_Success(t *testing.T) {
 	mockRepo := new(MockCartRepository)
 	useCase := NewCartUseCase(mockRepo)
 
-	ctx := context.Background()
+	ctx := callerContext()
 	userID := uuid.New()
 	productID := 100
 	quantity := 2
 
-	mockRepo.On("AddToCart", ctx, userID, productID, quantity).Return(nil)
+	mockRepo.On("AddToCart", fromCaller, userID, productID, quantity).Return(nil)
 
 	err := useCase.AddToCart(ctx, userID, productID, quantity)
 
@@ -79,13 +79,13 @@ func TestCartUseCase_AddToCart_Error(t *testing.T) {
 	mockRepo := new(MockCartRepository)
 	useCase := NewCartUseCase(mockRepo)
 
-	ctx := context.Background()
+	ctx := callerContext()
 	userID := uuid.New()
 	productID := 100
 	quantity := 2
 
 	expectedErr := errors.New("repository error")
-	mockRepo.On("AddToCart", ctx, userID, productID, quantity).Return(expectedErr)
+	mockRepo.On("AddToCart", fromCaller, userID, productID, quantity).Return(expectedErr)
 
 	err := useCase.AddToCart(ctx, userID, productID, quantity)
 
@@ -98,7 +98,7 @@ func TestCartUseCase_GetCartItems_Success(t *testing.T) {
 	mockRepo := new(MockCartRepository)
 	useCase := NewCartUseCase(mockRepo)
 
-	ctx := context.Background()
+	ctx := callerContext()
 	userID := uuid.New()
 
 	expectedItems := []entity.CartItem{
@@ -106,7 +106,7 @@ func TestCartUseCase_GetCartItems_Success(t *testing.T) {
 		sampleEntityCartItem(userID, 101, 3),
 	}
 
-	mockRepo.On("GetCartItems", ctx, userID).Return(expectedItems, nil)
+	mockRepo.On("GetCartItems", fromCaller, userID).Return(expectedItems, nil)
 
 	items, err := useCase.GetCartItems(ctx, userID)
 
@@ -127,10 +127,10 @@ func TestCartUseCase_GetCartItems_Empty(t *testing.T) {
 	mockRepo := new(MockCartRepository)
 	useCase := NewCartUseCase(mockRepo)
 
-	ctx := context.Background()
+	ctx := callerContext()
 	userID := uuid.New()
 
-	mockRepo.On("GetCartItems", ctx, userID).Return([]entity.CartItem{}, nil)
+	mockRepo.On("GetCartItems", fromCaller, userID).Return([]entity.CartItem{}, nil)
 
 	items, err := useCase.GetCartItems(ctx, userID)
 
@@ -145,11 +145,11 @@ func TestCartUseCase_GetCartItems_Error(t *testing.T) {
 	mockRepo := new(MockCartRepository)
 	useCase := NewCartUseCase(mockRepo)
 
-	ctx := context.Background()
+	ctx := callerContext()
 	userID := uuid.New()
 
 	expectedErr := errors.New("repository error")
-	mockRepo.On("GetCartItems", ctx, userID).Return(nil, expectedErr)
+	mockRepo.On("GetCartItems", fromCaller, userID).Return(nil, expectedErr)
 
 	items, err := useCase.GetCartItems(ctx, userID)
 
@@ -164,11 +164,11 @@ func TestCartUseCase_RemoveFromCart_Success(t *testing.T) {
 	mockRepo := new(MockCartRepository)
 	useCase := NewCartUseCase(mockRepo)
 
-	ctx := context.Background()
+	ctx := callerContext()
 	userID := uuid.New()
 	productID := 100
 
-	mockRepo.On("RemoveFromCart", ctx, userID, productID).Return(nil)
+	mockRepo.On("RemoveFromCart", fromCaller, userID, productID).Return(nil)
 
 	err := useCase.RemoveFromCart(ctx, userID, productID)
 
@@ -180,12 +180,12 @@ func TestCartUseCase_RemoveFromCart_Error(t *testing.T) {
 	mockRepo := new(MockCartRepository)
 	useCase := NewCartUseCase(mockRepo)
 
-	ctx := context.Background()
+	ctx := callerContext()
 	userID := uuid.New()
 	productID := 999
 
 	expectedErr := errors.New("item not found")
-	mockRepo.On("RemoveFromCart", ctx, userID, productID).Return(expectedErr)
+	mockRepo.On("RemoveFromCart", fromCaller, userID, productID).Return(expectedErr)
 
 	err := useCase.RemoveFromCart(ctx, userID, productID)
 
@@ -199,10 +199,10 @@ func TestCartUseCase_ClearCart_Success(t *testing.T) {
 	mockRepo := new(MockCartRepository)
 	useCase := NewCartUseCase(mockRepo)
 
-	ctx := context.Background()
+	ctx := callerContext()
 	userID := uuid.New()
 
-	mockRepo.On("ClearCart", ctx, userID).Return(nil)
+	mockRepo.On("ClearCart", fromCaller, userID).Return(nil)
 
 	err := useCase.ClearCart(ctx, userID)
 
@@ -214,11 +214,11 @@ func TestCartUseCase_ClearCart_Error(t *testing.T) {
 	mockRepo := new(MockCartRepository)
 	useCase := NewCartUseCase(mockRepo)
 
-	ctx := context.Background()
+	ctx := callerContext()
 	userID := uuid.New()
 
 	expectedErr := errors.New("database error")
-	mockRepo.On("ClearCart", ctx, userID).Return(expectedErr)
+	mockRepo.On("ClearCart", fromCaller, userID).Return(expectedErr)
 
 	err := useCase.ClearCart(ctx, userID)
 
@@ -234,12 +234,12 @@ func TestCartUseCase_AddToCart_ZeroQuantity(t *testing.T) {
 	mockRepo := new(MockCartRepository)
 	useCase := NewCartUseCase(mockRepo)
 
-	ctx := context.Background()
+	ctx := callerContext()
 	userID := uuid.New()
 	productID := 100
 	quantity := 0
 
-	mockRepo.On("AddToCart", ctx, userID, productID, quantity).Return(nil)
+	mockRepo.On("AddToCart", fromCaller, userID, productID, quantity).Return(nil)
 
 	err := useCase.AddToCart(ctx, userID, productID, quantity)
 
@@ -251,12 +251,12 @@ func TestCartUseCase_AddToCart_NegativeQuantity(t *testing.T) {
 	mockRepo := new(MockCartRepository)
 	useCase := NewCartUseCase(mockRepo)
 
-	ctx := context.Background()
+	ctx := callerContext()
 	userID := uuid.New()
 	productID := 100
 	quantity := -1
 
-	mockRepo.On("AddToCart", ctx, userID, productID, quantity).Return(nil)
+	mockRepo.On("AddToCart", fromCaller, userID, productID, quantity).Return(nil)
 
 	err := useCase.AddToCart(ctx, userID, productID, quantity)
 
@@ -268,15 +268,15 @@ func TestCartUseCase_Operations_WithEmptyUUID(t *testing.T) {
 	mockRepo := new(MockCartRepository)
 	useCase := NewCartUseCase(mockRepo)
 
-	ctx := context.Background()
+	ctx := callerContext()
 	var emptyUUID uuid.UUID // Zero value UUID
 	productID := 100
 	quantity := 2
 
-	mockRepo.On("AddToCart", ctx, emptyUUID, productID, quantity).Return(nil)
-	mockRepo.On("GetCartItems", ctx, emptyUUID).Return([]entity.CartItem{}, nil)
-	mockRepo.On("RemoveFromCart", ctx, emptyUUID, productID).Return(nil)
-	mockRepo.On("ClearCart", ctx, emptyUUID).Return(nil)
+	mockRepo.On("AddToCart", fromCaller, emptyUUID, productID, quantity).Return(nil)
+	mockRepo.On("GetCartItems", fromCaller, emptyUUID).Return([]entity.CartItem{}, nil)
+	mockRepo.On("RemoveFromCart", fromCaller, emptyUUID, productID).Return(nil)
+	mockRepo.On("ClearCart", fromCaller, emptyUUID).Return(nil)
 
 	// Test all operations with empty UUID
 	err := useCase.AddToCart(ctx, emptyUUID, productID, quantity)
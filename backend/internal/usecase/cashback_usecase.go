@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"backend/internal/database"
+	"backend/internal/domain"
+	"backend/internal/entity"
+	"backend/internal/repository"
+	"context"
+)
+
+type CashbackUseCase interface {
+	OrderStatusHook
+	GetCashbackRates(ctx context.Context) ([]*entity.CategoryCashbackRate, error)
+	SetCashbackRate(ctx context.Context, categoryID int32, ratePercent float64) (*entity.CategoryCashbackRate, error)
+	DeleteCashbackRate(ctx context.Context, categoryID int32) error
+}
+
+type cashbackUseCase struct {
+	transactionRepo  repository.CoinTransactionRepository
+	orderRepo        repository.OrderRepository
+	cashbackRateRepo repository.CashbackRateRepository
+	txManager        database.TxManager
+	policy           entity.CashbackPolicy
+}
+
+func NewCashbackUseCase(transactionRepo repository.CoinTransactionRepository, orderRepo repository.OrderRepository, cashbackRateRepo repository.CashbackRateRepository, txManager database.TxManager, policy entity.CashbackPolicy) CashbackUseCase {
+	return &cashbackUseCase{
+		transactionRepo:  transactionRepo,
+		orderRepo:        orderRepo,
+		cashbackRateRepo: cashbackRateRepo,
+		txManager:        txManager,
+		policy:           policy,
+	}
+}
+
+// OnOrderStatusChanged grants cashback when an order completes and takes it
+// back when the order is refunded. The rates and subtotals are read in the
+// transaction that pays, so the amount matches what was saved with them.
+func (uc *cashbackUseCase) OnOrderStatusChanged(ctx context.Context, order *entity.Order, from string) (_ []*entity.CoinTransaction, err error) {
+	ctx, end := startSpan(ctx, "CashbackUseCase.OnOrderStatusChanged")
+	defer end(&err)
+
+	var entry *entity.CoinTransaction
+	switch order.Status {
+	case entity.OrderStatusCompleted:
+		err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+			var grantErr error
+			entry, grantErr = uc.grantCashback(ctx, order)
+			return grantErr
+		})
+	case entity.OrderStatusRefunded:
+		entry, err = uc.transactionRepo.ReverseCashback(ctx, order)
+	}
+	if err != nil || entry == nil {
+		return nil, err
+	}
+
+	return []*entity.CoinTransaction{entry}, nil
+}
+
+func (uc *cashbackUseCase) grantCashback(ctx context.Context, order *entity.Order) (*entity.CoinTransaction, error) {
+	if order.TotalCoinsUsed <= 0 {
+		return nil, nil
+	}
+
+	subtotals, err := uc.orderRepo.GetOrderCategorySubtotals(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := uc.cashbackRateRepo.GetCashbackRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rateByCategory := make(map[int32]float64, len(rates))
+	for _, rate := range rates {
+		rateByCategory[rate.CategoryID] = rate.RatePercent
+	}
+
+	amount := uc.policy.CalculateCashback(order.TotalCoinsUsed, subtotals, rateByCategory)
+	if amount <= 0 {
+		return nil, nil
+	}
+
+	return uc.transactionRepo.GrantCashback(ctx, order, amount)
+}
+
+func (uc *cashbackUseCase) GetCashbackRates(ctx context.Context) (_ []*entity.CategoryCashbackRate, err error) {
+	ctx, end := startSpan(ctx, "CashbackUseCase.GetCashbackRates")
+	defer end(&err)
+
+	return uc.cashbackRateRepo.GetCashbackRates(ctx)
+}
+
+func (uc *cashbackUseCase) SetCashbackRate(ctx context.Context, categoryID int32, ratePercent float64) (_ *entity.CategoryCashbackRate, err error) {
+	ctx, end := startSpan(ctx, "CashbackUseCase.SetCashbackRate")
+	defer end(&err)
+
+	if ratePercent < 0 || ratePercent > 100 {
+		return nil, domain.ErrCashbackRateOutOfRange
+	}
+
+	return uc.cashbackRateRepo.SetCashbackRate(ctx, categoryID, ratePercent)
+}
+
+func (uc *cashbackUseCase) DeleteCashbackRate(ctx context.Context, categoryID int32) (err error) {
+	ctx, end := startSpan(ctx, "CashbackUseCase.DeleteCashbackRate")
+	defer end(&err)
+
+	return uc.cashbackRateRepo.DeleteCashbackRate(ctx, categoryID)
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"backend/internal/entity"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCashbackRateRepository matches your repository interface
+type MockCashbackRateRepository struct {
+	mock.Mock
+}
+
+func (m *MockCashbackRateRepository) GetCashbackRates(ctx context.Context) ([]*entity.CategoryCashbackRate, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.CategoryCashbackRate), args.Error(1)
+}
+
+func (m *MockCashbackRateRepository) SetCashbackRate(ctx context.Context, categoryID int32, ratePercent float64) (*entity.CategoryCashbackRate, error) {
+	args := m.Called(ctx, categoryID, ratePercent)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CategoryCashbackRate), args.Error(1)
+}
+
+func (m *MockCashbackRateRepository) DeleteCashbackRate(ctx context.Context, categoryID int32) error {
+	args := m.Called(ctx, categoryID)
+	return args.Error(0)
+}
+
+func setupCashbackUseCase(policy entity.CashbackPolicy) (CashbackUseCase, *MockCoinTransactionRepository, *MockOrderRepository, *MockCashbackRateRepository) {
+	mockTransactionRepo := new(MockCoinTransactionRepository)
+	mockOrderRepo := new(MockOrderRepository)
+	mockRateRepo := new(MockCashbackRateRepository)
+	useCase := NewCashbackUseCase(mockTransactionRepo, mockOrderRepo, mockRateRepo, &fakeTxManager{}, policy)
+	return useCase, mockTransactionRepo, mockOrderRepo, mockRateRepo
+}
+
+func TestCashbackOnCompleted_CategoryRates(t *testing.T) {
+	uc, mockTransactionRepo, mockOrderRepo, mockRateRepo := setupCashbackUseCase(entity.CashbackPolicy{DefaultRatePercent: 1})
+	ctx := callerContext()
+
+	order := &entity.Order{ID: 1, UserID: uuid.New(), TotalCoinsUsed: 1000, Status: entity.OrderStatusCompleted}
+
+	// 600 coins at 10% and 400 coins at the 1% default
+	mockOrderRepo.On("GetOrderCategorySubtotals", fromCaller, int32(1)).Return([]entity.OrderCategorySubtotal{
+		{CategoryID: 1, Subtotal: 60},
+		{CategoryID: 2, Subtotal: 40},
+	}, nil)
+	mockRateRepo.On("GetCashbackRates", fromCaller).Return([]*entity.CategoryCashbackRate{
+		{CategoryID: 1, RatePercent: 10},
+	}, nil)
+	mockTransactionRepo.On("GrantCashback", fromCaller, order, 64).Return(&entity.CoinTransaction{ID: 5, Amount: 64}, nil)
+
+	entries, err := uc.OnOrderStatusChanged(ctx, order, entity.OrderStatusPending)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.CoinTransaction{{ID: 5, Amount: 64}}, entries)
+
+	mockTransactionRepo.AssertExpectations(t)
+	mockOrderRepo.AssertExpectations(t)
+	mockRateRepo.AssertExpectations(t)
+}
+
+func TestCashbackOnCompleted_GrantFailureRollsBack(t *testing.T) {
+	mockTransactionRepo := new(MockCoinTransactionRepository)
+	mockOrderRepo := new(MockOrderRepository)
+	mockRateRepo := new(MockCashbackRateRepository)
+	txManager := &fakeTxManager{}
+	uc := NewCashbackUseCase(mockTransactionRepo, mockOrderRepo, mockRateRepo, txManager, entity.CashbackPolicy{DefaultRatePercent: 5})
+	ctx := callerContext()
+
+	order := &entity.Order{ID: 1, UserID: uuid.New(), TotalCoinsUsed: 1000, Status: entity.OrderStatusCompleted}
+	grantErr := errors.New("database error")
+
+	mockOrderRepo.On("GetOrderCategorySubtotals", fromCaller, int32(1)).Return([]entity.OrderCategorySubtotal{{CategoryID: 1, Subtotal: 100}}, nil)
+	mockRateRepo.On("GetCashbackRates", fromCaller).Return([]*entity.CategoryCashbackRate{}, nil)
+	mockTransactionRepo.On("GrantCashback", fromCaller, order, 50).Return(nil, grantErr)
+
+	_, err := uc.OnOrderStatusChanged(ctx, order, entity.OrderStatusPending)
+
+	assert.ErrorIs(t, err, grantErr)
+	assert.Equal(t, 0, txManager.commits)
+	assert.Equal(t, 1, txManager.rollbacks)
+}
+
+func TestCashbackOnCompleted_NoCoinsUsed(t *testing.T) {
+	uc, mockTransactionRepo, mockOrderRepo, _ := setupCashbackUseCase(entity.CashbackPolicy{DefaultRatePercent: 5})
+	ctx := callerContext()
+
+	order := &entity.Order{ID: 1, TotalCoinsUsed: 0, Status: entity.OrderStatusCompleted}
+
+	_, err := uc.OnOrderStatusChanged(ctx, order, entity.OrderStatusPending)
+
+	assert.NoError(t, err)
+
+	mockOrderRepo.AssertNotCalled(t, "GetOrderCategorySubtotals", mock.Anything, mock.Anything)
+	mockTransactionRepo.AssertNotCalled(t, "GrantCashback", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCashbackOnCompleted_RoundsDownToZero(t *testing.T) {
+	uc, mockTransactionRepo, mockOrderRepo, mockRateRepo := setupCashbackUseCase(entity.CashbackPolicy{DefaultRatePercent: 1})
+	ctx := callerContext()
+
+	order := &entity.Order{ID: 1, TotalCoinsUsed: 99, Status: entity.OrderStatusCompleted}
+
+	mockOrderRepo.On("GetOrderCategorySubtotals", fromCaller, int32(1)).Return([]entity.OrderCategorySubtotal{}, nil)
+	mockRateRepo.On("GetCashbackRates", fromCaller).Return([]*entity.CategoryCashbackRate{}, nil)
+
+	entries, err := uc.OnOrderStatusChanged(ctx, order, entity.OrderStatusPending)
+
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	mockTransactionRepo.AssertNotCalled(t, "GrantCashback", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCashbackOnRefunded_Reverses(t *testing.T) {
+	uc, mockTransactionRepo, _, _ := setupCashbackUseCase(entity.CashbackPolicy{DefaultRatePercent: 1})
+	ctx := callerContext()
+
+	order := &entity.Order{ID: 1, TotalCoinsUsed: 1000, Status: entity.OrderStatusRefunded}
+
+	mockTransactionRepo.On("ReverseCashback", fromCaller, order).Return(&entity.CoinTransaction{ID: 6, Amount: -10}, nil)
+
+	entries, err := uc.OnOrderStatusChanged(ctx, order, entity.OrderStatusCompleted)
+
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	mockTransactionRepo.AssertExpectations(t)
+}
+
+func TestCashbackOnCancelled_Ignored(t *testing.T) {
+	uc, mockTransactionRepo, mockOrderRepo, _ := setupCashbackUseCase(entity.CashbackPolicy{DefaultRatePercent: 1})
+	ctx := callerContext()
+
+	order := &entity.Order{ID: 1, TotalCoinsUsed: 1000, Status: entity.OrderStatusCancelled}
+
+	_, err := uc.OnOrderStatusChanged(ctx, order, entity.OrderStatusPending)
+
+	assert.NoError(t, err)
+
+	mockOrderRepo.AssertNotCalled(t, "GetOrderCategorySubtotals", mock.Anything, mock.Anything)
+	mockTransactionRepo.AssertNotCalled(t, "ReverseCashback", mock.Anything, mock.Anything)
+}
+
+func TestSetCashbackRate_OutOfRange(t *testing.T) {
+	uc, _, _, mockRateRepo := setupCashbackUseCase(entity.CashbackPolicy{})
+	ctx := callerContext()
+
+	rate, err := uc.SetCashbackRate(ctx, 1, 150)
+
+	assert.Error(t, err)
+	assert.Nil(t, rate)
+	assert.Equal(t, "cashback rate must be between 0 and 100", err.Error())
+
+	mockRateRepo.AssertNotCalled(t, "SetCashbackRate", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetCashbackRate_RepositoryError(t *testing.T) {
+	uc, _, _, mockRateRepo := setupCashbackUseCase(entity.CashbackPolicy{})
+	ctx := callerContext()
+
+	mockRateRepo.On("SetCashbackRate", fromCaller, int32(1), 5.0).Return(nil, errors.New("database error"))
+
+	rate, err := uc.SetCashbackRate(ctx, 1, 5)
+
+	assert.Error(t, err)
+	assert.Nil(t, rate)
+
+	mockRateRepo.AssertExpectations(t)
+}
@@ -15,10 +15,16 @@ func NewCategoryUseCase(r repository.CategoryRepository) *CategoryUseCase {
 	return &CategoryUseCase{repo: r}
 }
 
-func (u *CategoryUseCase) GetAllCategories(ctx context.Context) ([]entity.Category, error) {
+func (u *CategoryUseCase) GetAllCategories(ctx context.Context) (_ []entity.Category, err error) {
+	ctx, end := startSpan(ctx, "CategoryUseCase.GetAllCategories")
+	defer end(&err)
+
 	return u.repo.GetAllCategories(ctx)
 }
 
-func (u *CategoryUseCase) GetCategoryByID(ctx context.Context, id int) (*entity.Category, error) {
+func (u *CategoryUseCase) GetCategoryByID(ctx context.Context, id int) (_ *entity.Category, err error) {
+	ctx, end := startSpan(ctx, "CategoryUseCase.GetCategoryByID")
+	defer end(&err)
+
 	return u.repo.GetCategoryByID(ctx, id)
 }
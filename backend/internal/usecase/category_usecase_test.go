@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"backend/internal/domain"
 	"context"
 	"errors"
 	"testing"
@@ -51,9 +52,9 @@ func TestCategoryUseCase_GetAllCategories_Success(t *testing.T) {
 		sampleCategory(3, "Clothing"),
 	}
 
-	mockRepo.On("GetAllCategories", mock.Anything).Return(expectedCategories, nil)
+	mockRepo.On("GetAllCategories", fromCaller).Return(expectedCategories, nil)
 
-	categories, err := uc.GetAllCategories(context.Background())
+	categories, err := uc.GetAllCategories(callerContext())
 
 	assert.NoError(t, err)
 	assert.Len(t, categories, 3)
@@ -70,9 +71,9 @@ func TestCategoryUseCase_GetAllCategories_Empty(t *testing.T) {
 
 	emptyCategories := []entity.Category{}
 
-	mockRepo.On("GetAllCategories", mock.Anything).Return(emptyCategories, nil)
+	mockRepo.On("GetAllCategories", fromCaller).Return(emptyCategories, nil)
 
-	categories, err := uc.GetAllCategories(context.Background())
+	categories, err := uc.GetAllCategories(callerContext())
 
 	assert.NoError(t, err)
 	assert.Len(t, categories, 0)
@@ -84,9 +85,9 @@ func TestCategoryUseCase_GetAllCategories_Error(t *testing.T) {
 	mockRepo := new(MockCategoryRepository)
 	uc := NewCategoryUseCase(mockRepo)
 
-	mockRepo.On("GetAllCategories", mock.Anything).Return([]entity.Category(nil), errors.New("database connection failed"))
+	mockRepo.On("GetAllCategories", fromCaller).Return([]entity.Category(nil), errors.New("database connection failed"))
 
-	categories, err := uc.GetAllCategories(context.Background())
+	categories, err := uc.GetAllCategories(callerContext())
 
 	assert.Error(t, err)
 	assert.Nil(t, categories)
@@ -101,9 +102,9 @@ func TestCategoryUseCase_GetCategoryByID_Found(t *testing.T) {
 
 	expectedCategory := sampleCategory(1, "Electronics")
 
-	mockRepo.On("GetCategoryByID", mock.Anything, 1).Return(&expectedCategory, nil)
+	mockRepo.On("GetCategoryByID", fromCaller, 1).Return(&expectedCategory, nil)
 
-	category, err := uc.GetCategoryByID(context.Background(), 1)
+	category, err := uc.GetCategoryByID(callerContext(), 1)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, category)
@@ -117,9 +118,9 @@ func TestCategoryUseCase_GetCategoryByID_NotFound(t *testing.T) {
 	mockRepo := new(MockCategoryRepository)
 	uc := NewCategoryUseCase(mockRepo)
 
-	mockRepo.On("GetCategoryByID", mock.Anything, 999).Return(nil, errors.New("category not found"))
+	mockRepo.On("GetCategoryByID", fromCaller, 999).Return(nil, domain.ErrCategoryNotFound)
 
-	category, err := uc.GetCategoryByID(context.Background(), 999)
+	category, err := uc.GetCategoryByID(callerContext(), 999)
 
 	assert.Error(t, err)
 	assert.Nil(t, category)
@@ -132,9 +133,9 @@ func TestCategoryUseCase_GetCategoryByID_InvalidID(t *testing.T) {
 	mockRepo := new(MockCategoryRepository)
 	uc := NewCategoryUseCase(mockRepo)
 
-	mockRepo.On("GetCategoryByID", mock.Anything, 0).Return(nil, errors.New("invalid category ID"))
+	mockRepo.On("GetCategoryByID", fromCaller, 0).Return(nil, errors.New("invalid category ID"))
 
-	category, err := uc.GetCategoryByID(context.Background(), 0)
+	category, err := uc.GetCategoryByID(callerContext(), 0)
 
 	assert.Error(t, err)
 	assert.Nil(t, category)
@@ -147,9 +148,9 @@ func TestCategoryUseCase_GetCategoryByID_DatabaseError(t *testing.T) {
 	mockRepo := new(MockCategoryRepository)
 	uc := NewCategoryUseCase(mockRepo)
 
-	mockRepo.On("GetCategoryByID", mock.Anything, 1).Return(nil, errors.New("database timeout"))
+	mockRepo.On("GetCategoryByID", fromCaller, 1).Return(nil, errors.New("database timeout"))
 
-	category, err := uc.GetCategoryByID(context.Background(), 1)
+	category, err := uc.GetCategoryByID(callerContext(), 1)
 
 	assert.Error(t, err)
 	assert.Nil(t, category)
@@ -169,11 +170,11 @@ func BenchmarkCategoryUseCase_GetAllCategories(b *testing.B) {
 		sampleCategory(2, "Books"),
 	}
 
-	mockRepo.On("GetAllCategories", mock.Anything).Return(categories, nil)
+	mockRepo.On("GetAllCategories", fromCaller).Return(categories, nil)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = uc.GetAllCategories(context.Background())
+		_, _ = uc.GetAllCategories(callerContext())
 	}
 }
 
@@ -182,10 +183,10 @@ func BenchmarkCategoryUseCase_GetCategoryByID(b *testing.B) {
 	uc := NewCategoryUseCase(mockRepo)
 
 	category := sampleCategory(1, "Electronics")
-	mockRepo.On("GetCategoryByID", mock.Anything, 1).Return(&category, nil)
+	mockRepo.On("GetCategoryByID", fromCaller, 1).Return(&category, nil)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = uc.GetCategoryByID(context.Background(), 1)
+		_, _ = uc.GetCategoryByID(callerContext(), 1)
 	}
 }
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"backend/internal/domain"
+	"backend/internal/entity"
+	"backend/internal/repository"
+	"context"
+)
+
+type CoinPackUseCase interface {
+	GetActiveCoinPacks(ctx context.Context) ([]*entity.CoinPack, error)
+	GetAllCoinPacks(ctx context.Context) ([]*entity.CoinPack, error)
+	CreateCoinPack(ctx context.Context, req entity.CreateCoinPackRequest) (*entity.CoinPack, error)
+	UpdateCoinPack(ctx context.Context, id int32, req entity.UpdateCoinPackRequest) (*entity.CoinPack, error)
+}
+
+type coinPackUseCase struct {
+	coinPackRepo repository.CoinPackRepository
+}
+
+func NewCoinPackUseCase(coinPackRepo repository.CoinPackRepository) CoinPackUseCase {
+	return &coinPackUseCase{
+		coinPackRepo: coinPackRepo,
+	}
+}
+
+func (uc *coinPackUseCase) GetActiveCoinPacks(ctx context.Context) (_ []*entity.CoinPack, err error) {
+	ctx, end := startSpan(ctx, "CoinPackUseCase.GetActiveCoinPacks")
+	defer end(&err)
+
+	return uc.coinPackRepo.GetActiveCoinPacks(ctx)
+}
+
+func (uc *coinPackUseCase) GetAllCoinPacks(ctx context.Context) (_ []*entity.CoinPack, err error) {
+	ctx, end := startSpan(ctx, "CoinPackUseCase.GetAllCoinPacks")
+	defer end(&err)
+
+	return uc.coinPackRepo.GetAllCoinPacks(ctx)
+}
+
+func (uc *coinPackUseCase) CreateCoinPack(ctx context.Context, req entity.CreateCoinPackRequest) (_ *entity.CoinPack, err error) {
+	ctx, end := startSpan(ctx, "CoinPackUseCase.CreateCoinPack")
+	defer end(&err)
+
+	if err := validateCoinPack(req.Name, req.BaseCoins, req.BonusCoins, req.Price); err != nil {
+		return nil, err
+	}
+
+	return uc.coinPackRepo.CreateCoinPack(ctx, req)
+}
+
+func (uc *coinPackUseCase) UpdateCoinPack(ctx context.Context, id int32, req entity.UpdateCoinPackRequest) (_ *entity.CoinPack, err error) {
+	ctx, end := startSpan(ctx, "CoinPackUseCase.UpdateCoinPack")
+	defer end(&err)
+
+	if id <= 0 {
+		return nil, domain.ErrInvalidCoinPackID
+	}
+
+	if err := validateCoinPack(req.Name, req.BaseCoins, req.BonusCoins, req.Price); err != nil {
+		return nil, err
+	}
+
+	return uc.coinPackRepo.UpdateCoinPack(ctx, id, req)
+}
+
+func validateCoinPack(name string, baseCoins, bonusCoins int, price float64) error {
+	if name == "" {
+		return domain.ErrNameRequired
+	}
+	if baseCoins <= 0 {
+		return domain.ErrBaseCoinsNotPositive
+	}
+	if bonusCoins < 0 {
+		return domain.ErrBonusCoinsNegative
+	}
+	if price < 0 {
+		return domain.ErrCoinPackPriceNegative
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"backend/internal/domain"
+	"backend/internal/entity"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCoinPackRepository matches your repository interface
+type MockCoinPackRepository struct {
+	mock.Mock
+}
+
+func (m *MockCoinPackRepository) GetActiveCoinPacks(ctx context.Context) ([]*entity.CoinPack, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.CoinPack), args.Error(1)
+}
+
+func (m *MockCoinPackRepository) GetAllCoinPacks(ctx context.Context) ([]*entity.CoinPack, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.CoinPack), args.Error(1)
+}
+
+func (m *MockCoinPackRepository) GetCoinPackByID(ctx context.Context, id int32) (*entity.CoinPack, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CoinPack), args.Error(1)
+}
+
+func (m *MockCoinPackRepository) CreateCoinPack(ctx context.Context, req entity.CreateCoinPackRequest) (*entity.CoinPack, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CoinPack), args.Error(1)
+}
+
+func (m *MockCoinPackRepository) UpdateCoinPack(ctx context.Context, id int32, req entity.UpdateCoinPackRequest) (*entity.CoinPack, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CoinPack), args.Error(1)
+}
+
+// Helper functions
+func createCoinPack(id int32, name string, baseCoins, bonusCoins int) *entity.CoinPack {
+	return &entity.CoinPack{
+		ID:         id,
+		Name:       name,
+		BaseCoins:  baseCoins,
+		BonusCoins: bonusCoins,
+		Price:      9.99,
+		IsActive:   true,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+}
+
+func setupCoinPackUseCase() (CoinPackUseCase, *MockCoinPackRepository) {
+	mockRepo := new(MockCoinPackRepository)
+	useCase := NewCoinPackUseCase(mockRepo)
+	return useCase, mockRepo
+}
+
+func TestGetActiveCoinPacks_Success(t *testing.T) {
+	uc, mockRepo := setupCoinPackUseCase()
+	ctx := callerContext()
+
+	expectedPacks := []*entity.CoinPack{
+		createCoinPack(1, "1000 Coins", 1000, 0),
+		createCoinPack(2, "5000 + 500 Bonus", 5000, 500),
+	}
+
+	mockRepo.On("GetActiveCoinPacks", fromCaller).Return(expectedPacks, nil)
+
+	packs, err := uc.GetActiveCoinPacks(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, packs, 2)
+	assert.Equal(t, 5500, packs[1].TotalCoins())
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetActiveCoinPacks_RepositoryError(t *testing.T) {
+	uc, mockRepo := setupCoinPackUseCase()
+	ctx := callerContext()
+
+	mockRepo.On("GetActiveCoinPacks", fromCaller).Return(nil, errors.New("database error"))
+
+	packs, err := uc.GetActiveCoinPacks(ctx)
+
+	assert.Error(t, err)
+	assert.Nil(t, packs)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateCoinPack_Success(t *testing.T) {
+	uc, mockRepo := setupCoinPackUseCase()
+	ctx := callerContext()
+
+	req := entity.CreateCoinPackRequest{
+		Name:       "5000 + 500 Bonus",
+		BaseCoins:  5000,
+		BonusCoins: 500,
+		Price:      49.99,
+		IsActive:   true,
+	}
+	expectedPack := createCoinPack(2, req.Name, req.BaseCoins, req.BonusCoins)
+
+	mockRepo.On("CreateCoinPack", fromCaller, req).Return(expectedPack, nil)
+
+	pack, err := uc.CreateCoinPack(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), pack.ID)
+	assert.Equal(t, 500, pack.BonusCoins)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateCoinPack_InvalidInput(t *testing.T) {
+	uc, _ := setupCoinPackUseCase()
+	ctx := callerContext()
+
+	tests := []struct {
+		name     string
+		req      entity.CreateCoinPackRequest
+		expected string
+	}{
+		{"empty name", entity.CreateCoinPackRequest{BaseCoins: 100}, "name is required"},
+		{"zero base coins", entity.CreateCoinPackRequest{Name: "Pack"}, "base coins must be positive"},
+		{"negative bonus", entity.CreateCoinPackRequest{Name: "Pack", BaseCoins: 100, BonusCoins: -1}, "bonus coins cannot be negative"},
+		{"negative price", entity.CreateCoinPackRequest{Name: "Pack", BaseCoins: 100, Price: -1}, "price cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pack, err := uc.CreateCoinPack(ctx, tt.req)
+
+			assert.Error(t, err)
+			assert.Nil(t, pack)
+			assert.Equal(t, tt.expected, err.Error())
+		})
+	}
+}
+
+func TestUpdateCoinPack_InvalidID(t *testing.T) {
+	uc, _ := setupCoinPackUseCase()
+	ctx := callerContext()
+
+	pack, err := uc.UpdateCoinPack(ctx, 0, entity.UpdateCoinPackRequest{Name: "Pack", BaseCoins: 100})
+
+	assert.Error(t, err)
+	assert.Nil(t, pack)
+	assert.Equal(t, "invalid coin pack ID", err.Error())
+}
+
+func TestUpdateCoinPack_NotFound(t *testing.T) {
+	uc, mockRepo := setupCoinPackUseCase()
+	ctx := callerContext()
+
+	req := entity.UpdateCoinPackRequest{Name: "Pack", BaseCoins: 100}
+	mockRepo.On("UpdateCoinPack", fromCaller, int32(99), req).Return(nil, domain.ErrCoinPackNotFound)
+
+	pack, err := uc.UpdateCoinPack(ctx, 99, req)
+
+	assert.Error(t, err)
+	assert.Nil(t, pack)
+	assert.Equal(t, "coin pack not found", err.Error())
+
+	mockRepo.AssertExpectations(t)
+}
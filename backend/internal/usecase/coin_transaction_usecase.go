@@ -1,56 +1,406 @@
 package usecase
 
 import (
+	"backend/internal/database"
+	"backend/internal/domain"
 	"backend/internal/entity"
+	"backend/internal/metrics"
 	"backend/internal/repository"
 	"context"
-	"errors"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type CoinTransactionUseCase interface {
+	OrderStatusHook
 	ChargeUserCoins(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32) (*entity.User, *entity.CoinTransaction, error)
 	SpendUserCoins(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32) (*entity.User, *entity.CoinTransaction, error)
-	GetUserTransactions(ctx context.Context, userID uuid.UUID, page, limit int32) ([]*entity.CoinTransaction, error)
+	GetUserTransactions(ctx context.Context, userID uuid.UUID, filter entity.CoinTransactionFilter) (*entity.CoinTransactionHistory, error)
 	GetTransactionByID(ctx context.Context, id int32) (*entity.CoinTransaction, error)
+	PurchaseCoinPack(ctx context.Context, userID uuid.UUID, packID int32) (*entity.User, []*entity.CoinTransaction, error)
+	GetCoinBalance(ctx context.Context, userID uuid.UUID) (*entity.CoinBalance, error)
+	GetCoinBalanceAt(ctx context.Context, userID uuid.UUID, at time.Time) (*entity.CoinBalanceAt, error)
+	GetCoinBalanceSeries(ctx context.Context, userID uuid.UUID, from, to time.Time, interval string, location *time.Location) (*entity.CoinBalanceSeries, error)
+	ExportCoinStatement(ctx context.Context, userID uuid.UUID, from, to time.Time, w entity.CoinStatementWriter) error
+	ExpireCoins(ctx context.Context) (int, error)
+	HoldUserCoins(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32) (*entity.CoinHold, error)
+	GetUserHolds(ctx context.Context, userID uuid.UUID, page, limit int32) ([]*entity.CoinHold, error)
+	CaptureHold(ctx context.Context, userID uuid.UUID, holdID int32) (*entity.CoinHold, *entity.CoinTransaction, error)
+	ReleaseHold(ctx context.Context, userID uuid.UUID, holdID int32) (*entity.CoinHold, error)
+	ExpireHolds(ctx context.Context) (int, error)
 }
 
+// coinExpiryBatchSize is how many lots ExpireCoins, or holds ExpireHolds,
+// asks the repository to sweep at once
+const coinExpiryBatchSize = 100
+
+// maxBalanceSeriesPoints caps how many buckets one balance series may have
+const maxBalanceSeriesPoints = 1000
+
+// dailySpendWindow is how far back the daily spend limit looks
+const dailySpendWindow = 24 * time.Hour
+
 type coinTransactionUseCase struct {
 	transactionRepo repository.CoinTransactionRepository
+	coinPackRepo    repository.CoinPackRepository
+	coinHoldRepo    repository.CoinHoldRepository
+	spendLimitRepo  repository.SpendLimitRepository
+	txManager       database.TxManager
+	spendPolicy     entity.SpendLimitPolicy
+	holdTTL         time.Duration
 }
 
-func NewCoinTransactionUseCase(transactionRepo repository.CoinTransactionRepository) CoinTransactionUseCase {
+func NewCoinTransactionUseCase(transactionRepo repository.CoinTransactionRepository, coinPackRepo repository.CoinPackRepository, coinHoldRepo repository.CoinHoldRepository, spendLimitRepo repository.SpendLimitRepository, txManager database.TxManager, spendPolicy entity.SpendLimitPolicy, holdTTL time.Duration) CoinTransactionUseCase {
 	return &coinTransactionUseCase{
 		transactionRepo: transactionRepo,
+		coinPackRepo:    coinPackRepo,
+		coinHoldRepo:    coinHoldRepo,
+		spendLimitRepo:  spendLimitRepo,
+		txManager:       txManager,
+		spendPolicy:     spendPolicy,
+		holdTTL:         holdTTL,
 	}
 }
 
-func (uc *coinTransactionUseCase) ChargeUserCoins(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32) (*entity.User, *entity.CoinTransaction, error) {
+func (uc *coinTransactionUseCase) ChargeUserCoins(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32) (_ *entity.User, _ *entity.CoinTransaction, err error) {
+	ctx, end := startSpan(ctx, "CoinTransactionUseCase.ChargeUserCoins")
+	defer end(&err)
+
 	if amount <= 0 {
-		return nil, nil, errors.New("amount must be positive")
+		return nil, nil, domain.ErrAmountNotPositive
 	}
 
 	if description == "" {
-		return nil, nil, errors.New("description is required")
+		return nil, nil, domain.ErrDescriptionRequired
+	}
+
+	user, tx, err := uc.transactionRepo.ChargeUserCoins(ctx, userID, amount, description, orderID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metrics.ObserveCoinTransactions(tx)
+	return user, tx, nil
+}
+
+func (uc *coinTransactionUseCase) SpendUserCoins(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32) (_ *entity.User, _ *entity.CoinTransaction, err error) {
+	ctx, end := startSpan(ctx, "CoinTransactionUseCase.SpendUserCoins")
+	defer end(&err)
+
+	if amount <= 0 {
+		return nil, nil, domain.ErrAmountNotPositive
+	}
+
+	if description == "" {
+		return nil, nil, domain.ErrDescriptionRequired
+	}
+
+	var user *entity.User
+	var tx *entity.CoinTransaction
+	err = uc.withinSpendLimits(ctx, userID, amount, func(ctx context.Context) error {
+		var err error
+		user, tx, err = uc.transactionRepo.SpendUserCoins(ctx, userID, amount, description, orderID)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metrics.ObserveCoinTransactions(tx)
+	return user, tx, nil
+}
+
+// withinSpendLimits runs spend in a transaction once the spend has passed
+// the user's limits. The user is locked first, so concurrent spends are
+// checked one after another against the activity each left behind. A spend
+// that trips the velocity rule is rejected, but the block it sets is kept.
+func (uc *coinTransactionUseCase) withinSpendLimits(ctx context.Context, userID uuid.UUID, amount int, spend func(ctx context.Context) error) error {
+	blocked := false
+	err := uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.spendLimitRepo.LockUser(ctx, userID); err != nil {
+			return err
+		}
+
+		var err error
+		blocked, err = uc.checkSpendLimits(ctx, userID, amount)
+		if err != nil || blocked {
+			return err
+		}
+
+		return spend(ctx)
+	})
+	if err != nil {
+		return err
+	}
+
+	if blocked {
+		return domain.ErrSpendingBlocked
+	}
+	return nil
+}
+
+// checkSpendLimits rejects a spend that breaks the user's limits. Tripping the
+// velocity rule instead blocks further spending for a while, records a
+// security event and returns true, so the caller can keep the block while
+// rejecting the spend.
+func (uc *coinTransactionUseCase) checkSpendLimits(ctx context.Context, userID uuid.UUID, amount int) (bool, error) {
+	override, err := uc.spendLimitRepo.GetUserSpendLimit(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	limits := uc.spendPolicy.For(override)
+	now := time.Now()
+
+	if limits.SpendingBlockedUntil != nil && limits.SpendingBlockedUntil.After(now) {
+		return false, domain.ErrSpendingBlocked
+	}
+
+	if limits.PerTransactionLimit > 0 && amount > limits.PerTransactionLimit {
+		return false, domain.ErrPerTransactionLimitExceeded
+	}
+
+	checkVelocity := uc.spendPolicy.VelocityMaxSpends > 0 && uc.spendPolicy.VelocityWindow > 0
+	if limits.DailyLimit <= 0 && !checkVelocity {
+		return false, nil
+	}
+
+	activity, err := uc.spendLimitRepo.GetSpendActivity(ctx, userID, now.Add(-dailySpendWindow), now.Add(-uc.spendPolicy.VelocityWindow))
+	if err != nil {
+		return false, err
+	}
+
+	if checkVelocity && activity.SpendCount >= uc.spendPolicy.VelocityMaxSpends {
+		details := fmt.Sprintf("%d spends within %s", activity.SpendCount+1, uc.spendPolicy.VelocityWindow)
+		if err := uc.spendLimitRepo.BlockSpending(ctx, userID, now.Add(uc.spendPolicy.BlockDuration), entity.SecurityEventSpendVelocity, details); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if limits.DailyLimit > 0 && activity.Spent+amount > limits.DailyLimit {
+		return false, domain.ErrDailySpendLimitExceeded
+	}
+
+	return false, nil
+}
+
+func (uc *coinTransactionUseCase) GetUserTransactions(ctx context.Context, userID uuid.UUID, filter entity.CoinTransactionFilter) (_ *entity.CoinTransactionHistory, err error) {
+	ctx, end := startSpan(ctx, "CoinTransactionUseCase.GetUserTransactions")
+	defer end(&err)
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.Limit < 1 || filter.Limit > 100 {
+		filter.Limit = 20
+	}
+
+	for _, transactionType := range filter.TransactionTypes {
+		if !slices.Contains(entity.TransactionTypes, transactionType) {
+			return nil, domain.ErrInvalidTxType
+		}
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, domain.ErrInvalidDateRange
+	}
+
+	if filter.AmountSign != "" && filter.AmountSign != entity.AmountSignPositive && filter.AmountSign != entity.AmountSignNegative {
+		return nil, domain.ErrInvalidAmountSign
+	}
+
+	transactions, summary, err := uc.transactionRepo.GetFilteredTransactions(ctx, userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.CoinTransactionHistory{
+		Transactions: transactions,
+		Summary:      *summary,
+		Page:         filter.Page,
+		Limit:        filter.Limit,
+	}, nil
+}
+
+func (uc *coinTransactionUseCase) GetTransactionByID(ctx context.Context, id int32) (_ *entity.CoinTransaction, err error) {
+	ctx, end := startSpan(ctx, "CoinTransactionUseCase.GetTransactionByID")
+	defer end(&err)
+
+	if id <= 0 {
+		return nil, domain.ErrInvalidTransactionID
+	}
+
+	return uc.transactionRepo.GetTransactionByID(ctx, id)
+}
+
+func (uc *coinTransactionUseCase) PurchaseCoinPack(ctx context.Context, userID uuid.UUID, packID int32) (_ *entity.User, _ []*entity.CoinTransaction, err error) {
+	ctx, end := startSpan(ctx, "CoinTransactionUseCase.PurchaseCoinPack")
+	defer end(&err)
+
+	if packID <= 0 {
+		return nil, nil, domain.ErrInvalidCoinPackID
 	}
 
-	return uc.transactionRepo.ChargeUserCoins(ctx, userID, amount, description, orderID)
+	pack, err := uc.coinPackRepo.GetCoinPackByID(ctx, packID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !pack.IsActive {
+		return nil, nil, domain.ErrCoinPackNotAvailable
+	}
+
+	user, txs, err := uc.transactionRepo.ChargeCoinPack(ctx, userID, pack)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metrics.ObserveCoinTransactions(txs...)
+	return user, txs, nil
+}
+
+func (uc *coinTransactionUseCase) GetCoinBalance(ctx context.Context, userID uuid.UUID) (_ *entity.CoinBalance, err error) {
+	ctx, end := startSpan(ctx, "CoinTransactionUseCase.GetCoinBalance")
+	defer end(&err)
+
+	return uc.transactionRepo.GetCoinBalance(ctx, userID)
+}
+
+func (uc *coinTransactionUseCase) GetCoinBalanceAt(ctx context.Context, userID uuid.UUID, at time.Time) (_ *entity.CoinBalanceAt, err error) {
+	ctx, end := startSpan(ctx, "CoinTransactionUseCase.GetCoinBalanceAt")
+	defer end(&err)
+
+	balance, err := uc.transactionRepo.GetCoinBalanceAt(ctx, userID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.CoinBalanceAt{At: at, Balance: balance}, nil
+}
+
+// GetCoinBalanceSeries returns the user's closing balance for every interval
+// from the one containing from up to to, with intervals cut in location.
+// Intervals without activity carry the previous balance forward.
+func (uc *coinTransactionUseCase) GetCoinBalanceSeries(ctx context.Context, userID uuid.UUID, from, to time.Time, interval string, location *time.Location) (_ *entity.CoinBalanceSeries, err error) {
+	ctx, end := startSpan(ctx, "CoinTransactionUseCase.GetCoinBalanceSeries")
+	defer end(&err)
+
+	if !entity.IsValidBalanceInterval(interval) {
+		return nil, domain.ErrInvalidInterval
+	}
+
+	if !from.Before(to) {
+		return nil, domain.ErrInvalidDateRange
+	}
+
+	from, to = from.In(location), to.In(location)
+	start := entity.TruncateToInterval(from, interval)
+
+	var buckets []time.Time
+	for b := start; b.Before(to); b = entity.AddInterval(b, interval, 1) {
+		if len(buckets) == maxBalanceSeriesPoints {
+			return nil, domain.ErrTooManySeriesPoints
+		}
+		buckets = append(buckets, b)
+	}
+
+	openingBalance, changes, err := uc.transactionRepo.GetCoinBalanceChanges(ctx, userID, start, to, interval, location.String())
+	if err != nil {
+		return nil, err
+	}
+
+	series := &entity.CoinBalanceSeries{
+		Interval:       interval,
+		From:           start,
+		To:             to,
+		OpeningBalance: openingBalance,
+		Points:         make([]entity.CoinBalanceSeriesPoint, len(buckets)),
+	}
+
+	balance := openingBalance
+	next := 0
+	for i, bucket := range buckets {
+		point := entity.CoinBalanceSeriesPoint{Start: bucket}
+		if next < len(changes) && changes[next].BucketStart.Equal(bucket) {
+			point.NetChange = changes[next].NetChange
+			balance = openingBalance + changes[next].RunningChange
+			next++
+		}
+		point.Balance = balance
+		series.Points[i] = point
+	}
+
+	return series, nil
 }
 
-func (uc *coinTransactionUseCase) SpendUserCoins(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32) (*entity.User, *entity.CoinTransaction, error) {
+// ExportCoinStatement writes the user's statement for [from, to) to w
+func (uc *coinTransactionUseCase) ExportCoinStatement(ctx context.Context, userID uuid.UUID, from, to time.Time, w entity.CoinStatementWriter) (err error) {
+	ctx, end := startSpan(ctx, "CoinTransactionUseCase.ExportCoinStatement")
+	defer end(&err)
+
+	if !from.Before(to) {
+		return domain.ErrInvalidDateRange
+	}
+
+	return uc.transactionRepo.WriteCoinStatement(ctx, userID, from, to, w)
+}
+
+// ExpireCoins sweeps every lot that has expired so far and returns how many were expired.
+func (uc *coinTransactionUseCase) ExpireCoins(ctx context.Context) (_ int, err error) {
+	ctx, end := startSpan(ctx, "CoinTransactionUseCase.ExpireCoins")
+	defer end(&err)
+
+	total := 0
+	for {
+		// Each lot commits on its own, so its entry is counted even if a
+		// later one fails
+		expired, entries, err := uc.transactionRepo.ExpireCoinLots(ctx, time.Now(), coinExpiryBatchSize)
+		metrics.ObserveCoinTransactions(entries...)
+		total += expired
+		if err != nil {
+			return total, err
+		}
+		if expired < coinExpiryBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// HoldUserCoins reserves coins for a pending purchase. Spend limits are
+// checked here, since a hold is normally captured without further checks.
+func (uc *coinTransactionUseCase) HoldUserCoins(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32) (_ *entity.CoinHold, err error) {
+	ctx, end := startSpan(ctx, "CoinTransactionUseCase.HoldUserCoins")
+	defer end(&err)
+
 	if amount <= 0 {
-		return nil, nil, errors.New("amount must be positive")
+		return nil, domain.ErrAmountNotPositive
 	}
 
 	if description == "" {
-		return nil, nil, errors.New("description is required")
+		return nil, domain.ErrDescriptionRequired
+	}
+
+	var hold *entity.CoinHold
+	err = uc.withinSpendLimits(ctx, userID, amount, func(ctx context.Context) error {
+		var err error
+		hold, err = uc.coinHoldRepo.CreateCoinHold(ctx, userID, amount, description, orderID, time.Now().Add(uc.holdTTL))
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return uc.transactionRepo.SpendUserCoins(ctx, userID, amount, description, orderID)
+	return hold, nil
 }
 
-func (uc *coinTransactionUseCase) GetUserTransactions(ctx context.Context, userID uuid.UUID, page, limit int32) ([]*entity.CoinTransaction, error) {
+func (uc *coinTransactionUseCase) GetUserHolds(ctx context.Context, userID uuid.UUID, page, limit int32) (_ []*entity.CoinHold, err error) {
+	ctx, end := startSpan(ctx, "CoinTransactionUseCase.GetUserHolds")
+	defer end(&err)
+
 	if page < 1 {
 		page = 1
 	}
@@ -58,14 +408,91 @@ func (uc *coinTransactionUseCase) GetUserTransactions(ctx context.Context, userI
 		limit = 20
 	}
 
-	offset := (page - 1) * limit
-	return uc.transactionRepo.GetTransactionsByUserID(ctx, userID, limit, offset)
+	return uc.coinHoldRepo.GetCoinHoldsByUserID(ctx, userID, limit, (page-1)*limit)
 }
 
-func (uc *coinTransactionUseCase) GetTransactionByID(ctx context.Context, id int32) (*entity.CoinTransaction, error) {
-	if id <= 0 {
-		return nil, errors.New("invalid transaction ID")
+func (uc *coinTransactionUseCase) CaptureHold(ctx context.Context, userID uuid.UUID, holdID int32) (_ *entity.CoinHold, _ *entity.CoinTransaction, err error) {
+	ctx, end := startSpan(ctx, "CoinTransactionUseCase.CaptureHold")
+	defer end(&err)
+
+	if holdID <= 0 {
+		return nil, nil, domain.ErrInvalidCoinHoldID
 	}
 
-	return uc.transactionRepo.GetTransactionByID(ctx, id)
+	hold, tx, err := uc.coinHoldRepo.CaptureCoinHold(ctx, userID, holdID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metrics.ObserveCoinTransactions(tx)
+	return hold, tx, nil
+}
+
+func (uc *coinTransactionUseCase) ReleaseHold(ctx context.Context, userID uuid.UUID, holdID int32) (_ *entity.CoinHold, err error) {
+	ctx, end := startSpan(ctx, "CoinTransactionUseCase.ReleaseHold")
+	defer end(&err)
+
+	if holdID <= 0 {
+		return nil, domain.ErrInvalidCoinHoldID
+	}
+
+	return uc.coinHoldRepo.ReleaseCoinHold(ctx, userID, holdID)
+}
+
+// OnOrderStatusChanged captures the holds placed for an order when it
+// completes and releases them when it is cancelled. Only holds of the order's
+// buyer count; an expired hold fails the completion rather than leave the
+// order unpaid.
+func (uc *coinTransactionUseCase) OnOrderStatusChanged(ctx context.Context, order *entity.Order, from string) (_ []*entity.CoinTransaction, err error) {
+	ctx, end := startSpan(ctx, "CoinTransactionUseCase.OnOrderStatusChanged")
+	defer end(&err)
+
+	if order.Status != entity.OrderStatusCompleted && order.Status != entity.OrderStatusCancelled {
+		return nil, nil
+	}
+
+	holds, err := uc.coinHoldRepo.GetActiveCoinHoldsByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var purchases []*entity.CoinTransaction
+	for _, hold := range holds {
+		if hold.UserID != order.UserID {
+			continue
+		}
+
+		if order.Status == entity.OrderStatusCompleted {
+			var purchase *entity.CoinTransaction
+			_, purchase, err = uc.coinHoldRepo.CaptureCoinHold(ctx, order.UserID, hold.ID)
+			if purchase != nil {
+				purchases = append(purchases, purchase)
+			}
+		} else {
+			_, err = uc.coinHoldRepo.ReleaseCoinHold(ctx, order.UserID, hold.ID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to settle coin hold %d: %w", hold.ID, err)
+		}
+	}
+
+	return purchases, nil
+}
+
+// ExpireHolds releases every hold that has expired so far and returns how many were released.
+func (uc *coinTransactionUseCase) ExpireHolds(ctx context.Context) (_ int, err error) {
+	ctx, end := startSpan(ctx, "CoinTransactionUseCase.ExpireHolds")
+	defer end(&err)
+
+	total := 0
+	for {
+		expired, err := uc.coinHoldRepo.ExpireCoinHolds(ctx, time.Now(), coinExpiryBatchSize)
+		total += expired
+		if err != nil {
+			return total, err
+		}
+		if expired < coinExpiryBatchSize {
+			return total, nil
+		}
+	}
 }
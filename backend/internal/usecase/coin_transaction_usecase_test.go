@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"backend/internal/domain"
 	"backend/internal/entity"
 	"backend/internal/repository"
 	"context"
@@ -11,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockCoinTransactionRepository matches your repository interface
@@ -42,6 +44,14 @@ func (m *MockCoinTransactionRepository) GetTransactionByID(ctx context.Context,
 	return args.Get(0).(*entity.CoinTransaction), args.Error(1)
 }
 
+func (m *MockCoinTransactionRepository) GetFilteredTransactions(ctx context.Context, userID uuid.UUID, filter entity.CoinTransactionFilter) ([]*entity.CoinTransaction, *entity.CoinTransactionSummary, error) {
+	args := m.Called(ctx, userID, filter)
+	if args.Get(0) == nil || args.Get(1) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).([]*entity.CoinTransaction), args.Get(1).(*entity.CoinTransactionSummary), args.Error(2)
+}
+
 func (m *MockCoinTransactionRepository) ChargeUserCoins(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32) (*entity.User, *entity.CoinTransaction, error) {
 	args := m.Called(ctx, userID, amount, description, orderID)
 	if args.Get(0) == nil || args.Get(1) == nil {
@@ -58,6 +68,130 @@ func (m *MockCoinTransactionRepository) SpendUserCoins(ctx context.Context, user
 	return args.Get(0).(*entity.User), args.Get(1).(*entity.CoinTransaction), args.Error(2)
 }
 
+func (m *MockCoinTransactionRepository) ChargeCoinPack(ctx context.Context, userID uuid.UUID, pack *entity.CoinPack) (*entity.User, []*entity.CoinTransaction, error) {
+	args := m.Called(ctx, userID, pack)
+	if args.Get(0) == nil || args.Get(1) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entity.User), args.Get(1).([]*entity.CoinTransaction), args.Error(2)
+}
+
+func (m *MockCoinTransactionRepository) GetCoinBalance(ctx context.Context, userID uuid.UUID) (*entity.CoinBalance, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CoinBalance), args.Error(1)
+}
+
+func (m *MockCoinTransactionRepository) GetCoinBalanceAt(ctx context.Context, userID uuid.UUID, at time.Time) (int, error) {
+	args := m.Called(ctx, userID, at)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCoinTransactionRepository) GetCoinBalanceChanges(ctx context.Context, userID uuid.UUID, from, to time.Time, interval, timeZone string) (int, []entity.CoinBalanceChange, error) {
+	args := m.Called(ctx, userID, from, to, interval, timeZone)
+	if args.Get(1) == nil {
+		return args.Int(0), nil, args.Error(2)
+	}
+	return args.Int(0), args.Get(1).([]entity.CoinBalanceChange), args.Error(2)
+}
+
+func (m *MockCoinTransactionRepository) WriteCoinStatement(ctx context.Context, userID uuid.UUID, from, to time.Time, w entity.CoinStatementWriter) error {
+	args := m.Called(ctx, userID, from, to, w)
+	return args.Error(0)
+}
+
+func (m *MockCoinTransactionRepository) ExpireCoinLots(ctx context.Context, now time.Time, limit int32) (int, []*entity.CoinTransaction, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(1) == nil {
+		return args.Int(0), nil, args.Error(2)
+	}
+	return args.Int(0), args.Get(1).([]*entity.CoinTransaction), args.Error(2)
+}
+
+func (m *MockCoinTransactionRepository) GrantCashback(ctx context.Context, order *entity.Order, amount int) (*entity.CoinTransaction, error) {
+	args := m.Called(ctx, order, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CoinTransaction), args.Error(1)
+}
+
+func (m *MockCoinTransactionRepository) ReverseCashback(ctx context.Context, order *entity.Order) (*entity.CoinTransaction, error) {
+	args := m.Called(ctx, order)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CoinTransaction), args.Error(1)
+}
+
+func (m *MockCoinTransactionRepository) RedeemGiftCode(ctx context.Context, userID uuid.UUID, codeHash string, now time.Time) (*entity.User, *entity.CoinTransaction, error) {
+	args := m.Called(ctx, userID, codeHash, now)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entity.User), args.Get(1).(*entity.CoinTransaction), args.Error(2)
+}
+
+func (m *MockCoinTransactionRepository) GrantReferralBonuses(ctx context.Context, refereeID uuid.UUID, policy entity.ReferralPolicy) (*entity.Referral, []*entity.CoinTransaction, error) {
+	args := m.Called(ctx, refereeID, policy)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entity.Referral), args.Get(1).([]*entity.CoinTransaction), args.Error(2)
+}
+
+// MockCoinHoldRepository matches your repository interface
+type MockCoinHoldRepository struct {
+	mock.Mock
+}
+
+func (m *MockCoinHoldRepository) CreateCoinHold(ctx context.Context, userID uuid.UUID, amount int, description string, orderID *int32, expiresAt time.Time) (*entity.CoinHold, error) {
+	args := m.Called(ctx, userID, amount, description, orderID, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CoinHold), args.Error(1)
+}
+
+func (m *MockCoinHoldRepository) GetCoinHoldsByUserID(ctx context.Context, userID uuid.UUID, limit, offset int32) ([]*entity.CoinHold, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.CoinHold), args.Error(1)
+}
+
+func (m *MockCoinHoldRepository) GetActiveCoinHoldsByOrderID(ctx context.Context, orderID int32) ([]*entity.CoinHold, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.CoinHold), args.Error(1)
+}
+
+func (m *MockCoinHoldRepository) CaptureCoinHold(ctx context.Context, userID uuid.UUID, holdID int32) (*entity.CoinHold, *entity.CoinTransaction, error) {
+	args := m.Called(ctx, userID, holdID)
+	if args.Get(0) == nil || args.Get(1) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entity.CoinHold), args.Get(1).(*entity.CoinTransaction), args.Error(2)
+}
+
+func (m *MockCoinHoldRepository) ReleaseCoinHold(ctx context.Context, userID uuid.UUID, holdID int32) (*entity.CoinHold, error) {
+	args := m.Called(ctx, userID, holdID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CoinHold), args.Error(1)
+}
+
+func (m *MockCoinHoldRepository) ExpireCoinHolds(ctx context.Context, now time.Time, limit int32) (int, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Int(0), args.Error(1)
+}
+
 // Helper functions
 func createCoinTransactionUser(id uuid.UUID, name, email string, coins int) *entity.User {
 	return &entity.User{
@@ -83,15 +217,44 @@ func createCoinTransaction(id int32, userID uuid.UUID, txType string, amount, ba
 }
 
 func setupCoinTransactionUseCase() (CoinTransactionUseCase, *MockCoinTransactionRepository) {
-	mockRepo := new(MockCoinTransactionRepository)
-	useCase := NewCoinTransactionUseCase(mockRepo)
+	useCase, mockRepo, _ := setupCoinTransactionUseCaseWithPacks()
 	return useCase, mockRepo
 }
 
+func setupCoinTransactionUseCaseWithPacks() (CoinTransactionUseCase, *MockCoinTransactionRepository, *MockCoinPackRepository) {
+	mockRepo := new(MockCoinTransactionRepository)
+	mockPackRepo := new(MockCoinPackRepository)
+	// No overrides and no default limits, so spends are never limited
+	mockLimitRepo := new(MockSpendLimitRepository)
+	mockLimitRepo.On("LockUser", fromCaller, mock.Anything).Return(nil).Maybe()
+	mockLimitRepo.On("GetUserSpendLimit", fromCaller, mock.Anything).Return(nil, nil).Maybe()
+	useCase := NewCoinTransactionUseCase(mockRepo, mockPackRepo, new(MockCoinHoldRepository), mockLimitRepo, &fakeTxManager{}, entity.SpendLimitPolicy{}, testCoinHoldTTL)
+	return useCase, mockRepo, mockPackRepo
+}
+
+func setupCoinTransactionUseCaseWithLimits(policy entity.SpendLimitPolicy) (CoinTransactionUseCase, *MockCoinTransactionRepository, *MockSpendLimitRepository, *fakeTxManager) {
+	mockRepo := new(MockCoinTransactionRepository)
+	mockLimitRepo := new(MockSpendLimitRepository)
+	txManager := &fakeTxManager{}
+	useCase := NewCoinTransactionUseCase(mockRepo, new(MockCoinPackRepository), new(MockCoinHoldRepository), mockLimitRepo, txManager, policy, testCoinHoldTTL)
+	return useCase, mockRepo, mockLimitRepo, txManager
+}
+
+const testCoinHoldTTL = 30 * time.Minute
+
+func setupCoinTransactionUseCaseWithHolds() (CoinTransactionUseCase, *MockCoinHoldRepository) {
+	mockHoldRepo := new(MockCoinHoldRepository)
+	mockLimitRepo := new(MockSpendLimitRepository)
+	mockLimitRepo.On("LockUser", fromCaller, mock.Anything).Return(nil).Maybe()
+	mockLimitRepo.On("GetUserSpendLimit", fromCaller, mock.Anything).Return(nil, nil).Maybe()
+	useCase := NewCoinTransactionUseCase(new(MockCoinTransactionRepository), new(MockCoinPackRepository), mockHoldRepo, mockLimitRepo, &fakeTxManager{}, entity.SpendLimitPolicy{}, testCoinHoldTTL)
+	return useCase, mockHoldRepo
+}
+
 // Tests for ChargeUserCoins
 func TestChargeUserCoins_Success(t *testing.T) {
 	uc, mockRepo := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 	amount := 100
@@ -101,7 +264,7 @@ func TestChargeUserCoins_Success(t *testing.T) {
 	expectedUser := createCoinTransactionUser(userID, "Alice", "alice@example.com", 200)
 	expectedTransaction := createCoinTransaction(1, userID, "charge", amount, 200)
 
-	mockRepo.On("ChargeUserCoins", ctx, userID, amount, description, orderID).
+	mockRepo.On("ChargeUserCoins", fromCaller, userID, amount, description, orderID).
 		Return(expectedUser, expectedTransaction, nil)
 
 	user, transaction, err := uc.ChargeUserCoins(ctx, userID, amount, description, orderID)
@@ -119,7 +282,7 @@ func TestChargeUserCoins_Success(t *testing.T) {
 
 func TestChargeUserCoins_WithOrderID(t *testing.T) {
 	uc, mockRepo := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 	amount := 50
@@ -130,7 +293,7 @@ func TestChargeUserCoins_WithOrderID(t *testing.T) {
 	expectedTransaction := createCoinTransaction(2, userID, "charge", amount, 150)
 	expectedTransaction.OrderID = &orderID
 
-	mockRepo.On("ChargeUserCoins", ctx, userID, amount, description, &orderID).
+	mockRepo.On("ChargeUserCoins", fromCaller, userID, amount, description, &orderID).
 		Return(expectedUser, expectedTransaction, nil)
 
 	user, transaction, err := uc.ChargeUserCoins(ctx, userID, amount, description, &orderID)
@@ -146,7 +309,7 @@ func TestChargeUserCoins_WithOrderID(t *testing.T) {
 
 func TestChargeUserCoins_ZeroAmount(t *testing.T) {
 	uc, _ := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 	amount := 0
@@ -162,7 +325,7 @@ func TestChargeUserCoins_ZeroAmount(t *testing.T) {
 
 func TestChargeUserCoins_NegativeAmount(t *testing.T) {
 	uc, _ := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 	amount := -50
@@ -178,7 +341,7 @@ func TestChargeUserCoins_NegativeAmount(t *testing.T) {
 
 func TestChargeUserCoins_EmptyDescription(t *testing.T) {
 	uc, _ := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 	amount := 100
@@ -194,14 +357,14 @@ func TestChargeUserCoins_EmptyDescription(t *testing.T) {
 
 func TestChargeUserCoins_RepositoryError(t *testing.T) {
 	uc, mockRepo := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 	amount := 100
 	description := "Test charge"
 
-	mockRepo.On("ChargeUserCoins", ctx, userID, amount, description, (*int32)(nil)).
-		Return(nil, nil, errors.New("user not found"))
+	mockRepo.On("ChargeUserCoins", fromCaller, userID, amount, description, (*int32)(nil)).
+		Return(nil, nil, domain.ErrUserNotFound)
 
 	user, transaction, err := uc.ChargeUserCoins(ctx, userID, amount, description, nil)
 
@@ -216,7 +379,7 @@ func TestChargeUserCoins_RepositoryError(t *testing.T) {
 // Tests for SpendUserCoins
 func TestSpendUserCoins_Success(t *testing.T) {
 	uc, mockRepo := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 	amount := 50
@@ -226,7 +389,7 @@ func TestSpendUserCoins_Success(t *testing.T) {
 	expectedUser := createCoinTransactionUser(userID, "Alice", "alice@example.com", 50)
 	expectedTransaction := createCoinTransaction(3, userID, "purchase", -amount, 50)
 
-	mockRepo.On("SpendUserCoins", ctx, userID, amount, description, orderID).
+	mockRepo.On("SpendUserCoins", fromCaller, userID, amount, description, orderID).
 		Return(expectedUser, expectedTransaction, nil)
 
 	user, transaction, err := uc.SpendUserCoins(ctx, userID, amount, description, orderID)
@@ -244,7 +407,7 @@ func TestSpendUserCoins_Success(t *testing.T) {
 
 func TestSpendUserCoins_ZeroAmount(t *testing.T) {
 	uc, _ := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 	amount := 0
@@ -260,7 +423,7 @@ func TestSpendUserCoins_ZeroAmount(t *testing.T) {
 
 func TestSpendUserCoins_EmptyDescription(t *testing.T) {
 	uc, _ := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 	amount := 50
@@ -276,135 +439,312 @@ func TestSpendUserCoins_EmptyDescription(t *testing.T) {
 
 func TestSpendUserCoins_InsufficientCoins(t *testing.T) {
 	uc, mockRepo := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 	amount := 200
 	description := "Expensive item"
 
-	mockRepo.On("SpendUserCoins", ctx, userID, amount, description, (*int32)(nil)).
-		Return(nil, nil, errors.New("insufficient coins: have 100, need 200"))
+	mockRepo.On("SpendUserCoins", fromCaller, userID, amount, description, (*int32)(nil)).
+		Return(nil, nil, domain.Errorf(domain.ErrInsufficientCoins, "have 100, need 200"))
 
 	user, transaction, err := uc.SpendUserCoins(ctx, userID, amount, description, nil)
 
 	assert.Error(t, err)
 	assert.Nil(t, user)
 	assert.Nil(t, transaction)
-	assert.Contains(t, err.Error(), "insufficient coins")
+	assert.ErrorIs(t, err, domain.ErrInsufficientCoins)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSpendUserCoins_PerTransactionLimit(t *testing.T) {
+	uc, mockRepo, mockLimitRepo, _ := setupCoinTransactionUseCaseWithLimits(entity.SpendLimitPolicy{PerTransactionLimit: 100})
+	ctx := callerContext()
+
+	userID := uuid.New()
+
+	mockLimitRepo.On("LockUser", fromCaller, userID).Return(nil)
+	mockLimitRepo.On("GetUserSpendLimit", fromCaller, userID).Return(nil, nil)
+
+	user, transaction, err := uc.SpendUserCoins(ctx, userID, 150, "Expensive item", nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, user)
+	assert.Nil(t, transaction)
+	assert.Equal(t, "amount exceeds per-transaction limit", err.Error())
+
+	mockLimitRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "SpendUserCoins", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSpendUserCoins_PerTransactionLimitOverride(t *testing.T) {
+	uc, mockRepo, mockLimitRepo, _ := setupCoinTransactionUseCaseWithLimits(entity.SpendLimitPolicy{PerTransactionLimit: 100})
+	ctx := callerContext()
+
+	userID := uuid.New()
+	expectedUser := createCoinTransactionUser(userID, "Alice", "alice@example.com", 50)
+	expectedTransaction := createCoinTransaction(3, userID, "purchase", -150, 50)
+
+	mockLimitRepo.On("LockUser", fromCaller, userID).Return(nil)
+	mockLimitRepo.On("GetUserSpendLimit", fromCaller, userID).Return(&entity.UserSpendLimit{
+		UserID:              userID,
+		PerTransactionLimit: intPtr(200),
+	}, nil)
+	mockRepo.On("SpendUserCoins", fromCaller, userID, 150, "Expensive item", (*int32)(nil)).
+		Return(expectedUser, expectedTransaction, nil)
 
+	_, _, err := uc.SpendUserCoins(ctx, userID, 150, "Expensive item", nil)
+
+	assert.NoError(t, err)
+
+	mockLimitRepo.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSpendUserCoins_DailyLimitExceeded(t *testing.T) {
+	uc, mockRepo, mockLimitRepo, _ := setupCoinTransactionUseCaseWithLimits(entity.SpendLimitPolicy{DailyLimit: 500})
+	ctx := callerContext()
+
+	userID := uuid.New()
+
+	mockLimitRepo.On("LockUser", fromCaller, userID).Return(nil)
+	mockLimitRepo.On("GetUserSpendLimit", fromCaller, userID).Return(nil, nil)
+	mockLimitRepo.On("GetSpendActivity", fromCaller, userID, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(&entity.SpendActivity{Spent: 450, SpendCount: 3}, nil)
+
+	user, transaction, err := uc.SpendUserCoins(ctx, userID, 100, "Test purchase", nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, user)
+	assert.Nil(t, transaction)
+	assert.Equal(t, "daily spend limit exceeded", err.Error())
+
+	mockLimitRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "SpendUserCoins", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSpendUserCoins_WithinDailyLimit(t *testing.T) {
+	uc, mockRepo, mockLimitRepo, _ := setupCoinTransactionUseCaseWithLimits(entity.SpendLimitPolicy{DailyLimit: 500})
+	ctx := callerContext()
+
+	userID := uuid.New()
+	expectedUser := createCoinTransactionUser(userID, "Alice", "alice@example.com", 50)
+	expectedTransaction := createCoinTransaction(3, userID, "purchase", -50, 50)
+
+	mockLimitRepo.On("LockUser", fromCaller, userID).Return(nil)
+	mockLimitRepo.On("GetUserSpendLimit", fromCaller, userID).Return(nil, nil)
+	mockLimitRepo.On("GetSpendActivity", fromCaller, userID, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(&entity.SpendActivity{Spent: 450, SpendCount: 3}, nil)
+	mockRepo.On("SpendUserCoins", fromCaller, userID, 50, "Test purchase", (*int32)(nil)).
+		Return(expectedUser, expectedTransaction, nil)
+
+	_, _, err := uc.SpendUserCoins(ctx, userID, 50, "Test purchase", nil)
+
+	assert.NoError(t, err)
+
+	mockLimitRepo.AssertExpectations(t)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestSpendUserCoins_VelocityBlocks(t *testing.T) {
+	policy := entity.SpendLimitPolicy{
+		VelocityMaxSpends: 5,
+		VelocityWindow:    10 * time.Minute,
+		BlockDuration:     30 * time.Minute,
+	}
+	uc, mockRepo, mockLimitRepo, txManager := setupCoinTransactionUseCaseWithLimits(policy)
+	ctx := callerContext()
+
+	userID := uuid.New()
+
+	mockLimitRepo.On("LockUser", fromCaller, userID).Return(nil)
+	mockLimitRepo.On("GetUserSpendLimit", fromCaller, userID).Return(nil, nil)
+	mockLimitRepo.On("GetSpendActivity", fromCaller, userID, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(&entity.SpendActivity{Spent: 250, SpendCount: 5}, nil)
+	mockLimitRepo.On("BlockSpending", fromCaller, userID, mock.AnythingOfType("time.Time"), entity.SecurityEventSpendVelocity, "6 spends within 10m0s").
+		Return(nil)
+
+	user, transaction, err := uc.SpendUserCoins(ctx, userID, 50, "Test purchase", nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, user)
+	assert.Nil(t, transaction)
+	assert.Equal(t, "spending is temporarily blocked", err.Error())
+	assert.Equal(t, 1, txManager.commits, "the block is kept")
+
+	mockLimitRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "SpendUserCoins", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSpendUserCoins_AlreadyBlocked(t *testing.T) {
+	uc, mockRepo, mockLimitRepo, _ := setupCoinTransactionUseCaseWithLimits(entity.SpendLimitPolicy{})
+	ctx := callerContext()
+
+	userID := uuid.New()
+	blockedUntil := time.Now().Add(10 * time.Minute)
+
+	mockLimitRepo.On("LockUser", fromCaller, userID).Return(nil)
+	mockLimitRepo.On("GetUserSpendLimit", fromCaller, userID).Return(&entity.UserSpendLimit{
+		UserID:               userID,
+		SpendingBlockedUntil: &blockedUntil,
+	}, nil)
+
+	_, _, err := uc.SpendUserCoins(ctx, userID, 50, "Test purchase", nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, "spending is temporarily blocked", err.Error())
+
+	mockLimitRepo.AssertNotCalled(t, "GetSpendActivity", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "SpendUserCoins", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 // Tests for GetUserTransactions
 func TestGetUserTransactions_Success(t *testing.T) {
 	uc, mockRepo := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
-	page := int32(1)
-	limit := int32(10)
-	offset := int32(0)
+	filter := entity.CoinTransactionFilter{Page: 1, Limit: 10}
 
 	expectedTransactions := []*entity.CoinTransaction{
 		createCoinTransaction(1, userID, "charge", 100, 100),
 		createCoinTransaction(2, userID, "purchase", -50, 50),
 	}
+	expectedSummary := &entity.CoinTransactionSummary{TotalCount: 2, TotalCharged: 100, TotalSpent: 50}
 
-	mockRepo.On("GetTransactionsByUserID", ctx, userID, limit, offset).
-		Return(expectedTransactions, nil)
+	mockRepo.On("GetFilteredTransactions", fromCaller, userID, filter).
+		Return(expectedTransactions, expectedSummary, nil)
 
-	transactions, err := uc.GetUserTransactions(ctx, userID, page, limit)
+	history, err := uc.GetUserTransactions(ctx, userID, filter)
 
 	assert.NoError(t, err)
-	assert.NotNil(t, transactions)
-	assert.Len(t, transactions, 2)
-	assert.Equal(t, "charge", transactions[0].TransactionType)
-	assert.Equal(t, "purchase", transactions[1].TransactionType)
+	assert.NotNil(t, history)
+	assert.Len(t, history.Transactions, 2)
+	assert.Equal(t, "charge", history.Transactions[0].TransactionType)
+	assert.Equal(t, "purchase", history.Transactions[1].TransactionType)
+	assert.Equal(t, 2, history.Summary.TotalCount)
+	assert.Equal(t, 100, history.Summary.TotalCharged)
+	assert.Equal(t, 50, history.Summary.TotalSpent)
 
 	mockRepo.AssertExpectations(t)
 }
 
-func TestGetUserTransactions_WithPagination(t *testing.T) {
+func TestGetUserTransactions_WithFilters(t *testing.T) {
 	uc, mockRepo := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
-	page := int32(2)
-	limit := int32(5)
-	offset := int32(5)
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	orderID := int32(42)
+	filter := entity.CoinTransactionFilter{
+		TransactionTypes: []string{"purchase", "refund"},
+		From:             &from,
+		To:               &to,
+		OrderID:          &orderID,
+		AmountSign:       entity.AmountSignNegative,
+		Page:             2,
+		Limit:            5,
+	}
 
 	expectedTransactions := []*entity.CoinTransaction{
-		createCoinTransaction(6, userID, "refund", 25, 75),
+		createCoinTransaction(6, userID, "purchase", -25, 75),
 	}
+	expectedSummary := &entity.CoinTransactionSummary{TotalCount: 6, TotalSpent: 150}
 
-	mockRepo.On("GetTransactionsByUserID", ctx, userID, limit, offset).
-		Return(expectedTransactions, nil)
+	mockRepo.On("GetFilteredTransactions", fromCaller, userID, filter).
+		Return(expectedTransactions, expectedSummary, nil)
 
-	transactions, err := uc.GetUserTransactions(ctx, userID, page, limit)
+	history, err := uc.GetUserTransactions(ctx, userID, filter)
 
 	assert.NoError(t, err)
-	assert.Len(t, transactions, 1)
+	assert.Len(t, history.Transactions, 1)
+	assert.Equal(t, 6, history.Summary.TotalCount)
+	assert.Equal(t, int32(2), history.Page)
+	assert.Equal(t, int32(5), history.Limit)
 
 	mockRepo.AssertExpectations(t)
 }
 
 func TestGetUserTransactions_DefaultValues(t *testing.T) {
 	uc, mockRepo := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
-	page := int32(0)
-	limit := int32(0)
-
-	expectedTransactions := []*entity.CoinTransaction{}
 
-	mockRepo.On("GetTransactionsByUserID", ctx, userID, int32(20), int32(0)).
-		Return(expectedTransactions, nil)
+	mockRepo.On("GetFilteredTransactions", fromCaller, userID, entity.CoinTransactionFilter{Page: 1, Limit: 20}).
+		Return([]*entity.CoinTransaction{}, &entity.CoinTransactionSummary{}, nil)
 
-	transactions, err := uc.GetUserTransactions(ctx, userID, page, limit)
+	history, err := uc.GetUserTransactions(ctx, userID, entity.CoinTransactionFilter{})
 
 	assert.NoError(t, err)
-	assert.NotNil(t, transactions)
+	assert.NotNil(t, history)
+	assert.Equal(t, int32(1), history.Page)
+	assert.Equal(t, int32(20), history.Limit)
 
 	mockRepo.AssertExpectations(t)
 }
 
 func TestGetUserTransactions_LimitTooHigh(t *testing.T) {
 	uc, mockRepo := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
-	page := int32(1)
-	limit := int32(150)
-
-	expectedTransactions := []*entity.CoinTransaction{}
 
-	mockRepo.On("GetTransactionsByUserID", ctx, userID, int32(20), int32(0)).
-		Return(expectedTransactions, nil)
+	mockRepo.On("GetFilteredTransactions", fromCaller, userID, entity.CoinTransactionFilter{Page: 1, Limit: 20}).
+		Return([]*entity.CoinTransaction{}, &entity.CoinTransactionSummary{}, nil)
 
-	transactions, err := uc.GetUserTransactions(ctx, userID, page, limit)
+	history, err := uc.GetUserTransactions(ctx, userID, entity.CoinTransactionFilter{Page: 1, Limit: 150})
 
 	assert.NoError(t, err)
-	assert.NotNil(t, transactions)
+	assert.NotNil(t, history)
 
 	mockRepo.AssertExpectations(t)
 }
 
+func TestGetUserTransactions_InvalidFilters(t *testing.T) {
+	uc, _ := setupCoinTransactionUseCase()
+	ctx := callerContext()
+
+	from := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		filter   entity.CoinTransactionFilter
+		expected string
+	}{
+		{"unknown type", entity.CoinTransactionFilter{TransactionTypes: []string{"gift"}}, "invalid transaction type"},
+		{"reversed range", entity.CoinTransactionFilter{From: &from, To: &to}, "invalid date range"},
+		{"unknown sign", entity.CoinTransactionFilter{AmountSign: "zero"}, "invalid amount sign"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history, err := uc.GetUserTransactions(ctx, uuid.New(), tt.filter)
+
+			assert.Error(t, err)
+			assert.Nil(t, history)
+			assert.Equal(t, tt.expected, err.Error())
+		})
+	}
+}
+
 func TestGetUserTransactions_RepositoryError(t *testing.T) {
 	uc, mockRepo := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
-	page := int32(1)
-	limit := int32(10)
+	filter := entity.CoinTransactionFilter{Page: 1, Limit: 10}
 
-	mockRepo.On("GetTransactionsByUserID", ctx, userID, limit, int32(0)).
-		Return(nil, errors.New("database error"))
+	mockRepo.On("GetFilteredTransactions", fromCaller, userID, filter).
+		Return(nil, nil, errors.New("database error"))
 
-	transactions, err := uc.GetUserTransactions(ctx, userID, page, limit)
+	history, err := uc.GetUserTransactions(ctx, userID, filter)
 
 	assert.Error(t, err)
-	assert.Nil(t, transactions)
+	assert.Nil(t, history)
 	assert.Equal(t, "database error", err.Error())
 
 	mockRepo.AssertExpectations(t)
@@ -413,13 +753,13 @@ func TestGetUserTransactions_RepositoryError(t *testing.T) {
 // Tests for GetTransactionByID
 func TestGetTransactionByID_Success(t *testing.T) {
 	uc, mockRepo := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	transactionID := int32(1)
 	userID := uuid.New()
 	expectedTransaction := createCoinTransaction(transactionID, userID, "charge", 100, 200)
 
-	mockRepo.On("GetTransactionByID", ctx, transactionID).
+	mockRepo.On("GetTransactionByID", fromCaller, transactionID).
 		Return(expectedTransaction, nil)
 
 	transaction, err := uc.GetTransactionByID(ctx, transactionID)
@@ -434,7 +774,7 @@ func TestGetTransactionByID_Success(t *testing.T) {
 
 func TestGetTransactionByID_InvalidID(t *testing.T) {
 	uc, _ := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	// Test zero ID
 	transaction, err := uc.GetTransactionByID(ctx, 0)
@@ -451,12 +791,12 @@ func TestGetTransactionByID_InvalidID(t *testing.T) {
 
 func TestGetTransactionByID_NotFound(t *testing.T) {
 	uc, mockRepo := setupCoinTransactionUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	transactionID := int32(999)
 
-	mockRepo.On("GetTransactionByID", ctx, transactionID).
-		Return(nil, errors.New("transaction not found"))
+	mockRepo.On("GetTransactionByID", fromCaller, transactionID).
+		Return(nil, domain.ErrTransactionNotFound)
 
 	transaction, err := uc.GetTransactionByID(ctx, transactionID)
 
@@ -466,3 +806,550 @@ func TestGetTransactionByID_NotFound(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+// Tests for PurchaseCoinPack
+func TestPurchaseCoinPack_WithBonus(t *testing.T) {
+	uc, mockRepo, mockPackRepo := setupCoinTransactionUseCaseWithPacks()
+	ctx := callerContext()
+
+	userID := uuid.New()
+	pack := createCoinPack(2, "5000 + 500 Bonus", 5000, 500)
+
+	expectedUser := createCoinTransactionUser(userID, "Alice", "alice@example.com", 5500)
+	chargeTx := createCoinTransaction(1, userID, "charge", 5000, 5000)
+	bonusTx := createCoinTransaction(2, userID, "bonus", 500, 5500)
+
+	mockPackRepo.On("GetCoinPackByID", fromCaller, int32(2)).Return(pack, nil)
+	mockRepo.On("ChargeCoinPack", fromCaller, userID, pack).
+		Return(expectedUser, []*entity.CoinTransaction{chargeTx, bonusTx}, nil)
+
+	user, transactions, err := uc.PurchaseCoinPack(ctx, userID, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5500, user.Coins)
+	assert.Len(t, transactions, 2)
+	assert.Equal(t, "charge", transactions[0].TransactionType)
+	assert.Equal(t, "bonus", transactions[1].TransactionType)
+
+	mockRepo.AssertExpectations(t)
+	mockPackRepo.AssertExpectations(t)
+}
+
+func TestPurchaseCoinPack_InvalidID(t *testing.T) {
+	uc, _ := setupCoinTransactionUseCase()
+	ctx := callerContext()
+
+	user, transactions, err := uc.PurchaseCoinPack(ctx, uuid.New(), 0)
+
+	assert.Error(t, err)
+	assert.Nil(t, user)
+	assert.Nil(t, transactions)
+	assert.Equal(t, "invalid coin pack ID", err.Error())
+}
+
+func TestPurchaseCoinPack_NotFound(t *testing.T) {
+	uc, mockRepo, mockPackRepo := setupCoinTransactionUseCaseWithPacks()
+	ctx := callerContext()
+
+	mockPackRepo.On("GetCoinPackByID", fromCaller, int32(99)).Return(nil, domain.ErrCoinPackNotFound)
+
+	user, transactions, err := uc.PurchaseCoinPack(ctx, uuid.New(), 99)
+
+	assert.Error(t, err)
+	assert.Nil(t, user)
+	assert.Nil(t, transactions)
+	assert.Equal(t, "coin pack not found", err.Error())
+
+	mockRepo.AssertNotCalled(t, "ChargeCoinPack", mock.Anything, mock.Anything, mock.Anything)
+	mockPackRepo.AssertExpectations(t)
+}
+
+func TestPurchaseCoinPack_Inactive(t *testing.T) {
+	uc, mockRepo, mockPackRepo := setupCoinTransactionUseCaseWithPacks()
+	ctx := callerContext()
+
+	pack := createCoinPack(3, "Retired Pack", 1000, 0)
+	pack.IsActive = false
+
+	mockPackRepo.On("GetCoinPackByID", fromCaller, int32(3)).Return(pack, nil)
+
+	user, transactions, err := uc.PurchaseCoinPack(ctx, uuid.New(), 3)
+
+	assert.Error(t, err)
+	assert.Nil(t, user)
+	assert.Nil(t, transactions)
+	assert.Equal(t, "coin pack is not available", err.Error())
+
+	mockRepo.AssertNotCalled(t, "ChargeCoinPack", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Tests for GetCoinBalance
+func TestGetCoinBalance_Success(t *testing.T) {
+	uc, mockRepo := setupCoinTransactionUseCase()
+	ctx := callerContext()
+
+	userID := uuid.New()
+	expectedBalance := &entity.CoinBalance{
+		Coins:       1500,
+		NonExpiring: 1000,
+		Expiring: []entity.CoinExpiryBucket{
+			{ExpiresOn: "2026-12-01", Amount: 300},
+			{ExpiresOn: "2027-01-15", Amount: 200},
+		},
+	}
+
+	mockRepo.On("GetCoinBalance", fromCaller, userID).Return(expectedBalance, nil)
+
+	balance, err := uc.GetCoinBalance(ctx, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1500, balance.Coins)
+	assert.Equal(t, 1000, balance.NonExpiring)
+	assert.Len(t, balance.Expiring, 2)
+
+	mockRepo.AssertExpectations(t)
+}
+
+// Tests for ExportCoinStatement
+func TestExportCoinStatement_Success(t *testing.T) {
+	uc, mockRepo := setupCoinTransactionUseCase()
+	ctx := callerContext()
+
+	userID := uuid.New()
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	var w entity.CoinStatementWriter
+
+	mockRepo.On("WriteCoinStatement", fromCaller, userID, from, to, w).Return(nil)
+
+	err := uc.ExportCoinStatement(ctx, userID, from, to, w)
+
+	assert.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestExportCoinStatement_InvalidDateRange(t *testing.T) {
+	uc, mockRepo := setupCoinTransactionUseCase()
+	ctx := callerContext()
+
+	from := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	err := uc.ExportCoinStatement(ctx, uuid.New(), from, from, nil)
+
+	assert.Error(t, err)
+	assert.Equal(t, "invalid date range", err.Error())
+
+	mockRepo.AssertNotCalled(t, "WriteCoinStatement", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Tests for GetCoinBalanceAt and GetCoinBalanceSeries
+func TestGetCoinBalanceAt_Success(t *testing.T) {
+	uc, mockRepo := setupCoinTransactionUseCase()
+	ctx := callerContext()
+
+	userID := uuid.New()
+	at := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	mockRepo.On("GetCoinBalanceAt", fromCaller, userID, at).Return(750, nil)
+
+	balance, err := uc.GetCoinBalanceAt(ctx, userID, at)
+
+	assert.NoError(t, err)
+	assert.Equal(t, at, balance.At)
+	assert.Equal(t, 750, balance.Balance)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetCoinBalanceSeries_FillsEmptyDays(t *testing.T) {
+	uc, mockRepo := setupCoinTransactionUseCase()
+	ctx := callerContext()
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	userID := uuid.New()
+	from := time.Date(2026, 3, 1, 15, 30, 0, 0, tokyo)
+	to := time.Date(2026, 3, 5, 0, 0, 0, 0, tokyo)
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, tokyo)
+
+	mockRepo.On("GetCoinBalanceChanges", fromCaller, userID, start, to, entity.BalanceIntervalDay, "Asia/Tokyo").Return(1000, []entity.CoinBalanceChange{
+		{BucketStart: time.Date(2026, 3, 2, 0, 0, 0, 0, tokyo), NetChange: -200, RunningChange: -200},
+		{BucketStart: time.Date(2026, 3, 4, 0, 0, 0, 0, tokyo), NetChange: 500, RunningChange: 300},
+	}, nil)
+
+	series, err := uc.GetCoinBalanceSeries(ctx, userID, from, to, entity.BalanceIntervalDay, tokyo)
+
+	assert.NoError(t, err)
+	assert.Equal(t, start, series.From)
+	assert.Equal(t, 1000, series.OpeningBalance)
+	assert.Len(t, series.Points, 4)
+
+	balances := make([]int, len(series.Points))
+	for i, point := range series.Points {
+		balances[i] = point.Balance
+	}
+	assert.Equal(t, []int{1000, 800, 800, 1300}, balances)
+	assert.Equal(t, 0, series.Points[0].NetChange)
+	assert.Equal(t, 500, series.Points[3].NetChange)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetCoinBalanceSeries_WeeksStartOnMonday(t *testing.T) {
+	uc, mockRepo := setupCoinTransactionUseCase()
+	ctx := callerContext()
+
+	userID := uuid.New()
+	from := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC) // Thursday
+	to := time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	mockRepo.On("GetCoinBalanceChanges", fromCaller, userID, start, to, entity.BalanceIntervalWeek, "UTC").Return(0, []entity.CoinBalanceChange{}, nil)
+
+	series, err := uc.GetCoinBalanceSeries(ctx, userID, from, to, entity.BalanceIntervalWeek, time.UTC)
+
+	assert.NoError(t, err)
+	assert.Len(t, series.Points, 3)
+	assert.Equal(t, start, series.Points[0].Start)
+	assert.Equal(t, time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), series.Points[2].Start)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetCoinBalanceSeries_CutsIntervalsInLocation(t *testing.T) {
+	uc, mockRepo := setupCoinTransactionUseCase()
+	ctx := callerContext()
+
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	userID := uuid.New()
+	// 20:00 UTC on March 1st is already March 2nd in Tokyo
+	from := time.Date(2026, 3, 1, 20, 0, 0, 0, time.FixedZone("", 0))
+	to := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2026, 3, 2, 0, 0, 0, 0, tokyo)
+
+	mockRepo.On("GetCoinBalanceChanges", fromCaller, userID, start, to.In(tokyo), entity.BalanceIntervalDay, "Asia/Tokyo").
+		Return(0, []entity.CoinBalanceChange{}, nil)
+
+	series, err := uc.GetCoinBalanceSeries(ctx, userID, from, to, entity.BalanceIntervalDay, tokyo)
+
+	assert.NoError(t, err)
+	assert.Equal(t, start, series.From)
+	// 00:00 UTC on March 4th is 09:00 there, so March 4th is included
+	assert.Len(t, series.Points, 3)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetCoinBalanceSeries_InvalidInterval(t *testing.T) {
+	uc, mockRepo := setupCoinTransactionUseCase()
+	ctx := callerContext()
+
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	series, err := uc.GetCoinBalanceSeries(ctx, uuid.New(), from, from.AddDate(0, 0, 7), "hour", time.UTC)
+
+	assert.Error(t, err)
+	assert.Nil(t, series)
+	assert.Equal(t, "invalid interval", err.Error())
+
+	mockRepo.AssertNotCalled(t, "GetCoinBalanceChanges", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetCoinBalanceSeries_TooManyPoints(t *testing.T) {
+	uc, mockRepo := setupCoinTransactionUseCase()
+	ctx := callerContext()
+
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	series, err := uc.GetCoinBalanceSeries(ctx, uuid.New(), from, from.AddDate(5, 0, 0), entity.BalanceIntervalDay, time.UTC)
+
+	assert.Error(t, err)
+	assert.Nil(t, series)
+	assert.Equal(t, "too many points in series", err.Error())
+
+	mockRepo.AssertNotCalled(t, "GetCoinBalanceChanges", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Tests for ExpireCoins
+func TestExpireCoins_SingleBatch(t *testing.T) {
+	uc, mockRepo := setupCoinTransactionUseCase()
+	ctx := callerContext()
+
+	mockRepo.On("ExpireCoinLots", fromCaller, mock.AnythingOfType("time.Time"), int32(coinExpiryBatchSize)).
+		Return(3, []*entity.CoinTransaction{{TransactionType: entity.TransactionTypeExpiry, Amount: -100}}, nil).Once()
+
+	expired, err := uc.ExpireCoins(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, expired)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestExpireCoins_MultipleBatches(t *testing.T) {
+	uc, mockRepo := setupCoinTransactionUseCase()
+	ctx := callerContext()
+
+	mockRepo.On("ExpireCoinLots", fromCaller, mock.AnythingOfType("time.Time"), int32(coinExpiryBatchSize)).
+		Return(coinExpiryBatchSize, nil, nil).Twice()
+	mockRepo.On("ExpireCoinLots", fromCaller, mock.AnythingOfType("time.Time"), int32(coinExpiryBatchSize)).
+		Return(5, nil, nil).Once()
+
+	expired, err := uc.ExpireCoins(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2*coinExpiryBatchSize+5, expired)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestExpireCoins_RepositoryError(t *testing.T) {
+	uc, mockRepo := setupCoinTransactionUseCase()
+	ctx := callerContext()
+
+	mockRepo.On("ExpireCoinLots", fromCaller, mock.AnythingOfType("time.Time"), int32(coinExpiryBatchSize)).
+		Return(2, nil, errors.New("database error")).Once()
+
+	expired, err := uc.ExpireCoins(ctx)
+
+	assert.Error(t, err)
+	assert.Equal(t, 2, expired)
+
+	mockRepo.AssertExpectations(t)
+}
+
+// Tests for coin holds
+func createCoinHold(id int32, userID uuid.UUID, amount int, status string) *entity.CoinHold {
+	return &entity.CoinHold{
+		ID:          id,
+		UserID:      userID,
+		Amount:      amount,
+		Description: "Order checkout",
+		Status:      status,
+		ExpiresAt:   time.Now().Add(testCoinHoldTTL),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+func TestHoldUserCoins_Success(t *testing.T) {
+	uc, mockHoldRepo := setupCoinTransactionUseCaseWithHolds()
+	ctx := callerContext()
+
+	userID := uuid.New()
+	orderID := int32(7)
+	expectedHold := createCoinHold(1, userID, 300, entity.CoinHoldStatusActive)
+
+	before := time.Now()
+	mockHoldRepo.On("CreateCoinHold", fromCaller, userID, 300, "Order checkout", &orderID, mock.MatchedBy(func(expiresAt time.Time) bool {
+		return !expiresAt.Before(before.Add(testCoinHoldTTL))
+	})).Return(expectedHold, nil)
+
+	hold, err := uc.HoldUserCoins(ctx, userID, 300, "Order checkout", &orderID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.CoinHoldStatusActive, hold.Status)
+	assert.Equal(t, 300, hold.Amount)
+
+	mockHoldRepo.AssertExpectations(t)
+}
+
+func TestHoldUserCoins_InvalidAmount(t *testing.T) {
+	uc, mockHoldRepo := setupCoinTransactionUseCaseWithHolds()
+	ctx := callerContext()
+
+	hold, err := uc.HoldUserCoins(ctx, uuid.New(), 0, "Order checkout", nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, hold)
+	assert.Equal(t, "amount must be positive", err.Error())
+
+	mockHoldRepo.AssertNotCalled(t, "CreateCoinHold", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHoldUserCoins_InsufficientCoins(t *testing.T) {
+	uc, mockHoldRepo := setupCoinTransactionUseCaseWithHolds()
+	ctx := callerContext()
+
+	userID := uuid.New()
+
+	mockHoldRepo.On("CreateCoinHold", fromCaller, userID, 5000, "Order checkout", (*int32)(nil), mock.AnythingOfType("time.Time")).
+		Return(nil, domain.Errorf(domain.ErrInsufficientCoins, "have 1000, need 5000"))
+
+	hold, err := uc.HoldUserCoins(ctx, userID, 5000, "Order checkout", nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, hold)
+	assert.Contains(t, err.Error(), "insufficient coins")
+
+	mockHoldRepo.AssertExpectations(t)
+}
+
+func TestGetUserHolds_DefaultValues(t *testing.T) {
+	uc, mockHoldRepo := setupCoinTransactionUseCaseWithHolds()
+	ctx := callerContext()
+
+	userID := uuid.New()
+
+	mockHoldRepo.On("GetCoinHoldsByUserID", fromCaller, userID, int32(20), int32(0)).Return([]*entity.CoinHold{}, nil)
+
+	holds, err := uc.GetUserHolds(ctx, userID, 0, 0)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, holds)
+
+	mockHoldRepo.AssertExpectations(t)
+}
+
+func TestCaptureHold_Success(t *testing.T) {
+	uc, mockHoldRepo := setupCoinTransactionUseCaseWithHolds()
+	ctx := callerContext()
+
+	userID := uuid.New()
+	capturedHold := createCoinHold(1, userID, 300, entity.CoinHoldStatusCaptured)
+	expectedTransaction := createCoinTransaction(9, userID, "purchase", -300, 700)
+
+	mockHoldRepo.On("CaptureCoinHold", fromCaller, userID, int32(1)).Return(capturedHold, expectedTransaction, nil)
+
+	hold, transaction, err := uc.CaptureHold(ctx, userID, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.CoinHoldStatusCaptured, hold.Status)
+	assert.Equal(t, "purchase", transaction.TransactionType)
+	assert.Equal(t, -300, transaction.Amount)
+
+	mockHoldRepo.AssertExpectations(t)
+}
+
+func TestCaptureHold_NotActive(t *testing.T) {
+	uc, mockHoldRepo := setupCoinTransactionUseCaseWithHolds()
+	ctx := callerContext()
+
+	userID := uuid.New()
+
+	mockHoldRepo.On("CaptureCoinHold", fromCaller, userID, int32(1)).Return(nil, nil, domain.ErrCoinHoldNotActive)
+
+	hold, transaction, err := uc.CaptureHold(ctx, userID, 1)
+
+	assert.Error(t, err)
+	assert.Nil(t, hold)
+	assert.Nil(t, transaction)
+	assert.Equal(t, "coin hold is not active", err.Error())
+
+	mockHoldRepo.AssertExpectations(t)
+}
+
+func TestCaptureHold_InvalidID(t *testing.T) {
+	uc, mockHoldRepo := setupCoinTransactionUseCaseWithHolds()
+	ctx := callerContext()
+
+	_, _, err := uc.CaptureHold(ctx, uuid.New(), 0)
+
+	assert.Error(t, err)
+	assert.Equal(t, "invalid coin hold ID", err.Error())
+
+	mockHoldRepo.AssertNotCalled(t, "CaptureCoinHold", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReleaseHold_Success(t *testing.T) {
+	uc, mockHoldRepo := setupCoinTransactionUseCaseWithHolds()
+	ctx := callerContext()
+
+	userID := uuid.New()
+	releasedHold := createCoinHold(1, userID, 300, entity.CoinHoldStatusReleased)
+
+	mockHoldRepo.On("ReleaseCoinHold", fromCaller, userID, int32(1)).Return(releasedHold, nil)
+
+	hold, err := uc.ReleaseHold(ctx, userID, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.CoinHoldStatusReleased, hold.Status)
+
+	mockHoldRepo.AssertExpectations(t)
+}
+
+func TestOnOrderStatusChanged_CompletedCapturesOrderHolds(t *testing.T) {
+	uc, mockHoldRepo := setupCoinTransactionUseCaseWithHolds()
+	ctx := callerContext()
+
+	userID := uuid.New()
+	order := &entity.Order{ID: 7, UserID: userID, Status: entity.OrderStatusCompleted}
+	mockHoldRepo.On("GetActiveCoinHoldsByOrderID", fromCaller, int32(7)).Return([]*entity.CoinHold{
+		createCoinHold(1, userID, 300, entity.CoinHoldStatusActive),
+		createCoinHold(2, uuid.New(), 100, entity.CoinHoldStatusActive),
+	}, nil)
+	mockHoldRepo.On("CaptureCoinHold", fromCaller, userID, int32(1)).
+		Return(createCoinHold(1, userID, 300, entity.CoinHoldStatusCaptured), createCoinTransaction(9, userID, "purchase", -300, 700), nil)
+
+	purchases, err := uc.OnOrderStatusChanged(ctx, order, entity.OrderStatusPending)
+
+	assert.NoError(t, err)
+	require.Len(t, purchases, 1)
+	assert.Equal(t, int32(9), purchases[0].ID)
+	mockHoldRepo.AssertExpectations(t)
+	mockHoldRepo.AssertNotCalled(t, "CaptureCoinHold", mock.Anything, mock.Anything, int32(2))
+}
+
+func TestOnOrderStatusChanged_CancelledReleasesOrderHolds(t *testing.T) {
+	uc, mockHoldRepo := setupCoinTransactionUseCaseWithHolds()
+	ctx := callerContext()
+
+	userID := uuid.New()
+	order := &entity.Order{ID: 7, UserID: userID, Status: entity.OrderStatusCancelled}
+	mockHoldRepo.On("GetActiveCoinHoldsByOrderID", fromCaller, int32(7)).Return([]*entity.CoinHold{
+		createCoinHold(1, userID, 300, entity.CoinHoldStatusActive),
+	}, nil)
+	mockHoldRepo.On("ReleaseCoinHold", fromCaller, userID, int32(1)).
+		Return(createCoinHold(1, userID, 300, entity.CoinHoldStatusReleased), nil)
+
+	_, err := uc.OnOrderStatusChanged(ctx, order, entity.OrderStatusPending)
+
+	assert.NoError(t, err)
+	mockHoldRepo.AssertExpectations(t)
+}
+
+func TestOnOrderStatusChanged_CaptureFailureFailsTheChange(t *testing.T) {
+	uc, mockHoldRepo := setupCoinTransactionUseCaseWithHolds()
+	ctx := callerContext()
+
+	userID := uuid.New()
+	order := &entity.Order{ID: 7, UserID: userID, Status: entity.OrderStatusCompleted}
+	mockHoldRepo.On("GetActiveCoinHoldsByOrderID", fromCaller, int32(7)).Return([]*entity.CoinHold{
+		createCoinHold(1, userID, 300, entity.CoinHoldStatusActive),
+	}, nil)
+	mockHoldRepo.On("CaptureCoinHold", fromCaller, userID, int32(1)).Return(nil, nil, domain.ErrCoinHoldExpired)
+
+	_, err := uc.OnOrderStatusChanged(ctx, order, entity.OrderStatusPending)
+
+	assert.ErrorIs(t, err, domain.ErrCoinHoldExpired)
+	mockHoldRepo.AssertExpectations(t)
+}
+
+func TestOnOrderStatusChanged_IgnoresOtherStatuses(t *testing.T) {
+	uc, mockHoldRepo := setupCoinTransactionUseCaseWithHolds()
+	ctx := callerContext()
+
+	order := &entity.Order{ID: 7, UserID: uuid.New(), Status: entity.OrderStatusRefunded}
+
+	_, err := uc.OnOrderStatusChanged(ctx, order, entity.OrderStatusCompleted)
+
+	assert.NoError(t, err)
+	mockHoldRepo.AssertNotCalled(t, "GetActiveCoinHoldsByOrderID", mock.Anything, mock.Anything)
+}
+
+func TestExpireHolds_MultipleBatches(t *testing.T) {
+	uc, mockHoldRepo := setupCoinTransactionUseCaseWithHolds()
+	ctx := callerContext()
+
+	mockHoldRepo.On("ExpireCoinHolds", fromCaller, mock.AnythingOfType("time.Time"), int32(coinExpiryBatchSize)).
+		Return(coinExpiryBatchSize, nil).Once()
+	mockHoldRepo.On("ExpireCoinHolds", fromCaller, mock.AnythingOfType("time.Time"), int32(coinExpiryBatchSize)).
+		Return(4, nil).Once()
+
+	expired, err := uc.ExpireHolds(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, coinExpiryBatchSize+4, expired)
+
+	mockHoldRepo.AssertExpectations(t)
+}
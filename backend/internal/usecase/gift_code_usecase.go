@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"backend/internal/database"
+	"backend/internal/domain"
+	"backend/internal/entity"
+	"backend/internal/metrics"
+	"backend/internal/repository"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GiftCodeUseCase interface {
+	CreateGiftCodeBatch(ctx context.Context, createdBy uuid.UUID, req entity.CreateGiftCodeBatchRequest) (*entity.GiftCodeBatch, []string, error)
+	GetGiftCodeBatch(ctx context.Context, id int32) (*entity.GiftCodeBatch, error)
+	RedeemGiftCode(ctx context.Context, userID uuid.UUID, code string) (*entity.User, *entity.CoinTransaction, error)
+}
+
+const (
+	// giftCodeAlphabet leaves out 0/O and 1/I so printed codes are easy to type.
+	// Its 32 letters give 5 random bits per character.
+	giftCodeAlphabet  = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	giftCodeLength    = 16
+	giftCodeGroupSize = 4
+	giftCodeHintSize  = 4
+
+	maxGiftCodeBatchSize = 1000
+)
+
+type giftCodeUseCase struct {
+	giftCodeRepo    repository.GiftCodeRepository
+	transactionRepo repository.CoinTransactionRepository
+	txManager       database.TxManager
+	policy          entity.GiftCodePolicy
+}
+
+func NewGiftCodeUseCase(giftCodeRepo repository.GiftCodeRepository, transactionRepo repository.CoinTransactionRepository, txManager database.TxManager, policy entity.GiftCodePolicy) GiftCodeUseCase {
+	return &giftCodeUseCase{
+		giftCodeRepo:    giftCodeRepo,
+		transactionRepo: transactionRepo,
+		txManager:       txManager,
+		policy:          policy,
+	}
+}
+
+// CreateGiftCodeBatch generates req.Count new codes. The plain codes are
+// returned only here; afterwards just their hashes exist.
+func (uc *giftCodeUseCase) CreateGiftCodeBatch(ctx context.Context, createdBy uuid.UUID, req entity.CreateGiftCodeBatchRequest) (_ *entity.GiftCodeBatch, _ []string, err error) {
+	ctx, end := startSpan(ctx, "GiftCodeUseCase.CreateGiftCodeBatch")
+	defer end(&err)
+
+	if req.Count <= 0 || req.Count > maxGiftCodeBatchSize {
+		return nil, nil, domain.ErrGiftCodeCountOutOfRange
+	}
+	if req.CoinAmount <= 0 {
+		return nil, nil, domain.ErrGiftCodeAmountNotPositive
+	}
+	if req.MaxRedemptions <= 0 {
+		return nil, nil, domain.ErrMaxRedemptionsNotPositive
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, nil, domain.ErrGiftCodeExpiryNotInFuture
+	}
+
+	codes := make([]string, req.Count)
+	params := make([]repository.CreateGiftCodeParams, req.Count)
+	for i := range codes {
+		code, err := generateGiftCode()
+		if err != nil {
+			return nil, nil, err
+		}
+		codes[i] = formatGiftCode(code)
+		params[i] = repository.CreateGiftCodeParams{
+			CodeHash: hashGiftCode(code),
+			CodeHint: code[len(code)-giftCodeHintSize:],
+		}
+	}
+
+	batch, err := uc.giftCodeRepo.CreateGiftCodeBatch(ctx, createdBy, req, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return batch, codes, nil
+}
+
+func (uc *giftCodeUseCase) GetGiftCodeBatch(ctx context.Context, id int32) (_ *entity.GiftCodeBatch, err error) {
+	ctx, end := startSpan(ctx, "GiftCodeUseCase.GetGiftCodeBatch")
+	defer end(&err)
+
+	return uc.giftCodeRepo.GetGiftCodeBatch(ctx, id)
+}
+
+// RedeemGiftCode credits a gift code to the user. Every failed attempt is
+// recorded, and a user with too many recent failures is refused before the
+// code is even looked up. Attempts lock the user, so concurrent ones are
+// counted one after another, and an attempt that cannot be recorded fails.
+func (uc *giftCodeUseCase) RedeemGiftCode(ctx context.Context, userID uuid.UUID, code string) (_ *entity.User, _ *entity.CoinTransaction, err error) {
+	ctx, end := startSpan(ctx, "GiftCodeUseCase.RedeemGiftCode")
+	defer end(&err)
+
+	now := time.Now()
+
+	var user *entity.User
+	var coinTx *entity.CoinTransaction
+	var failure error
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		failure = nil
+
+		if err := uc.giftCodeRepo.LockUser(ctx, userID); err != nil {
+			return err
+		}
+
+		if uc.policy.MaxFailedAttempts > 0 {
+			failures, err := uc.giftCodeRepo.CountFailedRedemptions(ctx, userID, now.Add(-uc.policy.FailureWindow))
+			if err != nil {
+				return err
+			}
+			if failures >= uc.policy.MaxFailedAttempts {
+				return domain.ErrTooManyRedemptionAttempts
+			}
+		}
+
+		var err error
+		user, coinTx, err = uc.redeem(ctx, userID, code, now)
+		if isRedemptionFailure(err) {
+			// The failure is committed; the redemption it reports is not
+			failure = err
+			return uc.giftCodeRepo.RecordFailedRedemption(ctx, userID, err.Error())
+		}
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if failure != nil {
+		return nil, nil, failure
+	}
+
+	metrics.ObserveCoinTransactions(coinTx)
+	return user, coinTx, nil
+}
+
+func (uc *giftCodeUseCase) redeem(ctx context.Context, userID uuid.UUID, code string, now time.Time) (*entity.User, *entity.CoinTransaction, error) {
+	normalized := normalizeGiftCode(code)
+	if len(normalized) != giftCodeLength {
+		return nil, nil, domain.ErrInvalidGiftCode
+	}
+
+	return uc.transactionRepo.RedeemGiftCode(ctx, userID, hashGiftCode(normalized), now)
+}
+
+// isRedemptionFailure reports whether err is the user's mistake, which counts
+// towards the failed attempt limit, rather than the service's
+func isRedemptionFailure(err error) bool {
+	return errors.Is(err, domain.ErrInvalidGiftCode) || errors.Is(err, domain.ErrGiftCodeExpired) ||
+		errors.Is(err, domain.ErrGiftCodeFullyRedeemed) || errors.Is(err, domain.ErrGiftCodeAlreadyRedeemed)
+}
+
+func generateGiftCode() (string, error) {
+	buf := make([]byte, giftCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate gift code: %w", err)
+	}
+
+	// 256 is a multiple of 32, so masking keeps every letter equally likely
+	code := make([]byte, giftCodeLength)
+	for i, b := range buf {
+		code[i] = giftCodeAlphabet[b&31]
+	}
+
+	return string(code), nil
+}
+
+// formatGiftCode splits a code into dash-separated groups for printing
+func formatGiftCode(code string) string {
+	groups := make([]string, 0, len(code)/giftCodeGroupSize)
+	for i := 0; i < len(code); i += giftCodeGroupSize {
+		groups = append(groups, code[i:min(i+giftCodeGroupSize, len(code))])
+	}
+	return strings.Join(groups, "-")
+}
+
+// normalizeGiftCode undoes formatting and the ways people retype codes
+func normalizeGiftCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(code)))
+}
+
+func hashGiftCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"backend/internal/domain"
+	"backend/internal/entity"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockGiftCodeRepository matches your repository interface
+type MockGiftCodeRepository struct {
+	mock.Mock
+}
+
+func (m *MockGiftCodeRepository) CreateGiftCodeBatch(ctx context.Context, createdBy uuid.UUID, req entity.CreateGiftCodeBatchRequest, codes []repository.CreateGiftCodeParams) (*entity.GiftCodeBatch, error) {
+	args := m.Called(ctx, createdBy, req, codes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.GiftCodeBatch), args.Error(1)
+}
+
+func (m *MockGiftCodeRepository) GetGiftCodeBatch(ctx context.Context, id int32) (*entity.GiftCodeBatch, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.GiftCodeBatch), args.Error(1)
+}
+
+func (m *MockGiftCodeRepository) LockUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockGiftCodeRepository) CountFailedRedemptions(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	args := m.Called(ctx, userID, since)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockGiftCodeRepository) RecordFailedRedemption(ctx context.Context, userID uuid.UUID, details string) error {
+	args := m.Called(ctx, userID, details)
+	return args.Error(0)
+}
+
+var testGiftCodePolicy = entity.GiftCodePolicy{MaxFailedAttempts: 5, FailureWindow: 15 * time.Minute}
+
+func setupGiftCodeUseCase() (GiftCodeUseCase, *MockGiftCodeRepository, *MockCoinTransactionRepository) {
+	useCase, mockGiftCodeRepo, mockTransactionRepo, _ := setupGiftCodeUseCaseWithTx()
+	return useCase, mockGiftCodeRepo, mockTransactionRepo
+}
+
+func setupGiftCodeUseCaseWithTx() (GiftCodeUseCase, *MockGiftCodeRepository, *MockCoinTransactionRepository, *fakeTxManager) {
+	mockGiftCodeRepo := new(MockGiftCodeRepository)
+	mockGiftCodeRepo.On("LockUser", fromCaller, mock.Anything).Return(nil).Maybe()
+	mockTransactionRepo := new(MockCoinTransactionRepository)
+	txManager := &fakeTxManager{}
+	useCase := NewGiftCodeUseCase(mockGiftCodeRepo, mockTransactionRepo, txManager, testGiftCodePolicy)
+	return useCase, mockGiftCodeRepo, mockTransactionRepo, txManager
+}
+
+func TestCreateGiftCodeBatch_Success(t *testing.T) {
+	uc, mockGiftCodeRepo, _ := setupGiftCodeUseCase()
+	ctx := callerContext()
+
+	adminID := uuid.New()
+	req := entity.CreateGiftCodeBatchRequest{Count: 3, CoinAmount: 500, MaxRedemptions: 1, Description: "Spring campaign"}
+
+	var stored []repository.CreateGiftCodeParams
+	mockGiftCodeRepo.On("CreateGiftCodeBatch", fromCaller, adminID, req, mock.AnythingOfType("[]repository.CreateGiftCodeParams")).
+		Run(func(args mock.Arguments) {
+			stored = args.Get(3).([]repository.CreateGiftCodeParams)
+		}).
+		Return(&entity.GiftCodeBatch{ID: 1, Description: "Spring campaign"}, nil)
+
+	batch, codes, err := uc.CreateGiftCodeBatch(ctx, adminID, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), batch.ID)
+	assert.Len(t, codes, 3)
+	assert.Len(t, stored, 3)
+
+	for i, code := range codes {
+		assert.Len(t, code, 19) // four groups of four plus three dashes
+		normalized := normalizeGiftCode(code)
+		assert.Equal(t, hashGiftCode(normalized), stored[i].CodeHash)
+		assert.Equal(t, normalized[12:], stored[i].CodeHint)
+	}
+
+	mockGiftCodeRepo.AssertExpectations(t)
+}
+
+func TestCreateGiftCodeBatch_InvalidCount(t *testing.T) {
+	uc, mockGiftCodeRepo, _ := setupGiftCodeUseCase()
+	ctx := callerContext()
+
+	batch, codes, err := uc.CreateGiftCodeBatch(ctx, uuid.New(), entity.CreateGiftCodeBatchRequest{Count: 1001, CoinAmount: 100, MaxRedemptions: 1})
+
+	assert.Error(t, err)
+	assert.Nil(t, batch)
+	assert.Nil(t, codes)
+	assert.Equal(t, "count must be between 1 and 1000", err.Error())
+
+	mockGiftCodeRepo.AssertNotCalled(t, "CreateGiftCodeBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateGiftCodeBatch_PastExpiry(t *testing.T) {
+	uc, _, _ := setupGiftCodeUseCase()
+	ctx := callerContext()
+
+	past := time.Now().Add(-time.Hour)
+	_, _, err := uc.CreateGiftCodeBatch(ctx, uuid.New(), entity.CreateGiftCodeBatchRequest{Count: 1, CoinAmount: 100, MaxRedemptions: 1, ExpiresAt: &past})
+
+	assert.Error(t, err)
+	assert.Equal(t, "expiry must be in the future", err.Error())
+}
+
+func TestRedeemGiftCode_Success(t *testing.T) {
+	uc, mockGiftCodeRepo, mockTransactionRepo := setupGiftCodeUseCase()
+	ctx := callerContext()
+
+	userID := uuid.New()
+	expectedUser := &entity.User{ID: userID, Coins: 1500}
+	expectedTx := &entity.CoinTransaction{ID: 1, UserID: userID, TransactionType: entity.TransactionTypeGiftCode, Amount: 500}
+
+	mockGiftCodeRepo.On("CountFailedRedemptions", fromCaller, userID, mock.AnythingOfType("time.Time")).Return(0, nil)
+	// Lower case and spaces are accepted and normalized before hashing
+	mockTransactionRepo.On("RedeemGiftCode", fromCaller, userID, hashGiftCode("ABCDEFGHJKLMNPQR"), mock.AnythingOfType("time.Time")).
+		Return(expectedUser, expectedTx, nil)
+
+	user, transaction, err := uc.RedeemGiftCode(ctx, userID, " abcd efgh-jklm-npqr ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1500, user.Coins)
+	assert.Equal(t, 500, transaction.Amount)
+
+	mockGiftCodeRepo.AssertNotCalled(t, "RecordFailedRedemption", mock.Anything, mock.Anything, mock.Anything)
+	mockTransactionRepo.AssertExpectations(t)
+}
+
+func TestRedeemGiftCode_InvalidCodeRecordsFailure(t *testing.T) {
+	uc, mockGiftCodeRepo, mockTransactionRepo, txManager := setupGiftCodeUseCaseWithTx()
+	ctx := callerContext()
+
+	userID := uuid.New()
+
+	mockGiftCodeRepo.On("CountFailedRedemptions", fromCaller, userID, mock.AnythingOfType("time.Time")).Return(2, nil)
+	mockTransactionRepo.On("RedeemGiftCode", fromCaller, userID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+		Return(nil, nil, domain.ErrInvalidGiftCode)
+	mockGiftCodeRepo.On("RecordFailedRedemption", fromCaller, userID, "invalid gift code").Return(nil)
+
+	user, transaction, err := uc.RedeemGiftCode(ctx, userID, "AAAA-BBBB-CCCC-DDDD")
+
+	assert.Error(t, err)
+	assert.Nil(t, user)
+	assert.Nil(t, transaction)
+	assert.Equal(t, "invalid gift code", err.Error())
+	// The failure is kept even though the redemption fails
+	assert.Equal(t, 1, txManager.commits)
+
+	mockGiftCodeRepo.AssertExpectations(t)
+}
+
+func TestRedeemGiftCode_UnrecordedFailureFails(t *testing.T) {
+	uc, mockGiftCodeRepo, mockTransactionRepo, txManager := setupGiftCodeUseCaseWithTx()
+	ctx := callerContext()
+
+	userID := uuid.New()
+
+	mockGiftCodeRepo.On("CountFailedRedemptions", fromCaller, userID, mock.AnythingOfType("time.Time")).Return(0, nil)
+	mockTransactionRepo.On("RedeemGiftCode", fromCaller, userID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+		Return(nil, nil, domain.ErrInvalidGiftCode)
+	mockGiftCodeRepo.On("RecordFailedRedemption", fromCaller, userID, "invalid gift code").
+		Return(errors.New("failed to record failed redemption: connection reset"))
+
+	_, _, err := uc.RedeemGiftCode(ctx, userID, "AAAA-BBBB-CCCC-DDDD")
+
+	assert.EqualError(t, err, "failed to record failed redemption: connection reset")
+	assert.Equal(t, 0, txManager.commits)
+	assert.Equal(t, 1, txManager.rollbacks)
+}
+
+func TestRedeemGiftCode_CountErrorFails(t *testing.T) {
+	uc, mockGiftCodeRepo, mockTransactionRepo := setupGiftCodeUseCase()
+	ctx := callerContext()
+
+	userID := uuid.New()
+
+	mockGiftCodeRepo.On("CountFailedRedemptions", fromCaller, userID, mock.AnythingOfType("time.Time")).
+		Return(0, errors.New("failed to count failed redemptions: connection reset"))
+
+	_, _, err := uc.RedeemGiftCode(ctx, userID, "ABCD-EFGH-JKLM-NPQR")
+
+	assert.Error(t, err)
+	mockTransactionRepo.AssertNotCalled(t, "RedeemGiftCode", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRedeemGiftCode_MalformedCodeSkipsLookup(t *testing.T) {
+	uc, mockGiftCodeRepo, mockTransactionRepo := setupGiftCodeUseCase()
+	ctx := callerContext()
+
+	userID := uuid.New()
+
+	mockGiftCodeRepo.On("CountFailedRedemptions", fromCaller, userID, mock.AnythingOfType("time.Time")).Return(0, nil)
+	mockGiftCodeRepo.On("RecordFailedRedemption", fromCaller, userID, "invalid gift code").Return(nil)
+
+	_, _, err := uc.RedeemGiftCode(ctx, userID, "SHORT")
+
+	assert.Error(t, err)
+	assert.Equal(t, "invalid gift code", err.Error())
+
+	mockTransactionRepo.AssertNotCalled(t, "RedeemGiftCode", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockGiftCodeRepo.AssertExpectations(t)
+}
+
+func TestRedeemGiftCode_TooManyFailures(t *testing.T) {
+	uc, mockGiftCodeRepo, mockTransactionRepo := setupGiftCodeUseCase()
+	ctx := callerContext()
+
+	userID := uuid.New()
+
+	mockGiftCodeRepo.On("CountFailedRedemptions", fromCaller, userID, mock.AnythingOfType("time.Time")).Return(5, nil)
+
+	_, _, err := uc.RedeemGiftCode(ctx, userID, "ABCD-EFGH-JKLM-NPQR")
+
+	assert.Error(t, err)
+	assert.Equal(t, "too many failed redemption attempts", err.Error())
+
+	mockTransactionRepo.AssertNotCalled(t, "RedeemGiftCode", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockGiftCodeRepo.AssertNotCalled(t, "RecordFailedRedemption", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRedeemGiftCode_DatabaseErrorNotCounted(t *testing.T) {
+	uc, mockGiftCodeRepo, mockTransactionRepo := setupGiftCodeUseCase()
+	ctx := callerContext()
+
+	userID := uuid.New()
+
+	mockGiftCodeRepo.On("CountFailedRedemptions", fromCaller, userID, mock.AnythingOfType("time.Time")).Return(0, nil)
+	mockTransactionRepo.On("RedeemGiftCode", fromCaller, userID, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+		Return(nil, nil, errors.New("failed to lock gift code: connection reset"))
+
+	_, _, err := uc.RedeemGiftCode(ctx, userID, "ABCD-EFGH-JKLM-NPQR")
+
+	assert.Error(t, err)
+
+	mockGiftCodeRepo.AssertNotCalled(t, "RecordFailedRedemption", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGenerateGiftCode(t *testing.T) {
+	code, err := generateGiftCode()
+
+	assert.NoError(t, err)
+	assert.Len(t, code, giftCodeLength)
+	for _, r := range code {
+		assert.True(t, strings.ContainsRune(giftCodeAlphabet, r))
+	}
+}
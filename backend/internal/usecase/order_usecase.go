@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"backend/internal/database"
+	"backend/internal/domain"
+	"backend/internal/entity"
+	"backend/internal/metrics"
+	"backend/internal/repository"
+	"context"
+	"fmt"
+)
+
+type OrderUseCase interface {
+	GetOrderByID(ctx context.Context, id int32) (*entity.Order, error)
+	UpdateOrderStatus(ctx context.Context, id int32, status string) (*entity.Order, error)
+}
+
+// OrderStatusHook is told about every order status change in the transaction
+// that saves it. A failing hook rolls the change back, and the transaction may
+// run again when it fails to serialize, so hooks must only change the
+// database. The ledger entries a hook returns are counted once the change
+// commits.
+type OrderStatusHook interface {
+	OnOrderStatusChanged(ctx context.Context, order *entity.Order, from string) ([]*entity.CoinTransaction, error)
+}
+
+type orderUseCase struct {
+	orderRepo repository.OrderRepository
+	txManager database.TxManager
+	hooks     []OrderStatusHook
+}
+
+func NewOrderUseCase(orderRepo repository.OrderRepository, txManager database.TxManager, hooks ...OrderStatusHook) OrderUseCase {
+	return &orderUseCase{
+		orderRepo: orderRepo,
+		txManager: txManager,
+		hooks:     hooks,
+	}
+}
+
+func (uc *orderUseCase) GetOrderByID(ctx context.Context, id int32) (_ *entity.Order, err error) {
+	ctx, end := startSpan(ctx, "OrderUseCase.GetOrderByID")
+	defer end(&err)
+
+	return uc.orderRepo.GetOrderByID(ctx, id)
+}
+
+func (uc *orderUseCase) UpdateOrderStatus(ctx context.Context, id int32, status string) (_ *entity.Order, err error) {
+	ctx, end := startSpan(ctx, "OrderUseCase.UpdateOrderStatus")
+	defer end(&err)
+
+	switch status {
+	case entity.OrderStatusPending, entity.OrderStatusCompleted, entity.OrderStatusCancelled, entity.OrderStatusRefunded:
+	default:
+		return nil, domain.ErrInvalidOrderStatus
+	}
+
+	var updated *entity.Order
+	var entries []*entity.CoinTransaction
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		entries = nil
+
+		order, err := uc.orderRepo.GetOrderByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if !entity.CanTransitionOrderStatus(order.Status, status) {
+			return domain.ErrInvalidOrderStatusTransition
+		}
+
+		updated, err = uc.orderRepo.UpdateOrderStatus(ctx, id, order.Status, status)
+		if err != nil {
+			return err
+		}
+
+		// Cashback and referral bonuses commit or roll back with the status
+		for _, hook := range uc.hooks {
+			written, err := hook.OnOrderStatusChanged(ctx, updated, order.Status)
+			if err != nil {
+				return fmt.Errorf("order status hook failed: %w", err)
+			}
+			entries = append(entries, written...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.OrderStatusChanges.WithLabelValues(updated.Status).Inc()
+	metrics.ObserveCoinTransactions(entries...)
+
+	return updated, nil
+}
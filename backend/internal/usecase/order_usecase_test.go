@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"backend/internal/domain"
+	"backend/internal/entity"
+	"backend/internal/metrics"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOrderRepository matches your repository interface
+type MockOrderRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrderRepository) GetOrderByID(ctx context.Context, id int32) (*entity.Order, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) UpdateOrderStatus(ctx context.Context, id int32, from, to string) (*entity.Order, error) {
+	args := m.Called(ctx, id, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetOrderCategorySubtotals(ctx context.Context, id int32) ([]entity.OrderCategorySubtotal, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.OrderCategorySubtotal), args.Error(1)
+}
+
+func (m *MockOrderRepository) CountOrdersByStatus(ctx context.Context) (map[string]int, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+// MockOrderStatusHook records the status changes it is told about
+type MockOrderStatusHook struct {
+	mock.Mock
+}
+
+func (m *MockOrderStatusHook) OnOrderStatusChanged(ctx context.Context, order *entity.Order, from string) ([]*entity.CoinTransaction, error) {
+	args := m.Called(ctx, order, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.CoinTransaction), args.Error(1)
+}
+
+// fakeTxManager runs each unit of work directly and counts how the outermost
+// ones ended
+type fakeTxManager struct {
+	depth     int
+	commits   int
+	rollbacks int
+}
+
+func (m *fakeTxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.depth++
+	err := fn(ctx)
+	m.depth--
+	if m.depth == 0 {
+		if err != nil {
+			m.rollbacks++
+		} else {
+			m.commits++
+		}
+	}
+	return err
+}
+
+func (m *fakeTxManager) WithinReadOnlyTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return m.WithinTx(ctx, fn)
+}
+
+func setupOrderUseCase() (OrderUseCase, *MockOrderRepository, *MockOrderStatusHook, *fakeTxManager) {
+	mockRepo := new(MockOrderRepository)
+	mockHook := new(MockOrderStatusHook)
+	txManager := &fakeTxManager{}
+	useCase := NewOrderUseCase(mockRepo, txManager, mockHook)
+	return useCase, mockRepo, mockHook, txManager
+}
+
+func TestUpdateOrderStatus_Success(t *testing.T) {
+	uc, mockRepo, mockHook, txManager := setupOrderUseCase()
+	ctx := callerContext()
+
+	order := &entity.Order{ID: 1, UserID: uuid.New(), Status: entity.OrderStatusPending}
+	completed := &entity.Order{ID: 1, UserID: order.UserID, Status: entity.OrderStatusCompleted}
+
+	mockRepo.On("GetOrderByID", fromCaller, int32(1)).Return(order, nil)
+	mockRepo.On("UpdateOrderStatus", fromCaller, int32(1), entity.OrderStatusPending, entity.OrderStatusCompleted).Return(completed, nil)
+	mockHook.On("OnOrderStatusChanged", fromCaller, completed, entity.OrderStatusPending).Return(nil, nil)
+
+	result, err := uc.UpdateOrderStatus(ctx, 1, entity.OrderStatusCompleted)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entity.OrderStatusCompleted, result.Status)
+	assert.Equal(t, 1, txManager.commits)
+
+	mockRepo.AssertExpectations(t)
+	mockHook.AssertExpectations(t)
+}
+
+func TestUpdateOrderStatus_HookErrorRollsBackStatus(t *testing.T) {
+	uc, mockRepo, mockHook, txManager := setupOrderUseCase()
+	ctx := callerContext()
+
+	order := &entity.Order{ID: 1, Status: entity.OrderStatusCompleted}
+	refunded := &entity.Order{ID: 1, Status: entity.OrderStatusRefunded}
+	hookErr := errors.New("database error")
+
+	mockRepo.On("GetOrderByID", fromCaller, int32(1)).Return(order, nil)
+	mockRepo.On("UpdateOrderStatus", fromCaller, int32(1), entity.OrderStatusCompleted, entity.OrderStatusRefunded).Return(refunded, nil)
+	mockHook.On("OnOrderStatusChanged", fromCaller, refunded, entity.OrderStatusCompleted).Return(nil, hookErr)
+
+	result, err := uc.UpdateOrderStatus(ctx, 1, entity.OrderStatusRefunded)
+
+	assert.ErrorIs(t, err, hookErr)
+	assert.Nil(t, result)
+	assert.Equal(t, 0, txManager.commits)
+	assert.Equal(t, 1, txManager.rollbacks)
+
+	mockHook.AssertExpectations(t)
+}
+
+func TestUpdateOrderStatus_CountsHookEntriesOnCommit(t *testing.T) {
+	uc, mockRepo, mockHook, _ := setupOrderUseCase()
+	ctx := callerContext()
+	cashback := metrics.CoinsCharged.WithLabelValues(entity.TransactionTypeCashback)
+	before := testutil.ToFloat64(cashback)
+
+	order := &entity.Order{ID: 1, Status: entity.OrderStatusPending}
+	completed := &entity.Order{ID: 1, Status: entity.OrderStatusCompleted}
+
+	mockRepo.On("GetOrderByID", fromCaller, int32(1)).Return(order, nil)
+	mockRepo.On("UpdateOrderStatus", fromCaller, int32(1), entity.OrderStatusPending, entity.OrderStatusCompleted).Return(completed, nil)
+	mockHook.On("OnOrderStatusChanged", fromCaller, completed, entity.OrderStatusPending).
+		Return([]*entity.CoinTransaction{{TransactionType: entity.TransactionTypeCashback, Amount: 40}}, nil)
+
+	_, err := uc.UpdateOrderStatus(ctx, 1, entity.OrderStatusCompleted)
+	assert.NoError(t, err)
+	assert.Equal(t, before+40, testutil.ToFloat64(cashback))
+
+	assert.Equal(t, before+40, testutil.ToFloat64(cashback))
+}
+
+func TestUpdateOrderStatus_RolledBackHookEntriesAreNotCounted(t *testing.T) {
+	mockRepo := new(MockOrderRepository)
+	paying, failing := new(MockOrderStatusHook), new(MockOrderStatusHook)
+	uc := NewOrderUseCase(mockRepo, &fakeTxManager{}, paying, failing)
+	ctx := callerContext()
+	cashback := metrics.CoinsCharged.WithLabelValues(entity.TransactionTypeCashback)
+	before := testutil.ToFloat64(cashback)
+
+	order := &entity.Order{ID: 1, Status: entity.OrderStatusPending}
+	completed := &entity.Order{ID: 1, Status: entity.OrderStatusCompleted}
+
+	mockRepo.On("GetOrderByID", fromCaller, int32(1)).Return(order, nil)
+	mockRepo.On("UpdateOrderStatus", fromCaller, int32(1), entity.OrderStatusPending, entity.OrderStatusCompleted).Return(completed, nil)
+	paying.On("OnOrderStatusChanged", fromCaller, completed, entity.OrderStatusPending).
+		Return([]*entity.CoinTransaction{{TransactionType: entity.TransactionTypeCashback, Amount: 40}}, nil)
+	failing.On("OnOrderStatusChanged", fromCaller, completed, entity.OrderStatusPending).Return(nil, errors.New("database error"))
+
+	_, err := uc.UpdateOrderStatus(ctx, 1, entity.OrderStatusCompleted)
+
+	assert.Error(t, err)
+	assert.Equal(t, before, testutil.ToFloat64(cashback))
+}
+
+func TestUpdateOrderStatus_InvalidStatus(t *testing.T) {
+	uc, mockRepo, _, _ := setupOrderUseCase()
+	ctx := callerContext()
+
+	result, err := uc.UpdateOrderStatus(ctx, 1, "shipped")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "invalid order status", err.Error())
+
+	mockRepo.AssertNotCalled(t, "GetOrderByID", mock.Anything, mock.Anything)
+}
+
+func TestUpdateOrderStatus_InvalidTransition(t *testing.T) {
+	uc, mockRepo, mockHook, _ := setupOrderUseCase()
+	ctx := callerContext()
+
+	mockRepo.On("GetOrderByID", fromCaller, int32(1)).Return(&entity.Order{ID: 1, Status: entity.OrderStatusCancelled}, nil)
+
+	result, err := uc.UpdateOrderStatus(ctx, 1, entity.OrderStatusCompleted)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "invalid order status transition", err.Error())
+
+	mockRepo.AssertNotCalled(t, "UpdateOrderStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockHook.AssertNotCalled(t, "OnOrderStatusChanged", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateOrderStatus_ConcurrentChange(t *testing.T) {
+	uc, mockRepo, mockHook, _ := setupOrderUseCase()
+	ctx := callerContext()
+
+	mockRepo.On("GetOrderByID", fromCaller, int32(1)).Return(&entity.Order{ID: 1, Status: entity.OrderStatusPending}, nil)
+	mockRepo.On("UpdateOrderStatus", fromCaller, int32(1), entity.OrderStatusPending, entity.OrderStatusCompleted).Return(nil, domain.ErrOrderStatusChanged)
+
+	result, err := uc.UpdateOrderStatus(ctx, 1, entity.OrderStatusCompleted)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "order status has changed", err.Error())
+
+	mockHook.AssertNotCalled(t, "OnOrderStatusChanged", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -15,18 +15,30 @@ func NewProductUseCase(r repository.ProductRepository) *ProductUseCase {
 	return &ProductUseCase{repo: r}
 }
 
-func (u *ProductUseCase) GetAllProducts(ctx context.Context, page, limit int) ([]entity.Product, error) {
+func (u *ProductUseCase) GetAllProducts(ctx context.Context, page, limit int) (_ []entity.Product, err error) {
+	ctx, end := startSpan(ctx, "ProductUseCase.GetAllProducts")
+	defer end(&err)
+
 	return u.repo.GetAllProducts(ctx, page, limit)
 }
 
-func (u *ProductUseCase) GetProductByID(ctx context.Context, id int) (*entity.Product, error) {
+func (u *ProductUseCase) GetProductByID(ctx context.Context, id int) (_ *entity.Product, err error) {
+	ctx, end := startSpan(ctx, "ProductUseCase.GetProductByID")
+	defer end(&err)
+
 	return u.repo.GetProductByID(ctx, id)
 }
 
-func (u *ProductUseCase) GetProductsByCategory(ctx context.Context, categoryID, page, limit int) ([]entity.Product, error) {
+func (u *ProductUseCase) GetProductsByCategory(ctx context.Context, categoryID, page, limit int) (_ []entity.Product, err error) {
+	ctx, end := startSpan(ctx, "ProductUseCase.GetProductsByCategory")
+	defer end(&err)
+
 	return u.repo.GetProductsByCategory(ctx, categoryID, page, limit)
 }
 
-func (u *ProductUseCase) UpdateStock(ctx context.Context, productID, newStock int) error {
+func (u *ProductUseCase) UpdateStock(ctx context.Context, productID, newStock int) (err error) {
+	ctx, end := startSpan(ctx, "ProductUseCase.UpdateStock")
+	defer end(&err)
+
 	return u.repo.UpdateStock(ctx, productID, newStock)
 }
@@ -1,8 +1,8 @@
 package usecase
 
 import (
+	"backend/internal/domain"
 	"context"
-	"errors"
 	"testing"
 
 	"backend/internal/entity"
@@ -67,9 +67,9 @@ func TestGetAllProducts(t *testing.T) {
 		sampleProduct(2, "Banana"),
 	}
 
-	mockRepo.On("GetAllProducts", mock.Anything, 1, 10).Return(expectedProducts, nil)
+	mockRepo.On("GetAllProducts", fromCaller, 1, 10).Return(expectedProducts, nil)
 
-	products, err := uc.GetAllProducts(context.Background(), 1, 10)
+	products, err := uc.GetAllProducts(callerContext(), 1, 10)
 
 	assert.NoError(t, err)
 	assert.Len(t, products, 2)
@@ -84,9 +84,9 @@ func TestGetProductByID_Found(t *testing.T) {
 	uc := NewProductUseCase(mockRepo)
 
 	expectedProduct := sampleProduct(1, "Apple")
-	mockRepo.On("GetProductByID", mock.Anything, 1).Return(&expectedProduct, nil)
+	mockRepo.On("GetProductByID", fromCaller, 1).Return(&expectedProduct, nil)
 
-	product, err := uc.GetProductByID(context.Background(), 1)
+	product, err := uc.GetProductByID(callerContext(), 1)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, product)
@@ -100,9 +100,9 @@ func TestGetProductByID_NotFound(t *testing.T) {
 	mockRepo := new(MockProductRepository)
 	uc := NewProductUseCase(mockRepo)
 
-	mockRepo.On("GetProductByID", mock.Anything, 999).Return(nil, errors.New("product not found"))
+	mockRepo.On("GetProductByID", fromCaller, 999).Return(nil, domain.ErrProductNotFound)
 
-	product, err := uc.GetProductByID(context.Background(), 999)
+	product, err := uc.GetProductByID(callerContext(), 999)
 
 	assert.Error(t, err)
 	assert.Nil(t, product)
@@ -120,9 +120,9 @@ func TestGetProductsByCategory(t *testing.T) {
 		sampleProduct(2, "MacBook"),
 	}
 
-	mockRepo.On("GetProductsByCategory", mock.Anything, 1, 1, 10).Return(expectedProducts, nil)
+	mockRepo.On("GetProductsByCategory", fromCaller, 1, 1, 10).Return(expectedProducts, nil)
 
-	products, err := uc.GetProductsByCategory(context.Background(), 1, 1, 10)
+	products, err := uc.GetProductsByCategory(callerContext(), 1, 1, 10)
 
 	assert.NoError(t, err)
 	assert.Len(t, products, 2)
@@ -136,9 +136,9 @@ func TestUpdateStock(t *testing.T) {
 	mockRepo := new(MockProductRepository)
 	uc := NewProductUseCase(mockRepo)
 
-	mockRepo.On("UpdateStock", mock.Anything, 1, 5).Return(nil)
+	mockRepo.On("UpdateStock", fromCaller, 1, 5).Return(nil)
 
-	err := uc.UpdateStock(context.Background(), 1, 5)
+	err := uc.UpdateStock(callerContext(), 1, 5)
 
 	assert.NoError(t, err)
 
@@ -149,9 +149,9 @@ func TestUpdateStock_Error(t *testing.T) {
 	mockRepo := new(MockProductRepository)
 	uc := NewProductUseCase(mockRepo)
 
-	mockRepo.On("UpdateStock", mock.Anything, 999, 5).Return(errors.New("product not found"))
+	mockRepo.On("UpdateStock", fromCaller, 999, 5).Return(domain.ErrProductNotFound)
 
-	err := uc.UpdateStock(context.Background(), 999, 5)
+	err := uc.UpdateStock(callerContext(), 999, 5)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "not found")
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"backend/internal/database"
+	"backend/internal/entity"
+	"backend/internal/repository"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ReferralUseCase interface {
+	OrderStatusHook
+	GetUserReferrals(ctx context.Context, userID uuid.UUID, page, limit int32) ([]*entity.Referral, error)
+}
+
+type referralUseCase struct {
+	transactionRepo repository.CoinTransactionRepository
+	referralRepo    repository.ReferralRepository
+	txManager       database.TxManager
+	policy          entity.ReferralPolicy
+}
+
+func NewReferralUseCase(transactionRepo repository.CoinTransactionRepository, referralRepo repository.ReferralRepository, txManager database.TxManager, policy entity.ReferralPolicy) ReferralUseCase {
+	return &referralUseCase{
+		transactionRepo: transactionRepo,
+		referralRepo:    referralRepo,
+		txManager:       txManager,
+		policy:          policy,
+	}
+}
+
+// OnOrderStatusChanged pays out the buyer's referral when an order completes.
+// Only a pending referral pays, so this happens on the first completed order.
+func (uc *referralUseCase) OnOrderStatusChanged(ctx context.Context, order *entity.Order, from string) (_ []*entity.CoinTransaction, err error) {
+	ctx, end := startSpan(ctx, "ReferralUseCase.OnOrderStatusChanged")
+	defer end(&err)
+
+	if order.Status != entity.OrderStatusCompleted {
+		return nil, nil
+	}
+
+	if uc.policy.ReferrerBonus <= 0 && uc.policy.RefereeBonus <= 0 {
+		return nil, nil
+	}
+
+	// The pending referral is looked up in the transaction that pays it
+	var bonuses []*entity.CoinTransaction
+	err = uc.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		_, bonuses, err = uc.transactionRepo.GrantReferralBonuses(ctx, order.UserID, uc.policy)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bonuses, nil
+}
+
+func (uc *referralUseCase) GetUserReferrals(ctx context.Context, userID uuid.UUID, page, limit int32) (_ []*entity.Referral, err error) {
+	ctx, end := startSpan(ctx, "ReferralUseCase.GetUserReferrals")
+	defer end(&err)
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	return uc.referralRepo.GetReferralsByReferrerID(ctx, userID, limit, (page-1)*limit)
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"backend/internal/entity"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReferralRepository matches your repository interface
+type MockReferralRepository struct {
+	mock.Mock
+}
+
+func (m *MockReferralRepository) GetReferralsByReferrerID(ctx context.Context, referrerID uuid.UUID, limit, offset int32) ([]*entity.Referral, error) {
+	args := m.Called(ctx, referrerID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Referral), args.Error(1)
+}
+
+func (m *MockReferralRepository) CreateReferral(ctx context.Context, referrerID, refereeID uuid.UUID, rejectionReason string) (*entity.Referral, error) {
+	args := m.Called(ctx, referrerID, refereeID, rejectionReason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Referral), args.Error(1)
+}
+
+func (m *MockReferralRepository) RecordSecurityEvent(ctx context.Context, userID uuid.UUID, eventType, details string) error {
+	args := m.Called(ctx, userID, eventType, details)
+	return args.Error(0)
+}
+
+var testReferralPolicy = entity.ReferralPolicy{ReferrerBonus: 500, RefereeBonus: 300}
+
+func setupReferralUseCase(policy entity.ReferralPolicy) (ReferralUseCase, *MockCoinTransactionRepository, *MockReferralRepository) {
+	mockTransactionRepo := new(MockCoinTransactionRepository)
+	mockReferralRepo := new(MockReferralRepository)
+	useCase := NewReferralUseCase(mockTransactionRepo, mockReferralRepo, &fakeTxManager{}, policy)
+	return useCase, mockTransactionRepo, mockReferralRepo
+}
+
+func TestReferralOnCompleted_GrantsBonuses(t *testing.T) {
+	uc, mockTransactionRepo, _ := setupReferralUseCase(testReferralPolicy)
+	ctx := callerContext()
+
+	order := &entity.Order{ID: 1, UserID: uuid.New(), Status: entity.OrderStatusCompleted}
+	rewardedAt := time.Now()
+
+	mockTransactionRepo.On("GrantReferralBonuses", fromCaller, order.UserID, testReferralPolicy).Return(&entity.Referral{
+		ID:         1,
+		RefereeID:  order.UserID,
+		Status:     entity.ReferralStatusRewarded,
+		RewardedAt: &rewardedAt,
+	}, []*entity.CoinTransaction{
+		{TransactionType: entity.TransactionTypeReferral, Amount: 500},
+		{TransactionType: entity.TransactionTypeReferral, Amount: 300},
+	}, nil)
+
+	bonuses, err := uc.OnOrderStatusChanged(ctx, order, entity.OrderStatusPending)
+
+	assert.NoError(t, err)
+	assert.Len(t, bonuses, 2)
+
+	mockTransactionRepo.AssertExpectations(t)
+}
+
+func TestReferralOnCompleted_NoPendingReferral(t *testing.T) {
+	uc, mockTransactionRepo, _ := setupReferralUseCase(testReferralPolicy)
+	ctx := callerContext()
+
+	order := &entity.Order{ID: 2, UserID: uuid.New(), Status: entity.OrderStatusCompleted}
+
+	mockTransactionRepo.On("GrantReferralBonuses", fromCaller, order.UserID, testReferralPolicy).Return(nil, nil, nil)
+
+	_, err := uc.OnOrderStatusChanged(ctx, order, entity.OrderStatusPending)
+
+	assert.NoError(t, err)
+
+	mockTransactionRepo.AssertExpectations(t)
+}
+
+func TestReferralOnCompleted_RepositoryError(t *testing.T) {
+	uc, mockTransactionRepo, _ := setupReferralUseCase(testReferralPolicy)
+	ctx := callerContext()
+
+	order := &entity.Order{ID: 1, UserID: uuid.New(), Status: entity.OrderStatusCompleted}
+
+	mockTransactionRepo.On("GrantReferralBonuses", fromCaller, order.UserID, testReferralPolicy).Return(nil, nil, errors.New("database error"))
+
+	_, err := uc.OnOrderStatusChanged(ctx, order, entity.OrderStatusPending)
+
+	assert.Error(t, err)
+}
+
+func TestReferralOnRefunded_Ignored(t *testing.T) {
+	uc, mockTransactionRepo, _ := setupReferralUseCase(testReferralPolicy)
+	ctx := callerContext()
+
+	order := &entity.Order{ID: 1, UserID: uuid.New(), Status: entity.OrderStatusRefunded}
+
+	_, err := uc.OnOrderStatusChanged(ctx, order, entity.OrderStatusCompleted)
+
+	assert.NoError(t, err)
+
+	mockTransactionRepo.AssertNotCalled(t, "GrantReferralBonuses", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReferralOnCompleted_BonusesDisabled(t *testing.T) {
+	uc, mockTransactionRepo, _ := setupReferralUseCase(entity.ReferralPolicy{})
+	ctx := callerContext()
+
+	order := &entity.Order{ID: 1, UserID: uuid.New(), Status: entity.OrderStatusCompleted}
+
+	_, err := uc.OnOrderStatusChanged(ctx, order, entity.OrderStatusPending)
+
+	assert.NoError(t, err)
+
+	mockTransactionRepo.AssertNotCalled(t, "GrantReferralBonuses", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetUserReferrals_DefaultPaging(t *testing.T) {
+	uc, _, mockReferralRepo := setupReferralUseCase(testReferralPolicy)
+	ctx := callerContext()
+
+	userID := uuid.New()
+	expected := []*entity.Referral{{ID: 1, ReferrerID: userID, Status: entity.ReferralStatusPending}}
+
+	mockReferralRepo.On("GetReferralsByReferrerID", fromCaller, userID, int32(20), int32(0)).Return(expected, nil)
+
+	referrals, err := uc.GetUserReferrals(ctx, userID, 0, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, referrals, 1)
+
+	mockReferralRepo.AssertExpectations(t)
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"backend/internal/domain"
+	"backend/internal/entity"
+	"backend/internal/repository"
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type SpendLimitUseCase interface {
+	GetUserSpendLimits(ctx context.Context, userID uuid.UUID) (*entity.SpendLimits, error)
+	SetUserSpendLimits(ctx context.Context, userID uuid.UUID, req entity.UpdateSpendLimitRequest) (*entity.SpendLimits, error)
+	ClearSpendingBlock(ctx context.Context, userID uuid.UUID) (*entity.SpendLimits, error)
+	GetSecurityEvents(ctx context.Context, userID uuid.UUID) ([]*entity.SecurityEvent, error)
+}
+
+// securityEventListLimit caps how many recent events are returned for a user
+const securityEventListLimit = 100
+
+type spendLimitUseCase struct {
+	spendLimitRepo repository.SpendLimitRepository
+	policy         entity.SpendLimitPolicy
+}
+
+func NewSpendLimitUseCase(spendLimitRepo repository.SpendLimitRepository, policy entity.SpendLimitPolicy) SpendLimitUseCase {
+	return &spendLimitUseCase{
+		spendLimitRepo: spendLimitRepo,
+		policy:         policy,
+	}
+}
+
+// GetUserSpendLimits returns the limits in effect for the user, defaults included
+func (uc *spendLimitUseCase) GetUserSpendLimits(ctx context.Context, userID uuid.UUID) (_ *entity.SpendLimits, err error) {
+	ctx, end := startSpan(ctx, "SpendLimitUseCase.GetUserSpendLimits")
+	defer end(&err)
+
+	override, err := uc.spendLimitRepo.GetUserSpendLimit(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := uc.policy.For(override)
+	return &limits, nil
+}
+
+// SetUserSpendLimits replaces the user's override. A nil limit in req goes
+// back to the default.
+func (uc *spendLimitUseCase) SetUserSpendLimits(ctx context.Context, userID uuid.UUID, req entity.UpdateSpendLimitRequest) (_ *entity.SpendLimits, err error) {
+	ctx, end := startSpan(ctx, "SpendLimitUseCase.SetUserSpendLimits")
+	defer end(&err)
+
+	if req.DailyLimit != nil && *req.DailyLimit < 0 {
+		return nil, domain.ErrDailyLimitNegative
+	}
+	if req.PerTransactionLimit != nil && *req.PerTransactionLimit < 0 {
+		return nil, domain.ErrPerTransactionLimitNegative
+	}
+
+	override, err := uc.spendLimitRepo.SetUserSpendLimit(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := uc.policy.For(override)
+	return &limits, nil
+}
+
+// ClearSpendingBlock lifts a velocity block before it runs out. Clearing is
+// recorded as a security event, next to the event that set the block.
+func (uc *spendLimitUseCase) ClearSpendingBlock(ctx context.Context, userID uuid.UUID) (_ *entity.SpendLimits, err error) {
+	ctx, end := startSpan(ctx, "SpendLimitUseCase.ClearSpendingBlock")
+	defer end(&err)
+
+	if _, err := uc.spendLimitRepo.UnblockSpending(ctx, userID, entity.SecurityEventSpendUnblocked, "cleared by an admin"); err != nil {
+		return nil, err
+	}
+
+	override, err := uc.spendLimitRepo.GetUserSpendLimit(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := uc.policy.For(override)
+	return &limits, nil
+}
+
+func (uc *spendLimitUseCase) GetSecurityEvents(ctx context.Context, userID uuid.UUID) (_ []*entity.SecurityEvent, err error) {
+	ctx, end := startSpan(ctx, "SpendLimitUseCase.GetSecurityEvents")
+	defer end(&err)
+
+	return uc.spendLimitRepo.GetSecurityEvents(ctx, userID, securityEventListLimit)
+}
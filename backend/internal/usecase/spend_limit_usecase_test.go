@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"backend/internal/entity"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSpendLimitRepository matches your repository interface
+type MockSpendLimitRepository struct {
+	mock.Mock
+}
+
+func (m *MockSpendLimitRepository) GetUserSpendLimit(ctx context.Context, userID uuid.UUID) (*entity.UserSpendLimit, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.UserSpendLimit), args.Error(1)
+}
+
+func (m *MockSpendLimitRepository) SetUserSpendLimit(ctx context.Context, userID uuid.UUID, req entity.UpdateSpendLimitRequest) (*entity.UserSpendLimit, error) {
+	args := m.Called(ctx, userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.UserSpendLimit), args.Error(1)
+}
+
+func (m *MockSpendLimitRepository) GetSpendActivity(ctx context.Context, userID uuid.UUID, spentSince, countedSince time.Time) (*entity.SpendActivity, error) {
+	args := m.Called(ctx, userID, spentSince, countedSince)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.SpendActivity), args.Error(1)
+}
+
+func (m *MockSpendLimitRepository) BlockSpending(ctx context.Context, userID uuid.UUID, until time.Time, eventType, details string) error {
+	args := m.Called(ctx, userID, until, eventType, details)
+	return args.Error(0)
+}
+
+func (m *MockSpendLimitRepository) UnblockSpending(ctx context.Context, userID uuid.UUID, eventType, details string) (bool, error) {
+	args := m.Called(ctx, userID, eventType, details)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockSpendLimitRepository) LockUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockSpendLimitRepository) GetSecurityEvents(ctx context.Context, userID uuid.UUID, limit int32) ([]*entity.SecurityEvent, error) {
+	args := m.Called(ctx, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.SecurityEvent), args.Error(1)
+}
+
+func setupSpendLimitUseCase(policy entity.SpendLimitPolicy) (SpendLimitUseCase, *MockSpendLimitRepository) {
+	mockRepo := new(MockSpendLimitRepository)
+	useCase := NewSpendLimitUseCase(mockRepo, policy)
+	return useCase, mockRepo
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestGetUserSpendLimits_Defaults(t *testing.T) {
+	uc, mockRepo := setupSpendLimitUseCase(entity.SpendLimitPolicy{DailyLimit: 5000, PerTransactionLimit: 1000})
+	ctx := callerContext()
+
+	userID := uuid.New()
+
+	mockRepo.On("GetUserSpendLimit", fromCaller, userID).Return(nil, nil)
+
+	limits, err := uc.GetUserSpendLimits(ctx, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5000, limits.DailyLimit)
+	assert.Equal(t, 1000, limits.PerTransactionLimit)
+	assert.Nil(t, limits.SpendingBlockedUntil)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetUserSpendLimits_Override(t *testing.T) {
+	uc, mockRepo := setupSpendLimitUseCase(entity.SpendLimitPolicy{DailyLimit: 5000, PerTransactionLimit: 1000})
+	ctx := callerContext()
+
+	userID := uuid.New()
+	blockedUntil := time.Now().Add(time.Hour)
+
+	mockRepo.On("GetUserSpendLimit", fromCaller, userID).Return(&entity.UserSpendLimit{
+		UserID:               userID,
+		DailyLimit:           intPtr(20000),
+		SpendingBlockedUntil: &blockedUntil,
+	}, nil)
+
+	limits, err := uc.GetUserSpendLimits(ctx, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 20000, limits.DailyLimit)
+	assert.Equal(t, 1000, limits.PerTransactionLimit)
+	assert.Equal(t, &blockedUntil, limits.SpendingBlockedUntil)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestClearSpendingBlock_Success(t *testing.T) {
+	uc, mockRepo := setupSpendLimitUseCase(entity.SpendLimitPolicy{DailyLimit: 5000})
+	ctx := callerContext()
+
+	userID := uuid.New()
+
+	mockRepo.On("UnblockSpending", fromCaller, userID, entity.SecurityEventSpendUnblocked, "cleared by an admin").Return(true, nil)
+	mockRepo.On("GetUserSpendLimit", fromCaller, userID).Return(&entity.UserSpendLimit{UserID: userID}, nil)
+
+	limits, err := uc.ClearSpendingBlock(ctx, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5000, limits.DailyLimit)
+	assert.Nil(t, limits.SpendingBlockedUntil)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestClearSpendingBlock_Error(t *testing.T) {
+	uc, mockRepo := setupSpendLimitUseCase(entity.SpendLimitPolicy{})
+	ctx := callerContext()
+
+	userID := uuid.New()
+
+	mockRepo.On("UnblockSpending", fromCaller, userID, entity.SecurityEventSpendUnblocked, "cleared by an admin").Return(false, errors.New("database error"))
+
+	limits, err := uc.ClearSpendingBlock(ctx, userID)
+
+	assert.Error(t, err)
+	assert.Nil(t, limits)
+
+	mockRepo.AssertNotCalled(t, "GetUserSpendLimit", mock.Anything, mock.Anything)
+}
+
+func TestSetUserSpendLimits_Success(t *testing.T) {
+	uc, mockRepo := setupSpendLimitUseCase(entity.SpendLimitPolicy{DailyLimit: 5000, PerTransactionLimit: 1000})
+	ctx := callerContext()
+
+	userID := uuid.New()
+	req := entity.UpdateSpendLimitRequest{PerTransactionLimit: intPtr(3000)}
+
+	mockRepo.On("SetUserSpendLimit", fromCaller, userID, req).Return(&entity.UserSpendLimit{
+		UserID:              userID,
+		PerTransactionLimit: intPtr(3000),
+	}, nil)
+
+	limits, err := uc.SetUserSpendLimits(ctx, userID, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5000, limits.DailyLimit)
+	assert.Equal(t, 3000, limits.PerTransactionLimit)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSetUserSpendLimits_NegativeLimit(t *testing.T) {
+	uc, mockRepo := setupSpendLimitUseCase(entity.SpendLimitPolicy{})
+	ctx := callerContext()
+
+	limits, err := uc.SetUserSpendLimits(ctx, uuid.New(), entity.UpdateSpendLimitRequest{DailyLimit: intPtr(-1)})
+
+	assert.Error(t, err)
+	assert.Nil(t, limits)
+	assert.Equal(t, "daily limit cannot be negative", err.Error())
+
+	mockRepo.AssertNotCalled(t, "SetUserSpendLimit", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSetUserSpendLimits_RepositoryError(t *testing.T) {
+	uc, mockRepo := setupSpendLimitUseCase(entity.SpendLimitPolicy{})
+	ctx := callerContext()
+
+	userID := uuid.New()
+	req := entity.UpdateSpendLimitRequest{DailyLimit: intPtr(100)}
+
+	mockRepo.On("SetUserSpendLimit", fromCaller, userID, req).Return(nil, errors.New("database error"))
+
+	limits, err := uc.SetUserSpendLimits(ctx, userID, req)
+
+	assert.Error(t, err)
+	assert.Nil(t, limits)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetSecurityEvents_Success(t *testing.T) {
+	uc, mockRepo := setupSpendLimitUseCase(entity.SpendLimitPolicy{})
+	ctx := callerContext()
+
+	userID := uuid.New()
+	expectedEvents := []*entity.SecurityEvent{
+		{ID: 1, UserID: userID, EventType: entity.SecurityEventSpendVelocity, CreatedAt: time.Now()},
+	}
+
+	mockRepo.On("GetSecurityEvents", fromCaller, userID, int32(securityEventListLimit)).Return(expectedEvents, nil)
+
+	events, err := uc.GetSecurityEvents(ctx, userID)
+
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+
+	"backend/internal/logging"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+var tracer = otel.Tracer("backend/internal/usecase")
+
+// startSpan starts the span for a use case call, named Type.Method and
+// tagged with the authenticated user when there is one. The returned end
+// ends the span, marking it failed with *errp when that is not nil; use
+// cases defer it with their named error result.
+func startSpan(ctx context.Context, name string) (context.Context, func(errp *error)) {
+	ctx, span := tracer.Start(ctx, name)
+	if userID := logging.UserID(ctx); userID != "" {
+		span.SetAttributes(semconv.EnduserID(userID))
+	}
+
+	return ctx, func(errp *error) {
+		if errp != nil && *errp != nil {
+			span.RecordError(*errp)
+			span.SetStatus(codes.Error, (*errp).Error())
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"backend/internal/domain"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordSpans points the use case tracer at a recorder for the test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previous := tracer
+	tracer = provider.Tracer("backend/internal/usecase")
+	t.Cleanup(func() { tracer = previous })
+	return recorder
+}
+
+func TestStartSpan_EndsWithoutError(t *testing.T) {
+	recorder := recordSpans(t)
+
+	var err error
+	_, end := startSpan(context.Background(), "Test.Succeeds")
+	end(&err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "Test.Succeeds", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Empty(t, spans[0].Events())
+}
+
+func TestStartSpan_RecordsReturnedError(t *testing.T) {
+	recorder := recordSpans(t)
+	uc, _, _, _ := setupOrderUseCase()
+
+	_, err := uc.UpdateOrderStatus(context.Background(), 1, "shipped")
+	require.ErrorIs(t, err, domain.ErrInvalidOrderStatus)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "OrderUseCase.UpdateOrderStatus", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, domain.ErrInvalidOrderStatus.Error(), spans[0].Status().Description)
+	require.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, "exception", spans[0].Events()[0].Name)
+}
+
+type callerKey struct{}
+
+// callerContext is the context tests pass to use cases. Repositories must get
+// it, or a context derived from it such as the use case's span, so the
+// caller's deadline, values and transaction reach them.
+func callerContext() context.Context {
+	return context.WithValue(context.Background(), callerKey{}, true)
+}
+
+// fromCaller matches a context derived from callerContext
+var fromCaller = mock.MatchedBy(func(ctx context.Context) bool {
+	fromCaller, _ := ctx.Value(callerKey{}).(bool)
+	return fromCaller
+})
@@ -1,99 +1,181 @@
 package usecase
 
 import (
+	"backend/internal/database"
+	"backend/internal/domain"
 	"backend/internal/entity"
+	"backend/internal/metrics"
 	"backend/internal/repository"
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type UserUseCase struct {
-	repo repository.UserRepository
+	repo         repository.UserRepository
+	referralRepo repository.ReferralRepository
+	txManager    database.TxManager
 }
 
-func NewUserUseCase(r repository.UserRepository) *UserUseCase {
+func NewUserUseCase(r repository.UserRepository, referralRepo repository.ReferralRepository, txManager database.TxManager) *UserUseCase {
 	return &UserUseCase{
-		repo: r,
+		repo:         r,
+		referralRepo: referralRepo,
+		txManager:    txManager,
 	}
 }
 
-func (u *UserUseCase) GetUserById(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+func (u *UserUseCase) GetUserById(ctx context.Context, id uuid.UUID) (_ *entity.User, err error) {
+	ctx, end := startSpan(ctx, "UserUseCase.GetUserById")
+	defer end(&err)
+
 	return u.repo.GetUserById(ctx, id)
 }
 
-func (u *UserUseCase) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+func (u *UserUseCase) GetUserByEmail(ctx context.Context, email string) (_ *entity.User, err error) {
+	ctx, end := startSpan(ctx, "UserUseCase.GetUserByEmail")
+	defer end(&err)
+
 	return u.repo.GetUserByEmail(ctx, email)
 }
 
-func (u *UserUseCase) SignUp(ctx context.Context, req entity.CreateUserRequest) (*entity.User, error) {
+func (u *UserUseCase) SignUp(ctx context.Context, req entity.CreateUserRequest) (_ *entity.User, err error) {
+	ctx, end := startSpan(ctx, "UserUseCase.SignUp")
+	defer end(&err)
+
 	if req.Name == "" {
-		return nil, errors.New("name is required")
+		return nil, domain.ErrNameRequired
 	}
 	if req.Email == "" {
-		return nil, errors.New("email is required")
+		return nil, domain.ErrEmailRequired
 	}
 	if len(req.Password) < 8 {
-		return nil, errors.New("password must be at least 8 characters")
+		return nil, domain.ErrPasswordTooShort
+	}
+
+	var user *entity.User
+	err = u.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		if req.ReferralCode == "" {
+			var err error
+			user, err = u.repo.CreateUser(ctx, req)
+			return err
+		}
+
+		referrer, err := u.repo.GetUserByReferralCode(ctx, strings.ToUpper(strings.TrimSpace(req.ReferralCode)))
+		if err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				return domain.ErrInvalidReferralCode
+			}
+			return err
+		}
+
+		normalizedEmail := entity.NormalizeEmail(req.Email)
+		if err := u.repo.LockNormalizedEmail(ctx, normalizedEmail); err != nil {
+			return err
+		}
+
+		// Counted before the insert so the new user is not among them
+		existingAccounts, err := u.repo.CountUsersByNormalizedEmail(ctx, normalizedEmail)
+		if err != nil {
+			return err
+		}
+
+		user, err = u.repo.CreateUser(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		return u.createReferral(ctx, referrer, user, existingAccounts)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return u.repo.CreateUser(ctx, req)
+	// The sign-up bonus is the new user's whole balance
+	if user.Coins > 0 {
+		metrics.CoinsCharged.WithLabelValues(entity.TransactionTypeBonus).Add(float64(user.Coins))
+	}
+
+	return user, nil
 }
 
-func (u *UserUseCase) Login(ctx context.Context, email, password string) (*entity.User, error) {
+// createReferral saves the referral of referee by referrer. One that looks
+// like self-referral or a second account is still saved, but as rejected, and
+// flagged as a security event.
+func (u *UserUseCase) createReferral(ctx context.Context, referrer, referee *entity.User, existingAccounts int) error {
+	reason := entity.ReferralRejectionReason(entity.NormalizeEmail(referrer.Email), entity.NormalizeEmail(referee.Email), existingAccounts)
+
+	if _, err := u.referralRepo.CreateReferral(ctx, referrer.ID, referee.ID, reason); err != nil {
+		return err
+	}
+
+	if reason == "" {
+		return nil
+	}
+
+	return u.referralRepo.RecordSecurityEvent(ctx, referee.ID, entity.SecurityEventReferralRejected,
+		fmt.Sprintf("%s: referred by %s", reason, referrer.ID))
+}
+
+func (u *UserUseCase) Login(ctx context.Context, email, password string) (_ *entity.User, err error) {
+	ctx, end := startSpan(ctx, "UserUseCase.Login")
+	defer end(&err)
+
 	if email == "" {
-		return nil, errors.New("email is required")
+		return nil, domain.ErrEmailRequired
 	}
 	if password == "" {
-		return nil, errors.New("password is required")
+		return nil, domain.ErrPasswordRequired
 	}
 
 	user, err := u.repo.GetUserByEmail(ctx, email)
 	if err != nil {
-		return nil, errors.New("invalid credentials")
+		if errors.Is(err, domain.ErrUserNotFound) {
+			metrics.LoginFailures.Inc()
+			return nil, domain.ErrInvalidCredentials
+		}
+		return nil, err
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
 	if err != nil {
-		return nil, errors.New("invalid credentials")
+		metrics.LoginFailures.Inc()
+		return nil, domain.ErrInvalidCredentials
 	}
 
 	return user, nil
 }
 
-func (u *UserUseCase) UpdateUserName(ctx context.Context, id uuid.UUID, name string) (*entity.User, error) {
+func (u *UserUseCase) UpdateUserName(ctx context.Context, id uuid.UUID, name string) (_ *entity.User, err error) {
+	ctx, end := startSpan(ctx, "UserUseCase.UpdateUserName")
+	defer end(&err)
+
 	if name == "" {
-		return nil, errors.New("name cannot be empty")
+		return nil, domain.ErrNameEmpty
 	}
 	return u.repo.UpdateUserName(ctx, id, name)
 }
 
-func (u *UserUseCase) UpdateUserEmail(ctx context.Context, id uuid.UUID, email string) (*entity.User, error) {
+func (u *UserUseCase) UpdateUserEmail(ctx context.Context, id uuid.UUID, email string) (_ *entity.User, err error) {
+	ctx, end := startSpan(ctx, "UserUseCase.UpdateUserEmail")
+	defer end(&err)
+
 	if email == "" {
-		return nil, errors.New("email cannot be empty")
+		return nil, domain.ErrEmailEmpty
 	}
 	return u.repo.UpdateUserEmail(ctx, id, email)
 }
 
-func (u *UserUseCase) UpdateUserCoins(ctx context.Context, id uuid.UUID, coinsDelta int) (*entity.User, error) {
-	user, err := u.repo.GetUserById(ctx, id)
-	if err != nil {
-		return nil, err
-	}
-
-	newBalance := user.Coins + coinsDelta
-	if newBalance < 0 {
-		return nil, errors.New("insufficient coins")
-	}
+func (u *UserUseCase) ChangePassword(ctx context.Context, id uuid.UUID, currentPassword, newPassword string) (err error) {
+	ctx, end := startSpan(ctx, "UserUseCase.ChangePassword")
+	defer end(&err)
 
-	return u.repo.UpdateUserCoins(ctx, id, coinsDelta)
-}
-
-func (u *UserUseCase) ChangePassword(ctx context.Context, id uuid.UUID, currentPassword, newPassword string) error {
 	if len(newPassword) < 8 {
-		return errors.New("new password must be at least 8 characters")
+		return domain.ErrNewPasswordTooShort
 	}
 
 	user, err := u.repo.GetUserById(ctx, id)
@@ -103,24 +185,22 @@ func (u *UserUseCase) ChangePassword(ctx context.Context, id uuid.UUID, currentP
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(currentPassword))
 	if err != nil {
-		return errors.New("current password is incorrect")
+		return domain.ErrCurrentPasswordIncorrect
 	}
 
 	return u.repo.UpdateUserPassword(ctx, id, newPassword)
 }
 
-func (u *UserUseCase) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	return u.repo.DeleteUser(ctx, id)
-}
+func (u *UserUseCase) DeleteUser(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, end := startSpan(ctx, "UserUseCase.DeleteUser")
+	defer end(&err)
 
-func (u *UserUseCase) CheckEmailExists(ctx context.Context, email string) (bool, error) {
-	return u.repo.CheckEmailExists(ctx, email)
+	return u.repo.DeleteUser(ctx, id)
 }
 
-func (u *UserUseCase) PurchaseItem(ctx context.Context, userID uuid.UUID, itemPrice int) (*entity.User, error) {
-	if itemPrice <= 0 {
-		return nil, errors.New("invalid item price")
-	}
+func (u *UserUseCase) CheckEmailExists(ctx context.Context, email string) (_ bool, err error) {
+	ctx, end := startSpan(ctx, "UserUseCase.CheckEmailExists")
+	defer end(&err)
 
-	return u.UpdateUserCoins(ctx, userID, -itemPrice)
+	return u.repo.CheckEmailExists(ctx, email)
 }
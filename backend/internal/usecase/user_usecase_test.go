@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"backend/internal/domain"
 	"backend/internal/entity"
 	"context"
 	"errors"
@@ -34,6 +35,24 @@ func (m *MockUserRepository) GetUserByEmail(ctx context.Context, email string) (
 	return args.Get(0).(*entity.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetUserByReferralCode(ctx context.Context, code string) (*entity.User, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepository) LockNormalizedEmail(ctx context.Context, normalizedEmail string) error {
+	args := m.Called(ctx, normalizedEmail)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) CountUsersByNormalizedEmail(ctx context.Context, normalizedEmail string) (int, error) {
+	args := m.Called(ctx, normalizedEmail)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockUserRepository) CreateUser(ctx context.Context, req entity.CreateUserRequest) (*entity.User, error) {
 	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
@@ -63,14 +82,6 @@ func (m *MockUserRepository) UpdateUserPassword(ctx context.Context, id uuid.UUI
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) UpdateUserCoins(ctx context.Context, id uuid.UUID, coinsDelta int) (*entity.User, error) {
-	args := m.Called(ctx, id, coinsDelta)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.User), args.Error(1)
-}
-
 func (m *MockUserRepository) CheckEmailExists(ctx context.Context, email string) (bool, error) {
 	args := m.Called(ctx, email)
 	return args.Bool(0), args.Error(1)
@@ -95,20 +106,27 @@ func createTestUser(id uuid.UUID, name, email, passwordHash string, coins int) *
 }
 
 func setupUserUseCase() (*UserUseCase, *MockUserRepository) {
-	mockRepo := new(MockUserRepository)
-	useCase := NewUserUseCase(mockRepo)
+	useCase, mockRepo, _, _ := setupUserUseCaseWithReferrals()
 	return useCase, mockRepo
 }
 
+func setupUserUseCaseWithReferrals() (*UserUseCase, *MockUserRepository, *MockReferralRepository, *fakeTxManager) {
+	mockRepo := new(MockUserRepository)
+	mockReferralRepo := new(MockReferralRepository)
+	txManager := &fakeTxManager{}
+	useCase := NewUserUseCase(mockRepo, mockReferralRepo, txManager)
+	return useCase, mockRepo, mockReferralRepo, txManager
+}
+
 // Tests for GetUserById
 func TestGetUserById_Success(t *testing.T) {
 	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 	expectedUser := createTestUser(userID, "Alice", "alice@example.com", "hashedpassword", 100)
 
-	mockRepo.On("GetUserById", ctx, userID).Return(expectedUser, nil)
+	mockRepo.On("GetUserById", fromCaller, userID).Return(expectedUser, nil)
 
 	user, err := uc.GetUserById(ctx, userID)
 
@@ -123,11 +141,11 @@ func TestGetUserById_Success(t *testing.T) {
 
 func TestGetUserById_NotFound(t *testing.T) {
 	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 
-	mockRepo.On("GetUserById", ctx, userID).Return(nil, errors.New("user not found"))
+	mockRepo.On("GetUserById", fromCaller, userID).Return(nil, domain.ErrUserNotFound)
 
 	user, err := uc.GetUserById(ctx, userID)
 
@@ -141,12 +159,12 @@ func TestGetUserById_NotFound(t *testing.T) {
 // Tests for GetUserByEmail
 func TestGetUserByEmail_Success(t *testing.T) {
 	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	email := "alice@example.com"
 	expectedUser := createTestUser(uuid.New(), "Alice", email, "hashedpassword", 100)
 
-	mockRepo.On("GetUserByEmail", ctx, email).Return(expectedUser, nil)
+	mockRepo.On("GetUserByEmail", fromCaller, email).Return(expectedUser, nil)
 
 	user, err := uc.GetUserByEmail(ctx, email)
 
@@ -159,11 +177,11 @@ func TestGetUserByEmail_Success(t *testing.T) {
 
 func TestGetUserByEmail_NotFound(t *testing.T) {
 	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	email := "nonexistent@example.com"
 
-	mockRepo.On("GetUserByEmail", ctx, email).Return(nil, errors.New("user not found"))
+	mockRepo.On("GetUserByEmail", fromCaller, email).Return(nil, domain.ErrUserNotFound)
 
 	user, err := uc.GetUserByEmail(ctx, email)
 
@@ -176,7 +194,7 @@ func TestGetUserByEmail_NotFound(t *testing.T) {
 // Tests for SignUp
 func TestSignUp_Success(t *testing.T) {
 	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	req := entity.CreateUserRequest{
 		Name:     "Bob",
@@ -187,7 +205,7 @@ func TestSignUp_Success(t *testing.T) {
 
 	expectedUser := createTestUser(uuid.New(), req.Name, req.Email, "hashedpassword", req.Coins)
 
-	mockRepo.On("CreateUser", ctx, req).Return(expectedUser, nil)
+	mockRepo.On("CreateUser", fromCaller, req).Return(expectedUser, nil)
 
 	user, err := uc.SignUp(ctx, req)
 
@@ -201,7 +219,7 @@ func TestSignUp_Success(t *testing.T) {
 
 func TestSignUp_EmptyName(t *testing.T) {
 	uc, _ := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	req := entity.CreateUserRequest{
 		Name:     "",
@@ -218,7 +236,7 @@ func TestSignUp_EmptyName(t *testing.T) {
 
 func TestSignUp_EmptyEmail(t *testing.T) {
 	uc, _ := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	req := entity.CreateUserRequest{
 		Name:     "Bob",
@@ -235,7 +253,7 @@ func TestSignUp_EmptyEmail(t *testing.T) {
 
 func TestSignUp_ShortPassword(t *testing.T) {
 	uc, _ := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	req := entity.CreateUserRequest{
 		Name:     "Bob",
@@ -252,7 +270,7 @@ func TestSignUp_ShortPassword(t *testing.T) {
 
 func TestSignUp_RepositoryError(t *testing.T) {
 	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	req := entity.CreateUserRequest{
 		Name:     "Bob",
@@ -260,7 +278,7 @@ func TestSignUp_RepositoryError(t *testing.T) {
 		Password: "secret123",
 	}
 
-	mockRepo.On("CreateUser", ctx, req).Return(nil, errors.New("email already exists"))
+	mockRepo.On("CreateUser", fromCaller, req).Return(nil, domain.ErrEmailAlreadyExists)
 
 	user, err := uc.SignUp(ctx, req)
 
@@ -271,10 +289,122 @@ func TestSignUp_RepositoryError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestSignUp_PendingReferral(t *testing.T) {
+	uc, mockRepo, mockReferralRepo, txManager := setupUserUseCaseWithReferrals()
+	ctx := callerContext()
+
+	referrer := createTestUser(uuid.New(), "Alice", "alice@example.com", "hashedpassword", 100)
+	req := entity.CreateUserRequest{
+		Name:         "Bob",
+		Email:        "Bob+shop@example.com",
+		Password:     "secret123",
+		ReferralCode: " abcd2345 ",
+	}
+	createdUser := createTestUser(uuid.New(), req.Name, req.Email, "hashedpassword", 0)
+
+	mockRepo.On("GetUserByReferralCode", fromCaller, "ABCD2345").Return(referrer, nil)
+	mockRepo.On("LockNormalizedEmail", fromCaller, "bob@example.com").Return(nil)
+	mockRepo.On("CountUsersByNormalizedEmail", fromCaller, "bob@example.com").Return(0, nil)
+	mockRepo.On("CreateUser", fromCaller, req).Return(createdUser, nil)
+	mockReferralRepo.On("CreateReferral", fromCaller, referrer.ID, createdUser.ID, "").
+		Return(&entity.Referral{ID: 1, Status: entity.ReferralStatusPending}, nil)
+
+	user, err := uc.SignUp(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, createdUser.ID, user.ID)
+	assert.Equal(t, 1, txManager.commits)
+
+	mockRepo.AssertExpectations(t)
+	mockReferralRepo.AssertExpectations(t)
+	mockReferralRepo.AssertNotCalled(t, "RecordSecurityEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSignUp_RejectedReferralIsFlagged(t *testing.T) {
+	uc, mockRepo, mockReferralRepo, _ := setupUserUseCaseWithReferrals()
+	ctx := callerContext()
+
+	referrer := createTestUser(uuid.New(), "Alice", "alice@example.com", "hashedpassword", 100)
+	req := entity.CreateUserRequest{
+		Name:         "Alice Again",
+		Email:        "alice+2@example.com",
+		Password:     "secret123",
+		ReferralCode: "ABCD2345",
+	}
+	createdUser := createTestUser(uuid.New(), req.Name, req.Email, "hashedpassword", 0)
+
+	// Signing up again with the referrer's own address is saved as rejected
+	// and flagged
+	mockRepo.On("GetUserByReferralCode", fromCaller, "ABCD2345").Return(referrer, nil)
+	mockRepo.On("LockNormalizedEmail", fromCaller, "alice@example.com").Return(nil)
+	mockRepo.On("CountUsersByNormalizedEmail", fromCaller, "alice@example.com").Return(1, nil)
+	mockRepo.On("CreateUser", fromCaller, req).Return(createdUser, nil)
+	mockReferralRepo.On("CreateReferral", fromCaller, referrer.ID, createdUser.ID, entity.ReferralRejectedSelfReferral).
+		Return(&entity.Referral{ID: 1, Status: entity.ReferralStatusRejected}, nil)
+	mockReferralRepo.On("RecordSecurityEvent", fromCaller, createdUser.ID, entity.SecurityEventReferralRejected,
+		entity.ReferralRejectedSelfReferral+": referred by "+referrer.ID.String()).Return(nil)
+
+	user, err := uc.SignUp(ctx, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, createdUser.ID, user.ID)
+
+	mockRepo.AssertExpectations(t)
+	mockReferralRepo.AssertExpectations(t)
+}
+
+func TestSignUp_InvalidReferralCode(t *testing.T) {
+	uc, mockRepo, _, _ := setupUserUseCaseWithReferrals()
+	ctx := callerContext()
+
+	req := entity.CreateUserRequest{
+		Name:         "Bob",
+		Email:        "bob@example.com",
+		Password:     "secret123",
+		ReferralCode: "NOSUCH23",
+	}
+
+	mockRepo.On("GetUserByReferralCode", fromCaller, "NOSUCH23").Return(nil, domain.ErrUserNotFound)
+
+	user, err := uc.SignUp(ctx, req)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidReferralCode)
+	assert.Nil(t, user)
+	mockRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything)
+}
+
+func TestSignUp_ReferralFailureRollsBackUser(t *testing.T) {
+	uc, mockRepo, mockReferralRepo, txManager := setupUserUseCaseWithReferrals()
+	ctx := callerContext()
+
+	referrer := createTestUser(uuid.New(), "Alice", "alice@example.com", "hashedpassword", 100)
+	req := entity.CreateUserRequest{
+		Name:         "Bob",
+		Email:        "bob@example.com",
+		Password:     "secret123",
+		ReferralCode: "ABCD2345",
+	}
+	createdUser := createTestUser(uuid.New(), req.Name, req.Email, "hashedpassword", 0)
+
+	mockRepo.On("GetUserByReferralCode", fromCaller, "ABCD2345").Return(referrer, nil)
+	mockRepo.On("LockNormalizedEmail", fromCaller, "bob@example.com").Return(nil)
+	mockRepo.On("CountUsersByNormalizedEmail", fromCaller, "bob@example.com").Return(0, nil)
+	mockRepo.On("CreateUser", fromCaller, req).Return(createdUser, nil)
+	mockReferralRepo.On("CreateReferral", fromCaller, referrer.ID, createdUser.ID, "").
+		Return(nil, errors.New("failed to create referral: connection reset"))
+
+	user, err := uc.SignUp(ctx, req)
+
+	assert.Error(t, err)
+	assert.Nil(t, user)
+	assert.Equal(t, 0, txManager.commits)
+	assert.Equal(t, 1, txManager.rollbacks)
+}
+
 // Tests for Login
 func TestLogin_Success(t *testing.T) {
 	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	email := "alice@example.com"
 	password := "secret123"
@@ -282,7 +412,7 @@ func TestLogin_Success(t *testing.T) {
 
 	expectedUser := createTestUser(uuid.New(), "Alice", email, string(hashedPassword), 100)
 
-	mockRepo.On("GetUserByEmail", ctx, email).Return(expectedUser, nil)
+	mockRepo.On("GetUserByEmail", fromCaller, email).Return(expectedUser, nil)
 
 	user, err := uc.Login(ctx, email, password)
 
@@ -295,7 +425,7 @@ func TestLogin_Success(t *testing.T) {
 
 func TestLogin_EmptyEmail(t *testing.T) {
 	uc, _ := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	user, err := uc.Login(ctx, "", "password")
 
@@ -306,7 +436,7 @@ func TestLogin_EmptyEmail(t *testing.T) {
 
 func TestLogin_EmptyPassword(t *testing.T) {
 	uc, _ := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	user, err := uc.Login(ctx, "alice@example.com", "")
 
@@ -317,11 +447,11 @@ func TestLogin_EmptyPassword(t *testing.T) {
 
 func TestLogin_UserNotFound(t *testing.T) {
 	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	email := "nonexistent@example.com"
 
-	mockRepo.On("GetUserByEmail", ctx, email).Return(nil, errors.New("user not found"))
+	mockRepo.On("GetUserByEmail", fromCaller, email).Return(nil, domain.ErrUserNotFound)
 
 	user, err := uc.Login(ctx, email, "password")
 
@@ -334,7 +464,7 @@ func TestLogin_UserNotFound(t *testing.T) {
 
 func TestLogin_WrongPassword(t *testing.T) {
 	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	email := "alice@example.com"
 	correctPassword := "secret123"
@@ -343,7 +473,7 @@ func TestLogin_WrongPassword(t *testing.T) {
 
 	expectedUser := createTestUser(uuid.New(), "Alice", email, string(hashedPassword), 100)
 
-	mockRepo.On("GetUserByEmail", ctx, email).Return(expectedUser, nil)
+	mockRepo.On("GetUserByEmail", fromCaller, email).Return(expectedUser, nil)
 
 	user, err := uc.Login(ctx, email, wrongPassword)
 
@@ -357,13 +487,13 @@ func TestLogin_WrongPassword(t *testing.T) {
 // Tests for UpdateUserName
 func TestUpdateUserName_Success(t *testing.T) {
 	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 	newName := "Updated Name"
 	updatedUser := createTestUser(userID, newName, "alice@example.com", "hashedpassword", 100)
 
-	mockRepo.On("UpdateUserName", ctx, userID, newName).Return(updatedUser, nil)
+	mockRepo.On("UpdateUserName", fromCaller, userID, newName).Return(updatedUser, nil)
 
 	user, err := uc.UpdateUserName(ctx, userID, newName)
 
@@ -376,7 +506,7 @@ func TestUpdateUserName_Success(t *testing.T) {
 
 func TestUpdateUserName_EmptyName(t *testing.T) {
 	uc, _ := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 
@@ -390,13 +520,13 @@ func TestUpdateUserName_EmptyName(t *testing.T) {
 // Tests for UpdateUserEmail
 func TestUpdateUserEmail_Success(t *testing.T) {
 	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 	newEmail := "newemail@example.com"
 	updatedUser := createTestUser(userID, "Alice", newEmail, "hashedpassword", 100)
 
-	mockRepo.On("UpdateUserEmail", ctx, userID, newEmail).Return(updatedUser, nil)
+	mockRepo.On("UpdateUserEmail", fromCaller, userID, newEmail).Return(updatedUser, nil)
 
 	user, err := uc.UpdateUserEmail(ctx, userID, newEmail)
 
@@ -409,7 +539,7 @@ func TestUpdateUserEmail_Success(t *testing.T) {
 
 func TestUpdateUserEmail_EmptyEmail(t *testing.T) {
 	uc, _ := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 
@@ -420,51 +550,10 @@ func TestUpdateUserEmail_EmptyEmail(t *testing.T) {
 	assert.Equal(t, "email cannot be empty", err.Error())
 }
 
-// Tests for UpdateUserCoins
-func TestUpdateUserCoins_Success(t *testing.T) {
-	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
-
-	userID := uuid.New()
-	currentUser := createTestUser(userID, "Alice", "alice@example.com", "hashedpassword", 100)
-	coinsDelta := 50
-	updatedUser := createTestUser(userID, "Alice", "alice@example.com", "hashedpassword", 150)
-
-	mockRepo.On("GetUserById", ctx, userID).Return(currentUser, nil)
-	mockRepo.On("UpdateUserCoins", ctx, userID, coinsDelta).Return(updatedUser, nil)
-
-	user, err := uc.UpdateUserCoins(ctx, userID, coinsDelta)
-
-	assert.NoError(t, err)
-	assert.NotNil(t, user)
-	assert.Equal(t, 150, user.Coins)
-
-	mockRepo.AssertExpectations(t)
-}
-
-func TestUpdateUserCoins_InsufficientCoins(t *testing.T) {
-	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
-
-	userID := uuid.New()
-	currentUser := createTestUser(userID, "Alice", "alice@example.com", "hashedpassword", 50)
-	coinsDelta := -100
-
-	mockRepo.On("GetUserById", ctx, userID).Return(currentUser, nil)
-
-	user, err := uc.UpdateUserCoins(ctx, userID, coinsDelta)
-
-	assert.Error(t, err)
-	assert.Nil(t, user)
-	assert.Equal(t, "insufficient coins", err.Error())
-
-	mockRepo.AssertExpectations(t)
-}
-
 // Tests for ChangePassword
 func TestChangePassword_Success(t *testing.T) {
 	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 	currentPassword := "oldpassword"
@@ -473,8 +562,8 @@ func TestChangePassword_Success(t *testing.T) {
 
 	currentUser := createTestUser(userID, "Alice", "alice@example.com", string(hashedCurrentPassword), 100)
 
-	mockRepo.On("GetUserById", ctx, userID).Return(currentUser, nil)
-	mockRepo.On("UpdateUserPassword", ctx, userID, newPassword).Return(nil)
+	mockRepo.On("GetUserById", fromCaller, userID).Return(currentUser, nil)
+	mockRepo.On("UpdateUserPassword", fromCaller, userID, newPassword).Return(nil)
 
 	err := uc.ChangePassword(ctx, userID, currentPassword, newPassword)
 
@@ -485,7 +574,7 @@ func TestChangePassword_Success(t *testing.T) {
 
 func TestChangePassword_ShortNewPassword(t *testing.T) {
 	uc, _ := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 
@@ -497,7 +586,7 @@ func TestChangePassword_ShortNewPassword(t *testing.T) {
 
 func TestChangePassword_IncorrectCurrentPassword(t *testing.T) {
 	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 	correctCurrentPassword := "correctpassword"
@@ -507,7 +596,7 @@ func TestChangePassword_IncorrectCurrentPassword(t *testing.T) {
 
 	currentUser := createTestUser(userID, "Alice", "alice@example.com", string(hashedCurrentPassword), 100)
 
-	mockRepo.On("GetUserById", ctx, userID).Return(currentUser, nil)
+	mockRepo.On("GetUserById", fromCaller, userID).Return(currentUser, nil)
 
 	err := uc.ChangePassword(ctx, userID, wrongCurrentPassword, newPassword)
 
@@ -520,11 +609,11 @@ func TestChangePassword_IncorrectCurrentPassword(t *testing.T) {
 // Tests for DeleteUser
 func TestDeleteUser_Success(t *testing.T) {
 	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	userID := uuid.New()
 
-	mockRepo.On("DeleteUser", ctx, userID).Return(nil)
+	mockRepo.On("DeleteUser", fromCaller, userID).Return(nil)
 
 	err := uc.DeleteUser(ctx, userID)
 
@@ -536,11 +625,11 @@ func TestDeleteUser_Success(t *testing.T) {
 // Tests for CheckEmailExists
 func TestCheckEmailExists_True(t *testing.T) {
 	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	email := "existing@example.com"
 
-	mockRepo.On("CheckEmailExists", ctx, email).Return(true, nil)
+	mockRepo.On("CheckEmailExists", fromCaller, email).Return(true, nil)
 
 	exists, err := uc.CheckEmailExists(ctx, email)
 
@@ -552,11 +641,11 @@ func TestCheckEmailExists_True(t *testing.T) {
 
 func TestCheckEmailExists_False(t *testing.T) {
 	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
+	ctx := callerContext()
 
 	email := "nonexistent@example.com"
 
-	mockRepo.On("CheckEmailExists", ctx, email).Return(false, nil)
+	mockRepo.On("CheckEmailExists", fromCaller, email).Return(false, nil)
 
 	exists, err := uc.CheckEmailExists(ctx, email)
 
@@ -565,63 +654,3 @@ func TestCheckEmailExists_False(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
-
-// Tests for PurchaseItem
-func TestPurchaseItem_Success(t *testing.T) {
-	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
-
-	userID := uuid.New()
-	itemPrice := 50
-	currentUser := createTestUser(userID, "Alice", "alice@example.com", "hashedpassword", 100)
-	updatedUser := createTestUser(userID, "Alice", "alice@example.com", "hashedpassword", 50)
-
-	mockRepo.On("GetUserById", ctx, userID).Return(currentUser, nil)
-	mockRepo.On("UpdateUserCoins", ctx, userID, -itemPrice).Return(updatedUser, nil)
-
-	user, err := uc.PurchaseItem(ctx, userID, itemPrice)
-
-	assert.NoError(t, err)
-	assert.NotNil(t, user)
-	assert.Equal(t, 50, user.Coins)
-
-	mockRepo.AssertExpectations(t)
-}
-
-func TestPurchaseItem_InvalidPrice(t *testing.T) {
-	uc, _ := setupUserUseCase()
-	ctx := context.Background()
-
-	userID := uuid.New()
-
-	// Test zero price
-	user, err := uc.PurchaseItem(ctx, userID, 0)
-	assert.Error(t, err)
-	assert.Nil(t, user)
-	assert.Equal(t, "invalid item price", err.Error())
-
-	// Test negative price
-	user, err = uc.PurchaseItem(ctx, userID, -10)
-	assert.Error(t, err)
-	assert.Nil(t, user)
-	assert.Equal(t, "invalid item price", err.Error())
-}
-
-func TestPurchaseItem_InsufficientCoins(t *testing.T) {
-	uc, mockRepo := setupUserUseCase()
-	ctx := context.Background()
-
-	userID := uuid.New()
-	itemPrice := 150
-	currentUser := createTestUser(userID, "Alice", "alice@example.com", "hashedpassword", 100)
-
-	mockRepo.On("GetUserById", ctx, userID).Return(currentUser, nil)
-
-	user, err := uc.PurchaseItem(ctx, userID, itemPrice)
-
-	assert.Error(t, err)
-	assert.Nil(t, user)
-	assert.Equal(t, "insufficient coins", err.Error())
-
-	mockRepo.AssertExpectations(t)
-}
@@ -0,0 +1,42 @@
+package worker
+
+import (
+	"backend/internal/usecase"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// CoinExpiryWorker periodically turns expired coin lots into expiry transactions.
+type CoinExpiryWorker struct {
+	coinTransactionUC usecase.CoinTransactionUseCase
+	interval          time.Duration
+}
+
+func NewCoinExpiryWorker(coinTransactionUC usecase.CoinTransactionUseCase, interval time.Duration) *CoinExpiryWorker {
+	return &CoinExpiryWorker{
+		coinTransactionUC: coinTransactionUC,
+		interval:          interval,
+	}
+}
+
+// Run sweeps once immediately and then on every interval until ctx is cancelled.
+func (w *CoinExpiryWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		expired, err := w.coinTransactionUC.ExpireCoins(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "coin expiry sweep failed", "error", err)
+		} else if expired > 0 {
+			slog.InfoContext(ctx, "expired coin lots", "count", expired)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
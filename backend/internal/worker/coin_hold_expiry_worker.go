@@ -0,0 +1,43 @@
+package worker
+
+import (
+	"backend/internal/usecase"
+	"context"
+	"log/slog"
+	"time"
+)
+
+// CoinHoldExpiryWorker periodically releases coin holds that were neither
+// captured nor released before they expired.
+type CoinHoldExpiryWorker struct {
+	coinTransactionUC usecase.CoinTransactionUseCase
+	interval          time.Duration
+}
+
+func NewCoinHoldExpiryWorker(coinTransactionUC usecase.CoinTransactionUseCase, interval time.Duration) *CoinHoldExpiryWorker {
+	return &CoinHoldExpiryWorker{
+		coinTransactionUC: coinTransactionUC,
+		interval:          interval,
+	}
+}
+
+// Run sweeps once immediately and then on every interval until ctx is cancelled.
+func (w *CoinHoldExpiryWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		expired, err := w.coinTransactionUC.ExpireHolds(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "coin hold expiry sweep failed", "error", err)
+		} else if expired > 0 {
+			slog.InfoContext(ctx, "expired coin holds", "count", expired)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
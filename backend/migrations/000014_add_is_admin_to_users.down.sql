@@ -0,0 +1,2 @@
+-- Remove admin flag from users
+ALTER TABLE users DROP COLUMN IF EXISTS is_admin;
\ No newline at end of file
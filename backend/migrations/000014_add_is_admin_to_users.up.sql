@@ -0,0 +1,2 @@
+-- Add admin flag to users
+ALTER TABLE users ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT FALSE;
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- Drop pack reference from coin_transactions
+DROP INDEX IF EXISTS idx_coin_transactions_coin_pack_id;
+ALTER TABLE coin_transactions DROP COLUMN IF EXISTS coin_pack_id;
+
+-- Remove 'bonus' from transaction_type (enum values cannot be dropped directly)
+DELETE FROM coin_transactions WHERE transaction_type = 'bonus';
+ALTER TYPE transaction_type RENAME TO transaction_type_old;
+CREATE TYPE transaction_type AS ENUM ('charge', 'purchase', 'refund');
+ALTER TABLE coin_transactions
+    ALTER COLUMN transaction_type TYPE transaction_type USING transaction_type::text::transaction_type;
+DROP TYPE transaction_type_old;
+
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_coin_packs_updated_at ON coin_packs;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_coin_packs_active_sort;
+
+-- Drop table
+DROP TABLE IF EXISTS coin_packs;
\ No newline at end of file
@@ -0,0 +1,29 @@
+-- Create coin_packs table
+CREATE TABLE coin_packs (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    base_coins INTEGER NOT NULL CHECK (base_coins > 0),
+    bonus_coins INTEGER NOT NULL DEFAULT 0 CHECK (bonus_coins >= 0),
+    price DECIMAL(10,2) NOT NULL CHECK (price >= 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX idx_coin_packs_active_sort ON coin_packs(is_active, sort_order);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_coin_packs_updated_at 
+    BEFORE UPDATE ON coin_packs
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Bonus coins are recorded as their own ledger entries
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'bonus';
+
+-- Link ledger entries to the pack they came from
+ALTER TABLE coin_transactions ADD COLUMN coin_pack_id INTEGER NULL REFERENCES coin_packs(id);
+
+CREATE INDEX idx_coin_transactions_coin_pack_id ON coin_transactions(coin_pack_id);
+
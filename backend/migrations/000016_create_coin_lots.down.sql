@@ -0,0 +1,14 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_coin_lots_user_open;
+DROP INDEX IF EXISTS idx_coin_lots_expires_at;
+
+-- Drop table
+DROP TABLE IF EXISTS coin_lots;
+
+-- Remove 'expiry' from transaction_type (enum values cannot be dropped directly)
+DELETE FROM coin_transactions WHERE transaction_type = 'expiry';
+ALTER TYPE transaction_type RENAME TO transaction_type_old;
+CREATE TYPE transaction_type AS ENUM ('charge', 'purchase', 'refund', 'bonus');
+ALTER TABLE coin_transactions
+    ALTER COLUMN transaction_type TYPE transaction_type USING transaction_type::text::transaction_type;
+DROP TYPE transaction_type_old;
\ No newline at end of file
@@ -0,0 +1,20 @@
+-- Expired coins are written to the ledger as their own entries
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'expiry';
+
+-- Create coin_lots table
+-- Each charge or bonus creates a lot; spends consume lots FIFO by expiry.
+-- Coins without a lot (e.g. balances that predate lots) never expire.
+CREATE TABLE coin_lots (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    coin_transaction_id INTEGER NULL REFERENCES coin_transactions(id),
+    source transaction_type NOT NULL,
+    original_amount INTEGER NOT NULL CHECK (original_amount > 0),
+    remaining_amount INTEGER NOT NULL CHECK (remaining_amount >= 0 AND remaining_amount <= original_amount),
+    expires_at TIMESTAMP WITH TIME ZONE NULL, -- NULL means the lot never expires
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX idx_coin_lots_user_open ON coin_lots(user_id, expires_at, id) WHERE remaining_amount > 0;
+CREATE INDEX idx_coin_lots_expires_at ON coin_lots(expires_at) WHERE remaining_amount > 0;
\ No newline at end of file
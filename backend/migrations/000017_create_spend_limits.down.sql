@@ -0,0 +1,9 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_coin_transactions_user_type_created_at;
+DROP INDEX IF EXISTS idx_security_events_user_created_at;
+
+-- Drop tables
+DROP TABLE IF EXISTS security_events;
+
+DROP TRIGGER IF EXISTS update_user_spend_limits_updated_at ON user_spend_limits;
+DROP TABLE IF EXISTS user_spend_limits;
\ No newline at end of file
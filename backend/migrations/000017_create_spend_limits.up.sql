@@ -0,0 +1,30 @@
+-- Per-user overrides of the default spend limits. NULL limits fall back to
+-- the configured defaults; spending_blocked_until is set by the velocity check.
+CREATE TABLE user_spend_limits (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    daily_limit INTEGER NULL CHECK (daily_limit >= 0),
+    per_transaction_limit INTEGER NULL CHECK (per_transaction_limit >= 0),
+    spending_blocked_until TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_user_spend_limits_updated_at 
+    BEFORE UPDATE ON user_spend_limits
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create security_events table
+CREATE TABLE security_events (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    details TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX idx_security_events_user_created_at ON security_events(user_id, created_at DESC);
+
+-- Spend activity is counted per user over recent purchases
+CREATE INDEX idx_coin_transactions_user_type_created_at ON coin_transactions(user_id, transaction_type, created_at);
\ No newline at end of file
@@ -0,0 +1,17 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_coin_holds_updated_at ON coin_holds;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_coin_holds_order_id;
+DROP INDEX IF EXISTS idx_coin_holds_active_expires_at;
+DROP INDEX IF EXISTS idx_coin_holds_user_id;
+
+-- Drop table
+DROP TABLE IF EXISTS coin_holds;
+
+-- Drop ENUM types
+DROP TYPE IF EXISTS coin_hold_status;
+
+-- Drop held coins from users
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_held_coins_within_balance;
+ALTER TABLE users DROP COLUMN IF EXISTS held_coins;
\ No newline at end of file
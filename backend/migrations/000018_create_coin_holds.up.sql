@@ -0,0 +1,31 @@
+-- Coins reserved by active holds. They still count towards the settled
+-- balance in coins but cannot be spent until the hold is released.
+ALTER TABLE users ADD COLUMN held_coins INTEGER NOT NULL DEFAULT 0 CHECK (held_coins >= 0);
+ALTER TABLE users ADD CONSTRAINT users_held_coins_within_balance CHECK (held_coins <= coins);
+
+-- Create ENUM types
+CREATE TYPE coin_hold_status AS ENUM ('active', 'captured', 'released', 'expired');
+
+-- Create coin_holds table
+CREATE TABLE coin_holds (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    description TEXT,
+    order_id INTEGER NULL REFERENCES orders(id),
+    status coin_hold_status NOT NULL DEFAULT 'active',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    coin_transaction_id INTEGER NULL REFERENCES coin_transactions(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create indexes
+CREATE INDEX idx_coin_holds_user_id ON coin_holds(user_id, created_at DESC);
+CREATE INDEX idx_coin_holds_active_expires_at ON coin_holds(expires_at) WHERE status = 'active';
+CREATE INDEX idx_coin_holds_order_id ON coin_holds(order_id);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_coin_holds_updated_at 
+    BEFORE UPDATE ON coin_holds
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- Remove 'cashback' and 'cashback_reversal' from transaction_type (enum values cannot be dropped directly)
+DELETE FROM coin_lots WHERE source IN ('cashback', 'cashback_reversal');
+DELETE FROM coin_transactions WHERE transaction_type IN ('cashback', 'cashback_reversal');
+ALTER TYPE transaction_type RENAME TO transaction_type_old;
+CREATE TYPE transaction_type AS ENUM ('charge', 'purchase', 'refund', 'bonus', 'expiry');
+ALTER TABLE coin_transactions
+    ALTER COLUMN transaction_type TYPE transaction_type USING transaction_type::text::transaction_type;
+ALTER TABLE coin_lots
+    ALTER COLUMN source TYPE transaction_type USING source::text::transaction_type;
+DROP TYPE transaction_type_old;
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- Cashback rewards and their reversals are recorded as their own ledger entries.
+-- These run in a migration of their own because new enum values cannot be
+-- used in the transaction that adds them.
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'cashback';
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'cashback_reversal';
\ No newline at end of file
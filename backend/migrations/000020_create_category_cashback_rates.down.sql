@@ -0,0 +1,8 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_coin_transactions_order_cashback;
+
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_category_cashback_rates_updated_at ON category_cashback_rates;
+
+-- Drop table
+DROP TABLE IF EXISTS category_cashback_rates;
\ No newline at end of file
@@ -0,0 +1,17 @@
+-- Per-category cashback rates. Categories without a row use the configured default rate.
+CREATE TABLE category_cashback_rates (
+    category_id INTEGER PRIMARY KEY REFERENCES categories(id) ON DELETE CASCADE,
+    rate_percent DECIMAL(5,2) NOT NULL CHECK (rate_percent >= 0 AND rate_percent <= 100),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_category_cashback_rates_updated_at 
+    BEFORE UPDATE ON category_cashback_rates
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- An order earns cashback at most once and has it reversed at most once
+CREATE UNIQUE INDEX idx_coin_transactions_order_cashback
+    ON coin_transactions(order_id, transaction_type)
+    WHERE transaction_type IN ('cashback', 'cashback_reversal');
\ No newline at end of file
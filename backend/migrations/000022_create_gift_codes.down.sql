@@ -0,0 +1,8 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_security_events_user_type_created_at;
+DROP INDEX IF EXISTS idx_gift_codes_batch_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS gift_code_redemptions;
+DROP TABLE IF EXISTS gift_codes;
+DROP TABLE IF EXISTS gift_code_batches;
\ No newline at end of file
@@ -0,0 +1,37 @@
+-- Gift codes are generated in batches. Only a SHA-256 hash of each code is
+-- stored; code_hint keeps the last characters so support can tell codes apart.
+CREATE TABLE gift_code_batches (
+    id SERIAL PRIMARY KEY,
+    description TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE gift_codes (
+    id SERIAL PRIMARY KEY,
+    batch_id INTEGER NOT NULL REFERENCES gift_code_batches(id) ON DELETE CASCADE,
+    code_hash CHAR(64) NOT NULL UNIQUE,
+    code_hint VARCHAR(4) NOT NULL,
+    coin_amount INTEGER NOT NULL CHECK (coin_amount > 0),
+    max_redemptions INTEGER NOT NULL CHECK (max_redemptions > 0),
+    redemption_count INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT gift_codes_redemptions_within_max CHECK (redemption_count >= 0 AND redemption_count <= max_redemptions)
+);
+
+-- A user can redeem each code once
+CREATE TABLE gift_code_redemptions (
+    id SERIAL PRIMARY KEY,
+    gift_code_id INTEGER NOT NULL REFERENCES gift_codes(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    coin_transaction_id INTEGER NOT NULL REFERENCES coin_transactions(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (gift_code_id, user_id)
+);
+
+-- Create indexes
+CREATE INDEX idx_gift_codes_batch_id ON gift_codes(batch_id);
+
+-- Failed redemptions are counted per user over a recent window
+CREATE INDEX idx_security_events_user_type_created_at ON security_events(user_id, event_type, created_at);
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- Referral bonuses are recorded as their own ledger entries.
+-- This runs in a migration of its own because new enum values cannot be
+-- used in the transaction that adds them.
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'referral';
\ No newline at end of file
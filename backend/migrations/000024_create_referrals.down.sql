@@ -0,0 +1,17 @@
+-- Drop trigger first
+DROP TRIGGER IF EXISTS update_referrals_updated_at ON referrals;
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_referrals_referrer_id;
+DROP INDEX IF EXISTS idx_users_normalized_email;
+
+-- Drop table
+DROP TABLE IF EXISTS referrals;
+
+-- Drop ENUM types
+DROP TYPE IF EXISTS referral_status;
+
+-- Drop referral columns from users
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_referral_code_key;
+ALTER TABLE users DROP COLUMN IF EXISTS normalized_email;
+ALTER TABLE users DROP COLUMN IF EXISTS referral_code;
\ No newline at end of file
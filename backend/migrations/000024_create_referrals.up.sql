@@ -0,0 +1,67 @@
+-- Every user gets a referral code to share. normalized_email is the email
+-- with case, +tags and Gmail dots removed, so one inbox signing up twice can
+-- be recognised. The backfill mirrors entity.NormalizeEmail.
+ALTER TABLE users ADD COLUMN referral_code VARCHAR(16) NULL;
+ALTER TABLE users ADD COLUMN normalized_email VARCHAR(255) NULL;
+
+-- Backfilled codes use the alphabet new codes are drawn from
+-- (referralCodeAlphabet, without 0/O and 1/I). The subquery refers to the row
+-- so it runs once per user rather than once for the whole update.
+UPDATE users u
+SET referral_code = (
+    SELECT STRING_AGG(SUBSTR('ABCDEFGHJKLMNPQRSTUVWXYZ23456789', FLOOR(RANDOM() * 32)::INTEGER + 1, 1), '')
+    FROM GENERATE_SERIES(1, 8)
+    WHERE u.id IS NOT NULL
+);
+
+-- Like entity.NormalizeEmail this splits at the last '@': the greedy '^(.*)@'
+-- takes everything before it, '@([^@]*)$' everything after. An email without
+-- an '@' is only trimmed and lowered.
+UPDATE users u
+SET normalized_email = CASE
+        WHEN n.local IS NULL THEN n.email
+        WHEN n.domain IN ('gmail.com', 'googlemail.com')
+            THEN REPLACE(SPLIT_PART(n.local, '+', 1), '.', '') || '@gmail.com'
+        ELSE SPLIT_PART(n.local, '+', 1) || '@' || n.domain
+    END
+FROM (
+    SELECT id,
+           email,
+           SUBSTRING(email FROM '^(.*)@') AS local,
+           SUBSTRING(email FROM '@([^@]*)$') AS domain
+    FROM (SELECT id, LOWER(TRIM(email)) AS email FROM users) e
+) n
+WHERE u.id = n.id;
+
+ALTER TABLE users ALTER COLUMN referral_code SET NOT NULL;
+ALTER TABLE users ALTER COLUMN normalized_email SET NOT NULL;
+ALTER TABLE users ADD CONSTRAINT users_referral_code_key UNIQUE (referral_code);
+
+-- Create ENUM types
+CREATE TYPE referral_status AS ENUM ('pending', 'rewarded', 'rejected');
+
+-- Create referrals table
+-- A user is referred at most once. Rejected referrals are kept with the reason
+-- so abuse can be reviewed.
+CREATE TABLE referrals (
+    id SERIAL PRIMARY KEY,
+    referrer_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    referee_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    status referral_status NOT NULL DEFAULT 'pending',
+    rejection_reason VARCHAR(50) NULL,
+    referrer_transaction_id INTEGER NULL REFERENCES coin_transactions(id),
+    referee_transaction_id INTEGER NULL REFERENCES coin_transactions(id),
+    rewarded_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT referrals_not_self CHECK (referrer_id <> referee_id)
+);
+
+-- Create trigger for updated_at
+CREATE TRIGGER update_referrals_updated_at 
+    BEFORE UPDATE ON referrals
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create indexes
+CREATE INDEX idx_users_normalized_email ON users(normalized_email);
+CREATE INDEX idx_referrals_referrer_id ON referrals(referrer_id, created_at DESC);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_coin_transactions_user_created_at;
\ No newline at end of file
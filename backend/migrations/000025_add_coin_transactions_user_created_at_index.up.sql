@@ -0,0 +1,3 @@
+-- Point-in-time balances and balance series sum a user's entries over a
+-- created_at range; including amount lets both be answered from the index.
+CREATE INDEX idx_coin_transactions_user_created_at ON coin_transactions(user_id, created_at) INCLUDE (amount);
\ No newline at end of file
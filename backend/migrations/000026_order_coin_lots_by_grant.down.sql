@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_coin_lots_user_open;
+CREATE INDEX idx_coin_lots_user_open ON coin_lots(user_id, expires_at, id) WHERE remaining_amount > 0;
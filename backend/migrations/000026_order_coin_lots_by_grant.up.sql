@@ -0,0 +1,4 @@
+-- Spends consume lots oldest first, so the open-lot index follows grant order
+-- instead of expiry.
+DROP INDEX IF EXISTS idx_coin_lots_user_open;
+CREATE INDEX idx_coin_lots_user_open ON coin_lots(user_id, created_at, id) WHERE remaining_amount > 0;
@@ -0,0 +1,44 @@
+// Package migrations embeds the SQL migrations and applies them, so the
+// binary carries the schema it was built for.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var FS embed.FS
+
+// Versions returns the version of every embedded migration in order
+func Versions() ([]uint, error) {
+	ups, err := fs.Glob(FS, "*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]uint, 0, len(ups))
+	for _, name := range ups {
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has no version prefix", name)
+		}
+		versions = append(versions, uint(version))
+	}
+	slices.Sort(versions)
+	return versions, nil
+}
+
+// LatestVersion returns the highest migration version in FS
+func LatestVersion() (uint, error) {
+	versions, err := Versions()
+	if err != nil || len(versions) == 0 {
+		return 0, err
+	}
+	return versions[len(versions)-1], nil
+}
@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersions_NumberedWithoutGaps(t *testing.T) {
+	versions, err := Versions()
+	require.NoError(t, err)
+	require.NotEmpty(t, versions)
+
+	for i, version := range versions {
+		assert.Equal(t, uint(i+1), version)
+	}
+
+	latest, err := LatestVersion()
+	require.NoError(t, err)
+	assert.Equal(t, versions[len(versions)-1], latest)
+}
+
+func TestMigrations_HaveDownFiles(t *testing.T) {
+	ups, err := fs.Glob(FS, "*.up.sql")
+	require.NoError(t, err)
+
+	for _, up := range ups {
+		down := strings.TrimSuffix(up, ".up.sql") + ".down.sql"
+		_, err := fs.Stat(FS, down)
+		assert.NoError(t, err, "%s has no down migration", up)
+	}
+}
+
+func TestMigrations_UseOneConvention(t *testing.T) {
+	files, err := fs.Glob(FS, "*.sql")
+	require.NoError(t, err)
+
+	for _, name := range files {
+		body, err := fs.ReadFile(FS, name)
+		require.NoError(t, err)
+		assert.NotContains(t, string(body), "+goose", "%s uses goose annotations", name)
+	}
+}
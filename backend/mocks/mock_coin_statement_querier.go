@@ -0,0 +1,4728 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	database "backend/internal/database"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pgtype "github.com/jackc/pgx/v5/pgtype"
+)
+
+// MockCoinStatementQuerier is an autogenerated mock type for the CoinStatementQuerier type
+type MockCoinStatementQuerier struct {
+	mock.Mock
+}
+
+type MockCoinStatementQuerier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCoinStatementQuerier) EXPECT() *MockCoinStatementQuerier_Expecter {
+	return &MockCoinStatementQuerier_Expecter{mock: &_m.Mock}
+}
+
+// BlockUserSpending provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) BlockUserSpending(ctx context.Context, arg database.BlockUserSpendingParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockUserSpending")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.BlockUserSpendingParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCoinStatementQuerier_BlockUserSpending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockUserSpending'
+type MockCoinStatementQuerier_BlockUserSpending_Call struct {
+	*mock.Call
+}
+
+// BlockUserSpending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.BlockUserSpendingParams
+func (_e *MockCoinStatementQuerier_Expecter) BlockUserSpending(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_BlockUserSpending_Call {
+	return &MockCoinStatementQuerier_BlockUserSpending_Call{Call: _e.mock.On("BlockUserSpending", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_BlockUserSpending_Call) Run(run func(ctx context.Context, arg database.BlockUserSpendingParams)) *MockCoinStatementQuerier_BlockUserSpending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.BlockUserSpendingParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_BlockUserSpending_Call) Return(_a0 error) *MockCoinStatementQuerier_BlockUserSpending_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_BlockUserSpending_Call) RunAndReturn(run func(context.Context, database.BlockUserSpendingParams) error) *MockCoinStatementQuerier_BlockUserSpending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckCartItemExists provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) CheckCartItemExists(ctx context.Context, arg database.CheckCartItemExistsParams) (bool, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckCartItemExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CheckCartItemExistsParams) (bool, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CheckCartItemExistsParams) bool); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CheckCartItemExistsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_CheckCartItemExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckCartItemExists'
+type MockCoinStatementQuerier_CheckCartItemExists_Call struct {
+	*mock.Call
+}
+
+// CheckCartItemExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CheckCartItemExistsParams
+func (_e *MockCoinStatementQuerier_Expecter) CheckCartItemExists(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_CheckCartItemExists_Call {
+	return &MockCoinStatementQuerier_CheckCartItemExists_Call{Call: _e.mock.On("CheckCartItemExists", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_CheckCartItemExists_Call) Run(run func(ctx context.Context, arg database.CheckCartItemExistsParams)) *MockCoinStatementQuerier_CheckCartItemExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CheckCartItemExistsParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CheckCartItemExists_Call) Return(_a0 bool, _a1 error) *MockCoinStatementQuerier_CheckCartItemExists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CheckCartItemExists_Call) RunAndReturn(run func(context.Context, database.CheckCartItemExistsParams) (bool, error)) *MockCoinStatementQuerier_CheckCartItemExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckEmailExists provides a mock function with given fields: ctx, email
+func (_m *MockCoinStatementQuerier) CheckEmailExists(ctx context.Context, email string) (bool, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckEmailExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_CheckEmailExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckEmailExists'
+type MockCoinStatementQuerier_CheckEmailExists_Call struct {
+	*mock.Call
+}
+
+// CheckEmailExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockCoinStatementQuerier_Expecter) CheckEmailExists(ctx interface{}, email interface{}) *MockCoinStatementQuerier_CheckEmailExists_Call {
+	return &MockCoinStatementQuerier_CheckEmailExists_Call{Call: _e.mock.On("CheckEmailExists", ctx, email)}
+}
+
+func (_c *MockCoinStatementQuerier_CheckEmailExists_Call) Run(run func(ctx context.Context, email string)) *MockCoinStatementQuerier_CheckEmailExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CheckEmailExists_Call) Return(_a0 bool, _a1 error) *MockCoinStatementQuerier_CheckEmailExists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CheckEmailExists_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockCoinStatementQuerier_CheckEmailExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckEmailExistsForOtherUser provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) CheckEmailExistsForOtherUser(ctx context.Context, arg database.CheckEmailExistsForOtherUserParams) (bool, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckEmailExistsForOtherUser")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CheckEmailExistsForOtherUserParams) (bool, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CheckEmailExistsForOtherUserParams) bool); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CheckEmailExistsForOtherUserParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_CheckEmailExistsForOtherUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckEmailExistsForOtherUser'
+type MockCoinStatementQuerier_CheckEmailExistsForOtherUser_Call struct {
+	*mock.Call
+}
+
+// CheckEmailExistsForOtherUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CheckEmailExistsForOtherUserParams
+func (_e *MockCoinStatementQuerier_Expecter) CheckEmailExistsForOtherUser(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_CheckEmailExistsForOtherUser_Call {
+	return &MockCoinStatementQuerier_CheckEmailExistsForOtherUser_Call{Call: _e.mock.On("CheckEmailExistsForOtherUser", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_CheckEmailExistsForOtherUser_Call) Run(run func(ctx context.Context, arg database.CheckEmailExistsForOtherUserParams)) *MockCoinStatementQuerier_CheckEmailExistsForOtherUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CheckEmailExistsForOtherUserParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CheckEmailExistsForOtherUser_Call) Return(_a0 bool, _a1 error) *MockCoinStatementQuerier_CheckEmailExistsForOtherUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CheckEmailExistsForOtherUser_Call) RunAndReturn(run func(context.Context, database.CheckEmailExistsForOtherUserParams) (bool, error)) *MockCoinStatementQuerier_CheckEmailExistsForOtherUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountOrdersByStatus provides a mock function with given fields: ctx
+func (_m *MockCoinStatementQuerier) CountOrdersByStatus(ctx context.Context) ([]database.CountOrdersByStatusRow, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountOrdersByStatus")
+	}
+
+	var r0 []database.CountOrdersByStatusRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]database.CountOrdersByStatusRow, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []database.CountOrdersByStatusRow); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CountOrdersByStatusRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_CountOrdersByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOrdersByStatus'
+type MockCoinStatementQuerier_CountOrdersByStatus_Call struct {
+	*mock.Call
+}
+
+// CountOrdersByStatus is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCoinStatementQuerier_Expecter) CountOrdersByStatus(ctx interface{}) *MockCoinStatementQuerier_CountOrdersByStatus_Call {
+	return &MockCoinStatementQuerier_CountOrdersByStatus_Call{Call: _e.mock.On("CountOrdersByStatus", ctx)}
+}
+
+func (_c *MockCoinStatementQuerier_CountOrdersByStatus_Call) Run(run func(ctx context.Context)) *MockCoinStatementQuerier_CountOrdersByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CountOrdersByStatus_Call) Return(_a0 []database.CountOrdersByStatusRow, _a1 error) *MockCoinStatementQuerier_CountOrdersByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CountOrdersByStatus_Call) RunAndReturn(run func(context.Context) ([]database.CountOrdersByStatusRow, error)) *MockCoinStatementQuerier_CountOrdersByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountSecurityEventsSince provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) CountSecurityEventsSince(ctx context.Context, arg database.CountSecurityEventsSinceParams) (int32, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountSecurityEventsSince")
+	}
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CountSecurityEventsSinceParams) (int32, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CountSecurityEventsSinceParams) int32); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CountSecurityEventsSinceParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_CountSecurityEventsSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountSecurityEventsSince'
+type MockCoinStatementQuerier_CountSecurityEventsSince_Call struct {
+	*mock.Call
+}
+
+// CountSecurityEventsSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CountSecurityEventsSinceParams
+func (_e *MockCoinStatementQuerier_Expecter) CountSecurityEventsSince(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_CountSecurityEventsSince_Call {
+	return &MockCoinStatementQuerier_CountSecurityEventsSince_Call{Call: _e.mock.On("CountSecurityEventsSince", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_CountSecurityEventsSince_Call) Run(run func(ctx context.Context, arg database.CountSecurityEventsSinceParams)) *MockCoinStatementQuerier_CountSecurityEventsSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CountSecurityEventsSinceParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CountSecurityEventsSince_Call) Return(_a0 int32, _a1 error) *MockCoinStatementQuerier_CountSecurityEventsSince_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CountSecurityEventsSince_Call) RunAndReturn(run func(context.Context, database.CountSecurityEventsSinceParams) (int32, error)) *MockCoinStatementQuerier_CountSecurityEventsSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountUsersByNormalizedEmail provides a mock function with given fields: ctx, normalizedEmail
+func (_m *MockCoinStatementQuerier) CountUsersByNormalizedEmail(ctx context.Context, normalizedEmail string) (int32, error) {
+	ret := _m.Called(ctx, normalizedEmail)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUsersByNormalizedEmail")
+	}
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int32, error)); ok {
+		return rf(ctx, normalizedEmail)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int32); ok {
+		r0 = rf(ctx, normalizedEmail)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, normalizedEmail)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_CountUsersByNormalizedEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUsersByNormalizedEmail'
+type MockCoinStatementQuerier_CountUsersByNormalizedEmail_Call struct {
+	*mock.Call
+}
+
+// CountUsersByNormalizedEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - normalizedEmail string
+func (_e *MockCoinStatementQuerier_Expecter) CountUsersByNormalizedEmail(ctx interface{}, normalizedEmail interface{}) *MockCoinStatementQuerier_CountUsersByNormalizedEmail_Call {
+	return &MockCoinStatementQuerier_CountUsersByNormalizedEmail_Call{Call: _e.mock.On("CountUsersByNormalizedEmail", ctx, normalizedEmail)}
+}
+
+func (_c *MockCoinStatementQuerier_CountUsersByNormalizedEmail_Call) Run(run func(ctx context.Context, normalizedEmail string)) *MockCoinStatementQuerier_CountUsersByNormalizedEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CountUsersByNormalizedEmail_Call) Return(_a0 int32, _a1 error) *MockCoinStatementQuerier_CountUsersByNormalizedEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CountUsersByNormalizedEmail_Call) RunAndReturn(run func(context.Context, string) (int32, error)) *MockCoinStatementQuerier_CountUsersByNormalizedEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCartItem provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) CreateCartItem(ctx context.Context, arg database.CreateCartItemParams) (database.CartItem, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCartItem")
+	}
+
+	var r0 database.CartItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCartItemParams) (database.CartItem, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCartItemParams) database.CartItem); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CartItem)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateCartItemParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_CreateCartItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCartItem'
+type MockCoinStatementQuerier_CreateCartItem_Call struct {
+	*mock.Call
+}
+
+// CreateCartItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateCartItemParams
+func (_e *MockCoinStatementQuerier_Expecter) CreateCartItem(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_CreateCartItem_Call {
+	return &MockCoinStatementQuerier_CreateCartItem_Call{Call: _e.mock.On("CreateCartItem", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_CreateCartItem_Call) Run(run func(ctx context.Context, arg database.CreateCartItemParams)) *MockCoinStatementQuerier_CreateCartItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateCartItemParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateCartItem_Call) Return(_a0 database.CartItem, _a1 error) *MockCoinStatementQuerier_CreateCartItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateCartItem_Call) RunAndReturn(run func(context.Context, database.CreateCartItemParams) (database.CartItem, error)) *MockCoinStatementQuerier_CreateCartItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCoinHold provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) CreateCoinHold(ctx context.Context, arg database.CreateCoinHoldParams) (database.CoinHold, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCoinHold")
+	}
+
+	var r0 database.CoinHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCoinHoldParams) (database.CoinHold, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCoinHoldParams) database.CoinHold); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CoinHold)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateCoinHoldParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_CreateCoinHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCoinHold'
+type MockCoinStatementQuerier_CreateCoinHold_Call struct {
+	*mock.Call
+}
+
+// CreateCoinHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateCoinHoldParams
+func (_e *MockCoinStatementQuerier_Expecter) CreateCoinHold(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_CreateCoinHold_Call {
+	return &MockCoinStatementQuerier_CreateCoinHold_Call{Call: _e.mock.On("CreateCoinHold", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_CreateCoinHold_Call) Run(run func(ctx context.Context, arg database.CreateCoinHoldParams)) *MockCoinStatementQuerier_CreateCoinHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateCoinHoldParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateCoinHold_Call) Return(_a0 database.CoinHold, _a1 error) *MockCoinStatementQuerier_CreateCoinHold_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateCoinHold_Call) RunAndReturn(run func(context.Context, database.CreateCoinHoldParams) (database.CoinHold, error)) *MockCoinStatementQuerier_CreateCoinHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCoinLot provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) CreateCoinLot(ctx context.Context, arg database.CreateCoinLotParams) (database.CoinLot, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCoinLot")
+	}
+
+	var r0 database.CoinLot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCoinLotParams) (database.CoinLot, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCoinLotParams) database.CoinLot); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CoinLot)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateCoinLotParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_CreateCoinLot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCoinLot'
+type MockCoinStatementQuerier_CreateCoinLot_Call struct {
+	*mock.Call
+}
+
+// CreateCoinLot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateCoinLotParams
+func (_e *MockCoinStatementQuerier_Expecter) CreateCoinLot(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_CreateCoinLot_Call {
+	return &MockCoinStatementQuerier_CreateCoinLot_Call{Call: _e.mock.On("CreateCoinLot", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_CreateCoinLot_Call) Run(run func(ctx context.Context, arg database.CreateCoinLotParams)) *MockCoinStatementQuerier_CreateCoinLot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateCoinLotParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateCoinLot_Call) Return(_a0 database.CoinLot, _a1 error) *MockCoinStatementQuerier_CreateCoinLot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateCoinLot_Call) RunAndReturn(run func(context.Context, database.CreateCoinLotParams) (database.CoinLot, error)) *MockCoinStatementQuerier_CreateCoinLot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCoinPack provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) CreateCoinPack(ctx context.Context, arg database.CreateCoinPackParams) (database.CoinPack, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCoinPack")
+	}
+
+	var r0 database.CoinPack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCoinPackParams) (database.CoinPack, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCoinPackParams) database.CoinPack); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CoinPack)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateCoinPackParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_CreateCoinPack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCoinPack'
+type MockCoinStatementQuerier_CreateCoinPack_Call struct {
+	*mock.Call
+}
+
+// CreateCoinPack is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateCoinPackParams
+func (_e *MockCoinStatementQuerier_Expecter) CreateCoinPack(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_CreateCoinPack_Call {
+	return &MockCoinStatementQuerier_CreateCoinPack_Call{Call: _e.mock.On("CreateCoinPack", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_CreateCoinPack_Call) Run(run func(ctx context.Context, arg database.CreateCoinPackParams)) *MockCoinStatementQuerier_CreateCoinPack_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateCoinPackParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateCoinPack_Call) Return(_a0 database.CoinPack, _a1 error) *MockCoinStatementQuerier_CreateCoinPack_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateCoinPack_Call) RunAndReturn(run func(context.Context, database.CreateCoinPackParams) (database.CoinPack, error)) *MockCoinStatementQuerier_CreateCoinPack_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCoinTransaction provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) CreateCoinTransaction(ctx context.Context, arg database.CreateCoinTransactionParams) (database.CoinTransaction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCoinTransaction")
+	}
+
+	var r0 database.CoinTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCoinTransactionParams) (database.CoinTransaction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCoinTransactionParams) database.CoinTransaction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CoinTransaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateCoinTransactionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_CreateCoinTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCoinTransaction'
+type MockCoinStatementQuerier_CreateCoinTransaction_Call struct {
+	*mock.Call
+}
+
+// CreateCoinTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateCoinTransactionParams
+func (_e *MockCoinStatementQuerier_Expecter) CreateCoinTransaction(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_CreateCoinTransaction_Call {
+	return &MockCoinStatementQuerier_CreateCoinTransaction_Call{Call: _e.mock.On("CreateCoinTransaction", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_CreateCoinTransaction_Call) Run(run func(ctx context.Context, arg database.CreateCoinTransactionParams)) *MockCoinStatementQuerier_CreateCoinTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateCoinTransactionParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateCoinTransaction_Call) Return(_a0 database.CoinTransaction, _a1 error) *MockCoinStatementQuerier_CreateCoinTransaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateCoinTransaction_Call) RunAndReturn(run func(context.Context, database.CreateCoinTransactionParams) (database.CoinTransaction, error)) *MockCoinStatementQuerier_CreateCoinTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateGiftCode provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) CreateGiftCode(ctx context.Context, arg database.CreateGiftCodeParams) (database.GiftCode, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGiftCode")
+	}
+
+	var r0 database.GiftCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateGiftCodeParams) (database.GiftCode, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateGiftCodeParams) database.GiftCode); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.GiftCode)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateGiftCodeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_CreateGiftCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateGiftCode'
+type MockCoinStatementQuerier_CreateGiftCode_Call struct {
+	*mock.Call
+}
+
+// CreateGiftCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateGiftCodeParams
+func (_e *MockCoinStatementQuerier_Expecter) CreateGiftCode(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_CreateGiftCode_Call {
+	return &MockCoinStatementQuerier_CreateGiftCode_Call{Call: _e.mock.On("CreateGiftCode", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_CreateGiftCode_Call) Run(run func(ctx context.Context, arg database.CreateGiftCodeParams)) *MockCoinStatementQuerier_CreateGiftCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateGiftCodeParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateGiftCode_Call) Return(_a0 database.GiftCode, _a1 error) *MockCoinStatementQuerier_CreateGiftCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateGiftCode_Call) RunAndReturn(run func(context.Context, database.CreateGiftCodeParams) (database.GiftCode, error)) *MockCoinStatementQuerier_CreateGiftCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateGiftCodeBatch provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) CreateGiftCodeBatch(ctx context.Context, arg database.CreateGiftCodeBatchParams) (database.GiftCodeBatch, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGiftCodeBatch")
+	}
+
+	var r0 database.GiftCodeBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateGiftCodeBatchParams) (database.GiftCodeBatch, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateGiftCodeBatchParams) database.GiftCodeBatch); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.GiftCodeBatch)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateGiftCodeBatchParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_CreateGiftCodeBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateGiftCodeBatch'
+type MockCoinStatementQuerier_CreateGiftCodeBatch_Call struct {
+	*mock.Call
+}
+
+// CreateGiftCodeBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateGiftCodeBatchParams
+func (_e *MockCoinStatementQuerier_Expecter) CreateGiftCodeBatch(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_CreateGiftCodeBatch_Call {
+	return &MockCoinStatementQuerier_CreateGiftCodeBatch_Call{Call: _e.mock.On("CreateGiftCodeBatch", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_CreateGiftCodeBatch_Call) Run(run func(ctx context.Context, arg database.CreateGiftCodeBatchParams)) *MockCoinStatementQuerier_CreateGiftCodeBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateGiftCodeBatchParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateGiftCodeBatch_Call) Return(_a0 database.GiftCodeBatch, _a1 error) *MockCoinStatementQuerier_CreateGiftCodeBatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateGiftCodeBatch_Call) RunAndReturn(run func(context.Context, database.CreateGiftCodeBatchParams) (database.GiftCodeBatch, error)) *MockCoinStatementQuerier_CreateGiftCodeBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateGiftCodeRedemption provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) CreateGiftCodeRedemption(ctx context.Context, arg database.CreateGiftCodeRedemptionParams) (database.GiftCodeRedemption, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGiftCodeRedemption")
+	}
+
+	var r0 database.GiftCodeRedemption
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateGiftCodeRedemptionParams) (database.GiftCodeRedemption, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateGiftCodeRedemptionParams) database.GiftCodeRedemption); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.GiftCodeRedemption)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateGiftCodeRedemptionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_CreateGiftCodeRedemption_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateGiftCodeRedemption'
+type MockCoinStatementQuerier_CreateGiftCodeRedemption_Call struct {
+	*mock.Call
+}
+
+// CreateGiftCodeRedemption is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateGiftCodeRedemptionParams
+func (_e *MockCoinStatementQuerier_Expecter) CreateGiftCodeRedemption(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_CreateGiftCodeRedemption_Call {
+	return &MockCoinStatementQuerier_CreateGiftCodeRedemption_Call{Call: _e.mock.On("CreateGiftCodeRedemption", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_CreateGiftCodeRedemption_Call) Run(run func(ctx context.Context, arg database.CreateGiftCodeRedemptionParams)) *MockCoinStatementQuerier_CreateGiftCodeRedemption_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateGiftCodeRedemptionParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateGiftCodeRedemption_Call) Return(_a0 database.GiftCodeRedemption, _a1 error) *MockCoinStatementQuerier_CreateGiftCodeRedemption_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateGiftCodeRedemption_Call) RunAndReturn(run func(context.Context, database.CreateGiftCodeRedemptionParams) (database.GiftCodeRedemption, error)) *MockCoinStatementQuerier_CreateGiftCodeRedemption_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateReferral provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) CreateReferral(ctx context.Context, arg database.CreateReferralParams) (database.Referral, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReferral")
+	}
+
+	var r0 database.Referral
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateReferralParams) (database.Referral, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateReferralParams) database.Referral); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.Referral)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateReferralParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_CreateReferral_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReferral'
+type MockCoinStatementQuerier_CreateReferral_Call struct {
+	*mock.Call
+}
+
+// CreateReferral is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateReferralParams
+func (_e *MockCoinStatementQuerier_Expecter) CreateReferral(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_CreateReferral_Call {
+	return &MockCoinStatementQuerier_CreateReferral_Call{Call: _e.mock.On("CreateReferral", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_CreateReferral_Call) Run(run func(ctx context.Context, arg database.CreateReferralParams)) *MockCoinStatementQuerier_CreateReferral_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateReferralParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateReferral_Call) Return(_a0 database.Referral, _a1 error) *MockCoinStatementQuerier_CreateReferral_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateReferral_Call) RunAndReturn(run func(context.Context, database.CreateReferralParams) (database.Referral, error)) *MockCoinStatementQuerier_CreateReferral_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSecurityEvent provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) CreateSecurityEvent(ctx context.Context, arg database.CreateSecurityEventParams) (database.SecurityEvent, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSecurityEvent")
+	}
+
+	var r0 database.SecurityEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateSecurityEventParams) (database.SecurityEvent, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateSecurityEventParams) database.SecurityEvent); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.SecurityEvent)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateSecurityEventParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_CreateSecurityEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSecurityEvent'
+type MockCoinStatementQuerier_CreateSecurityEvent_Call struct {
+	*mock.Call
+}
+
+// CreateSecurityEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateSecurityEventParams
+func (_e *MockCoinStatementQuerier_Expecter) CreateSecurityEvent(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_CreateSecurityEvent_Call {
+	return &MockCoinStatementQuerier_CreateSecurityEvent_Call{Call: _e.mock.On("CreateSecurityEvent", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_CreateSecurityEvent_Call) Run(run func(ctx context.Context, arg database.CreateSecurityEventParams)) *MockCoinStatementQuerier_CreateSecurityEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateSecurityEventParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateSecurityEvent_Call) Return(_a0 database.SecurityEvent, _a1 error) *MockCoinStatementQuerier_CreateSecurityEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateSecurityEvent_Call) RunAndReturn(run func(context.Context, database.CreateSecurityEventParams) (database.SecurityEvent, error)) *MockCoinStatementQuerier_CreateSecurityEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUser provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) CreateUser(ctx context.Context, arg database.CreateUserParams) (database.CreateUserRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 database.CreateUserRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateUserParams) (database.CreateUserRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateUserParams) database.CreateUserRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CreateUserRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateUserParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type MockCoinStatementQuerier_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateUserParams
+func (_e *MockCoinStatementQuerier_Expecter) CreateUser(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_CreateUser_Call {
+	return &MockCoinStatementQuerier_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_CreateUser_Call) Run(run func(ctx context.Context, arg database.CreateUserParams)) *MockCoinStatementQuerier_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateUserParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateUser_Call) Return(_a0 database.CreateUserRow, _a1 error) *MockCoinStatementQuerier_CreateUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_CreateUser_Call) RunAndReturn(run func(context.Context, database.CreateUserParams) (database.CreateUserRow, error)) *MockCoinStatementQuerier_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAllCartItemsByUser provides a mock function with given fields: ctx, userID
+func (_m *MockCoinStatementQuerier) DeleteAllCartItemsByUser(ctx context.Context, userID pgtype.UUID) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAllCartItemsByUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCoinStatementQuerier_DeleteAllCartItemsByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAllCartItemsByUser'
+type MockCoinStatementQuerier_DeleteAllCartItemsByUser_Call struct {
+	*mock.Call
+}
+
+// DeleteAllCartItemsByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID pgtype.UUID
+func (_e *MockCoinStatementQuerier_Expecter) DeleteAllCartItemsByUser(ctx interface{}, userID interface{}) *MockCoinStatementQuerier_DeleteAllCartItemsByUser_Call {
+	return &MockCoinStatementQuerier_DeleteAllCartItemsByUser_Call{Call: _e.mock.On("DeleteAllCartItemsByUser", ctx, userID)}
+}
+
+func (_c *MockCoinStatementQuerier_DeleteAllCartItemsByUser_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockCoinStatementQuerier_DeleteAllCartItemsByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_DeleteAllCartItemsByUser_Call) Return(_a0 error) *MockCoinStatementQuerier_DeleteAllCartItemsByUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_DeleteAllCartItemsByUser_Call) RunAndReturn(run func(context.Context, pgtype.UUID) error) *MockCoinStatementQuerier_DeleteAllCartItemsByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCartItem provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) DeleteCartItem(ctx context.Context, arg database.DeleteCartItemParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCartItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.DeleteCartItemParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCoinStatementQuerier_DeleteCartItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCartItem'
+type MockCoinStatementQuerier_DeleteCartItem_Call struct {
+	*mock.Call
+}
+
+// DeleteCartItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.DeleteCartItemParams
+func (_e *MockCoinStatementQuerier_Expecter) DeleteCartItem(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_DeleteCartItem_Call {
+	return &MockCoinStatementQuerier_DeleteCartItem_Call{Call: _e.mock.On("DeleteCartItem", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_DeleteCartItem_Call) Run(run func(ctx context.Context, arg database.DeleteCartItemParams)) *MockCoinStatementQuerier_DeleteCartItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.DeleteCartItemParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_DeleteCartItem_Call) Return(_a0 error) *MockCoinStatementQuerier_DeleteCartItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_DeleteCartItem_Call) RunAndReturn(run func(context.Context, database.DeleteCartItemParams) error) *MockCoinStatementQuerier_DeleteCartItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCategoryCashbackRate provides a mock function with given fields: ctx, categoryID
+func (_m *MockCoinStatementQuerier) DeleteCategoryCashbackRate(ctx context.Context, categoryID int32) (int64, error) {
+	ret := _m.Called(ctx, categoryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCategoryCashbackRate")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (int64, error)); ok {
+		return rf(ctx, categoryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) int64); ok {
+		r0 = rf(ctx, categoryID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, categoryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_DeleteCategoryCashbackRate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCategoryCashbackRate'
+type MockCoinStatementQuerier_DeleteCategoryCashbackRate_Call struct {
+	*mock.Call
+}
+
+// DeleteCategoryCashbackRate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - categoryID int32
+func (_e *MockCoinStatementQuerier_Expecter) DeleteCategoryCashbackRate(ctx interface{}, categoryID interface{}) *MockCoinStatementQuerier_DeleteCategoryCashbackRate_Call {
+	return &MockCoinStatementQuerier_DeleteCategoryCashbackRate_Call{Call: _e.mock.On("DeleteCategoryCashbackRate", ctx, categoryID)}
+}
+
+func (_c *MockCoinStatementQuerier_DeleteCategoryCashbackRate_Call) Run(run func(ctx context.Context, categoryID int32)) *MockCoinStatementQuerier_DeleteCategoryCashbackRate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_DeleteCategoryCashbackRate_Call) Return(_a0 int64, _a1 error) *MockCoinStatementQuerier_DeleteCategoryCashbackRate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_DeleteCategoryCashbackRate_Call) RunAndReturn(run func(context.Context, int32) (int64, error)) *MockCoinStatementQuerier_DeleteCategoryCashbackRate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUser provides a mock function with given fields: ctx, id
+func (_m *MockCoinStatementQuerier) DeleteUser(ctx context.Context, id pgtype.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCoinStatementQuerier_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type MockCoinStatementQuerier_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id pgtype.UUID
+func (_e *MockCoinStatementQuerier_Expecter) DeleteUser(ctx interface{}, id interface{}) *MockCoinStatementQuerier_DeleteUser_Call {
+	return &MockCoinStatementQuerier_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, id)}
+}
+
+func (_c *MockCoinStatementQuerier_DeleteUser_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockCoinStatementQuerier_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_DeleteUser_Call) Return(_a0 error) *MockCoinStatementQuerier_DeleteUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_DeleteUser_Call) RunAndReturn(run func(context.Context, pgtype.UUID) error) *MockCoinStatementQuerier_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllCategories provides a mock function with given fields: ctx
+func (_m *MockCoinStatementQuerier) GetAllCategories(ctx context.Context) ([]database.Category, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllCategories")
+	}
+
+	var r0 []database.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]database.Category, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []database.Category); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.Category)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetAllCategories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllCategories'
+type MockCoinStatementQuerier_GetAllCategories_Call struct {
+	*mock.Call
+}
+
+// GetAllCategories is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCoinStatementQuerier_Expecter) GetAllCategories(ctx interface{}) *MockCoinStatementQuerier_GetAllCategories_Call {
+	return &MockCoinStatementQuerier_GetAllCategories_Call{Call: _e.mock.On("GetAllCategories", ctx)}
+}
+
+func (_c *MockCoinStatementQuerier_GetAllCategories_Call) Run(run func(ctx context.Context)) *MockCoinStatementQuerier_GetAllCategories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetAllCategories_Call) Return(_a0 []database.Category, _a1 error) *MockCoinStatementQuerier_GetAllCategories_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetAllCategories_Call) RunAndReturn(run func(context.Context) ([]database.Category, error)) *MockCoinStatementQuerier_GetAllCategories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCartItemsByUser provides a mock function with given fields: ctx, userID
+func (_m *MockCoinStatementQuerier) GetCartItemsByUser(ctx context.Context, userID pgtype.UUID) ([]database.CartItem, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCartItemsByUser")
+	}
+
+	var r0 []database.CartItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) ([]database.CartItem, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) []database.CartItem); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CartItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetCartItemsByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCartItemsByUser'
+type MockCoinStatementQuerier_GetCartItemsByUser_Call struct {
+	*mock.Call
+}
+
+// GetCartItemsByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID pgtype.UUID
+func (_e *MockCoinStatementQuerier_Expecter) GetCartItemsByUser(ctx interface{}, userID interface{}) *MockCoinStatementQuerier_GetCartItemsByUser_Call {
+	return &MockCoinStatementQuerier_GetCartItemsByUser_Call{Call: _e.mock.On("GetCartItemsByUser", ctx, userID)}
+}
+
+func (_c *MockCoinStatementQuerier_GetCartItemsByUser_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockCoinStatementQuerier_GetCartItemsByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCartItemsByUser_Call) Return(_a0 []database.CartItem, _a1 error) *MockCoinStatementQuerier_GetCartItemsByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCartItemsByUser_Call) RunAndReturn(run func(context.Context, pgtype.UUID) ([]database.CartItem, error)) *MockCoinStatementQuerier_GetCartItemsByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCategoryByID provides a mock function with given fields: ctx, id
+func (_m *MockCoinStatementQuerier) GetCategoryByID(ctx context.Context, id int32) (database.Category, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCategoryByID")
+	}
+
+	var r0 database.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.Category, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.Category); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.Category)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetCategoryByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCategoryByID'
+type MockCoinStatementQuerier_GetCategoryByID_Call struct {
+	*mock.Call
+}
+
+// GetCategoryByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockCoinStatementQuerier_Expecter) GetCategoryByID(ctx interface{}, id interface{}) *MockCoinStatementQuerier_GetCategoryByID_Call {
+	return &MockCoinStatementQuerier_GetCategoryByID_Call{Call: _e.mock.On("GetCategoryByID", ctx, id)}
+}
+
+func (_c *MockCoinStatementQuerier_GetCategoryByID_Call) Run(run func(ctx context.Context, id int32)) *MockCoinStatementQuerier_GetCategoryByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCategoryByID_Call) Return(_a0 database.Category, _a1 error) *MockCoinStatementQuerier_GetCategoryByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCategoryByID_Call) RunAndReturn(run func(context.Context, int32) (database.Category, error)) *MockCoinStatementQuerier_GetCategoryByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoinBalanceAt provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) GetCoinBalanceAt(ctx context.Context, arg database.GetCoinBalanceAtParams) (int32, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoinBalanceAt")
+	}
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetCoinBalanceAtParams) (int32, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetCoinBalanceAtParams) int32); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetCoinBalanceAtParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetCoinBalanceAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoinBalanceAt'
+type MockCoinStatementQuerier_GetCoinBalanceAt_Call struct {
+	*mock.Call
+}
+
+// GetCoinBalanceAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.GetCoinBalanceAtParams
+func (_e *MockCoinStatementQuerier_Expecter) GetCoinBalanceAt(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_GetCoinBalanceAt_Call {
+	return &MockCoinStatementQuerier_GetCoinBalanceAt_Call{Call: _e.mock.On("GetCoinBalanceAt", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinBalanceAt_Call) Run(run func(ctx context.Context, arg database.GetCoinBalanceAtParams)) *MockCoinStatementQuerier_GetCoinBalanceAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.GetCoinBalanceAtParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinBalanceAt_Call) Return(_a0 int32, _a1 error) *MockCoinStatementQuerier_GetCoinBalanceAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinBalanceAt_Call) RunAndReturn(run func(context.Context, database.GetCoinBalanceAtParams) (int32, error)) *MockCoinStatementQuerier_GetCoinBalanceAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoinHoldByID provides a mock function with given fields: ctx, id
+func (_m *MockCoinStatementQuerier) GetCoinHoldByID(ctx context.Context, id int32) (database.CoinHold, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoinHoldByID")
+	}
+
+	var r0 database.CoinHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.CoinHold, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.CoinHold); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.CoinHold)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetCoinHoldByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoinHoldByID'
+type MockCoinStatementQuerier_GetCoinHoldByID_Call struct {
+	*mock.Call
+}
+
+// GetCoinHoldByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockCoinStatementQuerier_Expecter) GetCoinHoldByID(ctx interface{}, id interface{}) *MockCoinStatementQuerier_GetCoinHoldByID_Call {
+	return &MockCoinStatementQuerier_GetCoinHoldByID_Call{Call: _e.mock.On("GetCoinHoldByID", ctx, id)}
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinHoldByID_Call) Run(run func(ctx context.Context, id int32)) *MockCoinStatementQuerier_GetCoinHoldByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinHoldByID_Call) Return(_a0 database.CoinHold, _a1 error) *MockCoinStatementQuerier_GetCoinHoldByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinHoldByID_Call) RunAndReturn(run func(context.Context, int32) (database.CoinHold, error)) *MockCoinStatementQuerier_GetCoinHoldByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoinHoldForUpdate provides a mock function with given fields: ctx, id
+func (_m *MockCoinStatementQuerier) GetCoinHoldForUpdate(ctx context.Context, id int32) (database.CoinHold, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoinHoldForUpdate")
+	}
+
+	var r0 database.CoinHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.CoinHold, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.CoinHold); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.CoinHold)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetCoinHoldForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoinHoldForUpdate'
+type MockCoinStatementQuerier_GetCoinHoldForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetCoinHoldForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockCoinStatementQuerier_Expecter) GetCoinHoldForUpdate(ctx interface{}, id interface{}) *MockCoinStatementQuerier_GetCoinHoldForUpdate_Call {
+	return &MockCoinStatementQuerier_GetCoinHoldForUpdate_Call{Call: _e.mock.On("GetCoinHoldForUpdate", ctx, id)}
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinHoldForUpdate_Call) Run(run func(ctx context.Context, id int32)) *MockCoinStatementQuerier_GetCoinHoldForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinHoldForUpdate_Call) Return(_a0 database.CoinHold, _a1 error) *MockCoinStatementQuerier_GetCoinHoldForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinHoldForUpdate_Call) RunAndReturn(run func(context.Context, int32) (database.CoinHold, error)) *MockCoinStatementQuerier_GetCoinHoldForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoinLotForUpdate provides a mock function with given fields: ctx, id
+func (_m *MockCoinStatementQuerier) GetCoinLotForUpdate(ctx context.Context, id int32) (database.CoinLot, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoinLotForUpdate")
+	}
+
+	var r0 database.CoinLot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.CoinLot, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.CoinLot); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.CoinLot)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetCoinLotForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoinLotForUpdate'
+type MockCoinStatementQuerier_GetCoinLotForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetCoinLotForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockCoinStatementQuerier_Expecter) GetCoinLotForUpdate(ctx interface{}, id interface{}) *MockCoinStatementQuerier_GetCoinLotForUpdate_Call {
+	return &MockCoinStatementQuerier_GetCoinLotForUpdate_Call{Call: _e.mock.On("GetCoinLotForUpdate", ctx, id)}
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinLotForUpdate_Call) Run(run func(ctx context.Context, id int32)) *MockCoinStatementQuerier_GetCoinLotForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinLotForUpdate_Call) Return(_a0 database.CoinLot, _a1 error) *MockCoinStatementQuerier_GetCoinLotForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinLotForUpdate_Call) RunAndReturn(run func(context.Context, int32) (database.CoinLot, error)) *MockCoinStatementQuerier_GetCoinLotForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoinPackByID provides a mock function with given fields: ctx, id
+func (_m *MockCoinStatementQuerier) GetCoinPackByID(ctx context.Context, id int32) (database.CoinPack, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoinPackByID")
+	}
+
+	var r0 database.CoinPack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.CoinPack, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.CoinPack); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.CoinPack)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetCoinPackByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoinPackByID'
+type MockCoinStatementQuerier_GetCoinPackByID_Call struct {
+	*mock.Call
+}
+
+// GetCoinPackByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockCoinStatementQuerier_Expecter) GetCoinPackByID(ctx interface{}, id interface{}) *MockCoinStatementQuerier_GetCoinPackByID_Call {
+	return &MockCoinStatementQuerier_GetCoinPackByID_Call{Call: _e.mock.On("GetCoinPackByID", ctx, id)}
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinPackByID_Call) Run(run func(ctx context.Context, id int32)) *MockCoinStatementQuerier_GetCoinPackByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinPackByID_Call) Return(_a0 database.CoinPack, _a1 error) *MockCoinStatementQuerier_GetCoinPackByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinPackByID_Call) RunAndReturn(run func(context.Context, int32) (database.CoinPack, error)) *MockCoinStatementQuerier_GetCoinPackByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoinTransactionByID provides a mock function with given fields: ctx, id
+func (_m *MockCoinStatementQuerier) GetCoinTransactionByID(ctx context.Context, id int32) (database.CoinTransaction, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoinTransactionByID")
+	}
+
+	var r0 database.CoinTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.CoinTransaction, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.CoinTransaction); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.CoinTransaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetCoinTransactionByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoinTransactionByID'
+type MockCoinStatementQuerier_GetCoinTransactionByID_Call struct {
+	*mock.Call
+}
+
+// GetCoinTransactionByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockCoinStatementQuerier_Expecter) GetCoinTransactionByID(ctx interface{}, id interface{}) *MockCoinStatementQuerier_GetCoinTransactionByID_Call {
+	return &MockCoinStatementQuerier_GetCoinTransactionByID_Call{Call: _e.mock.On("GetCoinTransactionByID", ctx, id)}
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinTransactionByID_Call) Run(run func(ctx context.Context, id int32)) *MockCoinStatementQuerier_GetCoinTransactionByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinTransactionByID_Call) Return(_a0 database.CoinTransaction, _a1 error) *MockCoinStatementQuerier_GetCoinTransactionByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinTransactionByID_Call) RunAndReturn(run func(context.Context, int32) (database.CoinTransaction, error)) *MockCoinStatementQuerier_GetCoinTransactionByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoinTransactionByOrderAndType provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) GetCoinTransactionByOrderAndType(ctx context.Context, arg database.GetCoinTransactionByOrderAndTypeParams) (database.CoinTransaction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoinTransactionByOrderAndType")
+	}
+
+	var r0 database.CoinTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetCoinTransactionByOrderAndTypeParams) (database.CoinTransaction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetCoinTransactionByOrderAndTypeParams) database.CoinTransaction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CoinTransaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetCoinTransactionByOrderAndTypeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetCoinTransactionByOrderAndType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoinTransactionByOrderAndType'
+type MockCoinStatementQuerier_GetCoinTransactionByOrderAndType_Call struct {
+	*mock.Call
+}
+
+// GetCoinTransactionByOrderAndType is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.GetCoinTransactionByOrderAndTypeParams
+func (_e *MockCoinStatementQuerier_Expecter) GetCoinTransactionByOrderAndType(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_GetCoinTransactionByOrderAndType_Call {
+	return &MockCoinStatementQuerier_GetCoinTransactionByOrderAndType_Call{Call: _e.mock.On("GetCoinTransactionByOrderAndType", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinTransactionByOrderAndType_Call) Run(run func(ctx context.Context, arg database.GetCoinTransactionByOrderAndTypeParams)) *MockCoinStatementQuerier_GetCoinTransactionByOrderAndType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.GetCoinTransactionByOrderAndTypeParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinTransactionByOrderAndType_Call) Return(_a0 database.CoinTransaction, _a1 error) *MockCoinStatementQuerier_GetCoinTransactionByOrderAndType_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinTransactionByOrderAndType_Call) RunAndReturn(run func(context.Context, database.GetCoinTransactionByOrderAndTypeParams) (database.CoinTransaction, error)) *MockCoinStatementQuerier_GetCoinTransactionByOrderAndType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoinTransactionsByUserID provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) GetCoinTransactionsByUserID(ctx context.Context, arg database.GetCoinTransactionsByUserIDParams) ([]database.CoinTransaction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoinTransactionsByUserID")
+	}
+
+	var r0 []database.CoinTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetCoinTransactionsByUserIDParams) ([]database.CoinTransaction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetCoinTransactionsByUserIDParams) []database.CoinTransaction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetCoinTransactionsByUserIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetCoinTransactionsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoinTransactionsByUserID'
+type MockCoinStatementQuerier_GetCoinTransactionsByUserID_Call struct {
+	*mock.Call
+}
+
+// GetCoinTransactionsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.GetCoinTransactionsByUserIDParams
+func (_e *MockCoinStatementQuerier_Expecter) GetCoinTransactionsByUserID(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_GetCoinTransactionsByUserID_Call {
+	return &MockCoinStatementQuerier_GetCoinTransactionsByUserID_Call{Call: _e.mock.On("GetCoinTransactionsByUserID", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinTransactionsByUserID_Call) Run(run func(ctx context.Context, arg database.GetCoinTransactionsByUserIDParams)) *MockCoinStatementQuerier_GetCoinTransactionsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.GetCoinTransactionsByUserIDParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinTransactionsByUserID_Call) Return(_a0 []database.CoinTransaction, _a1 error) *MockCoinStatementQuerier_GetCoinTransactionsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetCoinTransactionsByUserID_Call) RunAndReturn(run func(context.Context, database.GetCoinTransactionsByUserIDParams) ([]database.CoinTransaction, error)) *MockCoinStatementQuerier_GetCoinTransactionsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetExpiredCoinLotTotal provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) GetExpiredCoinLotTotal(ctx context.Context, arg database.GetExpiredCoinLotTotalParams) (int32, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpiredCoinLotTotal")
+	}
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetExpiredCoinLotTotalParams) (int32, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetExpiredCoinLotTotalParams) int32); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetExpiredCoinLotTotalParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetExpiredCoinLotTotal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExpiredCoinLotTotal'
+type MockCoinStatementQuerier_GetExpiredCoinLotTotal_Call struct {
+	*mock.Call
+}
+
+// GetExpiredCoinLotTotal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.GetExpiredCoinLotTotalParams
+func (_e *MockCoinStatementQuerier_Expecter) GetExpiredCoinLotTotal(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_GetExpiredCoinLotTotal_Call {
+	return &MockCoinStatementQuerier_GetExpiredCoinLotTotal_Call{Call: _e.mock.On("GetExpiredCoinLotTotal", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_GetExpiredCoinLotTotal_Call) Run(run func(ctx context.Context, arg database.GetExpiredCoinLotTotalParams)) *MockCoinStatementQuerier_GetExpiredCoinLotTotal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.GetExpiredCoinLotTotalParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetExpiredCoinLotTotal_Call) Return(_a0 int32, _a1 error) *MockCoinStatementQuerier_GetExpiredCoinLotTotal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetExpiredCoinLotTotal_Call) RunAndReturn(run func(context.Context, database.GetExpiredCoinLotTotalParams) (int32, error)) *MockCoinStatementQuerier_GetExpiredCoinLotTotal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGiftCodeBatchByID provides a mock function with given fields: ctx, id
+func (_m *MockCoinStatementQuerier) GetGiftCodeBatchByID(ctx context.Context, id int32) (database.GiftCodeBatch, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGiftCodeBatchByID")
+	}
+
+	var r0 database.GiftCodeBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.GiftCodeBatch, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.GiftCodeBatch); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.GiftCodeBatch)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetGiftCodeBatchByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGiftCodeBatchByID'
+type MockCoinStatementQuerier_GetGiftCodeBatchByID_Call struct {
+	*mock.Call
+}
+
+// GetGiftCodeBatchByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockCoinStatementQuerier_Expecter) GetGiftCodeBatchByID(ctx interface{}, id interface{}) *MockCoinStatementQuerier_GetGiftCodeBatchByID_Call {
+	return &MockCoinStatementQuerier_GetGiftCodeBatchByID_Call{Call: _e.mock.On("GetGiftCodeBatchByID", ctx, id)}
+}
+
+func (_c *MockCoinStatementQuerier_GetGiftCodeBatchByID_Call) Run(run func(ctx context.Context, id int32)) *MockCoinStatementQuerier_GetGiftCodeBatchByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetGiftCodeBatchByID_Call) Return(_a0 database.GiftCodeBatch, _a1 error) *MockCoinStatementQuerier_GetGiftCodeBatchByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetGiftCodeBatchByID_Call) RunAndReturn(run func(context.Context, int32) (database.GiftCodeBatch, error)) *MockCoinStatementQuerier_GetGiftCodeBatchByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGiftCodeByHashForUpdate provides a mock function with given fields: ctx, codeHash
+func (_m *MockCoinStatementQuerier) GetGiftCodeByHashForUpdate(ctx context.Context, codeHash string) (database.GiftCode, error) {
+	ret := _m.Called(ctx, codeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGiftCodeByHashForUpdate")
+	}
+
+	var r0 database.GiftCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (database.GiftCode, error)); ok {
+		return rf(ctx, codeHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) database.GiftCode); ok {
+		r0 = rf(ctx, codeHash)
+	} else {
+		r0 = ret.Get(0).(database.GiftCode)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, codeHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetGiftCodeByHashForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGiftCodeByHashForUpdate'
+type MockCoinStatementQuerier_GetGiftCodeByHashForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetGiftCodeByHashForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - codeHash string
+func (_e *MockCoinStatementQuerier_Expecter) GetGiftCodeByHashForUpdate(ctx interface{}, codeHash interface{}) *MockCoinStatementQuerier_GetGiftCodeByHashForUpdate_Call {
+	return &MockCoinStatementQuerier_GetGiftCodeByHashForUpdate_Call{Call: _e.mock.On("GetGiftCodeByHashForUpdate", ctx, codeHash)}
+}
+
+func (_c *MockCoinStatementQuerier_GetGiftCodeByHashForUpdate_Call) Run(run func(ctx context.Context, codeHash string)) *MockCoinStatementQuerier_GetGiftCodeByHashForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetGiftCodeByHashForUpdate_Call) Return(_a0 database.GiftCode, _a1 error) *MockCoinStatementQuerier_GetGiftCodeByHashForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetGiftCodeByHashForUpdate_Call) RunAndReturn(run func(context.Context, string) (database.GiftCode, error)) *MockCoinStatementQuerier_GetGiftCodeByHashForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrderByID provides a mock function with given fields: ctx, id
+func (_m *MockCoinStatementQuerier) GetOrderByID(ctx context.Context, id int32) (database.Order, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrderByID")
+	}
+
+	var r0 database.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.Order, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.Order); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.Order)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetOrderByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrderByID'
+type MockCoinStatementQuerier_GetOrderByID_Call struct {
+	*mock.Call
+}
+
+// GetOrderByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockCoinStatementQuerier_Expecter) GetOrderByID(ctx interface{}, id interface{}) *MockCoinStatementQuerier_GetOrderByID_Call {
+	return &MockCoinStatementQuerier_GetOrderByID_Call{Call: _e.mock.On("GetOrderByID", ctx, id)}
+}
+
+func (_c *MockCoinStatementQuerier_GetOrderByID_Call) Run(run func(ctx context.Context, id int32)) *MockCoinStatementQuerier_GetOrderByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetOrderByID_Call) Return(_a0 database.Order, _a1 error) *MockCoinStatementQuerier_GetOrderByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetOrderByID_Call) RunAndReturn(run func(context.Context, int32) (database.Order, error)) *MockCoinStatementQuerier_GetOrderByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductByID provides a mock function with given fields: ctx, id
+func (_m *MockCoinStatementQuerier) GetProductByID(ctx context.Context, id int32) (database.Product, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductByID")
+	}
+
+	var r0 database.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.Product, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.Product); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.Product)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetProductByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductByID'
+type MockCoinStatementQuerier_GetProductByID_Call struct {
+	*mock.Call
+}
+
+// GetProductByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockCoinStatementQuerier_Expecter) GetProductByID(ctx interface{}, id interface{}) *MockCoinStatementQuerier_GetProductByID_Call {
+	return &MockCoinStatementQuerier_GetProductByID_Call{Call: _e.mock.On("GetProductByID", ctx, id)}
+}
+
+func (_c *MockCoinStatementQuerier_GetProductByID_Call) Run(run func(ctx context.Context, id int32)) *MockCoinStatementQuerier_GetProductByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetProductByID_Call) Return(_a0 database.Product, _a1 error) *MockCoinStatementQuerier_GetProductByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetProductByID_Call) RunAndReturn(run func(context.Context, int32) (database.Product, error)) *MockCoinStatementQuerier_GetProductByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReferralByRefereeID provides a mock function with given fields: ctx, refereeID
+func (_m *MockCoinStatementQuerier) GetReferralByRefereeID(ctx context.Context, refereeID pgtype.UUID) (database.Referral, error) {
+	ret := _m.Called(ctx, refereeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReferralByRefereeID")
+	}
+
+	var r0 database.Referral
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (database.Referral, error)); ok {
+		return rf(ctx, refereeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) database.Referral); ok {
+		r0 = rf(ctx, refereeID)
+	} else {
+		r0 = ret.Get(0).(database.Referral)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = rf(ctx, refereeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetReferralByRefereeID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReferralByRefereeID'
+type MockCoinStatementQuerier_GetReferralByRefereeID_Call struct {
+	*mock.Call
+}
+
+// GetReferralByRefereeID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refereeID pgtype.UUID
+func (_e *MockCoinStatementQuerier_Expecter) GetReferralByRefereeID(ctx interface{}, refereeID interface{}) *MockCoinStatementQuerier_GetReferralByRefereeID_Call {
+	return &MockCoinStatementQuerier_GetReferralByRefereeID_Call{Call: _e.mock.On("GetReferralByRefereeID", ctx, refereeID)}
+}
+
+func (_c *MockCoinStatementQuerier_GetReferralByRefereeID_Call) Run(run func(ctx context.Context, refereeID pgtype.UUID)) *MockCoinStatementQuerier_GetReferralByRefereeID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetReferralByRefereeID_Call) Return(_a0 database.Referral, _a1 error) *MockCoinStatementQuerier_GetReferralByRefereeID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetReferralByRefereeID_Call) RunAndReturn(run func(context.Context, pgtype.UUID) (database.Referral, error)) *MockCoinStatementQuerier_GetReferralByRefereeID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReferralForUpdate provides a mock function with given fields: ctx, id
+func (_m *MockCoinStatementQuerier) GetReferralForUpdate(ctx context.Context, id int32) (database.Referral, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReferralForUpdate")
+	}
+
+	var r0 database.Referral
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.Referral, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.Referral); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.Referral)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetReferralForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReferralForUpdate'
+type MockCoinStatementQuerier_GetReferralForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetReferralForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockCoinStatementQuerier_Expecter) GetReferralForUpdate(ctx interface{}, id interface{}) *MockCoinStatementQuerier_GetReferralForUpdate_Call {
+	return &MockCoinStatementQuerier_GetReferralForUpdate_Call{Call: _e.mock.On("GetReferralForUpdate", ctx, id)}
+}
+
+func (_c *MockCoinStatementQuerier_GetReferralForUpdate_Call) Run(run func(ctx context.Context, id int32)) *MockCoinStatementQuerier_GetReferralForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetReferralForUpdate_Call) Return(_a0 database.Referral, _a1 error) *MockCoinStatementQuerier_GetReferralForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetReferralForUpdate_Call) RunAndReturn(run func(context.Context, int32) (database.Referral, error)) *MockCoinStatementQuerier_GetReferralForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSpendActivity provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) GetSpendActivity(ctx context.Context, arg database.GetSpendActivityParams) (database.GetSpendActivityRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSpendActivity")
+	}
+
+	var r0 database.GetSpendActivityRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetSpendActivityParams) (database.GetSpendActivityRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetSpendActivityParams) database.GetSpendActivityRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.GetSpendActivityRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetSpendActivityParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetSpendActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSpendActivity'
+type MockCoinStatementQuerier_GetSpendActivity_Call struct {
+	*mock.Call
+}
+
+// GetSpendActivity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.GetSpendActivityParams
+func (_e *MockCoinStatementQuerier_Expecter) GetSpendActivity(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_GetSpendActivity_Call {
+	return &MockCoinStatementQuerier_GetSpendActivity_Call{Call: _e.mock.On("GetSpendActivity", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_GetSpendActivity_Call) Run(run func(ctx context.Context, arg database.GetSpendActivityParams)) *MockCoinStatementQuerier_GetSpendActivity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.GetSpendActivityParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetSpendActivity_Call) Return(_a0 database.GetSpendActivityRow, _a1 error) *MockCoinStatementQuerier_GetSpendActivity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetSpendActivity_Call) RunAndReturn(run func(context.Context, database.GetSpendActivityParams) (database.GetSpendActivityRow, error)) *MockCoinStatementQuerier_GetSpendActivity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUpcomingCoinExpiries provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) GetUpcomingCoinExpiries(ctx context.Context, arg database.GetUpcomingCoinExpiriesParams) ([]database.GetUpcomingCoinExpiriesRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUpcomingCoinExpiries")
+	}
+
+	var r0 []database.GetUpcomingCoinExpiriesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetUpcomingCoinExpiriesParams) ([]database.GetUpcomingCoinExpiriesRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetUpcomingCoinExpiriesParams) []database.GetUpcomingCoinExpiriesRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.GetUpcomingCoinExpiriesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetUpcomingCoinExpiriesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetUpcomingCoinExpiries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUpcomingCoinExpiries'
+type MockCoinStatementQuerier_GetUpcomingCoinExpiries_Call struct {
+	*mock.Call
+}
+
+// GetUpcomingCoinExpiries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.GetUpcomingCoinExpiriesParams
+func (_e *MockCoinStatementQuerier_Expecter) GetUpcomingCoinExpiries(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_GetUpcomingCoinExpiries_Call {
+	return &MockCoinStatementQuerier_GetUpcomingCoinExpiries_Call{Call: _e.mock.On("GetUpcomingCoinExpiries", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_GetUpcomingCoinExpiries_Call) Run(run func(ctx context.Context, arg database.GetUpcomingCoinExpiriesParams)) *MockCoinStatementQuerier_GetUpcomingCoinExpiries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.GetUpcomingCoinExpiriesParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetUpcomingCoinExpiries_Call) Return(_a0 []database.GetUpcomingCoinExpiriesRow, _a1 error) *MockCoinStatementQuerier_GetUpcomingCoinExpiries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetUpcomingCoinExpiries_Call) RunAndReturn(run func(context.Context, database.GetUpcomingCoinExpiriesParams) ([]database.GetUpcomingCoinExpiriesRow, error)) *MockCoinStatementQuerier_GetUpcomingCoinExpiries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByEmail provides a mock function with given fields: ctx, email
+func (_m *MockCoinStatementQuerier) GetUserByEmail(ctx context.Context, email string) (database.User, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByEmail")
+	}
+
+	var r0 database.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (database.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) database.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(database.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetUserByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByEmail'
+type MockCoinStatementQuerier_GetUserByEmail_Call struct {
+	*mock.Call
+}
+
+// GetUserByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockCoinStatementQuerier_Expecter) GetUserByEmail(ctx interface{}, email interface{}) *MockCoinStatementQuerier_GetUserByEmail_Call {
+	return &MockCoinStatementQuerier_GetUserByEmail_Call{Call: _e.mock.On("GetUserByEmail", ctx, email)}
+}
+
+func (_c *MockCoinStatementQuerier_GetUserByEmail_Call) Run(run func(ctx context.Context, email string)) *MockCoinStatementQuerier_GetUserByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetUserByEmail_Call) Return(_a0 database.User, _a1 error) *MockCoinStatementQuerier_GetUserByEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetUserByEmail_Call) RunAndReturn(run func(context.Context, string) (database.User, error)) *MockCoinStatementQuerier_GetUserByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByID provides a mock function with given fields: ctx, id
+func (_m *MockCoinStatementQuerier) GetUserByID(ctx context.Context, id pgtype.UUID) (database.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 database.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (database.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) database.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type MockCoinStatementQuerier_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id pgtype.UUID
+func (_e *MockCoinStatementQuerier_Expecter) GetUserByID(ctx interface{}, id interface{}) *MockCoinStatementQuerier_GetUserByID_Call {
+	return &MockCoinStatementQuerier_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, id)}
+}
+
+func (_c *MockCoinStatementQuerier_GetUserByID_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockCoinStatementQuerier_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetUserByID_Call) Return(_a0 database.User, _a1 error) *MockCoinStatementQuerier_GetUserByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetUserByID_Call) RunAndReturn(run func(context.Context, pgtype.UUID) (database.User, error)) *MockCoinStatementQuerier_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByIDForUpdate provides a mock function with given fields: ctx, id
+func (_m *MockCoinStatementQuerier) GetUserByIDForUpdate(ctx context.Context, id pgtype.UUID) (database.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByIDForUpdate")
+	}
+
+	var r0 database.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (database.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) database.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetUserByIDForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByIDForUpdate'
+type MockCoinStatementQuerier_GetUserByIDForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetUserByIDForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id pgtype.UUID
+func (_e *MockCoinStatementQuerier_Expecter) GetUserByIDForUpdate(ctx interface{}, id interface{}) *MockCoinStatementQuerier_GetUserByIDForUpdate_Call {
+	return &MockCoinStatementQuerier_GetUserByIDForUpdate_Call{Call: _e.mock.On("GetUserByIDForUpdate", ctx, id)}
+}
+
+func (_c *MockCoinStatementQuerier_GetUserByIDForUpdate_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockCoinStatementQuerier_GetUserByIDForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetUserByIDForUpdate_Call) Return(_a0 database.User, _a1 error) *MockCoinStatementQuerier_GetUserByIDForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetUserByIDForUpdate_Call) RunAndReturn(run func(context.Context, pgtype.UUID) (database.User, error)) *MockCoinStatementQuerier_GetUserByIDForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByReferralCode provides a mock function with given fields: ctx, referralCode
+func (_m *MockCoinStatementQuerier) GetUserByReferralCode(ctx context.Context, referralCode string) (database.User, error) {
+	ret := _m.Called(ctx, referralCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByReferralCode")
+	}
+
+	var r0 database.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (database.User, error)); ok {
+		return rf(ctx, referralCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) database.User); ok {
+		r0 = rf(ctx, referralCode)
+	} else {
+		r0 = ret.Get(0).(database.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, referralCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetUserByReferralCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByReferralCode'
+type MockCoinStatementQuerier_GetUserByReferralCode_Call struct {
+	*mock.Call
+}
+
+// GetUserByReferralCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - referralCode string
+func (_e *MockCoinStatementQuerier_Expecter) GetUserByReferralCode(ctx interface{}, referralCode interface{}) *MockCoinStatementQuerier_GetUserByReferralCode_Call {
+	return &MockCoinStatementQuerier_GetUserByReferralCode_Call{Call: _e.mock.On("GetUserByReferralCode", ctx, referralCode)}
+}
+
+func (_c *MockCoinStatementQuerier_GetUserByReferralCode_Call) Run(run func(ctx context.Context, referralCode string)) *MockCoinStatementQuerier_GetUserByReferralCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetUserByReferralCode_Call) Return(_a0 database.User, _a1 error) *MockCoinStatementQuerier_GetUserByReferralCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetUserByReferralCode_Call) RunAndReturn(run func(context.Context, string) (database.User, error)) *MockCoinStatementQuerier_GetUserByReferralCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserSpendLimit provides a mock function with given fields: ctx, userID
+func (_m *MockCoinStatementQuerier) GetUserSpendLimit(ctx context.Context, userID pgtype.UUID) (database.UserSpendLimit, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserSpendLimit")
+	}
+
+	var r0 database.UserSpendLimit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (database.UserSpendLimit, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) database.UserSpendLimit); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(database.UserSpendLimit)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GetUserSpendLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserSpendLimit'
+type MockCoinStatementQuerier_GetUserSpendLimit_Call struct {
+	*mock.Call
+}
+
+// GetUserSpendLimit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID pgtype.UUID
+func (_e *MockCoinStatementQuerier_Expecter) GetUserSpendLimit(ctx interface{}, userID interface{}) *MockCoinStatementQuerier_GetUserSpendLimit_Call {
+	return &MockCoinStatementQuerier_GetUserSpendLimit_Call{Call: _e.mock.On("GetUserSpendLimit", ctx, userID)}
+}
+
+func (_c *MockCoinStatementQuerier_GetUserSpendLimit_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockCoinStatementQuerier_GetUserSpendLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetUserSpendLimit_Call) Return(_a0 database.UserSpendLimit, _a1 error) *MockCoinStatementQuerier_GetUserSpendLimit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GetUserSpendLimit_Call) RunAndReturn(run func(context.Context, pgtype.UUID) (database.UserSpendLimit, error)) *MockCoinStatementQuerier_GetUserSpendLimit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GiftCodeRedeemedByUser provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) GiftCodeRedeemedByUser(ctx context.Context, arg database.GiftCodeRedeemedByUserParams) (bool, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GiftCodeRedeemedByUser")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GiftCodeRedeemedByUserParams) (bool, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GiftCodeRedeemedByUserParams) bool); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GiftCodeRedeemedByUserParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_GiftCodeRedeemedByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GiftCodeRedeemedByUser'
+type MockCoinStatementQuerier_GiftCodeRedeemedByUser_Call struct {
+	*mock.Call
+}
+
+// GiftCodeRedeemedByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.GiftCodeRedeemedByUserParams
+func (_e *MockCoinStatementQuerier_Expecter) GiftCodeRedeemedByUser(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_GiftCodeRedeemedByUser_Call {
+	return &MockCoinStatementQuerier_GiftCodeRedeemedByUser_Call{Call: _e.mock.On("GiftCodeRedeemedByUser", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_GiftCodeRedeemedByUser_Call) Run(run func(ctx context.Context, arg database.GiftCodeRedeemedByUserParams)) *MockCoinStatementQuerier_GiftCodeRedeemedByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.GiftCodeRedeemedByUserParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GiftCodeRedeemedByUser_Call) Return(_a0 bool, _a1 error) *MockCoinStatementQuerier_GiftCodeRedeemedByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_GiftCodeRedeemedByUser_Call) RunAndReturn(run func(context.Context, database.GiftCodeRedeemedByUserParams) (bool, error)) *MockCoinStatementQuerier_GiftCodeRedeemedByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementGiftCodeRedemptions provides a mock function with given fields: ctx, id
+func (_m *MockCoinStatementQuerier) IncrementGiftCodeRedemptions(ctx context.Context, id int32) (database.GiftCode, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementGiftCodeRedemptions")
+	}
+
+	var r0 database.GiftCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.GiftCode, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.GiftCode); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.GiftCode)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_IncrementGiftCodeRedemptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementGiftCodeRedemptions'
+type MockCoinStatementQuerier_IncrementGiftCodeRedemptions_Call struct {
+	*mock.Call
+}
+
+// IncrementGiftCodeRedemptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockCoinStatementQuerier_Expecter) IncrementGiftCodeRedemptions(ctx interface{}, id interface{}) *MockCoinStatementQuerier_IncrementGiftCodeRedemptions_Call {
+	return &MockCoinStatementQuerier_IncrementGiftCodeRedemptions_Call{Call: _e.mock.On("IncrementGiftCodeRedemptions", ctx, id)}
+}
+
+func (_c *MockCoinStatementQuerier_IncrementGiftCodeRedemptions_Call) Run(run func(ctx context.Context, id int32)) *MockCoinStatementQuerier_IncrementGiftCodeRedemptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_IncrementGiftCodeRedemptions_Call) Return(_a0 database.GiftCode, _a1 error) *MockCoinStatementQuerier_IncrementGiftCodeRedemptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_IncrementGiftCodeRedemptions_Call) RunAndReturn(run func(context.Context, int32) (database.GiftCode, error)) *MockCoinStatementQuerier_IncrementGiftCodeRedemptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListActiveCoinHoldsByOrderID provides a mock function with given fields: ctx, orderID
+func (_m *MockCoinStatementQuerier) ListActiveCoinHoldsByOrderID(ctx context.Context, orderID pgtype.Int4) ([]database.CoinHold, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActiveCoinHoldsByOrderID")
+	}
+
+	var r0 []database.CoinHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Int4) ([]database.CoinHold, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Int4) []database.CoinHold); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinHold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.Int4) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_ListActiveCoinHoldsByOrderID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListActiveCoinHoldsByOrderID'
+type MockCoinStatementQuerier_ListActiveCoinHoldsByOrderID_Call struct {
+	*mock.Call
+}
+
+// ListActiveCoinHoldsByOrderID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID pgtype.Int4
+func (_e *MockCoinStatementQuerier_Expecter) ListActiveCoinHoldsByOrderID(ctx interface{}, orderID interface{}) *MockCoinStatementQuerier_ListActiveCoinHoldsByOrderID_Call {
+	return &MockCoinStatementQuerier_ListActiveCoinHoldsByOrderID_Call{Call: _e.mock.On("ListActiveCoinHoldsByOrderID", ctx, orderID)}
+}
+
+func (_c *MockCoinStatementQuerier_ListActiveCoinHoldsByOrderID_Call) Run(run func(ctx context.Context, orderID pgtype.Int4)) *MockCoinStatementQuerier_ListActiveCoinHoldsByOrderID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.Int4))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListActiveCoinHoldsByOrderID_Call) Return(_a0 []database.CoinHold, _a1 error) *MockCoinStatementQuerier_ListActiveCoinHoldsByOrderID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListActiveCoinHoldsByOrderID_Call) RunAndReturn(run func(context.Context, pgtype.Int4) ([]database.CoinHold, error)) *MockCoinStatementQuerier_ListActiveCoinHoldsByOrderID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListActiveCoinPacks provides a mock function with given fields: ctx
+func (_m *MockCoinStatementQuerier) ListActiveCoinPacks(ctx context.Context) ([]database.CoinPack, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActiveCoinPacks")
+	}
+
+	var r0 []database.CoinPack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]database.CoinPack, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []database.CoinPack); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinPack)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_ListActiveCoinPacks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListActiveCoinPacks'
+type MockCoinStatementQuerier_ListActiveCoinPacks_Call struct {
+	*mock.Call
+}
+
+// ListActiveCoinPacks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCoinStatementQuerier_Expecter) ListActiveCoinPacks(ctx interface{}) *MockCoinStatementQuerier_ListActiveCoinPacks_Call {
+	return &MockCoinStatementQuerier_ListActiveCoinPacks_Call{Call: _e.mock.On("ListActiveCoinPacks", ctx)}
+}
+
+func (_c *MockCoinStatementQuerier_ListActiveCoinPacks_Call) Run(run func(ctx context.Context)) *MockCoinStatementQuerier_ListActiveCoinPacks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListActiveCoinPacks_Call) Return(_a0 []database.CoinPack, _a1 error) *MockCoinStatementQuerier_ListActiveCoinPacks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListActiveCoinPacks_Call) RunAndReturn(run func(context.Context) ([]database.CoinPack, error)) *MockCoinStatementQuerier_ListActiveCoinPacks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCategoryCashbackRates provides a mock function with given fields: ctx
+func (_m *MockCoinStatementQuerier) ListCategoryCashbackRates(ctx context.Context) ([]database.CategoryCashbackRate, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCategoryCashbackRates")
+	}
+
+	var r0 []database.CategoryCashbackRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]database.CategoryCashbackRate, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []database.CategoryCashbackRate); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CategoryCashbackRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_ListCategoryCashbackRates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCategoryCashbackRates'
+type MockCoinStatementQuerier_ListCategoryCashbackRates_Call struct {
+	*mock.Call
+}
+
+// ListCategoryCashbackRates is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCoinStatementQuerier_Expecter) ListCategoryCashbackRates(ctx interface{}) *MockCoinStatementQuerier_ListCategoryCashbackRates_Call {
+	return &MockCoinStatementQuerier_ListCategoryCashbackRates_Call{Call: _e.mock.On("ListCategoryCashbackRates", ctx)}
+}
+
+func (_c *MockCoinStatementQuerier_ListCategoryCashbackRates_Call) Run(run func(ctx context.Context)) *MockCoinStatementQuerier_ListCategoryCashbackRates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListCategoryCashbackRates_Call) Return(_a0 []database.CategoryCashbackRate, _a1 error) *MockCoinStatementQuerier_ListCategoryCashbackRates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListCategoryCashbackRates_Call) RunAndReturn(run func(context.Context) ([]database.CategoryCashbackRate, error)) *MockCoinStatementQuerier_ListCategoryCashbackRates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCoinBalanceChangesByInterval provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) ListCoinBalanceChangesByInterval(ctx context.Context, arg database.ListCoinBalanceChangesByIntervalParams) ([]database.ListCoinBalanceChangesByIntervalRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCoinBalanceChangesByInterval")
+	}
+
+	var r0 []database.ListCoinBalanceChangesByIntervalRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListCoinBalanceChangesByIntervalParams) ([]database.ListCoinBalanceChangesByIntervalRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListCoinBalanceChangesByIntervalParams) []database.ListCoinBalanceChangesByIntervalRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.ListCoinBalanceChangesByIntervalRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListCoinBalanceChangesByIntervalParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_ListCoinBalanceChangesByInterval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCoinBalanceChangesByInterval'
+type MockCoinStatementQuerier_ListCoinBalanceChangesByInterval_Call struct {
+	*mock.Call
+}
+
+// ListCoinBalanceChangesByInterval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListCoinBalanceChangesByIntervalParams
+func (_e *MockCoinStatementQuerier_Expecter) ListCoinBalanceChangesByInterval(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_ListCoinBalanceChangesByInterval_Call {
+	return &MockCoinStatementQuerier_ListCoinBalanceChangesByInterval_Call{Call: _e.mock.On("ListCoinBalanceChangesByInterval", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_ListCoinBalanceChangesByInterval_Call) Run(run func(ctx context.Context, arg database.ListCoinBalanceChangesByIntervalParams)) *MockCoinStatementQuerier_ListCoinBalanceChangesByInterval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListCoinBalanceChangesByIntervalParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListCoinBalanceChangesByInterval_Call) Return(_a0 []database.ListCoinBalanceChangesByIntervalRow, _a1 error) *MockCoinStatementQuerier_ListCoinBalanceChangesByInterval_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListCoinBalanceChangesByInterval_Call) RunAndReturn(run func(context.Context, database.ListCoinBalanceChangesByIntervalParams) ([]database.ListCoinBalanceChangesByIntervalRow, error)) *MockCoinStatementQuerier_ListCoinBalanceChangesByInterval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCoinHoldsByUserID provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) ListCoinHoldsByUserID(ctx context.Context, arg database.ListCoinHoldsByUserIDParams) ([]database.CoinHold, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCoinHoldsByUserID")
+	}
+
+	var r0 []database.CoinHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListCoinHoldsByUserIDParams) ([]database.CoinHold, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListCoinHoldsByUserIDParams) []database.CoinHold); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinHold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListCoinHoldsByUserIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_ListCoinHoldsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCoinHoldsByUserID'
+type MockCoinStatementQuerier_ListCoinHoldsByUserID_Call struct {
+	*mock.Call
+}
+
+// ListCoinHoldsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListCoinHoldsByUserIDParams
+func (_e *MockCoinStatementQuerier_Expecter) ListCoinHoldsByUserID(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_ListCoinHoldsByUserID_Call {
+	return &MockCoinStatementQuerier_ListCoinHoldsByUserID_Call{Call: _e.mock.On("ListCoinHoldsByUserID", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_ListCoinHoldsByUserID_Call) Run(run func(ctx context.Context, arg database.ListCoinHoldsByUserIDParams)) *MockCoinStatementQuerier_ListCoinHoldsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListCoinHoldsByUserIDParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListCoinHoldsByUserID_Call) Return(_a0 []database.CoinHold, _a1 error) *MockCoinStatementQuerier_ListCoinHoldsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListCoinHoldsByUserID_Call) RunAndReturn(run func(context.Context, database.ListCoinHoldsByUserIDParams) ([]database.CoinHold, error)) *MockCoinStatementQuerier_ListCoinHoldsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCoinPacks provides a mock function with given fields: ctx
+func (_m *MockCoinStatementQuerier) ListCoinPacks(ctx context.Context) ([]database.CoinPack, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCoinPacks")
+	}
+
+	var r0 []database.CoinPack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]database.CoinPack, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []database.CoinPack); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinPack)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_ListCoinPacks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCoinPacks'
+type MockCoinStatementQuerier_ListCoinPacks_Call struct {
+	*mock.Call
+}
+
+// ListCoinPacks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockCoinStatementQuerier_Expecter) ListCoinPacks(ctx interface{}) *MockCoinStatementQuerier_ListCoinPacks_Call {
+	return &MockCoinStatementQuerier_ListCoinPacks_Call{Call: _e.mock.On("ListCoinPacks", ctx)}
+}
+
+func (_c *MockCoinStatementQuerier_ListCoinPacks_Call) Run(run func(ctx context.Context)) *MockCoinStatementQuerier_ListCoinPacks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListCoinPacks_Call) Return(_a0 []database.CoinPack, _a1 error) *MockCoinStatementQuerier_ListCoinPacks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListCoinPacks_Call) RunAndReturn(run func(context.Context) ([]database.CoinPack, error)) *MockCoinStatementQuerier_ListCoinPacks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCoinTransactionsFiltered provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) ListCoinTransactionsFiltered(ctx context.Context, arg database.ListCoinTransactionsFilteredParams) ([]database.CoinTransaction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCoinTransactionsFiltered")
+	}
+
+	var r0 []database.CoinTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListCoinTransactionsFilteredParams) ([]database.CoinTransaction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListCoinTransactionsFilteredParams) []database.CoinTransaction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListCoinTransactionsFilteredParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_ListCoinTransactionsFiltered_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCoinTransactionsFiltered'
+type MockCoinStatementQuerier_ListCoinTransactionsFiltered_Call struct {
+	*mock.Call
+}
+
+// ListCoinTransactionsFiltered is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListCoinTransactionsFilteredParams
+func (_e *MockCoinStatementQuerier_Expecter) ListCoinTransactionsFiltered(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_ListCoinTransactionsFiltered_Call {
+	return &MockCoinStatementQuerier_ListCoinTransactionsFiltered_Call{Call: _e.mock.On("ListCoinTransactionsFiltered", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_ListCoinTransactionsFiltered_Call) Run(run func(ctx context.Context, arg database.ListCoinTransactionsFilteredParams)) *MockCoinStatementQuerier_ListCoinTransactionsFiltered_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListCoinTransactionsFilteredParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListCoinTransactionsFiltered_Call) Return(_a0 []database.CoinTransaction, _a1 error) *MockCoinStatementQuerier_ListCoinTransactionsFiltered_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListCoinTransactionsFiltered_Call) RunAndReturn(run func(context.Context, database.ListCoinTransactionsFilteredParams) ([]database.CoinTransaction, error)) *MockCoinStatementQuerier_ListCoinTransactionsFiltered_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListExpiredCoinHolds provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) ListExpiredCoinHolds(ctx context.Context, arg database.ListExpiredCoinHoldsParams) ([]database.CoinHold, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExpiredCoinHolds")
+	}
+
+	var r0 []database.CoinHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListExpiredCoinHoldsParams) ([]database.CoinHold, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListExpiredCoinHoldsParams) []database.CoinHold); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinHold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListExpiredCoinHoldsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_ListExpiredCoinHolds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExpiredCoinHolds'
+type MockCoinStatementQuerier_ListExpiredCoinHolds_Call struct {
+	*mock.Call
+}
+
+// ListExpiredCoinHolds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListExpiredCoinHoldsParams
+func (_e *MockCoinStatementQuerier_Expecter) ListExpiredCoinHolds(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_ListExpiredCoinHolds_Call {
+	return &MockCoinStatementQuerier_ListExpiredCoinHolds_Call{Call: _e.mock.On("ListExpiredCoinHolds", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_ListExpiredCoinHolds_Call) Run(run func(ctx context.Context, arg database.ListExpiredCoinHoldsParams)) *MockCoinStatementQuerier_ListExpiredCoinHolds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListExpiredCoinHoldsParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListExpiredCoinHolds_Call) Return(_a0 []database.CoinHold, _a1 error) *MockCoinStatementQuerier_ListExpiredCoinHolds_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListExpiredCoinHolds_Call) RunAndReturn(run func(context.Context, database.ListExpiredCoinHoldsParams) ([]database.CoinHold, error)) *MockCoinStatementQuerier_ListExpiredCoinHolds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListExpiredCoinLots provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) ListExpiredCoinLots(ctx context.Context, arg database.ListExpiredCoinLotsParams) ([]database.CoinLot, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExpiredCoinLots")
+	}
+
+	var r0 []database.CoinLot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListExpiredCoinLotsParams) ([]database.CoinLot, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListExpiredCoinLotsParams) []database.CoinLot); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinLot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListExpiredCoinLotsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_ListExpiredCoinLots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExpiredCoinLots'
+type MockCoinStatementQuerier_ListExpiredCoinLots_Call struct {
+	*mock.Call
+}
+
+// ListExpiredCoinLots is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListExpiredCoinLotsParams
+func (_e *MockCoinStatementQuerier_Expecter) ListExpiredCoinLots(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_ListExpiredCoinLots_Call {
+	return &MockCoinStatementQuerier_ListExpiredCoinLots_Call{Call: _e.mock.On("ListExpiredCoinLots", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_ListExpiredCoinLots_Call) Run(run func(ctx context.Context, arg database.ListExpiredCoinLotsParams)) *MockCoinStatementQuerier_ListExpiredCoinLots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListExpiredCoinLotsParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListExpiredCoinLots_Call) Return(_a0 []database.CoinLot, _a1 error) *MockCoinStatementQuerier_ListExpiredCoinLots_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListExpiredCoinLots_Call) RunAndReturn(run func(context.Context, database.ListExpiredCoinLotsParams) ([]database.CoinLot, error)) *MockCoinStatementQuerier_ListExpiredCoinLots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListGiftCodesByBatchID provides a mock function with given fields: ctx, batchID
+func (_m *MockCoinStatementQuerier) ListGiftCodesByBatchID(ctx context.Context, batchID int32) ([]database.GiftCode, error) {
+	ret := _m.Called(ctx, batchID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGiftCodesByBatchID")
+	}
+
+	var r0 []database.GiftCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) ([]database.GiftCode, error)); ok {
+		return rf(ctx, batchID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) []database.GiftCode); ok {
+		r0 = rf(ctx, batchID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.GiftCode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, batchID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_ListGiftCodesByBatchID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListGiftCodesByBatchID'
+type MockCoinStatementQuerier_ListGiftCodesByBatchID_Call struct {
+	*mock.Call
+}
+
+// ListGiftCodesByBatchID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchID int32
+func (_e *MockCoinStatementQuerier_Expecter) ListGiftCodesByBatchID(ctx interface{}, batchID interface{}) *MockCoinStatementQuerier_ListGiftCodesByBatchID_Call {
+	return &MockCoinStatementQuerier_ListGiftCodesByBatchID_Call{Call: _e.mock.On("ListGiftCodesByBatchID", ctx, batchID)}
+}
+
+func (_c *MockCoinStatementQuerier_ListGiftCodesByBatchID_Call) Run(run func(ctx context.Context, batchID int32)) *MockCoinStatementQuerier_ListGiftCodesByBatchID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListGiftCodesByBatchID_Call) Return(_a0 []database.GiftCode, _a1 error) *MockCoinStatementQuerier_ListGiftCodesByBatchID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListGiftCodesByBatchID_Call) RunAndReturn(run func(context.Context, int32) ([]database.GiftCode, error)) *MockCoinStatementQuerier_ListGiftCodesByBatchID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOrderCategorySubtotals provides a mock function with given fields: ctx, orderID
+func (_m *MockCoinStatementQuerier) ListOrderCategorySubtotals(ctx context.Context, orderID int32) ([]database.ListOrderCategorySubtotalsRow, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOrderCategorySubtotals")
+	}
+
+	var r0 []database.ListOrderCategorySubtotalsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) ([]database.ListOrderCategorySubtotalsRow, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) []database.ListOrderCategorySubtotalsRow); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.ListOrderCategorySubtotalsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_ListOrderCategorySubtotals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrderCategorySubtotals'
+type MockCoinStatementQuerier_ListOrderCategorySubtotals_Call struct {
+	*mock.Call
+}
+
+// ListOrderCategorySubtotals is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID int32
+func (_e *MockCoinStatementQuerier_Expecter) ListOrderCategorySubtotals(ctx interface{}, orderID interface{}) *MockCoinStatementQuerier_ListOrderCategorySubtotals_Call {
+	return &MockCoinStatementQuerier_ListOrderCategorySubtotals_Call{Call: _e.mock.On("ListOrderCategorySubtotals", ctx, orderID)}
+}
+
+func (_c *MockCoinStatementQuerier_ListOrderCategorySubtotals_Call) Run(run func(ctx context.Context, orderID int32)) *MockCoinStatementQuerier_ListOrderCategorySubtotals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListOrderCategorySubtotals_Call) Return(_a0 []database.ListOrderCategorySubtotalsRow, _a1 error) *MockCoinStatementQuerier_ListOrderCategorySubtotals_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListOrderCategorySubtotals_Call) RunAndReturn(run func(context.Context, int32) ([]database.ListOrderCategorySubtotalsRow, error)) *MockCoinStatementQuerier_ListOrderCategorySubtotals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListProducts provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) ListProducts(ctx context.Context, arg database.ListProductsParams) ([]database.Product, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProducts")
+	}
+
+	var r0 []database.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListProductsParams) ([]database.Product, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListProductsParams) []database.Product); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListProductsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_ListProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListProducts'
+type MockCoinStatementQuerier_ListProducts_Call struct {
+	*mock.Call
+}
+
+// ListProducts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListProductsParams
+func (_e *MockCoinStatementQuerier_Expecter) ListProducts(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_ListProducts_Call {
+	return &MockCoinStatementQuerier_ListProducts_Call{Call: _e.mock.On("ListProducts", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_ListProducts_Call) Run(run func(ctx context.Context, arg database.ListProductsParams)) *MockCoinStatementQuerier_ListProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListProductsParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListProducts_Call) Return(_a0 []database.Product, _a1 error) *MockCoinStatementQuerier_ListProducts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListProducts_Call) RunAndReturn(run func(context.Context, database.ListProductsParams) ([]database.Product, error)) *MockCoinStatementQuerier_ListProducts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListProductsByCategory provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) ListProductsByCategory(ctx context.Context, arg database.ListProductsByCategoryParams) ([]database.Product, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProductsByCategory")
+	}
+
+	var r0 []database.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListProductsByCategoryParams) ([]database.Product, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListProductsByCategoryParams) []database.Product); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListProductsByCategoryParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_ListProductsByCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListProductsByCategory'
+type MockCoinStatementQuerier_ListProductsByCategory_Call struct {
+	*mock.Call
+}
+
+// ListProductsByCategory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListProductsByCategoryParams
+func (_e *MockCoinStatementQuerier_Expecter) ListProductsByCategory(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_ListProductsByCategory_Call {
+	return &MockCoinStatementQuerier_ListProductsByCategory_Call{Call: _e.mock.On("ListProductsByCategory", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_ListProductsByCategory_Call) Run(run func(ctx context.Context, arg database.ListProductsByCategoryParams)) *MockCoinStatementQuerier_ListProductsByCategory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListProductsByCategoryParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListProductsByCategory_Call) Return(_a0 []database.Product, _a1 error) *MockCoinStatementQuerier_ListProductsByCategory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListProductsByCategory_Call) RunAndReturn(run func(context.Context, database.ListProductsByCategoryParams) ([]database.Product, error)) *MockCoinStatementQuerier_ListProductsByCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListReferralsByReferrerID provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) ListReferralsByReferrerID(ctx context.Context, arg database.ListReferralsByReferrerIDParams) ([]database.Referral, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReferralsByReferrerID")
+	}
+
+	var r0 []database.Referral
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListReferralsByReferrerIDParams) ([]database.Referral, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListReferralsByReferrerIDParams) []database.Referral); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.Referral)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListReferralsByReferrerIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_ListReferralsByReferrerID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReferralsByReferrerID'
+type MockCoinStatementQuerier_ListReferralsByReferrerID_Call struct {
+	*mock.Call
+}
+
+// ListReferralsByReferrerID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListReferralsByReferrerIDParams
+func (_e *MockCoinStatementQuerier_Expecter) ListReferralsByReferrerID(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_ListReferralsByReferrerID_Call {
+	return &MockCoinStatementQuerier_ListReferralsByReferrerID_Call{Call: _e.mock.On("ListReferralsByReferrerID", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_ListReferralsByReferrerID_Call) Run(run func(ctx context.Context, arg database.ListReferralsByReferrerIDParams)) *MockCoinStatementQuerier_ListReferralsByReferrerID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListReferralsByReferrerIDParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListReferralsByReferrerID_Call) Return(_a0 []database.Referral, _a1 error) *MockCoinStatementQuerier_ListReferralsByReferrerID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListReferralsByReferrerID_Call) RunAndReturn(run func(context.Context, database.ListReferralsByReferrerIDParams) ([]database.Referral, error)) *MockCoinStatementQuerier_ListReferralsByReferrerID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSecurityEventsByUserID provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) ListSecurityEventsByUserID(ctx context.Context, arg database.ListSecurityEventsByUserIDParams) ([]database.SecurityEvent, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSecurityEventsByUserID")
+	}
+
+	var r0 []database.SecurityEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListSecurityEventsByUserIDParams) ([]database.SecurityEvent, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListSecurityEventsByUserIDParams) []database.SecurityEvent); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.SecurityEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListSecurityEventsByUserIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_ListSecurityEventsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSecurityEventsByUserID'
+type MockCoinStatementQuerier_ListSecurityEventsByUserID_Call struct {
+	*mock.Call
+}
+
+// ListSecurityEventsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListSecurityEventsByUserIDParams
+func (_e *MockCoinStatementQuerier_Expecter) ListSecurityEventsByUserID(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_ListSecurityEventsByUserID_Call {
+	return &MockCoinStatementQuerier_ListSecurityEventsByUserID_Call{Call: _e.mock.On("ListSecurityEventsByUserID", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_ListSecurityEventsByUserID_Call) Run(run func(ctx context.Context, arg database.ListSecurityEventsByUserIDParams)) *MockCoinStatementQuerier_ListSecurityEventsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListSecurityEventsByUserIDParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListSecurityEventsByUserID_Call) Return(_a0 []database.SecurityEvent, _a1 error) *MockCoinStatementQuerier_ListSecurityEventsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListSecurityEventsByUserID_Call) RunAndReturn(run func(context.Context, database.ListSecurityEventsByUserIDParams) ([]database.SecurityEvent, error)) *MockCoinStatementQuerier_ListSecurityEventsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSpendableCoinLotsForUpdate provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) ListSpendableCoinLotsForUpdate(ctx context.Context, arg database.ListSpendableCoinLotsForUpdateParams) ([]database.CoinLot, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSpendableCoinLotsForUpdate")
+	}
+
+	var r0 []database.CoinLot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListSpendableCoinLotsForUpdateParams) ([]database.CoinLot, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListSpendableCoinLotsForUpdateParams) []database.CoinLot); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinLot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListSpendableCoinLotsForUpdateParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_ListSpendableCoinLotsForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSpendableCoinLotsForUpdate'
+type MockCoinStatementQuerier_ListSpendableCoinLotsForUpdate_Call struct {
+	*mock.Call
+}
+
+// ListSpendableCoinLotsForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListSpendableCoinLotsForUpdateParams
+func (_e *MockCoinStatementQuerier_Expecter) ListSpendableCoinLotsForUpdate(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_ListSpendableCoinLotsForUpdate_Call {
+	return &MockCoinStatementQuerier_ListSpendableCoinLotsForUpdate_Call{Call: _e.mock.On("ListSpendableCoinLotsForUpdate", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_ListSpendableCoinLotsForUpdate_Call) Run(run func(ctx context.Context, arg database.ListSpendableCoinLotsForUpdateParams)) *MockCoinStatementQuerier_ListSpendableCoinLotsForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListSpendableCoinLotsForUpdateParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListSpendableCoinLotsForUpdate_Call) Return(_a0 []database.CoinLot, _a1 error) *MockCoinStatementQuerier_ListSpendableCoinLotsForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_ListSpendableCoinLotsForUpdate_Call) RunAndReturn(run func(context.Context, database.ListSpendableCoinLotsForUpdateParams) ([]database.CoinLot, error)) *MockCoinStatementQuerier_ListSpendableCoinLotsForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockNormalizedEmail provides a mock function with given fields: ctx, normalizedEmail
+func (_m *MockCoinStatementQuerier) LockNormalizedEmail(ctx context.Context, normalizedEmail string) error {
+	ret := _m.Called(ctx, normalizedEmail)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockNormalizedEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, normalizedEmail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCoinStatementQuerier_LockNormalizedEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockNormalizedEmail'
+type MockCoinStatementQuerier_LockNormalizedEmail_Call struct {
+	*mock.Call
+}
+
+// LockNormalizedEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - normalizedEmail string
+func (_e *MockCoinStatementQuerier_Expecter) LockNormalizedEmail(ctx interface{}, normalizedEmail interface{}) *MockCoinStatementQuerier_LockNormalizedEmail_Call {
+	return &MockCoinStatementQuerier_LockNormalizedEmail_Call{Call: _e.mock.On("LockNormalizedEmail", ctx, normalizedEmail)}
+}
+
+func (_c *MockCoinStatementQuerier_LockNormalizedEmail_Call) Run(run func(ctx context.Context, normalizedEmail string)) *MockCoinStatementQuerier_LockNormalizedEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_LockNormalizedEmail_Call) Return(_a0 error) *MockCoinStatementQuerier_LockNormalizedEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_LockNormalizedEmail_Call) RunAndReturn(run func(context.Context, string) error) *MockCoinStatementQuerier_LockNormalizedEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkReferralRewarded provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) MarkReferralRewarded(ctx context.Context, arg database.MarkReferralRewardedParams) (database.Referral, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkReferralRewarded")
+	}
+
+	var r0 database.Referral
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.MarkReferralRewardedParams) (database.Referral, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.MarkReferralRewardedParams) database.Referral); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.Referral)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.MarkReferralRewardedParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_MarkReferralRewarded_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkReferralRewarded'
+type MockCoinStatementQuerier_MarkReferralRewarded_Call struct {
+	*mock.Call
+}
+
+// MarkReferralRewarded is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.MarkReferralRewardedParams
+func (_e *MockCoinStatementQuerier_Expecter) MarkReferralRewarded(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_MarkReferralRewarded_Call {
+	return &MockCoinStatementQuerier_MarkReferralRewarded_Call{Call: _e.mock.On("MarkReferralRewarded", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_MarkReferralRewarded_Call) Run(run func(ctx context.Context, arg database.MarkReferralRewardedParams)) *MockCoinStatementQuerier_MarkReferralRewarded_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.MarkReferralRewardedParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_MarkReferralRewarded_Call) Return(_a0 database.Referral, _a1 error) *MockCoinStatementQuerier_MarkReferralRewarded_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_MarkReferralRewarded_Call) RunAndReturn(run func(context.Context, database.MarkReferralRewardedParams) (database.Referral, error)) *MockCoinStatementQuerier_MarkReferralRewarded_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamCoinTransactionsForStatement provides a mock function with given fields: ctx, arg, fn
+func (_m *MockCoinStatementQuerier) StreamCoinTransactionsForStatement(ctx context.Context, arg database.StreamCoinTransactionsForStatementParams, fn func(database.CoinTransaction) error) error {
+	ret := _m.Called(ctx, arg, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamCoinTransactionsForStatement")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.StreamCoinTransactionsForStatementParams, func(database.CoinTransaction) error) error); ok {
+		r0 = rf(ctx, arg, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCoinStatementQuerier_StreamCoinTransactionsForStatement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamCoinTransactionsForStatement'
+type MockCoinStatementQuerier_StreamCoinTransactionsForStatement_Call struct {
+	*mock.Call
+}
+
+// StreamCoinTransactionsForStatement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.StreamCoinTransactionsForStatementParams
+//   - fn func(database.CoinTransaction) error
+func (_e *MockCoinStatementQuerier_Expecter) StreamCoinTransactionsForStatement(ctx interface{}, arg interface{}, fn interface{}) *MockCoinStatementQuerier_StreamCoinTransactionsForStatement_Call {
+	return &MockCoinStatementQuerier_StreamCoinTransactionsForStatement_Call{Call: _e.mock.On("StreamCoinTransactionsForStatement", ctx, arg, fn)}
+}
+
+func (_c *MockCoinStatementQuerier_StreamCoinTransactionsForStatement_Call) Run(run func(ctx context.Context, arg database.StreamCoinTransactionsForStatementParams, fn func(database.CoinTransaction) error)) *MockCoinStatementQuerier_StreamCoinTransactionsForStatement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.StreamCoinTransactionsForStatementParams), args[2].(func(database.CoinTransaction) error))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_StreamCoinTransactionsForStatement_Call) Return(_a0 error) *MockCoinStatementQuerier_StreamCoinTransactionsForStatement_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_StreamCoinTransactionsForStatement_Call) RunAndReturn(run func(context.Context, database.StreamCoinTransactionsForStatementParams, func(database.CoinTransaction) error) error) *MockCoinStatementQuerier_StreamCoinTransactionsForStatement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SummarizeCoinTransactionsFiltered provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) SummarizeCoinTransactionsFiltered(ctx context.Context, arg database.SummarizeCoinTransactionsFilteredParams) (database.SummarizeCoinTransactionsFilteredRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SummarizeCoinTransactionsFiltered")
+	}
+
+	var r0 database.SummarizeCoinTransactionsFilteredRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.SummarizeCoinTransactionsFilteredParams) (database.SummarizeCoinTransactionsFilteredRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.SummarizeCoinTransactionsFilteredParams) database.SummarizeCoinTransactionsFilteredRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.SummarizeCoinTransactionsFilteredRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.SummarizeCoinTransactionsFilteredParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_SummarizeCoinTransactionsFiltered_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SummarizeCoinTransactionsFiltered'
+type MockCoinStatementQuerier_SummarizeCoinTransactionsFiltered_Call struct {
+	*mock.Call
+}
+
+// SummarizeCoinTransactionsFiltered is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.SummarizeCoinTransactionsFilteredParams
+func (_e *MockCoinStatementQuerier_Expecter) SummarizeCoinTransactionsFiltered(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_SummarizeCoinTransactionsFiltered_Call {
+	return &MockCoinStatementQuerier_SummarizeCoinTransactionsFiltered_Call{Call: _e.mock.On("SummarizeCoinTransactionsFiltered", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_SummarizeCoinTransactionsFiltered_Call) Run(run func(ctx context.Context, arg database.SummarizeCoinTransactionsFilteredParams)) *MockCoinStatementQuerier_SummarizeCoinTransactionsFiltered_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.SummarizeCoinTransactionsFilteredParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_SummarizeCoinTransactionsFiltered_Call) Return(_a0 database.SummarizeCoinTransactionsFilteredRow, _a1 error) *MockCoinStatementQuerier_SummarizeCoinTransactionsFiltered_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_SummarizeCoinTransactionsFiltered_Call) RunAndReturn(run func(context.Context, database.SummarizeCoinTransactionsFilteredParams) (database.SummarizeCoinTransactionsFilteredRow, error)) *MockCoinStatementQuerier_SummarizeCoinTransactionsFiltered_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnblockUserSpending provides a mock function with given fields: ctx, userID
+func (_m *MockCoinStatementQuerier) UnblockUserSpending(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnblockUserSpending")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_UnblockUserSpending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnblockUserSpending'
+type MockCoinStatementQuerier_UnblockUserSpending_Call struct {
+	*mock.Call
+}
+
+// UnblockUserSpending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID pgtype.UUID
+func (_e *MockCoinStatementQuerier_Expecter) UnblockUserSpending(ctx interface{}, userID interface{}) *MockCoinStatementQuerier_UnblockUserSpending_Call {
+	return &MockCoinStatementQuerier_UnblockUserSpending_Call{Call: _e.mock.On("UnblockUserSpending", ctx, userID)}
+}
+
+func (_c *MockCoinStatementQuerier_UnblockUserSpending_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockCoinStatementQuerier_UnblockUserSpending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UnblockUserSpending_Call) Return(_a0 int64, _a1 error) *MockCoinStatementQuerier_UnblockUserSpending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UnblockUserSpending_Call) RunAndReturn(run func(context.Context, pgtype.UUID) (int64, error)) *MockCoinStatementQuerier_UnblockUserSpending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCartItemQuantity provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) UpdateCartItemQuantity(ctx context.Context, arg database.UpdateCartItemQuantityParams) (database.CartItem, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCartItemQuantity")
+	}
+
+	var r0 database.CartItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateCartItemQuantityParams) (database.CartItem, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateCartItemQuantityParams) database.CartItem); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CartItem)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateCartItemQuantityParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_UpdateCartItemQuantity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCartItemQuantity'
+type MockCoinStatementQuerier_UpdateCartItemQuantity_Call struct {
+	*mock.Call
+}
+
+// UpdateCartItemQuantity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateCartItemQuantityParams
+func (_e *MockCoinStatementQuerier_Expecter) UpdateCartItemQuantity(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_UpdateCartItemQuantity_Call {
+	return &MockCoinStatementQuerier_UpdateCartItemQuantity_Call{Call: _e.mock.On("UpdateCartItemQuantity", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_UpdateCartItemQuantity_Call) Run(run func(ctx context.Context, arg database.UpdateCartItemQuantityParams)) *MockCoinStatementQuerier_UpdateCartItemQuantity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateCartItemQuantityParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateCartItemQuantity_Call) Return(_a0 database.CartItem, _a1 error) *MockCoinStatementQuerier_UpdateCartItemQuantity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateCartItemQuantity_Call) RunAndReturn(run func(context.Context, database.UpdateCartItemQuantityParams) (database.CartItem, error)) *MockCoinStatementQuerier_UpdateCartItemQuantity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCoinHoldStatus provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) UpdateCoinHoldStatus(ctx context.Context, arg database.UpdateCoinHoldStatusParams) (database.CoinHold, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCoinHoldStatus")
+	}
+
+	var r0 database.CoinHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateCoinHoldStatusParams) (database.CoinHold, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateCoinHoldStatusParams) database.CoinHold); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CoinHold)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateCoinHoldStatusParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_UpdateCoinHoldStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCoinHoldStatus'
+type MockCoinStatementQuerier_UpdateCoinHoldStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateCoinHoldStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateCoinHoldStatusParams
+func (_e *MockCoinStatementQuerier_Expecter) UpdateCoinHoldStatus(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_UpdateCoinHoldStatus_Call {
+	return &MockCoinStatementQuerier_UpdateCoinHoldStatus_Call{Call: _e.mock.On("UpdateCoinHoldStatus", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_UpdateCoinHoldStatus_Call) Run(run func(ctx context.Context, arg database.UpdateCoinHoldStatusParams)) *MockCoinStatementQuerier_UpdateCoinHoldStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateCoinHoldStatusParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateCoinHoldStatus_Call) Return(_a0 database.CoinHold, _a1 error) *MockCoinStatementQuerier_UpdateCoinHoldStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateCoinHoldStatus_Call) RunAndReturn(run func(context.Context, database.UpdateCoinHoldStatusParams) (database.CoinHold, error)) *MockCoinStatementQuerier_UpdateCoinHoldStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCoinLotRemaining provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) UpdateCoinLotRemaining(ctx context.Context, arg database.UpdateCoinLotRemainingParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCoinLotRemaining")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateCoinLotRemainingParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCoinStatementQuerier_UpdateCoinLotRemaining_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCoinLotRemaining'
+type MockCoinStatementQuerier_UpdateCoinLotRemaining_Call struct {
+	*mock.Call
+}
+
+// UpdateCoinLotRemaining is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateCoinLotRemainingParams
+func (_e *MockCoinStatementQuerier_Expecter) UpdateCoinLotRemaining(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_UpdateCoinLotRemaining_Call {
+	return &MockCoinStatementQuerier_UpdateCoinLotRemaining_Call{Call: _e.mock.On("UpdateCoinLotRemaining", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_UpdateCoinLotRemaining_Call) Run(run func(ctx context.Context, arg database.UpdateCoinLotRemainingParams)) *MockCoinStatementQuerier_UpdateCoinLotRemaining_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateCoinLotRemainingParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateCoinLotRemaining_Call) Return(_a0 error) *MockCoinStatementQuerier_UpdateCoinLotRemaining_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateCoinLotRemaining_Call) RunAndReturn(run func(context.Context, database.UpdateCoinLotRemainingParams) error) *MockCoinStatementQuerier_UpdateCoinLotRemaining_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCoinPack provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) UpdateCoinPack(ctx context.Context, arg database.UpdateCoinPackParams) (database.CoinPack, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCoinPack")
+	}
+
+	var r0 database.CoinPack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateCoinPackParams) (database.CoinPack, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateCoinPackParams) database.CoinPack); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CoinPack)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateCoinPackParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_UpdateCoinPack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCoinPack'
+type MockCoinStatementQuerier_UpdateCoinPack_Call struct {
+	*mock.Call
+}
+
+// UpdateCoinPack is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateCoinPackParams
+func (_e *MockCoinStatementQuerier_Expecter) UpdateCoinPack(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_UpdateCoinPack_Call {
+	return &MockCoinStatementQuerier_UpdateCoinPack_Call{Call: _e.mock.On("UpdateCoinPack", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_UpdateCoinPack_Call) Run(run func(ctx context.Context, arg database.UpdateCoinPackParams)) *MockCoinStatementQuerier_UpdateCoinPack_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateCoinPackParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateCoinPack_Call) Return(_a0 database.CoinPack, _a1 error) *MockCoinStatementQuerier_UpdateCoinPack_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateCoinPack_Call) RunAndReturn(run func(context.Context, database.UpdateCoinPackParams) (database.CoinPack, error)) *MockCoinStatementQuerier_UpdateCoinPack_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateOrderStatus provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) UpdateOrderStatus(ctx context.Context, arg database.UpdateOrderStatusParams) (database.Order, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOrderStatus")
+	}
+
+	var r0 database.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateOrderStatusParams) (database.Order, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateOrderStatusParams) database.Order); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.Order)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateOrderStatusParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_UpdateOrderStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOrderStatus'
+type MockCoinStatementQuerier_UpdateOrderStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateOrderStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateOrderStatusParams
+func (_e *MockCoinStatementQuerier_Expecter) UpdateOrderStatus(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_UpdateOrderStatus_Call {
+	return &MockCoinStatementQuerier_UpdateOrderStatus_Call{Call: _e.mock.On("UpdateOrderStatus", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_UpdateOrderStatus_Call) Run(run func(ctx context.Context, arg database.UpdateOrderStatusParams)) *MockCoinStatementQuerier_UpdateOrderStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateOrderStatusParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateOrderStatus_Call) Return(_a0 database.Order, _a1 error) *MockCoinStatementQuerier_UpdateOrderStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateOrderStatus_Call) RunAndReturn(run func(context.Context, database.UpdateOrderStatusParams) (database.Order, error)) *MockCoinStatementQuerier_UpdateOrderStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProductStock provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) UpdateProductStock(ctx context.Context, arg database.UpdateProductStockParams) (database.Product, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProductStock")
+	}
+
+	var r0 database.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateProductStockParams) (database.Product, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateProductStockParams) database.Product); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.Product)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateProductStockParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_UpdateProductStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProductStock'
+type MockCoinStatementQuerier_UpdateProductStock_Call struct {
+	*mock.Call
+}
+
+// UpdateProductStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateProductStockParams
+func (_e *MockCoinStatementQuerier_Expecter) UpdateProductStock(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_UpdateProductStock_Call {
+	return &MockCoinStatementQuerier_UpdateProductStock_Call{Call: _e.mock.On("UpdateProductStock", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_UpdateProductStock_Call) Run(run func(ctx context.Context, arg database.UpdateProductStockParams)) *MockCoinStatementQuerier_UpdateProductStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateProductStockParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateProductStock_Call) Return(_a0 database.Product, _a1 error) *MockCoinStatementQuerier_UpdateProductStock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateProductStock_Call) RunAndReturn(run func(context.Context, database.UpdateProductStockParams) (database.Product, error)) *MockCoinStatementQuerier_UpdateProductStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserCoins provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) UpdateUserCoins(ctx context.Context, arg database.UpdateUserCoinsParams) (database.UpdateUserCoinsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserCoins")
+	}
+
+	var r0 database.UpdateUserCoinsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserCoinsParams) (database.UpdateUserCoinsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserCoinsParams) database.UpdateUserCoinsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.UpdateUserCoinsRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateUserCoinsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_UpdateUserCoins_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserCoins'
+type MockCoinStatementQuerier_UpdateUserCoins_Call struct {
+	*mock.Call
+}
+
+// UpdateUserCoins is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateUserCoinsParams
+func (_e *MockCoinStatementQuerier_Expecter) UpdateUserCoins(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_UpdateUserCoins_Call {
+	return &MockCoinStatementQuerier_UpdateUserCoins_Call{Call: _e.mock.On("UpdateUserCoins", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_UpdateUserCoins_Call) Run(run func(ctx context.Context, arg database.UpdateUserCoinsParams)) *MockCoinStatementQuerier_UpdateUserCoins_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateUserCoinsParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateUserCoins_Call) Return(_a0 database.UpdateUserCoinsRow, _a1 error) *MockCoinStatementQuerier_UpdateUserCoins_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateUserCoins_Call) RunAndReturn(run func(context.Context, database.UpdateUserCoinsParams) (database.UpdateUserCoinsRow, error)) *MockCoinStatementQuerier_UpdateUserCoins_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserEmail provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) UpdateUserEmail(ctx context.Context, arg database.UpdateUserEmailParams) (database.UpdateUserEmailRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserEmail")
+	}
+
+	var r0 database.UpdateUserEmailRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserEmailParams) (database.UpdateUserEmailRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserEmailParams) database.UpdateUserEmailRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.UpdateUserEmailRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateUserEmailParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_UpdateUserEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserEmail'
+type MockCoinStatementQuerier_UpdateUserEmail_Call struct {
+	*mock.Call
+}
+
+// UpdateUserEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateUserEmailParams
+func (_e *MockCoinStatementQuerier_Expecter) UpdateUserEmail(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_UpdateUserEmail_Call {
+	return &MockCoinStatementQuerier_UpdateUserEmail_Call{Call: _e.mock.On("UpdateUserEmail", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_UpdateUserEmail_Call) Run(run func(ctx context.Context, arg database.UpdateUserEmailParams)) *MockCoinStatementQuerier_UpdateUserEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateUserEmailParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateUserEmail_Call) Return(_a0 database.UpdateUserEmailRow, _a1 error) *MockCoinStatementQuerier_UpdateUserEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateUserEmail_Call) RunAndReturn(run func(context.Context, database.UpdateUserEmailParams) (database.UpdateUserEmailRow, error)) *MockCoinStatementQuerier_UpdateUserEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserHeldCoins provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) UpdateUserHeldCoins(ctx context.Context, arg database.UpdateUserHeldCoinsParams) (database.UpdateUserHeldCoinsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserHeldCoins")
+	}
+
+	var r0 database.UpdateUserHeldCoinsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserHeldCoinsParams) (database.UpdateUserHeldCoinsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserHeldCoinsParams) database.UpdateUserHeldCoinsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.UpdateUserHeldCoinsRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateUserHeldCoinsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_UpdateUserHeldCoins_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserHeldCoins'
+type MockCoinStatementQuerier_UpdateUserHeldCoins_Call struct {
+	*mock.Call
+}
+
+// UpdateUserHeldCoins is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateUserHeldCoinsParams
+func (_e *MockCoinStatementQuerier_Expecter) UpdateUserHeldCoins(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_UpdateUserHeldCoins_Call {
+	return &MockCoinStatementQuerier_UpdateUserHeldCoins_Call{Call: _e.mock.On("UpdateUserHeldCoins", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_UpdateUserHeldCoins_Call) Run(run func(ctx context.Context, arg database.UpdateUserHeldCoinsParams)) *MockCoinStatementQuerier_UpdateUserHeldCoins_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateUserHeldCoinsParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateUserHeldCoins_Call) Return(_a0 database.UpdateUserHeldCoinsRow, _a1 error) *MockCoinStatementQuerier_UpdateUserHeldCoins_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateUserHeldCoins_Call) RunAndReturn(run func(context.Context, database.UpdateUserHeldCoinsParams) (database.UpdateUserHeldCoinsRow, error)) *MockCoinStatementQuerier_UpdateUserHeldCoins_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserName provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) UpdateUserName(ctx context.Context, arg database.UpdateUserNameParams) (database.UpdateUserNameRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserName")
+	}
+
+	var r0 database.UpdateUserNameRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserNameParams) (database.UpdateUserNameRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserNameParams) database.UpdateUserNameRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.UpdateUserNameRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateUserNameParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_UpdateUserName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserName'
+type MockCoinStatementQuerier_UpdateUserName_Call struct {
+	*mock.Call
+}
+
+// UpdateUserName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateUserNameParams
+func (_e *MockCoinStatementQuerier_Expecter) UpdateUserName(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_UpdateUserName_Call {
+	return &MockCoinStatementQuerier_UpdateUserName_Call{Call: _e.mock.On("UpdateUserName", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_UpdateUserName_Call) Run(run func(ctx context.Context, arg database.UpdateUserNameParams)) *MockCoinStatementQuerier_UpdateUserName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateUserNameParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateUserName_Call) Return(_a0 database.UpdateUserNameRow, _a1 error) *MockCoinStatementQuerier_UpdateUserName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateUserName_Call) RunAndReturn(run func(context.Context, database.UpdateUserNameParams) (database.UpdateUserNameRow, error)) *MockCoinStatementQuerier_UpdateUserName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserPassword provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) UpdateUserPassword(ctx context.Context, arg database.UpdateUserPasswordParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserPasswordParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCoinStatementQuerier_UpdateUserPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserPassword'
+type MockCoinStatementQuerier_UpdateUserPassword_Call struct {
+	*mock.Call
+}
+
+// UpdateUserPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateUserPasswordParams
+func (_e *MockCoinStatementQuerier_Expecter) UpdateUserPassword(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_UpdateUserPassword_Call {
+	return &MockCoinStatementQuerier_UpdateUserPassword_Call{Call: _e.mock.On("UpdateUserPassword", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_UpdateUserPassword_Call) Run(run func(ctx context.Context, arg database.UpdateUserPasswordParams)) *MockCoinStatementQuerier_UpdateUserPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateUserPasswordParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateUserPassword_Call) Return(_a0 error) *MockCoinStatementQuerier_UpdateUserPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpdateUserPassword_Call) RunAndReturn(run func(context.Context, database.UpdateUserPasswordParams) error) *MockCoinStatementQuerier_UpdateUserPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertCategoryCashbackRate provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) UpsertCategoryCashbackRate(ctx context.Context, arg database.UpsertCategoryCashbackRateParams) (database.CategoryCashbackRate, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertCategoryCashbackRate")
+	}
+
+	var r0 database.CategoryCashbackRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpsertCategoryCashbackRateParams) (database.CategoryCashbackRate, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpsertCategoryCashbackRateParams) database.CategoryCashbackRate); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CategoryCashbackRate)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpsertCategoryCashbackRateParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_UpsertCategoryCashbackRate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertCategoryCashbackRate'
+type MockCoinStatementQuerier_UpsertCategoryCashbackRate_Call struct {
+	*mock.Call
+}
+
+// UpsertCategoryCashbackRate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpsertCategoryCashbackRateParams
+func (_e *MockCoinStatementQuerier_Expecter) UpsertCategoryCashbackRate(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_UpsertCategoryCashbackRate_Call {
+	return &MockCoinStatementQuerier_UpsertCategoryCashbackRate_Call{Call: _e.mock.On("UpsertCategoryCashbackRate", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_UpsertCategoryCashbackRate_Call) Run(run func(ctx context.Context, arg database.UpsertCategoryCashbackRateParams)) *MockCoinStatementQuerier_UpsertCategoryCashbackRate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpsertCategoryCashbackRateParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpsertCategoryCashbackRate_Call) Return(_a0 database.CategoryCashbackRate, _a1 error) *MockCoinStatementQuerier_UpsertCategoryCashbackRate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpsertCategoryCashbackRate_Call) RunAndReturn(run func(context.Context, database.UpsertCategoryCashbackRateParams) (database.CategoryCashbackRate, error)) *MockCoinStatementQuerier_UpsertCategoryCashbackRate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertUserSpendLimit provides a mock function with given fields: ctx, arg
+func (_m *MockCoinStatementQuerier) UpsertUserSpendLimit(ctx context.Context, arg database.UpsertUserSpendLimitParams) (database.UserSpendLimit, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertUserSpendLimit")
+	}
+
+	var r0 database.UserSpendLimit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpsertUserSpendLimitParams) (database.UserSpendLimit, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpsertUserSpendLimitParams) database.UserSpendLimit); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.UserSpendLimit)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpsertUserSpendLimitParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCoinStatementQuerier_UpsertUserSpendLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertUserSpendLimit'
+type MockCoinStatementQuerier_UpsertUserSpendLimit_Call struct {
+	*mock.Call
+}
+
+// UpsertUserSpendLimit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpsertUserSpendLimitParams
+func (_e *MockCoinStatementQuerier_Expecter) UpsertUserSpendLimit(ctx interface{}, arg interface{}) *MockCoinStatementQuerier_UpsertUserSpendLimit_Call {
+	return &MockCoinStatementQuerier_UpsertUserSpendLimit_Call{Call: _e.mock.On("UpsertUserSpendLimit", ctx, arg)}
+}
+
+func (_c *MockCoinStatementQuerier_UpsertUserSpendLimit_Call) Run(run func(ctx context.Context, arg database.UpsertUserSpendLimitParams)) *MockCoinStatementQuerier_UpsertUserSpendLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpsertUserSpendLimitParams))
+	})
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpsertUserSpendLimit_Call) Return(_a0 database.UserSpendLimit, _a1 error) *MockCoinStatementQuerier_UpsertUserSpendLimit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCoinStatementQuerier_UpsertUserSpendLimit_Call) RunAndReturn(run func(context.Context, database.UpsertUserSpendLimitParams) (database.UserSpendLimit, error)) *MockCoinStatementQuerier_UpsertUserSpendLimit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCoinStatementQuerier creates a new instance of MockCoinStatementQuerier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCoinStatementQuerier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCoinStatementQuerier {
+	mock := &MockCoinStatementQuerier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,4680 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	database "backend/internal/database"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	pgtype "github.com/jackc/pgx/v5/pgtype"
+)
+
+// MockQuerier is an autogenerated mock type for the Querier type
+type MockQuerier struct {
+	mock.Mock
+}
+
+type MockQuerier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockQuerier) EXPECT() *MockQuerier_Expecter {
+	return &MockQuerier_Expecter{mock: &_m.Mock}
+}
+
+// BlockUserSpending provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) BlockUserSpending(ctx context.Context, arg database.BlockUserSpendingParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BlockUserSpending")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.BlockUserSpendingParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_BlockUserSpending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BlockUserSpending'
+type MockQuerier_BlockUserSpending_Call struct {
+	*mock.Call
+}
+
+// BlockUserSpending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.BlockUserSpendingParams
+func (_e *MockQuerier_Expecter) BlockUserSpending(ctx interface{}, arg interface{}) *MockQuerier_BlockUserSpending_Call {
+	return &MockQuerier_BlockUserSpending_Call{Call: _e.mock.On("BlockUserSpending", ctx, arg)}
+}
+
+func (_c *MockQuerier_BlockUserSpending_Call) Run(run func(ctx context.Context, arg database.BlockUserSpendingParams)) *MockQuerier_BlockUserSpending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.BlockUserSpendingParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_BlockUserSpending_Call) Return(_a0 error) *MockQuerier_BlockUserSpending_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_BlockUserSpending_Call) RunAndReturn(run func(context.Context, database.BlockUserSpendingParams) error) *MockQuerier_BlockUserSpending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckCartItemExists provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CheckCartItemExists(ctx context.Context, arg database.CheckCartItemExistsParams) (bool, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckCartItemExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CheckCartItemExistsParams) (bool, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CheckCartItemExistsParams) bool); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CheckCartItemExistsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CheckCartItemExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckCartItemExists'
+type MockQuerier_CheckCartItemExists_Call struct {
+	*mock.Call
+}
+
+// CheckCartItemExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CheckCartItemExistsParams
+func (_e *MockQuerier_Expecter) CheckCartItemExists(ctx interface{}, arg interface{}) *MockQuerier_CheckCartItemExists_Call {
+	return &MockQuerier_CheckCartItemExists_Call{Call: _e.mock.On("CheckCartItemExists", ctx, arg)}
+}
+
+func (_c *MockQuerier_CheckCartItemExists_Call) Run(run func(ctx context.Context, arg database.CheckCartItemExistsParams)) *MockQuerier_CheckCartItemExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CheckCartItemExistsParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CheckCartItemExists_Call) Return(_a0 bool, _a1 error) *MockQuerier_CheckCartItemExists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CheckCartItemExists_Call) RunAndReturn(run func(context.Context, database.CheckCartItemExistsParams) (bool, error)) *MockQuerier_CheckCartItemExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckEmailExists provides a mock function with given fields: ctx, email
+func (_m *MockQuerier) CheckEmailExists(ctx context.Context, email string) (bool, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckEmailExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CheckEmailExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckEmailExists'
+type MockQuerier_CheckEmailExists_Call struct {
+	*mock.Call
+}
+
+// CheckEmailExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockQuerier_Expecter) CheckEmailExists(ctx interface{}, email interface{}) *MockQuerier_CheckEmailExists_Call {
+	return &MockQuerier_CheckEmailExists_Call{Call: _e.mock.On("CheckEmailExists", ctx, email)}
+}
+
+func (_c *MockQuerier_CheckEmailExists_Call) Run(run func(ctx context.Context, email string)) *MockQuerier_CheckEmailExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CheckEmailExists_Call) Return(_a0 bool, _a1 error) *MockQuerier_CheckEmailExists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CheckEmailExists_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockQuerier_CheckEmailExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckEmailExistsForOtherUser provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CheckEmailExistsForOtherUser(ctx context.Context, arg database.CheckEmailExistsForOtherUserParams) (bool, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckEmailExistsForOtherUser")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CheckEmailExistsForOtherUserParams) (bool, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CheckEmailExistsForOtherUserParams) bool); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CheckEmailExistsForOtherUserParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CheckEmailExistsForOtherUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckEmailExistsForOtherUser'
+type MockQuerier_CheckEmailExistsForOtherUser_Call struct {
+	*mock.Call
+}
+
+// CheckEmailExistsForOtherUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CheckEmailExistsForOtherUserParams
+func (_e *MockQuerier_Expecter) CheckEmailExistsForOtherUser(ctx interface{}, arg interface{}) *MockQuerier_CheckEmailExistsForOtherUser_Call {
+	return &MockQuerier_CheckEmailExistsForOtherUser_Call{Call: _e.mock.On("CheckEmailExistsForOtherUser", ctx, arg)}
+}
+
+func (_c *MockQuerier_CheckEmailExistsForOtherUser_Call) Run(run func(ctx context.Context, arg database.CheckEmailExistsForOtherUserParams)) *MockQuerier_CheckEmailExistsForOtherUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CheckEmailExistsForOtherUserParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CheckEmailExistsForOtherUser_Call) Return(_a0 bool, _a1 error) *MockQuerier_CheckEmailExistsForOtherUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CheckEmailExistsForOtherUser_Call) RunAndReturn(run func(context.Context, database.CheckEmailExistsForOtherUserParams) (bool, error)) *MockQuerier_CheckEmailExistsForOtherUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountOrdersByStatus provides a mock function with given fields: ctx
+func (_m *MockQuerier) CountOrdersByStatus(ctx context.Context) ([]database.CountOrdersByStatusRow, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountOrdersByStatus")
+	}
+
+	var r0 []database.CountOrdersByStatusRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]database.CountOrdersByStatusRow, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []database.CountOrdersByStatusRow); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CountOrdersByStatusRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CountOrdersByStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountOrdersByStatus'
+type MockQuerier_CountOrdersByStatus_Call struct {
+	*mock.Call
+}
+
+// CountOrdersByStatus is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) CountOrdersByStatus(ctx interface{}) *MockQuerier_CountOrdersByStatus_Call {
+	return &MockQuerier_CountOrdersByStatus_Call{Call: _e.mock.On("CountOrdersByStatus", ctx)}
+}
+
+func (_c *MockQuerier_CountOrdersByStatus_Call) Run(run func(ctx context.Context)) *MockQuerier_CountOrdersByStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CountOrdersByStatus_Call) Return(_a0 []database.CountOrdersByStatusRow, _a1 error) *MockQuerier_CountOrdersByStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CountOrdersByStatus_Call) RunAndReturn(run func(context.Context) ([]database.CountOrdersByStatusRow, error)) *MockQuerier_CountOrdersByStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountSecurityEventsSince provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CountSecurityEventsSince(ctx context.Context, arg database.CountSecurityEventsSinceParams) (int32, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountSecurityEventsSince")
+	}
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CountSecurityEventsSinceParams) (int32, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CountSecurityEventsSinceParams) int32); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CountSecurityEventsSinceParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CountSecurityEventsSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountSecurityEventsSince'
+type MockQuerier_CountSecurityEventsSince_Call struct {
+	*mock.Call
+}
+
+// CountSecurityEventsSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CountSecurityEventsSinceParams
+func (_e *MockQuerier_Expecter) CountSecurityEventsSince(ctx interface{}, arg interface{}) *MockQuerier_CountSecurityEventsSince_Call {
+	return &MockQuerier_CountSecurityEventsSince_Call{Call: _e.mock.On("CountSecurityEventsSince", ctx, arg)}
+}
+
+func (_c *MockQuerier_CountSecurityEventsSince_Call) Run(run func(ctx context.Context, arg database.CountSecurityEventsSinceParams)) *MockQuerier_CountSecurityEventsSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CountSecurityEventsSinceParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CountSecurityEventsSince_Call) Return(_a0 int32, _a1 error) *MockQuerier_CountSecurityEventsSince_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CountSecurityEventsSince_Call) RunAndReturn(run func(context.Context, database.CountSecurityEventsSinceParams) (int32, error)) *MockQuerier_CountSecurityEventsSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountUsersByNormalizedEmail provides a mock function with given fields: ctx, normalizedEmail
+func (_m *MockQuerier) CountUsersByNormalizedEmail(ctx context.Context, normalizedEmail string) (int32, error) {
+	ret := _m.Called(ctx, normalizedEmail)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUsersByNormalizedEmail")
+	}
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int32, error)); ok {
+		return rf(ctx, normalizedEmail)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int32); ok {
+		r0 = rf(ctx, normalizedEmail)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, normalizedEmail)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CountUsersByNormalizedEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUsersByNormalizedEmail'
+type MockQuerier_CountUsersByNormalizedEmail_Call struct {
+	*mock.Call
+}
+
+// CountUsersByNormalizedEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - normalizedEmail string
+func (_e *MockQuerier_Expecter) CountUsersByNormalizedEmail(ctx interface{}, normalizedEmail interface{}) *MockQuerier_CountUsersByNormalizedEmail_Call {
+	return &MockQuerier_CountUsersByNormalizedEmail_Call{Call: _e.mock.On("CountUsersByNormalizedEmail", ctx, normalizedEmail)}
+}
+
+func (_c *MockQuerier_CountUsersByNormalizedEmail_Call) Run(run func(ctx context.Context, normalizedEmail string)) *MockQuerier_CountUsersByNormalizedEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CountUsersByNormalizedEmail_Call) Return(_a0 int32, _a1 error) *MockQuerier_CountUsersByNormalizedEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CountUsersByNormalizedEmail_Call) RunAndReturn(run func(context.Context, string) (int32, error)) *MockQuerier_CountUsersByNormalizedEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCartItem provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateCartItem(ctx context.Context, arg database.CreateCartItemParams) (database.CartItem, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCartItem")
+	}
+
+	var r0 database.CartItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCartItemParams) (database.CartItem, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCartItemParams) database.CartItem); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CartItem)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateCartItemParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CreateCartItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCartItem'
+type MockQuerier_CreateCartItem_Call struct {
+	*mock.Call
+}
+
+// CreateCartItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateCartItemParams
+func (_e *MockQuerier_Expecter) CreateCartItem(ctx interface{}, arg interface{}) *MockQuerier_CreateCartItem_Call {
+	return &MockQuerier_CreateCartItem_Call{Call: _e.mock.On("CreateCartItem", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateCartItem_Call) Run(run func(ctx context.Context, arg database.CreateCartItemParams)) *MockQuerier_CreateCartItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateCartItemParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateCartItem_Call) Return(_a0 database.CartItem, _a1 error) *MockQuerier_CreateCartItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateCartItem_Call) RunAndReturn(run func(context.Context, database.CreateCartItemParams) (database.CartItem, error)) *MockQuerier_CreateCartItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCoinHold provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateCoinHold(ctx context.Context, arg database.CreateCoinHoldParams) (database.CoinHold, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCoinHold")
+	}
+
+	var r0 database.CoinHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCoinHoldParams) (database.CoinHold, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCoinHoldParams) database.CoinHold); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CoinHold)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateCoinHoldParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CreateCoinHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCoinHold'
+type MockQuerier_CreateCoinHold_Call struct {
+	*mock.Call
+}
+
+// CreateCoinHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateCoinHoldParams
+func (_e *MockQuerier_Expecter) CreateCoinHold(ctx interface{}, arg interface{}) *MockQuerier_CreateCoinHold_Call {
+	return &MockQuerier_CreateCoinHold_Call{Call: _e.mock.On("CreateCoinHold", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateCoinHold_Call) Run(run func(ctx context.Context, arg database.CreateCoinHoldParams)) *MockQuerier_CreateCoinHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateCoinHoldParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateCoinHold_Call) Return(_a0 database.CoinHold, _a1 error) *MockQuerier_CreateCoinHold_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateCoinHold_Call) RunAndReturn(run func(context.Context, database.CreateCoinHoldParams) (database.CoinHold, error)) *MockQuerier_CreateCoinHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCoinLot provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateCoinLot(ctx context.Context, arg database.CreateCoinLotParams) (database.CoinLot, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCoinLot")
+	}
+
+	var r0 database.CoinLot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCoinLotParams) (database.CoinLot, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCoinLotParams) database.CoinLot); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CoinLot)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateCoinLotParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CreateCoinLot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCoinLot'
+type MockQuerier_CreateCoinLot_Call struct {
+	*mock.Call
+}
+
+// CreateCoinLot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateCoinLotParams
+func (_e *MockQuerier_Expecter) CreateCoinLot(ctx interface{}, arg interface{}) *MockQuerier_CreateCoinLot_Call {
+	return &MockQuerier_CreateCoinLot_Call{Call: _e.mock.On("CreateCoinLot", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateCoinLot_Call) Run(run func(ctx context.Context, arg database.CreateCoinLotParams)) *MockQuerier_CreateCoinLot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateCoinLotParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateCoinLot_Call) Return(_a0 database.CoinLot, _a1 error) *MockQuerier_CreateCoinLot_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateCoinLot_Call) RunAndReturn(run func(context.Context, database.CreateCoinLotParams) (database.CoinLot, error)) *MockQuerier_CreateCoinLot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCoinPack provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateCoinPack(ctx context.Context, arg database.CreateCoinPackParams) (database.CoinPack, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCoinPack")
+	}
+
+	var r0 database.CoinPack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCoinPackParams) (database.CoinPack, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCoinPackParams) database.CoinPack); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CoinPack)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateCoinPackParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CreateCoinPack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCoinPack'
+type MockQuerier_CreateCoinPack_Call struct {
+	*mock.Call
+}
+
+// CreateCoinPack is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateCoinPackParams
+func (_e *MockQuerier_Expecter) CreateCoinPack(ctx interface{}, arg interface{}) *MockQuerier_CreateCoinPack_Call {
+	return &MockQuerier_CreateCoinPack_Call{Call: _e.mock.On("CreateCoinPack", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateCoinPack_Call) Run(run func(ctx context.Context, arg database.CreateCoinPackParams)) *MockQuerier_CreateCoinPack_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateCoinPackParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateCoinPack_Call) Return(_a0 database.CoinPack, _a1 error) *MockQuerier_CreateCoinPack_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateCoinPack_Call) RunAndReturn(run func(context.Context, database.CreateCoinPackParams) (database.CoinPack, error)) *MockQuerier_CreateCoinPack_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCoinTransaction provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateCoinTransaction(ctx context.Context, arg database.CreateCoinTransactionParams) (database.CoinTransaction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCoinTransaction")
+	}
+
+	var r0 database.CoinTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCoinTransactionParams) (database.CoinTransaction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateCoinTransactionParams) database.CoinTransaction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CoinTransaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateCoinTransactionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CreateCoinTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCoinTransaction'
+type MockQuerier_CreateCoinTransaction_Call struct {
+	*mock.Call
+}
+
+// CreateCoinTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateCoinTransactionParams
+func (_e *MockQuerier_Expecter) CreateCoinTransaction(ctx interface{}, arg interface{}) *MockQuerier_CreateCoinTransaction_Call {
+	return &MockQuerier_CreateCoinTransaction_Call{Call: _e.mock.On("CreateCoinTransaction", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateCoinTransaction_Call) Run(run func(ctx context.Context, arg database.CreateCoinTransactionParams)) *MockQuerier_CreateCoinTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateCoinTransactionParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateCoinTransaction_Call) Return(_a0 database.CoinTransaction, _a1 error) *MockQuerier_CreateCoinTransaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateCoinTransaction_Call) RunAndReturn(run func(context.Context, database.CreateCoinTransactionParams) (database.CoinTransaction, error)) *MockQuerier_CreateCoinTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateGiftCode provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateGiftCode(ctx context.Context, arg database.CreateGiftCodeParams) (database.GiftCode, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGiftCode")
+	}
+
+	var r0 database.GiftCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateGiftCodeParams) (database.GiftCode, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateGiftCodeParams) database.GiftCode); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.GiftCode)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateGiftCodeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CreateGiftCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateGiftCode'
+type MockQuerier_CreateGiftCode_Call struct {
+	*mock.Call
+}
+
+// CreateGiftCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateGiftCodeParams
+func (_e *MockQuerier_Expecter) CreateGiftCode(ctx interface{}, arg interface{}) *MockQuerier_CreateGiftCode_Call {
+	return &MockQuerier_CreateGiftCode_Call{Call: _e.mock.On("CreateGiftCode", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateGiftCode_Call) Run(run func(ctx context.Context, arg database.CreateGiftCodeParams)) *MockQuerier_CreateGiftCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateGiftCodeParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateGiftCode_Call) Return(_a0 database.GiftCode, _a1 error) *MockQuerier_CreateGiftCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateGiftCode_Call) RunAndReturn(run func(context.Context, database.CreateGiftCodeParams) (database.GiftCode, error)) *MockQuerier_CreateGiftCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateGiftCodeBatch provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateGiftCodeBatch(ctx context.Context, arg database.CreateGiftCodeBatchParams) (database.GiftCodeBatch, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGiftCodeBatch")
+	}
+
+	var r0 database.GiftCodeBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateGiftCodeBatchParams) (database.GiftCodeBatch, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateGiftCodeBatchParams) database.GiftCodeBatch); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.GiftCodeBatch)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateGiftCodeBatchParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CreateGiftCodeBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateGiftCodeBatch'
+type MockQuerier_CreateGiftCodeBatch_Call struct {
+	*mock.Call
+}
+
+// CreateGiftCodeBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateGiftCodeBatchParams
+func (_e *MockQuerier_Expecter) CreateGiftCodeBatch(ctx interface{}, arg interface{}) *MockQuerier_CreateGiftCodeBatch_Call {
+	return &MockQuerier_CreateGiftCodeBatch_Call{Call: _e.mock.On("CreateGiftCodeBatch", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateGiftCodeBatch_Call) Run(run func(ctx context.Context, arg database.CreateGiftCodeBatchParams)) *MockQuerier_CreateGiftCodeBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateGiftCodeBatchParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateGiftCodeBatch_Call) Return(_a0 database.GiftCodeBatch, _a1 error) *MockQuerier_CreateGiftCodeBatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateGiftCodeBatch_Call) RunAndReturn(run func(context.Context, database.CreateGiftCodeBatchParams) (database.GiftCodeBatch, error)) *MockQuerier_CreateGiftCodeBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateGiftCodeRedemption provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateGiftCodeRedemption(ctx context.Context, arg database.CreateGiftCodeRedemptionParams) (database.GiftCodeRedemption, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGiftCodeRedemption")
+	}
+
+	var r0 database.GiftCodeRedemption
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateGiftCodeRedemptionParams) (database.GiftCodeRedemption, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateGiftCodeRedemptionParams) database.GiftCodeRedemption); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.GiftCodeRedemption)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateGiftCodeRedemptionParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CreateGiftCodeRedemption_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateGiftCodeRedemption'
+type MockQuerier_CreateGiftCodeRedemption_Call struct {
+	*mock.Call
+}
+
+// CreateGiftCodeRedemption is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateGiftCodeRedemptionParams
+func (_e *MockQuerier_Expecter) CreateGiftCodeRedemption(ctx interface{}, arg interface{}) *MockQuerier_CreateGiftCodeRedemption_Call {
+	return &MockQuerier_CreateGiftCodeRedemption_Call{Call: _e.mock.On("CreateGiftCodeRedemption", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateGiftCodeRedemption_Call) Run(run func(ctx context.Context, arg database.CreateGiftCodeRedemptionParams)) *MockQuerier_CreateGiftCodeRedemption_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateGiftCodeRedemptionParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateGiftCodeRedemption_Call) Return(_a0 database.GiftCodeRedemption, _a1 error) *MockQuerier_CreateGiftCodeRedemption_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateGiftCodeRedemption_Call) RunAndReturn(run func(context.Context, database.CreateGiftCodeRedemptionParams) (database.GiftCodeRedemption, error)) *MockQuerier_CreateGiftCodeRedemption_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateReferral provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateReferral(ctx context.Context, arg database.CreateReferralParams) (database.Referral, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReferral")
+	}
+
+	var r0 database.Referral
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateReferralParams) (database.Referral, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateReferralParams) database.Referral); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.Referral)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateReferralParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CreateReferral_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReferral'
+type MockQuerier_CreateReferral_Call struct {
+	*mock.Call
+}
+
+// CreateReferral is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateReferralParams
+func (_e *MockQuerier_Expecter) CreateReferral(ctx interface{}, arg interface{}) *MockQuerier_CreateReferral_Call {
+	return &MockQuerier_CreateReferral_Call{Call: _e.mock.On("CreateReferral", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateReferral_Call) Run(run func(ctx context.Context, arg database.CreateReferralParams)) *MockQuerier_CreateReferral_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateReferralParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateReferral_Call) Return(_a0 database.Referral, _a1 error) *MockQuerier_CreateReferral_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateReferral_Call) RunAndReturn(run func(context.Context, database.CreateReferralParams) (database.Referral, error)) *MockQuerier_CreateReferral_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSecurityEvent provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateSecurityEvent(ctx context.Context, arg database.CreateSecurityEventParams) (database.SecurityEvent, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSecurityEvent")
+	}
+
+	var r0 database.SecurityEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateSecurityEventParams) (database.SecurityEvent, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateSecurityEventParams) database.SecurityEvent); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.SecurityEvent)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateSecurityEventParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CreateSecurityEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSecurityEvent'
+type MockQuerier_CreateSecurityEvent_Call struct {
+	*mock.Call
+}
+
+// CreateSecurityEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateSecurityEventParams
+func (_e *MockQuerier_Expecter) CreateSecurityEvent(ctx interface{}, arg interface{}) *MockQuerier_CreateSecurityEvent_Call {
+	return &MockQuerier_CreateSecurityEvent_Call{Call: _e.mock.On("CreateSecurityEvent", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateSecurityEvent_Call) Run(run func(ctx context.Context, arg database.CreateSecurityEventParams)) *MockQuerier_CreateSecurityEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateSecurityEventParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateSecurityEvent_Call) Return(_a0 database.SecurityEvent, _a1 error) *MockQuerier_CreateSecurityEvent_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateSecurityEvent_Call) RunAndReturn(run func(context.Context, database.CreateSecurityEventParams) (database.SecurityEvent, error)) *MockQuerier_CreateSecurityEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUser provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) CreateUser(ctx context.Context, arg database.CreateUserParams) (database.CreateUserRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 database.CreateUserRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateUserParams) (database.CreateUserRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.CreateUserParams) database.CreateUserRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CreateUserRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.CreateUserParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type MockQuerier_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.CreateUserParams
+func (_e *MockQuerier_Expecter) CreateUser(ctx interface{}, arg interface{}) *MockQuerier_CreateUser_Call {
+	return &MockQuerier_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateUser_Call) Run(run func(ctx context.Context, arg database.CreateUserParams)) *MockQuerier_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.CreateUserParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateUser_Call) Return(_a0 database.CreateUserRow, _a1 error) *MockQuerier_CreateUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_CreateUser_Call) RunAndReturn(run func(context.Context, database.CreateUserParams) (database.CreateUserRow, error)) *MockQuerier_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAllCartItemsByUser provides a mock function with given fields: ctx, userID
+func (_m *MockQuerier) DeleteAllCartItemsByUser(ctx context.Context, userID pgtype.UUID) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAllCartItemsByUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteAllCartItemsByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAllCartItemsByUser'
+type MockQuerier_DeleteAllCartItemsByUser_Call struct {
+	*mock.Call
+}
+
+// DeleteAllCartItemsByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID pgtype.UUID
+func (_e *MockQuerier_Expecter) DeleteAllCartItemsByUser(ctx interface{}, userID interface{}) *MockQuerier_DeleteAllCartItemsByUser_Call {
+	return &MockQuerier_DeleteAllCartItemsByUser_Call{Call: _e.mock.On("DeleteAllCartItemsByUser", ctx, userID)}
+}
+
+func (_c *MockQuerier_DeleteAllCartItemsByUser_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_DeleteAllCartItemsByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteAllCartItemsByUser_Call) Return(_a0 error) *MockQuerier_DeleteAllCartItemsByUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteAllCartItemsByUser_Call) RunAndReturn(run func(context.Context, pgtype.UUID) error) *MockQuerier_DeleteAllCartItemsByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCartItem provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) DeleteCartItem(ctx context.Context, arg database.DeleteCartItemParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCartItem")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.DeleteCartItemParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteCartItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCartItem'
+type MockQuerier_DeleteCartItem_Call struct {
+	*mock.Call
+}
+
+// DeleteCartItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.DeleteCartItemParams
+func (_e *MockQuerier_Expecter) DeleteCartItem(ctx interface{}, arg interface{}) *MockQuerier_DeleteCartItem_Call {
+	return &MockQuerier_DeleteCartItem_Call{Call: _e.mock.On("DeleteCartItem", ctx, arg)}
+}
+
+func (_c *MockQuerier_DeleteCartItem_Call) Run(run func(ctx context.Context, arg database.DeleteCartItemParams)) *MockQuerier_DeleteCartItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.DeleteCartItemParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCartItem_Call) Return(_a0 error) *MockQuerier_DeleteCartItem_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCartItem_Call) RunAndReturn(run func(context.Context, database.DeleteCartItemParams) error) *MockQuerier_DeleteCartItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCategoryCashbackRate provides a mock function with given fields: ctx, categoryID
+func (_m *MockQuerier) DeleteCategoryCashbackRate(ctx context.Context, categoryID int32) (int64, error) {
+	ret := _m.Called(ctx, categoryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCategoryCashbackRate")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (int64, error)); ok {
+		return rf(ctx, categoryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) int64); ok {
+		r0 = rf(ctx, categoryID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, categoryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_DeleteCategoryCashbackRate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCategoryCashbackRate'
+type MockQuerier_DeleteCategoryCashbackRate_Call struct {
+	*mock.Call
+}
+
+// DeleteCategoryCashbackRate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - categoryID int32
+func (_e *MockQuerier_Expecter) DeleteCategoryCashbackRate(ctx interface{}, categoryID interface{}) *MockQuerier_DeleteCategoryCashbackRate_Call {
+	return &MockQuerier_DeleteCategoryCashbackRate_Call{Call: _e.mock.On("DeleteCategoryCashbackRate", ctx, categoryID)}
+}
+
+func (_c *MockQuerier_DeleteCategoryCashbackRate_Call) Run(run func(ctx context.Context, categoryID int32)) *MockQuerier_DeleteCategoryCashbackRate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCategoryCashbackRate_Call) Return(_a0 int64, _a1 error) *MockQuerier_DeleteCategoryCashbackRate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteCategoryCashbackRate_Call) RunAndReturn(run func(context.Context, int32) (int64, error)) *MockQuerier_DeleteCategoryCashbackRate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUser provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) DeleteUser(ctx context.Context, id pgtype.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type MockQuerier_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id pgtype.UUID
+func (_e *MockQuerier_Expecter) DeleteUser(ctx interface{}, id interface{}) *MockQuerier_DeleteUser_Call {
+	return &MockQuerier_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, id)}
+}
+
+func (_c *MockQuerier_DeleteUser_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteUser_Call) Return(_a0 error) *MockQuerier_DeleteUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteUser_Call) RunAndReturn(run func(context.Context, pgtype.UUID) error) *MockQuerier_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllCategories provides a mock function with given fields: ctx
+func (_m *MockQuerier) GetAllCategories(ctx context.Context) ([]database.Category, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllCategories")
+	}
+
+	var r0 []database.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]database.Category, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []database.Category); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.Category)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetAllCategories_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllCategories'
+type MockQuerier_GetAllCategories_Call struct {
+	*mock.Call
+}
+
+// GetAllCategories is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) GetAllCategories(ctx interface{}) *MockQuerier_GetAllCategories_Call {
+	return &MockQuerier_GetAllCategories_Call{Call: _e.mock.On("GetAllCategories", ctx)}
+}
+
+func (_c *MockQuerier_GetAllCategories_Call) Run(run func(ctx context.Context)) *MockQuerier_GetAllCategories_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetAllCategories_Call) Return(_a0 []database.Category, _a1 error) *MockQuerier_GetAllCategories_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetAllCategories_Call) RunAndReturn(run func(context.Context) ([]database.Category, error)) *MockQuerier_GetAllCategories_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCartItemsByUser provides a mock function with given fields: ctx, userID
+func (_m *MockQuerier) GetCartItemsByUser(ctx context.Context, userID pgtype.UUID) ([]database.CartItem, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCartItemsByUser")
+	}
+
+	var r0 []database.CartItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) ([]database.CartItem, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) []database.CartItem); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CartItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetCartItemsByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCartItemsByUser'
+type MockQuerier_GetCartItemsByUser_Call struct {
+	*mock.Call
+}
+
+// GetCartItemsByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID pgtype.UUID
+func (_e *MockQuerier_Expecter) GetCartItemsByUser(ctx interface{}, userID interface{}) *MockQuerier_GetCartItemsByUser_Call {
+	return &MockQuerier_GetCartItemsByUser_Call{Call: _e.mock.On("GetCartItemsByUser", ctx, userID)}
+}
+
+func (_c *MockQuerier_GetCartItemsByUser_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_GetCartItemsByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetCartItemsByUser_Call) Return(_a0 []database.CartItem, _a1 error) *MockQuerier_GetCartItemsByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetCartItemsByUser_Call) RunAndReturn(run func(context.Context, pgtype.UUID) ([]database.CartItem, error)) *MockQuerier_GetCartItemsByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCategoryByID provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetCategoryByID(ctx context.Context, id int32) (database.Category, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCategoryByID")
+	}
+
+	var r0 database.Category
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.Category, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.Category); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.Category)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetCategoryByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCategoryByID'
+type MockQuerier_GetCategoryByID_Call struct {
+	*mock.Call
+}
+
+// GetCategoryByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) GetCategoryByID(ctx interface{}, id interface{}) *MockQuerier_GetCategoryByID_Call {
+	return &MockQuerier_GetCategoryByID_Call{Call: _e.mock.On("GetCategoryByID", ctx, id)}
+}
+
+func (_c *MockQuerier_GetCategoryByID_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetCategoryByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetCategoryByID_Call) Return(_a0 database.Category, _a1 error) *MockQuerier_GetCategoryByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetCategoryByID_Call) RunAndReturn(run func(context.Context, int32) (database.Category, error)) *MockQuerier_GetCategoryByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoinBalanceAt provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) GetCoinBalanceAt(ctx context.Context, arg database.GetCoinBalanceAtParams) (int32, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoinBalanceAt")
+	}
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetCoinBalanceAtParams) (int32, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetCoinBalanceAtParams) int32); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetCoinBalanceAtParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetCoinBalanceAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoinBalanceAt'
+type MockQuerier_GetCoinBalanceAt_Call struct {
+	*mock.Call
+}
+
+// GetCoinBalanceAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.GetCoinBalanceAtParams
+func (_e *MockQuerier_Expecter) GetCoinBalanceAt(ctx interface{}, arg interface{}) *MockQuerier_GetCoinBalanceAt_Call {
+	return &MockQuerier_GetCoinBalanceAt_Call{Call: _e.mock.On("GetCoinBalanceAt", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetCoinBalanceAt_Call) Run(run func(ctx context.Context, arg database.GetCoinBalanceAtParams)) *MockQuerier_GetCoinBalanceAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.GetCoinBalanceAtParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetCoinBalanceAt_Call) Return(_a0 int32, _a1 error) *MockQuerier_GetCoinBalanceAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetCoinBalanceAt_Call) RunAndReturn(run func(context.Context, database.GetCoinBalanceAtParams) (int32, error)) *MockQuerier_GetCoinBalanceAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoinHoldByID provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetCoinHoldByID(ctx context.Context, id int32) (database.CoinHold, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoinHoldByID")
+	}
+
+	var r0 database.CoinHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.CoinHold, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.CoinHold); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.CoinHold)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetCoinHoldByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoinHoldByID'
+type MockQuerier_GetCoinHoldByID_Call struct {
+	*mock.Call
+}
+
+// GetCoinHoldByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) GetCoinHoldByID(ctx interface{}, id interface{}) *MockQuerier_GetCoinHoldByID_Call {
+	return &MockQuerier_GetCoinHoldByID_Call{Call: _e.mock.On("GetCoinHoldByID", ctx, id)}
+}
+
+func (_c *MockQuerier_GetCoinHoldByID_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetCoinHoldByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetCoinHoldByID_Call) Return(_a0 database.CoinHold, _a1 error) *MockQuerier_GetCoinHoldByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetCoinHoldByID_Call) RunAndReturn(run func(context.Context, int32) (database.CoinHold, error)) *MockQuerier_GetCoinHoldByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoinHoldForUpdate provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetCoinHoldForUpdate(ctx context.Context, id int32) (database.CoinHold, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoinHoldForUpdate")
+	}
+
+	var r0 database.CoinHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.CoinHold, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.CoinHold); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.CoinHold)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetCoinHoldForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoinHoldForUpdate'
+type MockQuerier_GetCoinHoldForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetCoinHoldForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) GetCoinHoldForUpdate(ctx interface{}, id interface{}) *MockQuerier_GetCoinHoldForUpdate_Call {
+	return &MockQuerier_GetCoinHoldForUpdate_Call{Call: _e.mock.On("GetCoinHoldForUpdate", ctx, id)}
+}
+
+func (_c *MockQuerier_GetCoinHoldForUpdate_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetCoinHoldForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetCoinHoldForUpdate_Call) Return(_a0 database.CoinHold, _a1 error) *MockQuerier_GetCoinHoldForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetCoinHoldForUpdate_Call) RunAndReturn(run func(context.Context, int32) (database.CoinHold, error)) *MockQuerier_GetCoinHoldForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoinLotForUpdate provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetCoinLotForUpdate(ctx context.Context, id int32) (database.CoinLot, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoinLotForUpdate")
+	}
+
+	var r0 database.CoinLot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.CoinLot, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.CoinLot); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.CoinLot)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetCoinLotForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoinLotForUpdate'
+type MockQuerier_GetCoinLotForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetCoinLotForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) GetCoinLotForUpdate(ctx interface{}, id interface{}) *MockQuerier_GetCoinLotForUpdate_Call {
+	return &MockQuerier_GetCoinLotForUpdate_Call{Call: _e.mock.On("GetCoinLotForUpdate", ctx, id)}
+}
+
+func (_c *MockQuerier_GetCoinLotForUpdate_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetCoinLotForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetCoinLotForUpdate_Call) Return(_a0 database.CoinLot, _a1 error) *MockQuerier_GetCoinLotForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetCoinLotForUpdate_Call) RunAndReturn(run func(context.Context, int32) (database.CoinLot, error)) *MockQuerier_GetCoinLotForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoinPackByID provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetCoinPackByID(ctx context.Context, id int32) (database.CoinPack, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoinPackByID")
+	}
+
+	var r0 database.CoinPack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.CoinPack, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.CoinPack); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.CoinPack)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetCoinPackByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoinPackByID'
+type MockQuerier_GetCoinPackByID_Call struct {
+	*mock.Call
+}
+
+// GetCoinPackByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) GetCoinPackByID(ctx interface{}, id interface{}) *MockQuerier_GetCoinPackByID_Call {
+	return &MockQuerier_GetCoinPackByID_Call{Call: _e.mock.On("GetCoinPackByID", ctx, id)}
+}
+
+func (_c *MockQuerier_GetCoinPackByID_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetCoinPackByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetCoinPackByID_Call) Return(_a0 database.CoinPack, _a1 error) *MockQuerier_GetCoinPackByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetCoinPackByID_Call) RunAndReturn(run func(context.Context, int32) (database.CoinPack, error)) *MockQuerier_GetCoinPackByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoinTransactionByID provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetCoinTransactionByID(ctx context.Context, id int32) (database.CoinTransaction, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoinTransactionByID")
+	}
+
+	var r0 database.CoinTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.CoinTransaction, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.CoinTransaction); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.CoinTransaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetCoinTransactionByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoinTransactionByID'
+type MockQuerier_GetCoinTransactionByID_Call struct {
+	*mock.Call
+}
+
+// GetCoinTransactionByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) GetCoinTransactionByID(ctx interface{}, id interface{}) *MockQuerier_GetCoinTransactionByID_Call {
+	return &MockQuerier_GetCoinTransactionByID_Call{Call: _e.mock.On("GetCoinTransactionByID", ctx, id)}
+}
+
+func (_c *MockQuerier_GetCoinTransactionByID_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetCoinTransactionByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetCoinTransactionByID_Call) Return(_a0 database.CoinTransaction, _a1 error) *MockQuerier_GetCoinTransactionByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetCoinTransactionByID_Call) RunAndReturn(run func(context.Context, int32) (database.CoinTransaction, error)) *MockQuerier_GetCoinTransactionByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoinTransactionByOrderAndType provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) GetCoinTransactionByOrderAndType(ctx context.Context, arg database.GetCoinTransactionByOrderAndTypeParams) (database.CoinTransaction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoinTransactionByOrderAndType")
+	}
+
+	var r0 database.CoinTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetCoinTransactionByOrderAndTypeParams) (database.CoinTransaction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetCoinTransactionByOrderAndTypeParams) database.CoinTransaction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CoinTransaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetCoinTransactionByOrderAndTypeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetCoinTransactionByOrderAndType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoinTransactionByOrderAndType'
+type MockQuerier_GetCoinTransactionByOrderAndType_Call struct {
+	*mock.Call
+}
+
+// GetCoinTransactionByOrderAndType is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.GetCoinTransactionByOrderAndTypeParams
+func (_e *MockQuerier_Expecter) GetCoinTransactionByOrderAndType(ctx interface{}, arg interface{}) *MockQuerier_GetCoinTransactionByOrderAndType_Call {
+	return &MockQuerier_GetCoinTransactionByOrderAndType_Call{Call: _e.mock.On("GetCoinTransactionByOrderAndType", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetCoinTransactionByOrderAndType_Call) Run(run func(ctx context.Context, arg database.GetCoinTransactionByOrderAndTypeParams)) *MockQuerier_GetCoinTransactionByOrderAndType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.GetCoinTransactionByOrderAndTypeParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetCoinTransactionByOrderAndType_Call) Return(_a0 database.CoinTransaction, _a1 error) *MockQuerier_GetCoinTransactionByOrderAndType_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetCoinTransactionByOrderAndType_Call) RunAndReturn(run func(context.Context, database.GetCoinTransactionByOrderAndTypeParams) (database.CoinTransaction, error)) *MockQuerier_GetCoinTransactionByOrderAndType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCoinTransactionsByUserID provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) GetCoinTransactionsByUserID(ctx context.Context, arg database.GetCoinTransactionsByUserIDParams) ([]database.CoinTransaction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoinTransactionsByUserID")
+	}
+
+	var r0 []database.CoinTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetCoinTransactionsByUserIDParams) ([]database.CoinTransaction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetCoinTransactionsByUserIDParams) []database.CoinTransaction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetCoinTransactionsByUserIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetCoinTransactionsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoinTransactionsByUserID'
+type MockQuerier_GetCoinTransactionsByUserID_Call struct {
+	*mock.Call
+}
+
+// GetCoinTransactionsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.GetCoinTransactionsByUserIDParams
+func (_e *MockQuerier_Expecter) GetCoinTransactionsByUserID(ctx interface{}, arg interface{}) *MockQuerier_GetCoinTransactionsByUserID_Call {
+	return &MockQuerier_GetCoinTransactionsByUserID_Call{Call: _e.mock.On("GetCoinTransactionsByUserID", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetCoinTransactionsByUserID_Call) Run(run func(ctx context.Context, arg database.GetCoinTransactionsByUserIDParams)) *MockQuerier_GetCoinTransactionsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.GetCoinTransactionsByUserIDParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetCoinTransactionsByUserID_Call) Return(_a0 []database.CoinTransaction, _a1 error) *MockQuerier_GetCoinTransactionsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetCoinTransactionsByUserID_Call) RunAndReturn(run func(context.Context, database.GetCoinTransactionsByUserIDParams) ([]database.CoinTransaction, error)) *MockQuerier_GetCoinTransactionsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetExpiredCoinLotTotal provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) GetExpiredCoinLotTotal(ctx context.Context, arg database.GetExpiredCoinLotTotalParams) (int32, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpiredCoinLotTotal")
+	}
+
+	var r0 int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetExpiredCoinLotTotalParams) (int32, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetExpiredCoinLotTotalParams) int32); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetExpiredCoinLotTotalParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetExpiredCoinLotTotal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExpiredCoinLotTotal'
+type MockQuerier_GetExpiredCoinLotTotal_Call struct {
+	*mock.Call
+}
+
+// GetExpiredCoinLotTotal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.GetExpiredCoinLotTotalParams
+func (_e *MockQuerier_Expecter) GetExpiredCoinLotTotal(ctx interface{}, arg interface{}) *MockQuerier_GetExpiredCoinLotTotal_Call {
+	return &MockQuerier_GetExpiredCoinLotTotal_Call{Call: _e.mock.On("GetExpiredCoinLotTotal", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetExpiredCoinLotTotal_Call) Run(run func(ctx context.Context, arg database.GetExpiredCoinLotTotalParams)) *MockQuerier_GetExpiredCoinLotTotal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.GetExpiredCoinLotTotalParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetExpiredCoinLotTotal_Call) Return(_a0 int32, _a1 error) *MockQuerier_GetExpiredCoinLotTotal_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetExpiredCoinLotTotal_Call) RunAndReturn(run func(context.Context, database.GetExpiredCoinLotTotalParams) (int32, error)) *MockQuerier_GetExpiredCoinLotTotal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGiftCodeBatchByID provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetGiftCodeBatchByID(ctx context.Context, id int32) (database.GiftCodeBatch, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGiftCodeBatchByID")
+	}
+
+	var r0 database.GiftCodeBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.GiftCodeBatch, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.GiftCodeBatch); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.GiftCodeBatch)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetGiftCodeBatchByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGiftCodeBatchByID'
+type MockQuerier_GetGiftCodeBatchByID_Call struct {
+	*mock.Call
+}
+
+// GetGiftCodeBatchByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) GetGiftCodeBatchByID(ctx interface{}, id interface{}) *MockQuerier_GetGiftCodeBatchByID_Call {
+	return &MockQuerier_GetGiftCodeBatchByID_Call{Call: _e.mock.On("GetGiftCodeBatchByID", ctx, id)}
+}
+
+func (_c *MockQuerier_GetGiftCodeBatchByID_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetGiftCodeBatchByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetGiftCodeBatchByID_Call) Return(_a0 database.GiftCodeBatch, _a1 error) *MockQuerier_GetGiftCodeBatchByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetGiftCodeBatchByID_Call) RunAndReturn(run func(context.Context, int32) (database.GiftCodeBatch, error)) *MockQuerier_GetGiftCodeBatchByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetGiftCodeByHashForUpdate provides a mock function with given fields: ctx, codeHash
+func (_m *MockQuerier) GetGiftCodeByHashForUpdate(ctx context.Context, codeHash string) (database.GiftCode, error) {
+	ret := _m.Called(ctx, codeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGiftCodeByHashForUpdate")
+	}
+
+	var r0 database.GiftCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (database.GiftCode, error)); ok {
+		return rf(ctx, codeHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) database.GiftCode); ok {
+		r0 = rf(ctx, codeHash)
+	} else {
+		r0 = ret.Get(0).(database.GiftCode)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, codeHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetGiftCodeByHashForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetGiftCodeByHashForUpdate'
+type MockQuerier_GetGiftCodeByHashForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetGiftCodeByHashForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - codeHash string
+func (_e *MockQuerier_Expecter) GetGiftCodeByHashForUpdate(ctx interface{}, codeHash interface{}) *MockQuerier_GetGiftCodeByHashForUpdate_Call {
+	return &MockQuerier_GetGiftCodeByHashForUpdate_Call{Call: _e.mock.On("GetGiftCodeByHashForUpdate", ctx, codeHash)}
+}
+
+func (_c *MockQuerier_GetGiftCodeByHashForUpdate_Call) Run(run func(ctx context.Context, codeHash string)) *MockQuerier_GetGiftCodeByHashForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetGiftCodeByHashForUpdate_Call) Return(_a0 database.GiftCode, _a1 error) *MockQuerier_GetGiftCodeByHashForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetGiftCodeByHashForUpdate_Call) RunAndReturn(run func(context.Context, string) (database.GiftCode, error)) *MockQuerier_GetGiftCodeByHashForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrderByID provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetOrderByID(ctx context.Context, id int32) (database.Order, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrderByID")
+	}
+
+	var r0 database.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.Order, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.Order); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.Order)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetOrderByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrderByID'
+type MockQuerier_GetOrderByID_Call struct {
+	*mock.Call
+}
+
+// GetOrderByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) GetOrderByID(ctx interface{}, id interface{}) *MockQuerier_GetOrderByID_Call {
+	return &MockQuerier_GetOrderByID_Call{Call: _e.mock.On("GetOrderByID", ctx, id)}
+}
+
+func (_c *MockQuerier_GetOrderByID_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetOrderByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetOrderByID_Call) Return(_a0 database.Order, _a1 error) *MockQuerier_GetOrderByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetOrderByID_Call) RunAndReturn(run func(context.Context, int32) (database.Order, error)) *MockQuerier_GetOrderByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductByID provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetProductByID(ctx context.Context, id int32) (database.Product, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductByID")
+	}
+
+	var r0 database.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.Product, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.Product); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.Product)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetProductByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductByID'
+type MockQuerier_GetProductByID_Call struct {
+	*mock.Call
+}
+
+// GetProductByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) GetProductByID(ctx interface{}, id interface{}) *MockQuerier_GetProductByID_Call {
+	return &MockQuerier_GetProductByID_Call{Call: _e.mock.On("GetProductByID", ctx, id)}
+}
+
+func (_c *MockQuerier_GetProductByID_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetProductByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetProductByID_Call) Return(_a0 database.Product, _a1 error) *MockQuerier_GetProductByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetProductByID_Call) RunAndReturn(run func(context.Context, int32) (database.Product, error)) *MockQuerier_GetProductByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReferralByRefereeID provides a mock function with given fields: ctx, refereeID
+func (_m *MockQuerier) GetReferralByRefereeID(ctx context.Context, refereeID pgtype.UUID) (database.Referral, error) {
+	ret := _m.Called(ctx, refereeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReferralByRefereeID")
+	}
+
+	var r0 database.Referral
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (database.Referral, error)); ok {
+		return rf(ctx, refereeID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) database.Referral); ok {
+		r0 = rf(ctx, refereeID)
+	} else {
+		r0 = ret.Get(0).(database.Referral)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = rf(ctx, refereeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetReferralByRefereeID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReferralByRefereeID'
+type MockQuerier_GetReferralByRefereeID_Call struct {
+	*mock.Call
+}
+
+// GetReferralByRefereeID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refereeID pgtype.UUID
+func (_e *MockQuerier_Expecter) GetReferralByRefereeID(ctx interface{}, refereeID interface{}) *MockQuerier_GetReferralByRefereeID_Call {
+	return &MockQuerier_GetReferralByRefereeID_Call{Call: _e.mock.On("GetReferralByRefereeID", ctx, refereeID)}
+}
+
+func (_c *MockQuerier_GetReferralByRefereeID_Call) Run(run func(ctx context.Context, refereeID pgtype.UUID)) *MockQuerier_GetReferralByRefereeID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetReferralByRefereeID_Call) Return(_a0 database.Referral, _a1 error) *MockQuerier_GetReferralByRefereeID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetReferralByRefereeID_Call) RunAndReturn(run func(context.Context, pgtype.UUID) (database.Referral, error)) *MockQuerier_GetReferralByRefereeID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReferralForUpdate provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetReferralForUpdate(ctx context.Context, id int32) (database.Referral, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReferralForUpdate")
+	}
+
+	var r0 database.Referral
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.Referral, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.Referral); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.Referral)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetReferralForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReferralForUpdate'
+type MockQuerier_GetReferralForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetReferralForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) GetReferralForUpdate(ctx interface{}, id interface{}) *MockQuerier_GetReferralForUpdate_Call {
+	return &MockQuerier_GetReferralForUpdate_Call{Call: _e.mock.On("GetReferralForUpdate", ctx, id)}
+}
+
+func (_c *MockQuerier_GetReferralForUpdate_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetReferralForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetReferralForUpdate_Call) Return(_a0 database.Referral, _a1 error) *MockQuerier_GetReferralForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetReferralForUpdate_Call) RunAndReturn(run func(context.Context, int32) (database.Referral, error)) *MockQuerier_GetReferralForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSpendActivity provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) GetSpendActivity(ctx context.Context, arg database.GetSpendActivityParams) (database.GetSpendActivityRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSpendActivity")
+	}
+
+	var r0 database.GetSpendActivityRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetSpendActivityParams) (database.GetSpendActivityRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetSpendActivityParams) database.GetSpendActivityRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.GetSpendActivityRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetSpendActivityParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetSpendActivity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSpendActivity'
+type MockQuerier_GetSpendActivity_Call struct {
+	*mock.Call
+}
+
+// GetSpendActivity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.GetSpendActivityParams
+func (_e *MockQuerier_Expecter) GetSpendActivity(ctx interface{}, arg interface{}) *MockQuerier_GetSpendActivity_Call {
+	return &MockQuerier_GetSpendActivity_Call{Call: _e.mock.On("GetSpendActivity", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetSpendActivity_Call) Run(run func(ctx context.Context, arg database.GetSpendActivityParams)) *MockQuerier_GetSpendActivity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.GetSpendActivityParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetSpendActivity_Call) Return(_a0 database.GetSpendActivityRow, _a1 error) *MockQuerier_GetSpendActivity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetSpendActivity_Call) RunAndReturn(run func(context.Context, database.GetSpendActivityParams) (database.GetSpendActivityRow, error)) *MockQuerier_GetSpendActivity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUpcomingCoinExpiries provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) GetUpcomingCoinExpiries(ctx context.Context, arg database.GetUpcomingCoinExpiriesParams) ([]database.GetUpcomingCoinExpiriesRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUpcomingCoinExpiries")
+	}
+
+	var r0 []database.GetUpcomingCoinExpiriesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetUpcomingCoinExpiriesParams) ([]database.GetUpcomingCoinExpiriesRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GetUpcomingCoinExpiriesParams) []database.GetUpcomingCoinExpiriesRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.GetUpcomingCoinExpiriesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GetUpcomingCoinExpiriesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetUpcomingCoinExpiries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUpcomingCoinExpiries'
+type MockQuerier_GetUpcomingCoinExpiries_Call struct {
+	*mock.Call
+}
+
+// GetUpcomingCoinExpiries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.GetUpcomingCoinExpiriesParams
+func (_e *MockQuerier_Expecter) GetUpcomingCoinExpiries(ctx interface{}, arg interface{}) *MockQuerier_GetUpcomingCoinExpiries_Call {
+	return &MockQuerier_GetUpcomingCoinExpiries_Call{Call: _e.mock.On("GetUpcomingCoinExpiries", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetUpcomingCoinExpiries_Call) Run(run func(ctx context.Context, arg database.GetUpcomingCoinExpiriesParams)) *MockQuerier_GetUpcomingCoinExpiries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.GetUpcomingCoinExpiriesParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetUpcomingCoinExpiries_Call) Return(_a0 []database.GetUpcomingCoinExpiriesRow, _a1 error) *MockQuerier_GetUpcomingCoinExpiries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetUpcomingCoinExpiries_Call) RunAndReturn(run func(context.Context, database.GetUpcomingCoinExpiriesParams) ([]database.GetUpcomingCoinExpiriesRow, error)) *MockQuerier_GetUpcomingCoinExpiries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByEmail provides a mock function with given fields: ctx, email
+func (_m *MockQuerier) GetUserByEmail(ctx context.Context, email string) (database.User, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByEmail")
+	}
+
+	var r0 database.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (database.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) database.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Get(0).(database.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetUserByEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByEmail'
+type MockQuerier_GetUserByEmail_Call struct {
+	*mock.Call
+}
+
+// GetUserByEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+func (_e *MockQuerier_Expecter) GetUserByEmail(ctx interface{}, email interface{}) *MockQuerier_GetUserByEmail_Call {
+	return &MockQuerier_GetUserByEmail_Call{Call: _e.mock.On("GetUserByEmail", ctx, email)}
+}
+
+func (_c *MockQuerier_GetUserByEmail_Call) Run(run func(ctx context.Context, email string)) *MockQuerier_GetUserByEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetUserByEmail_Call) Return(_a0 database.User, _a1 error) *MockQuerier_GetUserByEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetUserByEmail_Call) RunAndReturn(run func(context.Context, string) (database.User, error)) *MockQuerier_GetUserByEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByID provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetUserByID(ctx context.Context, id pgtype.UUID) (database.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 database.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (database.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) database.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type MockQuerier_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id pgtype.UUID
+func (_e *MockQuerier_Expecter) GetUserByID(ctx interface{}, id interface{}) *MockQuerier_GetUserByID_Call {
+	return &MockQuerier_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, id)}
+}
+
+func (_c *MockQuerier_GetUserByID_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetUserByID_Call) Return(_a0 database.User, _a1 error) *MockQuerier_GetUserByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetUserByID_Call) RunAndReturn(run func(context.Context, pgtype.UUID) (database.User, error)) *MockQuerier_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByIDForUpdate provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) GetUserByIDForUpdate(ctx context.Context, id pgtype.UUID) (database.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByIDForUpdate")
+	}
+
+	var r0 database.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (database.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) database.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetUserByIDForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByIDForUpdate'
+type MockQuerier_GetUserByIDForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetUserByIDForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id pgtype.UUID
+func (_e *MockQuerier_Expecter) GetUserByIDForUpdate(ctx interface{}, id interface{}) *MockQuerier_GetUserByIDForUpdate_Call {
+	return &MockQuerier_GetUserByIDForUpdate_Call{Call: _e.mock.On("GetUserByIDForUpdate", ctx, id)}
+}
+
+func (_c *MockQuerier_GetUserByIDForUpdate_Call) Run(run func(ctx context.Context, id pgtype.UUID)) *MockQuerier_GetUserByIDForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetUserByIDForUpdate_Call) Return(_a0 database.User, _a1 error) *MockQuerier_GetUserByIDForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetUserByIDForUpdate_Call) RunAndReturn(run func(context.Context, pgtype.UUID) (database.User, error)) *MockQuerier_GetUserByIDForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserByReferralCode provides a mock function with given fields: ctx, referralCode
+func (_m *MockQuerier) GetUserByReferralCode(ctx context.Context, referralCode string) (database.User, error) {
+	ret := _m.Called(ctx, referralCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByReferralCode")
+	}
+
+	var r0 database.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (database.User, error)); ok {
+		return rf(ctx, referralCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) database.User); ok {
+		r0 = rf(ctx, referralCode)
+	} else {
+		r0 = ret.Get(0).(database.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, referralCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetUserByReferralCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByReferralCode'
+type MockQuerier_GetUserByReferralCode_Call struct {
+	*mock.Call
+}
+
+// GetUserByReferralCode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - referralCode string
+func (_e *MockQuerier_Expecter) GetUserByReferralCode(ctx interface{}, referralCode interface{}) *MockQuerier_GetUserByReferralCode_Call {
+	return &MockQuerier_GetUserByReferralCode_Call{Call: _e.mock.On("GetUserByReferralCode", ctx, referralCode)}
+}
+
+func (_c *MockQuerier_GetUserByReferralCode_Call) Run(run func(ctx context.Context, referralCode string)) *MockQuerier_GetUserByReferralCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetUserByReferralCode_Call) Return(_a0 database.User, _a1 error) *MockQuerier_GetUserByReferralCode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetUserByReferralCode_Call) RunAndReturn(run func(context.Context, string) (database.User, error)) *MockQuerier_GetUserByReferralCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserSpendLimit provides a mock function with given fields: ctx, userID
+func (_m *MockQuerier) GetUserSpendLimit(ctx context.Context, userID pgtype.UUID) (database.UserSpendLimit, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserSpendLimit")
+	}
+
+	var r0 database.UserSpendLimit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (database.UserSpendLimit, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) database.UserSpendLimit); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(database.UserSpendLimit)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GetUserSpendLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserSpendLimit'
+type MockQuerier_GetUserSpendLimit_Call struct {
+	*mock.Call
+}
+
+// GetUserSpendLimit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID pgtype.UUID
+func (_e *MockQuerier_Expecter) GetUserSpendLimit(ctx interface{}, userID interface{}) *MockQuerier_GetUserSpendLimit_Call {
+	return &MockQuerier_GetUserSpendLimit_Call{Call: _e.mock.On("GetUserSpendLimit", ctx, userID)}
+}
+
+func (_c *MockQuerier_GetUserSpendLimit_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_GetUserSpendLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetUserSpendLimit_Call) Return(_a0 database.UserSpendLimit, _a1 error) *MockQuerier_GetUserSpendLimit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GetUserSpendLimit_Call) RunAndReturn(run func(context.Context, pgtype.UUID) (database.UserSpendLimit, error)) *MockQuerier_GetUserSpendLimit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GiftCodeRedeemedByUser provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) GiftCodeRedeemedByUser(ctx context.Context, arg database.GiftCodeRedeemedByUserParams) (bool, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GiftCodeRedeemedByUser")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.GiftCodeRedeemedByUserParams) (bool, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.GiftCodeRedeemedByUserParams) bool); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.GiftCodeRedeemedByUserParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_GiftCodeRedeemedByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GiftCodeRedeemedByUser'
+type MockQuerier_GiftCodeRedeemedByUser_Call struct {
+	*mock.Call
+}
+
+// GiftCodeRedeemedByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.GiftCodeRedeemedByUserParams
+func (_e *MockQuerier_Expecter) GiftCodeRedeemedByUser(ctx interface{}, arg interface{}) *MockQuerier_GiftCodeRedeemedByUser_Call {
+	return &MockQuerier_GiftCodeRedeemedByUser_Call{Call: _e.mock.On("GiftCodeRedeemedByUser", ctx, arg)}
+}
+
+func (_c *MockQuerier_GiftCodeRedeemedByUser_Call) Run(run func(ctx context.Context, arg database.GiftCodeRedeemedByUserParams)) *MockQuerier_GiftCodeRedeemedByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.GiftCodeRedeemedByUserParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GiftCodeRedeemedByUser_Call) Return(_a0 bool, _a1 error) *MockQuerier_GiftCodeRedeemedByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_GiftCodeRedeemedByUser_Call) RunAndReturn(run func(context.Context, database.GiftCodeRedeemedByUserParams) (bool, error)) *MockQuerier_GiftCodeRedeemedByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementGiftCodeRedemptions provides a mock function with given fields: ctx, id
+func (_m *MockQuerier) IncrementGiftCodeRedemptions(ctx context.Context, id int32) (database.GiftCode, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementGiftCodeRedemptions")
+	}
+
+	var r0 database.GiftCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) (database.GiftCode, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) database.GiftCode); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(database.GiftCode)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_IncrementGiftCodeRedemptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementGiftCodeRedemptions'
+type MockQuerier_IncrementGiftCodeRedemptions_Call struct {
+	*mock.Call
+}
+
+// IncrementGiftCodeRedemptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) IncrementGiftCodeRedemptions(ctx interface{}, id interface{}) *MockQuerier_IncrementGiftCodeRedemptions_Call {
+	return &MockQuerier_IncrementGiftCodeRedemptions_Call{Call: _e.mock.On("IncrementGiftCodeRedemptions", ctx, id)}
+}
+
+func (_c *MockQuerier_IncrementGiftCodeRedemptions_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_IncrementGiftCodeRedemptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_IncrementGiftCodeRedemptions_Call) Return(_a0 database.GiftCode, _a1 error) *MockQuerier_IncrementGiftCodeRedemptions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_IncrementGiftCodeRedemptions_Call) RunAndReturn(run func(context.Context, int32) (database.GiftCode, error)) *MockQuerier_IncrementGiftCodeRedemptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListActiveCoinHoldsByOrderID provides a mock function with given fields: ctx, orderID
+func (_m *MockQuerier) ListActiveCoinHoldsByOrderID(ctx context.Context, orderID pgtype.Int4) ([]database.CoinHold, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActiveCoinHoldsByOrderID")
+	}
+
+	var r0 []database.CoinHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Int4) ([]database.CoinHold, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.Int4) []database.CoinHold); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinHold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.Int4) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListActiveCoinHoldsByOrderID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListActiveCoinHoldsByOrderID'
+type MockQuerier_ListActiveCoinHoldsByOrderID_Call struct {
+	*mock.Call
+}
+
+// ListActiveCoinHoldsByOrderID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID pgtype.Int4
+func (_e *MockQuerier_Expecter) ListActiveCoinHoldsByOrderID(ctx interface{}, orderID interface{}) *MockQuerier_ListActiveCoinHoldsByOrderID_Call {
+	return &MockQuerier_ListActiveCoinHoldsByOrderID_Call{Call: _e.mock.On("ListActiveCoinHoldsByOrderID", ctx, orderID)}
+}
+
+func (_c *MockQuerier_ListActiveCoinHoldsByOrderID_Call) Run(run func(ctx context.Context, orderID pgtype.Int4)) *MockQuerier_ListActiveCoinHoldsByOrderID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.Int4))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListActiveCoinHoldsByOrderID_Call) Return(_a0 []database.CoinHold, _a1 error) *MockQuerier_ListActiveCoinHoldsByOrderID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListActiveCoinHoldsByOrderID_Call) RunAndReturn(run func(context.Context, pgtype.Int4) ([]database.CoinHold, error)) *MockQuerier_ListActiveCoinHoldsByOrderID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListActiveCoinPacks provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListActiveCoinPacks(ctx context.Context) ([]database.CoinPack, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActiveCoinPacks")
+	}
+
+	var r0 []database.CoinPack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]database.CoinPack, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []database.CoinPack); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinPack)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListActiveCoinPacks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListActiveCoinPacks'
+type MockQuerier_ListActiveCoinPacks_Call struct {
+	*mock.Call
+}
+
+// ListActiveCoinPacks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListActiveCoinPacks(ctx interface{}) *MockQuerier_ListActiveCoinPacks_Call {
+	return &MockQuerier_ListActiveCoinPacks_Call{Call: _e.mock.On("ListActiveCoinPacks", ctx)}
+}
+
+func (_c *MockQuerier_ListActiveCoinPacks_Call) Run(run func(ctx context.Context)) *MockQuerier_ListActiveCoinPacks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListActiveCoinPacks_Call) Return(_a0 []database.CoinPack, _a1 error) *MockQuerier_ListActiveCoinPacks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListActiveCoinPacks_Call) RunAndReturn(run func(context.Context) ([]database.CoinPack, error)) *MockQuerier_ListActiveCoinPacks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCategoryCashbackRates provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListCategoryCashbackRates(ctx context.Context) ([]database.CategoryCashbackRate, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCategoryCashbackRates")
+	}
+
+	var r0 []database.CategoryCashbackRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]database.CategoryCashbackRate, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []database.CategoryCashbackRate); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CategoryCashbackRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListCategoryCashbackRates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCategoryCashbackRates'
+type MockQuerier_ListCategoryCashbackRates_Call struct {
+	*mock.Call
+}
+
+// ListCategoryCashbackRates is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListCategoryCashbackRates(ctx interface{}) *MockQuerier_ListCategoryCashbackRates_Call {
+	return &MockQuerier_ListCategoryCashbackRates_Call{Call: _e.mock.On("ListCategoryCashbackRates", ctx)}
+}
+
+func (_c *MockQuerier_ListCategoryCashbackRates_Call) Run(run func(ctx context.Context)) *MockQuerier_ListCategoryCashbackRates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListCategoryCashbackRates_Call) Return(_a0 []database.CategoryCashbackRate, _a1 error) *MockQuerier_ListCategoryCashbackRates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListCategoryCashbackRates_Call) RunAndReturn(run func(context.Context) ([]database.CategoryCashbackRate, error)) *MockQuerier_ListCategoryCashbackRates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCoinBalanceChangesByInterval provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListCoinBalanceChangesByInterval(ctx context.Context, arg database.ListCoinBalanceChangesByIntervalParams) ([]database.ListCoinBalanceChangesByIntervalRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCoinBalanceChangesByInterval")
+	}
+
+	var r0 []database.ListCoinBalanceChangesByIntervalRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListCoinBalanceChangesByIntervalParams) ([]database.ListCoinBalanceChangesByIntervalRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListCoinBalanceChangesByIntervalParams) []database.ListCoinBalanceChangesByIntervalRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.ListCoinBalanceChangesByIntervalRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListCoinBalanceChangesByIntervalParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListCoinBalanceChangesByInterval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCoinBalanceChangesByInterval'
+type MockQuerier_ListCoinBalanceChangesByInterval_Call struct {
+	*mock.Call
+}
+
+// ListCoinBalanceChangesByInterval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListCoinBalanceChangesByIntervalParams
+func (_e *MockQuerier_Expecter) ListCoinBalanceChangesByInterval(ctx interface{}, arg interface{}) *MockQuerier_ListCoinBalanceChangesByInterval_Call {
+	return &MockQuerier_ListCoinBalanceChangesByInterval_Call{Call: _e.mock.On("ListCoinBalanceChangesByInterval", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListCoinBalanceChangesByInterval_Call) Run(run func(ctx context.Context, arg database.ListCoinBalanceChangesByIntervalParams)) *MockQuerier_ListCoinBalanceChangesByInterval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListCoinBalanceChangesByIntervalParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListCoinBalanceChangesByInterval_Call) Return(_a0 []database.ListCoinBalanceChangesByIntervalRow, _a1 error) *MockQuerier_ListCoinBalanceChangesByInterval_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListCoinBalanceChangesByInterval_Call) RunAndReturn(run func(context.Context, database.ListCoinBalanceChangesByIntervalParams) ([]database.ListCoinBalanceChangesByIntervalRow, error)) *MockQuerier_ListCoinBalanceChangesByInterval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCoinHoldsByUserID provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListCoinHoldsByUserID(ctx context.Context, arg database.ListCoinHoldsByUserIDParams) ([]database.CoinHold, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCoinHoldsByUserID")
+	}
+
+	var r0 []database.CoinHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListCoinHoldsByUserIDParams) ([]database.CoinHold, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListCoinHoldsByUserIDParams) []database.CoinHold); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinHold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListCoinHoldsByUserIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListCoinHoldsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCoinHoldsByUserID'
+type MockQuerier_ListCoinHoldsByUserID_Call struct {
+	*mock.Call
+}
+
+// ListCoinHoldsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListCoinHoldsByUserIDParams
+func (_e *MockQuerier_Expecter) ListCoinHoldsByUserID(ctx interface{}, arg interface{}) *MockQuerier_ListCoinHoldsByUserID_Call {
+	return &MockQuerier_ListCoinHoldsByUserID_Call{Call: _e.mock.On("ListCoinHoldsByUserID", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListCoinHoldsByUserID_Call) Run(run func(ctx context.Context, arg database.ListCoinHoldsByUserIDParams)) *MockQuerier_ListCoinHoldsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListCoinHoldsByUserIDParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListCoinHoldsByUserID_Call) Return(_a0 []database.CoinHold, _a1 error) *MockQuerier_ListCoinHoldsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListCoinHoldsByUserID_Call) RunAndReturn(run func(context.Context, database.ListCoinHoldsByUserIDParams) ([]database.CoinHold, error)) *MockQuerier_ListCoinHoldsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCoinPacks provides a mock function with given fields: ctx
+func (_m *MockQuerier) ListCoinPacks(ctx context.Context) ([]database.CoinPack, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCoinPacks")
+	}
+
+	var r0 []database.CoinPack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]database.CoinPack, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []database.CoinPack); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinPack)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListCoinPacks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCoinPacks'
+type MockQuerier_ListCoinPacks_Call struct {
+	*mock.Call
+}
+
+// ListCoinPacks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListCoinPacks(ctx interface{}) *MockQuerier_ListCoinPacks_Call {
+	return &MockQuerier_ListCoinPacks_Call{Call: _e.mock.On("ListCoinPacks", ctx)}
+}
+
+func (_c *MockQuerier_ListCoinPacks_Call) Run(run func(ctx context.Context)) *MockQuerier_ListCoinPacks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListCoinPacks_Call) Return(_a0 []database.CoinPack, _a1 error) *MockQuerier_ListCoinPacks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListCoinPacks_Call) RunAndReturn(run func(context.Context) ([]database.CoinPack, error)) *MockQuerier_ListCoinPacks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCoinTransactionsFiltered provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListCoinTransactionsFiltered(ctx context.Context, arg database.ListCoinTransactionsFilteredParams) ([]database.CoinTransaction, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCoinTransactionsFiltered")
+	}
+
+	var r0 []database.CoinTransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListCoinTransactionsFilteredParams) ([]database.CoinTransaction, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListCoinTransactionsFilteredParams) []database.CoinTransaction); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinTransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListCoinTransactionsFilteredParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListCoinTransactionsFiltered_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCoinTransactionsFiltered'
+type MockQuerier_ListCoinTransactionsFiltered_Call struct {
+	*mock.Call
+}
+
+// ListCoinTransactionsFiltered is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListCoinTransactionsFilteredParams
+func (_e *MockQuerier_Expecter) ListCoinTransactionsFiltered(ctx interface{}, arg interface{}) *MockQuerier_ListCoinTransactionsFiltered_Call {
+	return &MockQuerier_ListCoinTransactionsFiltered_Call{Call: _e.mock.On("ListCoinTransactionsFiltered", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListCoinTransactionsFiltered_Call) Run(run func(ctx context.Context, arg database.ListCoinTransactionsFilteredParams)) *MockQuerier_ListCoinTransactionsFiltered_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListCoinTransactionsFilteredParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListCoinTransactionsFiltered_Call) Return(_a0 []database.CoinTransaction, _a1 error) *MockQuerier_ListCoinTransactionsFiltered_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListCoinTransactionsFiltered_Call) RunAndReturn(run func(context.Context, database.ListCoinTransactionsFilteredParams) ([]database.CoinTransaction, error)) *MockQuerier_ListCoinTransactionsFiltered_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListExpiredCoinHolds provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListExpiredCoinHolds(ctx context.Context, arg database.ListExpiredCoinHoldsParams) ([]database.CoinHold, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExpiredCoinHolds")
+	}
+
+	var r0 []database.CoinHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListExpiredCoinHoldsParams) ([]database.CoinHold, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListExpiredCoinHoldsParams) []database.CoinHold); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinHold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListExpiredCoinHoldsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListExpiredCoinHolds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExpiredCoinHolds'
+type MockQuerier_ListExpiredCoinHolds_Call struct {
+	*mock.Call
+}
+
+// ListExpiredCoinHolds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListExpiredCoinHoldsParams
+func (_e *MockQuerier_Expecter) ListExpiredCoinHolds(ctx interface{}, arg interface{}) *MockQuerier_ListExpiredCoinHolds_Call {
+	return &MockQuerier_ListExpiredCoinHolds_Call{Call: _e.mock.On("ListExpiredCoinHolds", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListExpiredCoinHolds_Call) Run(run func(ctx context.Context, arg database.ListExpiredCoinHoldsParams)) *MockQuerier_ListExpiredCoinHolds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListExpiredCoinHoldsParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListExpiredCoinHolds_Call) Return(_a0 []database.CoinHold, _a1 error) *MockQuerier_ListExpiredCoinHolds_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListExpiredCoinHolds_Call) RunAndReturn(run func(context.Context, database.ListExpiredCoinHoldsParams) ([]database.CoinHold, error)) *MockQuerier_ListExpiredCoinHolds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListExpiredCoinLots provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListExpiredCoinLots(ctx context.Context, arg database.ListExpiredCoinLotsParams) ([]database.CoinLot, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExpiredCoinLots")
+	}
+
+	var r0 []database.CoinLot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListExpiredCoinLotsParams) ([]database.CoinLot, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListExpiredCoinLotsParams) []database.CoinLot); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinLot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListExpiredCoinLotsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListExpiredCoinLots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExpiredCoinLots'
+type MockQuerier_ListExpiredCoinLots_Call struct {
+	*mock.Call
+}
+
+// ListExpiredCoinLots is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListExpiredCoinLotsParams
+func (_e *MockQuerier_Expecter) ListExpiredCoinLots(ctx interface{}, arg interface{}) *MockQuerier_ListExpiredCoinLots_Call {
+	return &MockQuerier_ListExpiredCoinLots_Call{Call: _e.mock.On("ListExpiredCoinLots", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListExpiredCoinLots_Call) Run(run func(ctx context.Context, arg database.ListExpiredCoinLotsParams)) *MockQuerier_ListExpiredCoinLots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListExpiredCoinLotsParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListExpiredCoinLots_Call) Return(_a0 []database.CoinLot, _a1 error) *MockQuerier_ListExpiredCoinLots_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListExpiredCoinLots_Call) RunAndReturn(run func(context.Context, database.ListExpiredCoinLotsParams) ([]database.CoinLot, error)) *MockQuerier_ListExpiredCoinLots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListGiftCodesByBatchID provides a mock function with given fields: ctx, batchID
+func (_m *MockQuerier) ListGiftCodesByBatchID(ctx context.Context, batchID int32) ([]database.GiftCode, error) {
+	ret := _m.Called(ctx, batchID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGiftCodesByBatchID")
+	}
+
+	var r0 []database.GiftCode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) ([]database.GiftCode, error)); ok {
+		return rf(ctx, batchID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) []database.GiftCode); ok {
+		r0 = rf(ctx, batchID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.GiftCode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, batchID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListGiftCodesByBatchID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListGiftCodesByBatchID'
+type MockQuerier_ListGiftCodesByBatchID_Call struct {
+	*mock.Call
+}
+
+// ListGiftCodesByBatchID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchID int32
+func (_e *MockQuerier_Expecter) ListGiftCodesByBatchID(ctx interface{}, batchID interface{}) *MockQuerier_ListGiftCodesByBatchID_Call {
+	return &MockQuerier_ListGiftCodesByBatchID_Call{Call: _e.mock.On("ListGiftCodesByBatchID", ctx, batchID)}
+}
+
+func (_c *MockQuerier_ListGiftCodesByBatchID_Call) Run(run func(ctx context.Context, batchID int32)) *MockQuerier_ListGiftCodesByBatchID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListGiftCodesByBatchID_Call) Return(_a0 []database.GiftCode, _a1 error) *MockQuerier_ListGiftCodesByBatchID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListGiftCodesByBatchID_Call) RunAndReturn(run func(context.Context, int32) ([]database.GiftCode, error)) *MockQuerier_ListGiftCodesByBatchID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOrderCategorySubtotals provides a mock function with given fields: ctx, orderID
+func (_m *MockQuerier) ListOrderCategorySubtotals(ctx context.Context, orderID int32) ([]database.ListOrderCategorySubtotalsRow, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOrderCategorySubtotals")
+	}
+
+	var r0 []database.ListOrderCategorySubtotalsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) ([]database.ListOrderCategorySubtotalsRow, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) []database.ListOrderCategorySubtotalsRow); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.ListOrderCategorySubtotalsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListOrderCategorySubtotals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrderCategorySubtotals'
+type MockQuerier_ListOrderCategorySubtotals_Call struct {
+	*mock.Call
+}
+
+// ListOrderCategorySubtotals is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID int32
+func (_e *MockQuerier_Expecter) ListOrderCategorySubtotals(ctx interface{}, orderID interface{}) *MockQuerier_ListOrderCategorySubtotals_Call {
+	return &MockQuerier_ListOrderCategorySubtotals_Call{Call: _e.mock.On("ListOrderCategorySubtotals", ctx, orderID)}
+}
+
+func (_c *MockQuerier_ListOrderCategorySubtotals_Call) Run(run func(ctx context.Context, orderID int32)) *MockQuerier_ListOrderCategorySubtotals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListOrderCategorySubtotals_Call) Return(_a0 []database.ListOrderCategorySubtotalsRow, _a1 error) *MockQuerier_ListOrderCategorySubtotals_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListOrderCategorySubtotals_Call) RunAndReturn(run func(context.Context, int32) ([]database.ListOrderCategorySubtotalsRow, error)) *MockQuerier_ListOrderCategorySubtotals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListProducts provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListProducts(ctx context.Context, arg database.ListProductsParams) ([]database.Product, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProducts")
+	}
+
+	var r0 []database.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListProductsParams) ([]database.Product, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListProductsParams) []database.Product); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListProductsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListProducts'
+type MockQuerier_ListProducts_Call struct {
+	*mock.Call
+}
+
+// ListProducts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListProductsParams
+func (_e *MockQuerier_Expecter) ListProducts(ctx interface{}, arg interface{}) *MockQuerier_ListProducts_Call {
+	return &MockQuerier_ListProducts_Call{Call: _e.mock.On("ListProducts", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListProducts_Call) Run(run func(ctx context.Context, arg database.ListProductsParams)) *MockQuerier_ListProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListProductsParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListProducts_Call) Return(_a0 []database.Product, _a1 error) *MockQuerier_ListProducts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListProducts_Call) RunAndReturn(run func(context.Context, database.ListProductsParams) ([]database.Product, error)) *MockQuerier_ListProducts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListProductsByCategory provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListProductsByCategory(ctx context.Context, arg database.ListProductsByCategoryParams) ([]database.Product, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProductsByCategory")
+	}
+
+	var r0 []database.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListProductsByCategoryParams) ([]database.Product, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListProductsByCategoryParams) []database.Product); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListProductsByCategoryParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListProductsByCategory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListProductsByCategory'
+type MockQuerier_ListProductsByCategory_Call struct {
+	*mock.Call
+}
+
+// ListProductsByCategory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListProductsByCategoryParams
+func (_e *MockQuerier_Expecter) ListProductsByCategory(ctx interface{}, arg interface{}) *MockQuerier_ListProductsByCategory_Call {
+	return &MockQuerier_ListProductsByCategory_Call{Call: _e.mock.On("ListProductsByCategory", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListProductsByCategory_Call) Run(run func(ctx context.Context, arg database.ListProductsByCategoryParams)) *MockQuerier_ListProductsByCategory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListProductsByCategoryParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListProductsByCategory_Call) Return(_a0 []database.Product, _a1 error) *MockQuerier_ListProductsByCategory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListProductsByCategory_Call) RunAndReturn(run func(context.Context, database.ListProductsByCategoryParams) ([]database.Product, error)) *MockQuerier_ListProductsByCategory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListReferralsByReferrerID provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListReferralsByReferrerID(ctx context.Context, arg database.ListReferralsByReferrerIDParams) ([]database.Referral, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReferralsByReferrerID")
+	}
+
+	var r0 []database.Referral
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListReferralsByReferrerIDParams) ([]database.Referral, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListReferralsByReferrerIDParams) []database.Referral); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.Referral)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListReferralsByReferrerIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListReferralsByReferrerID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReferralsByReferrerID'
+type MockQuerier_ListReferralsByReferrerID_Call struct {
+	*mock.Call
+}
+
+// ListReferralsByReferrerID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListReferralsByReferrerIDParams
+func (_e *MockQuerier_Expecter) ListReferralsByReferrerID(ctx interface{}, arg interface{}) *MockQuerier_ListReferralsByReferrerID_Call {
+	return &MockQuerier_ListReferralsByReferrerID_Call{Call: _e.mock.On("ListReferralsByReferrerID", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListReferralsByReferrerID_Call) Run(run func(ctx context.Context, arg database.ListReferralsByReferrerIDParams)) *MockQuerier_ListReferralsByReferrerID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListReferralsByReferrerIDParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListReferralsByReferrerID_Call) Return(_a0 []database.Referral, _a1 error) *MockQuerier_ListReferralsByReferrerID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListReferralsByReferrerID_Call) RunAndReturn(run func(context.Context, database.ListReferralsByReferrerIDParams) ([]database.Referral, error)) *MockQuerier_ListReferralsByReferrerID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSecurityEventsByUserID provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListSecurityEventsByUserID(ctx context.Context, arg database.ListSecurityEventsByUserIDParams) ([]database.SecurityEvent, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSecurityEventsByUserID")
+	}
+
+	var r0 []database.SecurityEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListSecurityEventsByUserIDParams) ([]database.SecurityEvent, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListSecurityEventsByUserIDParams) []database.SecurityEvent); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.SecurityEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListSecurityEventsByUserIDParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListSecurityEventsByUserID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSecurityEventsByUserID'
+type MockQuerier_ListSecurityEventsByUserID_Call struct {
+	*mock.Call
+}
+
+// ListSecurityEventsByUserID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListSecurityEventsByUserIDParams
+func (_e *MockQuerier_Expecter) ListSecurityEventsByUserID(ctx interface{}, arg interface{}) *MockQuerier_ListSecurityEventsByUserID_Call {
+	return &MockQuerier_ListSecurityEventsByUserID_Call{Call: _e.mock.On("ListSecurityEventsByUserID", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListSecurityEventsByUserID_Call) Run(run func(ctx context.Context, arg database.ListSecurityEventsByUserIDParams)) *MockQuerier_ListSecurityEventsByUserID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListSecurityEventsByUserIDParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListSecurityEventsByUserID_Call) Return(_a0 []database.SecurityEvent, _a1 error) *MockQuerier_ListSecurityEventsByUserID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListSecurityEventsByUserID_Call) RunAndReturn(run func(context.Context, database.ListSecurityEventsByUserIDParams) ([]database.SecurityEvent, error)) *MockQuerier_ListSecurityEventsByUserID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSpendableCoinLotsForUpdate provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) ListSpendableCoinLotsForUpdate(ctx context.Context, arg database.ListSpendableCoinLotsForUpdateParams) ([]database.CoinLot, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSpendableCoinLotsForUpdate")
+	}
+
+	var r0 []database.CoinLot
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListSpendableCoinLotsForUpdateParams) ([]database.CoinLot, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ListSpendableCoinLotsForUpdateParams) []database.CoinLot); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]database.CoinLot)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ListSpendableCoinLotsForUpdateParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_ListSpendableCoinLotsForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSpendableCoinLotsForUpdate'
+type MockQuerier_ListSpendableCoinLotsForUpdate_Call struct {
+	*mock.Call
+}
+
+// ListSpendableCoinLotsForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.ListSpendableCoinLotsForUpdateParams
+func (_e *MockQuerier_Expecter) ListSpendableCoinLotsForUpdate(ctx interface{}, arg interface{}) *MockQuerier_ListSpendableCoinLotsForUpdate_Call {
+	return &MockQuerier_ListSpendableCoinLotsForUpdate_Call{Call: _e.mock.On("ListSpendableCoinLotsForUpdate", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListSpendableCoinLotsForUpdate_Call) Run(run func(ctx context.Context, arg database.ListSpendableCoinLotsForUpdateParams)) *MockQuerier_ListSpendableCoinLotsForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ListSpendableCoinLotsForUpdateParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListSpendableCoinLotsForUpdate_Call) Return(_a0 []database.CoinLot, _a1 error) *MockQuerier_ListSpendableCoinLotsForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_ListSpendableCoinLotsForUpdate_Call) RunAndReturn(run func(context.Context, database.ListSpendableCoinLotsForUpdateParams) ([]database.CoinLot, error)) *MockQuerier_ListSpendableCoinLotsForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockNormalizedEmail provides a mock function with given fields: ctx, normalizedEmail
+func (_m *MockQuerier) LockNormalizedEmail(ctx context.Context, normalizedEmail string) error {
+	ret := _m.Called(ctx, normalizedEmail)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockNormalizedEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, normalizedEmail)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_LockNormalizedEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockNormalizedEmail'
+type MockQuerier_LockNormalizedEmail_Call struct {
+	*mock.Call
+}
+
+// LockNormalizedEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - normalizedEmail string
+func (_e *MockQuerier_Expecter) LockNormalizedEmail(ctx interface{}, normalizedEmail interface{}) *MockQuerier_LockNormalizedEmail_Call {
+	return &MockQuerier_LockNormalizedEmail_Call{Call: _e.mock.On("LockNormalizedEmail", ctx, normalizedEmail)}
+}
+
+func (_c *MockQuerier_LockNormalizedEmail_Call) Run(run func(ctx context.Context, normalizedEmail string)) *MockQuerier_LockNormalizedEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_LockNormalizedEmail_Call) Return(_a0 error) *MockQuerier_LockNormalizedEmail_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_LockNormalizedEmail_Call) RunAndReturn(run func(context.Context, string) error) *MockQuerier_LockNormalizedEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkReferralRewarded provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) MarkReferralRewarded(ctx context.Context, arg database.MarkReferralRewardedParams) (database.Referral, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkReferralRewarded")
+	}
+
+	var r0 database.Referral
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.MarkReferralRewardedParams) (database.Referral, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.MarkReferralRewardedParams) database.Referral); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.Referral)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.MarkReferralRewardedParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_MarkReferralRewarded_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkReferralRewarded'
+type MockQuerier_MarkReferralRewarded_Call struct {
+	*mock.Call
+}
+
+// MarkReferralRewarded is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.MarkReferralRewardedParams
+func (_e *MockQuerier_Expecter) MarkReferralRewarded(ctx interface{}, arg interface{}) *MockQuerier_MarkReferralRewarded_Call {
+	return &MockQuerier_MarkReferralRewarded_Call{Call: _e.mock.On("MarkReferralRewarded", ctx, arg)}
+}
+
+func (_c *MockQuerier_MarkReferralRewarded_Call) Run(run func(ctx context.Context, arg database.MarkReferralRewardedParams)) *MockQuerier_MarkReferralRewarded_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.MarkReferralRewardedParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_MarkReferralRewarded_Call) Return(_a0 database.Referral, _a1 error) *MockQuerier_MarkReferralRewarded_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_MarkReferralRewarded_Call) RunAndReturn(run func(context.Context, database.MarkReferralRewardedParams) (database.Referral, error)) *MockQuerier_MarkReferralRewarded_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SummarizeCoinTransactionsFiltered provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) SummarizeCoinTransactionsFiltered(ctx context.Context, arg database.SummarizeCoinTransactionsFilteredParams) (database.SummarizeCoinTransactionsFilteredRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SummarizeCoinTransactionsFiltered")
+	}
+
+	var r0 database.SummarizeCoinTransactionsFilteredRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.SummarizeCoinTransactionsFilteredParams) (database.SummarizeCoinTransactionsFilteredRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.SummarizeCoinTransactionsFilteredParams) database.SummarizeCoinTransactionsFilteredRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.SummarizeCoinTransactionsFilteredRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.SummarizeCoinTransactionsFilteredParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_SummarizeCoinTransactionsFiltered_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SummarizeCoinTransactionsFiltered'
+type MockQuerier_SummarizeCoinTransactionsFiltered_Call struct {
+	*mock.Call
+}
+
+// SummarizeCoinTransactionsFiltered is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.SummarizeCoinTransactionsFilteredParams
+func (_e *MockQuerier_Expecter) SummarizeCoinTransactionsFiltered(ctx interface{}, arg interface{}) *MockQuerier_SummarizeCoinTransactionsFiltered_Call {
+	return &MockQuerier_SummarizeCoinTransactionsFiltered_Call{Call: _e.mock.On("SummarizeCoinTransactionsFiltered", ctx, arg)}
+}
+
+func (_c *MockQuerier_SummarizeCoinTransactionsFiltered_Call) Run(run func(ctx context.Context, arg database.SummarizeCoinTransactionsFilteredParams)) *MockQuerier_SummarizeCoinTransactionsFiltered_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.SummarizeCoinTransactionsFilteredParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_SummarizeCoinTransactionsFiltered_Call) Return(_a0 database.SummarizeCoinTransactionsFilteredRow, _a1 error) *MockQuerier_SummarizeCoinTransactionsFiltered_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_SummarizeCoinTransactionsFiltered_Call) RunAndReturn(run func(context.Context, database.SummarizeCoinTransactionsFilteredParams) (database.SummarizeCoinTransactionsFilteredRow, error)) *MockQuerier_SummarizeCoinTransactionsFiltered_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnblockUserSpending provides a mock function with given fields: ctx, userID
+func (_m *MockQuerier) UnblockUserSpending(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnblockUserSpending")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) (int64, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgtype.UUID) int64); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgtype.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UnblockUserSpending_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnblockUserSpending'
+type MockQuerier_UnblockUserSpending_Call struct {
+	*mock.Call
+}
+
+// UnblockUserSpending is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID pgtype.UUID
+func (_e *MockQuerier_Expecter) UnblockUserSpending(ctx interface{}, userID interface{}) *MockQuerier_UnblockUserSpending_Call {
+	return &MockQuerier_UnblockUserSpending_Call{Call: _e.mock.On("UnblockUserSpending", ctx, userID)}
+}
+
+func (_c *MockQuerier_UnblockUserSpending_Call) Run(run func(ctx context.Context, userID pgtype.UUID)) *MockQuerier_UnblockUserSpending_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pgtype.UUID))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UnblockUserSpending_Call) Return(_a0 int64, _a1 error) *MockQuerier_UnblockUserSpending_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UnblockUserSpending_Call) RunAndReturn(run func(context.Context, pgtype.UUID) (int64, error)) *MockQuerier_UnblockUserSpending_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCartItemQuantity provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateCartItemQuantity(ctx context.Context, arg database.UpdateCartItemQuantityParams) (database.CartItem, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCartItemQuantity")
+	}
+
+	var r0 database.CartItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateCartItemQuantityParams) (database.CartItem, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateCartItemQuantityParams) database.CartItem); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CartItem)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateCartItemQuantityParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpdateCartItemQuantity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCartItemQuantity'
+type MockQuerier_UpdateCartItemQuantity_Call struct {
+	*mock.Call
+}
+
+// UpdateCartItemQuantity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateCartItemQuantityParams
+func (_e *MockQuerier_Expecter) UpdateCartItemQuantity(ctx interface{}, arg interface{}) *MockQuerier_UpdateCartItemQuantity_Call {
+	return &MockQuerier_UpdateCartItemQuantity_Call{Call: _e.mock.On("UpdateCartItemQuantity", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateCartItemQuantity_Call) Run(run func(ctx context.Context, arg database.UpdateCartItemQuantityParams)) *MockQuerier_UpdateCartItemQuantity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateCartItemQuantityParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateCartItemQuantity_Call) Return(_a0 database.CartItem, _a1 error) *MockQuerier_UpdateCartItemQuantity_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateCartItemQuantity_Call) RunAndReturn(run func(context.Context, database.UpdateCartItemQuantityParams) (database.CartItem, error)) *MockQuerier_UpdateCartItemQuantity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCoinHoldStatus provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateCoinHoldStatus(ctx context.Context, arg database.UpdateCoinHoldStatusParams) (database.CoinHold, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCoinHoldStatus")
+	}
+
+	var r0 database.CoinHold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateCoinHoldStatusParams) (database.CoinHold, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateCoinHoldStatusParams) database.CoinHold); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CoinHold)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateCoinHoldStatusParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpdateCoinHoldStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCoinHoldStatus'
+type MockQuerier_UpdateCoinHoldStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateCoinHoldStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateCoinHoldStatusParams
+func (_e *MockQuerier_Expecter) UpdateCoinHoldStatus(ctx interface{}, arg interface{}) *MockQuerier_UpdateCoinHoldStatus_Call {
+	return &MockQuerier_UpdateCoinHoldStatus_Call{Call: _e.mock.On("UpdateCoinHoldStatus", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateCoinHoldStatus_Call) Run(run func(ctx context.Context, arg database.UpdateCoinHoldStatusParams)) *MockQuerier_UpdateCoinHoldStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateCoinHoldStatusParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateCoinHoldStatus_Call) Return(_a0 database.CoinHold, _a1 error) *MockQuerier_UpdateCoinHoldStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateCoinHoldStatus_Call) RunAndReturn(run func(context.Context, database.UpdateCoinHoldStatusParams) (database.CoinHold, error)) *MockQuerier_UpdateCoinHoldStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCoinLotRemaining provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateCoinLotRemaining(ctx context.Context, arg database.UpdateCoinLotRemainingParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCoinLotRemaining")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateCoinLotRemainingParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_UpdateCoinLotRemaining_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCoinLotRemaining'
+type MockQuerier_UpdateCoinLotRemaining_Call struct {
+	*mock.Call
+}
+
+// UpdateCoinLotRemaining is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateCoinLotRemainingParams
+func (_e *MockQuerier_Expecter) UpdateCoinLotRemaining(ctx interface{}, arg interface{}) *MockQuerier_UpdateCoinLotRemaining_Call {
+	return &MockQuerier_UpdateCoinLotRemaining_Call{Call: _e.mock.On("UpdateCoinLotRemaining", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateCoinLotRemaining_Call) Run(run func(ctx context.Context, arg database.UpdateCoinLotRemainingParams)) *MockQuerier_UpdateCoinLotRemaining_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateCoinLotRemainingParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateCoinLotRemaining_Call) Return(_a0 error) *MockQuerier_UpdateCoinLotRemaining_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateCoinLotRemaining_Call) RunAndReturn(run func(context.Context, database.UpdateCoinLotRemainingParams) error) *MockQuerier_UpdateCoinLotRemaining_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCoinPack provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateCoinPack(ctx context.Context, arg database.UpdateCoinPackParams) (database.CoinPack, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCoinPack")
+	}
+
+	var r0 database.CoinPack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateCoinPackParams) (database.CoinPack, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateCoinPackParams) database.CoinPack); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CoinPack)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateCoinPackParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpdateCoinPack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCoinPack'
+type MockQuerier_UpdateCoinPack_Call struct {
+	*mock.Call
+}
+
+// UpdateCoinPack is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateCoinPackParams
+func (_e *MockQuerier_Expecter) UpdateCoinPack(ctx interface{}, arg interface{}) *MockQuerier_UpdateCoinPack_Call {
+	return &MockQuerier_UpdateCoinPack_Call{Call: _e.mock.On("UpdateCoinPack", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateCoinPack_Call) Run(run func(ctx context.Context, arg database.UpdateCoinPackParams)) *MockQuerier_UpdateCoinPack_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateCoinPackParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateCoinPack_Call) Return(_a0 database.CoinPack, _a1 error) *MockQuerier_UpdateCoinPack_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateCoinPack_Call) RunAndReturn(run func(context.Context, database.UpdateCoinPackParams) (database.CoinPack, error)) *MockQuerier_UpdateCoinPack_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateOrderStatus provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateOrderStatus(ctx context.Context, arg database.UpdateOrderStatusParams) (database.Order, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOrderStatus")
+	}
+
+	var r0 database.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateOrderStatusParams) (database.Order, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateOrderStatusParams) database.Order); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.Order)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateOrderStatusParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpdateOrderStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOrderStatus'
+type MockQuerier_UpdateOrderStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateOrderStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateOrderStatusParams
+func (_e *MockQuerier_Expecter) UpdateOrderStatus(ctx interface{}, arg interface{}) *MockQuerier_UpdateOrderStatus_Call {
+	return &MockQuerier_UpdateOrderStatus_Call{Call: _e.mock.On("UpdateOrderStatus", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateOrderStatus_Call) Run(run func(ctx context.Context, arg database.UpdateOrderStatusParams)) *MockQuerier_UpdateOrderStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateOrderStatusParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateOrderStatus_Call) Return(_a0 database.Order, _a1 error) *MockQuerier_UpdateOrderStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateOrderStatus_Call) RunAndReturn(run func(context.Context, database.UpdateOrderStatusParams) (database.Order, error)) *MockQuerier_UpdateOrderStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProductStock provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateProductStock(ctx context.Context, arg database.UpdateProductStockParams) (database.Product, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProductStock")
+	}
+
+	var r0 database.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateProductStockParams) (database.Product, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateProductStockParams) database.Product); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.Product)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateProductStockParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpdateProductStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProductStock'
+type MockQuerier_UpdateProductStock_Call struct {
+	*mock.Call
+}
+
+// UpdateProductStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateProductStockParams
+func (_e *MockQuerier_Expecter) UpdateProductStock(ctx interface{}, arg interface{}) *MockQuerier_UpdateProductStock_Call {
+	return &MockQuerier_UpdateProductStock_Call{Call: _e.mock.On("UpdateProductStock", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateProductStock_Call) Run(run func(ctx context.Context, arg database.UpdateProductStockParams)) *MockQuerier_UpdateProductStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateProductStockParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateProductStock_Call) Return(_a0 database.Product, _a1 error) *MockQuerier_UpdateProductStock_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateProductStock_Call) RunAndReturn(run func(context.Context, database.UpdateProductStockParams) (database.Product, error)) *MockQuerier_UpdateProductStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserCoins provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateUserCoins(ctx context.Context, arg database.UpdateUserCoinsParams) (database.UpdateUserCoinsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserCoins")
+	}
+
+	var r0 database.UpdateUserCoinsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserCoinsParams) (database.UpdateUserCoinsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserCoinsParams) database.UpdateUserCoinsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.UpdateUserCoinsRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateUserCoinsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpdateUserCoins_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserCoins'
+type MockQuerier_UpdateUserCoins_Call struct {
+	*mock.Call
+}
+
+// UpdateUserCoins is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateUserCoinsParams
+func (_e *MockQuerier_Expecter) UpdateUserCoins(ctx interface{}, arg interface{}) *MockQuerier_UpdateUserCoins_Call {
+	return &MockQuerier_UpdateUserCoins_Call{Call: _e.mock.On("UpdateUserCoins", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateUserCoins_Call) Run(run func(ctx context.Context, arg database.UpdateUserCoinsParams)) *MockQuerier_UpdateUserCoins_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateUserCoinsParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateUserCoins_Call) Return(_a0 database.UpdateUserCoinsRow, _a1 error) *MockQuerier_UpdateUserCoins_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateUserCoins_Call) RunAndReturn(run func(context.Context, database.UpdateUserCoinsParams) (database.UpdateUserCoinsRow, error)) *MockQuerier_UpdateUserCoins_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserEmail provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateUserEmail(ctx context.Context, arg database.UpdateUserEmailParams) (database.UpdateUserEmailRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserEmail")
+	}
+
+	var r0 database.UpdateUserEmailRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserEmailParams) (database.UpdateUserEmailRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserEmailParams) database.UpdateUserEmailRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.UpdateUserEmailRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateUserEmailParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpdateUserEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserEmail'
+type MockQuerier_UpdateUserEmail_Call struct {
+	*mock.Call
+}
+
+// UpdateUserEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateUserEmailParams
+func (_e *MockQuerier_Expecter) UpdateUserEmail(ctx interface{}, arg interface{}) *MockQuerier_UpdateUserEmail_Call {
+	return &MockQuerier_UpdateUserEmail_Call{Call: _e.mock.On("UpdateUserEmail", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateUserEmail_Call) Run(run func(ctx context.Context, arg database.UpdateUserEmailParams)) *MockQuerier_UpdateUserEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateUserEmailParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateUserEmail_Call) Return(_a0 database.UpdateUserEmailRow, _a1 error) *MockQuerier_UpdateUserEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateUserEmail_Call) RunAndReturn(run func(context.Context, database.UpdateUserEmailParams) (database.UpdateUserEmailRow, error)) *MockQuerier_UpdateUserEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserHeldCoins provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateUserHeldCoins(ctx context.Context, arg database.UpdateUserHeldCoinsParams) (database.UpdateUserHeldCoinsRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserHeldCoins")
+	}
+
+	var r0 database.UpdateUserHeldCoinsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserHeldCoinsParams) (database.UpdateUserHeldCoinsRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserHeldCoinsParams) database.UpdateUserHeldCoinsRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.UpdateUserHeldCoinsRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateUserHeldCoinsParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpdateUserHeldCoins_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserHeldCoins'
+type MockQuerier_UpdateUserHeldCoins_Call struct {
+	*mock.Call
+}
+
+// UpdateUserHeldCoins is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateUserHeldCoinsParams
+func (_e *MockQuerier_Expecter) UpdateUserHeldCoins(ctx interface{}, arg interface{}) *MockQuerier_UpdateUserHeldCoins_Call {
+	return &MockQuerier_UpdateUserHeldCoins_Call{Call: _e.mock.On("UpdateUserHeldCoins", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateUserHeldCoins_Call) Run(run func(ctx context.Context, arg database.UpdateUserHeldCoinsParams)) *MockQuerier_UpdateUserHeldCoins_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateUserHeldCoinsParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateUserHeldCoins_Call) Return(_a0 database.UpdateUserHeldCoinsRow, _a1 error) *MockQuerier_UpdateUserHeldCoins_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateUserHeldCoins_Call) RunAndReturn(run func(context.Context, database.UpdateUserHeldCoinsParams) (database.UpdateUserHeldCoinsRow, error)) *MockQuerier_UpdateUserHeldCoins_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserName provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateUserName(ctx context.Context, arg database.UpdateUserNameParams) (database.UpdateUserNameRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserName")
+	}
+
+	var r0 database.UpdateUserNameRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserNameParams) (database.UpdateUserNameRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserNameParams) database.UpdateUserNameRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.UpdateUserNameRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpdateUserNameParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpdateUserName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserName'
+type MockQuerier_UpdateUserName_Call struct {
+	*mock.Call
+}
+
+// UpdateUserName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateUserNameParams
+func (_e *MockQuerier_Expecter) UpdateUserName(ctx interface{}, arg interface{}) *MockQuerier_UpdateUserName_Call {
+	return &MockQuerier_UpdateUserName_Call{Call: _e.mock.On("UpdateUserName", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateUserName_Call) Run(run func(ctx context.Context, arg database.UpdateUserNameParams)) *MockQuerier_UpdateUserName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateUserNameParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateUserName_Call) Return(_a0 database.UpdateUserNameRow, _a1 error) *MockQuerier_UpdateUserName_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateUserName_Call) RunAndReturn(run func(context.Context, database.UpdateUserNameParams) (database.UpdateUserNameRow, error)) *MockQuerier_UpdateUserName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateUserPassword provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpdateUserPassword(ctx context.Context, arg database.UpdateUserPasswordParams) error {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserPassword")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpdateUserPasswordParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockQuerier_UpdateUserPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserPassword'
+type MockQuerier_UpdateUserPassword_Call struct {
+	*mock.Call
+}
+
+// UpdateUserPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpdateUserPasswordParams
+func (_e *MockQuerier_Expecter) UpdateUserPassword(ctx interface{}, arg interface{}) *MockQuerier_UpdateUserPassword_Call {
+	return &MockQuerier_UpdateUserPassword_Call{Call: _e.mock.On("UpdateUserPassword", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpdateUserPassword_Call) Run(run func(ctx context.Context, arg database.UpdateUserPasswordParams)) *MockQuerier_UpdateUserPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpdateUserPasswordParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpdateUserPassword_Call) Return(_a0 error) *MockQuerier_UpdateUserPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockQuerier_UpdateUserPassword_Call) RunAndReturn(run func(context.Context, database.UpdateUserPasswordParams) error) *MockQuerier_UpdateUserPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertCategoryCashbackRate provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertCategoryCashbackRate(ctx context.Context, arg database.UpsertCategoryCashbackRateParams) (database.CategoryCashbackRate, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertCategoryCashbackRate")
+	}
+
+	var r0 database.CategoryCashbackRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpsertCategoryCashbackRateParams) (database.CategoryCashbackRate, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpsertCategoryCashbackRateParams) database.CategoryCashbackRate); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.CategoryCashbackRate)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpsertCategoryCashbackRateParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpsertCategoryCashbackRate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertCategoryCashbackRate'
+type MockQuerier_UpsertCategoryCashbackRate_Call struct {
+	*mock.Call
+}
+
+// UpsertCategoryCashbackRate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpsertCategoryCashbackRateParams
+func (_e *MockQuerier_Expecter) UpsertCategoryCashbackRate(ctx interface{}, arg interface{}) *MockQuerier_UpsertCategoryCashbackRate_Call {
+	return &MockQuerier_UpsertCategoryCashbackRate_Call{Call: _e.mock.On("UpsertCategoryCashbackRate", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertCategoryCashbackRate_Call) Run(run func(ctx context.Context, arg database.UpsertCategoryCashbackRateParams)) *MockQuerier_UpsertCategoryCashbackRate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpsertCategoryCashbackRateParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertCategoryCashbackRate_Call) Return(_a0 database.CategoryCashbackRate, _a1 error) *MockQuerier_UpsertCategoryCashbackRate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertCategoryCashbackRate_Call) RunAndReturn(run func(context.Context, database.UpsertCategoryCashbackRateParams) (database.CategoryCashbackRate, error)) *MockQuerier_UpsertCategoryCashbackRate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertUserSpendLimit provides a mock function with given fields: ctx, arg
+func (_m *MockQuerier) UpsertUserSpendLimit(ctx context.Context, arg database.UpsertUserSpendLimitParams) (database.UserSpendLimit, error) {
+	ret := _m.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertUserSpendLimit")
+	}
+
+	var r0 database.UserSpendLimit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpsertUserSpendLimitParams) (database.UserSpendLimit, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.UpsertUserSpendLimitParams) database.UserSpendLimit); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(database.UserSpendLimit)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.UpsertUserSpendLimitParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerier_UpsertUserSpendLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertUserSpendLimit'
+type MockQuerier_UpsertUserSpendLimit_Call struct {
+	*mock.Call
+}
+
+// UpsertUserSpendLimit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg database.UpsertUserSpendLimitParams
+func (_e *MockQuerier_Expecter) UpsertUserSpendLimit(ctx interface{}, arg interface{}) *MockQuerier_UpsertUserSpendLimit_Call {
+	return &MockQuerier_UpsertUserSpendLimit_Call{Call: _e.mock.On("UpsertUserSpendLimit", ctx, arg)}
+}
+
+func (_c *MockQuerier_UpsertUserSpendLimit_Call) Run(run func(ctx context.Context, arg database.UpsertUserSpendLimitParams)) *MockQuerier_UpsertUserSpendLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.UpsertUserSpendLimitParams))
+	})
+	return _c
+}
+
+func (_c *MockQuerier_UpsertUserSpendLimit_Call) Return(_a0 database.UserSpendLimit, _a1 error) *MockQuerier_UpsertUserSpendLimit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerier_UpsertUserSpendLimit_Call) RunAndReturn(run func(context.Context, database.UpsertUserSpendLimitParams) (database.UserSpendLimit, error)) *MockQuerier_UpsertUserSpendLimit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockQuerier creates a new instance of MockQuerier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockQuerier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockQuerier {
+	mock := &MockQuerier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
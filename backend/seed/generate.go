@@ -0,0 +1,529 @@
+// Package seed generates demo and load-test data. Generate is deterministic:
+// the same Options always produce the same Dataset, so a load test can be
+// rerun against identical data. The data is internally consistent: every
+// balance is the sum of its ledger, lots account for the coins still held
+// and expire as the service's expiry policy says, and product ratings are the
+// average of their reviews.
+package seed
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"backend/internal/entity"
+
+	"github.com/google/uuid"
+)
+
+// PasswordHash is the bcrypt hash of "password123", the password of every
+// generated user. It is fixed so that generation stays deterministic.
+const PasswordHash = "$2a$10$mLwfoEvw8pvDGsOMeuhniu/49G.peYzs1YfuXmJOWPvuOmj.bwWr2"
+
+// Password is the plain text of PasswordHash
+const Password = "password123"
+
+type Options struct {
+	Seed     uint64
+	Users    int
+	Products int
+	// End is the latest timestamp generated; history reaches back Days before it
+	End  time.Time
+	Days int
+	// Expiry sets when granted coins expire, as it does in the service
+	Expiry entity.CoinExpiryPolicy
+}
+
+type CoinPack struct {
+	ID         int32
+	Name       string
+	BaseCoins  int
+	BonusCoins int
+	PriceCents int64
+	SortOrder  int
+}
+
+// DefaultCoinPacks are the packs a seeded shop sells, which generated users
+// buy their coins with
+var DefaultCoinPacks = []CoinPack{
+	{ID: 1, Name: "1000 Coins", BaseCoins: 1000, PriceCents: 999, SortOrder: 1},
+	{ID: 2, Name: "5000 + 500 Bonus", BaseCoins: 5000, BonusCoins: 500, PriceCents: 4999, SortOrder: 2},
+	{ID: 3, Name: "10000 + 1500 Bonus", BaseCoins: 10000, BonusCoins: 1500, PriceCents: 9999, SortOrder: 3},
+}
+
+type Dataset struct {
+	CoinPacks    []CoinPack
+	Categories   []Category
+	Products     []Product
+	Users        []User
+	CartItems    []CartItem
+	Orders       []Order
+	Comments     []Comment
+	Transactions []Transaction
+	Lots         []Lot
+}
+
+type Category struct {
+	ID   int32
+	Name string
+}
+
+type Product struct {
+	ID            int32
+	CategoryID    int32
+	Name          string
+	Description   string
+	PriceCents    int64
+	Stock         int
+	ImageURL      string
+	AverageRating float64
+	TotalComments int
+	CreatedAt     time.Time
+}
+
+type User struct {
+	ID              uuid.UUID
+	Name            string
+	Email           string
+	NormalizedEmail string
+	ReferralCode    string
+	Coins           int
+	IsAdmin         bool
+	CreatedAt       time.Time
+}
+
+type CartItem struct {
+	UserID    uuid.UUID
+	ProductID int32
+	Quantity  int
+}
+
+type Order struct {
+	ID         int32
+	UserID     uuid.UUID
+	Number     string
+	TotalCents int64
+	CoinsUsed  int
+	Status     string
+	CreatedAt  time.Time
+	Items      []OrderItem
+}
+
+type OrderItem struct {
+	ProductID  int32
+	Name       string
+	PriceCents int64
+	Quantity   int
+}
+
+type Comment struct {
+	UserID    uuid.UUID
+	ProductID int32
+	Rating    int
+	Text      string
+	CreatedAt time.Time
+}
+
+type Transaction struct {
+	ID           int32
+	UserID       uuid.UUID
+	Type         string
+	Amount       int
+	BalanceAfter int
+	OrderID      *int32
+	CoinPackID   *int32
+	Description  string
+	CreatedAt    time.Time
+}
+
+// Lot is what is left of a charge, bonus or refund after later spends took
+// from the oldest open lots first and, once ExpiresAt passed, expiry took the
+// rest. Lots are numbered from 1 in the order they appear in Dataset.Lots.
+type Lot struct {
+	UserID        uuid.UUID
+	TransactionID int32
+	Source        string
+	Original      int
+	Remaining     int
+	ExpiresAt     *time.Time
+	CreatedAt     time.Time
+}
+
+// Generate builds the dataset described by opts
+func Generate(opts Options) (*Dataset, error) {
+	if opts.Users < 1 || opts.Products < 1 || opts.Days < 1 {
+		return nil, fmt.Errorf("users, products and days must be at least 1")
+	}
+
+	g := &generator{
+		opts:  opts,
+		rng:   rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x9e3779b97f4a7c15)),
+		start: opts.End.AddDate(0, 0, -opts.Days),
+		data:  &Dataset{CoinPacks: slices.Clone(DefaultCoinPacks)},
+		lots:  map[uuid.UUID][]int{},
+	}
+	g.catalogue()
+	g.users()
+	for i := range g.data.Users {
+		g.history(&g.data.Users[i])
+	}
+	g.ratings()
+	return g.data, nil
+}
+
+type generator struct {
+	opts  Options
+	rng   *rand.Rand
+	start time.Time
+	data  *Dataset
+	lots  map[uuid.UUID][]int
+}
+
+var catalogue = []struct {
+	name       string
+	adjectives []string
+	nouns      []string
+	images     []string
+}{
+	{"Electronics", []string{"Wireless", "Smart", "Portable", "Ultra", "Pro", "Compact"},
+		[]string{"Earbuds", "Monitor", "Keyboard", "Speaker", "Smartwatch", "Laptop", "Tablet", "Camera"},
+		[]string{"https://images.unsplash.com/photo-1510557880182-3d4d3cba35a5", "https://images.unsplash.com/photo-1517336714731-489689fd1ca8"}},
+	{"Books", []string{"Essential", "Modern", "Practical", "Illustrated", "Complete", "Pocket"},
+		[]string{"Guide to Go", "History of Japan", "Cookbook", "Fantasy Saga", "Atlas", "Poetry Collection"},
+		[]string{"https://images.unsplash.com/photo-1553729784-e91953dec042", "https://images.unsplash.com/photo-1507842217343-583bb7270b66"}},
+	{"Clothing", []string{"Classic", "Slim Fit", "Organic", "Waterproof", "Vintage", "Oversized"},
+		[]string{"T-Shirt", "Jeans", "Hoodie", "Jacket", "Dress", "Cap", "Sneakers"},
+		[]string{"https://images.unsplash.com/photo-1521572163474-6864f9cf17ab", "https://images.unsplash.com/photo-1551024601-bec78aea704b"}},
+	{"Home & Kitchen", []string{"Ceramic", "Stainless", "Bamboo", "Nordic", "Cast Iron", "Glass"},
+		[]string{"Mug Set", "Frying Pan", "Cutting Board", "Lamp", "Kettle", "Storage Jars"},
+		[]string{"https://images.unsplash.com/photo-1556911220-bff31c812dba", "https://images.unsplash.com/photo-1513694203232-719a280e022f"}},
+	{"Sports", []string{"Lightweight", "Training", "Trail", "Adjustable", "Pro", "Foldable"},
+		[]string{"Running Shoes", "Yoga Mat", "Dumbbells", "Water Bottle", "Backpack", "Tent"},
+		[]string{"https://images.unsplash.com/photo-1528701800489-20be9c7e6d8f", "https://images.unsplash.com/photo-1517836357463-d25dfeac3438"}},
+	{"Toys", []string{"Wooden", "Magnetic", "Plush", "Remote Control", "Educational", "Giant"},
+		[]string{"Building Blocks", "Puzzle", "Teddy Bear", "Race Car", "Board Game", "Train Set"},
+		[]string{"https://images.unsplash.com/photo-1558060370-d644479cb6f7", "https://images.unsplash.com/photo-1566576912321-d58ddd7a6088"}},
+}
+
+var (
+	firstNames = []string{"Aiko", "Ben", "Chloe", "Daichi", "Emma", "Felix", "Grace", "Haruto", "Isla", "Jun",
+		"Kenji", "Lena", "Mia", "Noah", "Olivia", "Priya", "Ren", "Sofia", "Takumi", "Yui"}
+	lastNames = []string{"Suzuki", "Smith", "Tanaka", "Garcia", "Sato", "Müller", "Watanabe", "Brown", "Ito", "Kim",
+		"Yamamoto", "Rossi", "Nakamura", "Silva", "Kobayashi", "Nguyen"}
+	reviews = [5][]string{
+		{"Broke within a week.", "Not as described.", "Would not buy again."},
+		{"Disappointing quality.", "Arrived late and scratched.", "Expected more for the price."},
+		{"It is fine.", "Does the job.", "Average, nothing special."},
+		{"Good value.", "Works well, minor issues.", "Happy with it overall."},
+		{"Excellent, highly recommend!", "Exactly what I needed.", "Great quality, fast delivery."},
+	}
+	// ratingWeights skews reviews positive the way real shops do
+	ratingWeights = [5]int{5, 8, 17, 35, 35}
+)
+
+// referralCodeAlphabet matches the one the user repository draws from
+const referralCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+func (g *generator) between(from, to time.Time) time.Time {
+	if !to.After(from) {
+		return from
+	}
+	return from.Add(time.Duration(g.rng.Int64N(int64(to.Sub(from)))))
+}
+
+func (g *generator) catalogue() {
+	for i, c := range catalogue {
+		g.data.Categories = append(g.data.Categories, Category{ID: int32(i + 1), Name: c.name})
+	}
+
+	names := map[string]bool{}
+	for i := 0; i < g.opts.Products; i++ {
+		c := catalogue[i%len(catalogue)]
+		name := c.adjectives[g.rng.IntN(len(c.adjectives))] + " " + c.nouns[g.rng.IntN(len(c.nouns))]
+		// Names repeat once the combinations run out, so number the copies
+		for base, n := name, 2; names[name]; n++ {
+			name = fmt.Sprintf("%s %d", base, n)
+		}
+		names[name] = true
+
+		g.data.Products = append(g.data.Products, Product{
+			ID:          int32(i + 1),
+			CategoryID:  int32(i%len(catalogue) + 1),
+			Name:        name,
+			Description: fmt.Sprintf("%s from our %s range.", name, strings.ToLower(c.name)),
+			// Prices end in .99 like a real catalogue
+			PriceCents: int64(5+g.rng.IntN(300))*100 - 1,
+			Stock:      g.rng.IntN(500),
+			ImageURL:   c.images[g.rng.IntN(len(c.images))],
+			CreatedAt:  g.start,
+		})
+	}
+}
+
+func (g *generator) users() {
+	codes := map[string]bool{}
+	for i := 0; i < g.opts.Users; i++ {
+		id, _ := uuid.NewRandomFromReader(rngReader{g.rng})
+
+		code := make([]byte, 8)
+		for codes[string(code)] || code[0] == 0 {
+			for j := range code {
+				code[j] = referralCodeAlphabet[g.rng.IntN(len(referralCodeAlphabet))]
+			}
+		}
+		codes[string(code)] = true
+
+		email := fmt.Sprintf("user%04d@example.com", i+1)
+		name := firstNames[g.rng.IntN(len(firstNames))] + " " + lastNames[g.rng.IntN(len(lastNames))]
+		if i == 0 {
+			email, name = "admin@example.com", "Admin"
+		}
+
+		g.data.Users = append(g.data.Users, User{
+			ID:              id,
+			Name:            name,
+			Email:           email,
+			NormalizedEmail: email,
+			ReferralCode:    string(code),
+			IsAdmin:         i == 0,
+			CreatedAt:       g.between(g.start, g.opts.End),
+		})
+	}
+}
+
+// history plays out a user's purchases from sign-up to End, recording every
+// coin movement in order so the balance always matches the ledger
+func (g *generator) history(user *User) {
+	reviewed := map[int32]bool{}
+	at := user.CreatedAt
+
+	for {
+		at = at.Add(time.Duration(1+g.rng.IntN(14*24)) * time.Hour)
+		if at.After(g.opts.End) {
+			break
+		}
+
+		// Lots that ran out since the last order are swept first, so the
+		// balance left is what the order can spend
+		g.expire(user, at)
+		order := g.order(user, at)
+		for user.Coins < order.CoinsUsed {
+			g.buyPack(user, order.CreatedAt.Add(-time.Minute))
+		}
+		g.data.Orders = append(g.data.Orders, order)
+		orderID := order.ID
+
+		g.spend(user, -order.CoinsUsed, "purchase", &orderID, fmt.Sprintf("Purchased order %s", order.Number), at)
+
+		switch order.Status {
+		case "cancelled", "refunded":
+			refundAt := at.Add(time.Duration(1+g.rng.IntN(72)) * time.Hour)
+			g.credit(user, order.CoinsUsed, "refund", &orderID, nil, fmt.Sprintf("Refund for order %s", order.Number), refundAt)
+		case "completed":
+			for _, item := range order.Items {
+				if reviewed[item.ProductID] || g.rng.IntN(2) == 0 {
+					continue
+				}
+				reviewed[item.ProductID] = true
+				g.review(user, item.ProductID, at.Add(time.Duration(1+g.rng.IntN(10*24))*time.Hour))
+			}
+		}
+	}
+	g.expire(user, g.opts.End)
+
+	if g.rng.IntN(10) < 4 {
+		for _, idx := range g.rng.Perm(len(g.data.Products))[:min(1+g.rng.IntN(3), len(g.data.Products))] {
+			g.data.CartItems = append(g.data.CartItems, CartItem{
+				UserID:    user.ID,
+				ProductID: g.data.Products[idx].ID,
+				Quantity:  1 + g.rng.IntN(3),
+			})
+		}
+	}
+}
+
+func (g *generator) order(user *User, at time.Time) Order {
+	id := int32(len(g.data.Orders) + 1)
+	order := Order{
+		ID:        id,
+		UserID:    user.ID,
+		Number:    fmt.Sprintf("ORD-%06d", id),
+		CreatedAt: at,
+	}
+
+	for _, idx := range g.rng.Perm(len(g.data.Products))[:min(1+g.rng.IntN(4), len(g.data.Products))] {
+		product := g.data.Products[idx]
+		item := OrderItem{
+			ProductID:  product.ID,
+			Name:       product.Name,
+			PriceCents: product.PriceCents,
+			Quantity:   1 + g.rng.IntN(2),
+		}
+		order.Items = append(order.Items, item)
+		order.TotalCents += item.PriceCents * int64(item.Quantity)
+	}
+	// A coin is worth a cent, as in the coin packs
+	order.CoinsUsed = int(order.TotalCents)
+
+	switch n := g.rng.IntN(10); {
+	case n < 7:
+		order.Status = "completed"
+	case n < 8:
+		order.Status = "pending"
+	case n < 9:
+		order.Status = "cancelled"
+	default:
+		order.Status = "refunded"
+	}
+	return order
+}
+
+func (g *generator) buyPack(user *User, at time.Time) {
+	pack := g.data.CoinPacks[g.rng.IntN(len(g.data.CoinPacks))]
+	packID := pack.ID
+	g.credit(user, pack.BaseCoins, "charge", nil, &packID, "Coin pack purchase", at)
+	if pack.BonusCoins > 0 {
+		g.credit(user, pack.BonusCoins, "bonus", nil, &packID, "Coin pack bonus", at)
+	}
+}
+
+func (g *generator) credit(user *User, amount int, txType string, orderID, packID *int32, description string, at time.Time) {
+	tx := g.record(user, amount, txType, orderID, packID, description, at)
+
+	g.lots[user.ID] = append(g.lots[user.ID], len(g.data.Lots))
+	g.data.Lots = append(g.data.Lots, Lot{
+		UserID:        user.ID,
+		TransactionID: tx.ID,
+		Source:        txType,
+		Original:      amount,
+		Remaining:     amount,
+		ExpiresAt:     g.opts.Expiry.ExpiresAt(txType, at),
+		CreatedAt:     at,
+	})
+	// A refund can be dated after packs bought for a later order, so the
+	// open lots are kept in the order spends take from them
+	slices.SortStableFunc(g.lots[user.ID], func(a, b int) int {
+		return cmp.Or(g.data.Lots[a].CreatedAt.Compare(g.data.Lots[b].CreatedAt), cmp.Compare(a, b))
+	})
+}
+
+// spend records a debit and takes it from the user's open lots by when they
+// were granted, then by id, as the repository does. Lots past their expiry
+// have been swept by then.
+func (g *generator) spend(user *User, amount int, txType string, orderID *int32, description string, at time.Time) {
+	g.record(user, amount, txType, orderID, nil, description, at)
+
+	remaining := -amount
+	for _, idx := range g.lots[user.ID] {
+		lot := &g.data.Lots[idx]
+		take := min(lot.Remaining, remaining)
+		lot.Remaining -= take
+		remaining -= take
+		if remaining == 0 {
+			break
+		}
+	}
+	g.dropEmptyLots(user)
+}
+
+// expire writes off what is left of the user's lots that expired by now, in
+// the order they expired, as the expiry worker would have
+func (g *generator) expire(user *User, now time.Time) {
+	var expired []int
+	for _, idx := range g.lots[user.ID] {
+		if lot := g.data.Lots[idx]; lot.ExpiresAt != nil && !lot.ExpiresAt.After(now) {
+			expired = append(expired, idx)
+		}
+	}
+	slices.SortStableFunc(expired, func(a, b int) int {
+		return cmp.Or(g.data.Lots[a].ExpiresAt.Compare(*g.data.Lots[b].ExpiresAt), cmp.Compare(a, b))
+	})
+
+	for _, idx := range expired {
+		lot := &g.data.Lots[idx]
+		description := fmt.Sprintf("Expired %s coins (lot #%d)", lot.Source, idx+1)
+		g.record(user, -lot.Remaining, entity.TransactionTypeExpiry, nil, nil, description, *lot.ExpiresAt)
+		lot.Remaining = 0
+	}
+	g.dropEmptyLots(user)
+}
+
+func (g *generator) dropEmptyLots(user *User) {
+	g.lots[user.ID] = slices.DeleteFunc(g.lots[user.ID], func(idx int) bool {
+		return g.data.Lots[idx].Remaining == 0
+	})
+}
+
+func (g *generator) record(user *User, amount int, txType string, orderID, packID *int32, description string, at time.Time) Transaction {
+	user.Coins += amount
+	tx := Transaction{
+		ID:           int32(len(g.data.Transactions) + 1),
+		UserID:       user.ID,
+		Type:         txType,
+		Amount:       amount,
+		BalanceAfter: user.Coins,
+		OrderID:      orderID,
+		CoinPackID:   packID,
+		Description:  description,
+		CreatedAt:    at,
+	}
+	g.data.Transactions = append(g.data.Transactions, tx)
+	return tx
+}
+
+func (g *generator) review(user *User, productID int32, at time.Time) {
+	if at.After(g.opts.End) {
+		at = g.opts.End
+	}
+
+	rating, n := 0, g.rng.IntN(100)
+	for rating = 0; n >= ratingWeights[rating]; rating++ {
+		n -= ratingWeights[rating]
+	}
+	texts := reviews[rating]
+
+	g.data.Comments = append(g.data.Comments, Comment{
+		UserID:    user.ID,
+		ProductID: productID,
+		Rating:    rating + 1,
+		Text:      texts[g.rng.IntN(len(texts))],
+		CreatedAt: at,
+	})
+}
+
+// ratings sets each product's rating the way the comments trigger would
+func (g *generator) ratings() {
+	sums := make([]int, len(g.data.Products))
+	counts := make([]int, len(g.data.Products))
+	for _, c := range g.data.Comments {
+		sums[c.ProductID-1] += c.Rating
+		counts[c.ProductID-1]++
+	}
+
+	for i := range g.data.Products {
+		g.data.Products[i].TotalComments = counts[i]
+		if counts[i] > 0 {
+			g.data.Products[i].AverageRating = roundRating(sums[i], counts[i])
+		}
+	}
+}
+
+// roundRating matches ROUND(AVG(rating), 2), which rounds halves away from zero
+func roundRating(sum, count int) float64 {
+	return float64((sum*200+count)/(count*2)) / 100
+}
+
+// rngReader lets uuid draw its bytes from the seeded generator
+type rngReader struct {
+	rng *rand.Rand
+}
+
+func (r rngReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(r.rng.Uint32())
+	}
+	return len(p), nil
+}
@@ -0,0 +1,200 @@
+package seed
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+	"time"
+
+	"backend/internal/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testOptions = Options{
+	Seed:     42,
+	Users:    50,
+	Products: 60,
+	End:      time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+	Days:     180,
+	// Shorter than the history, so some bonus lots expire
+	Expiry: entity.CoinExpiryPolicy{ChargeMonths: 1, BonusMonths: 1},
+}
+
+func TestGenerate_IsDeterministic(t *testing.T) {
+	first, err := Generate(testOptions)
+	require.NoError(t, err)
+	second, err := Generate(testOptions)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	opts := testOptions
+	opts.Seed++
+	other, err := Generate(opts)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Users[0].ID, other.Users[0].ID)
+}
+
+func TestGenerate_BalancesMatchLedger(t *testing.T) {
+	data, err := Generate(testOptions)
+	require.NoError(t, err)
+	require.NotEmpty(t, data.Transactions)
+
+	sums := map[uuid.UUID]int{}
+	last := map[uuid.UUID]int{}
+	for _, tx := range data.Transactions {
+		sums[tx.UserID] += tx.Amount
+		assert.Equal(t, sums[tx.UserID], tx.BalanceAfter, "transaction %d", tx.ID)
+		assert.GreaterOrEqual(t, tx.BalanceAfter, 0)
+		last[tx.UserID] = tx.BalanceAfter
+	}
+
+	remaining := map[uuid.UUID]int{}
+	for _, lot := range data.Lots {
+		assert.LessOrEqual(t, lot.Remaining, lot.Original)
+		remaining[lot.UserID] += lot.Remaining
+	}
+
+	for _, u := range data.Users {
+		assert.Equal(t, last[u.ID], u.Coins, "user %s", u.Email)
+		assert.Equal(t, u.Coins, remaining[u.ID], "lots of user %s", u.Email)
+	}
+}
+
+func TestGenerate_OrdersArePaidInCoins(t *testing.T) {
+	data, err := Generate(testOptions)
+	require.NoError(t, err)
+	require.NotEmpty(t, data.Orders)
+
+	paid := map[int32]int{}
+	for _, tx := range data.Transactions {
+		if tx.OrderID != nil {
+			paid[*tx.OrderID] += tx.Amount
+		}
+	}
+
+	for _, o := range data.Orders {
+		var total int64
+		for _, item := range o.Items {
+			total += item.PriceCents * int64(item.Quantity)
+		}
+		assert.Equal(t, total, o.TotalCents)
+		assert.Equal(t, int(total), o.CoinsUsed)
+
+		switch o.Status {
+		case "cancelled", "refunded":
+			assert.Zero(t, paid[o.ID], "order %s", o.Number)
+		default:
+			assert.Equal(t, -o.CoinsUsed, paid[o.ID], "order %s", o.Number)
+		}
+	}
+}
+
+func TestGenerate_LotsExpireByPolicy(t *testing.T) {
+	data, err := Generate(testOptions)
+	require.NoError(t, err)
+
+	expiredCoins := 0
+	for _, tx := range data.Transactions {
+		if tx.Type == entity.TransactionTypeExpiry {
+			assert.Negative(t, tx.Amount)
+			expiredCoins -= tx.Amount
+		}
+	}
+	require.Positive(t, expiredCoins)
+
+	writtenOff := 0
+	for i, lot := range data.Lots {
+		assert.Equal(t, testOptions.Expiry.ExpiresAt(lot.Source, lot.CreatedAt), lot.ExpiresAt, "lot %d", i+1)
+		if lot.ExpiresAt != nil && !lot.ExpiresAt.After(testOptions.End) {
+			assert.Zero(t, lot.Remaining, "lot %d", i+1)
+		}
+		writtenOff += lot.Original - lot.Remaining
+	}
+	assert.LessOrEqual(t, expiredCoins, writtenOff)
+}
+
+func TestGenerate_SpendsTakeOldestLotsFirst(t *testing.T) {
+	data, err := Generate(testOptions)
+	require.NoError(t, err)
+
+	open := map[uuid.UUID][]int{}
+	for i, lot := range data.Lots {
+		if lot.Remaining > 0 {
+			open[lot.UserID] = append(open[lot.UserID], i)
+		}
+	}
+
+	// Only the oldest open lot of a user can have been partly spent
+	for userID, lots := range open {
+		slices.SortFunc(lots, func(a, b int) int {
+			return cmp.Or(data.Lots[a].CreatedAt.Compare(data.Lots[b].CreatedAt), cmp.Compare(a, b))
+		})
+		for _, idx := range lots[1:] {
+			assert.Equal(t, data.Lots[idx].Original, data.Lots[idx].Remaining, "lot %d of user %s", idx+1, userID)
+		}
+	}
+}
+
+func TestGenerate_RatingsMatchComments(t *testing.T) {
+	data, err := Generate(testOptions)
+	require.NoError(t, err)
+	require.NotEmpty(t, data.Comments)
+
+	sums := map[int32]int{}
+	counts := map[int32]int{}
+	seen := map[CartItem]bool{}
+	for _, c := range data.Comments {
+		key := CartItem{UserID: c.UserID, ProductID: c.ProductID}
+		assert.False(t, seen[key], "one review per user and product")
+		seen[key] = true
+		assert.True(t, c.Rating >= 1 && c.Rating <= 5)
+		sums[c.ProductID] += c.Rating
+		counts[c.ProductID]++
+	}
+
+	for _, p := range data.Products {
+		assert.Equal(t, counts[p.ID], p.TotalComments, "product %d", p.ID)
+		if counts[p.ID] > 0 {
+			assert.InDelta(t, float64(sums[p.ID])/float64(counts[p.ID]), p.AverageRating, 0.005, "product %d", p.ID)
+		}
+	}
+}
+
+func TestGenerate_UniqueKeys(t *testing.T) {
+	data, err := Generate(testOptions)
+	require.NoError(t, err)
+
+	emails := map[string]bool{}
+	codes := map[string]bool{}
+	for _, u := range data.Users {
+		assert.False(t, emails[u.Email], u.Email)
+		assert.False(t, codes[u.ReferralCode], u.ReferralCode)
+		assert.Len(t, u.ReferralCode, 8)
+		emails[u.Email] = true
+		codes[u.ReferralCode] = true
+	}
+	assert.True(t, data.Users[0].IsAdmin)
+
+	names := map[string]bool{}
+	for _, p := range data.Products {
+		assert.False(t, names[p.Name], p.Name)
+		names[p.Name] = true
+	}
+
+	cart := map[CartItem]bool{}
+	for _, c := range data.CartItems {
+		key := CartItem{UserID: c.UserID, ProductID: c.ProductID}
+		assert.False(t, cart[key], "one cart row per user and product")
+		cart[key] = true
+	}
+}
+
+func TestRoundRating(t *testing.T) {
+	assert.Equal(t, 4.33, roundRating(13, 3))
+	assert.Equal(t, 4.67, roundRating(14, 3))
+	assert.Equal(t, 3.5, roundRating(7, 2))
+	assert.Equal(t, 5.0, roundRating(5, 1))
+}
@@ -0,0 +1,138 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrNotEmpty is returned by Load when the database already has users,
+// products or coin packs, since seeded ids would collide with them
+var ErrNotEmpty = errors.New("database already has users, products or coin packs")
+
+// Load writes the dataset in a single transaction and moves the sequences
+// past the ids it used
+func Load(ctx context.Context, pool *pgxpool.Pool, data *Dataset) error {
+	return pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		var exists bool
+		if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM users) OR EXISTS (SELECT 1 FROM products) OR EXISTS (SELECT 1 FROM coin_packs)`).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return ErrNotEmpty
+		}
+
+		steps := []struct {
+			table string
+			queue func(*pgx.Batch)
+		}{
+			{"coin_packs", data.queueCoinPacks},
+			{"categories", data.queueCategories},
+			{"products", data.queueProducts},
+			{"users", data.queueUsers},
+			{"cart_items", data.queueCartItems},
+			{"orders", data.queueOrders},
+			{"coin_transactions", data.queueTransactions},
+			{"coin_lots", data.queueLots},
+			// Comments go last: their trigger recomputes the product ratings
+			{"comments", data.queueComments},
+		}
+		for _, step := range steps {
+			batch := &pgx.Batch{}
+			step.queue(batch)
+			if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+				return fmt.Errorf("failed to insert %s: %w", step.table, err)
+			}
+		}
+
+		for _, table := range []string{"coin_packs", "categories", "products", "orders", "order_items", "coin_transactions", "coin_lots", "cart_items", "comments"} {
+			// setval(..., false) for empty tables, so the next id is still 1
+			_, err := tx.Exec(ctx, fmt.Sprintf(
+				`SELECT setval(pg_get_serial_sequence('%[1]s', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM %[1]s`, table))
+			if err != nil {
+				return fmt.Errorf("failed to reset the %s sequence: %w", table, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (d *Dataset) queueCoinPacks(b *pgx.Batch) {
+	for _, p := range d.CoinPacks {
+		b.Queue(`INSERT INTO coin_packs (id, name, base_coins, bonus_coins, price, sort_order) VALUES ($1, $2, $3, $4, $5, $6)`,
+			p.ID, p.Name, p.BaseCoins, p.BonusCoins, cents(p.PriceCents), p.SortOrder)
+	}
+}
+
+func (d *Dataset) queueCategories(b *pgx.Batch) {
+	for _, c := range d.Categories {
+		b.Queue(`INSERT INTO categories (id, name) VALUES ($1, $2)`, c.ID, c.Name)
+	}
+}
+
+func (d *Dataset) queueProducts(b *pgx.Batch) {
+	for _, p := range d.Products {
+		b.Queue(`INSERT INTO products (id, category_id, name, description, price, stock_quantity, image_url, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)`,
+			p.ID, p.CategoryID, p.Name, p.Description, cents(p.PriceCents), p.Stock, p.ImageURL, p.CreatedAt)
+	}
+}
+
+func (d *Dataset) queueUsers(b *pgx.Batch) {
+	for _, u := range d.Users {
+		b.Queue(`INSERT INTO users (id, name, email, normalized_email, password_hash, coins, is_admin, referral_code, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)`,
+			u.ID, u.Name, u.Email, u.NormalizedEmail, PasswordHash, u.Coins, u.IsAdmin, u.ReferralCode, u.CreatedAt)
+	}
+}
+
+func (d *Dataset) queueCartItems(b *pgx.Batch) {
+	for _, c := range d.CartItems {
+		b.Queue(`INSERT INTO cart_items (user_id, product_id, quantity) VALUES ($1, $2, $3)`, c.UserID, c.ProductID, c.Quantity)
+	}
+}
+
+func (d *Dataset) queueOrders(b *pgx.Batch) {
+	for _, o := range d.Orders {
+		b.Queue(`INSERT INTO orders (id, user_id, order_number, total_amount, total_coins_used, status, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6::order_status, $7, $7)`,
+			o.ID, o.UserID, o.Number, cents(o.TotalCents), o.CoinsUsed, o.Status, o.CreatedAt)
+		for _, item := range o.Items {
+			b.Queue(`INSERT INTO order_items (order_id, product_id, product_name, product_price, quantity, subtotal)
+				VALUES ($1, $2, $3, $4, $5, $6)`,
+				o.ID, item.ProductID, item.Name, cents(item.PriceCents), item.Quantity, cents(item.PriceCents*int64(item.Quantity)))
+		}
+	}
+}
+
+func (d *Dataset) queueTransactions(b *pgx.Batch) {
+	for _, t := range d.Transactions {
+		b.Queue(`INSERT INTO coin_transactions (id, user_id, transaction_type, amount, balance_after, order_id, coin_pack_id, description, created_at)
+			VALUES ($1, $2, $3::transaction_type, $4, $5, $6, $7, $8, $9)`,
+			t.ID, t.UserID, t.Type, t.Amount, t.BalanceAfter, t.OrderID, t.CoinPackID, t.Description, t.CreatedAt)
+	}
+}
+
+func (d *Dataset) queueLots(b *pgx.Batch) {
+	for i, l := range d.Lots {
+		b.Queue(`INSERT INTO coin_lots (id, user_id, coin_transaction_id, source, original_amount, remaining_amount, expires_at, created_at)
+			VALUES ($1, $2, $3, $4::transaction_type, $5, $6, $7, $8)`,
+			int32(i+1), l.UserID, l.TransactionID, l.Source, l.Original, l.Remaining, l.ExpiresAt, l.CreatedAt)
+	}
+}
+
+func (d *Dataset) queueComments(b *pgx.Batch) {
+	for _, c := range d.Comments {
+		b.Queue(`INSERT INTO comments (user_id, product_id, rating, comment, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $5)`,
+			c.UserID, c.ProductID, c.Rating, c.Text, c.CreatedAt)
+	}
+}
+
+func cents(c int64) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(c), Exp: -2, Valid: true}
+}
@@ -0,0 +1,235 @@
+package seed
+
+import (
+	"backend/internal/database"
+	"backend/internal/database/memdb"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// LoadMemory writes the dataset to an in-memory database the way Load writes
+// it to Postgres: in a single transaction, and only into an empty database
+func LoadMemory(ctx context.Context, db *memdb.Queries, data *Dataset) error {
+	return pgx.BeginTxFunc(ctx, db, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		q := db.WithTx(tx)
+
+		exists, err := q.HasSeedableRows(ctx)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return ErrNotEmpty
+		}
+
+		steps := []struct {
+			table  string
+			insert func(context.Context, *memdb.Queries) error
+		}{
+			{"coin_packs", data.insertCoinPacks},
+			{"categories", data.insertCategories},
+			{"products", data.insertProducts},
+			{"users", data.insertUsers},
+			{"cart_items", data.insertCartItems},
+			{"orders", data.insertOrders},
+			{"coin_transactions", data.insertTransactions},
+			{"coin_lots", data.insertLots},
+			// Comments go last, as in Load, so the ratings come out the same
+			{"comments", data.insertComments},
+		}
+		for _, step := range steps {
+			if err := step.insert(ctx, q); err != nil {
+				return fmt.Errorf("failed to insert %s: %w", step.table, err)
+			}
+		}
+		return nil
+	})
+}
+
+func (d *Dataset) insertCoinPacks(ctx context.Context, q *memdb.Queries) error {
+	for _, p := range d.CoinPacks {
+		_, err := q.InsertCoinPack(ctx, database.CoinPack{
+			ID:         p.ID,
+			Name:       p.Name,
+			BaseCoins:  int32(p.BaseCoins),
+			BonusCoins: int32(p.BonusCoins),
+			Price:      cents(p.PriceCents),
+			IsActive:   true,
+			SortOrder:  int32(p.SortOrder),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Dataset) insertCategories(ctx context.Context, q *memdb.Queries) error {
+	for _, c := range d.Categories {
+		if _, err := q.InsertCategory(ctx, database.Category{ID: c.ID, Name: c.Name}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Dataset) insertProducts(ctx context.Context, q *memdb.Queries) error {
+	for _, p := range d.Products {
+		_, err := q.InsertProduct(ctx, database.Product{
+			ID:            p.ID,
+			CategoryID:    p.CategoryID,
+			Name:          p.Name,
+			Description:   pgtype.Text{String: p.Description, Valid: true},
+			Price:         cents(p.PriceCents),
+			StockQuantity: int32(p.Stock),
+			ImageUrl:      pgtype.Text{String: p.ImageURL, Valid: true},
+			CreatedAt:     timestamptz(p.CreatedAt),
+			UpdatedAt:     timestamptz(p.CreatedAt),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Dataset) insertUsers(ctx context.Context, q *memdb.Queries) error {
+	for _, u := range d.Users {
+		_, err := q.InsertUser(ctx, database.User{
+			ID:              pgtype.UUID{Bytes: u.ID, Valid: true},
+			Name:            u.Name,
+			Email:           u.Email,
+			NormalizedEmail: u.NormalizedEmail,
+			PasswordHash:    PasswordHash,
+			Coins:           pgtype.Int4{Int32: int32(u.Coins), Valid: true},
+			IsAdmin:         u.IsAdmin,
+			ReferralCode:    u.ReferralCode,
+			CreatedAt:       timestamptz(u.CreatedAt),
+			UpdatedAt:       timestamptz(u.CreatedAt),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Dataset) insertCartItems(ctx context.Context, q *memdb.Queries) error {
+	for _, c := range d.CartItems {
+		_, err := q.InsertCartItem(ctx, database.CartItem{
+			UserID:    pgtype.UUID{Bytes: c.UserID, Valid: true},
+			ProductID: c.ProductID,
+			Quantity:  int32(c.Quantity),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Dataset) insertOrders(ctx context.Context, q *memdb.Queries) error {
+	for _, o := range d.Orders {
+		_, err := q.InsertOrder(ctx, database.Order{
+			ID:             o.ID,
+			UserID:         pgtype.UUID{Bytes: o.UserID, Valid: true},
+			OrderNumber:    o.Number,
+			TotalAmount:    cents(o.TotalCents),
+			TotalCoinsUsed: int32(o.CoinsUsed),
+			Status:         database.NullOrderStatus{OrderStatus: database.OrderStatus(o.Status), Valid: true},
+			CreatedAt:      timestamptz(o.CreatedAt),
+			UpdatedAt:      timestamptz(o.CreatedAt),
+		})
+		if err != nil {
+			return err
+		}
+		for _, item := range o.Items {
+			_, err := q.InsertOrderItem(ctx, database.OrderItem{
+				OrderID:      o.ID,
+				ProductID:    item.ProductID,
+				ProductName:  item.Name,
+				ProductPrice: cents(item.PriceCents),
+				Quantity:     int32(item.Quantity),
+				Subtotal:     cents(item.PriceCents * int64(item.Quantity)),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Dataset) insertTransactions(ctx context.Context, q *memdb.Queries) error {
+	for _, t := range d.Transactions {
+		_, err := q.InsertCoinTransaction(ctx, database.CoinTransaction{
+			ID:              t.ID,
+			UserID:          pgtype.UUID{Bytes: t.UserID, Valid: true},
+			TransactionType: database.TransactionType(t.Type),
+			Amount:          int32(t.Amount),
+			BalanceAfter:    int32(t.BalanceAfter),
+			OrderID:         int4(t.OrderID),
+			CoinPackID:      int4(t.CoinPackID),
+			Description:     pgtype.Text{String: t.Description, Valid: true},
+			CreatedAt:       timestamptz(t.CreatedAt),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Dataset) insertLots(ctx context.Context, q *memdb.Queries) error {
+	for i, l := range d.Lots {
+		var expiresAt pgtype.Timestamptz
+		if l.ExpiresAt != nil {
+			expiresAt = timestamptz(*l.ExpiresAt)
+		}
+		_, err := q.InsertCoinLot(ctx, database.CoinLot{
+			ID:                int32(i + 1),
+			UserID:            pgtype.UUID{Bytes: l.UserID, Valid: true},
+			CoinTransactionID: pgtype.Int4{Int32: l.TransactionID, Valid: true},
+			Source:            database.TransactionType(l.Source),
+			OriginalAmount:    int32(l.Original),
+			RemainingAmount:   int32(l.Remaining),
+			ExpiresAt:         expiresAt,
+			CreatedAt:         timestamptz(l.CreatedAt),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Dataset) insertComments(ctx context.Context, q *memdb.Queries) error {
+	for _, c := range d.Comments {
+		_, err := q.InsertComment(ctx, database.Comment{
+			UserID:    pgtype.UUID{Bytes: c.UserID, Valid: true},
+			ProductID: c.ProductID,
+			Rating:    pgtype.Int4{Int32: int32(c.Rating), Valid: true},
+			Comment:   c.Text,
+			CreatedAt: timestamptz(c.CreatedAt),
+			UpdatedAt: timestamptz(c.CreatedAt),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func timestamptz(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t, Valid: true}
+}
+
+func int4(v *int32) pgtype.Int4 {
+	if v == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: *v, Valid: true}
+}